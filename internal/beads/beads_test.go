@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -660,7 +661,7 @@ func TestMRFieldsRoundTrip(t *testing.T) {
 		t.Fatal("round-trip parse returned nil")
 	}
 
-	if *parsed != *original {
+	if !reflect.DeepEqual(parsed, original) {
 		t.Errorf("round-trip mismatch:\ngot  %+v\nwant %+v", parsed, original)
 	}
 }
@@ -727,6 +728,37 @@ Also see http://localhost:8080/api`,
 	}
 }
 
+func TestMRFieldsRepoBranchesRoundTrip(t *testing.T) {
+	fields := &MRFields{
+		Branch: "polecat/nux/gt-abc",
+		Target: "main",
+		RepoBranches: []RepoBranch{
+			{Repo: "web", Branch: "polecat/nux/gt-abc"},
+			{Repo: "proto", Branch: "polecat/nux/gt-abc"},
+		},
+	}
+	desc := FormatMRFields(fields)
+	if !strings.Contains(desc, "repo_branches: web=polecat/nux/gt-abc,proto=polecat/nux/gt-abc") {
+		t.Fatalf("FormatMRFields() = %q", desc)
+	}
+
+	parsed := ParseMRFields(&Issue{Description: desc})
+	if parsed == nil || len(parsed.RepoBranches) != 2 || parsed.RepoBranches[1].Repo != "proto" {
+		t.Fatalf("ParseMRFields() RepoBranches = %+v", parsed)
+	}
+
+	// SetMRFields replaces the line rather than duplicating it.
+	parsed.RepoBranches = parsed.RepoBranches[:1]
+	updated := SetMRFields(&Issue{Description: desc}, parsed)
+	if strings.Count(updated, "repo_branches:") != 1 || strings.Contains(updated, "proto=") {
+		t.Errorf("SetMRFields() = %q", updated)
+	}
+
+	if got := ParseRepoBranches("web=a, bad, =b,proto=c"); len(got) != 2 {
+		t.Errorf("ParseRepoBranches should skip malformed entries, got %+v", got)
+	}
+}
+
 // TestParseAttachmentFields tests parsing attachment fields from issue descriptions.
 func TestParseAttachmentFields(t *testing.T) {
 	tests := []struct {
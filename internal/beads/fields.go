@@ -400,6 +400,40 @@ type MRFields struct {
 	// Convoy tracking (for priority scoring - convoy starvation prevention)
 	ConvoyID        string // Parent convoy ID if part of a convoy
 	ConvoyCreatedAt string // Convoy creation time (ISO 8601) for starvation prevention
+
+	// RepoBranches lists the member-repo branches of a multi-repo rig that
+	// must land together with Branch (empty for single-repo MRs).
+	RepoBranches []RepoBranch
+}
+
+// RepoBranch is a branch in one member repository of a multi-repo rig.
+type RepoBranch struct {
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+}
+
+// ParseRepoBranches parses the "repo=branch,repo=branch" form of the
+// repo_branches MR field. Malformed entries are skipped.
+func ParseRepoBranches(value string) []RepoBranch {
+	var out []RepoBranch
+	for _, entry := range strings.Split(value, ",") {
+		repo, branch, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		repo, branch = strings.TrimSpace(repo), strings.TrimSpace(branch)
+		if !ok || repo == "" || branch == "" {
+			continue
+		}
+		out = append(out, RepoBranch{Repo: repo, Branch: branch})
+	}
+	return out
+}
+
+// FormatRepoBranches formats repo branches for the repo_branches MR field.
+func FormatRepoBranches(branches []RepoBranch) string {
+	parts := make([]string, 0, len(branches))
+	for _, rb := range branches {
+		parts = append(parts, rb.Repo+"="+rb.Branch)
+	}
+	return strings.Join(parts, ",")
 }
 
 // ParseMRFields extracts structured merge-request fields from an issue's description.
@@ -474,6 +508,9 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "convoy_created_at", "convoy-created-at", "convoycreatedat":
 			fields.ConvoyCreatedAt = value
 			hasFields = true
+		case "repo_branches", "repo-branches", "repobranches":
+			fields.RepoBranches = ParseRepoBranches(value)
+			hasFields = true
 		}
 	}
 
@@ -538,6 +575,9 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.ConvoyCreatedAt != "" {
 		lines = append(lines, "convoy_created_at: "+fields.ConvoyCreatedAt)
 	}
+	if len(fields.RepoBranches) > 0 {
+		lines = append(lines, "repo_branches: "+FormatRepoBranches(fields.RepoBranches))
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -584,6 +624,9 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"convoy_created_at":  true,
 		"convoy-created-at":  true,
 		"convoycreatedat":    true,
+		"repo_branches":      true,
+		"repo-branches":      true,
+		"repobranches":       true,
 	}
 
 	// Collect non-MR lines from existing description
@@ -38,6 +38,10 @@ type Checkpoint struct {
 	// Branch is the current git branch.
 	Branch string `json:"branch,omitempty"`
 
+	// Snapshot is the ref preserving the uncommitted changes themselves
+	// (see TakeSnapshot), so they survive the worktree being nuked.
+	Snapshot string `json:"snapshot,omitempty"`
+
 	// HookedBead is the bead ID on the agent's hook.
 	HookedBead string `json:"hooked_bead,omitempty"`
 
@@ -115,7 +119,9 @@ func Remove(polecatDir string) error {
 }
 
 // Capture creates a checkpoint by capturing current git and work state.
-func Capture(polecatDir string) (*Checkpoint, error) {
+// If agent is set and the worktree has uncommitted changes, their content is
+// also preserved as a snapshot ref for that agent.
+func Capture(polecatDir, agent string) (*Checkpoint, error) {
 	cp := &Checkpoint{
 		Timestamp: time.Now(),
 	}
@@ -153,6 +159,17 @@ func Capture(polecatDir string) (*Checkpoint, error) {
 		cp.Branch = strings.TrimSpace(string(output))
 	}
 
+	// Preserve the uncommitted content, not just the file names
+	if agent != "" && len(cp.ModifiedFiles) > 0 {
+		snap, err := TakeSnapshot(polecatDir, agent, "checkpoint")
+		if err != nil {
+			return nil, fmt.Errorf("snapshotting uncommitted work: %w", err)
+		}
+		if snap != nil {
+			cp.Snapshot = snap.Ref
+		}
+	}
+
 	return cp, nil
 }
 
@@ -210,6 +227,10 @@ func (cp *Checkpoint) Summary() string {
 		parts = append(parts, fmt.Sprintf("branch: %s", cp.Branch))
 	}
 
+	if cp.Snapshot != "" {
+		parts = append(parts, "snapshot saved")
+	}
+
 	if len(parts) == 0 {
 		return "no significant state"
 	}
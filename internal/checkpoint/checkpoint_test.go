@@ -185,7 +185,7 @@ func TestCapture(t *testing.T) {
 		gitRoot = parent
 	}
 
-	cp, err := Capture(gitRoot)
+	cp, err := Capture(gitRoot, "")
 	if err != nil {
 		t.Fatalf("Capture: %v", err)
 	}
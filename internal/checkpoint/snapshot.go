@@ -0,0 +1,306 @@
+package checkpoint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RefPrefix is the ref namespace for working-tree snapshots. Refs are shared
+// by every worktree of a repository, so snapshots outlive the polecat
+// worktree they were taken from.
+const RefPrefix = "refs/gastown/checkpoints/"
+
+// refTimeLayout is the timestamp component of a snapshot ref.
+const refTimeLayout = "20060102T150405Z"
+
+// Snapshot is a stash-style commit preserving a worktree's uncommitted state:
+// its tree is the working tree (including untracked, non-ignored files), its
+// first parent is the HEAD it was taken on, and its second parent records the
+// index. Taking one changes no branch, index, or file.
+type Snapshot struct {
+	Ref       string    `json:"ref"`
+	Commit    string    `json:"commit"`
+	Polecat   string    `json:"polecat"`
+	Base      string    `json:"base,omitempty"`
+	Branch    string    `json:"branch,omitempty"` // Branch checked out when taken; empty if detached
+	Reason    string    `json:"reason,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Name returns the snapshot's ref relative to RefPrefix (<polecat>/<ts>).
+func (s *Snapshot) Name() string {
+	return strings.TrimPrefix(s.Ref, RefPrefix)
+}
+
+// Age returns how long ago the snapshot was taken.
+func (s *Snapshot) Age() time.Duration {
+	return time.Since(s.Timestamp)
+}
+
+// SnapshotRef returns the ref for a polecat's snapshot taken at ts.
+func SnapshotRef(polecat string, ts time.Time) string {
+	return RefPrefix + polecat + "/" + ts.UTC().Format(refTimeLayout)
+}
+
+// TakeSnapshot snapshots the uncommitted state of the worktree at workDir
+// under refs/gastown/checkpoints/<polecat>/<ts>. reason is recorded in the
+// commit message. Returns nil, nil if there is nothing uncommitted to keep.
+func TakeSnapshot(workDir, polecat, reason string) (*Snapshot, error) {
+	if polecat == "" || strings.ContainsAny(polecat, "/ ") {
+		return nil, fmt.Errorf("invalid polecat name %q", polecat)
+	}
+
+	base, _ := gitOutput(workDir, nil, "rev-parse", "--verify", "-q", "HEAD")
+	branch, _ := gitOutput(workDir, nil, "symbolic-ref", "--short", "-q", "HEAD")
+	indexTree, err := gitOutput(workDir, nil, "write-tree")
+	if err != nil {
+		return nil, fmt.Errorf("writing index tree: %w", err)
+	}
+
+	// Stage everything into a throwaway copy of the index so the real one is
+	// left untouched.
+	indexPath, err := gitOutput(workDir, nil, "rev-parse", "--git-path", "index")
+	if err != nil {
+		return nil, fmt.Errorf("locating index: %w", err)
+	}
+	if !filepath.IsAbs(indexPath) {
+		indexPath = filepath.Join(workDir, indexPath)
+	}
+	tmpIndex, err := os.CreateTemp("", "gt-checkpoint-index-*")
+	if err != nil {
+		return nil, err
+	}
+	tmpIndexPath := tmpIndex.Name()
+	_ = tmpIndex.Close()
+	defer os.Remove(tmpIndexPath)
+	if data, err := os.ReadFile(indexPath); err == nil {
+		if err := os.WriteFile(tmpIndexPath, data, 0600); err != nil {
+			return nil, err
+		}
+	} else {
+		_ = os.Remove(tmpIndexPath)
+	}
+	env := []string{"GIT_INDEX_FILE=" + tmpIndexPath}
+	if _, err := gitOutput(workDir, env, "add", "-A"); err != nil {
+		return nil, fmt.Errorf("staging working tree: %w", err)
+	}
+	workTree, err := gitOutput(workDir, env, "write-tree")
+	if err != nil {
+		return nil, fmt.Errorf("writing working tree: %w", err)
+	}
+
+	if base != "" {
+		headTree, _ := gitOutput(workDir, nil, "rev-parse", base+"^{tree}")
+		if headTree == indexTree && headTree == workTree {
+			return nil, nil
+		}
+	}
+
+	now := time.Now()
+	var parents []string
+	if base != "" {
+		parents = append(parents, "-p", base)
+	}
+	indexCommit, err := gitOutput(workDir, commitEnv(), append([]string{"commit-tree", indexTree, "-m", "index on checkpoint"}, parents...)...)
+	if err != nil {
+		return nil, fmt.Errorf("committing index: %w", err)
+	}
+
+	msg := fmt.Sprintf("gastown checkpoint: %s", polecat)
+	if branch != "" {
+		msg += " on " + branch
+	}
+	if reason != "" {
+		msg += " (" + reason + ")"
+	}
+	commit, err := gitOutput(workDir, commitEnv(), append([]string{"commit-tree", workTree, "-m", msg}, append(parents, "-p", indexCommit)...)...)
+	if err != nil {
+		return nil, fmt.Errorf("committing working tree: %w", err)
+	}
+
+	ref := SnapshotRef(polecat, now)
+	if _, err := gitOutput(workDir, nil, "update-ref", ref, commit); err != nil {
+		return nil, fmt.Errorf("creating %s: %w", ref, err)
+	}
+
+	return &Snapshot{Ref: ref, Commit: commit, Polecat: polecat, Base: base, Branch: branch, Reason: reason, Timestamp: now}, nil
+}
+
+// ListSnapshots returns snapshots in the repository containing dir (a
+// worktree or bare repo), newest first. An empty polecat lists all.
+func ListSnapshots(dir, polecat string) ([]Snapshot, error) {
+	pattern := strings.TrimSuffix(RefPrefix, "/")
+	if polecat != "" {
+		pattern = RefPrefix + polecat
+	}
+	out, err := gitOutput(dir, nil, "for-each-ref",
+		"--format=%(refname)%09%(objectname)%09%(subject)", pattern)
+	if err != nil {
+		return nil, fmt.Errorf("listing snapshots: %w", err)
+	}
+
+	var snaps []Snapshot
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 2 {
+			continue
+		}
+		rest := strings.TrimPrefix(fields[0], RefPrefix)
+		name, stamp, ok := strings.Cut(rest, "/")
+		if !ok {
+			continue
+		}
+		ts, err := time.Parse(refTimeLayout, stamp)
+		if err != nil {
+			continue
+		}
+		s := Snapshot{Ref: fields[0], Commit: fields[1], Polecat: name, Timestamp: ts}
+		if len(fields) == 3 {
+			head, reason, ok := strings.Cut(fields[2], " (")
+			if ok {
+				s.Reason = strings.TrimSuffix(reason, ")")
+			}
+			if _, branch, ok := strings.Cut(head, " on "); ok {
+				s.Branch = branch
+			}
+		}
+		// Snapshots taken on an unborn branch have only the index parent.
+		if _, err := gitOutput(dir, nil, "rev-parse", "-q", "--verify", s.Commit+"^2"); err == nil {
+			s.Base, _ = gitOutput(dir, nil, "rev-parse", s.Commit+"^1")
+		}
+		snaps = append(snaps, s)
+	}
+
+	sort.Slice(snaps, func(i, j int) bool {
+		return snaps[i].Timestamp.After(snaps[j].Timestamp)
+	})
+	return snaps, nil
+}
+
+// FindSnapshot resolves name to a snapshot: a full ref, "<polecat>/<ts>", or
+// "latest" for the polecat's newest snapshot.
+func FindSnapshot(dir, polecat, name string) (*Snapshot, error) {
+	snaps, err := ListSnapshots(dir, polecat)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" || name == "latest" {
+		if len(snaps) == 0 {
+			return nil, fmt.Errorf("no checkpoint snapshots for %q", polecat)
+		}
+		return &snaps[0], nil
+	}
+	for i := range snaps {
+		if snaps[i].Ref == name || snaps[i].Name() == name {
+			return &snaps[i], nil
+		}
+	}
+	return nil, fmt.Errorf("checkpoint snapshot %q not found", name)
+}
+
+// RestoreSnapshot applies a snapshot's changes onto the worktree at workDir,
+// like `git stash apply`. If the worktree has moved to a different base, the
+// changes are merged and conflicts are left for the caller to resolve.
+func RestoreSnapshot(workDir string, s *Snapshot) error {
+	if s.Base == "" {
+		// Taken on an unborn branch: nothing to diff against, so check
+		// the files out directly.
+		if _, err := gitOutput(workDir, nil, "checkout", s.Commit, "--", "."); err != nil {
+			return fmt.Errorf("restoring %s: %w", s.Name(), err)
+		}
+		return nil
+	}
+	if _, err := gitOutput(workDir, nil, "stash", "apply", s.Commit); err != nil {
+		return fmt.Errorf("restoring %s: %w", s.Name(), err)
+	}
+	return nil
+}
+
+// DeleteSnapshots removes all of a polecat's snapshots and returns how many
+// were removed.
+func DeleteSnapshots(dir, polecat string) (int, error) {
+	if polecat == "" {
+		return 0, fmt.Errorf("polecat name required")
+	}
+	snaps, err := ListSnapshots(dir, polecat)
+	if err != nil {
+		return 0, err
+	}
+	for i, s := range snaps {
+		if _, err := gitOutput(dir, nil, "update-ref", "-d", s.Ref); err != nil {
+			return i, fmt.Errorf("deleting %s: %w", s.Ref, err)
+		}
+	}
+	return len(snaps), nil
+}
+
+// DeleteMergedSnapshots removes the polecat's snapshots made obsolete by
+// merging branch at commit merged: those taken on branch, and those with no
+// recorded branch whose base is an ancestor of merged. Snapshots of other
+// work by the same polecat name are kept. Returns how many were removed.
+func DeleteMergedSnapshots(dir, polecat, branch, merged string) (int, error) {
+	if polecat == "" {
+		return 0, fmt.Errorf("polecat name required")
+	}
+	snaps, err := ListSnapshots(dir, polecat)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, s := range snaps {
+		if !supersededBy(dir, &s, branch, merged) {
+			continue
+		}
+		if _, err := gitOutput(dir, nil, "update-ref", "-d", s.Ref); err != nil {
+			return removed, fmt.Errorf("deleting %s: %w", s.Ref, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// supersededBy reports whether merging branch at commit merged supersedes
+// snapshot s.
+func supersededBy(dir string, s *Snapshot, branch, merged string) bool {
+	if s.Branch != "" {
+		return branch != "" && s.Branch == branch
+	}
+	if s.Base == "" || merged == "" {
+		return false
+	}
+	_, err := gitOutput(dir, nil, "merge-base", "--is-ancestor", s.Base, merged)
+	return err == nil
+}
+
+// commitEnv supplies a committer identity so snapshots work in worktrees
+// without user.name/user.email configured.
+func commitEnv() []string {
+	return []string{
+		"GIT_AUTHOR_NAME=gastown", "GIT_AUTHOR_EMAIL=gastown@localhost",
+		"GIT_COMMITTER_NAME=gastown", "GIT_COMMITTER_EMAIL=gastown@localhost",
+	}
+}
+
+func gitOutput(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,198 @@
+package checkpoint
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "test"},
+	} {
+		if _, err := gitOutput(dir, nil, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, dir, "main.go", "package main\n")
+	if _, err := gitOutput(dir, nil, "add", "."); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gitOutput(dir, nil, "commit", "-q", "-m", "init"); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestTakeSnapshot_CleanWorktree(t *testing.T) {
+	dir := initRepo(t)
+	snap, err := TakeSnapshot(dir, "nux", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap != nil {
+		t.Errorf("clean worktree should not be snapshotted, got %s", snap.Ref)
+	}
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, "main.go", "package main\n\nfunc main() {}\n")
+	writeFile(t, dir, "new.go", "package main // untracked\n")
+	statusBefore, _ := gitOutput(dir, nil, "status", "--porcelain")
+
+	snap, err := TakeSnapshot(dir, "nux", "nuke")
+	if err != nil || snap == nil {
+		t.Fatalf("TakeSnapshot = %v, %v", snap, err)
+	}
+	if !strings.HasPrefix(snap.Ref, RefPrefix+"nux/") {
+		t.Errorf("ref = %s", snap.Ref)
+	}
+
+	// Taking a snapshot must not touch the worktree or index.
+	if statusAfter, _ := gitOutput(dir, nil, "status", "--porcelain"); statusAfter != statusBefore {
+		t.Errorf("status changed:\n%s\n->\n%s", statusBefore, statusAfter)
+	}
+
+	snaps, err := ListSnapshots(dir, "nux")
+	if err != nil || len(snaps) != 1 {
+		t.Fatalf("ListSnapshots = %v, %v", snaps, err)
+	}
+	if snaps[0].Reason != "nuke" || snaps[0].Base == "" || snaps[0].Commit != snap.Commit {
+		t.Errorf("listed snapshot = %+v", snaps[0])
+	}
+	if other, _ := ListSnapshots(dir, "nu"); len(other) != 0 {
+		t.Errorf("polecat filter should match whole names, got %v", other)
+	}
+
+	// Lose the work, then restore it.
+	if _, err := gitOutput(dir, nil, "checkout", "--", "."); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Remove(filepath.Join(dir, "new.go"))
+
+	found, err := FindSnapshot(dir, "nux", "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RestoreSnapshot(dir, found); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, dir, "main.go"); !strings.Contains(got, "func main") {
+		t.Errorf("main.go not restored: %q", got)
+	}
+	if got := readFile(t, dir, "new.go"); !strings.Contains(got, "untracked") {
+		t.Errorf("untracked file not restored: %q", got)
+	}
+
+	n, err := DeleteSnapshots(dir, "nux")
+	if err != nil || n != 1 {
+		t.Errorf("DeleteSnapshots = %d, %v", n, err)
+	}
+	if snaps, _ := ListSnapshots(dir, ""); len(snaps) != 0 {
+		t.Errorf("snapshots remain after delete: %v", snaps)
+	}
+}
+
+func TestDeleteMergedSnapshots_KeepsOtherWork(t *testing.T) {
+	dir := initRepo(t)
+	git := func(args ...string) string {
+		t.Helper()
+		out, err := gitOutput(dir, commitEnv(), args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+	// Take snapshots under distinct timestamps; refs have second resolution.
+	n := 0
+	take := func() *Snapshot {
+		t.Helper()
+		writeFile(t, dir, "wip.go", fmt.Sprintf("package main // wip %d\n", n))
+		snap, err := TakeSnapshot(dir, "nux", "")
+		if err != nil || snap == nil {
+			t.Fatalf("TakeSnapshot = %v, %v", snap, err)
+		}
+		ref := SnapshotRef("nux", time.Date(2026, 1, 1, 0, 0, n, 0, time.UTC))
+		git("update-ref", ref, snap.Commit)
+		git("update-ref", "-d", snap.Ref)
+		git("checkout", "-q", "--", ".")
+		_ = os.Remove(filepath.Join(dir, "wip.go"))
+		n++
+		return snap
+	}
+	initial := git("rev-parse", "HEAD")
+
+	// Earlier, unmerged assignment: snapshot taken on its own branch.
+	git("checkout", "-q", "-b", "polecat/nux/gt-1")
+	writeFile(t, dir, "one.go", "package main\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "gt-1")
+	if s := take(); s.Branch != "polecat/nux/gt-1" {
+		t.Fatalf("Branch = %q", s.Branch)
+	}
+
+	// Assignment being merged: one snapshot on its branch, one detached.
+	git("checkout", "-q", "-b", "polecat/nux/gt-2", initial)
+	writeFile(t, dir, "two.go", "package main\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "gt-2")
+	merged := git("rev-parse", "HEAD")
+	take()
+	git("checkout", "-q", "--detach", merged)
+	if s := take(); s.Branch != "" {
+		t.Fatalf("detached snapshot Branch = %q", s.Branch)
+	}
+
+	removed, err := DeleteMergedSnapshots(dir, "nux", "polecat/nux/gt-2", merged)
+	if err != nil || removed != 2 {
+		t.Fatalf("DeleteMergedSnapshots = %d, %v; want 2", removed, err)
+	}
+	snaps, _ := ListSnapshots(dir, "nux")
+	if len(snaps) != 1 || snaps[0].Branch != "polecat/nux/gt-1" {
+		t.Errorf("remaining snapshots = %+v, want only the gt-1 snapshot", snaps)
+	}
+}
+
+func TestCapture_WithSnapshot(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, "main.go", "package main // edited\n")
+
+	cp, err := Capture(dir, "toast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(cp.Snapshot, RefPrefix+"toast/") {
+		t.Errorf("Snapshot = %q", cp.Snapshot)
+	}
+	if !strings.Contains(cp.Summary(), "snapshot saved") {
+		t.Errorf("Summary = %q", cp.Summary())
+	}
+}
@@ -3,15 +3,19 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/secrets"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
 )
 
 // Account command flags
@@ -19,31 +23,36 @@ var (
 	accountJSON        bool
 	accountEmail       string
 	accountDescription string
+	accountProvider    string
+	accountSecret      string
 )
 
 var accountCmd = &cobra.Command{
 	Use:     "account",
 	GroupID: GroupConfig,
-	Short:   "Manage Claude Code accounts",
+	Short:   "Manage agent accounts (Claude Code config dirs, provider API keys)",
 	RunE:    requireSubcommand,
-	Long: `Manage multiple Claude Code accounts for Gas Town.
+	Long: `Manage multiple agent accounts for Gas Town.
 
 This enables switching between accounts (e.g., personal vs work) with
-easy account selection per spawn or globally.
+easy account selection per spawn or globally. Claude Code accounts are
+config directories; other providers (codex, gemini, copilot) use an API
+key or token kept in a secret backend.
 
 Commands:
   gt account list              List registered accounts
   gt account add <handle>      Add a new account
   gt account default <handle>  Set the default account
+  gt account strategy <name>   Set how new polecats pick an account
   gt account status            Show current account info`,
 }
 
 var accountListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List registered accounts",
-	Long: `List all registered Claude Code accounts.
+	Long: `List all registered accounts.
 
-Shows account handles, emails, and which is the default.
+Shows account handles, emails, providers, and which is the default.
 
 Examples:
   gt account list           # Text output
@@ -54,20 +63,117 @@ Examples:
 var accountAddCmd = &cobra.Command{
 	Use:   "add <handle>",
 	Short: "Add a new account",
-	Long: `Add a new Claude Code account.
+	Long: `Add a new agent account.
 
-Creates a config directory at ~/.claude-accounts/<handle> and registers
-the account. You'll need to run 'claude' with CLAUDE_CONFIG_DIR set to
-that directory to complete the login.
+Claude Code accounts (the default provider) get a config directory at
+~/.claude-accounts/<handle>. You'll need to run 'claude' with
+CLAUDE_CONFIG_DIR set to that directory to complete the login.
+
+Accounts for other providers (--provider codex|gemini|copilot) authenticate
+with a credential injected into the agent's API key env var at startup.
+Use --secret to reference an existing secret ("<backend>:<key>", backends:
+file, env, keychain on macOS), or pipe the key on stdin to store it in the
+town's secret backend under the account handle.
 
 Examples:
   gt account add work
   gt account add work --email steve@company.com
+  echo "$OPENAI_KEY" | gt account add openai-work --provider codex
+  gt account add gemini-ci --provider gemini --secret env:GEMINI_CI_KEY
   gt account add work --email steve@company.com --desc "Work account"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAccountAdd,
 }
 
+// addCredentialAccount registers an API-key account for a non-Claude provider.
+func addCredentialAccount(townRoot, accountsPath string, cfg *config.AccountsConfig, handle string) error {
+	envVar := config.GetCredentialEnvVar(accountProvider)
+	if envVar == "" {
+		return fmt.Errorf("provider %q does not support credential accounts", accountProvider)
+	}
+
+	ref := accountSecret
+	if ref == "" {
+		// No reference given: read the key from stdin and store it.
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("pipe the %s on stdin or pass --secret <backend>:<key>", envVar)
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("reading credential: %w", err)
+		}
+		value := strings.TrimSpace(string(data))
+		if value == "" {
+			return fmt.Errorf("empty credential on stdin")
+		}
+		ref = handle
+		if err := secrets.Store(townRoot, ref, cfg.SecretBackend, value); err != nil {
+			return err
+		}
+	}
+	parsed, err := secrets.ParseRef(ref, cfg.SecretBackend)
+	if err != nil {
+		return err
+	}
+
+	cfg.Accounts[handle] = config.Account{
+		Email:       accountEmail,
+		Description: accountDescription,
+		Provider:    accountProvider,
+		Secret:      parsed.String(),
+	}
+	if cfg.Default == "" {
+		cfg.Default = handle
+	}
+	if err := config.SaveAccountsConfig(accountsPath, cfg); err != nil {
+		return fmt.Errorf("saving accounts config: %w", err)
+	}
+
+	fmt.Printf("Added %s account '%s'\n", accountProvider, handle)
+	fmt.Printf("Credential: %s (injected as %s)\n", parsed, envVar)
+	return nil
+}
+
+var accountCredentialCmd = &cobra.Command{
+	Use:    "credential <handle>",
+	Short:  "Print an account's credential (used by agent startup)",
+	Hidden: true,
+	Long: `Print the API key or token for a credential account.
+
+Agent startup commands call this inside the session so the secret is
+loaded at exec time and never appears in command lines or tmux state.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAccountCredential,
+}
+
+func runAccountCredential(cmd *cobra.Command, args []string) error {
+	handle := args[0]
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading accounts config: %w", err)
+	}
+	acct, ok := cfg.Accounts[handle]
+	if !ok {
+		return fmt.Errorf("account '%s' not found", handle)
+	}
+	if !acct.UsesCredential() {
+		return fmt.Errorf("account '%s' is config-dir based and has no credential", handle)
+	}
+
+	value, err := secrets.Resolve(townRoot, acct.Secret, cfg.SecretBackend)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
 var accountDefaultCmd = &cobra.Command{
 	Use:   "default <handle>",
 	Short: "Set the default account",
@@ -89,6 +195,7 @@ type AccountListItem struct {
 	Email       string `json:"email"`
 	Description string `json:"description,omitempty"`
 	ConfigDir   string `json:"config_dir"`
+	Provider    string `json:"provider"`
 	IsDefault   bool   `json:"is_default"`
 }
 
@@ -123,6 +230,7 @@ func runAccountList(cmd *cobra.Command, args []string) error {
 			Email:       acct.Email,
 			Description: acct.Description,
 			ConfigDir:   acct.ConfigDir,
+			Provider:    acct.ProviderName(),
 			IsDefault:   handle == cfg.Default,
 		})
 	}
@@ -139,7 +247,7 @@ func runAccountList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Text output
-	fmt.Printf("%s\n\n", style.Bold.Render("Claude Code Accounts"))
+	fmt.Printf("%s\n\n", style.Bold.Render("Accounts"))
 	for _, item := range items {
 		marker := "  "
 		if item.IsDefault {
@@ -150,6 +258,9 @@ func runAccountList(cmd *cobra.Command, args []string) error {
 		if item.Email != "" {
 			fmt.Printf("  %s", item.Email)
 		}
+		if item.Provider != string(config.AgentClaude) {
+			fmt.Printf("  %s", style.Dim.Render("["+item.Provider+"]"))
+		}
 		if item.IsDefault {
 			fmt.Printf("  %s", style.Dim.Render("(default)"))
 		}
@@ -184,6 +295,10 @@ func runAccountAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("account '%s' already exists", handle)
 	}
 
+	if accountProvider != "" && accountProvider != string(config.AgentClaude) {
+		return addCredentialAccount(townRoot, accountsPath, cfg, handle)
+	}
+
 	// Build config directory path
 	baseDir, err := config.DefaultAccountsConfigDir()
 	if err != nil {
@@ -260,6 +375,56 @@ func runAccountDefault(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var accountStrategyCmd = &cobra.Command{
+	Use:   "strategy <default|balanced>",
+	Short: "Set how new polecats pick an account",
+	Long: `Set the account selection strategy for new polecat sessions.
+
+Strategies:
+  default   Use the default account (or --account / GT_ACCOUNT)
+  balanced  Spread polecats across available accounts: the account with
+            the fewest live sessions wins, then the least recently used.
+            Rate-limited accounts are skipped until their reset time.
+
+An explicit --account flag or GT_ACCOUNT always takes precedence.
+
+Examples:
+  gt account strategy balanced
+  gt account strategy default`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAccountStrategy,
+}
+
+func runAccountStrategy(cmd *cobra.Command, args []string) error {
+	strategy := args[0]
+	switch strategy {
+	case "default":
+		strategy = ""
+	case config.AccountStrategyBalanced:
+	default:
+		return fmt.Errorf("unknown strategy %q (valid: default, balanced)", args[0])
+	}
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	accountsPath := constants.MayorAccountsPath(townRoot)
+	cfg, err := config.LoadAccountsConfig(accountsPath)
+	if err != nil {
+		return fmt.Errorf("loading accounts config: %w", err)
+	}
+
+	cfg.Strategy = strategy
+	if err := config.SaveAccountsConfig(accountsPath, cfg); err != nil {
+		return fmt.Errorf("saving accounts config: %w", err)
+	}
+
+	fmt.Printf("Account strategy set to '%s'\n", args[0])
+	return nil
+}
+
 var accountStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current account info",
@@ -509,12 +674,16 @@ func init() {
 
 	accountAddCmd.Flags().StringVar(&accountEmail, "email", "", "Account email address")
 	accountAddCmd.Flags().StringVar(&accountDescription, "desc", "", "Account description")
+	accountAddCmd.Flags().StringVar(&accountProvider, "provider", "", "Agent provider for the account (claude, codex, gemini, copilot)")
+	accountAddCmd.Flags().StringVar(&accountSecret, "secret", "", "Secret reference for API-key providers (<backend>:<key>)")
 
 	// Add subcommands
 	accountCmd.AddCommand(accountListCmd)
 	accountCmd.AddCommand(accountAddCmd)
 	accountCmd.AddCommand(accountDefaultCmd)
+	accountCmd.AddCommand(accountStrategyCmd)
 	accountCmd.AddCommand(accountStatusCmd)
+	accountCmd.AddCommand(accountCredentialCmd)
 	accountCmd.AddCommand(accountSwitchCmd)
 
 	rootCmd.AddCommand(accountCmd)
@@ -24,9 +24,11 @@ moving beads between repos and viewing beads by ID with automatic
 prefix-based routing.
 
 Subcommands:
-  move    Move a bead from one repository to another
-  show    Show details of a bead (routes by prefix)
-  read    Alias for show`,
+  move     Move a bead from one repository to another
+  show     Show details of a bead (routes by prefix)
+  read     Alias for show
+  history  Field-level change history from Dolt commits
+  revert   Restore a bead to an earlier commit or time`,
 }
 
 var beadMoveCmd = &cobra.Command{
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltclient"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	beadHistoryJSON  bool
+	beadRevertTo     string
+	beadRevertDryRun bool
+)
+
+var beadHistoryCmd = &cobra.Command{
+	Use:   "history <bead-id>",
+	Short: "Show field-level history of a bead from Dolt commits",
+	Long: `Show every committed change to a bead: which fields changed, from what
+to what, in which Dolt commit, and who made the commit.
+
+The committer is the writing agent's identity (GIT_AUTHOR_NAME), so this
+answers "who closed this bead, and when?" without digging through logs.
+Uncommitted working-set changes are not shown.
+
+Examples:
+  gt bead history gt-abc123
+  gt bead history gt-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadHistory,
+}
+
+var beadRevertCmd = &cobra.Command{
+	Use:   "revert <bead-id> --to <commit|time>",
+	Short: "Restore a bead to its state at an earlier commit or time",
+	Long: `Restore a bead's fields and labels to their state at an earlier Dolt
+commit, recorded as a new commit. Only this bead is touched.
+
+--to accepts a commit hash (or unique prefix from 'gt bead history'), a
+duration meaning "that long ago" (10m, 2h), or a time (RFC 3339,
+"2006-01-02 15:04", or "2006-01-02").
+
+Examples:
+  gt bead revert gt-abc123 --to 3f9k2m1a
+  gt bead revert gt-abc123 --to 30m --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadRevert,
+}
+
+func init() {
+	beadHistoryCmd.Flags().BoolVar(&beadHistoryJSON, "json", false, "Output as JSON")
+	beadRevertCmd.Flags().StringVar(&beadRevertTo, "to", "", "Commit hash, duration ago, or time to revert to (required)")
+	beadRevertCmd.Flags().BoolVarP(&beadRevertDryRun, "dry-run", "n", false, "Show what would change without writing")
+	_ = beadRevertCmd.MarkFlagRequired("to")
+	beadCmd.AddCommand(beadHistoryCmd)
+	beadCmd.AddCommand(beadRevertCmd)
+}
+
+// openBeadDatabase returns the pooled client for the Dolt database that
+// stores beadID, resolved from its prefix via routes.jsonl.
+func openBeadDatabase(beadID string) (*doltclient.Client, string, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	prefix := beads.ExtractPrefix(beadID)
+	rigPath := beads.GetRigPathForPrefix(townRoot, prefix)
+	if rigPath == "" {
+		return nil, "", fmt.Errorf("no route for prefix %q of %s", prefix, beadID)
+	}
+	dbName := doltserver.DatabaseForBeadsDir(beads.ResolveBeadsDir(rigPath))
+	if dbName == "" {
+		return nil, "", fmt.Errorf("no Dolt database configured for %s", rigPath)
+	}
+	client, err := doltserver.Client(townRoot, dbName)
+	if err != nil {
+		return nil, "", fmt.Errorf("connecting to database %s: %w", dbName, err)
+	}
+	return client, dbName, nil
+}
+
+func runBeadHistory(cmd *cobra.Command, args []string) error {
+	beadID := args[0]
+	db, _, err := openBeadDatabase(beadID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	revisions, err := doltserver.BeadHistory(ctx, db, beadID)
+	if err != nil {
+		return err
+	}
+
+	if beadHistoryJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(revisions)
+	}
+	if len(revisions) == 0 {
+		fmt.Printf("No committed history for %s\n", beadID)
+		return nil
+	}
+	for _, rev := range revisions {
+		fmt.Printf("%s %s %s %s\n",
+			style.Bold.Render(shortHash(rev.Commit.Hash)),
+			rev.Commit.Date.Local().Format("2006-01-02 15:04:05"),
+			rev.Commit.Committer,
+			style.Dim.Render(util.FirstLine(rev.Commit.Message)))
+		if rev.Kind != doltserver.DiffModified {
+			fmt.Printf("    (%s)\n", rev.Kind)
+			if rev.Kind == doltserver.DiffAdded {
+				continue // every field is new; not worth listing
+			}
+		}
+		printFieldChanges(rev.Changes, "    ")
+	}
+	return nil
+}
+
+func runBeadRevert(cmd *cobra.Command, args []string) error {
+	beadID := args[0]
+	db, _, err := openBeadDatabase(beadID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	commit, err := doltserver.ResolveRevision(ctx, db, beadRevertTo, time.Now())
+	if err != nil {
+		return err
+	}
+	changes, err := doltserver.RevertBead(ctx, db, beadID, commit, detectActor(), beadRevertDryRun)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Printf("%s already matches %s; nothing to revert\n", beadID, shortHash(commit))
+		return nil
+	}
+	verb := "Reverted"
+	if beadRevertDryRun {
+		verb = "Would revert"
+	}
+	fmt.Printf("%s %s to %s:\n", verb, beadID, shortHash(commit))
+	printFieldChanges(changes, "  ")
+	return nil
+}
+
+// printFieldChanges prints one line per field change.
+func printFieldChanges(changes []doltserver.FieldChange, indent string) {
+	for _, c := range changes {
+		if c.Field == doltserver.LabelsField {
+			if c.To != nil {
+				fmt.Printf("%s%s +%s\n", indent, c.Field, *c.To)
+			} else if c.From != nil {
+				fmt.Printf("%s%s -%s\n", indent, c.Field, *c.From)
+			}
+			continue
+		}
+		fmt.Printf("%s%s: %s → %s\n", indent, c.Field, fieldValue(c.From), fieldValue(c.To))
+	}
+}
+
+// fieldValue renders a field value for display, truncating long text.
+func fieldValue(v *string) string {
+	if v == nil {
+		return style.Dim.Render("NULL")
+	}
+	s := strings.ReplaceAll(*v, "\n", "⏎")
+	if len([]rune(s)) > 60 {
+		s = string([]rune(s)[:57]) + "..."
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+func shortHash(h string) string {
+	if len(h) > 8 {
+		return h[:8]
+	}
+	return h
+}
@@ -10,8 +10,10 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/boot"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -328,10 +330,11 @@ func runDegradedTriage(b *boot.Boot) (action, target string, err error) {
 	return "nothing", "", nil
 }
 
-// executeWarrants scans the warrants directory and executes any pending warrants.
-// It is called as a side effect during degraded triage, before the normal
-// Deacon health decision is made. Errors are non-fatal: a failed execution is
-// logged and skipped rather than aborting triage.
+// executeWarrants scans the warrants directory and acts on pending warrants
+// under the town's warrant policy. It is called as a side effect during
+// degraded triage, before the normal Deacon health decision is made. Errors
+// are non-fatal: a failed execution is logged and skipped rather than
+// aborting triage.
 func executeWarrants(warrantDir string, tm *tmux.Tmux) {
 	entries, err := os.ReadDir(warrantDir)
 	if err != nil {
@@ -341,32 +344,97 @@ func executeWarrants(warrantDir string, tm *tmux.Tmux) {
 		return
 	}
 
+	policy := config.LoadWarrantPolicy(filepath.Dir(warrantDir))
+
 	for _, entry := range entries {
 		if !strings.HasSuffix(entry.Name(), ".warrant.json") {
 			continue
 		}
 
 		path := filepath.Join(warrantDir, entry.Name())
-		data, err := os.ReadFile(path)
-		if err != nil {
-			fmt.Printf("Warning: reading warrant file %s: %v\n", entry.Name(), err)
-			continue
+		if err := executeWarrantFile(path, policy, tm); err != nil {
+			fmt.Printf("Warning: %s: %v\n", entry.Name(), err)
 		}
+	}
+}
 
-		var w Warrant
-		if err := json.Unmarshal(data, &w); err != nil {
-			fmt.Printf("Warning: parsing warrant file %s: %v\n", entry.Name(), err)
-			continue
-		}
+// executeWarrantFile applies the warrant policy to one warrant file. The
+// warrant is loaded under its lock and the lock is held until the decision
+// is saved, so an appeal or approval filed meanwhile is never overwritten
+// and a warrant dismissed on appeal is never executed.
+func executeWarrantFile(path string, policy *config.WarrantPolicyConfig, tm *tmux.Tmux) error {
+	lock, err := lockWarrant(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
 
-		if w.Executed {
-			continue
+	w, err := loadWarrant(path)
+	if err != nil {
+		return err
+	}
+	if w == nil || !w.pending() {
+		return nil
+	}
+
+	if err := applyWarrantPolicy(w, path, policy, tm, time.Now()); err != nil {
+		return fmt.Errorf("executing warrant for %s: %w", w.Target, err)
+	}
+	return nil
+}
+
+// applyWarrantPolicy executes a pending warrant if its policy allows, and
+// otherwise opens the appeal window or records the hold. Warrants against
+// dead sessions are executed without policy checks: there is no live work
+// to protect. The caller must hold the warrant's lock.
+func applyWarrantPolicy(w *Warrant, path string, policy *config.WarrantPolicyConfig, tm *tmux.Tmux, now time.Time) error {
+	sessionName, err := targetToSessionName(w.Target)
+	if err != nil {
+		return fmt.Errorf("invalid target %s: %w", w.Target, err)
+	}
+	alive, err := tm.HasSession(sessionName)
+	if err != nil {
+		return fmt.Errorf("checking session %s: %w", sessionName, err)
+	}
+	if !alive {
+		return executeOneWarrant(w, path, tm)
+	}
+
+	rule := policy.Match(warrantTargetRole(w.Target), w.warrantClass())
+	decision, detail := evaluateWarrant(w, rule, now)
+	switch decision {
+	case warrantExecute:
+		return executeOneWarrant(w, path, tm)
+
+	case warrantOpenAppeal:
+		deadline := now.Add(rule.GetAppealWindow())
+		w.AppealDeadline = &deadline
+		w.Hold = decision
+		if err := saveWarrant(w, path); err != nil {
+			return err
 		}
+		if err := tm.NudgeSession(sessionName, appealMessage(w, deadline)); err != nil {
+			fmt.Printf("Warning: nudging %s about its warrant: %v\n", w.Target, err)
+		}
+		_ = events.LogFeed(events.TypeWarrantAppealWindow, "boot", events.WarrantPayload(w.ID, w.Target, w.Reason, detail))
+		fmt.Printf("Warrant held: %s nudged, appeal window until %s\n", w.Target, deadline.Format("15:04"))
+		return nil
 
-		if err := executeOneWarrant(&w, path, tm); err != nil {
-			fmt.Printf("Warning: executing warrant for %s: %v\n", w.Target, err)
-			continue
+	case warrantInAppealWindow:
+		return nil
+
+	default:
+		// Held for a co-signer or approval: record each new hold once.
+		if w.Hold == decision {
+			return nil
 		}
+		w.Hold = decision
+		if err := saveWarrant(w, path); err != nil {
+			return err
+		}
+		_ = events.LogAudit(events.TypeWarrantHeld, "boot", events.WarrantPayload(w.ID, w.Target, w.Reason, decision+": "+detail))
+		fmt.Printf("Warrant held: %s (%s)\n", w.Target, detail)
+		return nil
 	}
 }
 
@@ -122,6 +122,50 @@ func TestExecuteWarrants_SkipsAlreadyExecuted(t *testing.T) {
 	}
 }
 
+// TestExecuteWarrants_RereadsUnderLock verifies that executeWarrants loads
+// each warrant only once it holds the warrant's lock, so an appeal saved
+// while the lock was held is honored rather than executed over.
+func TestExecuteWarrants_RereadsUnderLock(t *testing.T) {
+	setupWarrantTestRegistry(t)
+
+	warrantDir := t.TempDir()
+	w := Warrant{
+		ID:      "warrant-appealed",
+		Target:  "gastown/polecats/appealed-x7q",
+		Reason:  "Zombie: no session, idle >10m",
+		FiledBy: "test",
+		FiledAt: time.Now().Add(-5 * time.Minute),
+	}
+	writeTestWarrant(t, warrantDir, w)
+	path := warrantFilePath(warrantDir, w.Target)
+
+	lock, err := lockWarrant(path)
+	if err != nil {
+		t.Fatalf("lockWarrant() error = %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		executeWarrants(warrantDir, tmux.NewTmux())
+		close(done)
+	}()
+
+	// The appeal lands while Boot waits for the lock.
+	time.Sleep(200 * time.Millisecond)
+	now := time.Now()
+	w.Dismissed = true
+	w.DismissedAt = &now
+	if err := saveWarrant(&w, path); err != nil {
+		t.Fatalf("saveWarrant() error = %v", err)
+	}
+	_ = lock.Unlock()
+	<-done
+
+	result := readTestWarrant(t, warrantDir, w.Target)
+	if result.Executed || !result.Dismissed {
+		t.Errorf("warrant = executed %v, dismissed %v; want dismissed and not executed", result.Executed, result.Dismissed)
+	}
+}
+
 // TestExecuteWarrants_MissingDir verifies that executeWarrants handles a
 // missing warrants directory gracefully (no panic, no error).
 func TestExecuteWarrants_MissingDir(t *testing.T) {
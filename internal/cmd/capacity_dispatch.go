@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/beads"
@@ -155,6 +156,9 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 			}
 			recordDispatchFailure(townBeads, b, err)
 		},
+		HostPressure: func() capacity.PressureVerdict {
+			return checkHostPressure(townRoot, schedulerCfg, !dryRun)
+		},
 		BatchSize:  batchSize,
 		SpawnDelay: spawnDelay,
 	}
@@ -191,6 +195,11 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 		}
 	}
 
+	if report.Reason == "pressure" {
+		fmt.Printf("%s Holding %d bead(s): host under pressure (%s)\n",
+			style.Warning.Render("⏸"), report.Skipped, report.Pressure)
+	}
+
 	if report.Dispatched > 0 || report.Failed > 0 {
 		fmt.Printf("\n%s Dispatched %d, failed %d (reason: %s)\n",
 			style.Bold.Render("✓"), report.Dispatched, report.Failed, report.Reason)
@@ -217,6 +226,10 @@ func printDryRunPlan(plan capacity.DispatchPlan, maxPolecats, batchSize int) {
 	}
 
 	totalReady := len(plan.ToDispatch) + plan.Skipped
+	if plan.Reason == "pressure" {
+		fmt.Printf("Host under pressure (%s), %d ready bead(s) waiting\n", plan.Pressure, totalReady)
+		return
+	}
 	if len(plan.ToDispatch) == 0 {
 		fmt.Printf("No capacity: %s, %d ready bead(s) waiting\n", capStr, totalReady)
 		return
@@ -366,6 +379,8 @@ func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 	})
 
 	seenWork := make(map[string]bool)
+	inMaintenance := make(map[string]bool) // Rig -> maintenance window open
+	now := time.Now()
 	var result []capacity.PendingBead
 	for _, ctx := range allContexts {
 		fields := beads.ParseSlingContextFields(ctx.Description)
@@ -383,6 +398,20 @@ func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 			continue
 		}
 
+		// Hold beads for rigs in a maintenance window. They stay scheduled
+		// and dispatch once the window closes, rather than failing against
+		// the parked rig and tripping the circuit breaker.
+		if fields.TargetRig != "" {
+			held, checked := inMaintenance[fields.TargetRig]
+			if !checked {
+				_, _, held = activeMaintenanceWindow(townRoot, fields.TargetRig, now)
+				inMaintenance[fields.TargetRig] = held
+			}
+			if held {
+				continue
+			}
+		}
+
 		// Deduplicate: one dispatch per work bead (oldest context wins)
 		if seenWork[fields.WorkBeadID] {
 			continue
@@ -26,9 +26,13 @@ Checkpoint data includes:
 - Hooked bead
 - Modified files list
 - Git branch and last commit
+- A snapshot of uncommitted changes
 - Timestamp
 
-Checkpoints are stored in .polecat-checkpoint.json in the polecat directory.`,
+Checkpoints are stored in .polecat-checkpoint.json in the polecat directory.
+Uncommitted changes are preserved as stash-style commits under
+refs/gastown/checkpoints/<name>/<timestamp> in the rig's shared repository,
+so they survive the worktree being nuked or repaired.`,
 }
 
 var checkpointWriteCmd = &cobra.Command{
@@ -59,16 +63,52 @@ var checkpointClearCmd = &cobra.Command{
 	RunE:  runCheckpointClear,
 }
 
+var checkpointListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List snapshots of uncommitted work",
+	Long: `List checkpoint snapshots for this worker, newest first.
+
+Snapshots are taken by 'gt checkpoint write' and automatically before a
+polecat worktree with uncommitted changes is nuked or repaired.
+
+Examples:
+  gt checkpoint list          # This worker's snapshots
+  gt checkpoint list --all    # Every worker's snapshots in this rig`,
+	RunE: runCheckpointList,
+}
+
+var checkpointRestoreCmd = &cobra.Command{
+	Use:   "restore [snapshot]",
+	Short: "Restore uncommitted work from a snapshot",
+	Long: `Apply a checkpoint snapshot's changes to the current worktree.
+
+The snapshot defaults to this worker's latest. Changes are applied like
+'git stash apply': if the worktree has moved to a different base commit,
+they are merged and any conflicts are left to resolve.
+
+Examples:
+  gt checkpoint restore                        # Latest snapshot
+  gt checkpoint restore nux/20260301T120000Z   # A specific snapshot`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCheckpointRestore,
+}
+
 var (
 	checkpointNotes    string
 	checkpointMolecule string
 	checkpointStep     string
+	checkpointListAll  bool
 )
 
 func init() {
 	checkpointCmd.AddCommand(checkpointWriteCmd)
 	checkpointCmd.AddCommand(checkpointReadCmd)
 	checkpointCmd.AddCommand(checkpointClearCmd)
+	checkpointCmd.AddCommand(checkpointListCmd)
+	checkpointCmd.AddCommand(checkpointRestoreCmd)
+
+	checkpointListCmd.Flags().BoolVar(&checkpointListAll, "all", false,
+		"List snapshots for every worker in the repository")
 
 	checkpointWriteCmd.Flags().StringVar(&checkpointNotes, "notes", "",
 		"Add notes to the checkpoint")
@@ -105,7 +145,7 @@ func runCheckpointWrite(cmd *cobra.Command, args []string) error {
 	}
 
 	// Capture current state
-	cp, err := checkpoint.Capture(cwd)
+	cp, err := checkpoint.Capture(cwd, roleInfo.Polecat)
 	if err != nil {
 		return fmt.Errorf("capturing checkpoint: %w", err)
 	}
@@ -194,6 +234,9 @@ func runCheckpointRead(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  - %s\n", f)
 		}
 	}
+	if cp.Snapshot != "" {
+		fmt.Printf("Snapshot: %s\n", cp.Snapshot)
+	}
 	if cp.Notes != "" {
 		fmt.Printf("Notes: %s\n", cp.Notes)
 	}
@@ -218,6 +261,90 @@ func runCheckpointClear(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCheckpointList(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	agent := ""
+	if !checkpointListAll {
+		if agent, err = checkpointAgent(cwd); err != nil {
+			return err
+		}
+	}
+
+	snaps, err := checkpoint.ListSnapshots(cwd, agent)
+	if err != nil {
+		return err
+	}
+	if len(snaps) == 0 {
+		fmt.Printf("%s No checkpoint snapshots\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	for _, s := range snaps {
+		fmt.Printf("  %s  %s\n", style.Bold.Render(s.Name()), style.Dim.Render(formatAge(s.Timestamp)))
+		if s.Reason != "" {
+			fmt.Printf("    %s %s\n", style.Dim.Render("reason:"), s.Reason)
+		}
+		if s.Base != "" {
+			fmt.Printf("    %s %s\n", style.Dim.Render("base:"), s.Base[:min(12, len(s.Base))])
+		}
+	}
+	fmt.Println()
+	fmt.Printf("%s\n", style.Dim.Render("Restore with: gt checkpoint restore <name>"))
+	return nil
+}
+
+func runCheckpointRestore(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	name := "latest"
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	// A named snapshot may belong to another worker (e.g. a predecessor
+	// polecat); "latest" means this worker's.
+	agent := ""
+	if name == "latest" {
+		if agent, err = checkpointAgent(cwd); err != nil {
+			return err
+		}
+	}
+
+	snap, err := checkpoint.FindSnapshot(cwd, agent, name)
+	if err != nil {
+		return err
+	}
+	if err := checkpoint.RestoreSnapshot(cwd, snap); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Restored %s\n", style.Bold.Render("✓"), snap.Name())
+	return nil
+}
+
+// checkpointAgent returns the worker name used for snapshot refs in cwd.
+func checkpointAgent(cwd string) (string, error) {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return "", fmt.Errorf("not in a Gas Town workspace")
+	}
+	roleInfo, err := GetRoleWithContext(cwd, townRoot)
+	if err != nil {
+		return "", fmt.Errorf("detecting role: %w", err)
+	}
+	if roleInfo.Polecat == "" {
+		return "", fmt.Errorf("not in a polecat or crew worktree")
+	}
+	return roleInfo.Polecat, nil
+}
+
 // detectMoleculeContext tries to detect the current molecule and step from beads.
 func detectMoleculeContext(workDir string, ctx RoleInfo) (moleculeID, stepID, stepTitle string) {
 	b := beads.New(workDir)
@@ -613,6 +613,11 @@ Supported keys:
   scheduler.max_polecats      Dispatch mode: -1 = direct (default), N > 0 = deferred
   scheduler.batch_size        Beads per heartbeat (default: 1)
   scheduler.spawn_delay       Delay between spawns (default: 0s)
+  scheduler.pressure.<metric>.high|low
+                              Host pressure watermarks for deferred dispatch;
+                              metric is load_per_cpu, memory_used_pct,
+                              disk_used_pct, dolt_latency_ms or
+                              session_memory_mb (high 0 disables the metric)
   maintenance.window          Maintenance window start time in HH:MM (e.g., "03:00")
   maintenance.interval        How often: "daily", "weekly", "monthly", or duration
   maintenance.threshold       Commit count threshold (default: 1000)
@@ -635,6 +640,7 @@ Examples:
   gt config set default_agent claude
   gt config set dolt.port 3308
   gt config set scheduler.max_polecats 5
+  gt config set scheduler.pressure.memory_used_pct.high 85
   gt config set maintenance.window 03:00
   gt config set maintenance.interval daily
   gt config set lifecycle.reaper.delete_age 336h
@@ -657,6 +663,8 @@ Supported keys:
   scheduler.max_polecats      Dispatch mode (-1 = direct, N > 0 = deferred)
   scheduler.batch_size        Beads per heartbeat
   scheduler.spawn_delay       Delay between spawns
+  scheduler.pressure.<metric>.high|low
+                              Host pressure watermarks
   maintenance.window          Maintenance window start time (HH:MM)
   maintenance.interval        How often: daily, weekly, monthly, or duration
   maintenance.threshold       Commit count threshold
@@ -780,7 +788,27 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		if strings.HasPrefix(key, "lifecycle.") {
 			return setLifecycleConfig(townRoot, key, value)
 		}
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
+		if strings.HasPrefix(key, "scheduler.pressure.") {
+			metric, bound, ok := parsePressureKey(key)
+			if !ok {
+				return fmt.Errorf("invalid key %q: expected scheduler.pressure.<metric>.high or .low", key)
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil || v < 0 {
+				return fmt.Errorf("invalid value for %s: expected non-negative number", key)
+			}
+			if townSettings.Scheduler == nil {
+				townSettings.Scheduler = capacity.DefaultSchedulerConfig()
+			}
+			if townSettings.Scheduler.Pressure == nil {
+				townSettings.Scheduler.Pressure = &capacity.PressureConfig{}
+			}
+			if err := townSettings.Scheduler.Pressure.SetWatermark(metric, bound, v); err != nil {
+				return err
+			}
+			break
+		}
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  scheduler.pressure.<metric>.high|low\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
 	}
 
 	if err := config.SaveTownSettings(settingsPath, townSettings); err != nil {
@@ -865,13 +893,42 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		if strings.HasPrefix(key, "lifecycle.") {
 			return getLifecycleConfig(townRoot, key)
 		}
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
+		if strings.HasPrefix(key, "scheduler.pressure.") {
+			metric, bound, ok := parsePressureKey(key)
+			if !ok {
+				return fmt.Errorf("invalid key %q: expected scheduler.pressure.<metric>.high or .low", key)
+			}
+			for _, m := range townSettings.Scheduler.GetPressureWatermarks() {
+				if m.Metric != metric {
+					continue
+				}
+				if bound == "high" {
+					fmt.Println(capacity.FormatMetric(m.High))
+				} else {
+					fmt.Println(capacity.FormatMetric(m.Low))
+				}
+				return nil
+			}
+			return fmt.Errorf("unknown pressure metric %q (known: %s)", metric, strings.Join(capacity.PressureMetrics, ", "))
+		}
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  scheduler.pressure.<metric>.high|low\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
 	}
 
 	fmt.Println(value)
 	return nil
 }
 
+// parsePressureKey splits scheduler.pressure.<metric>.<high|low>.
+func parsePressureKey(key string) (metric, bound string, ok bool) {
+	rest := strings.TrimPrefix(key, "scheduler.pressure.")
+	i := strings.LastIndex(rest, ".")
+	if i <= 0 {
+		return "", "", false
+	}
+	metric, bound = rest[:i], rest[i+1:]
+	return metric, bound, bound == "high" || bound == "low"
+}
+
 // setMaintenanceConfig sets a maintenance.* key in daemon.json (patrol config).
 func setMaintenanceConfig(townRoot, key, value string) error {
 	patrolConfig := daemon.LoadPatrolConfig(townRoot)
@@ -70,8 +70,16 @@ type FindingJSON struct {
 
 // WaveJSON is the JSON representation of a Wave with task details.
 type WaveJSON struct {
-	Number int        `json:"number"`
-	Tasks  []TaskJSON `json:"tasks"`
+	Number    int            `json:"number"`
+	Tasks     []TaskJSON     `json:"tasks"`
+	MergeRisk *MergeRiskJSON `json:"merge_risk,omitempty"` // only when beads carry paths
+}
+
+// MergeRiskJSON is the JSON representation of a wave's merge-conflict risk.
+type MergeRiskJSON struct {
+	Level      string   `json:"level"`
+	SharedDirs []string `json:"shared_dirs,omitempty"`
+	Unknown    []string `json:"unknown,omitempty"` // tasks without path information
 }
 
 // TaskJSON is the JSON representation of a task within a wave.
@@ -81,6 +89,8 @@ type TaskJSON struct {
 	Type      string   `json:"type"`
 	Rig       string   `json:"rig"`
 	BlockedBy []string `json:"blocked_by,omitempty"`
+	Paths     []string `json:"paths,omitempty"`
+	Inferred  bool     `json:"paths_inferred,omitempty"`
 }
 
 // TreeNodeJSON is the JSON representation of a DAG node in a nested tree.
@@ -207,6 +217,11 @@ Three input forms:
   gt convoy stage <task1> <task2>...  Analyze exactly the given tasks
   gt convoy stage <convoy-id>         Re-analyze an existing convoy's tracked beads
 
+Beads expected to edit the same files are placed in separate waves, and each
+wave gets a merge-conflict risk estimate. Paths come from 'path:<glob>' labels
+or a 'paths:' line in the description; beads with only 'component:<name>'
+labels get paths inferred from recent commits on that component.
+
 The staged convoy can later be launched with 'gt convoy launch'.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runConvoyStage,
@@ -808,6 +823,9 @@ type ConvoyDAGNode struct {
 	Blocks    []string // IDs of beads this one blocks
 	Children  []string // parent-child children (hierarchy only, not execution)
 	Parent    string   // parent-child parent
+
+	Paths         []string // files/globs this bead is expected to edit
+	PathsInferred bool     // Paths came from commit history, not declarations
 }
 
 // detectCycles checks the DAG for cycles in execution edges (blocks/conditional-blocks/waits-for).
@@ -922,6 +940,8 @@ func isSlingableType(beadType string) bool {
 // blocking edges ARE respected — a task blocked by a decision bead will not
 // appear until that decision is resolved (fixes #2141).
 // Parent-child deps do NOT create execution edges.
+// Ready tasks whose paths overlap an earlier ready task (by ID) are held back
+// to a later wave, so tasks likely to edit the same files never share a wave.
 // Returns (waves, gatedTasks, error). gatedTasks lists tasks blocked by open
 // non-slingable nodes (decisions, epics) that cannot be placed in any wave.
 func computeWaves(dag *ConvoyDAG) ([]Wave, []GatedTask, error) {
@@ -993,8 +1013,10 @@ func computeWaves(dag *ConvoyDAG) ([]Wave, []GatedTask, error) {
 			return waves, gated, nil
 		}
 
-		// Step 7: Sort within each wave for determinism.
+		// Step 7: Sort within each wave for determinism, then hold back
+		// tasks that would edit the same files as one already in the wave.
 		sort.Strings(ready)
+		ready, _ = deferFileConflicts(ready, slingable)
 		waveNum++
 
 		waves = append(waves, Wave{
@@ -1026,6 +1048,9 @@ type BeadInfo struct {
 	Type   string // "epic", "task", "bug", etc.
 	Status string
 	Rig    string // resolved rig name
+
+	Paths         []string // expected files/globs (see beadPathInfo)
+	PathsInferred bool
 }
 
 // DepInfo represents a raw dependency from bd dep list output.
@@ -1051,6 +1076,9 @@ func buildConvoyDAG(beads []BeadInfo, deps []DepInfo) *ConvoyDAG {
 			Type:   b.Type,
 			Status: b.Status,
 			Rig:    b.Rig,
+
+			Paths:         b.Paths,
+			PathsInferred: b.PathsInferred,
 		}
 	}
 
@@ -1082,7 +1110,7 @@ func buildConvoyDAG(beads []BeadInfo, deps []DepInfo) *ConvoyDAG {
 // StagingFinding represents an error or warning found during convoy staging analysis.
 type StagingFinding struct {
 	Severity     string   // "error" or "warning"
-	Category     string   // "cycle", "no-rig", "orphan", "blocked-rig", "cross-rig", "capacity", "missing-branch"
+	Category     string   // "cycle", "no-rig", "orphan", "blocked-rig", "cross-rig", "file-conflict", "capacity", "missing-branch"
 	BeadIDs      []string // affected bead IDs
 	Message      string   // human-readable description
 	SuggestedFix string   // actionable fix suggestion
@@ -1234,6 +1262,22 @@ func renderWaveTable(waves []Wave, dag *ConvoyDAG) string {
 	buf.WriteString(fmt.Sprintf("\n  %d tasks across %d waves (max parallelism: %d in wave %d)\n",
 		totalTasks, len(waves), maxParallel, maxWave))
 
+	// Merge-conflict risk, only when beads carry path information.
+	if dagHasPaths(dag) {
+		buf.WriteString("\n  Merge-conflict risk:\n")
+		for _, wave := range waves {
+			risk := estimateWaveRisk(wave, dag)
+			line := fmt.Sprintf("    wave %d: %s", wave.Number, risk.Level)
+			if len(risk.SharedDirs) > 0 {
+				line += " (shared: " + strings.Join(risk.SharedDirs, ", ") + ")"
+			}
+			if len(risk.Unknown) > 0 && len(risk.Unknown) < len(wave.Tasks) {
+				line += fmt.Sprintf(" [%d task(s) without paths]", len(risk.Unknown))
+			}
+			buf.WriteString(line + "\n")
+		}
+	}
+
 	return buf.String()
 }
 
@@ -1294,10 +1338,12 @@ func buildGatedJSON(gated []GatedTask, dag *ConvoyDAG) []GatedTaskJSON {
 
 // bdShowResult matches the JSON output of `bd show <id> --json`.
 type bdShowResult struct {
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Status    string `json:"status"`
-	IssueType string `json:"issue_type"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	IssueType   string   `json:"issue_type"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
 }
 
 // bdDepResult matches the JSON output of `bd dep list <id> --json`.
@@ -1465,12 +1511,16 @@ func collectEpicBeads(epicID string) ([]BeadInfo, []DepInfo, error) {
 		visited[current.ID] = true
 
 		// Add bead info.
+		paths, inferred := beadPathInfo(current.ID, current.Labels, current.Description)
 		allBeads = append(allBeads, BeadInfo{
 			ID:     current.ID,
 			Title:  current.Title,
 			Type:   current.IssueType,
 			Status: current.Status,
 			Rig:    rigFromBeadID(current.ID),
+
+			Paths:         paths,
+			PathsInferred: inferred,
 		})
 
 		// Fetch deps for this bead.
@@ -1513,12 +1563,16 @@ func collectTaskListBeads(taskIDs []string) ([]BeadInfo, []DepInfo, error) {
 			return nil, nil, fmt.Errorf("task %s: %w", id, err)
 		}
 
+		paths, inferred := beadPathInfo(result.ID, result.Labels, result.Description)
 		allBeads = append(allBeads, BeadInfo{
 			ID:     result.ID,
 			Title:  result.Title,
 			Type:   result.IssueType,
 			Status: result.Status,
 			Rig:    rigFromBeadID(result.ID),
+
+			Paths:         paths,
+			PathsInferred: inferred,
 		})
 
 		// Fetch deps.
@@ -1727,6 +1781,7 @@ func detectWarnings(dag *ConvoyDAG, input *StageInput) []StagingFinding {
 	findings = append(findings, detectOrphans(dag, input)...)
 	findings = append(findings, detectBlockedRigs(dag)...)
 	findings = append(findings, detectCrossRig(dag)...)
+	findings = append(findings, detectFileConflicts(dag)...)
 	findings = append(findings, estimateCapacity(dag)...)
 	findings = append(findings, detectMissingBranches(dag)...)
 
@@ -2022,12 +2077,23 @@ func buildWavesJSON(waves []Wave, dag *ConvoyDAG) []WaveJSON {
 				Type:      node.Type,
 				Rig:       node.Rig,
 				BlockedBy: blockedBy,
+				Paths:     node.Paths,
+				Inferred:  node.PathsInferred,
 			})
 		}
-		out = append(out, WaveJSON{
+		wj := WaveJSON{
 			Number: w.Number,
 			Tasks:  tasks,
-		})
+		}
+		if dagHasPaths(dag) {
+			risk := estimateWaveRisk(w, dag)
+			wj.MergeRisk = &MergeRiskJSON{
+				Level:      risk.Level,
+				SharedDirs: risk.SharedDirs,
+				Unknown:    risk.Unknown,
+			}
+		}
+		out = append(out, wj)
 	}
 	return out
 }
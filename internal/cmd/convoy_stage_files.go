@@ -0,0 +1,476 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// ---------------------------------------------------------------------------
+// File ownership: predicting which beads will edit the same files
+// ---------------------------------------------------------------------------
+//
+// A bead's paths come from one of two sources:
+//
+//   - Declared: "path:<glob>" labels, or a "paths:"/"files:" line in the
+//     description (comma- or space-separated).
+//   - Inferred: for beads with "component:<name>" labels and no declared
+//     paths, the files most often touched by recent commits on the
+//     component (commit subject "<name>: ..." or a path segment named
+//     <name>) in the rig's repo.
+//
+// computeWaves keeps beads with overlapping paths out of the same wave, so
+// gt convoy launch never dispatches them side by side. The daemon feeder
+// dispatches later waves one issue per completion, which keeps overlap low
+// but is not a strict guarantee; add a merge-blocks dep when it must be.
+
+// inferHistoryDepth is how many recent commits are scanned to infer a
+// component's files.
+const inferHistoryDepth = 200
+
+// inferPathLimit caps the number of inferred paths per component.
+const inferPathLimit = 10
+
+// Merge-conflict risk levels for a wave.
+const (
+	mergeRiskLow     = "low"
+	mergeRiskMedium  = "medium"
+	mergeRiskHigh    = "high"
+	mergeRiskUnknown = "unknown"
+)
+
+// beadPathInfo resolves the paths a bead is expected to touch.
+// Returns the paths and whether they were inferred from history.
+func beadPathInfo(beadID string, labels []string, description string) ([]string, bool) {
+	if declared := declaredPaths(labels, description); len(declared) > 0 {
+		return declared, false
+	}
+	components := componentLabels(labels)
+	if len(components) == 0 {
+		return nil, false
+	}
+	history := recentCommitFilesFn(rigFromBeadID(beadID))
+	var inferred []string
+	for _, c := range components {
+		inferred = append(inferred, inferComponentPaths(c, history, inferPathLimit)...)
+	}
+	return dedupeSorted(inferred), len(inferred) > 0
+}
+
+// declaredPaths extracts explicit path declarations from labels and the
+// description.
+func declaredPaths(labels []string, description string) []string {
+	var paths []string
+	for _, l := range labels {
+		if p, ok := strings.CutPrefix(l, "path:"); ok {
+			paths = append(paths, p)
+		}
+	}
+	for _, line := range strings.Split(description, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "paths", "files":
+			paths = append(paths, strings.FieldsFunc(value, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			})...)
+		}
+	}
+	var out []string
+	for _, p := range paths {
+		if p = normalizeStagePath(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return dedupeSorted(out)
+}
+
+// componentLabels returns the names from "component:<name>" labels.
+func componentLabels(labels []string) []string {
+	var out []string
+	for _, l := range labels {
+		if c, ok := strings.CutPrefix(l, "component:"); ok && c != "" {
+			out = append(out, strings.ToLower(c))
+		}
+	}
+	return out
+}
+
+// inferComponentPaths returns the files most frequently touched by commits
+// about component, at most limit of them (ties broken by path).
+func inferComponentPaths(component string, history []git.CommitFiles, limit int) []string {
+	counts := make(map[string]int)
+	for _, c := range history {
+		subject := strings.ToLower(c.Subject)
+		bySubject := strings.HasPrefix(subject, component+":") || strings.HasPrefix(subject, component+"(")
+		for _, f := range c.Files {
+			if bySubject || pathMentions(f, component) {
+				counts[f]++
+			}
+		}
+	}
+	files := make([]string, 0, len(counts))
+	for f := range counts {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if counts[files[i]] != counts[files[j]] {
+			return counts[files[i]] > counts[files[j]]
+		}
+		return files[i] < files[j]
+	})
+	if len(files) > limit {
+		files = files[:limit]
+	}
+	sort.Strings(files)
+	return files
+}
+
+// pathMentions reports whether a path has a directory or file stem named name.
+func pathMentions(p, name string) bool {
+	for _, seg := range strings.Split(strings.ToLower(p), "/") {
+		if seg == name || strings.TrimSuffix(seg, path.Ext(seg)) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// recentCommitFilesFn loads recent commit history for a rig's repo.
+// Overridable in tests.
+var recentCommitFilesFn = loadRigCommitFiles
+
+// rigCommitFilesCache memoizes history per rig for a single staging run.
+var rigCommitFilesCache = map[string][]git.CommitFiles{}
+
+func loadRigCommitFiles(rigName string) []git.CommitFiles {
+	if rigName == "" {
+		return nil
+	}
+	if cached, ok := rigCommitFilesCache[rigName]; ok {
+		return cached
+	}
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return nil
+	}
+	commits, _ := git.NewGit(filepath.Join(townRoot, rigName, "mayor", "rig")).RecentCommitFiles(inferHistoryDepth)
+	rigCommitFilesCache[rigName] = commits
+	return commits
+}
+
+// normalizeStagePath cleans a declared path to slash-separated relative form.
+func normalizeStagePath(p string) string {
+	p = strings.TrimSpace(filepath.ToSlash(p))
+	p = strings.TrimPrefix(p, "./")
+	p = strings.TrimSuffix(p, "/")
+	if p == "" || p == "." {
+		return ""
+	}
+	return path.Clean(p)
+}
+
+func dedupeSorted(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	sort.Strings(in)
+	out := in[:1]
+	for _, s := range in[1:] {
+		if s != out[len(out)-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// isGlob reports whether p contains glob metacharacters.
+func isGlob(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// globRoot returns the literal directory prefix of a glob ("a/b/*.go" → "a/b").
+func globRoot(p string) string {
+	i := strings.IndexAny(p, "*?[")
+	if i < 0 {
+		return p
+	}
+	return strings.TrimSuffix(path.Dir(p[:i]+"x"), "/")
+}
+
+// underDir reports whether p is dir itself or lies beneath it.
+func underDir(p, dir string) bool {
+	return dir == "." || p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// pathsOverlap reports whether two path declarations can refer to the same
+// file. A plain path also covers everything beneath it, so "internal/router"
+// overlaps "internal/router/router.go".
+func pathsOverlap(a, b string) bool {
+	switch {
+	case !isGlob(a) && !isGlob(b):
+		return underDir(a, b) || underDir(b, a)
+	case isGlob(a) && isGlob(b):
+		ra, rb := globRoot(a), globRoot(b)
+		return underDir(ra, rb) || underDir(rb, ra)
+	case isGlob(b):
+		a, b = b, a
+	}
+	// a is a glob, b is a literal path.
+	if ok, _ := path.Match(a, b); ok {
+		return true
+	}
+	if strings.Contains(a, "**") && underDir(b, globRoot(a)) {
+		return true
+	}
+	// b may be a directory containing files the glob matches.
+	return underDir(globRoot(a), b)
+}
+
+// sharedPaths returns the entries of a that overlap any entry of b.
+func sharedPaths(a, b []string) []string {
+	var out []string
+	for _, pa := range a {
+		for _, pb := range b {
+			if pathsOverlap(pa, pb) {
+				out = append(out, pa)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// deferFileConflicts splits a ready set (sorted by ID) into tasks that can
+// run together and tasks that overlap an earlier pick and must wait.
+// The first task is always kept, so every wave makes progress.
+func deferFileConflicts(ready []string, nodes map[string]*ConvoyDAGNode) (keep, deferred []string) {
+	for _, id := range ready {
+		conflict := false
+		for _, kept := range keep {
+			if len(sharedPaths(nodes[id].Paths, nodes[kept].Paths)) > 0 {
+				conflict = true
+				break
+			}
+		}
+		if conflict {
+			deferred = append(deferred, id)
+		} else {
+			keep = append(keep, id)
+		}
+	}
+	return keep, deferred
+}
+
+// detectFileConflicts warns about slingable beads that are expected to edit
+// the same files and are not already ordered by dependencies. One finding is
+// emitted per group of mutually conflicting beads; computeWaves serializes
+// them into separate waves.
+func detectFileConflicts(dag *ConvoyDAG) []StagingFinding {
+	var ids []string
+	for _, id := range sortedNodeIDs(dag) {
+		node := dag.Nodes[id]
+		if isSlingableType(node.Type) && len(node.Paths) > 0 && node.Status != "closed" && node.Status != "tombstone" {
+			ids = append(ids, id)
+		}
+	}
+
+	// Union-find over conflicting pairs.
+	group := make(map[string]string, len(ids))
+	for _, id := range ids {
+		group[id] = id
+	}
+	var find func(string) string
+	find = func(id string) string {
+		if group[id] != id {
+			group[id] = find(group[id])
+		}
+		return group[id]
+	}
+	shared := make(map[string]map[string]bool)
+	for i, a := range ids {
+		for _, b := range ids[i+1:] {
+			overlap := sharedPaths(dag.Nodes[a].Paths, dag.Nodes[b].Paths)
+			if len(overlap) == 0 || dagReaches(dag, a, b) || dagReaches(dag, b, a) {
+				continue
+			}
+			ra, rb := find(a), find(b)
+			if ra != rb {
+				group[rb] = ra
+			}
+			for _, p := range overlap {
+				if shared[a] == nil {
+					shared[a] = make(map[string]bool)
+				}
+				shared[a][p] = true
+			}
+		}
+	}
+
+	members := make(map[string][]string)
+	var roots []string
+	for _, id := range ids {
+		r := find(id)
+		if len(members[r]) == 0 {
+			roots = append(roots, r)
+		}
+		members[r] = append(members[r], id)
+	}
+
+	var findings []StagingFinding
+	for _, r := range roots {
+		beadIDs := members[r]
+		if len(beadIDs) < 2 {
+			continue
+		}
+		var files []string
+		inferred := false
+		for _, id := range beadIDs {
+			for p := range shared[id] {
+				files = append(files, p)
+			}
+			inferred = inferred || dag.Nodes[id].PathsInferred
+		}
+		files = dedupeSorted(files)
+		source := "declared paths"
+		if inferred {
+			source = "paths inferred from history"
+		}
+		findings = append(findings, StagingFinding{
+			Severity:     "warning",
+			Category:     "file-conflict",
+			BeadIDs:      beadIDs,
+			Message:      fmt.Sprintf("%d tasks are likely to edit the same files (%s: %s) — serialized into separate waves", len(beadIDs), source, strings.Join(files, ", ")),
+			SuggestedFix: "add blocks deps to choose the order, or split the shared file work into its own task",
+		})
+	}
+	return findings
+}
+
+// dagReaches reports whether from transitively blocks to via execution edges.
+func dagReaches(dag *ConvoyDAG, from, to string) bool {
+	seen := make(map[string]bool)
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		node := dag.Nodes[id]
+		if node == nil {
+			continue
+		}
+		for _, next := range node.Blocks {
+			if next == to {
+				return true
+			}
+			if !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}
+
+// WaveRisk estimates how likely a wave's tasks are to conflict at merge time.
+type WaveRisk struct {
+	Level      string   // low, medium, high, or unknown
+	SharedDirs []string // directories touched by more than one task
+	Unknown    []string // tasks with no path information
+}
+
+// estimateWaveRisk scores a wave from its tasks' paths. Overlapping files
+// are high risk; tasks sharing a directory are medium, or high when most
+// task pairs do. A wave with two or more tasks lacking path information and
+// nothing else to go on is unknown.
+func estimateWaveRisk(wave Wave, dag *ConvoyDAG) WaveRisk {
+	var risk WaveRisk
+	var known []*ConvoyDAGNode
+	for _, id := range wave.Tasks {
+		node := dag.Nodes[id]
+		if node == nil {
+			continue
+		}
+		if len(node.Paths) == 0 {
+			risk.Unknown = append(risk.Unknown, id)
+			continue
+		}
+		known = append(known, node)
+	}
+
+	pairs, dirPairs, filePairs := 0, 0, 0
+	dirs := make(map[string]bool)
+	for i, a := range known {
+		for _, b := range known[i+1:] {
+			pairs++
+			if len(sharedPaths(a.Paths, b.Paths)) > 0 {
+				filePairs++
+				continue
+			}
+			if common := sharedDirs(a.Paths, b.Paths); len(common) > 0 {
+				dirPairs++
+				for _, d := range common {
+					dirs[d] = true
+				}
+			}
+		}
+	}
+	for d := range dirs {
+		risk.SharedDirs = append(risk.SharedDirs, d)
+	}
+	sort.Strings(risk.SharedDirs)
+
+	switch {
+	case filePairs > 0 || (pairs > 0 && dirPairs*2 > pairs):
+		risk.Level = mergeRiskHigh
+	case dirPairs > 0:
+		risk.Level = mergeRiskMedium
+	case len(risk.Unknown) >= 2 || (len(risk.Unknown) == 1 && len(known) > 0):
+		risk.Level = mergeRiskUnknown
+	default:
+		risk.Level = mergeRiskLow
+	}
+	return risk
+}
+
+// sharedDirs returns the parent directories that both path sets touch.
+func sharedDirs(a, b []string) []string {
+	dirsOf := func(paths []string) map[string]bool {
+		m := make(map[string]bool)
+		for _, p := range paths {
+			d := globRoot(p)
+			if !isGlob(p) {
+				d = path.Dir(p)
+			}
+			if d != "." {
+				m[d] = true
+			}
+		}
+		return m
+	}
+	da, db := dirsOf(a), dirsOf(b)
+	var out []string
+	for d := range da {
+		if db[d] {
+			out = append(out, d)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// dagHasPaths reports whether any node carries path information.
+func dagHasPaths(dag *ConvoyDAG) bool {
+	for _, node := range dag.Nodes {
+		if len(node.Paths) > 0 {
+			return true
+		}
+	}
+	return false
+}
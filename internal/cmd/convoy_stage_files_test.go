@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+func TestDeclaredPaths(t *testing.T) {
+	got := declaredPaths(
+		[]string{"path:internal/router/router.go", "gt:task", "path:./docs/"},
+		"Fix routing.\n\nfiles: cmd/serve.go, internal/router/*.go\n",
+	)
+	want := []string{"cmd/serve.go", "docs", "internal/router/*.go", "internal/router/router.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("declaredPaths = %v, want %v", got, want)
+	}
+	if got := declaredPaths([]string{"component:router"}, "no paths here"); got != nil {
+		t.Errorf("declaredPaths without declarations = %v", got)
+	}
+}
+
+func TestPathsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"internal/router/router.go", "internal/router/router.go", true},
+		{"internal/router", "internal/router/router.go", true},
+		{"internal/router", "internal/routerx/a.go", false},
+		{"internal/router/*.go", "internal/router/router.go", true},
+		{"internal/router/*.go", "internal/router/README.md", false},
+		{"internal/**", "internal/router/deep/x.md", true},
+		{"internal/router/*.go", "internal", true},
+		{"internal/router/*.go", "internal/*/x.go", true},
+		{"cmd/*.go", "internal/*.go", false},
+	}
+	for _, tt := range tests {
+		if got := pathsOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("pathsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := pathsOverlap(tt.b, tt.a); got != tt.want {
+			t.Errorf("pathsOverlap(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestInferComponentPaths(t *testing.T) {
+	history := []git.CommitFiles{
+		{Subject: "router: add middleware", Files: []string{"internal/router/router.go", "internal/router/mw.go"}},
+		{Subject: "fix typo", Files: []string{"internal/router/router.go", "README.md"}},
+		{Subject: "Router(api): tidy", Files: []string{"internal/api/handlers.go"}},
+		{Subject: "unrelated", Files: []string{"cmd/main.go"}},
+	}
+	got := inferComponentPaths("router", history, 10)
+	want := []string{"internal/api/handlers.go", "internal/router/mw.go", "internal/router/router.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inferComponentPaths = %v, want %v", got, want)
+	}
+
+	// Limit keeps the most frequently touched files.
+	got = inferComponentPaths("router", history, 1)
+	if !reflect.DeepEqual(got, []string{"internal/router/router.go"}) {
+		t.Errorf("inferComponentPaths limit 1 = %v", got)
+	}
+}
+
+func TestBeadPathInfo_InfersFromComponentLabels(t *testing.T) {
+	orig := recentCommitFilesFn
+	defer func() { recentCommitFilesFn = orig }()
+	recentCommitFilesFn = func(string) []git.CommitFiles {
+		return []git.CommitFiles{{Subject: "router: x", Files: []string{"internal/router/router.go"}}}
+	}
+
+	paths, inferred := beadPathInfo("gt-1", []string{"component:Router"}, "")
+	if !inferred || !reflect.DeepEqual(paths, []string{"internal/router/router.go"}) {
+		t.Errorf("beadPathInfo = %v, %v", paths, inferred)
+	}
+
+	// Declared paths win over inference.
+	paths, inferred = beadPathInfo("gt-1", []string{"component:router", "path:api/x.go"}, "")
+	if inferred || !reflect.DeepEqual(paths, []string{"api/x.go"}) {
+		t.Errorf("beadPathInfo declared = %v, %v", paths, inferred)
+	}
+}
+
+func routerDAG() *ConvoyDAG {
+	router := []string{"internal/router/router.go"}
+	return &ConvoyDAG{Nodes: map[string]*ConvoyDAGNode{
+		"a": {ID: "a", Type: "task", Paths: router},
+		"b": {ID: "b", Type: "task", Paths: router},
+		"c": {ID: "c", Type: "task", Paths: []string{"internal/router/*.go"}},
+		"d": {ID: "d", Type: "task", Paths: []string{"docs/router.md"}},
+		"e": {ID: "e", Type: "task"},
+	}}
+}
+
+func TestComputeWaves_SerializesFileConflicts(t *testing.T) {
+	waves, _, err := computeWaves(routerDAG())
+	if err != nil {
+		t.Fatalf("computeWaves: %v", err)
+	}
+	want := [][]string{{"a", "d", "e"}, {"b"}, {"c"}}
+	if len(waves) != len(want) {
+		t.Fatalf("got %d waves, want %d: %+v", len(waves), len(want), waves)
+	}
+	for i, w := range waves {
+		if !reflect.DeepEqual(w.Tasks, want[i]) {
+			t.Errorf("wave %d = %v, want %v", w.Number, w.Tasks, want[i])
+		}
+	}
+}
+
+func TestComputeWaves_DeferredTaskKeepsDependentsOrdered(t *testing.T) {
+	// b conflicts with a and is deferred; c depends on b and must follow it.
+	dag := &ConvoyDAG{Nodes: map[string]*ConvoyDAGNode{
+		"a": {ID: "a", Type: "task", Paths: []string{"x.go"}},
+		"b": {ID: "b", Type: "task", Paths: []string{"x.go"}, Blocks: []string{"c"}},
+		"c": {ID: "c", Type: "task", BlockedBy: []string{"b"}},
+	}}
+	waves, _, err := computeWaves(dag)
+	if err != nil {
+		t.Fatalf("computeWaves: %v", err)
+	}
+	if waveOf(waves, "a") != 1 || waveOf(waves, "b") != 2 || waveOf(waves, "c") != 3 {
+		t.Errorf("waves = %+v", waves)
+	}
+}
+
+func TestDetectFileConflicts(t *testing.T) {
+	findings := detectFileConflicts(routerDAG())
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.Category != "file-conflict" || !reflect.DeepEqual(f.BeadIDs, []string{"a", "b", "c"}) {
+		t.Errorf("finding = %+v", f)
+	}
+	if !strings.Contains(f.Message, "internal/router/router.go") {
+		t.Errorf("message missing shared file: %s", f.Message)
+	}
+}
+
+func TestDetectFileConflicts_IgnoresOrderedPairs(t *testing.T) {
+	dag := &ConvoyDAG{Nodes: map[string]*ConvoyDAGNode{
+		"a": {ID: "a", Type: "task", Paths: []string{"x.go"}, Blocks: []string{"b"}},
+		"b": {ID: "b", Type: "task", Paths: []string{"x.go"}, BlockedBy: []string{"a"}, Blocks: []string{"c"}},
+		"c": {ID: "c", Type: "task", Paths: []string{"x.go"}, BlockedBy: []string{"b"}},
+	}}
+	if findings := detectFileConflicts(dag); len(findings) != 0 {
+		t.Errorf("dependency-ordered tasks should not warn: %+v", findings)
+	}
+}
+
+func TestEstimateWaveRisk(t *testing.T) {
+	dag := &ConvoyDAG{Nodes: map[string]*ConvoyDAGNode{
+		"a": {ID: "a", Paths: []string{"internal/router/a.go"}},
+		"b": {ID: "b", Paths: []string{"internal/router/b.go"}},
+		"c": {ID: "c", Paths: []string{"cmd/c.go"}},
+		"d": {ID: "d", Paths: []string{"docs/d.md"}},
+		"x": {ID: "x"},
+		"y": {ID: "y"},
+	}}
+	tests := []struct {
+		tasks []string
+		want  string
+	}{
+		{[]string{"a"}, mergeRiskLow},
+		{[]string{"a", "c", "d"}, mergeRiskLow},
+		{[]string{"a", "b"}, mergeRiskHigh},
+		{[]string{"a", "b", "c", "d"}, mergeRiskMedium},
+		{[]string{"x", "y"}, mergeRiskUnknown},
+		{[]string{"c", "x"}, mergeRiskUnknown},
+	}
+	for _, tt := range tests {
+		risk := estimateWaveRisk(Wave{Number: 1, Tasks: tt.tasks}, dag)
+		if risk.Level != tt.want {
+			t.Errorf("estimateWaveRisk(%v) = %s, want %s", tt.tasks, risk.Level, tt.want)
+		}
+	}
+	risk := estimateWaveRisk(Wave{Tasks: []string{"a", "b", "c", "d"}}, dag)
+	if !reflect.DeepEqual(risk.SharedDirs, []string{"internal/router"}) {
+		t.Errorf("SharedDirs = %v", risk.SharedDirs)
+	}
+}
+
+func TestBuildWavesJSON_MergeRisk(t *testing.T) {
+	dag := routerDAG()
+	waves, _, err := computeWaves(dag)
+	if err != nil {
+		t.Fatalf("computeWaves: %v", err)
+	}
+	out := buildWavesJSON(waves, dag)
+	if out[0].MergeRisk == nil || out[0].MergeRisk.Level != mergeRiskUnknown {
+		t.Errorf("wave 1 merge risk = %+v", out[0].MergeRisk)
+	}
+	data, err := json.Marshal(out[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"paths":["internal/router/router.go"]`) {
+		t.Errorf("wave 2 JSON missing paths: %s", data)
+	}
+
+	// No path information anywhere: no merge_risk field.
+	plain := &ConvoyDAG{Nodes: map[string]*ConvoyDAGNode{"a": {ID: "a", Type: "task"}}}
+	waves, _, _ = computeWaves(plain)
+	if got := buildWavesJSON(waves, plain); got[0].MergeRisk != nil {
+		t.Errorf("unexpected merge risk without paths: %+v", got[0].MergeRisk)
+	}
+}
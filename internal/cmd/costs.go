@@ -954,6 +954,12 @@ func getCostsLogPath() string {
 // This is called by the Claude Code Stop hook. It's designed to never fail due to
 // database availability - it's a simple file append operation.
 func runCostsRecord(cmd *cobra.Command, args []string) error {
+	// Worker-mode runs record their cost from the agent's event stream
+	// (gt worker run); recording again from the transcript would double count.
+	if os.Getenv("GT_WORKER") != "" {
+		return nil
+	}
+
 	// Get session from flag or try to detect from environment
 	session := recordSession
 	if session == "" {
@@ -1016,6 +1022,24 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 		WorkItem:  recordWorkItem,
 	}
 
+	if err := appendCostLogEntry(entry); err != nil {
+		return err
+	}
+
+	// Output confirmation (silent if cost is zero and no work item)
+	if cost > 0 || recordWorkItem != "" {
+		fmt.Printf("%s Recorded $%.2f for %s", style.Success.Render("✓"), cost, session)
+		if recordWorkItem != "" {
+			fmt.Printf(" (work: %s)", recordWorkItem)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// appendCostLogEntry appends one entry to the costs log file.
+func appendCostLogEntry(entry CostLogEntry) error {
 	// Marshal to JSON
 	entryJSON, err := json.Marshal(entry)
 	if err != nil {
@@ -1044,16 +1068,6 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 	if _, err := f.Write(append(entryJSON, '\n')); err != nil {
 		return fmt.Errorf("writing to costs log: %w", err)
 	}
-
-	// Output confirmation (silent if cost is zero and no work item)
-	if cost > 0 || recordWorkItem != "" {
-		fmt.Printf("%s Recorded $%.2f for %s", style.Success.Render("✓"), cost, session)
-		if recordWorkItem != "" {
-			fmt.Printf(" (work: %s)", recordWorkItem)
-		}
-		fmt.Println()
-	}
-
 	return nil
 }
 
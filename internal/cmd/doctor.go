@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/doctor"
+	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -17,6 +19,9 @@ var (
 	doctorRestartSessions bool
 	doctorNoStart         bool
 	doctorSlow            string
+	doctorFormat          string
+	doctorPlan            bool
+	doctorRollback        string
 )
 
 var doctorCmd = &cobra.Command{
@@ -46,6 +51,7 @@ Infrastructure checks:
   - daemon                   Check if daemon is running (fixable)
   - boot-health              Check Boot watchdog health (vet mode)
   - town-beads-config        Verify town .beads/config.yaml exists (fixable)
+  - sandbox                  Check polecat sandbox drivers are configured and available
 
 Cleanup checks (fixable):
   - orphan-sessions          Detect orphaned tmux sessions
@@ -111,7 +117,22 @@ Patrol checks:
 Use --fix to attempt automatic fixes for issues that support it.
 Use --no-start with --fix to suppress starting the daemon and agents.
 Use --rig to check a specific rig instead of the entire workspace.
-Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).`,
+Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).
+
+Machine-readable output:
+  --format json              One JSON document with every check result
+  --format junit             JUnit XML (one test suite per category) for CI
+
+Fix plan and rollback:
+  --plan                     List every fix --fix would apply and the files,
+                             beads and other side effects each one touches
+  --rollback <run-id>        Restore the files changed by a previous --fix run
+                             ("latest" for the most recent run)
+
+Every --fix run records a journal under .runtime/doctor/journal/<run-id>.
+Files declared by a check's fix plan are snapshotted before the fix runs.
+Rollback restores them unless they were edited again after the fix. Fixes
+that touch beads, sessions or undeclared files are reported but not undone.`,
 	RunE: runDoctor,
 }
 
@@ -124,16 +145,35 @@ func init() {
 	doctorCmd.Flags().StringVar(&doctorSlow, "slow", "", "Highlight slow checks (optional threshold, default 1s)")
 	// Allow --slow without a value (uses default 1s)
 	doctorCmd.Flags().Lookup("slow").NoOptDefVal = "1s"
+	doctorCmd.Flags().StringVar(&doctorFormat, "format", doctor.FormatText, "Output format: text, json, or junit")
+	doctorCmd.Flags().BoolVar(&doctorPlan, "plan", false, "Show the fixes --fix would apply without applying them")
+	doctorCmd.Flags().StringVar(&doctorRollback, "rollback", "", "Undo the file changes of a --fix run (run id or \"latest\")")
 	rootCmd.AddCommand(doctorCmd)
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
+	switch doctorFormat {
+	case doctor.FormatText, doctor.FormatJSON, doctor.FormatJUnit:
+	default:
+		return fmt.Errorf("invalid --format %q (want text, json, or junit)", doctorFormat)
+	}
+	if doctorPlan && doctorFix {
+		return fmt.Errorf("--plan and --fix are mutually exclusive")
+	}
+	if doctorRollback != "" && (doctorPlan || doctorFix) {
+		return fmt.Errorf("--rollback cannot be combined with --fix or --plan")
+	}
+
 	// Find town root
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
+	if doctorRollback != "" {
+		return runDoctorRollback(townRoot, doctorRollback)
+	}
+
 	// Create check context
 	ctx := &doctor.CheckContext{
 		TownRoot:        townRoot,
@@ -218,11 +258,13 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewSettingsCheck())
 	d.Register(doctor.NewSessionHookCheck())
 	d.Register(doctor.NewRuntimeGitignoreCheck())
+	d.Register(doctor.NewSecretsGitignoreCheck())
 	d.Register(doctor.NewLegacyGastownCheck())
 	// NOTE: ClaudeSettingsCheck moved before DaemonCheck (gt-99u race fix)
 	d.Register(doctor.NewDeprecatedMergeQueueKeysCheck())
 	d.Register(doctor.NewLandWorktreeGitignoreCheck())
 	d.Register(doctor.NewHooksPathAllRigsCheck())
+	d.Register(doctor.NewSandboxCheck())
 
 	// Sparse checkout migration (runs across all rigs, not just --rig mode)
 	d.Register(doctor.NewSparseCheckoutCheck())
@@ -275,17 +317,43 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Run checks with streaming output
-	fmt.Println() // Initial blank line
-	var report *doctor.Report
+	var journal *doctor.Journal
 	if doctorFix {
-		report = d.FixStreaming(ctx, os.Stdout, slowThreshold)
-	} else {
-		report = d.RunStreaming(ctx, os.Stdout, slowThreshold)
+		journal = doctor.NewJournal(townRoot)
+		d.SetJournal(journal)
 	}
 
-	// Print summary (checks were already printed during streaming)
-	report.PrintSummaryOnly(os.Stdout, doctorVerbose, slowThreshold)
+	var report *doctor.Report
+	if doctorFormat != doctor.FormatText {
+		report, err = runDoctorMachineReadable(d, ctx, journal)
+		if err != nil {
+			return err
+		}
+	} else if doctorPlan {
+		var plans []*doctor.FixPlan
+		report, plans = d.Plan(ctx)
+		report.PrintSummaryOnly(os.Stdout, doctorVerbose, slowThreshold)
+		doctor.PrintPlan(os.Stdout, plans)
+	} else {
+		// Run checks with streaming output
+		fmt.Println() // Initial blank line
+		if doctorFix {
+			report = d.FixStreaming(ctx, os.Stdout, slowThreshold)
+		} else {
+			report = d.RunStreaming(ctx, os.Stdout, slowThreshold)
+		}
+
+		// Print summary (checks were already printed during streaming)
+		report.PrintSummaryOnly(os.Stdout, doctorVerbose, slowThreshold)
+
+		if journal != nil && len(journal.Entries) > 0 {
+			fmt.Printf("\nFix journal: %s", journal.RunID)
+			if journal.Changed() {
+				fmt.Printf(" (undo file changes with: gt doctor --rollback %s)", journal.RunID)
+			}
+			fmt.Println()
+		}
+	}
 
 	// Exit with error code if there are errors
 	if report.HasErrors() {
@@ -294,3 +362,84 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runDoctorMachineReadable runs the checks (or fixes, or plan) and writes the
+// report to stdout as JSON or JUnit. Fixes print progress with fmt.Printf, so
+// stdout is pointed at stderr while they run to keep the document parseable.
+func runDoctorMachineReadable(d *doctor.Doctor, ctx *doctor.CheckContext, journal *doctor.Journal) (*doctor.Report, error) {
+	out := os.Stdout
+	os.Stdout = os.Stderr
+	var report *doctor.Report
+	var plans []*doctor.FixPlan
+	switch {
+	case doctorPlan:
+		report, plans = d.Plan(ctx)
+	case doctorFix:
+		report = d.Fix(ctx)
+	default:
+		report = d.Run(ctx)
+	}
+	os.Stdout = out
+
+	if doctorFormat == doctor.FormatJUnit {
+		return report, report.WriteJUnit(out)
+	}
+	runID := ""
+	if journal != nil && len(journal.Entries) > 0 {
+		runID = journal.RunID
+	}
+	return report, report.WriteJSON(out, plans, runID)
+}
+
+// runDoctorRollback undoes the file changes recorded by a --fix run.
+func runDoctorRollback(townRoot, runID string) error {
+	journals, err := doctor.ListJournals(townRoot)
+	if err != nil {
+		return fmt.Errorf("listing fix journals: %w", err)
+	}
+	if runID == "latest" {
+		if len(journals) == 0 {
+			return fmt.Errorf("no doctor --fix runs recorded")
+		}
+		runID = journals[0].RunID
+	}
+
+	result, err := doctor.Rollback(townRoot, runID)
+	if err != nil {
+		if result == nil && !errors.Is(err, doctor.ErrAlreadyRolledBack) && len(journals) > 0 {
+			fmt.Fprintf(os.Stderr, "Recent fix runs:\n")
+			for i, j := range journals {
+				if i == 10 {
+					break
+				}
+				state := ""
+				if j.RolledBackAt != nil {
+					state = " (rolled back)"
+				}
+				fmt.Fprintf(os.Stderr, "  %s  %d fix(es)%s\n", j.RunID, len(j.Entries), state)
+			}
+		}
+		return err
+	}
+
+	fmt.Printf("%s Rolled back doctor fix run %s\n", style.Success.Render("✓"), runID)
+	for _, path := range result.Restored {
+		fmt.Printf("  restored  %s\n", path)
+	}
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("\n%s %d file(s) changed since the fix; left as they are:\n", style.Warning.Render("⚠"), len(result.Conflicts))
+		for _, path := range result.Conflicts {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+	if len(result.Irreversible) > 0 {
+		fmt.Printf("\n%s Not undone (no file-level record):\n", style.Warning.Render("⚠"))
+		for _, what := range result.Irreversible {
+			fmt.Printf("  %s\n", what)
+		}
+	}
+	if len(result.Restored) == 0 && len(result.Conflicts) == 0 {
+		fmt.Println("  No file changes to restore")
+	}
+	return nil
+}
@@ -37,6 +37,12 @@ var (
 	dogDispatchJSON   bool
 	dogDispatchDryRun bool
 
+	// Queue flags (dispatch uses these when no dog is idle)
+	dogQueuePriority    int
+	dogQueueDeadline    time.Duration
+	dogQueueMaxAttempts int
+	dogQueueJSON        bool
+
 	// Health-check flags
 	dogHealthJSON          bool
 	dogHealthAutoClear     bool
@@ -213,6 +219,10 @@ The command:
 3. Sends mail with plugin instructions to the dog
 4. Returns immediately (non-blocking)
 
+If every dog is busy (and --create is not given), the plugin is added to
+the dog job queue instead. The daemon hands queued jobs to dogs as they
+free up, highest --priority first. See 'gt dog queue'.
+
 The dog discovers the work via its mail inbox and executes the plugin
 instructions. On completion, the dog sends DOG_DONE mail to deacon/.
 
@@ -222,10 +232,48 @@ Examples:
   gt dog dispatch --plugin rebuild-gt --dog alpha
   gt dog dispatch --plugin rebuild-gt --create
   gt dog dispatch --plugin rebuild-gt --dry-run
+  gt dog dispatch --plugin rebuild-gt --priority 10 --deadline 1h
   gt dog dispatch --plugin rebuild-gt --json`,
 	RunE: runDogDispatch,
 }
 
+var dogQueueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Show the dog job queue",
+	Long: `Show work waiting for a dog, running on one, or dead-lettered.
+
+Work dispatched while every dog is busy waits in the queue
+(deacon/dog-queue.json). The daemon drains it on each heartbeat, handing
+the highest-priority ready job to each idle dog.
+
+A job whose dog dies or gets stuck is retried with exponential backoff
+(1m, 2m, 4m, ... up to 30m). Once it has used all its attempts, or its
+deadline passes, it is dead-lettered and stays in the queue until retried
+or dropped.
+
+Examples:
+  gt dog queue
+  gt dog queue --json
+  gt dog queue retry dj-4
+  gt dog queue drop dj-4`,
+	Args: cobra.NoArgs,
+	RunE: runDogQueue,
+}
+
+var dogQueueRetryCmd = &cobra.Command{
+	Use:   "retry <job-id>",
+	Short: "Requeue a dead-lettered job",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDogQueueRetry,
+}
+
+var dogQueueDropCmd = &cobra.Command{
+	Use:   "drop <job-id>",
+	Short: "Remove a job from the queue",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDogQueueDrop,
+}
+
 var dogHealthCheckCmd = &cobra.Command{
 	Use:   "health-check [name]",
 	Short: "Check dog health (zombies, hung, orphans)",
@@ -278,8 +326,14 @@ func init() {
 	dogDispatchCmd.Flags().BoolVar(&dogDispatchCreate, "create", false, "Create a dog if none idle")
 	dogDispatchCmd.Flags().BoolVar(&dogDispatchJSON, "json", false, "Output as JSON")
 	dogDispatchCmd.Flags().BoolVarP(&dogDispatchDryRun, "dry-run", "n", false, "Show what would be done without doing it")
+	dogDispatchCmd.Flags().IntVar(&dogQueuePriority, "priority", 0, "Queue priority if no dog is idle (higher runs first)")
+	dogDispatchCmd.Flags().DurationVar(&dogQueueDeadline, "deadline", 0, "Dead-letter the queued job if not dispatched within this long")
+	dogDispatchCmd.Flags().IntVar(&dogQueueMaxAttempts, "max-attempts", dog.DefaultMaxAttempts, "Dispatch attempts before the queued job is dead-lettered")
 	_ = dogDispatchCmd.MarkFlagRequired("plugin")
 
+	// Queue flags
+	dogQueueCmd.Flags().BoolVar(&dogQueueJSON, "json", false, "Output as JSON")
+
 	// Health-check flags
 	dogHealthCheckCmd.Flags().BoolVar(&dogHealthJSON, "json", false, "Output as JSON")
 	dogHealthCheckCmd.Flags().BoolVar(&dogHealthAutoClear, "auto-clear", false, "Auto-clear zombie dogs")
@@ -295,6 +349,9 @@ func init() {
 	dogCmd.AddCommand(dogStatusCmd)
 	dogCmd.AddCommand(dogDispatchCmd)
 	dogCmd.AddCommand(dogHealthCheckCmd)
+	dogQueueCmd.AddCommand(dogQueueRetryCmd)
+	dogQueueCmd.AddCommand(dogQueueDropCmd)
+	dogCmd.AddCommand(dogQueueCmd)
 
 	rootCmd.AddCommand(dogCmd)
 }
@@ -627,6 +684,12 @@ func runDogClear(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("clearing work for dog %s: %w", name, err)
 	}
 
+	// An operator clear is not a failed attempt: forget the job rather than
+	// retrying it.
+	if err := mgr.Queue().Complete(name); err != nil {
+		style.PrintWarning("could not remove queued job for dog %s: %v", name, err)
+	}
+
 	fmt.Printf("✓ Cleared dog %s (now idle)\n", name)
 	if d.Work != "" {
 		fmt.Printf("  Previous work: %s\n", d.Work)
@@ -679,6 +742,10 @@ func runDogDone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("clearing work for dog %s: %w", name, err)
 	}
 
+	if err := mgr.Queue().Complete(name); err != nil {
+		style.PrintWarning("could not complete queued job for dog %s: %v", name, err)
+	}
+
 	fmt.Printf("✓ Dog %s returned to kennel (idle)\n", name)
 
 	// Auto-terminate the tmux session after a short delay.
@@ -1030,7 +1097,7 @@ func runDogDispatch(cmd *cobra.Command, args []string) error {
 					}
 				}
 			} else {
-				return fmt.Errorf("no idle dogs available (use --create to add one)")
+				return queuePluginDispatch(mgr.Queue(), p)
 			}
 		}
 	}
@@ -1139,12 +1206,145 @@ type dogDispatchResult struct {
 	Plugin     string `json:"plugin"`
 	PluginRig  string `json:"plugin_rig,omitempty"`
 	PluginPath string `json:"plugin_path"`
-	Dog        string `json:"dog"`
+	Dog        string `json:"dog,omitempty"`
 	DogCreated bool   `json:"dog_created,omitempty"`
 	Work       string `json:"work"`
+	QueuedJob  string `json:"queued_job,omitempty"` // Set when no dog was idle and the work was queued
 	DryRun     bool   `json:"dry_run,omitempty"`
 }
 
+// queuePluginDispatch adds a plugin to the dog job queue because no dog is
+// idle. The daemon dispatches it when one frees up.
+func queuePluginDispatch(queue *dog.Queue, p *plugin.Plugin) error {
+	result := dogDispatchResult{
+		Plugin:     p.Name,
+		PluginRig:  p.RigName,
+		PluginPath: p.Path,
+		Work:       fmt.Sprintf("plugin:%s", p.Name),
+		DryRun:     dogDispatchDryRun,
+	}
+
+	if dogDispatchDryRun {
+		if dogDispatchJSON {
+			return json.NewEncoder(os.Stdout).Encode(result)
+		}
+		fmt.Printf("Dry run - no idle dogs, would queue:\n")
+		fmt.Printf("  Plugin: %s\n", p.Name)
+		fmt.Printf("  Work: %s\n", result.Work)
+		fmt.Printf("  Priority: %d\n", dogQueuePriority)
+		return nil
+	}
+
+	job := dog.Job{
+		Work:        result.Work,
+		Subject:     fmt.Sprintf("Plugin: %s", p.Name),
+		Body:        p.FormatMailBody(),
+		Priority:    dogQueuePriority,
+		MaxAttempts: dogQueueMaxAttempts,
+	}
+	if dogQueueDeadline > 0 {
+		job.Deadline = time.Now().Add(dogQueueDeadline)
+	}
+	queued, err := queue.Enqueue(job)
+	if err != nil {
+		return fmt.Errorf("queueing plugin %s: %w", p.Name, err)
+	}
+	result.QueuedJob = queued.ID
+
+	if dogDispatchJSON {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	fmt.Printf("%s Found plugin: %s\n", style.Bold.Render("✓"), p.Name)
+	if queued.State == dog.JobDead {
+		fmt.Printf("%s No idle dogs - %s is dead-lettered (%s)\n", style.Bold.Render("⚠"), queued.ID, queued.LastError)
+		fmt.Printf("  Work: %s\n", queued.Work)
+		fmt.Printf("  Run 'gt dog queue retry %s' to queue it again.\n", queued.ID)
+		return nil
+	}
+	fmt.Printf("%s No idle dogs - queued as %s (%s)\n", style.Bold.Render("⏳"), queued.ID, queued.State)
+	fmt.Printf("  Work: %s\n", queued.Work)
+	fmt.Printf("  The daemon dispatches it when a dog frees up. See 'gt dog queue'.\n")
+	return nil
+}
+
+func runDogQueue(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	jobs, err := dog.NewQueue(townRoot).List()
+	if err != nil {
+		return err
+	}
+
+	if dogQueueJSON {
+		if jobs == nil {
+			jobs = []*dog.Job{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(jobs)
+	}
+
+	if len(jobs) == 0 {
+		fmt.Println("Dog job queue is empty")
+		return nil
+	}
+
+	now := time.Now()
+	fmt.Printf("%s\n\n", style.Bold.Render("Dog Job Queue"))
+	for _, j := range jobs {
+		fmt.Printf("  %s  %-8s  p%-3d %s\n", j.ID, j.State, j.Priority, j.Work)
+		var details []string
+		switch j.State {
+		case dog.JobRunning:
+			details = append(details, fmt.Sprintf("dog %s", j.Dog))
+		case dog.JobQueued:
+			if j.NextAttemptAt.After(now) {
+				details = append(details, fmt.Sprintf("retry in %s", j.NextAttemptAt.Sub(now).Truncate(time.Second)))
+			}
+		}
+		details = append(details, fmt.Sprintf("attempt %d/%d", j.Attempts, j.MaxAttempts))
+		if !j.Deadline.IsZero() {
+			details = append(details, fmt.Sprintf("deadline %s", j.Deadline.Format("15:04")))
+		}
+		details = append(details, fmt.Sprintf("queued %s", dogFormatTimeAgo(j.EnqueuedAt)))
+		fmt.Printf("    %s\n", style.Dim.Render(strings.Join(details, ", ")))
+		if j.LastError != "" {
+			fmt.Printf("    %s\n", style.Dim.Render("last error: "+j.LastError))
+		}
+	}
+	return nil
+}
+
+func runDogQueueRetry(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	job, err := dog.NewQueue(townRoot).Retry(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Requeued %s (%s)\n", job.ID, job.Work)
+	return nil
+}
+
+func runDogQueueDrop(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	if err := dog.NewQueue(townRoot).Drop(args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Dropped %s from the dog queue\n", args[0])
+	return nil
+}
+
 // ifStr returns ifTrue if cond is true, otherwise ifFalse.
 func ifStr(cond bool, ifTrue, ifFalse string) string {
 	if cond {
@@ -330,7 +330,8 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 	var pushFailed bool
 	var mrFailed bool
 	var doneErrors []string
-	var convoyInfo *ConvoyInfo // Populated if issue is tracked by a convoy
+	var convoyInfo *ConvoyInfo      // Populated if issue is tracked by a convoy
+	var memberWork []memberWorktree // Member repo worktrees with commits to land
 	if exitType == ExitCompleted {
 		if branch == defaultBranch || branch == "master" {
 			return fmt.Errorf("cannot submit %s/master branch to merge queue", defaultBranch)
@@ -357,6 +358,22 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 			return fmt.Errorf("cannot complete: uncommitted changes would be lost\nCommit your changes first, or use --status DEFERRED to exit without completing\nUncommitted: %s", workStatus.String())
 		}
 
+		// Multi-repo rigs: member worktrees must be clean too, and the ones
+		// with commits travel with the MR on the same branch name.
+		for _, w := range polecatMemberWorktrees(townRoot, rigName, cwd) {
+			mg := git.NewGit(w.Path)
+			memberStatus, err := mg.CheckUncommittedWork()
+			if err != nil {
+				return fmt.Errorf("checking git status of %s: %w", w.Repo.Name, err)
+			}
+			if memberStatus.HasUncommittedChanges {
+				return fmt.Errorf("cannot complete: uncommitted changes in repo %s would be lost\nCommit your changes first, or use --status DEFERRED to exit without completing\nUncommitted: %s", w.Repo.Name, memberStatus.String())
+			}
+			if ahead, err := mg.CommitsAhead("origin/"+w.Repo.Branch(), "HEAD"); err == nil && ahead > 0 {
+				memberWork = append(memberWork, w)
+			}
+		}
+
 		// Check if branch has commits ahead of origin/default
 		// If not, work may have been pushed directly to main - that's fine, just skip MR
 		originDefault := "origin/" + defaultBranch
@@ -378,7 +395,7 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 		// tasks (audits, reviews) that the formula explicitly directs to use it.
 		// IMPORTANT: The error message must NOT mention --cleanup-status=clean.
 		// LLM agents read error messages and self-bypass (the original bug).
-		if aheadCount == 0 {
+		if aheadCount == 0 && len(memberWork) == 0 {
 			if os.Getenv("GT_POLECAT") != "" && doneCleanupStatus != "clean" {
 				return fmt.Errorf("cannot complete: no commits on branch ahead of %s\n"+
 					"Polecats must have at least 1 commit to submit.\n"+
@@ -595,6 +612,17 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 		}
 		fmt.Printf("%s Branch pushed to origin\n", style.Bold.Render("✓"))
 
+		for _, w := range memberWork {
+			if pushErr = pushMemberBranch(townRoot, rigName, w, branch); pushErr != nil {
+				pushFailed = true
+				errMsg := fmt.Sprintf("push failed for branch '%s' in repo %s: %v", branch, w.Repo.Name, pushErr)
+				doneErrors = append(doneErrors, errMsg)
+				style.PrintWarning("%s\nCommits exist locally but failed to push. Witness will be notified.", errMsg)
+				goto notifyWitness
+			}
+			fmt.Printf("%s Branch pushed to origin in repo %s\n", style.Bold.Render("✓"), w.Repo.Name)
+		}
+
 		// Fix cleanup_status after successful push (gt-wcr).
 		// Status was detected before push, so "unpushed" is now stale.
 		if doneCleanupStatus == "unpushed" {
@@ -759,6 +787,14 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 				description += fmt.Sprintf("\nagent_bead: %s", agentBeadID)
 			}
 
+			if len(memberWork) > 0 {
+				repoBranches := make([]beads.RepoBranch, 0, len(memberWork))
+				for _, w := range memberWork {
+					repoBranches = append(repoBranches, beads.RepoBranch{Repo: w.Repo.Name, Branch: branch})
+				}
+				description += fmt.Sprintf("\nrepo_branches: %s", beads.FormatRepoBranches(repoBranches))
+			}
+
 			// Add conflict resolution tracking fields (initialized, updated by Refinery)
 			description += "\nretry_count: 0"
 			description += "\nlast_conflict_sha: null"
@@ -1393,3 +1429,43 @@ func selfKillSession(townRoot string, roleInfo RoleInfo) error {
 
 	return nil
 }
+
+// memberWorktree is a polecat's worktree of one of the rig's member repos.
+type memberWorktree struct {
+	Repo rig.MemberRepo
+	Path string
+}
+
+// polecatMemberWorktrees returns the member repo worktrees that sit next to
+// a polecat's primary worktree (polecats/<name>/<rig>). Other layouts (crew,
+// mayor, old-style polecat clones) have no member worktrees.
+func polecatMemberWorktrees(townRoot, rigName, primary string) []memberWorktree {
+	if filepath.Base(primary) != rigName || !strings.Contains(primary, "/polecats/") {
+		return nil
+	}
+	polecatDir := filepath.Dir(primary)
+	var worktrees []memberWorktree
+	for _, repo := range (&rig.Rig{Path: filepath.Join(townRoot, rigName)}).MemberRepos() {
+		path := filepath.Join(polecatDir, repo.Name)
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			worktrees = append(worktrees, memberWorktree{Repo: repo, Path: path})
+		}
+	}
+	return worktrees
+}
+
+// pushMemberBranch pushes branch from a member worktree, falling back to the
+// member's shared bare repo like the primary push does.
+func pushMemberBranch(townRoot, rigName string, w memberWorktree, branch string) error {
+	refspec := branch + ":" + branch
+	err := git.NewGit(w.Path).Push("origin", refspec, false)
+	if err == nil {
+		return nil
+	}
+	style.PrintWarning("%s push failed: %v — trying bare repo fallback...", w.Repo.Name, err)
+	barePath := rig.MemberBarePath(filepath.Join(townRoot, rigName), w.Repo.Name)
+	if _, statErr := os.Stat(barePath); statErr != nil {
+		return err
+	}
+	return git.NewGitWithDir(barePath, "").Push("origin", refspec, false)
+}
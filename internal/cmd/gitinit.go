@@ -68,6 +68,7 @@ const HQGitignore = `# Gas Town HQ .gitignore
 **/heartbeat.json
 **/activity.json
 .events.jsonl
+.krc-archive/
 .feed.jsonl
 **/audit.log
 **/last-touched
@@ -90,6 +91,11 @@ logs/
 **/.dolt/
 **/.doltcfg/
 
+# =============================================================================
+# Secrets written by the file backend (API keys - never commit)
+# =============================================================================
+mayor/secrets/
+
 # =============================================================================
 # Event stream storage
 # =============================================================================
@@ -216,6 +222,15 @@ func createGitignore(path string) error {
 
 		// Check if it already has Gas Town section
 		if strings.Contains(string(content), "Gas Town HQ") {
+			// Older HQ .gitignores predate the file secret backend.
+			if !strings.Contains(string(content), "mayor/secrets/") {
+				combined := strings.TrimRight(string(content), "\n") + "\n\n# Secrets written by the file backend (API keys - never commit)\nmayor/secrets/\n"
+				if err := os.WriteFile(path, []byte(combined), 0644); err != nil {
+					return fmt.Errorf("updating .gitignore: %w", err)
+				}
+				fmt.Printf("   ✓ Added mayor/secrets/ to .gitignore\n")
+				return nil
+			}
 			fmt.Printf("   ✓ .gitignore already configured for Gas Town\n")
 			return nil
 		}
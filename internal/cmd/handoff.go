@@ -15,9 +15,11 @@ import (
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 	"github.com/steveyegge/gastown/internal/ui"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -50,6 +52,11 @@ The --collect (-c) flag gathers current state (hooked work, inbox, ready beads,
 in-progress items) and includes it in the handoff mail. This provides context
 for the next session without manual summarization.
 
+Handoff mail also carries a summary of the outgoing session's transcript:
+files it changed, commands it ran and which failed, open questions, and its
+last plan. gt prime shows it to the successor so ruled-out approaches are not
+retried. Use --no-transcript to leave it out.
+
 The --cycle flag triggers automatic session cycling (used by PreCompact hooks).
 Unlike --auto (state only) or normal handoff (polecat→gt-done redirect), --cycle
 always does a full respawn regardless of role. This enables crew workers and
@@ -61,16 +68,17 @@ The SessionStart hook runs 'gt prime' to restore context.`,
 }
 
 var (
-	handoffWatch      bool
-	handoffDryRun     bool
-	handoffSubject    string
-	handoffMessage    string
-	handoffCollect    bool
-	handoffStdin      bool
-	handoffAuto       bool
-	handoffCycle      bool
-	handoffReason     string
-	handoffNoGitCheck bool
+	handoffWatch        bool
+	handoffDryRun       bool
+	handoffSubject      string
+	handoffMessage      string
+	handoffCollect      bool
+	handoffStdin        bool
+	handoffAuto         bool
+	handoffCycle        bool
+	handoffReason       string
+	handoffNoGitCheck   bool
+	handoffNoTranscript bool
 )
 
 func init() {
@@ -84,6 +92,7 @@ func init() {
 	handoffCmd.Flags().BoolVar(&handoffCycle, "cycle", false, "Auto-cycle session (for PreCompact hooks that want full session replacement)")
 	handoffCmd.Flags().StringVar(&handoffReason, "reason", "", "Reason for handoff (e.g., 'compaction', 'idle')")
 	handoffCmd.Flags().BoolVar(&handoffNoGitCheck, "no-git-check", false, "Skip git workspace cleanliness check")
+	handoffCmd.Flags().BoolVar(&handoffNoTranscript, "no-transcript", false, "Don't attach a summary of this session's transcript to the handoff mail")
 	rootCmd.AddCommand(handoffCmd)
 }
 
@@ -296,12 +305,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	// Write handoff marker for successor detection (prevents handoff loop bug).
 	// The marker is cleared by gt prime after it outputs the warning.
 	// This tells the new session "you're post-handoff, don't re-run /handoff"
-	if cwd, err := os.Getwd(); err == nil {
-		runtimeDir := filepath.Join(cwd, constants.DirRuntime)
-		_ = os.MkdirAll(runtimeDir, 0755)
-		markerPath := filepath.Join(runtimeDir, constants.FileHandoffMarker)
-		_ = os.WriteFile(markerPath, []byte(currentSession), 0644)
-	}
+	writeHandoffMarker(currentSession, "", beadID)
 
 	// Set remain-on-exit so the pane survives process death during handoff.
 	// Without this, killing processes causes tmux to destroy the pane before
@@ -375,18 +379,13 @@ func runHandoffAuto() error {
 	}
 
 	// Write handoff marker so post-compact prime knows it's post-handoff
-	if cwd, err := os.Getwd(); err == nil {
-		runtimeDir := filepath.Join(cwd, constants.DirRuntime)
-		_ = os.MkdirAll(runtimeDir, 0755)
-		markerPath := filepath.Join(runtimeDir, constants.FileHandoffMarker)
-		sessionName := "auto-handoff"
-		if tmux.IsInsideTmux() {
-			if name, err := getCurrentTmuxSession(); err == nil {
-				sessionName = name
-			}
+	sessionName := "auto-handoff"
+	if tmux.IsInsideTmux() {
+		if name, err := getCurrentTmuxSession(); err == nil {
+			sessionName = name
 		}
-		_ = os.WriteFile(markerPath, []byte(sessionName), 0644)
 	}
+	writeHandoffMarker(sessionName, "", beadID)
 
 	// Log handoff event
 	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
@@ -483,19 +482,10 @@ func runHandoffCycle() error {
 	}
 
 	// Write handoff marker so post-cycle prime knows it's post-handoff.
-	// Format: "session_id\nreason" — the reason enables isCompactResume()
-	// to detect compaction-triggered cycles and use a lighter continuation
-	// directive instead of full re-initialization. (GH#1965)
-	if cwd, err := os.Getwd(); err == nil {
-		runtimeDir := filepath.Join(cwd, constants.DirRuntime)
-		_ = os.MkdirAll(runtimeDir, 0755)
-		markerPath := filepath.Join(runtimeDir, constants.FileHandoffMarker)
-		markerContent := currentSession
-		if handoffReason != "" {
-			markerContent += "\n" + handoffReason
-		}
-		_ = os.WriteFile(markerPath, []byte(markerContent), 0644)
-	}
+	// The reason enables isCompactResume() to detect compaction-triggered
+	// cycles and use a lighter continuation directive instead of full
+	// re-initialization. (GH#1965)
+	writeHandoffMarker(currentSession, handoffReason, beadID)
 
 	// Log cycle event
 	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
@@ -734,6 +724,9 @@ type buildRestartCommandOpts struct {
 	// ContinueSession is true. If empty, falls back to a generic
 	// continuation message.
 	ContinuePrompt string
+	// Agent forces the runtime to restart with (e.g., the provider of a
+	// rotated account) instead of inheriting GT_AGENT.
+	Agent string
 }
 
 func buildRestartCommand(sessionName string) (string, error) {
@@ -797,6 +790,9 @@ func buildRestartCommandWithOpts(sessionName string, opts buildRestartCommandOpt
 	// Fall back to tmux session environment if process env doesn't have it,
 	// since exec env vars may not propagate through all agent runtimes.
 	currentAgent, agentInEnv := os.LookupEnv("GT_AGENT")
+	if opts.Agent != "" {
+		currentAgent, agentInEnv = opts.Agent, true
+	}
 	if !agentInEnv {
 		// GT_AGENT not in process env at all — try tmux session environment
 		// as fallback, since exec env vars may not propagate through all runtimes.
@@ -1182,6 +1178,13 @@ func sendHandoffMail(subject, message string) (string, error) {
 		message = "Context cycling. Check bd ready for pending work."
 	}
 
+	// Attach what this session tried so the successor doesn't repeat it.
+	if !handoffNoTranscript {
+		if section := collectTranscriptSection(); section != "" {
+			message += "\n\n" + section
+		}
+	}
+
 	// Detect agent identity for self-mail
 	agentID, _, _, err := resolveSelfTarget()
 	if err != nil {
@@ -1421,6 +1424,46 @@ func collectHandoffState() string {
 	return strings.Join(parts, "\n\n")
 }
 
+// collectTranscriptSection summarizes the current session's transcript for
+// handoff mail. Returns "" if the agent's log can't be found or read; a
+// missing summary must never block a handoff.
+func collectTranscriptSection() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	sessionID := runtime.SessionIDFromEnv()
+	if sessionID == "" {
+		sessionID = ReadPersistedSessionID()
+	}
+	summary, err := transcript.Summarize(os.Getenv("GT_AGENT"), cwd, sessionID)
+	if err != nil || summary.IsEmpty() {
+		return ""
+	}
+	return transcript.Section(summary)
+}
+
+// writeHandoffMarker records that a handoff just happened so the successor's
+// gt prime warns it not to re-run /handoff. Format: "session\nreason\nmail_id";
+// see checkHandoffMarker. The marker is cleared by gt prime after it outputs
+// the warning.
+func writeHandoffMarker(session, reason, mailID string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	runtimeDir := filepath.Join(cwd, constants.DirRuntime)
+	_ = os.MkdirAll(runtimeDir, 0755)
+	content := session
+	if reason != "" || mailID != "" {
+		content += "\n" + reason
+	}
+	if mailID != "" {
+		content += "\n" + mailID
+	}
+	_ = os.WriteFile(filepath.Join(runtimeDir, constants.FileHandoffMarker), []byte(content), 0644)
+}
+
 // collectGitState captures deterministic workspace state using the Go git library.
 // This uses only the git.Git wrapper (no shelling out to gt/bd), so it works
 // reliably even when PATH is broken or external commands are unavailable.
@@ -23,13 +23,16 @@ and other operational data that decays in forensic value over days.
 
 KRC provides:
   - Configurable TTLs per event type
-  - Auto-pruning of expired events
+  - Auto-pruning of expired events into a compressed archive (.krc-archive/)
+  - Per-type archive retention, so deaths and merges outlive their live TTL
+  - Queries across live and archived events
   - Statistics on ephemeral data lifecycle
 
 Examples:
   gt krc stats              # Show event statistics
   gt krc prune              # Remove expired events
   gt krc prune --dry-run    # Preview what would be pruned
+  gt krc query --type session_death --since 30d   # Search live + archive
   gt krc config             # Show TTL configuration
   gt krc config set patrol_* 12h   # Set TTL for patrol events`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -57,6 +60,10 @@ var krcPruneCmd = &cobra.Command{
 Events are removed from both .events.jsonl and .feed.jsonl.
 The operation is atomic (uses temp files and rename).
 
+Expired events from .events.jsonl are moved to the archive (.krc-archive/)
+when their type's archive retention is longer than its TTL, and archived
+events past their retention are removed.
+
 Use --dry-run to preview what would be pruned without making changes.`,
 	RunE: runKrcPrune,
 }
@@ -68,14 +75,20 @@ var krcConfigCmd = &cobra.Command{
 
 Without arguments, shows the current configuration.
 
+Retention has two tiers: the live TTL (how long events stay in
+.events.jsonl) and the archive retention (how long, from the event
+timestamp, expired events are kept in .krc-archive/).
+
 Subcommands:
-  set <pattern> <ttl>   Set TTL for event type pattern
+  set <pattern> <ttl>   Set TTL (or --tier archive retention) for a pattern
   reset                 Reset to default configuration
 
 Examples:
   gt krc config                     # Show current config
   gt krc config set patrol_* 12h    # Set patrol TTL to 12 hours
   gt krc config set default 3d      # Set default TTL to 3 days
+  gt krc config set --tier archive session_death 730d   # Keep deaths 2 years
+  gt krc config set --tier archive nudge 0   # Never archive nudges
   gt krc config reset               # Reset to defaults`,
 	RunE: runKrcConfig,
 }
@@ -88,6 +101,9 @@ var krcConfigSetCmd = &cobra.Command{
 Patterns support glob-style matching with * (e.g., "patrol_*" matches all patrol events).
 Use "default" as the pattern to set the default TTL.
 
+With --tier archive, sets how long expired events are kept in the archive
+instead. A retention of 0 (or no longer than the TTL) disables archiving.
+
 TTL format: 1h, 12h, 1d, 7d, 30d, etc.`,
 	Args: cobra.ExactArgs(2),
 	RunE: runKrcConfigSet,
@@ -118,6 +134,22 @@ Events with low forensic scores are candidates for aggressive pruning.`,
 	RunE: runKrcDecay,
 }
 
+var krcQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Search live and archived events",
+	Long: `Search events across .events.jsonl and the KRC archive together.
+
+Archived segments are selected by day and type from the archive index, so
+narrow --since/--type filters stay fast even with months of history.
+
+Examples:
+  gt krc query --type session_death --since 30d
+  gt krc query --type mass_death --type session_death --since 2026-09-01
+  gt krc query --type 'merge_*' --since 7d --until 2d --json
+  gt krc query --since 1h --limit 20`,
+	RunE: runKrcQuery,
+}
+
 var krcAutoPruneStatusCmd = &cobra.Command{
 	Use:   "auto-prune-status",
 	Short: "Show auto-prune scheduling state",
@@ -133,6 +165,14 @@ var (
 	krcPruneAuto   bool
 	krcStatsJSON   bool
 	krcDecayJSON   bool
+
+	krcConfigTier string
+
+	krcQueryTypes []string
+	krcQuerySince string
+	krcQueryUntil string
+	krcQueryLimit int
+	krcQueryJSON  bool
 )
 
 func init() {
@@ -142,6 +182,7 @@ func init() {
 	krcCmd.AddCommand(krcConfigCmd)
 	krcCmd.AddCommand(krcDecayCmd)
 	krcCmd.AddCommand(krcAutoPruneStatusCmd)
+	krcCmd.AddCommand(krcQueryCmd)
 	krcConfigCmd.AddCommand(krcConfigSetCmd)
 	krcConfigCmd.AddCommand(krcConfigResetCmd)
 
@@ -149,6 +190,13 @@ func init() {
 	krcPruneCmd.Flags().BoolVar(&krcPruneAuto, "auto", false, "Daemon mode: only prune if PruneInterval has elapsed")
 	krcStatsCmd.Flags().BoolVar(&krcStatsJSON, "json", false, "Output in JSON format")
 	krcDecayCmd.Flags().BoolVar(&krcDecayJSON, "json", false, "Output in JSON format")
+	krcConfigSetCmd.Flags().StringVar(&krcConfigTier, "tier", "live", "Retention tier to set: live (TTL) or archive")
+
+	krcQueryCmd.Flags().StringArrayVar(&krcQueryTypes, "type", nil, "Event type or glob pattern (repeatable)")
+	krcQueryCmd.Flags().StringVar(&krcQuerySince, "since", "", "Only events newer than this (duration like 30d, or date/RFC3339 time)")
+	krcQueryCmd.Flags().StringVar(&krcQueryUntil, "until", "", "Only events older than this (duration like 2d, or date/RFC3339 time)")
+	krcQueryCmd.Flags().IntVar(&krcQueryLimit, "limit", 0, "Show only the most recent N events (0 = all)")
+	krcQueryCmd.Flags().BoolVar(&krcQueryJSON, "json", false, "Output in JSON format")
 }
 
 func runKrcStats(cmd *cobra.Command, args []string) error {
@@ -184,6 +232,11 @@ func runKrcStats(cmd *cobra.Command, args []string) error {
 	fmt.Println(style.Bold.Render("Files:"))
 	fmt.Printf("  Events: %s (%d events)\n", formatBytes(stats.EventsFile.Size), stats.EventsFile.EventCount)
 	fmt.Printf("  Feed:   %s (%d events)\n", formatBytes(stats.FeedFile.Size), stats.FeedFile.EventCount)
+	if stats.Archive != nil {
+		fmt.Printf("  Archive: %s (%d events in %d segments, %s to %s)\n",
+			formatBytes(stats.Archive.Bytes), stats.Archive.Events, stats.Archive.Segments,
+			stats.Archive.OldestDay, stats.Archive.NewestDay)
+	}
 	fmt.Println()
 
 	// Age distribution
@@ -307,6 +360,10 @@ func runKrcPrune(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Events processed: %d\n", result.EventsProcessed)
 	fmt.Printf("  Events pruned:    %d\n", result.EventsPruned)
 	fmt.Printf("  Events retained:  %d\n", result.EventsRetained)
+	fmt.Printf("  Events archived:  %d\n", result.EventsArchived)
+	if result.ArchiveExpired > 0 {
+		fmt.Printf("  Archive expired:  %d\n", result.ArchiveExpired)
+	}
 	fmt.Printf("  Space saved:      %s\n", formatBytes(result.BytesBefore-result.BytesAfter))
 	fmt.Printf("  Duration:         %s\n", result.Duration.Round(time.Millisecond))
 
@@ -363,9 +420,30 @@ func runKrcConfig(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  %-20s %s\n", p, krcFormatDuration(config.TTLs[p]))
 	}
 
+	fmt.Println()
+	fmt.Printf("Default archive retention: %s\n", krcFormatRetention(config.DefaultArchiveRetention))
+	fmt.Println(style.Bold.Render("Archive retention by pattern:"))
+	patterns = patterns[:0]
+	for p := range config.ArchiveRetention {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+	for _, p := range patterns {
+		fmt.Printf("  %-20s %s\n", p, krcFormatRetention(config.ArchiveRetention[p]))
+	}
+
 	return nil
 }
 
+// krcFormatRetention formats an archive retention, where zero means the
+// events are not archived.
+func krcFormatRetention(d time.Duration) string {
+	if d <= 0 {
+		return "not archived"
+	}
+	return krcFormatDuration(d)
+}
+
 func runKrcConfigSet(cmd *cobra.Command, args []string) error {
 	pattern := args[0]
 	ttlStr := args[1]
@@ -385,6 +463,27 @@ func runKrcConfigSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading config: %w", err)
 	}
 
+	switch krcConfigTier {
+	case "live":
+	case "archive":
+		if pattern == "default" {
+			config.DefaultArchiveRetention = ttl
+			fmt.Printf("Set default archive retention to %s\n", krcFormatRetention(ttl))
+		} else {
+			if config.ArchiveRetention == nil {
+				config.ArchiveRetention = make(map[string]time.Duration)
+			}
+			config.ArchiveRetention[pattern] = ttl
+			fmt.Printf("Set archive retention for %q to %s\n", pattern, krcFormatRetention(ttl))
+		}
+		if err := krc.SaveConfig(townRoot, config); err != nil {
+			return fmt.Errorf("saving config: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid tier %q: must be live or archive", krcConfigTier)
+	}
+
 	if pattern == "default" {
 		config.DefaultTTL = ttl
 		fmt.Printf("Set default TTL to %s\n", krcFormatDuration(ttl))
@@ -495,9 +594,10 @@ func runKrcAutoPrune(townRoot string, config *krc.Config) error {
 		return nil
 	}
 
-	fmt.Printf("%s Auto-pruned %d events (%s freed)\n",
+	fmt.Printf("%s Auto-pruned %d events (%d archived, %s freed)\n",
 		style.Bold.Render("✓"),
 		result.EventsPruned,
+		result.EventsArchived,
 		formatBytes(result.BytesBefore-result.BytesAfter))
 
 	return nil
@@ -632,3 +732,93 @@ func runKrcAutoPruneStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runKrcQuery(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	now := time.Now()
+	opts := krc.QueryOptions{Limit: krcQueryLimit}
+	for _, t := range krcQueryTypes {
+		for _, part := range strings.Split(t, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				opts.Types = append(opts.Types, part)
+			}
+		}
+	}
+	if krcQuerySince != "" {
+		if opts.Since, err = krcParseTimeBound(krcQuerySince, now); err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if krcQueryUntil != "" {
+		if opts.Until, err = krcParseTimeBound(krcQueryUntil, now); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+
+	records, err := krc.Query(townRoot, opts)
+	if err != nil {
+		return fmt.Errorf("querying events: %w", err)
+	}
+
+	if krcQueryJSON {
+		if records == nil {
+			records = []krc.Record{}
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(records) == 0 {
+		fmt.Println("No matching events.")
+		return nil
+	}
+
+	archived := 0
+	for _, r := range records {
+		var event struct {
+			Actor   string                 `json:"actor"`
+			Payload map[string]interface{} `json:"payload"`
+		}
+		_ = json.Unmarshal(r.Event, &event)
+		tier := "live   "
+		if r.Archived {
+			tier = style.Dim.Render("archive")
+			archived++
+		}
+		line := fmt.Sprintf("%s  %-20s %s %s", r.Timestamp.Format(time.RFC3339), r.Type, tier, event.Actor)
+		if len(event.Payload) > 0 {
+			if payload, err := json.Marshal(event.Payload); err == nil {
+				line += "  " + style.Dim.Render(string(payload))
+			}
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	fmt.Printf("%d events (%d from archive)\n", len(records), archived)
+	return nil
+}
+
+// krcParseTimeBound parses a --since/--until value: a duration before now
+// (e.g., "30d", "12h"), a date (2006-01-02), or an RFC3339 time.
+func krcParseTimeBound(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	d, err := krcParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a duration, date, or RFC3339 time", s)
+	}
+	return now.Add(-d), nil
+}
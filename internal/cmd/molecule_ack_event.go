@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/eventchan"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	ackEventChannel string
+	ackEventGroup   string
+	ackEventOffset  uint64
+	ackEventLeave   bool
+)
+
+var moleculeAckEventCmd = &cobra.Command{
+	Use:   "ack-event",
+	Short: "Acknowledge events for a consumer group on a channel",
+	Long: `Acknowledge that a consumer group has processed events up to an offset.
+
+Consumer groups (await-event --group) are redelivered every event above
+their acknowledged offset. Acknowledge after processing so a crash between
+receiving and processing an event leads to redelivery, not loss.
+Acknowledgements never move a group backwards; use await-event
+--from-offset to replay.
+
+With --leave, the group is removed instead, so the channel no longer
+retains events for it.
+
+EXAMPLES:
+  # Acknowledge events up to offset 42 for group "nux"
+  gt mol step ack-event --channel convoy-done --group nux --offset 42
+
+  # Stop retaining events for a finished polecat
+  gt mol step ack-event --channel convoy-done --group nux --leave`,
+	RunE: runMoleculeAckEvent,
+}
+
+// AckEventResult is returned when events are acknowledged.
+type AckEventResult struct {
+	Channel string `json:"channel"`
+	Group   string `json:"group"`
+	Offset  uint64 `json:"offset"`
+	Left    bool   `json:"left,omitempty"`
+	Pruned  int    `json:"pruned"`
+}
+
+func init() {
+	moleculeAckEventCmd.Flags().StringVar(&ackEventChannel, "channel", "",
+		"Event channel name (required)")
+	moleculeAckEventCmd.Flags().StringVar(&ackEventGroup, "group", "",
+		"Consumer group name (required)")
+	moleculeAckEventCmd.Flags().Uint64Var(&ackEventOffset, "offset", 0,
+		"Highest offset the group has processed")
+	moleculeAckEventCmd.Flags().BoolVar(&ackEventLeave, "leave", false,
+		"Remove the consumer group from the channel")
+	moleculeAckEventCmd.Flags().BoolVar(&moleculeJSON, "json", false,
+		"Output as JSON")
+	_ = moleculeAckEventCmd.MarkFlagRequired("channel")
+	_ = moleculeAckEventCmd.MarkFlagRequired("group")
+
+	moleculeStepCmd.AddCommand(moleculeAckEventCmd)
+}
+
+func runMoleculeAckEvent(cmd *cobra.Command, args []string) error {
+	if !ackEventLeave && ackEventOffset == 0 {
+		return fmt.Errorf("--offset or --leave is required")
+	}
+
+	ch, err := eventchan.Open(eventTownRoot(), ackEventChannel)
+	if err != nil {
+		return err
+	}
+	result, err := ackEvents(ch, ackEventGroup, ackEventOffset, ackEventLeave)
+	if err != nil {
+		return err
+	}
+
+	if moleculeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	if result.Left {
+		fmt.Printf("%s Group %q left channel %q\n", style.Bold.Render("✓"), result.Group, result.Channel)
+	} else {
+		fmt.Printf("%s Group %q acknowledged through offset %d on channel %q\n",
+			style.Bold.Render("✓"), result.Group, result.Offset, result.Channel)
+	}
+	if result.Pruned > 0 {
+		fmt.Printf("  %s pruned %d event(s) acknowledged by every group\n", style.Dim.Render("→"), result.Pruned)
+	}
+	return nil
+}
+
+// ackEvents acknowledges (or leaves) for group and prunes events every group
+// has now acknowledged.
+func ackEvents(ch *eventchan.Channel, group string, offset uint64, leave bool) (*AckEventResult, error) {
+	result := &AckEventResult{Channel: ch.Name(), Group: group, Left: leave}
+	if leave {
+		if err := ch.Leave(group); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := ch.Ack(group, offset); err != nil {
+			return nil, err
+		}
+		current, err := ch.Offset(group)
+		if err != nil {
+			return nil, err
+		}
+		result.Offset = current
+	}
+
+	pruned, err := ch.Prune()
+	if err != nil {
+		return nil, fmt.Errorf("pruning channel: %w", err)
+	}
+	result.Pruned = pruned
+	return result, nil
+}
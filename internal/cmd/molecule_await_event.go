@@ -13,8 +13,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/eventchan"
 	"github.com/steveyegge/gastown/internal/style"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var (
@@ -26,6 +26,9 @@ var (
 	awaitEventQuiet       bool
 	awaitEventAgentBead   string
 	awaitEventCleanup     bool
+	awaitEventGroup       string
+	awaitEventFromOffset  uint64
+	awaitEventAck         bool
 )
 
 // validChannelName restricts channel names to safe characters (no path traversal).
@@ -40,19 +43,30 @@ Unlike await-signal (which subscribes to the generic beads activity feed),
 await-event watches a dedicated event channel directory for .event files.
 Events are emitted via "gt mol step emit-event" or programmatically.
 
-Channels are single-consumer: only one process should watch a given channel
-at a time. If multiple consumers watch the same channel with --cleanup,
-events may be deleted before all consumers read them.
+Without --group, a channel is single-consumer: only one process should
+watch it at a time. If multiple consumers watch the same channel with
+--cleanup, events may be deleted before all consumers read them.
+
+CONSUMER GROUPS:
+With --group, the watcher reads as a named consumer group with its own
+offset, so one signal can fan out to several waiting polecats safely.
+Each group is delivered every event above its acknowledged offset
+(at-least-once): events are redelivered until the group acknowledges them,
+with --ack here or with "gt mol step ack-event" after processing. A new
+group starts at the oldest retained event. --from-offset replays a group
+from an earlier offset. Events are pruned once every group has
+acknowledged them (see emit-event for retention limits).
 
 EVENT FORMAT:
 Events are JSON files in ~/gt/events/<channel>/*.event:
-  {"type": "...", "channel": "...", "timestamp": "...", "payload": {...}}
+  {"type": "...", "channel": "...", "offset": N, "timestamp": "...", "payload": {...}}
 
 BEHAVIOR:
 1. Check for already-pending events (return immediately if found)
 2. If none, poll the directory until a new .event file appears or timeout
 3. On wake, return all pending event file paths and contents
 4. With --cleanup, delete processed event files automatically
+   (with --group, use --ack instead)
 
 BACKOFF MODE:
 Same as await-signal: base * multiplier^idle_cycles, capped at max.
@@ -72,7 +86,13 @@ EXAMPLES:
     --backoff-base 60s --backoff-mult 2 --backoff-max 10m
 
   # Auto-cleanup processed events
-  gt mol step await-event --channel refinery --cleanup`,
+  gt mol step await-event --channel refinery --cleanup
+
+  # Wait as consumer group "nux", acknowledging what is returned
+  gt mol step await-event --channel convoy-done --group nux --ack
+
+  # Replay group "nux" from offset 40
+  gt mol step await-event --channel convoy-done --group nux --from-offset 40`,
 	RunE: runMoleculeAwaitEvent,
 }
 
@@ -87,6 +107,7 @@ type AwaitEventResult struct {
 // EventFile represents a single event file.
 type EventFile struct {
 	Path    string          `json:"path"`
+	Offset  uint64          `json:"offset,omitempty"` // Channel offset; set for --group reads
 	Content json.RawMessage `json:"content"`
 }
 
@@ -106,7 +127,13 @@ func init() {
 	moleculeAwaitEventCmd.Flags().BoolVar(&awaitEventQuiet, "quiet", false,
 		"Suppress output (for scripting)")
 	moleculeAwaitEventCmd.Flags().BoolVar(&awaitEventCleanup, "cleanup", false,
-		"Delete event files after reading them")
+		"Delete event files after reading them (not with --group)")
+	moleculeAwaitEventCmd.Flags().StringVar(&awaitEventGroup, "group", "",
+		"Read as a named consumer group with its own offset")
+	moleculeAwaitEventCmd.Flags().Uint64Var(&awaitEventFromOffset, "from-offset", 0,
+		"Replay the group from this offset (requires --group)")
+	moleculeAwaitEventCmd.Flags().BoolVar(&awaitEventAck, "ack", false,
+		"Acknowledge returned events for the group (requires --group)")
 	moleculeAwaitEventCmd.Flags().BoolVar(&moleculeJSON, "json", false,
 		"Output as JSON")
 	_ = moleculeAwaitEventCmd.MarkFlagRequired("channel")
@@ -120,16 +147,29 @@ func runMoleculeAwaitEvent(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid channel name %q: must match [a-zA-Z0-9_-]", awaitEventChannel)
 	}
 
-	// Resolve event directory
-	townRoot, err := workspace.FindFromCwd()
-	if err != nil || townRoot == "" {
-		// Fallback to ~/gt
-		home, _ := os.UserHomeDir()
-		townRoot = filepath.Join(home, "gt")
+	if awaitEventGroup == "" && (awaitEventAck || awaitEventFromOffset > 0) {
+		return fmt.Errorf("--ack and --from-offset require --group")
 	}
-	eventDir := filepath.Join(townRoot, "events", awaitEventChannel)
-	if err := os.MkdirAll(eventDir, 0755); err != nil {
-		return fmt.Errorf("creating event directory: %w", err)
+	if awaitEventGroup != "" && awaitEventCleanup {
+		return fmt.Errorf("--cleanup cannot be used with --group: acknowledged events are pruned once every group has acked them")
+	}
+
+	// Resolve event channel
+	ch, err := eventchan.Open(eventTownRoot(), awaitEventChannel)
+	if err != nil {
+		return err
+	}
+	read := func() ([]EventFile, error) { return readPendingEvents(ch.Dir()) }
+	if awaitEventGroup != "" {
+		if err := ch.Join(awaitEventGroup); err != nil {
+			return err
+		}
+		if awaitEventFromOffset > 0 {
+			if err := ch.Seek(awaitEventGroup, awaitEventFromOffset); err != nil {
+				return err
+			}
+		}
+		read = func() ([]EventFile, error) { return readGroupEvents(ch, awaitEventGroup) }
 	}
 
 	// Read current idle cycles and backoff window from agent bead
@@ -197,12 +237,20 @@ func runMoleculeAwaitEvent(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	result, err := waitForEventFiles(ctx, eventDir)
+	result, err := waitForEvents(ctx, read)
 	if err != nil {
 		return fmt.Errorf("event watch failed: %w", err)
 	}
 	result.Elapsed = time.Since(startTime)
 
+	// Acknowledge delivered events for the group
+	if awaitEventAck && result.Reason == "event" {
+		last := result.Events[len(result.Events)-1].Offset
+		if err := ch.Ack(awaitEventGroup, last); err != nil {
+			return fmt.Errorf("acknowledging events: %w", err)
+		}
+	}
+
 	// Update agent bead idle cycles and heartbeat
 	if awaitEventAgentBead != "" && beadsDir != "" {
 		// Always update heartbeat (both event and timeout) so witness doesn't
@@ -296,8 +344,14 @@ func calculateEventTimeout(idleCycles int) (time.Duration, error) {
 // waitForEventFiles checks for pending events, then polls until events appear or timeout.
 // Uses a polling loop instead of inotifywait for cross-platform compatibility.
 func waitForEventFiles(ctx context.Context, eventDir string) (*AwaitEventResult, error) {
+	return waitForEvents(ctx, func() ([]EventFile, error) { return readPendingEvents(eventDir) })
+}
+
+// waitForEvents checks for pending events with read, then polls until
+// events appear or timeout.
+func waitForEvents(ctx context.Context, read func() ([]EventFile, error)) (*AwaitEventResult, error) {
 	// Check for already-pending events
-	events, err := readPendingEvents(eventDir)
+	events, err := read()
 	if err != nil {
 		return nil, err
 	}
@@ -328,7 +382,7 @@ func waitForEventFiles(ctx context.Context, eventDir string) (*AwaitEventResult,
 		select {
 		case <-ctx.Done():
 			// Final check for events (race condition safety)
-			events, _ = readPendingEvents(eventDir)
+			events, _ = read()
 			if len(events) > 0 {
 				return &AwaitEventResult{
 					Reason: "event",
@@ -337,7 +391,7 @@ func waitForEventFiles(ctx context.Context, eventDir string) (*AwaitEventResult,
 			}
 			return &AwaitEventResult{Reason: "timeout"}, nil
 		case <-ticker.C:
-			events, err = readPendingEvents(eventDir)
+			events, err = read()
 			if err != nil {
 				return nil, err
 			}
@@ -386,3 +440,16 @@ func readPendingEvents(dir string) ([]EventFile, error) {
 
 	return events, nil
 }
+
+// readGroupEvents reads the events group has not acknowledged.
+func readGroupEvents(ch *eventchan.Channel, group string) ([]EventFile, error) {
+	pending, err := ch.ReadGroup(group)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]EventFile, 0, len(pending))
+	for _, e := range pending {
+		events = append(events, EventFile{Path: e.Path, Offset: e.Offset, Content: e.Content})
+	}
+	return events, nil
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/eventchan"
 )
 
 func TestCalculateEventTimeout(t *testing.T) {
@@ -381,3 +383,54 @@ func TestEventFileStruct(t *testing.T) {
 		t.Errorf("type = %v, want MQ_SUBMIT", parsed["type"])
 	}
 }
+
+func TestWaitForEventsConsumerGroupFanOut(t *testing.T) {
+	// One event should reach every waiting consumer group, and each group
+	// keeps receiving it until it acknowledges.
+	dir := t.TempDir()
+	path, err := emitEventImpl(dir, "fanout", "CONVOY_DONE", []string{"convoy=hq-1"})
+	if err != nil {
+		t.Fatalf("emit failed: %v", err)
+	}
+	ch, err := eventchan.OpenDir(dir, "fanout")
+	if err != nil {
+		t.Fatalf("OpenDir failed: %v", err)
+	}
+
+	for _, group := range []string{"nux", "furiosa"} {
+		if err := ch.Join(group); err != nil {
+			t.Fatalf("Join(%s) failed: %v", group, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		result, err := waitForEvents(ctx, func() ([]EventFile, error) { return readGroupEvents(ch, group) })
+		cancel()
+		if err != nil {
+			t.Fatalf("waitForEvents(%s) error: %v", group, err)
+		}
+		if result.Reason != "event" || len(result.Events) != 1 || result.Events[0].Path != path {
+			t.Fatalf("group %s got %+v, want the emitted event", group, result)
+		}
+		if result.Events[0].Offset != 1 {
+			t.Errorf("group %s offset = %d, want 1", group, result.Events[0].Offset)
+		}
+	}
+
+	res, err := ackEvents(ch, "nux", 1, false)
+	if err != nil {
+		t.Fatalf("ackEvents failed: %v", err)
+	}
+	if res.Pruned != 0 {
+		t.Errorf("pruned %d events while furiosa had not acked", res.Pruned)
+	}
+	if pending, _ := readGroupEvents(ch, "furiosa"); len(pending) != 1 {
+		t.Errorf("furiosa pending = %d after nux acked, want 1", len(pending))
+	}
+
+	res, err = ackEvents(ch, "furiosa", 1, false)
+	if err != nil {
+		t.Fatalf("ackEvents failed: %v", err)
+	}
+	if res.Pruned != 1 {
+		t.Errorf("pruned %d events after every group acked, want 1", res.Pruned)
+	}
+}
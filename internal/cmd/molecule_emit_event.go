@@ -6,21 +6,19 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync/atomic"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/eventchan"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
-// emitEventSeq is an atomic counter to ensure unique event filenames even when
-// time.Now().UnixNano() has low resolution (e.g., Windows ~100ns granularity).
-var emitEventSeq atomic.Uint64
-
 var (
-	emitEventChannel string
-	emitEventType    string
-	emitEventPayload []string
+	emitEventChannel   string
+	emitEventType      string
+	emitEventPayload   []string
+	emitEventMaxEvents int
+	emitEventMaxAge    string
 )
 
 var moleculeEmitEventCmd = &cobra.Command{
@@ -32,8 +30,17 @@ This is the Go counterpart to emit-event.sh. Events are JSON files consumed
 by await-event subscribers (e.g., the refinery watching for MERGE_READY events).
 
 EVENT FORMAT:
-Creates a JSON file at ~/gt/events/<channel>/<timestamp>.event:
-  {"type": "...", "channel": "...", "timestamp": "...", "payload": {...}}
+Creates a JSON file at ~/gt/events/<channel>/<nanos>-<offset>-<pid>.event:
+  {"type": "...", "channel": "...", "offset": N, "timestamp": "...", "payload": {...}}
+
+Offsets increase by one per event on a channel. Consumer groups
+(await-event --group) track the offset they have acknowledged.
+
+RETENTION:
+Events acknowledged by every consumer group are pruned on each emit.
+--max-events and --max-age set hard limits that apply even to events a
+group has not acknowledged; they are stored with the channel and apply
+to every later emit.
 
 EXAMPLES:
   # Emit a MERGE_READY event for the refinery
@@ -46,7 +53,11 @@ EXAMPLES:
 
   # Emit an MQ_SUBMIT event
   gt mol step emit-event --channel refinery --type MQ_SUBMIT \
-    --payload branch=feat/new-feature --payload mr_id=bd-42`,
+    --payload branch=feat/new-feature --payload mr_id=bd-42
+
+  # Keep at most 500 events, none older than a day
+  gt mol step emit-event --channel convoy-done --type DONE \
+    --max-events 500 --max-age 24h`,
 	RunE: runMoleculeEmitEvent,
 }
 
@@ -55,6 +66,7 @@ type EmitEventResult struct {
 	Path    string `json:"path"`
 	Channel string `json:"channel"`
 	Type    string `json:"type"`
+	Offset  uint64 `json:"offset,omitempty"`
 }
 
 func init() {
@@ -64,6 +76,10 @@ func init() {
 		"Event type (required, e.g., 'MERGE_READY')")
 	moleculeEmitEventCmd.Flags().StringArrayVar(&emitEventPayload, "payload", nil,
 		"Payload key=value pairs (repeatable)")
+	moleculeEmitEventCmd.Flags().IntVar(&emitEventMaxEvents, "max-events", 0,
+		"Retain at most this many events on the channel (0 = unchanged)")
+	moleculeEmitEventCmd.Flags().StringVar(&emitEventMaxAge, "max-age", "",
+		"Prune events older than this (e.g., 24h; unchanged if empty)")
 	moleculeEmitEventCmd.Flags().BoolVar(&moleculeJSON, "json", false,
 		"Output as JSON")
 	_ = moleculeEmitEventCmd.MarkFlagRequired("channel")
@@ -73,6 +89,12 @@ func init() {
 }
 
 func runMoleculeEmitEvent(cmd *cobra.Command, args []string) error {
+	if emitEventMaxEvents != 0 || emitEventMaxAge != "" {
+		if err := setEventRetention(eventTownRoot(), emitEventChannel, emitEventMaxEvents, emitEventMaxAge); err != nil {
+			return err
+		}
+	}
+
 	path, err := EmitEvent(emitEventChannel, emitEventType, emitEventPayload)
 	if err != nil {
 		return err
@@ -85,6 +107,7 @@ func runMoleculeEmitEvent(cmd *cobra.Command, args []string) error {
 			Path:    path,
 			Channel: emitEventChannel,
 			Type:    emitEventType,
+			Offset:  eventchan.OffsetOf(path),
 		})
 	}
 
@@ -101,18 +124,46 @@ func EmitEvent(channel, eventType string, payloadPairs []string) (string, error)
 		return "", fmt.Errorf("invalid channel name %q: must match [a-zA-Z0-9_-]", channel)
 	}
 
-	// Resolve event directory
+	eventDir := filepath.Join(eventTownRoot(), "events", channel)
+	return emitEventImpl(eventDir, channel, eventType, payloadPairs)
+}
+
+// eventTownRoot returns the town whose events/ directory holds event
+// channels, falling back to ~/gt outside a workspace.
+func eventTownRoot() string {
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil || townRoot == "" {
 		home, _ := os.UserHomeDir()
 		townRoot = filepath.Join(home, "gt")
 	}
-	eventDir := filepath.Join(townRoot, "events", channel)
-	if err := os.MkdirAll(eventDir, 0755); err != nil {
-		return "", fmt.Errorf("creating event directory: %w", err)
-	}
+	return townRoot
+}
 
-	return emitEventImpl(eventDir, channel, eventType, payloadPairs)
+// setEventRetention updates a channel's retention limits. Zero maxEvents or
+// empty maxAge leave that limit unchanged.
+func setEventRetention(townRoot, channel string, maxEvents int, maxAge string) error {
+	ch, err := eventchan.Open(townRoot, channel)
+	if err != nil {
+		return err
+	}
+	r, err := ch.Retention()
+	if err != nil {
+		return err
+	}
+	if maxEvents < 0 {
+		return fmt.Errorf("--max-events must not be negative")
+	}
+	if maxEvents > 0 {
+		r.MaxEvents = maxEvents
+	}
+	if maxAge != "" {
+		d, err := time.ParseDuration(maxAge)
+		if err != nil {
+			return fmt.Errorf("invalid --max-age: %w", err)
+		}
+		r.MaxAge = d
+	}
+	return ch.SetRetention(r)
 }
 
 // EmitEventToTown creates an event file using an explicit town root.
@@ -124,13 +175,11 @@ func EmitEventToTown(townRoot, channel, eventType string, payloadPairs []string)
 	}
 
 	eventDir := filepath.Join(townRoot, "events", channel)
-	if err := os.MkdirAll(eventDir, 0755); err != nil {
-		return "", fmt.Errorf("creating event directory: %w", err)
-	}
 	return emitEventImpl(eventDir, channel, eventType, payloadPairs)
 }
 
-// emitEventImpl writes an event file to the given directory.
+// emitEventImpl writes an event file to the given directory, assigning it
+// the channel's next offset.
 func emitEventImpl(eventDir, channel, eventType string, payloadPairs []string) (string, error) {
 	// Validate channel name (prevent path traversal)
 	if !validChannelName.MatchString(channel) {
@@ -146,26 +195,14 @@ func emitEventImpl(eventDir, channel, eventType string, payloadPairs []string) (
 		}
 	}
 
-	// Build event JSON
-	now := time.Now()
-	event := map[string]interface{}{
-		"type":      eventType,
-		"channel":   channel,
-		"timestamp": now.Format(time.RFC3339),
-		"payload":   payload,
-	}
-
-	data, err := json.MarshalIndent(event, "", "  ")
+	ch, err := eventchan.OpenDir(eventDir, channel)
 	if err != nil {
-		return "", fmt.Errorf("marshaling event: %w", err)
+		return "", err
 	}
-
-	// Write event file with nanosecond timestamp + sequence + PID for uniqueness
-	seq := emitEventSeq.Add(1)
-	eventFile := filepath.Join(eventDir, fmt.Sprintf("%d-%d-%d.event", now.UnixNano(), seq, os.Getpid()))
-	if err := os.WriteFile(eventFile, data, 0644); err != nil {
-		return "", fmt.Errorf("writing event file: %w", err)
+	ev, err := ch.Emit(eventType, payload)
+	if ev == nil {
+		return "", err
 	}
-
-	return eventFile, nil
+	// A failed prune leaves the event delivered; it is retried on the next emit.
+	return ev.Path, nil
 }
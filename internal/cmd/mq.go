@@ -174,7 +174,8 @@ var mqPostMergeCmd = &cobra.Command{
 This command consolidates post-merge steps into a single atomic operation:
   1. Close the MR bead (status: merged)
   2. Close the source issue
-  3. Delete the remote polecat branch (unless --skip-branch-delete)
+  3. Delete the remote polecat branch (unless --skip-branch-delete),
+     including member repo branches of a multi-repo MR
 
 Designed for use by the refinery formula after a successful merge to main.
 The branch name is read from the MR bead, so no manual branch argument is needed.
@@ -573,5 +574,16 @@ func runMQPostMerge(_ *cobra.Command, args []string) error {
 		fmt.Printf("  %s Deleted local branch: %s\n", style.Success.Render("✓"), mr.Branch)
 	}
 
+	// Multi-repo MRs: delete the member repo branches too
+	for _, rb := range mr.RepoBranches {
+		memberGit := git.NewGitWithDir(rig.MemberBarePath(r.Path, rb.Repo), "")
+		if err := memberGit.DeleteRemoteBranch("origin", rb.Branch); err != nil {
+			fmt.Printf("  %s remote branch delete in %s: %v\n", style.Warning.Render("⚠"), rb.Repo, err)
+		} else {
+			fmt.Printf("  %s Deleted remote branch in %s: %s\n", style.Success.Render("✓"), rb.Repo, rb.Branch)
+		}
+		_ = memberGit.DeleteBranch(rb.Branch, true)
+	}
+
 	return nil
 }
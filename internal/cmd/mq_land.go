@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var mqLandCmd = &cobra.Command{
+	Use:   "land <rig> <mr-id>",
+	Short: "Merge, gate and push a merge request in one step",
+	Long: `Land a merge request from the refinery worktree.
+
+Squash-merges the MR branch into its target, runs the rig's quality gates
+and pushes. For multi-repo MRs (those with repo_branches), every member
+repo is merged first, the gates run once against the whole set, and the
+pushes are atomic: if any repo fails to push, the repos already pushed are
+force-pushed back to their previous commit and nothing lands.
+
+This does not close the MR or notify anyone. On success, continue with the
+usual MERGED mail and 'gt mq post-merge'.
+
+Examples:
+  gt mq land product gt-mr-abc123`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQLand,
+}
+
+func init() {
+	mqCmd.AddCommand(mqLandCmd)
+}
+
+func runMQLand(cmd *cobra.Command, args []string) error {
+	rigName, mrID := args[0], args[1]
+
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	mr, err := eng.GetMRInfo(mrID)
+	if err != nil {
+		return fmt.Errorf("loading merge request %s: %w", mrID, err)
+	}
+
+	result := eng.ProcessMRInfo(context.Background(), mr)
+	if !result.Success {
+		kind := "merge failed"
+		switch {
+		case result.Conflict:
+			kind = "conflict"
+		case result.TestsFailed:
+			kind = "gates failed"
+		case result.SlotTimeout:
+			kind = "merge slot busy"
+		}
+		return fmt.Errorf("%s: %s", kind, result.Error)
+	}
+
+	fmt.Printf("%s Landed %s (%s)\n", style.Bold.Render("✓"), mr.ID, result.MergeCommit[:8])
+	if len(mr.RepoBranches) > 0 {
+		fmt.Printf("  Repos: %s\n", beads.FormatRepoBranches(mr.RepoBranches))
+	}
+	return nil
+}
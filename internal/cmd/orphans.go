@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/checkpoint"
 	"github.com/steveyegge/gastown/internal/constants"
 	gitpkg "github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -35,6 +36,9 @@ Polecat work can get lost when:
 This command scans for:
 1. Orphaned commits via 'git fsck --unreachable' (filtered by --days/--all)
 2. Unmerged polecat worktree branches (always shown)
+3. Checkpoint snapshots of uncommitted work (always shown), saved under
+   refs/gastown/checkpoints/ by 'gt checkpoint write' and before a dirty
+   worktree is nuked or repaired
 
 Note: --days and --all only apply to orphaned commits, not polecat branches.
 
@@ -275,6 +279,28 @@ func runOrphans(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  %s\n\n", style.Dim.Render(fmt.Sprintf("git log %s..HEAD        # View unmerged commits", defaultBranch)))
 	}
 
+	// --- Checkpoint snapshots of uncommitted work ---
+	repoDir := filepath.Join(r.Path, ".repo.git")
+	if _, err := os.Stat(repoDir); err != nil {
+		repoDir = mayorPath
+	}
+	if snaps, err := checkpoint.ListSnapshots(repoDir, ""); err != nil {
+		fmt.Printf("%s Could not list checkpoint snapshots: %v\n\n", style.Dim.Render("ℹ"), err)
+	} else if len(snaps) > 0 {
+		foundAnything = true
+		fmt.Printf("%s Found %d checkpoint snapshot(s) of uncommitted work:\n\n", style.Warning.Render("⚠"), len(snaps))
+		for _, s := range snaps {
+			fmt.Printf("  %s %s\n", style.Bold.Render(s.Name()), style.Dim.Render(formatAge(s.Timestamp)))
+			if s.Reason != "" {
+				fmt.Printf("    %s %s\n", style.Dim.Render("reason:"), s.Reason)
+			}
+		}
+		fmt.Println()
+		fmt.Printf("%s\n", style.Dim.Render("To recover a snapshot (from a worktree of this rig):"))
+		fmt.Printf("  %s\n", style.Dim.Render("gt checkpoint restore <name>     # Apply changes to the worktree"))
+		fmt.Printf("  %s\n\n", style.Dim.Render("git show --stat <ref>            # Inspect what was saved"))
+	}
+
 	if len(skipped) > 0 {
 		fmt.Printf("%s Skipped %d polecat(s) due to errors:\n", style.Warning.Render("⚠"), len(skipped))
 		for _, s := range skipped {
@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/quota"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -34,6 +35,7 @@ type SpawnedPolecatInfo struct {
 	// Internal fields for deferred session start
 	account string
 	agent   string
+	worker  bool
 }
 
 // AgentID returns the agent identifier (e.g., "gastown/polecats/Toast")
@@ -54,6 +56,7 @@ type SlingSpawnOptions struct {
 	HookBead   string // Bead ID to set as hook_bead at spawn time (atomic assignment)
 	Agent      string // Agent override for this spawn (e.g., "gemini", "codex", "claude-haiku")
 	BaseBranch string // Override base branch for polecat worktree (e.g., "develop", "release/v2")
+	Worker     bool   // Run the agent in structured worker mode (see gt worker)
 }
 
 // SpawnPolecatForSling creates a fresh polecat and optionally starts its session.
@@ -236,6 +239,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 				Branch:      polecatObj.Branch,
 				account:     opts.Account,
 				agent:       opts.Agent,
+				worker:      opts.Worker,
 			}, nil
 		}
 	}
@@ -320,6 +324,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		Branch:      polecatObj.Branch,
 		account:     opts.Account,
 		agent:       opts.Agent,
+		worker:      opts.Worker,
 	}, nil
 }
 
@@ -351,12 +356,36 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 		return "", fmt.Errorf("rig '%s' not found", s.RigName)
 	}
 
-	// Resolve account
+	// Resolve account. Balanced selection draws from the pool of the agent
+	// the polecat will run; an explicit credential account picks the agent.
+	provider := s.agent
+	if provider == "" {
+		provider = config.ResolveRoleAgentConfig("polecat", townRoot, r.Path).ResolvedAgent
+	}
 	accountsPath := constants.MayorAccountsPath(townRoot)
-	claudeConfigDir, _, err := config.ResolveAccountConfigDir(accountsPath, s.account)
+	acctHandle, acct, claudeConfigDir, err := resolveSpawnAccount(townRoot, accountsPath, s.account, provider)
 	if err != nil {
 		return "", fmt.Errorf("resolving account: %w", err)
 	}
+	var credentialEnv string
+	if acct != nil && acct.UsesCredential() {
+		if s.agent != "" && s.agent != acct.ProviderName() {
+			return "", fmt.Errorf("account %q is a %s account but agent is %s", acctHandle, acct.ProviderName(), s.agent)
+		}
+		s.agent = acct.ProviderName()
+		credentialEnv = config.GetCredentialEnvVar(s.agent)
+		if credentialEnv == "" {
+			return "", fmt.Errorf("account %q: agent %s does not take a credential", acctHandle, s.agent)
+		}
+		claudeConfigDir = ""
+	}
+
+	// Worker mode: requested for this spawn, or the rig's default.
+	if !s.worker {
+		if settings, err := config.LoadRigSettings(config.RigSettingsPath(r.Path)); err == nil {
+			s.worker = settings.WorkerMode
+		}
+	}
 
 	// Start session
 	t := tmux.NewTmux()
@@ -366,17 +395,25 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 	startOpts := polecat.SessionStartOptions{
 		RuntimeConfigDir: claudeConfigDir,
 		Agent:            s.agent,
+		Worker:           s.worker,
 	}
-	if s.agent != "" {
+	if s.agent != "" && !s.worker {
 		cmd, err := config.BuildPolecatStartupCommandWithAgentOverride(s.RigName, s.PolecatName, r.Path, "", s.agent)
 		if err != nil {
 			return "", err
 		}
 		startOpts.Command = cmd
 	}
+	if credentialEnv != "" {
+		startOpts.CommandPrefix = config.CredentialExportPrefix(credentialEnv, acctHandle)
+	}
 	if err := polecatSessMgr.Start(s.PolecatName, startOpts); err != nil {
 		return "", fmt.Errorf("starting session: %w", err)
 	}
+	if credentialEnv != "" {
+		// Let the quota scanner attribute rate limits to this account.
+		_ = t.SetEnvironment(s.SessionName, "GT_QUOTA_ACCOUNT", acctHandle)
+	}
 
 	// Wait for runtime to be fully ready before returning.
 	// When an agent override is specified (e.g., --agent codex), resolve the runtime
@@ -397,7 +434,9 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 	} else {
 		runtimeConfig = config.ResolveRoleAgentConfig("polecat", spawnTownRoot, r.Path)
 	}
-	if err := t.WaitForRuntimeReady(s.SessionName, runtimeConfig, 30*time.Second); err != nil {
+	if s.worker {
+		fmt.Printf("%s Running as structured worker (gt worker status %s)\n", style.Dim.Render("○"), s.SessionName)
+	} else if err := t.WaitForRuntimeReady(s.SessionName, runtimeConfig, 30*time.Second); err != nil {
 		style.PrintWarning("runtime may not be fully ready: %v", err)
 	}
 
@@ -432,6 +471,44 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 	return pane, nil
 }
 
+// resolveSpawnAccount resolves the account for a new polecat and returns its
+// handle, registered details (nil when no accounts are configured), and
+// expanded config dir. An explicit --account or GT_ACCOUNT wins; otherwise,
+// when the town uses the balanced strategy, the least-loaded available
+// account of provider is chosen so dispatch spreads across that provider's
+// pool. Falls back to the default account if balancing is unavailable (e.g.
+// every account is rate-limited).
+func resolveSpawnAccount(townRoot, accountsPath, accountFlag, provider string) (string, *config.Account, string, error) {
+	acctCfg, loadErr := config.LoadAccountsConfig(accountsPath)
+	if loadErr == nil && accountFlag == "" && os.Getenv("GT_ACCOUNT") == "" &&
+		acctCfg.Strategy == config.AccountStrategyBalanced {
+		pool := &config.AccountsConfig{Accounts: acctCfg.AccountsForProvider(provider)}
+		if handle := pickBalancedAccount(townRoot, pool); handle != "" {
+			accountFlag = handle
+		}
+	}
+	configDir, handle, err := config.ResolveAccountConfigDir(accountsPath, accountFlag)
+	if err != nil || handle == "" || loadErr != nil {
+		return handle, nil, configDir, err
+	}
+	return handle, acctCfg.GetAccount(handle), configDir, nil
+}
+
+// pickBalancedAccount reserves the least-loaded available account.
+// Returns "" on any failure so callers fall back to the default account.
+func pickBalancedAccount(townRoot string, acctCfg *config.AccountsConfig) string {
+	var usage map[string]int
+	if scanner, err := quota.NewScanner(tmux.NewTmux(), nil, acctCfg); err == nil {
+		usage, _ = scanner.AccountUsage()
+	}
+	handle, err := quota.NewManager(townRoot).SelectBalancedAccount(acctCfg, usage)
+	if err != nil {
+		style.PrintWarning("balanced account selection failed, using default: %v", err)
+		return ""
+	}
+	return handle
+}
+
 // IsRigName checks if a target string is a rig name (not a role or path).
 // Returns the rig name and true if it's a valid rig.
 func IsRigName(target string) (string, bool) {
@@ -171,6 +171,7 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 
 	outputMoleculeContext(ctx)
 	outputCheckpointContext(ctx)
+	outputCheckpointSnapshots(ctx)
 	runPrimeExternalTools(cwd)
 
 	if ctx.Role == RoleMayor {
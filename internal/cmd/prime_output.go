@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/templates"
+	"github.com/steveyegge/gastown/internal/transcript"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -571,6 +572,33 @@ func outputHandoffWarning(prevSession string) {
 	fmt.Println()
 }
 
+// outputHandoffTranscript renders the transcript summary attached to the
+// predecessor's handoff mail: what it changed, which commands failed, and
+// where its plan stood. Shown in full because the hooked-work view truncates
+// the mail body.
+func outputHandoffTranscript(workDir, mailID string) {
+	if mailID == "" {
+		return
+	}
+	townRoot, err := workspace.Find(workDir)
+	if err != nil || townRoot == "" {
+		return
+	}
+	issue, err := beads.New(townRoot).Show(mailID)
+	if err != nil || issue == nil {
+		return
+	}
+	summary, ok := transcript.Extract(issue.Description)
+	if !ok {
+		return
+	}
+	fmt.Printf("%s\n\n", style.Bold.Render("## 🧾 What Your Predecessor Tried"))
+	fmt.Println(summary)
+	fmt.Println()
+	fmt.Println(style.Dim.Render("(From handoff mail " + mailID + ". Don't repeat failed commands without a new idea.)"))
+	fmt.Println()
+}
+
 // outputState outputs only the session state (for --state flag).
 // If jsonOutput is true, outputs JSON format instead of key:value.
 func outputState(ctx RoleContext, jsonOutput bool) {
@@ -676,6 +704,39 @@ func outputCheckpointContext(ctx RoleContext) {
 	fmt.Println()
 }
 
+// outputCheckpointSnapshots lists snapshots of uncommitted work left by
+// earlier sessions of this worker. Unlike the checkpoint file, snapshots live
+// in the shared repo and survive the worktree being nuked or repaired.
+func outputCheckpointSnapshots(ctx RoleContext) {
+	if (ctx.Role != RolePolecat && ctx.Role != RoleCrew) || ctx.Polecat == "" {
+		return
+	}
+
+	snaps, err := checkpoint.ListSnapshots(ctx.WorkDir, ctx.Polecat)
+	if err != nil || len(snaps) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("%s\n\n", style.Bold.Render("## 💾 Saved Uncommitted Work"))
+	fmt.Printf("A previous session's uncommitted changes were saved (%d snapshot(s)):\n\n", len(snaps))
+	maxShow := min(3, len(snaps))
+	for _, s := range snaps[:maxShow] {
+		line := fmt.Sprintf("  - %s (%s ago", s.Name(), s.Age().Round(time.Minute))
+		if s.Reason != "" {
+			line += ", " + s.Reason
+		}
+		fmt.Println(line + ")")
+	}
+	if len(snaps) > maxShow {
+		fmt.Printf("  ... and %d more (gt checkpoint list)\n", len(snaps)-maxShow)
+	}
+	fmt.Println()
+	fmt.Println("If this work is not already in your worktree, inspect it with `git show --stat <ref>`")
+	fmt.Println("and restore it with `" + cli.Name() + " checkpoint restore <name>`.")
+	fmt.Println()
+}
+
 // outputDeaconPausedMessage outputs a prominent PAUSED message for the Deacon.
 // When paused, the Deacon must not perform any patrol actions.
 func outputDeaconPausedMessage(state *deacon.PauseState) {
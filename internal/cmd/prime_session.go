@@ -221,7 +221,7 @@ func detectSessionState(ctx RoleContext) SessionState {
 	markerPath := filepath.Join(ctx.WorkDir, constants.DirRuntime, constants.FileHandoffMarker)
 	if data, err := os.ReadFile(markerPath); err == nil {
 		state.State = "post-handoff"
-		state.PrevSession = strings.TrimSpace(string(data))
+		state.PrevSession, _, _ = parseHandoffMarker(data)
 		return state
 	}
 
@@ -314,7 +314,9 @@ func detectSessionState(ctx RoleContext) SessionState {
 // and incorrectly runs it again. The marker tells the new session: "handoff is DONE,
 // the /handoff you see in context was from YOUR PREDECESSOR, not a request for you."
 //
-// The marker format is: "session_id\nreason" (reason is optional, on second line).
+// The marker format is: "session_id\nreason\nmail_id" (reason and the handoff
+// mail ID are optional). The mail ID lets the new session render the
+// predecessor's transcript summary in full.
 // When present, the reason is stored in primeHandoffReason for compact/resume detection.
 // This enables compaction-triggered handoff cycles to route through the lighter
 // compact/resume path instead of full re-initialization. (GH#1965)
@@ -326,18 +328,15 @@ func checkHandoffMarker(workDir string) {
 		return
 	}
 
-	// Parse marker: first line is session ID, optional second line is reason
-	lines := strings.SplitN(strings.TrimSpace(string(data)), "\n", 2)
-	prevSession := strings.TrimSpace(lines[0])
-	if len(lines) > 1 {
-		primeHandoffReason = strings.TrimSpace(lines[1])
-	}
+	prevSession, reason, mailID := parseHandoffMarker(data)
+	primeHandoffReason = reason
 
 	// Remove the marker FIRST so we don't warn twice
 	_ = os.Remove(markerPath)
 
 	// Output prominent warning
 	outputHandoffWarning(prevSession)
+	outputHandoffTranscript(workDir, mailID)
 }
 
 // checkHandoffMarkerDryRun checks for handoff marker without removing it (for --dry-run).
@@ -350,15 +349,27 @@ func checkHandoffMarkerDryRun(workDir string) {
 		return
 	}
 
-	// Parse marker: first line is session ID, optional second line is reason
-	lines := strings.SplitN(strings.TrimSpace(string(data)), "\n", 2)
-	prevSession := strings.TrimSpace(lines[0])
-	if len(lines) > 1 {
-		primeHandoffReason = strings.TrimSpace(lines[1])
-	}
+	prevSession, reason, mailID := parseHandoffMarker(data)
+	primeHandoffReason = reason
 
 	explain(true, fmt.Sprintf("Post-handoff: marker found (predecessor: %s, reason: %s), marker NOT removed in dry-run", prevSession, primeHandoffReason))
 
 	// Output the warning but don't remove marker
 	outputHandoffWarning(prevSession)
+	outputHandoffTranscript(workDir, mailID)
+}
+
+// parseHandoffMarker splits a handoff marker into the predecessor's session,
+// the handoff reason, and the ID of the handoff mail. Only the session is
+// required; an empty line stands in for a missing reason.
+func parseHandoffMarker(data []byte) (session, reason, mailID string) {
+	lines := strings.SplitN(strings.TrimSpace(string(data)), "\n", 3)
+	session = strings.TrimSpace(lines[0])
+	if len(lines) > 1 {
+		reason = strings.TrimSpace(lines[1])
+	}
+	if len(lines) > 2 {
+		mailID = strings.TrimSpace(lines[2])
+	}
+	return session, reason, mailID
 }
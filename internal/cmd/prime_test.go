@@ -843,6 +843,25 @@ func TestCheckHandoffMarkerParsesReason(t *testing.T) {
 	})
 }
 
+func TestParseHandoffMarker(t *testing.T) {
+	tests := []struct {
+		data                    string
+		session, reason, mailID string
+	}{
+		{"sess-1", "sess-1", "", ""},
+		{"sess-1\ncompaction\n", "sess-1", "compaction", ""},
+		{"sess-1\n\nhq-abc", "sess-1", "", "hq-abc"},
+		{"sess-1\ncompaction\nhq-abc", "sess-1", "compaction", "hq-abc"},
+	}
+	for _, tt := range tests {
+		session, reason, mailID := parseHandoffMarker([]byte(tt.data))
+		if session != tt.session || reason != tt.reason || mailID != tt.mailID {
+			t.Errorf("parseHandoffMarker(%q) = %q, %q, %q; want %q, %q, %q",
+				tt.data, session, reason, mailID, tt.session, tt.reason, tt.mailID)
+		}
+	}
+}
+
 // TestOutputContinuationDirective tests that the continuation directive
 // outputs the expected content without the full autonomous mode block (GH#1965).
 func TestOutputContinuationDirective(t *testing.T) {
@@ -45,7 +45,19 @@ Commands:
   gt quota status            Show account quota status
   gt quota scan              Detect rate-limited sessions
   gt quota rotate            Swap blocked sessions to available accounts
-  gt quota clear             Mark account(s) as available again`,
+  gt quota clear             Mark account(s) as available again
+
+Automatic management:
+  Once accounts are registered, the daemon's quota patrol runs the same
+  scan on every heartbeat, records when each limited account resets,
+  re-enables accounts once their reset time passes, and rotates idle
+  rate-limited polecats and crew onto available accounts. To turn it off,
+  set in mayor/daemon.json:
+
+    "patrols": { "quota": { "enabled": false } }
+
+  To avoid limits in the first place, spread new polecats across the pool
+  with: gt account strategy balanced`,
 }
 
 var quotaStatusCmd = &cobra.Command{
@@ -66,6 +78,7 @@ Examples:
 type QuotaStatusItem struct {
 	Handle    string `json:"handle"`
 	Email     string `json:"email"`
+	Provider  string `json:"provider"`
 	Status    string `json:"status"`
 	LimitedAt string `json:"limited_at,omitempty"`
 	ResetsAt  string `json:"resets_at,omitempty"`
@@ -129,6 +142,7 @@ func printQuotaStatusJSON(acctCfg *config.AccountsConfig, state *config.QuotaSta
 		items = append(items, QuotaStatusItem{
 			Handle:    handle,
 			Email:     acct.Email,
+			Provider:  acct.ProviderName(),
 			Status:    status,
 			LimitedAt: qs.LimitedAt,
 			ResetsAt:  qs.ResetsAt,
@@ -148,8 +162,30 @@ func printQuotaStatusText(acctCfg *config.AccountsConfig, state *config.QuotaSta
 	fmt.Println(style.Bold.Render("Account Quota Status"))
 	fmt.Println()
 
-	for _, handle := range slices.Sorted(maps.Keys(acctCfg.Accounts)) {
+	// Quota is tracked per provider: group accounts by provider, with a
+	// heading per provider when the town runs more than one.
+	handles := slices.Sorted(maps.Keys(acctCfg.Accounts))
+	slices.SortStableFunc(handles, func(a, b string) int {
+		return strings.Compare(acctCfg.Accounts[a].ProviderName(), acctCfg.Accounts[b].ProviderName())
+	})
+	multiProvider := false
+	for _, handle := range handles {
+		if acctCfg.Accounts[handle].ProviderName() != acctCfg.Accounts[handles[0]].ProviderName() {
+			multiProvider = true
+			break
+		}
+	}
+
+	lastProvider := ""
+	for _, handle := range handles {
 		acct := acctCfg.Accounts[handle]
+		if multiProvider && acct.ProviderName() != lastProvider {
+			if lastProvider != "" {
+				fmt.Println()
+			}
+			lastProvider = acct.ProviderName()
+			fmt.Printf(" %s\n", style.Bold.Render(lastProvider))
+		}
 		qs := state.Accounts[handle]
 		status := qs.Status
 		if status == "" {
@@ -263,16 +299,10 @@ func updateQuotaState(townRoot string, results []quota.ScanResult, acctCfg *conf
 		}
 		mgr.EnsureAccountsTracked(state, acctCfg.Accounts)
 
-		now := time.Now().UTC().Format(time.RFC3339)
+		now := time.Now()
 		for _, r := range results {
 			if r.RateLimited && r.AccountHandle != "" {
-				existing := state.Accounts[r.AccountHandle]
-				state.Accounts[r.AccountHandle] = config.AccountQuotaState{
-					Status:    config.QuotaStatusLimited,
-					LimitedAt: now,
-					ResetsAt:  r.ResetsAt,
-					LastUsed:  existing.LastUsed,
-				}
+				state.Accounts[r.AccountHandle] = quota.LimitedState(state.Accounts[r.AccountHandle], r.ResetsAt, now)
 			}
 		}
 
@@ -343,10 +373,11 @@ it's not disruptive.
 
 The rotation process:
   1. Scans all Gas Town sessions for rate-limit indicators
-  2. Selects available accounts (LRU order)
-  3. Swaps macOS Keychain credentials (same config dir preserved)
+  2. Selects available accounts of the same provider (LRU order)
+  3. Swaps macOS Keychain credentials (same config dir preserved), or for
+     API-key accounts (codex, gemini, copilot) loads the new credential
   4. Restarts blocked sessions via respawn-pane
-  5. Sends /resume to recover conversation context
+  5. Sends /resume to recover conversation context (Claude accounts)
 
 Examples:
   gt quota rotate                    # Rotate all blocked sessions
@@ -535,7 +566,12 @@ func runQuotaRotate(cmd *cobra.Command, args []string) error {
 	var results []quota.RotateResult
 	for _, session := range sortedSessions {
 		newAccount := plan.Assignments[session]
-		result := executeKeychainRotation(t, mgr, acctCfg, session, newAccount, swappedConfigDirs)
+		var result quota.RotateResult
+		if acctCfg.Accounts[newAccount].UsesCredential() {
+			result = executeCredentialRotation(t, mgr, acctCfg, session, newAccount)
+		} else {
+			result = executeKeychainRotation(t, mgr, acctCfg, session, newAccount, swappedConfigDirs)
+		}
 		results = append(results, result)
 
 		if !quotaJSON {
@@ -707,11 +743,69 @@ func executeKeychainRotation(
 	// (the config dir still maps to the old account).
 	restartCmd = fmt.Sprintf("export CLAUDE_CONFIG_DIR=%q && export GT_QUOTA_ACCOUNT=%q && %s", currentConfigDir, newAccount, restartCmd)
 
+	if !respawnRotatedSession(t, mgr, session, newAccount, restartCmd, &result) {
+		return result
+	}
+
+	// Context recovery is handled by --continue in the restart command.
+	result.ResumedSession = "continue"
+	return result
+}
+
+// executeCredentialRotation rotates a session onto an API-key account of the
+// same provider: the agent is restarted with the new account's credential
+// loaded into the provider's credential env var. API-key runtimes keep no
+// per-account conversation state to swap, so the agent restarts with a
+// continuation prompt rather than resuming.
+func executeCredentialRotation(
+	t *ttmux.Tmux,
+	mgr *quota.Manager,
+	acctCfg *config.AccountsConfig,
+	session, newAccount string,
+) quota.RotateResult {
+	result := quota.RotateResult{
+		Session:    session,
+		NewAccount: newAccount,
+	}
+	if old, err := t.GetEnvironment(session, "GT_QUOTA_ACCOUNT"); err == nil {
+		result.OldAccount = strings.TrimSpace(old)
+	}
+
+	newAcct := acctCfg.Accounts[newAccount]
+	envVar := config.GetCredentialEnvVar(newAcct.ProviderName())
+	if envVar == "" {
+		result.Error = fmt.Sprintf("provider %q has no credential env var", newAcct.ProviderName())
+		return result
+	}
+
+	restartCmd, err := buildRestartCommandWithOpts(session, buildRestartCommandOpts{
+		ContinueSession: true,
+		Agent:           newAcct.ProviderName(),
+	})
+	if err != nil {
+		result.Error = fmt.Sprintf("building restart command: %v", err)
+		return result
+	}
+	restartCmd = config.CredentialExportPrefix(envVar, newAccount) + restartCmd
+
+	respawnRotatedSession(t, mgr, session, newAccount, restartCmd, &result)
+	return result
+}
+
+// respawnRotatedSession restarts a session's pane with restartCmd, records
+// the active account in GT_QUOTA_ACCOUNT, and marks newAccount used.
+// Returns false (with result.Error set) if the respawn failed.
+func respawnRotatedSession(
+	t *ttmux.Tmux,
+	mgr *quota.Manager,
+	session, newAccount, restartCmd string,
+	result *quota.RotateResult,
+) bool {
 	// Get target pane
 	pane, err := t.GetPaneID(session)
 	if err != nil {
 		result.Error = fmt.Sprintf("getting pane: %v", err)
-		return result
+		return false
 	}
 
 	// Set remain-on-exit to prevent pane destruction during restart
@@ -729,10 +823,10 @@ func executeKeychainRotation(
 		style.PrintWarning("could not clear history for %s: %v", session, err)
 	}
 
-	// Respawn with same config dir (fresh token already in keychain)
+	// Respawn under the new account
 	if err := t.RespawnPane(pane, restartCmd); err != nil {
 		result.Error = fmt.Sprintf("respawning pane: %v", err)
-		return result
+		return false
 	}
 
 	// Set GT_QUOTA_ACCOUNT in the tmux session environment so the scanner
@@ -742,9 +836,6 @@ func executeKeychainRotation(
 		style.PrintWarning("could not set GT_QUOTA_ACCOUNT for %s: %v", session, err)
 	}
 
-	// Context recovery is handled by --continue in the restart command.
-	result.ResumedSession = "continue"
-
 	// Update quota state: mark account as used
 	if err := mgr.WithLock(func() error {
 		state, loadErr := mgr.Load()
@@ -760,7 +851,7 @@ func executeKeychainRotation(
 	}
 
 	result.Rotated = true
-	return result
+	return true
 }
 
 
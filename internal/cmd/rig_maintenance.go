@@ -0,0 +1,411 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+// Wisp config keys tracking a rig's progress through a maintenance window.
+const (
+	RigMaintenanceKey         = "maintenance"          // Phase: draining, parked or held
+	RigMaintenanceWindowKey   = "maintenance_window"   // Window label
+	RigMaintenanceDeadlineKey = "maintenance_deadline" // RFC3339 time the rig parks
+	RigMaintenanceUntilKey    = "maintenance_until"    // RFC3339 time the window closes
+)
+
+// Maintenance phases stored under RigMaintenanceKey.
+const (
+	// maintenanceDraining: the window is open, dispatch is stopped and
+	// polecats have been told to finish before the deadline.
+	maintenanceDraining = "draining"
+	// maintenanceParked: the drain ended and the window parked the rig; it
+	// unparks when the window closes.
+	maintenanceParked = "parked"
+	// maintenanceHeld: the rig was already parked when the window opened.
+	// It is left alone and stays parked when the window closes.
+	maintenanceHeld = "held"
+)
+
+// maintenanceStep is the transition a tick applies to a rig.
+type maintenanceStep int
+
+const (
+	maintenanceStepNone maintenanceStep = iota
+	maintenanceStepStart
+	maintenanceStepPark
+	maintenanceStepEnd
+)
+
+var rigMaintenanceJSON bool
+
+var rigMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance [rig]...",
+	Short: "Show scheduled maintenance windows",
+	Long: `Show rigs' scheduled maintenance windows and where each rig is in them.
+
+Maintenance windows are configured per rig in <rig>/settings/config.json:
+
+  "maintenance": {
+    "windows": [
+      {"name": "nightly-migrations", "schedule": "0 2 * * *",
+       "duration": "1h", "drain": "10m", "reason": "schema migrations"}
+    ]
+  }
+
+schedule is a 5-field cron expression (local time) for when the window
+opens. While a window is open:
+  1. The scheduler stops dispatching new slings to the rig and gt sling
+     refuses it; scheduled beads wait rather than failing
+  2. Running polecats get a drain notice with a deadline (open + drain,
+     default 15m)
+  3. At the deadline, or once no polecats are running, the rig parks
+  4. When the window closes, the rig unparks
+
+A rig that was already parked when the window opened stays parked.
+The daemon applies these transitions each heartbeat via
+'gt rig maintenance tick', and each one is recorded as an event.
+
+Examples:
+  gt rig maintenance
+  gt rig maintenance gastown --json
+  gt rig maintenance tick`,
+	RunE: runRigMaintenanceStatus,
+}
+
+var rigMaintenanceTickCmd = &cobra.Command{
+	Use:   "tick",
+	Short: "Apply maintenance window transitions (run by the daemon)",
+	Long: `Open, park and close maintenance windows that are due across all rigs.
+
+The daemon runs this every heartbeat for rigs with maintenance windows.
+Running it by hand is safe; a tick with nothing due does nothing.`,
+	Args: cobra.NoArgs,
+	RunE: runRigMaintenanceTick,
+}
+
+func init() {
+	rigMaintenanceCmd.Flags().BoolVar(&rigMaintenanceJSON, "json", false, "Output as JSON")
+	rigMaintenanceCmd.AddCommand(rigMaintenanceTickCmd)
+	rigCmd.AddCommand(rigMaintenanceCmd)
+}
+
+// rigMaintenanceStatus is the JSON output for gt rig maintenance.
+type rigMaintenanceStatus struct {
+	Rig      string                     `json:"rig"`
+	Phase    string                     `json:"phase,omitempty"`
+	Window   string                     `json:"window,omitempty"`
+	Deadline string                     `json:"deadline,omitempty"`
+	Until    string                     `json:"until,omitempty"`
+	Windows  []config.MaintenanceWindow `json:"windows"`
+	Next     string                     `json:"next,omitempty"` // When the next window opens
+}
+
+// nextMaintenanceStep decides what a tick does to a rig given its recorded
+// phase, whether a window is open, and how many polecats are still running.
+func nextMaintenanceStep(phase string, windowOpen bool, now, deadline time.Time, runningPolecats int) maintenanceStep {
+	if !windowOpen {
+		if phase != "" {
+			return maintenanceStepEnd
+		}
+		return maintenanceStepNone
+	}
+	switch phase {
+	case "":
+		return maintenanceStepStart
+	case maintenanceDraining:
+		if runningPolecats == 0 || !now.Before(deadline) {
+			return maintenanceStepPark
+		}
+	}
+	return maintenanceStepNone
+}
+
+// activeMaintenanceWindow returns the maintenance window open on a rig at
+// now, along with the time it closes.
+func activeMaintenanceWindow(townRoot, rigName string, now time.Time) (*config.MaintenanceWindow, time.Time, bool) {
+	cfg, err := config.LoadRigMaintenance(filepath.Join(townRoot, rigName))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	w, start, ok := cfg.ActiveWindow(now)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	return w, start.Add(w.GetDuration()), true
+}
+
+// checkRigNotInMaintenance returns an error if a maintenance window is open
+// on the rig, so no new work is slung to it.
+func checkRigNotInMaintenance(townRoot, rigName string) error {
+	w, until, ok := activeMaintenanceWindow(townRoot, rigName, time.Now())
+	if !ok {
+		return nil
+	}
+	return fmt.Errorf("rig %q is in maintenance window %s until %s", rigName, w.Label(), until.Format("15:04"))
+}
+
+// maintenanceDrainNotice is the nudge sent to running polecats when a
+// window opens.
+func maintenanceDrainNotice(rigName string, w *config.MaintenanceWindow, deadline, until time.Time) string {
+	msg := fmt.Sprintf("MAINTENANCE: rig %s entered maintenance window %q. ", rigName, w.Label())
+	if w.Reason != "" {
+		msg += fmt.Sprintf("Reason: %s. ", w.Reason)
+	}
+	msg += fmt.Sprintf("Finish, commit and push your current work by %s; the rig parks then. "+
+		"No new work is dispatched until %s.", deadline.Format("15:04"), until.Format("15:04"))
+	return msg
+}
+
+func runRigMaintenanceTick(cmd *cobra.Command, args []string) error {
+	rigs, townRoot, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var errs int
+	for _, r := range rigs {
+		if err := tickRigMaintenance(townRoot, r, now); err != nil {
+			fmt.Printf("%s %s: %v\n", style.Error.Render("✗"), r.Name, err)
+			errs++
+		}
+	}
+	if errs > 0 {
+		return fmt.Errorf("maintenance tick failed for %d rig(s)", errs)
+	}
+	return nil
+}
+
+// tickRigMaintenance applies any maintenance transition due on one rig.
+func tickRigMaintenance(townRoot string, r *rig.Rig, now time.Time) error {
+	cfg, err := config.LoadRigMaintenance(r.Path)
+	if err != nil {
+		return fmt.Errorf("loading maintenance windows: %w", err)
+	}
+	wispCfg := wisp.NewConfig(townRoot, r.Name)
+	phase := wispCfg.GetString(RigMaintenanceKey)
+	w, start, open := cfg.ActiveWindow(now)
+
+	t := tmux.NewTmux()
+	polecats, _ := polecat.NewSessionManager(t, r).ListPolecats()
+
+	var deadline time.Time
+	if phase == maintenanceDraining {
+		deadline, _ = time.Parse(time.RFC3339, wispCfg.GetString(RigMaintenanceDeadlineKey))
+	}
+
+	switch nextMaintenanceStep(phase, open, now, deadline, len(polecats)) {
+	case maintenanceStepStart:
+		until := start.Add(w.GetDuration())
+		deadline = start.Add(w.GetDrain())
+		if IsRigParked(townRoot, r.Name) {
+			if err := setRigMaintenance(wispCfg, maintenanceHeld, w.Label(), time.Time{}, until); err != nil {
+				return err
+			}
+			fmt.Printf("%s Rig %s already parked; maintenance window %s leaves it parked\n",
+				style.Dim.Render("○"), r.Name, w.Label())
+			_ = events.LogFeed(events.TypeMaintenanceStarted, "daemon",
+				events.MaintenancePayload(r.Name, w.Label(), "rig already parked"))
+			return nil
+		}
+
+		if err := setRigMaintenance(wispCfg, maintenanceDraining, w.Label(), deadline, until); err != nil {
+			return err
+		}
+		for _, p := range polecats {
+			if err := t.NudgeSession(p.SessionID, maintenanceDrainNotice(r.Name, w, deadline, until)); err != nil {
+				fmt.Printf("  %s Failed to notify %s: %v\n", style.Warning.Render("!"), p.Polecat, err)
+			}
+		}
+		fmt.Printf("%s Rig %s entered maintenance window %s (%d polecat(s) draining until %s)\n",
+			style.Bold.Render("⏸"), r.Name, w.Label(), len(polecats), deadline.Format("15:04"))
+		_ = events.LogFeed(events.TypeMaintenanceStarted, "daemon",
+			events.MaintenancePayload(r.Name, w.Label(),
+				fmt.Sprintf("%d polecat(s) draining until %s", len(polecats), deadline.Format(time.RFC3339))))
+
+		// Park now if there is nothing to drain or the drain already
+		// passed (e.g., the daemon was down when the window opened).
+		if nextMaintenanceStep(maintenanceDraining, true, now, deadline, len(polecats)) != maintenanceStepPark {
+			return nil
+		}
+		fallthrough
+
+	case maintenanceStepPark:
+		if err := parkOneRig(r.Name); err != nil {
+			return fmt.Errorf("parking for maintenance: %w", err)
+		}
+		if err := wispCfg.Set(RigMaintenanceKey, maintenanceParked); err != nil {
+			return fmt.Errorf("recording maintenance phase: %w", err)
+		}
+		detail := "drain complete"
+		if len(polecats) > 0 {
+			detail = fmt.Sprintf("drain deadline passed with %d polecat(s) running", len(polecats))
+		}
+		_ = events.LogFeed(events.TypeMaintenanceParked, "daemon",
+			events.MaintenancePayload(r.Name, wispCfg.GetString(RigMaintenanceWindowKey), detail))
+
+	case maintenanceStepEnd:
+		window := wispCfg.GetString(RigMaintenanceWindowKey)
+		detail := "window closed"
+		// Only unpark what the window parked. A rig the operator unparked
+		// early, or that was parked before the window, is left as it is.
+		if phase == maintenanceParked && IsRigParked(townRoot, r.Name) {
+			if err := unparkOneRig(r.Name); err != nil {
+				return fmt.Errorf("unparking after maintenance: %w", err)
+			}
+			detail = "window closed; rig unparked"
+		}
+		if err := clearRigMaintenance(wispCfg); err != nil {
+			return err
+		}
+		fmt.Printf("%s Rig %s maintenance window %s ended\n", style.Success.Render("✓"), r.Name, window)
+		_ = events.LogFeed(events.TypeMaintenanceEnded, "daemon",
+			events.MaintenancePayload(r.Name, window, detail))
+	}
+	return nil
+}
+
+// setRigMaintenance records a rig's maintenance phase in the wisp layer.
+func setRigMaintenance(wispCfg *wisp.Config, phase, window string, deadline, until time.Time) error {
+	values := map[string]string{
+		RigMaintenanceWindowKey: window,
+		RigMaintenanceUntilKey:  until.Format(time.RFC3339),
+	}
+	if !deadline.IsZero() {
+		values[RigMaintenanceDeadlineKey] = deadline.Format(time.RFC3339)
+	}
+	for key, value := range values {
+		if err := wispCfg.Set(key, value); err != nil {
+			return fmt.Errorf("recording maintenance state: %w", err)
+		}
+	}
+	// Set the phase last so a partial write is retried next tick.
+	if err := wispCfg.Set(RigMaintenanceKey, phase); err != nil {
+		return fmt.Errorf("recording maintenance phase: %w", err)
+	}
+	return nil
+}
+
+// clearRigMaintenance removes a rig's maintenance state from the wisp layer.
+func clearRigMaintenance(wispCfg *wisp.Config) error {
+	for _, key := range []string{RigMaintenanceKey, RigMaintenanceWindowKey, RigMaintenanceDeadlineKey, RigMaintenanceUntilKey} {
+		if err := wispCfg.Unset(key); err != nil {
+			return fmt.Errorf("clearing maintenance state: %w", err)
+		}
+	}
+	return nil
+}
+
+func runRigMaintenanceStatus(cmd *cobra.Command, args []string) error {
+	rigs, townRoot, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		want := make(map[string]bool, len(args))
+		for _, a := range args {
+			want[a] = true
+		}
+		var filtered []*rig.Rig
+		for _, r := range rigs {
+			if want[r.Name] {
+				filtered = append(filtered, r)
+				delete(want, r.Name)
+			}
+		}
+		for name := range want {
+			return fmt.Errorf("rig %q not found", name)
+		}
+		rigs = filtered
+	}
+
+	now := time.Now()
+	var statuses []rigMaintenanceStatus
+	for _, r := range rigs {
+		cfg, err := config.LoadRigMaintenance(r.Path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", r.Name, err)
+		}
+		wispCfg := wisp.NewConfig(townRoot, r.Name)
+		s := rigMaintenanceStatus{
+			Rig:      r.Name,
+			Phase:    wispCfg.GetString(RigMaintenanceKey),
+			Window:   wispCfg.GetString(RigMaintenanceWindowKey),
+			Deadline: wispCfg.GetString(RigMaintenanceDeadlineKey),
+			Until:    wispCfg.GetString(RigMaintenanceUntilKey),
+			Windows:  []config.MaintenanceWindow{},
+		}
+		if cfg != nil {
+			s.Windows = cfg.Windows
+			var next time.Time
+			for _, w := range cfg.Windows {
+				if n := w.Next(now); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+					next = n
+				}
+			}
+			if !next.IsZero() {
+				s.Next = next.Format(time.RFC3339)
+			}
+		}
+		if len(args) == 0 && len(s.Windows) == 0 && s.Phase == "" {
+			continue
+		}
+		statuses = append(statuses, s)
+	}
+
+	if rigMaintenanceJSON {
+		if statuses == nil {
+			statuses = []rigMaintenanceStatus{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statuses)
+	}
+
+	if len(statuses) == 0 {
+		fmt.Println("No rigs have maintenance windows")
+		return nil
+	}
+	for i, s := range statuses {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s\n", style.Bold.Render(s.Rig))
+		switch s.Phase {
+		case maintenanceDraining:
+			fmt.Printf("  %s in window %s: draining, parks at %s\n", style.Warning.Render("⏸"), s.Window, formatMaintenanceTime(s.Deadline))
+		case maintenanceParked:
+			fmt.Printf("  %s in window %s: parked until %s\n", style.Warning.Render("⏸"), s.Window, formatMaintenanceTime(s.Until))
+		case maintenanceHeld:
+			fmt.Printf("  %s in window %s: already parked, left parked\n", style.Dim.Render("○"), s.Window)
+		}
+		for _, w := range s.Windows {
+			fmt.Printf("  %-20s %-15s for %s, drain %s\n", w.Label(), w.Schedule, w.GetDuration(), w.GetDrain())
+		}
+		if s.Next != "" {
+			fmt.Printf("  %s\n", style.Dim.Render("next window opens "+formatMaintenanceTime(s.Next)))
+		}
+	}
+	return nil
+}
+
+// formatMaintenanceTime renders a stored RFC3339 time for display.
+func formatMaintenanceTime(s string) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return t.Local().Format("Mon Jan 2 15:04")
+}
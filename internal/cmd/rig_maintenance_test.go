@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+func TestNextMaintenanceStep(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	tests := []struct {
+		name     string
+		phase    string
+		open     bool
+		deadline time.Time
+		running  int
+		want     maintenanceStep
+	}{
+		{"no window", "", false, time.Time{}, 2, maintenanceStepNone},
+		{"window opens", "", true, time.Time{}, 2, maintenanceStepStart},
+		{"draining before deadline", maintenanceDraining, true, now.Add(time.Minute), 2, maintenanceStepNone},
+		{"drain deadline passed", maintenanceDraining, true, now.Add(-time.Minute), 2, maintenanceStepPark},
+		{"all polecats drained", maintenanceDraining, true, now.Add(time.Minute), 0, maintenanceStepPark},
+		{"parked during window", maintenanceParked, true, time.Time{}, 0, maintenanceStepNone},
+		{"held during window", maintenanceHeld, true, time.Time{}, 0, maintenanceStepNone},
+		{"window closes while parked", maintenanceParked, false, time.Time{}, 0, maintenanceStepEnd},
+		{"window closes while draining", maintenanceDraining, false, now.Add(time.Minute), 1, maintenanceStepEnd},
+		{"window closes while held", maintenanceHeld, false, time.Time{}, 0, maintenanceStepEnd},
+	}
+	for _, tt := range tests {
+		if got := nextMaintenanceStep(tt.phase, tt.open, now, tt.deadline, tt.running); got != tt.want {
+			t.Errorf("%s: nextMaintenanceStep() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckRigNotInMaintenance(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	writeMaintenanceSettings := func(rigName, schedule string) {
+		path := config.RigSettingsPath(filepath.Join(townRoot, rigName))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		settings := `{"type": "rig-settings", "version": 1, "maintenance": {"windows": [
+			{"name": "freeze", "schedule": "` + schedule + `", "duration": "2h"}]}}`
+		if err := os.WriteFile(path, []byte(settings), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeMaintenanceSettings("always", "* * * * *")
+	writeMaintenanceSettings("never", "0 0 31 2 *") // February 31st
+
+	err := checkRigNotInMaintenance(townRoot, "always")
+	if err == nil || !strings.Contains(err.Error(), "maintenance window freeze") {
+		t.Errorf("checkRigNotInMaintenance(always) = %v, want maintenance error", err)
+	}
+	if err := checkRigNotInMaintenance(townRoot, "never"); err != nil {
+		t.Errorf("checkRigNotInMaintenance(never) = %v", err)
+	}
+	if err := checkRigNotInMaintenance(townRoot, "unconfigured"); err != nil {
+		t.Errorf("checkRigNotInMaintenance(unconfigured) = %v", err)
+	}
+}
+
+func TestMaintenanceDrainNotice(t *testing.T) {
+	t.Parallel()
+	deadline := time.Date(2026, 3, 2, 2, 10, 0, 0, time.Local)
+	until := deadline.Add(50 * time.Minute)
+	w := &config.MaintenanceWindow{Name: "nightly", Reason: "schema migrations"}
+
+	msg := maintenanceDrainNotice("gastown", w, deadline, until)
+	for _, want := range []string{"gastown", `"nightly"`, "schema migrations", "by 02:10", "until 03:00"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("drain notice %q missing %q", msg, want)
+		}
+	}
+}
+
+func TestSetAndClearRigMaintenance(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	wispCfg := wisp.NewConfig(townRoot, "gastown")
+	deadline := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	until := deadline.Add(time.Hour)
+
+	if err := setRigMaintenance(wispCfg, maintenanceDraining, "nightly", deadline, until); err != nil {
+		t.Fatalf("setRigMaintenance() error: %v", err)
+	}
+	if got := wispCfg.GetString(RigMaintenanceKey); got != maintenanceDraining {
+		t.Errorf("phase = %q, want draining", got)
+	}
+	got, err := time.Parse(time.RFC3339, wispCfg.GetString(RigMaintenanceDeadlineKey))
+	if err != nil || !got.Equal(deadline) {
+		t.Errorf("deadline = %v (%v), want %v", got, err, deadline)
+	}
+
+	if err := clearRigMaintenance(wispCfg); err != nil {
+		t.Fatalf("clearRigMaintenance() error: %v", err)
+	}
+	for _, key := range []string{RigMaintenanceKey, RigMaintenanceWindowKey, RigMaintenanceDeadlineKey, RigMaintenanceUntilKey} {
+		if v := wispCfg.GetString(key); v != "" {
+			t.Errorf("%s = %q after clear", key, v)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	rigRepoBranch  string
+	rigRepoPushURL string
+)
+
+var rigRepoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Manage member repositories of a multi-repo rig",
+	Long: `Manage additional repositories that belong to a rig.
+
+A rig's own repository stays the primary. Each member repo gets a shared
+bare repo under <rig>/.repos/ and a refinery worktree under
+<rig>/refinery/repos/. Every polecat gets a worktree of each member next
+to its primary worktree, on the same branch:
+
+  polecats/<name>/<rig>/     primary repo
+  polecats/<name>/<repo>/    member repo
+
+'gt done' pushes every repo with commits and records the branches on the
+MR. The refinery gates the set together and lands it atomically: either
+every repo's target branch moves, or none does.`,
+	RunE: requireSubcommand,
+}
+
+var rigRepoAddCmd = &cobra.Command{
+	Use:   "add <rig> <name> <git-url>",
+	Short: "Add a member repository to a rig",
+	Long: `Clone a member repository into a rig.
+
+New polecats get a worktree of it automatically. Existing polecats pick it
+up on their next spawn or repair.
+
+Examples:
+  gt rig repo add product web git@github.com:acme/web.git
+  gt rig repo add product proto https://github.com/acme/proto.git --branch develop`,
+	Args: cobra.ExactArgs(3),
+	RunE: runRigRepoAdd,
+}
+
+var rigRepoListCmd = &cobra.Command{
+	Use:   "list <rig>",
+	Short: "List a rig's member repositories",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRigRepoList,
+}
+
+var rigRepoRemoveCmd = &cobra.Command{
+	Use:   "remove <rig> <name>",
+	Short: "Remove a member repository from a rig",
+	Long: `Remove a member repository from a rig.
+
+Refuses while any polecat still has a worktree of the repo.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRigRepoRemove,
+}
+
+func init() {
+	rigRepoAddCmd.Flags().StringVar(&rigRepoBranch, "branch", "", "Default branch (default: the remote's HEAD)")
+	rigRepoAddCmd.Flags().StringVar(&rigRepoPushURL, "push-url", "", "Push URL, if it differs from the fetch URL")
+
+	rigRepoCmd.AddCommand(rigRepoAddCmd)
+	rigRepoCmd.AddCommand(rigRepoListCmd)
+	rigRepoCmd.AddCommand(rigRepoRemoveCmd)
+	rigCmd.AddCommand(rigRepoCmd)
+}
+
+// rigRepoManager returns a rig manager for member repo operations on rigName.
+func rigRepoManager(rigName string) (*rig.Manager, *rig.Rig, error) {
+	townRoot, r, err := getRig(rigName)
+	if err != nil {
+		return nil, nil, err
+	}
+	rigsConfig := &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	return rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot)), r, nil
+}
+
+func runRigRepoAdd(cmd *cobra.Command, args []string) error {
+	rigName, name, url := args[0], args[1], args[2]
+	mgr, _, err := rigRepoManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Adding repo %s to rig %s...\n", style.Bold.Render(name), rigName)
+	repo := rig.MemberRepo{
+		Name:          name,
+		GitURL:        url,
+		PushURL:       rigRepoPushURL,
+		DefaultBranch: rigRepoBranch,
+	}
+	if err := mgr.AddMemberRepo(rigName, repo); err != nil {
+		return err
+	}
+	fmt.Printf("%s Added repo %s\n", style.Success.Render("✓"), name)
+	fmt.Printf("  %s\n", style.Dim.Render("New polecats get a worktree at polecats/<name>/"+name))
+	return nil
+}
+
+func runRigRepoList(cmd *cobra.Command, args []string) error {
+	_, r, err := rigRepoManager(args[0])
+	if err != nil {
+		return err
+	}
+	repos, err := rig.LoadMemberRepos(r.Path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s (primary, %s)\n", style.Bold.Render(r.Name), r.GitURL, r.DefaultBranch())
+	if len(repos) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("No member repos"))
+		return nil
+	}
+	for _, repo := range repos {
+		fmt.Printf("  %s %s (%s)\n", style.Bold.Render(repo.Name), repo.GitURL, repo.Branch())
+	}
+	return nil
+}
+
+func runRigRepoRemove(cmd *cobra.Command, args []string) error {
+	rigName, name := args[0], args[1]
+	mgr, _, err := rigRepoManager(rigName)
+	if err != nil {
+		return err
+	}
+	if err := mgr.RemoveMemberRepo(rigName, name); err != nil {
+		return err
+	}
+	fmt.Printf("%s Removed repo %s from rig %s\n", style.Success.Render("✓"), name, rigName)
+	return nil
+}
@@ -71,6 +71,8 @@ var beadsExemptCommands = map[string]bool{
 	"run-migration":       true, // Migration orchestrator handles its own beads checks
 	"health":              true, // Health check doesn't require beads
 	"upgrade":             true, // Post-install migration orchestrator
+	"relay":               true, // gt sandbox relay runs beside every sandboxed session
+	"forward":             true, // gt sandbox forward runs inside the sandbox, without bd
 }
 
 // Commands exempt from the town root branch warning.
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/sandbox"
+)
+
+var (
+	sandboxDir   string
+	sandboxAllow []string
+)
+
+var sandboxCmd = &cobra.Command{
+	Use:    "sandbox",
+	Short:  "Sandbox network plumbing (internal use)",
+	Hidden: true,
+	Long: `Internal commands started by the bubblewrap sandbox driver when a
+session's network mode is "allowlist".
+
+The relay runs on the host and listens on one unix socket per allowed
+endpoint. The forwarder runs inside the sandbox, whose network namespace
+has only a loopback interface, and serves each endpoint on a loopback
+address that the sandbox's /etc/hosts maps the host name to. Connections
+to anything else have nowhere to go.
+
+Both exit when the process that started them goes away.`,
+	RunE: requireSubcommand,
+}
+
+var sandboxRelayCmd = &cobra.Command{
+	Use:   "relay",
+	Short: "Relay sandbox sockets to allowed endpoints (runs outside the sandbox)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSandboxPlumbing(sandbox.Relay)
+	},
+}
+
+var sandboxForwardCmd = &cobra.Command{
+	Use:   "forward",
+	Short: "Serve allowed endpoints on loopback (runs inside the sandbox)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSandboxPlumbing(sandbox.Forward)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{sandboxRelayCmd, sandboxForwardCmd} {
+		c.Flags().StringVar(&sandboxDir, "dir", "", "Sandbox runtime directory holding the relay sockets")
+		c.Flags().StringArrayVar(&sandboxAllow, "allow", nil, "Allowed host:port endpoint (repeatable)")
+		_ = c.MarkFlagRequired("dir")
+		sandboxCmd.AddCommand(c)
+	}
+	rootCmd.AddCommand(sandboxCmd)
+}
+
+// runSandboxPlumbing runs the relay or forwarder until the parent process
+// exits (the parent pid changes when the process is reparented).
+func runSandboxPlumbing(run func(context.Context, string, []sandbox.Endpoint) error) error {
+	eps, err := sandbox.ParseEndpoints(sandboxAllow)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	parent := os.Getppid()
+	go func() {
+		for os.Getppid() == parent {
+			time.Sleep(time.Second)
+		}
+		cancel()
+	}()
+	return run(ctx, sandboxDir, eps)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/scheduler/capacity"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -40,13 +41,23 @@ Subcommands:
 
 Config:
   gt config set scheduler.max_polecats 5    # Enable deferred dispatch
-  gt config set scheduler.max_polecats -1   # Direct dispatch (default)`,
+  gt config set scheduler.max_polecats -1   # Direct dispatch (default)
+
+Host pressure:
+  Before spawning, each dispatch cycle samples CPU load, memory, disk free
+  in the town root, Dolt query latency and the memory of tracked agent
+  sessions. A metric at its high watermark holds dispatch until every
+  metric is back at or below its low watermark.
+
+  gt config set scheduler.pressure.memory_used_pct.high 85
+  gt config set scheduler.pressure.session_memory_mb.high 12000
+  gt config set scheduler.pressure.load_per_cpu.high 0      # Disable a metric`,
 	RunE: requireSubcommand,
 }
 
 var schedulerStatusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Show scheduler state: pending, capacity, active polecats",
+	Short: "Show scheduler state: pending, capacity, active polecats, host pressure",
 	RunE:  runSchedulerStatus,
 }
 
@@ -144,14 +155,30 @@ func runSchedulerStatus(cmd *cobra.Command, args []string) error {
 
 	activePolecats := countActivePolecats()
 
+	var schedulerCfg *capacity.SchedulerConfig
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+		schedulerCfg = settings.Scheduler
+	}
+	marks := schedulerCfg.GetPressureWatermarks()
+	sample := sampleHostPressure(townRoot)
+	verdict := capacity.EvaluatePressure(marks, sample, state.Throttled)
+
 	if schedulerStatusJSON {
+		type hostPressure struct {
+			Throttled      bool                          `json:"throttled"`
+			Reason         string                        `json:"reason,omitempty"`
+			ThrottledSince string                        `json:"throttled_since,omitempty"`
+			Sample         capacity.HostSample           `json:"sample"`
+			Watermarks     map[string]capacity.Watermark `json:"watermarks"`
+		}
 		out := struct {
-			Paused         bool               `json:"paused"`
-			PausedBy       string             `json:"paused_by,omitempty"`
-			ScheduledTotal int                `json:"queued_total"`
-			ScheduledReady int                `json:"queued_ready"`
-			ActivePolecats int                `json:"active_polecats"`
-			LastDispatchAt string             `json:"last_dispatch_at,omitempty"`
+			Paused         bool                `json:"paused"`
+			PausedBy       string              `json:"paused_by,omitempty"`
+			ScheduledTotal int                 `json:"queued_total"`
+			ScheduledReady int                 `json:"queued_ready"`
+			ActivePolecats int                 `json:"active_polecats"`
+			LastDispatchAt string              `json:"last_dispatch_at,omitempty"`
+			HostPressure   hostPressure        `json:"host_pressure"`
 			Beads          []scheduledBeadInfo `json:"beads"`
 		}{
 			Paused:         state.Paused,
@@ -159,7 +186,17 @@ func runSchedulerStatus(cmd *cobra.Command, args []string) error {
 			ScheduledTotal: len(scheduled),
 			ActivePolecats: activePolecats,
 			LastDispatchAt: state.LastDispatchAt,
-			Beads:          scheduled,
+			HostPressure: hostPressure{
+				Throttled:      verdict.Throttled,
+				Reason:         verdict.Reason,
+				ThrottledSince: state.ThrottledSince,
+				Sample:         sample,
+				Watermarks:     make(map[string]capacity.Watermark),
+			},
+			Beads: scheduled,
+		}
+		for _, m := range marks {
+			out.HostPressure.Watermarks[m.Metric] = m.Watermark
 		}
 		for _, b := range scheduled {
 			if !b.Blocked {
@@ -189,6 +226,15 @@ func runSchedulerStatus(cmd *cobra.Command, args []string) error {
 	if state.LastDispatchAt != "" {
 		fmt.Printf("  Last dispatch: %s (%d beads)\n", state.LastDispatchAt, state.LastDispatchCount)
 	}
+	if verdict.Throttled {
+		fmt.Printf("  Host:      %s: %s\n", style.Warning.Render("THROTTLED"), verdict.Reason)
+		if state.ThrottledSince != "" {
+			fmt.Printf("             %s\n", style.Dim.Render("since "+state.ThrottledSince))
+		}
+	} else {
+		fmt.Printf("  Host:      ok\n")
+	}
+	printHostPressure(marks, sample)
 
 	return nil
 }
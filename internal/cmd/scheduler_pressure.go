@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/scheduler/hostload"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// doltSnapshotMaxAge is how old the daemon's Dolt health snapshot may be
+// before the scheduler measures query latency itself.
+const doltSnapshotMaxAge = 10 * time.Minute
+
+// sampleHostPressure measures every host pressure metric for the town.
+func sampleHostPressure(townRoot string) capacity.HostSample {
+	var pids []int
+	for _, pid := range session.TrackedPIDs(townRoot) {
+		pids = append(pids, pid)
+	}
+	sample := hostload.Sample(townRoot, pids)
+
+	if snap := doltserver.LoadHealthSnapshot(townRoot, doltSnapshotMaxAge); snap != nil && snap.QueryLatency > 0 {
+		sample[capacity.MetricDoltLatencyMs] = float64(snap.QueryLatency.Milliseconds())
+	} else if latency, err := doltserver.MeasureQueryLatency(townRoot); err == nil {
+		sample[capacity.MetricDoltLatencyMs] = float64(latency.Milliseconds())
+	}
+	return sample
+}
+
+// checkHostPressure samples the host and evaluates it against the configured
+// watermarks, honoring the throttled state of the previous cycle. With
+// record set, the sample and verdict are saved for gt scheduler status.
+func checkHostPressure(townRoot string, cfg *capacity.SchedulerConfig, record bool) capacity.PressureVerdict {
+	sample := sampleHostPressure(townRoot)
+
+	state, err := capacity.LoadState(townRoot)
+	if err != nil {
+		state = &capacity.SchedulerState{}
+	}
+	verdict := capacity.EvaluatePressure(cfg.GetPressureWatermarks(), sample, state.Throttled)
+	if !record {
+		return verdict
+	}
+	state.RecordPressure(sample, verdict)
+	if err := capacity.SaveState(townRoot, state); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Could not save scheduler state: %v\n", style.Dim.Render("Warning:"), err)
+	}
+	return verdict
+}
+
+// printHostPressure prints each pressure metric against its watermarks.
+func printHostPressure(marks []capacity.MetricWatermark, sample capacity.HostSample) {
+	for _, m := range marks {
+		v, ok := sample[m.Metric]
+		value := style.Dim.Render("n/a")
+		if ok {
+			value = capacity.FormatMetric(v)
+			if m.High > 0 && v >= m.High {
+				value = style.Warning.Render(value)
+			}
+		}
+		limits := style.Dim.Render("(no watermark)")
+		if m.High > 0 {
+			limits = fmt.Sprintf("(low %s, high %s)", capacity.FormatMetric(m.Low), capacity.FormatMetric(m.High))
+		}
+		fmt.Printf("    %-18s %s %s\n", m.Metric+":", value, limits)
+	}
+}
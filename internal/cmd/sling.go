@@ -56,6 +56,15 @@ Target Resolution:
   gt sling gt-abc deacon/dogs           # Auto-dispatch to idle dog
   gt sling gt-abc deacon/dogs/alpha     # Specific dog
 
+Automatic Target Selection (--auto):
+  gt sling gt-abc --auto                # Pick rig + worker for the bead
+
+  The rig comes from the bead prefix. Within it, the polecat pool and running
+  crew members are scored by declared skills (worker_skills in rig settings)
+  matched against bead labels, past outcomes on those labels, and current
+  load. The ranking is printed. With deferred dispatch (scheduler.max_polecats
+  > 0) only the rig is chosen and the bead is scheduled.
+
 Spawning Options (when target is a rig):
   gt sling gp-abc greenplace --create               # Create polecat if missing
   gt sling gp-abc greenplace --force                # Ignore unread mail
@@ -118,7 +127,7 @@ var (
 	// Flags migrated for polecat spawning (used by sling for work assignment)
 	slingCreate        bool   // --create: create polecat if it doesn't exist
 	slingForce         bool   // --force: force spawn even if polecat has unread mail
-	slingAccount       string // --account: Claude Code account handle to use
+	slingAccount       string // --account: account handle to use
 	slingAgent         string // --agent: override runtime agent for this sling/spawn
 	slingNoConvoy      bool   // --no-convoy: skip auto-convoy creation
 	slingOwned         bool   // --owned: mark auto-convoy as caller-managed lifecycle
@@ -129,6 +138,8 @@ var (
 	slingBaseBranch    string // --base-branch: override base branch for polecat worktree
 	slingRalph         bool   // --ralph: enable Ralph Wiggum loop mode for multi-step workflows
 	slingFormula       string // --formula: override formula for dispatch (default: mol-polecat-work)
+	slingAuto          bool   // --auto: pick rig/worker by skills, history, and load
+	slingWorker        bool   // --worker: run spawned polecat in structured worker mode
 )
 
 func init() {
@@ -143,7 +154,7 @@ func init() {
 	// Flags for polecat spawning (when target is a rig)
 	slingCmd.Flags().BoolVar(&slingCreate, "create", false, "Create polecat if it doesn't exist")
 	slingCmd.Flags().BoolVar(&slingForce, "force", false, "Force spawn even if polecat has unread mail")
-	slingCmd.Flags().StringVar(&slingAccount, "account", "", "Claude Code account handle to use")
+	slingCmd.Flags().StringVar(&slingAccount, "account", "", "Account handle to use (API-key accounts also select their agent)")
 	slingCmd.Flags().StringVar(&slingAgent, "agent", "", "Override agent/runtime for this sling (e.g., claude, gemini, codex, or custom alias)")
 	slingCmd.Flags().BoolVar(&slingNoConvoy, "no-convoy", false, "Skip auto-convoy creation for single-issue sling")
 	slingCmd.Flags().BoolVar(&slingOwned, "owned", false, "Mark auto-convoy as caller-managed lifecycle (no automatic witness/refinery registration)")
@@ -155,6 +166,8 @@ func init() {
 	slingCmd.Flags().StringVar(&slingBaseBranch, "base-branch", "", "Override base branch for polecat worktree (e.g., 'develop', 'release/v2')")
 	slingCmd.Flags().BoolVar(&slingRalph, "ralph", false, "Enable Ralph Wiggum loop mode (fresh context per step, for multi-step workflows)")
 	slingCmd.Flags().StringVar(&slingFormula, "formula", "", "Formula to apply (default: mol-polecat-work for polecat targets)")
+	slingCmd.Flags().BoolVar(&slingAuto, "auto", false, "Pick the target automatically by skills, history, and load")
+	slingCmd.Flags().BoolVar(&slingWorker, "worker", false, "Run a spawned polecat as a non-interactive stream-JSON worker (see gt worker)")
 
 	slingCmd.AddCommand(slingRespawnResetCmd)
 	rootCmd.AddCommand(slingCmd)
@@ -269,20 +282,30 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		args[i] = strings.TrimRight(args[i], "/")
 	}
 
+	// Config-driven dispatch mode: check scheduler.max_polecats
+	deferred, deferErr := shouldDeferDispatch()
+	if deferErr != nil {
+		return deferErr
+	}
+
+	// Capability-based target selection: gt sling <bead> --auto
+	// Appends the chosen target so the normal (or deferred) paths below apply.
+	if slingAuto {
+		args, err = resolveAutoTargetArgs(townRoot, args, deferred)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Validate target format early, before any dispatch path (bead, formula, batch)
-	// can trigger resolveTarget side-effects like polecat spawning.
+	// can trigger resolveTarget side-effects like polecat spawning. This runs
+	// after --auto so the chosen target is validated like an explicit one.
 	if len(args) > 1 {
 		if err := ValidateTarget(args[len(args)-1]); err != nil {
 			return err
 		}
 	}
 
-	// Config-driven dispatch mode: check scheduler.max_polecats
-	deferred, deferErr := shouldDeferDispatch()
-	if deferErr != nil {
-		return deferErr
-	}
-
 	// Batch mode detection: multiple beads with optional rig target
 	// Pattern A (explicit rig):  gt sling gt-abc gt-def gt-ghi gastown
 	// Pattern B (auto-resolve):  gt sling gt-abc gt-def gt-ghi
@@ -649,6 +672,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 		BeadID:     beadID,
 		TownRoot:   townRoot,
 		BaseBranch: slingBaseBranch,
+		Worker:     slingWorker,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/scheduler/autotarget"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// autoHistoryLimit caps how many closed beads per label are consulted when
+// building the history signal for --auto. Recent history is what matters.
+const autoHistoryLimit = 100
+
+// autoTargetFn is a seam for tests. Production uses selectAutoTarget.
+var autoTargetFn = selectAutoTarget
+
+// selectAutoTarget picks a sling target for a bead (gt sling --auto).
+//
+// The rig comes from the bead's prefix via routes. Within the rig, the fresh
+// polecat pool and each running crew member are scored by declared skills
+// (rig settings worker_skills), past outcomes on the bead's labels (closed
+// beads and merge-request results), and current load. When polecatsOnly is
+// set (deferred/scheduler dispatch), crew members are not considered and the
+// returned target is always the rig name.
+//
+// The ranking is printed so the caller can see why the target was chosen.
+func selectAutoTarget(townRoot, beadID string, polecatsOnly bool) (string, error) {
+	rigName := resolveRigForBead(townRoot, beadID)
+	if rigName == "" {
+		return "", fmt.Errorf("cannot auto-select target for %s: prefix %q is not mapped to any rig\nSpecify a target explicitly: gt sling %s <rig>",
+			beadID, beads.ExtractPrefix(beadID), beadID)
+	}
+	if blocked, reason := IsRigParkedOrDocked(townRoot, rigName); blocked {
+		return "", fmt.Errorf("cannot auto-select target for %s: rig %q is %s", beadID, rigName, reason)
+	}
+
+	info, err := getBeadInfo(beadID)
+	if err != nil {
+		return "", err
+	}
+
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return "", err
+	}
+
+	skills := loadWorkerSkills(r)
+	candidates := []autotarget.Candidate{polecatPoolCandidate(r, skills)}
+	if !polecatsOnly {
+		candidates = append(candidates, crewCandidates(r, skills)...)
+	}
+
+	labels := autotarget.MatchableLabels(info.Labels)
+	history := loadAutoHistory(r.BeadsPath(), labels)
+	choices := autotarget.Rank(labels, candidates, history)
+
+	printAutoTargetRanking(beadID, labels, choices)
+
+	best, ok := autotarget.Best(choices)
+	if !ok {
+		return "", fmt.Errorf("no eligible target for %s in rig %s (all candidates busy or unavailable)", beadID, rigName)
+	}
+	return best.Target, nil
+}
+
+// loadWorkerSkills returns the rig's declared worker skills, or nil.
+func loadWorkerSkills(r *rig.Rig) map[string][]string {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(r.Path))
+	if err != nil || settings == nil {
+		return nil
+	}
+	return settings.WorkerSkills
+}
+
+// polecatPoolCandidate describes "spawn a fresh polecat in this rig".
+func polecatPoolCandidate(r *rig.Rig, skills map[string][]string) autotarget.Candidate {
+	cand := autotarget.Candidate{
+		Target:     r.Name,
+		Kind:       autotarget.KindPolecat,
+		HistoryKey: r.Name + autotarget.PolecatPoolSuffix,
+		Skills:     skills[config.PolecatSkillsKey],
+		Capacity:   r.GetIntConfig("max_polecats"),
+	}
+	if mgr, _, err := getPolecatManager(r.Name); err == nil {
+		cand.Active, _ = mgr.PoolStatus()
+	}
+	return cand
+}
+
+// crewCandidates describes each crew member in the rig. Crew without a
+// running session are included but marked unavailable, so the ranking can
+// explain why they were skipped.
+func crewCandidates(r *rig.Rig, skills map[string][]string) []autotarget.Candidate {
+	crewMgr, _, err := getCrewManager(r.Name)
+	if err != nil {
+		return nil
+	}
+	workers, err := crewMgr.List()
+	if err != nil {
+		return nil
+	}
+
+	bd := beads.New(r.BeadsPath())
+	var out []autotarget.Candidate
+	for _, w := range workers {
+		address := fmt.Sprintf("%s/crew/%s", r.Name, w.Name)
+		cand := autotarget.Candidate{
+			Target:     address,
+			Kind:       autotarget.KindCrew,
+			HistoryKey: address,
+			Skills:     skills[w.Name],
+			Capacity:   1,
+		}
+		if running, _ := crewMgr.IsRunning(w.Name); !running {
+			cand.Unavailable = "session not running"
+		} else if assigned, err := bd.ListByAssignee(address); err == nil {
+			for _, issue := range assigned {
+				if issue.Status == "hooked" || issue.Status == "in_progress" {
+					cand.Active++
+				}
+			}
+		}
+		out = append(out, cand)
+	}
+	return out
+}
+
+// loadAutoHistory builds per-label outcomes from closed beads. A closed bead
+// counts as a success for its assignee unless its merge request was closed
+// as rejected or conflicting. History is best-effort: query failures just
+// leave the signal empty.
+func loadAutoHistory(beadsPath string, labels []string) autotarget.History {
+	history := autotarget.History{}
+	if len(labels) == 0 {
+		return history
+	}
+	bd := beads.New(beadsPath)
+
+	failedSources := make(map[string]bool)
+	if mrs, err := bd.List(beads.ListOptions{Status: "closed", Label: "gt:merge-request", Limit: autoHistoryLimit}); err == nil {
+		for _, mr := range mrs {
+			fields := beads.ParseMRFields(mr)
+			if fields == nil || fields.SourceIssue == "" {
+				continue
+			}
+			switch fields.CloseReason {
+			case "rejected", "conflict":
+				failedSources[fields.SourceIssue] = true
+			}
+		}
+	}
+
+	for _, label := range labels {
+		closed, err := bd.List(beads.ListOptions{Status: "closed", Label: label, Limit: autoHistoryLimit})
+		if err != nil {
+			continue
+		}
+		for _, issue := range closed {
+			if issue.Assignee == "" {
+				continue
+			}
+			history.Record(label, issue.Assignee, !failedSources[issue.ID])
+		}
+	}
+	return history
+}
+
+// printAutoTargetRanking explains the --auto decision.
+func printAutoTargetRanking(beadID string, labels []string, choices []autotarget.Choice) {
+	labelDesc := "(none)"
+	if len(labels) > 0 {
+		labelDesc = strings.Join(labels, ", ")
+	}
+	fmt.Printf("%s Auto-selecting target for %s (labels: %s)\n", style.Bold.Render("🧭"), beadID, labelDesc)
+	for i, c := range choices {
+		marker := style.Dim.Render("  ")
+		if i == 0 && c.Eligible() {
+			marker = style.Success.Render("→ ")
+		}
+		if !c.Eligible() {
+			fmt.Printf("  %s%s %s\n", marker, c.Target, style.Dim.Render("skipped: "+c.Unavailable))
+			continue
+		}
+		fmt.Printf("  %s%s score %.1f\n", marker, c.Target, c.Score)
+		for _, reason := range c.Reasons {
+			fmt.Printf("      %s\n", style.Dim.Render(reason))
+		}
+	}
+}
+
+// resolveAutoTargetArgs appends the auto-selected target to sling args.
+// --auto is only valid with a single bead and no explicit target.
+func resolveAutoTargetArgs(townRoot string, args []string, deferred bool) ([]string, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("--auto takes a single bead and no target (got %d args)", len(args))
+	}
+	if slingOnTarget != "" {
+		return nil, fmt.Errorf("--auto cannot be combined with --on")
+	}
+	target, err := autoTargetFn(townRoot, args[0], deferred)
+	if err != nil {
+		return nil, err
+	}
+	return append(args, target), nil
+}
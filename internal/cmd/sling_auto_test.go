@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveAutoTargetArgs(t *testing.T) {
+	prevFn := autoTargetFn
+	prevOn := slingOnTarget
+	t.Cleanup(func() {
+		autoTargetFn = prevFn
+		slingOnTarget = prevOn
+	})
+
+	var gotDeferred bool
+	autoTargetFn = func(townRoot, beadID string, polecatsOnly bool) (string, error) {
+		gotDeferred = polecatsOnly
+		return "gastown/crew/max", nil
+	}
+	slingOnTarget = ""
+
+	args, err := resolveAutoTargetArgs("/town", []string{"gt-abc"}, true)
+	if err != nil {
+		t.Fatalf("resolveAutoTargetArgs: %v", err)
+	}
+	if len(args) != 2 || args[1] != "gastown/crew/max" {
+		t.Errorf("args = %v, want [gt-abc gastown/crew/max]", args)
+	}
+	if !gotDeferred {
+		t.Error("deferred flag should be passed through as polecatsOnly")
+	}
+
+	if _, err := resolveAutoTargetArgs("/town", []string{"gt-abc", "gastown"}, false); err == nil ||
+		!strings.Contains(err.Error(), "single bead") {
+		t.Errorf("explicit target with --auto should error, got %v", err)
+	}
+
+	slingOnTarget = "gt-xyz"
+	if _, err := resolveAutoTargetArgs("/town", []string{"mol-review"}, false); err == nil {
+		t.Error("--auto with --on should error")
+	}
+}
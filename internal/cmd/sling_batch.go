@@ -151,6 +151,7 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 			BaseBranch:       slingBaseBranch,
 			Account:          slingAccount,
 			Agent:            slingAgent,
+			Worker:           slingWorker,
 			NoConvoy:         slingNoConvoy,
 			Owned:            slingOwned,
 			NoMerge:          slingNoMerge,
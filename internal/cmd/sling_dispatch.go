@@ -35,6 +35,7 @@ type SlingParams struct {
 	HookRawBead bool    // --hook-raw-bead
 	NoBoot     bool     // --no-boot
 	Mode       string   // --ralph: "" (normal) or "ralph"
+	Worker     bool     // --worker (not queued; queue dispatch follows the rig's worker_mode)
 
 	// Execution behavior (set by caller, not serialized to queue)
 	SkipCook         bool   // Batch optimization: formula already cooked
@@ -120,6 +121,10 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 			}
 			return result, fmt.Errorf("cannot sling to %s rig %q\n%s %s", reason, params.RigName, undoCmd, params.RigName)
 		}
+		if err := checkRigNotInMaintenance(townRoot, params.RigName); err != nil {
+			result.ErrMsg = "rig in maintenance"
+			return result, fmt.Errorf("cannot sling: %w", err)
+		}
 	}
 
 	// 1. Get bead info + status check
@@ -225,6 +230,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 		HookBead:   params.BeadID,
 		Agent:      params.Agent,
 		BaseBranch: params.BaseBranch,
+		Worker:     params.Worker,
 		// Create is always true for rig targets: executeSling only handles
 		// rig-targeted dispatch (batch sling + queue dispatch), where a fresh
 		// polecat must be spawned. The single-sling path (runSling) handles
@@ -94,6 +94,7 @@ func runSlingFormula(args []string) error {
 		Create:   slingCreate,
 		Account:  slingAccount,
 		Agent:    slingAgent,
+		Worker:   slingWorker,
 		NoBoot:   slingNoBoot,
 		WorkDesc: formulaName,
 		TownRoot: townRoot,
@@ -41,7 +41,8 @@ func init() {
 var townCmd = &cobra.Command{
 	Use:   "town",
 	Short: "Town-level operations",
-	Long:  `Commands for town-level operations including session cycling.`,
+	Long:  `Commands for town-level operations including session cycling and undoing
+bead writes.`,
 }
 
 var townNextCmd = &cobra.Command{
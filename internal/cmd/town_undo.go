@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	townUndoSince  string
+	townUndoActor  string
+	townUndoDB     string
+	townUndoDryRun bool
+	townUndoJSON   bool
+)
+
+var townUndoCmd = &cobra.Command{
+	Use:   "undo --since <duration|time> --actor <agent>",
+	Short: "Reverse a burst of bead writes by one agent",
+	Long: `Reverse every committed bead write made by one agent since a point in
+time, across all bead databases, as one new Dolt commit per database.
+
+Undo is field-level and conservative:
+  - A field is restored only if it still holds the value the agent left.
+    Fields changed again by someone else since are reported as conflicts
+    and left alone.
+  - Label additions and removals are reversed the same way.
+  - Beads the agent deleted are re-created; beads it created are reported,
+    not deleted.
+
+--actor is the agent's full address (its BD_ACTOR), such as
+gastown/polecats/toast. Writes are attributed from the actor bd records on
+each bead event, so writes that reached Dolt in a bulk commit by someone
+else (polecats run with auto-commit off) are found too. A bead the agent
+changed in the same commit as another agent is skipped; use gt bead revert
+for it. Deletions carry no bd event and are only found in commits made by
+the agent itself.
+
+Examples:
+  gt town undo --since 10m --actor gastown/polecats/toast --dry-run
+  gt town undo --since "2026-10-18 09:30" --actor gastown/crew/max
+  gt town undo --since 1h --actor gastown/witness --db gastown`,
+	RunE: runTownUndo,
+}
+
+func init() {
+	townUndoCmd.Flags().StringVar(&townUndoSince, "since", "", "Duration ago (10m, 2h) or time to undo from (required)")
+	townUndoCmd.Flags().StringVar(&townUndoActor, "actor", "", "Agent whose writes to undo (required)")
+	townUndoCmd.Flags().StringVar(&townUndoDB, "db", "", "Only undo writes in this database")
+	townUndoCmd.Flags().BoolVarP(&townUndoDryRun, "dry-run", "n", false, "Show the plan without writing")
+	townUndoCmd.Flags().BoolVar(&townUndoJSON, "json", false, "Output the plan as JSON")
+	_ = townUndoCmd.MarkFlagRequired("since")
+	_ = townUndoCmd.MarkFlagRequired("actor")
+	townCmd.AddCommand(townUndoCmd)
+}
+
+// townUndoResult is the per-database outcome of gt town undo.
+type townUndoResult struct {
+	Database string              `json:"database"`
+	Plan     doltserver.UndoPlan `json:"plan"`
+	Applied  bool                `json:"applied"`
+	Error    string              `json:"error,omitempty"`
+}
+
+func runTownUndo(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	since, ok := doltserver.ParseTimeSpec(townUndoSince, time.Now())
+	if !ok {
+		return fmt.Errorf("invalid --since %q: want a duration (10m) or time", townUndoSince)
+	}
+
+	databases := []string{townUndoDB}
+	if townUndoDB == "" {
+		databases, err = doltserver.ListDatabases(townRoot)
+		if err != nil {
+			return fmt.Errorf("listing databases: %w", err)
+		}
+	}
+
+	var results []townUndoResult
+	failed := 0
+	for _, dbName := range databases {
+		res, err := undoInDatabase(townRoot, dbName, since)
+		if err != nil {
+			res.Error = err.Error()
+			failed++
+		}
+		if len(res.Plan.Beads) > 0 || res.Error != "" {
+			results = append(results, res)
+		}
+	}
+
+	if townUndoJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		printTownUndo(results, since)
+	}
+	if failed > 0 {
+		return fmt.Errorf("undo failed in %d database(s)", failed)
+	}
+	return nil
+}
+
+// undoInDatabase plans and (unless dry-run) applies the undo in one database.
+// Databases without bead tables are silently skipped.
+func undoInDatabase(townRoot, dbName string, since time.Time) (townUndoResult, error) {
+	res := townUndoResult{Database: dbName}
+	db, err := doltserver.Client(townRoot, dbName)
+	if err != nil {
+		return res, fmt.Errorf("connecting to database %s: %w", dbName, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	hasIssues, err := db.TableExists(ctx, "issues")
+	if err != nil {
+		return res, fmt.Errorf("checking for issues table: %w", err)
+	}
+	if !hasIssues {
+		return res, nil // not a beads database
+	}
+
+	writes, err := doltserver.ActorWrites(ctx, db, townUndoActor, since)
+	if err != nil || len(writes) == 0 {
+		return res, err
+	}
+	ids := make([]string, 0, len(writes))
+	seen := make(map[string]bool)
+	for _, w := range writes {
+		if !seen[w.BeadID] {
+			seen[w.BeadID] = true
+			ids = append(ids, w.BeadID)
+		}
+	}
+	current, labels, err := doltserver.CurrentState(ctx, db, ids)
+	if err != nil {
+		return res, err
+	}
+	res.Plan = doltserver.PlanUndo(writes, current, labels)
+
+	if townUndoDryRun {
+		return res, nil
+	}
+	actionable := false
+	for i := range res.Plan.Beads {
+		actionable = actionable || res.Plan.Beads[i].Actionable()
+	}
+	if !actionable {
+		return res, nil
+	}
+	msg := fmt.Sprintf("gt: undo writes by %s since %s", townUndoActor, since.UTC().Format(time.RFC3339))
+	if err := doltserver.ApplyUndo(ctx, db, res.Plan, msg, detectActor()); err != nil {
+		return res, err
+	}
+	res.Applied = true
+	return res, nil
+}
+
+func printTownUndo(results []townUndoResult, since time.Time) {
+	if len(results) == 0 {
+		fmt.Printf("No bead writes by %s since %s\n", townUndoActor, since.Format("2006-01-02 15:04:05"))
+		return
+	}
+	restored, conflicts, skipped := 0, 0, 0
+	for _, res := range results {
+		header := res.Database
+		switch {
+		case res.Error != "":
+			header += " " + style.Error.Render("failed: "+res.Error)
+		case res.Applied:
+			header += " " + style.Success.Render("undone")
+		case townUndoDryRun:
+			header += " " + style.Dim.Render("(dry run)")
+		}
+		fmt.Println(style.Bold.Render(header))
+		for _, b := range res.Plan.Beads {
+			switch {
+			case b.Skipped != "":
+				skipped++
+				fmt.Printf("  %s %s\n", b.BeadID, style.Dim.Render("skipped: "+b.Skipped))
+				continue
+			case b.Restore != nil:
+				restored++
+				fmt.Printf("  %s re-created\n", b.BeadID)
+			case len(b.Changes) > 0:
+				restored++
+				fmt.Printf("  %s\n", b.BeadID)
+			default:
+				fmt.Printf("  %s %s\n", b.BeadID, style.Dim.Render("nothing left to undo"))
+			}
+			printFieldChanges(b.Changes, "    ")
+			if len(b.Conflicts) > 0 {
+				conflicts += len(b.Conflicts)
+				style.PrintWarning("    %s: changed again since, left alone: %v", b.BeadID, b.Conflicts)
+			}
+		}
+	}
+	fmt.Printf("\n%d bead(s) restored, %d field conflict(s), %d skipped\n", restored, conflicts, skipped)
+	if townUndoDryRun {
+		fmt.Println(style.Dim.Render("Dry run: re-run without --dry-run to apply."))
+	}
+}
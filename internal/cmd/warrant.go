@@ -6,10 +6,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -19,9 +22,11 @@ import (
 // Warrant flags
 var (
 	warrantReason  string
+	warrantClass   string
 	warrantListAll bool
 	warrantForce   bool
 	warrantStdin   bool // Read reason from stdin
+	warrantAppeal  string
 )
 
 // Warrant represents a death warrant for an agent
@@ -29,10 +34,21 @@ type Warrant struct {
 	ID         string     `json:"id"`
 	Target     string     `json:"target"` // e.g., "gastown/polecats/alpha", "deacon/dogs/bravo"
 	Reason     string     `json:"reason"`
+	Class      string     `json:"class,omitempty"` // Reason class; derived from Reason if empty
 	FiledBy    string     `json:"filed_by"`
 	FiledAt    time.Time  `json:"filed_at"`
+	Cosigners  []string   `json:"cosigners,omitempty"` // Other agents that filed the same warrant
 	Executed   bool       `json:"executed,omitempty"`
 	ExecutedAt *time.Time `json:"executed_at,omitempty"`
+
+	// Policy state (see WarrantPolicyConfig)
+	ApprovedBy     string     `json:"approved_by,omitempty"`
+	ApprovedAt     *time.Time `json:"approved_at,omitempty"`
+	AppealDeadline *time.Time `json:"appeal_deadline,omitempty"` // Set when the target is nudged
+	Dismissed      bool       `json:"dismissed,omitempty"`       // Target appealed
+	DismissedAt    *time.Time `json:"dismissed_at,omitempty"`
+	Appeal         string     `json:"appeal,omitempty"` // Target's appeal statement
+	Hold           string     `json:"hold,omitempty"`   // Last hold Boot recorded, so each is logged once
 }
 
 var warrantCmd = &cobra.Command{
@@ -46,8 +62,16 @@ forced termination. Boot handles warrant execution during triage cycles.
 The warrant system provides a controlled way to terminate agents:
 1. Deacon/Witness files a warrant with a reason
 2. Boot picks up the warrant during triage
-3. Boot executes the warrant (terminates session, updates state)
-4. Warrant is marked as executed
+3. Boot checks the warrant policy for the target's role and reason class
+4. Boot executes the warrant (terminates session, updates state)
+5. Warrant is marked as executed
+
+Warrant policy (town settings "warrants") can require a second filer or
+human approval (gt warrant approve), and can give the target an appeal
+window: Boot nudges the target, which can prove it is alive with
+gt warrant appeal. By default crew warrants need approval and polecats and
+dogs get a 10 minute appeal window. Warrants against sessions that are
+already dead are always executed. Every decision is recorded as an event.
 
 Warrants are stored in ~/gt/warrants/ as JSON files.`,
 }
@@ -62,9 +86,15 @@ The target should be an agent path like:
   - deacon/dogs/bravo
   - beads/polecats/charlie
 
+Filing a warrant that another agent already filed co-signs it.
+
+The reason class (zombie, stuck, unresponsive, other) selects the warrant
+policy. It is derived from the reason unless --class is given.
+
 Examples:
   gt warrant file gastown/polecats/alpha --reason "Zombie: no session, idle >10m"
-  gt warrant file deacon/dogs/bravo --reason "Stuck: working on task for >2h"`,
+  gt warrant file deacon/dogs/bravo --reason "Stuck: working on task for >2h"
+  gt warrant file gastown/crew/joe --class unresponsive -r "No reply to 3 nudges"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWarrantFile,
 }
@@ -92,7 +122,8 @@ This will:
 2. Terminate the agent's tmux session (if exists)
 3. Mark the warrant as executed
 
-Use --force to execute even if no warrant exists.
+The warrant policy must be satisfied (co-signers, approval, appeal
+window). Use --force to execute regardless, or even if no warrant exists.
 
 Examples:
   gt warrant execute gastown/polecats/alpha
@@ -101,20 +132,57 @@ Examples:
 	RunE: runWarrantExecute,
 }
 
+var warrantApproveCmd = &cobra.Command{
+	Use:   "approve <target>",
+	Short: "Approve a warrant held for human approval",
+	Long: `Approve a pending warrant that the warrant policy holds for human approval.
+
+Only the overseer (a human at a terminal, not an agent session) can approve.
+Boot executes the warrant on its next triage cycle, after any appeal window.
+
+Examples:
+  gt warrant approve gastown/crew/joe`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWarrantApprove,
+}
+
+var warrantAppealCmd = &cobra.Command{
+	Use:   "appeal [target]",
+	Short: "Appeal a warrant filed against you by proving liveness",
+	Long: `Appeal a pending death warrant filed against the calling agent.
+
+Running this from the target's own session proves it is alive, so the
+warrant is dismissed and the dismissal recorded as an event. The target
+defaults to the calling agent's identity; appealing on behalf of another
+agent is refused.
+
+Examples:
+  gt warrant appeal
+  gt warrant appeal --message "Running a 3h migration, 80% done"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWarrantAppeal,
+}
+
 func init() {
 	// File flags
 	warrantFileCmd.Flags().StringVarP(&warrantReason, "reason", "r", "", "Reason for the warrant (required unless --stdin)")
 	warrantFileCmd.Flags().BoolVar(&warrantStdin, "stdin", false, "Read reason from stdin (avoids shell quoting issues)")
+	warrantFileCmd.Flags().StringVar(&warrantClass, "class", "", "Reason class: zombie, stuck, unresponsive, other (default: derived from reason)")
 
 	// List flags
 	warrantListCmd.Flags().BoolVarP(&warrantListAll, "all", "a", false, "Include executed warrants")
 
 	// Execute flags
-	warrantExecuteCmd.Flags().BoolVarP(&warrantForce, "force", "f", false, "Execute even without a warrant")
+	warrantExecuteCmd.Flags().BoolVarP(&warrantForce, "force", "f", false, "Execute regardless of warrant policy, or without a warrant")
+
+	// Appeal flags
+	warrantAppealCmd.Flags().StringVarP(&warrantAppeal, "message", "m", "", "What the agent is doing (recorded with the appeal)")
 
 	warrantCmd.AddCommand(warrantFileCmd)
 	warrantCmd.AddCommand(warrantListCmd)
 	warrantCmd.AddCommand(warrantExecuteCmd)
+	warrantCmd.AddCommand(warrantApproveCmd)
+	warrantCmd.AddCommand(warrantAppealCmd)
 
 	rootCmd.AddCommand(warrantCmd)
 }
@@ -153,6 +221,10 @@ func runWarrantFile(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("required flag \"reason\" not set (use --reason/-r or --stdin)")
 	}
 
+	if warrantClass != "" && !slices.Contains(warrantClasses, warrantClass) {
+		return fmt.Errorf("invalid --class %q: must be one of %s", warrantClass, strings.Join(warrantClasses, ", "))
+	}
+
 	target := args[0]
 
 	warrantDir, err := getWarrantDir()
@@ -165,43 +237,52 @@ func runWarrantFile(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("creating warrants directory: %w", err)
 	}
 
-	// Check if warrant already exists
+	// Get filer identity
+	filedBy := os.Getenv("BD_ACTOR")
+	if filedBy == "" {
+		filedBy = "unknown"
+	}
+
+	// A pending warrant for the same target is co-signed rather than replaced
 	warrantPath := warrantFilePath(warrantDir, target)
-	if _, err := os.Stat(warrantPath); err == nil {
-		// Load existing warrant
-		data, _ := os.ReadFile(warrantPath)
-		var existing Warrant
-		if json.Unmarshal(data, &existing) == nil && !existing.Executed {
+	lock, err := lockWarrant(warrantPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+	if existing, _ := loadWarrant(warrantPath); existing != nil && existing.pending() {
+		if slices.Contains(existing.filers(), filedBy) {
 			fmt.Printf("Warrant already exists for %s\n", target)
 			fmt.Printf("  Reason: %s\n", existing.Reason)
 			fmt.Printf("  Filed: %s\n", existing.FiledAt.Format(time.RFC3339))
 			return nil
 		}
-	}
-
-	// Get filer identity
-	filedBy := os.Getenv("BD_ACTOR")
-	if filedBy == "" {
-		filedBy = "unknown"
+		existing.Cosigners = append(existing.Cosigners, filedBy)
+		if err := saveWarrant(existing, warrantPath); err != nil {
+			return err
+		}
+		_ = events.LogAudit(events.TypeWarrantCosigned, filedBy,
+			events.WarrantPayload(existing.ID, target, warrantReason, fmt.Sprintf("%d filers", len(existing.filers()))))
+		fmt.Printf("✓ Co-signed death warrant for %s\n", style.Bold.Render(target))
+		fmt.Printf("  Filers: %s\n", strings.Join(existing.filers(), ", "))
+		return nil
 	}
 
 	warrant := Warrant{
 		ID:       fmt.Sprintf("warrant-%d", time.Now().UnixMilli()),
 		Target:   target,
 		Reason:   warrantReason,
+		Class:    warrantClass,
 		FiledBy:  filedBy,
 		FiledAt:  time.Now(),
 		Executed: false,
 	}
 
-	data, err := json.MarshalIndent(warrant, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshaling warrant: %w", err)
-	}
-
-	if err := os.WriteFile(warrantPath, data, 0644); err != nil {
-		return fmt.Errorf("writing warrant: %w", err)
+	if err := saveWarrant(&warrant, warrantPath); err != nil {
+		return err
 	}
+	_ = events.LogFeed(events.TypeWarrantFiled, filedBy,
+		events.WarrantPayload(warrant.ID, target, warrantReason, warrant.warrantClass()))
 
 	fmt.Printf("✓ Filed death warrant for %s\n", style.Bold.Render(target))
 	fmt.Printf("  Reason: %s\n", warrantReason)
@@ -241,7 +322,7 @@ func runWarrantList(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		if warrantListAll || !w.Executed {
+		if warrantListAll || w.pending() {
 			warrants = append(warrants, w)
 		}
 	}
@@ -255,17 +336,34 @@ func runWarrantList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	policy := config.LoadWarrantPolicy(filepath.Dir(warrantDir))
+
 	fmt.Println(style.Bold.Render("Death Warrants"))
 	fmt.Println()
 
 	for _, w := range warrants {
 		status := "⚠️  PENDING"
-		if w.Executed {
+		switch {
+		case w.Executed:
 			status = "✓ EXECUTED"
+		case w.Dismissed:
+			status = "↩ APPEALED"
 		}
 		fmt.Printf("  %s %s\n", status, style.Bold.Render(w.Target))
-		fmt.Printf("     Reason: %s\n", w.Reason)
-		fmt.Printf("     Filed: %s by %s\n", w.FiledAt.Format("2006-01-02 15:04"), w.FiledBy)
+		fmt.Printf("     Reason: %s (%s)\n", w.Reason, w.warrantClass())
+		fmt.Printf("     Filed: %s by %s\n", w.FiledAt.Format("2006-01-02 15:04"), strings.Join(w.filers(), ", "))
+		if w.pending() {
+			rule := policy.Match(warrantTargetRole(w.Target), w.warrantClass())
+			if decision, detail := evaluateWarrant(&w, rule, time.Now()); decision != warrantExecute {
+				fmt.Printf("     Policy: %s (%s)\n", decision, detail)
+			}
+		}
+		if w.ApprovedBy != "" && w.ApprovedAt != nil {
+			fmt.Printf("     Approved: %s by %s\n", w.ApprovedAt.Format("2006-01-02 15:04"), w.ApprovedBy)
+		}
+		if w.Dismissed && w.DismissedAt != nil {
+			fmt.Printf("     Appealed: %s %s\n", w.DismissedAt.Format("2006-01-02 15:04"), w.Appeal)
+		}
 		if w.Executed && w.ExecutedAt != nil {
 			fmt.Printf("     Executed: %s\n", w.ExecutedAt.Format("2006-01-02 15:04"))
 		}
@@ -284,15 +382,14 @@ func runWarrantExecute(cmd *cobra.Command, args []string) error {
 	}
 
 	warrantPath := warrantFilePath(warrantDir, target)
-	var warrant *Warrant
+	lock, err := lockWarrant(warrantPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
 
 	// Load warrant if exists
-	if data, err := os.ReadFile(warrantPath); err == nil {
-		var w Warrant
-		if json.Unmarshal(data, &w) == nil {
-			warrant = &w
-		}
-	}
+	warrant, _ := loadWarrant(warrantPath)
 
 	if warrant == nil && !warrantForce {
 		return fmt.Errorf("no warrant found for %s (use --force to execute anyway)", target)
@@ -303,6 +400,16 @@ func runWarrantExecute(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if warrant != nil && !warrantForce {
+		if warrant.Dismissed {
+			return fmt.Errorf("warrant for %s was dismissed on appeal (use --force to execute anyway)", target)
+		}
+		rule := config.LoadWarrantPolicy(filepath.Dir(warrantDir)).Match(warrantTargetRole(target), warrant.warrantClass())
+		if decision, detail := evaluateWarrant(warrant, rule, time.Now()); decision != warrantExecute {
+			return fmt.Errorf("warrant policy not satisfied for %s: %s (%s); use --force to execute anyway", target, decision, detail)
+		}
+	}
+
 	tm := tmux.NewTmux()
 
 	if warrant != nil {
@@ -358,17 +465,126 @@ func executeOneWarrant(w *Warrant, warrantPath string, tm *tmux.Tmux) error {
 	now := time.Now()
 	w.Executed = true
 	w.ExecutedAt = &now
-	data, err := json.MarshalIndent(w, "", "  ")
+	if err := saveWarrant(w, warrantPath); err != nil {
+		return err
+	}
+	detail := "session terminated"
+	if !has {
+		detail = "session already dead"
+	}
+	_ = events.LogFeed(events.TypeWarrantExecuted, detectSender(), events.WarrantPayload(w.ID, w.Target, w.Reason, detail))
+
+	return nil
+}
+
+func runWarrantApprove(cmd *cobra.Command, args []string) error {
+	target := args[0]
+
+	approver := detectSender()
+	if approver != "overseer" {
+		return fmt.Errorf("warrant approval requires a human; %s is an agent identity", approver)
+	}
+
+	warrantDir, err := getWarrantDir()
+	if err != nil {
+		return err
+	}
+	warrantPath := warrantFilePath(warrantDir, target)
+	lock, err := lockWarrant(warrantPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	w, err := loadWarrant(warrantPath)
+	if err != nil {
+		return err
+	}
+	if w == nil || !w.pending() {
+		return fmt.Errorf("no pending warrant for %s", target)
+	}
+
+	now := time.Now()
+	w.ApprovedBy = approver
+	w.ApprovedAt = &now
+	if err := saveWarrant(w, warrantPath); err != nil {
+		return err
+	}
+	_ = events.LogFeed(events.TypeWarrantApproved, approver, events.WarrantPayload(w.ID, target, w.Reason, ""))
+
+	fmt.Printf("✓ Approved death warrant for %s\n", style.Bold.Render(target))
+	return nil
+}
+
+func runWarrantAppeal(cmd *cobra.Command, args []string) error {
+	caller := detectSender()
+	target := caller
+	if len(args) > 0 {
+		target = args[0]
+	}
+	if !sameAgent(caller, target) {
+		return fmt.Errorf("only %s can appeal its own warrant (you are %s)", target, caller)
+	}
+
+	warrantDir, err := getWarrantDir()
 	if err != nil {
-		return fmt.Errorf("marshaling warrant: %w", err)
+		return err
 	}
-	if err := os.WriteFile(warrantPath, data, 0644); err != nil {
-		return fmt.Errorf("writing warrant file: %w", err)
+	_, path, err := findPendingWarrant(warrantDir, target)
+	if err != nil {
+		return err
 	}
 
+	// Re-read under the lock: Boot may have executed the warrant since.
+	lock, err := lockWarrant(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+	w, err := loadWarrant(path)
+	if err != nil {
+		return err
+	}
+	if w == nil || !w.pending() {
+		return fmt.Errorf("no pending warrant for %s", target)
+	}
+
+	now := time.Now()
+	w.Dismissed = true
+	w.DismissedAt = &now
+	w.Appeal = warrantAppeal
+	if err := saveWarrant(w, path); err != nil {
+		return err
+	}
+	_ = events.LogFeed(events.TypeWarrantAppealed, caller, events.WarrantPayload(w.ID, w.Target, w.Reason, warrantAppeal))
+
+	fmt.Printf("✓ Appeal accepted: warrant for %s dismissed\n", style.Bold.Render(w.Target))
 	return nil
 }
 
+// findPendingWarrant finds the pending warrant whose target is agent, in
+// either polecat address form.
+func findPendingWarrant(warrantDir, agent string) (*Warrant, string, error) {
+	entries, err := os.ReadDir(warrantDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("reading warrants directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".warrant.json") {
+			continue
+		}
+		path := filepath.Join(warrantDir, entry.Name())
+		w, err := loadWarrant(path)
+		if err != nil || w == nil {
+			continue
+		}
+		if w.pending() && sameAgent(w.Target, agent) {
+			return w, path, nil
+		}
+	}
+	return nil, "", fmt.Errorf("no pending warrant for %s", agent)
+}
+
 // targetToSessionName converts a target path to a tmux session name
 func targetToSessionName(target string) (string, error) {
 	parts := strings.Split(target, "/")
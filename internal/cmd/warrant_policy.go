@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Policy decisions for a pending warrant against a live session.
+const (
+	warrantExecute        = "execute"           // Policy satisfied; terminate the target
+	warrantAwaitCosign    = "awaiting_cosign"   // Needs more distinct filers
+	warrantAwaitApproval  = "awaiting_approval" // Needs gt warrant approve from a human
+	warrantOpenAppeal     = "open_appeal"       // Nudge the target and start the appeal window
+	warrantInAppealWindow = "in_appeal_window"  // Target may still appeal
+)
+
+// warrantTargetRole returns the role of a warrant target path.
+func warrantTargetRole(target string) string {
+	parts := strings.Split(target, "/")
+	switch {
+	case len(parts) == 3 && parts[1] == "polecats":
+		return "polecat"
+	case len(parts) == 3 && parts[1] == "crew":
+		return "crew"
+	case len(parts) == 3 && parts[0] == "deacon" && parts[1] == "dogs":
+		return "dog"
+	case len(parts) == 2 && (parts[1] == "witness" || parts[1] == "refinery"):
+		return parts[1]
+	}
+	return "other"
+}
+
+// warrantClasses lists the valid reason classes for --class.
+var warrantClasses = []string{
+	config.WarrantClassZombie, config.WarrantClassStuck,
+	config.WarrantClassUnresponsive, config.WarrantClassOther,
+}
+
+// classifyWarrantReason derives a reason class from the reason text. Filers
+// conventionally lead with the class ("Zombie: no session, idle >10m").
+func classifyWarrantReason(reason string) string {
+	r := strings.ToLower(reason)
+	switch {
+	case strings.Contains(r, "zombie"), strings.Contains(r, "no session"), strings.Contains(r, "dead"):
+		return config.WarrantClassZombie
+	case strings.Contains(r, "unresponsive"), strings.Contains(r, "hung"), strings.Contains(r, "heartbeat"):
+		return config.WarrantClassUnresponsive
+	case strings.Contains(r, "stuck"), strings.Contains(r, "no progress"):
+		return config.WarrantClassStuck
+	}
+	return config.WarrantClassOther
+}
+
+// warrantClass returns the warrant's reason class.
+func (w *Warrant) warrantClass() string {
+	if w.Class != "" {
+		return w.Class
+	}
+	return classifyWarrantReason(w.Reason)
+}
+
+// filers returns the distinct agents that filed or co-signed the warrant.
+func (w *Warrant) filers() []string {
+	filers := []string{w.FiledBy}
+	for _, c := range w.Cosigners {
+		if !slices.Contains(filers, c) {
+			filers = append(filers, c)
+		}
+	}
+	return filers
+}
+
+// pending reports whether the warrant still awaits execution.
+func (w *Warrant) pending() bool { return !w.Executed && !w.Dismissed }
+
+// evaluateWarrant decides what Boot does with a pending warrant against a
+// live session under rule at now. The detail explains a hold.
+func evaluateWarrant(w *Warrant, rule config.WarrantPolicyRule, now time.Time) (string, string) {
+	if n, need := len(w.filers()), rule.GetMinFilers(); n < need {
+		return warrantAwaitCosign, fmt.Sprintf("%d of %d filers", n, need)
+	}
+	if rule.RequireApproval && w.ApprovedBy == "" {
+		return warrantAwaitApproval, "needs gt warrant approve"
+	}
+	if rule.GetAppealWindow() > 0 {
+		if w.AppealDeadline == nil {
+			return warrantOpenAppeal, fmt.Sprintf("appeal window %v", rule.GetAppealWindow())
+		}
+		if now.Before(*w.AppealDeadline) {
+			return warrantInAppealWindow, fmt.Sprintf("appeal until %s", w.AppealDeadline.Format(time.RFC3339))
+		}
+	}
+	return warrantExecute, ""
+}
+
+// sameAgent reports whether two agent addresses name the same agent. Polecat
+// addresses appear both as rig/polecats/name (warrant targets) and rig/name
+// (agent identities).
+func sameAgent(a, b string) bool {
+	norm := func(s string) string {
+		s = strings.TrimSuffix(s, "/")
+		if rig, name, ok := strings.Cut(s, "/polecats/"); ok {
+			return rig + "/" + name
+		}
+		return s
+	}
+	return a != "" && norm(a) == norm(b)
+}
+
+// appealMessage is the nudge sent to a warrant's target when its appeal
+// window opens.
+func appealMessage(w *Warrant, deadline time.Time) string {
+	return fmt.Sprintf("DEATH WARRANT filed against you by %s: %s. If you are alive and working, run `gt warrant appeal` before %s or your session will be terminated.",
+		strings.Join(w.filers(), ", "), w.Reason, deadline.Format("15:04"))
+}
+
+// warrantLockTimeout is how long to wait for a warrant lock. Boot holds it
+// while killing the target's session.
+const warrantLockTimeout = 30 * time.Second
+
+// lockWarrant acquires an exclusive lock on a warrant file, so that a load,
+// check and save is not interleaved with another filer, an appeal, or Boot
+// executing the warrant. The caller must unlock it.
+func lockWarrant(path string) (*flock.Flock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating warrants directory: %w", err)
+	}
+
+	lock := flock.New(path + ".lock")
+	ctx, cancel := context.WithTimeout(context.Background(), warrantLockTimeout)
+	defer cancel()
+
+	locked, err := lock.TryLockContext(ctx, 100*time.Millisecond)
+	if err != nil {
+		return nil, fmt.Errorf("locking warrant: %w", err)
+	}
+	if !locked {
+		return nil, fmt.Errorf("timeout waiting for warrant lock")
+	}
+	return lock, nil
+}
+
+// saveWarrant writes a warrant back to its file. Callers that loaded the
+// warrant first must hold its lock (see lockWarrant).
+func saveWarrant(w *Warrant, path string) error {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling warrant: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing warrant file: %w", err)
+	}
+	return nil
+}
+
+// loadWarrant reads a warrant file. It returns nil, nil if none exists.
+func loadWarrant(path string) (*Warrant, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading warrant: %w", err)
+	}
+	var w Warrant
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, fmt.Errorf("parsing warrant: %w", err)
+	}
+	return &w, nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/session"
 )
 
@@ -237,3 +238,115 @@ func TestWarrantFilePath(t *testing.T) {
 		})
 	}
 }
+
+// TestWarrantTargetRole verifies role extraction from warrant targets.
+func TestWarrantTargetRole(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"gastown/polecats/alpha": "polecat",
+		"gastown/crew/joe":       "crew",
+		"deacon/dogs/bravo":      "dog",
+		"gastown/witness":        "witness",
+		"gastown/refinery":       "refinery",
+		"mayor":                  "other",
+	}
+	for target, want := range tests {
+		if got := warrantTargetRole(target); got != want {
+			t.Errorf("warrantTargetRole(%q) = %q, want %q", target, got, want)
+		}
+	}
+}
+
+// TestClassifyWarrantReason verifies reason class derivation.
+func TestClassifyWarrantReason(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"Zombie: no session, idle >10m":  "zombie",
+		"Session dead but bead hooked":   "zombie",
+		"Stuck: working on task for >2h": "stuck",
+		"Unresponsive to 3 nudges":       "unresponsive",
+		"No heartbeat for 20m":           "unresponsive",
+		"Wrong branch":                   "other",
+	}
+	for reason, want := range tests {
+		if got := classifyWarrantReason(reason); got != want {
+			t.Errorf("classifyWarrantReason(%q) = %q, want %q", reason, got, want)
+		}
+	}
+}
+
+// TestEvaluateWarrant verifies the policy decision for live targets.
+func TestEvaluateWarrant(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		w    Warrant
+		rule config.WarrantPolicyRule
+		want string
+	}{
+		{"no policy", Warrant{FiledBy: "witness"}, config.WarrantPolicyRule{}, warrantExecute},
+		{"needs second filer", Warrant{FiledBy: "witness"}, config.WarrantPolicyRule{MinFilers: 2}, warrantAwaitCosign},
+		{"same filer twice does not count", Warrant{FiledBy: "witness", Cosigners: []string{"witness"}}, config.WarrantPolicyRule{MinFilers: 2}, warrantAwaitCosign},
+		{"co-signed", Warrant{FiledBy: "witness", Cosigners: []string{"deacon"}}, config.WarrantPolicyRule{MinFilers: 2}, warrantExecute},
+		{"needs approval", Warrant{FiledBy: "witness"}, config.WarrantPolicyRule{RequireApproval: true}, warrantAwaitApproval},
+		{"approved", Warrant{FiledBy: "witness", ApprovedBy: "overseer"}, config.WarrantPolicyRule{RequireApproval: true}, warrantExecute},
+		{"opens appeal window", Warrant{FiledBy: "witness"}, config.WarrantPolicyRule{AppealWindow: "10m"}, warrantOpenAppeal},
+		{"inside appeal window", Warrant{FiledBy: "witness", AppealDeadline: &future}, config.WarrantPolicyRule{AppealWindow: "10m"}, warrantInAppealWindow},
+		{"appeal window lapsed", Warrant{FiledBy: "witness", AppealDeadline: &past}, config.WarrantPolicyRule{AppealWindow: "10m"}, warrantExecute},
+		{"approval before appeal window", Warrant{FiledBy: "witness"}, config.WarrantPolicyRule{RequireApproval: true, AppealWindow: "10m"}, warrantAwaitApproval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got, _ := evaluateWarrant(&tt.w, tt.rule, now); got != tt.want {
+				t.Errorf("evaluateWarrant() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSameAgent verifies that appeals match both polecat address forms.
+func TestSameAgent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"gastown/alpha", "gastown/polecats/alpha", true},
+		{"gastown/crew/joe", "gastown/crew/joe", true},
+		{"gastown/beta", "gastown/polecats/alpha", false},
+		{"overseer", "gastown/polecats/alpha", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := sameAgent(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameAgent(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// TestFindPendingWarrant verifies that dismissed and executed warrants are
+// not appealable.
+func TestFindPendingWarrant(t *testing.T) {
+	dir := t.TempDir()
+	for _, w := range []Warrant{
+		{ID: "w1", Target: "gastown/polecats/alpha", FiledBy: "witness"},
+		{ID: "w2", Target: "gastown/polecats/beta", FiledBy: "witness", Dismissed: true},
+	} {
+		if err := saveWarrant(&w, warrantFilePath(dir, w.Target)); err != nil {
+			t.Fatalf("saveWarrant() error = %v", err)
+		}
+	}
+
+	w, _, err := findPendingWarrant(dir, "gastown/alpha")
+	if err != nil || w.ID != "w1" {
+		t.Errorf("findPendingWarrant(alpha) = %v, %v; want w1", w, err)
+	}
+	if _, _, err := findPendingWarrant(dir, "gastown/beta"); err == nil {
+		t.Error("findPendingWarrant(beta) found a dismissed warrant")
+	}
+}
@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltclient"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -20,6 +26,9 @@ var (
 	wlBrowsePriority int
 	wlBrowseLimit    int
 	wlBrowseJSON     bool
+
+	wlBrowseMinPosterRate   float64
+	wlBrowseMinClaimantRate float64
 )
 
 var wlBrowseCmd = &cobra.Command{
@@ -30,7 +39,8 @@ var wlBrowseCmd = &cobra.Command{
 	Long: `Browse the Wasteland wanted board (hop/wl-commons).
 
 Uses the clone-then-discard pattern: clones the commons database to a
-temporary directory, queries it, then deletes the clone.
+temporary directory, queries it through a throwaway dolt sql-server, then
+deletes the clone.
 
 EXAMPLES:
   gt wl browse                          # All open wanted items
@@ -39,16 +49,24 @@ EXAMPLES:
   gt wl browse --status claimed         # Claimed items
   gt wl browse --priority 0             # Critical priority only
   gt wl browse --limit 5               # Show 5 items
-  gt wl browse --json                   # JSON output`,
+  gt wl browse --min-poster-rate 0.5    # Posters who finish half their claims
+  gt wl browse --status claimed --min-claimant-rate 0.8
+  gt wl browse --json                   # JSON output
+
+Reputation filters use completion rate (validated completions per claim,
+0-1) from the commons history; see gt wl profile. Rigs with no claims
+are excluded when a filter is set.`,
 }
 
 func init() {
 	wlBrowseCmd.Flags().StringVar(&wlBrowseProject, "project", "", "Filter by project (e.g., gastown, beads, hop)")
-	wlBrowseCmd.Flags().StringVar(&wlBrowseStatus, "status", "open", "Filter by status (open, claimed, in_review, completed, withdrawn)")
+	wlBrowseCmd.Flags().StringVar(&wlBrowseStatus, "status", "open", "Filter by status (open, claimed, in_review, disputed, completed, withdrawn)")
 	wlBrowseCmd.Flags().StringVar(&wlBrowseType, "type", "", "Filter by type (feature, bug, design, rfc, docs)")
 	wlBrowseCmd.Flags().IntVar(&wlBrowsePriority, "priority", -1, "Filter by priority (0=critical, 2=medium, 4=backlog)")
 	wlBrowseCmd.Flags().IntVar(&wlBrowseLimit, "limit", 50, "Maximum items to display")
 	wlBrowseCmd.Flags().BoolVar(&wlBrowseJSON, "json", false, "Output as JSON")
+	wlBrowseCmd.Flags().Float64Var(&wlBrowseMinPosterRate, "min-poster-rate", 0, "Only items whose poster has at least this completion rate (0-1)")
+	wlBrowseCmd.Flags().Float64Var(&wlBrowseMinClaimantRate, "min-claimant-rate", 0, "Only items whose claimant has at least this completion rate (0-1)")
 
 	wlCmd.AddCommand(wlBrowseCmd)
 }
@@ -57,6 +75,12 @@ func runWLBrowse(cmd *cobra.Command, args []string) error {
 	if _, err := workspace.FindFromCwdOrError(); err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
+	if wlBrowseMinPosterRate < 0 || wlBrowseMinPosterRate > 1 {
+		return fmt.Errorf("--min-poster-rate must be between 0 and 1, got %g", wlBrowseMinPosterRate)
+	}
+	if wlBrowseMinClaimantRate < 0 || wlBrowseMinClaimantRate > 1 {
+		return fmt.Errorf("--min-claimant-rate must be between 0 and 1, got %g", wlBrowseMinClaimantRate)
+	}
 
 	doltPath, err := exec.LookPath("dolt")
 	if err != nil {
@@ -83,23 +107,60 @@ func runWLBrowse(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Printf("%s Cloned successfully\n\n", style.Bold.Render("✓"))
 
-	query := buildBrowseQuery(BrowseFilter{
+	query, queryArgs := buildBrowseQuery(BrowseFilter{
 		Status:   wlBrowseStatus,
 		Project:  wlBrowseProject,
 		Type:     wlBrowseType,
 		Priority: wlBrowsePriority,
 		Limit:    wlBrowseLimit,
+
+		MinPosterRate:   wlBrowseMinPosterRate,
+		MinClaimantRate: wlBrowseMinClaimantRate,
 	})
 
+	client, stop, err := doltserver.ServeClone(cloneDir)
+	if err != nil {
+		return err
+	}
+	defer stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	items, err := doltclient.Select(ctx, client, scanBrowseRow, query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+
 	if wlBrowseJSON {
-		sqlCmd := exec.Command(doltPath, "sql", "-q", query, "-r", "json")
-		sqlCmd.Dir = cloneDir
-		sqlCmd.Stdout = os.Stdout
-		sqlCmd.Stderr = os.Stderr
-		return sqlCmd.Run()
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string][]wlBrowseRow{"rows": items})
 	}
 
-	return renderWLBrowseTable(doltPath, cloneDir, query)
+	renderWLBrowseTable(items)
+	return nil
+}
+
+// wlBrowseRow is one wanted item as listed by gt wl browse.
+type wlBrowseRow struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Project     string `json:"project"`
+	Type        string `json:"type"`
+	Priority    int    `json:"priority"`
+	PostedBy    string `json:"posted_by"`
+	Status      string `json:"status"`
+	EffortLevel string `json:"effort_level"`
+}
+
+func scanBrowseRow(row doltclient.Scanner) (wlBrowseRow, error) {
+	var r wlBrowseRow
+	var title, project, typ, postedBy, status, effort sql.NullString
+	var priority sql.NullInt64
+	err := row.Scan(&r.ID, &title, &project, &typ, &priority, &postedBy, &status, &effort)
+	r.Title, r.Project, r.Type = title.String, project.String, typ.String
+	r.Priority = int(priority.Int64)
+	r.PostedBy, r.Status, r.EffortLevel = postedBy.String, status.String, effort.String
+	return r, err
 }
 
 // BrowseFilter holds filter parameters for building a browse query.
@@ -109,23 +170,40 @@ type BrowseFilter struct {
 	Type     string
 	Priority int
 	Limit    int
+
+	// Minimum completion rate of the poster and claimant; 0 disables.
+	MinPosterRate   float64
+	MinClaimantRate float64
 }
 
-func buildBrowseQuery(f BrowseFilter) string {
+// buildBrowseQuery returns the browse query and its bound arguments.
+func buildBrowseQuery(f BrowseFilter) (string, []any) {
 	var conditions []string
+	var args []any
 
 	if f.Status != "" {
-		conditions = append(conditions, fmt.Sprintf("status = '%s'", doltserver.EscapeSQL(f.Status)))
+		conditions = append(conditions, "status = ?")
+		args = append(args, f.Status)
 	}
 	if f.Project != "" {
-		conditions = append(conditions, fmt.Sprintf("project = '%s'", doltserver.EscapeSQL(f.Project)))
+		conditions = append(conditions, "project = ?")
+		args = append(args, f.Project)
 	}
 	if f.Type != "" {
-		conditions = append(conditions, fmt.Sprintf("type = '%s'", doltserver.EscapeSQL(f.Type)))
+		conditions = append(conditions, "type = ?")
+		args = append(args, f.Type)
 	}
 	if f.Priority >= 0 {
 		conditions = append(conditions, fmt.Sprintf("priority = %d", f.Priority))
 	}
+	// The reputation select is inlined rather than read from the reputation
+	// view, since the browsed clone may predate the view.
+	if f.MinPosterRate > 0 {
+		conditions = append(conditions, fmt.Sprintf("posted_by IN (SELECT handle FROM (%s) rep WHERE completion_rate >= %g)", doltserver.WLReputationSelect, f.MinPosterRate))
+	}
+	if f.MinClaimantRate > 0 {
+		conditions = append(conditions, fmt.Sprintf("claimed_by IN (SELECT handle FROM (%s) rep WHERE completion_rate >= %g)", doltserver.WLReputationSelect, f.MinClaimantRate))
+	}
 
 	query := "SELECT id, title, project, type, priority, posted_by, status, effort_level FROM wanted"
 	if len(conditions) > 0 {
@@ -134,24 +212,13 @@ func buildBrowseQuery(f BrowseFilter) string {
 	query += " ORDER BY priority ASC, created_at DESC"
 	query += fmt.Sprintf(" LIMIT %d", f.Limit)
 
-	return query
+	return query, args
 }
 
-func renderWLBrowseTable(doltPath, cloneDir, query string) error {
-	sqlCmd := exec.Command(doltPath, "sql", "-q", query, "-r", "csv")
-	sqlCmd.Dir = cloneDir
-	output, err := sqlCmd.Output()
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return fmt.Errorf("query failed: %s", string(exitErr.Stderr))
-		}
-		return fmt.Errorf("running query: %w", err)
-	}
-
-	rows := wlParseCSV(string(output))
-	if len(rows) <= 1 {
+func renderWLBrowseTable(items []wlBrowseRow) {
+	if len(items) == 0 {
 		fmt.Println("No wanted items found matching your filters.")
-		return nil
+		return
 	}
 
 	tbl := style.NewTable(
@@ -165,18 +232,13 @@ func renderWLBrowseTable(doltPath, cloneDir, query string) error {
 		style.Column{Name: "EFFORT", Width: 8},
 	)
 
-	for _, row := range rows[1:] {
-		if len(row) < 8 {
-			continue
-		}
-		pri := wlFormatPriority(row[4])
-		tbl.AddRow(row[0], row[1], row[2], row[3], pri, row[5], row[6], row[7])
+	for _, item := range items {
+		pri := wlFormatPriority(strconv.Itoa(item.Priority))
+		tbl.AddRow(item.ID, item.Title, item.Project, item.Type, pri, item.PostedBy, item.Status, item.EffortLevel)
 	}
 
-	fmt.Printf("Wanted items (%d):\n\n", len(rows)-1)
+	fmt.Printf("Wanted items (%d):\n\n", len(items))
 	fmt.Print(tbl.Render())
-
-	return nil
 }
 
 func wlParseCSV(data string) [][]string {
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"slices"
 	"strings"
 	"testing"
 )
@@ -137,11 +138,14 @@ func TestBuildBrowseQuery_DefaultFilters(t *testing.T) {
 		Priority: -1,
 		Limit:    50,
 	}
-	got := buildBrowseQuery(f)
-	want := "SELECT id, title, project, type, priority, posted_by, status, effort_level FROM wanted WHERE status = 'open' ORDER BY priority ASC, created_at DESC LIMIT 50"
+	got, args := buildBrowseQuery(f)
+	want := "SELECT id, title, project, type, priority, posted_by, status, effort_level FROM wanted WHERE status = ? ORDER BY priority ASC, created_at DESC LIMIT 50"
 	if got != want {
 		t.Errorf("buildBrowseQuery(default) =\n  %q\nwant\n  %q", got, want)
 	}
+	if len(args) != 1 || args[0] != "open" {
+		t.Errorf("buildBrowseQuery(default) args = %v, want [open]", args)
+	}
 }
 
 func TestBuildBrowseQuery_AllFilters(t *testing.T) {
@@ -153,12 +157,12 @@ func TestBuildBrowseQuery_AllFilters(t *testing.T) {
 		Priority: 0,
 		Limit:    5,
 	}
-	got := buildBrowseQuery(f)
+	got, args := buildBrowseQuery(f)
 	// All four conditions should be present
 	for _, substr := range []string{
-		"status = 'open'",
-		"project = 'gastown'",
-		"type = 'bug'",
+		"status = ?",
+		"project = ?",
+		"type = ?",
 		"priority = 0",
 		"LIMIT 5",
 	} {
@@ -166,6 +170,9 @@ func TestBuildBrowseQuery_AllFilters(t *testing.T) {
 			t.Errorf("buildBrowseQuery(all) missing %q in %q", substr, got)
 		}
 	}
+	if want := []any{"open", "gastown", "bug"}; !slices.Equal(args, want) {
+		t.Errorf("buildBrowseQuery(all) args = %v, want %v", args, want)
+	}
 }
 
 func TestBuildBrowseQuery_NoFilters(t *testing.T) {
@@ -174,22 +181,56 @@ func TestBuildBrowseQuery_NoFilters(t *testing.T) {
 		Priority: -1,
 		Limit:    50,
 	}
-	got := buildBrowseQuery(f)
+	got, _ := buildBrowseQuery(f)
 	if strings.Contains(got, "WHERE") {
 		t.Errorf("buildBrowseQuery(none) should not have WHERE clause: %q", got)
 	}
 }
 
-func TestBuildBrowseQuery_EscapesSQL(t *testing.T) {
+func TestBuildBrowseQuery_BindsFilterValues(t *testing.T) {
 	t.Parallel()
 	f := BrowseFilter{
 		Status:   "it's",
+		Project:  `x\' OR 1=1 --`,
 		Priority: -1,
 		Limit:    50,
 	}
-	got := buildBrowseQuery(f)
-	if !strings.Contains(got, "it''s") {
-		t.Errorf("buildBrowseQuery should escape single quotes: %q", got)
+	got, args := buildBrowseQuery(f)
+	if strings.Contains(got, "it's") || strings.Contains(got, "OR 1=1") {
+		t.Errorf("buildBrowseQuery should bind filter values, not splice them: %q", got)
+	}
+	if len(args) != 2 || args[0] != f.Status || args[1] != f.Project {
+		t.Errorf("buildBrowseQuery args = %v, want values unchanged", args)
+	}
+}
+
+func TestBuildBrowseQuery_ReputationFilters(t *testing.T) {
+	t.Parallel()
+	f := BrowseFilter{
+		Status:          "claimed",
+		Priority:        -1,
+		Limit:           50,
+		MinPosterRate:   0.5,
+		MinClaimantRate: 0.75,
+	}
+	got, _ := buildBrowseQuery(f)
+	for _, substr := range []string{
+		"posted_by IN (SELECT handle FROM (",
+		"completion_rate >= 0.5)",
+		"claimed_by IN (SELECT handle FROM (",
+		"completion_rate >= 0.75)",
+		"dolt_history_wanted",
+	} {
+		if !strings.Contains(got, substr) {
+			t.Errorf("buildBrowseQuery(reputation) missing %q in %q", substr, got)
+		}
 	}
 }
 
+func TestBuildBrowseQuery_NoReputationFilterByDefault(t *testing.T) {
+	t.Parallel()
+	got, _ := buildBrowseQuery(BrowseFilter{Priority: -1, Limit: 50})
+	if strings.Contains(got, "completion_rate") {
+		t.Errorf("buildBrowseQuery without rate filters should not join reputation: %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/doltserver"
@@ -10,6 +11,8 @@ import (
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
+var wlClaimLease time.Duration
+
 var wlClaimCmd = &cobra.Command{
 	Use:   "claim <wanted-id>",
 	Short: "Claim a wanted item",
@@ -18,16 +21,24 @@ var wlClaimCmd = &cobra.Command{
 Updates the wanted row: claimed_by=<your rig handle>, status='claimed'.
 The item must exist and have status='open'.
 
+A claim is a lease: it holds for --lease (default: claim_lease in
+mayor/wasteland.json, or 72h) and must be extended with gt wl renew while
+you work. When a lease runs out, gt wl sync returns the item to 'open' so
+another rig can pick it up. You are mailed a warning before expiry.
+
 In wild-west mode (Phase 1), this writes directly to the local wl-commons
 database. In PR mode, this will create a DoltHub PR instead.
 
 Examples:
-  gt wl claim w-abc123`,
+  gt wl claim w-abc123
+  gt wl claim w-abc123 --lease 24h`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWlClaim,
 }
 
 func init() {
+	wlClaimCmd.Flags().DurationVar(&wlClaimLease, "lease", 0, "How long the claim holds without renewal (default from config, else 72h)")
+
 	wlCmd.AddCommand(wlClaimCmd)
 }
 
@@ -44,13 +55,17 @@ func runWlClaim(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading wasteland config: %w", err)
 	}
 	rigHandle := wlCfg.RigHandle
+	lease, err := wlLease(wlCfg, wlClaimLease)
+	if err != nil {
+		return err
+	}
 
 	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDB) {
 		return fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDB)
 	}
 
 	store := doltserver.NewWLCommons(townRoot)
-	item, err := claimWanted(store, wantedID, rigHandle)
+	item, err := claimWanted(store, wantedID, rigHandle, lease)
 	if err != nil {
 		return err
 	}
@@ -58,6 +73,7 @@ func runWlClaim(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s Claimed %s\n", style.Bold.Render("✓"), wantedID)
 	fmt.Printf("  Claimed by: %s\n", rigHandle)
 	fmt.Printf("  Title: %s\n", item.Title)
+	fmt.Printf("  Lease: %s (renew with: gt wl renew %s)\n", lease, wantedID)
 
 	return nil
 }
@@ -65,7 +81,7 @@ func runWlClaim(cmd *cobra.Command, args []string) error {
 // claimWanted contains the testable business logic for claiming a wanted item.
 // The returned WantedItem reflects pre-claim state (status "open", empty ClaimedBy);
 // callers needing post-claim state should re-query.
-func claimWanted(store doltserver.WLCommonsStore, wantedID, rigHandle string) (*doltserver.WantedItem, error) {
+func claimWanted(store doltserver.WLCommonsStore, wantedID, rigHandle string, lease time.Duration) (*doltserver.WantedItem, error) {
 	item, err := store.QueryWanted(wantedID)
 	if err != nil {
 		return nil, fmt.Errorf("querying wanted item: %w", err)
@@ -75,7 +91,7 @@ func claimWanted(store doltserver.WLCommonsStore, wantedID, rigHandle string) (*
 		return nil, fmt.Errorf("wanted item %s is not open (status: %s)", wantedID, item.Status)
 	}
 
-	if err := store.ClaimWanted(wantedID, rigHandle); err != nil {
+	if err := store.ClaimWanted(wantedID, rigHandle, lease); err != nil {
 		return nil, fmt.Errorf("claiming wanted item: %w", err)
 	}
 
@@ -14,7 +14,7 @@ func TestClaimWanted_Success(t *testing.T) {
 		Title: "Fix auth bug",
 	})
 
-	item, err := claimWanted(store, "w-abc123", "my-rig")
+	item, err := claimWanted(store, "w-abc123", "my-rig", doltserver.DefaultClaimLease)
 	if err != nil {
 		t.Fatalf("claimWanted() error: %v", err)
 	}
@@ -41,7 +41,7 @@ func TestClaimWanted_NotOpen(t *testing.T) {
 		Status: "claimed",
 	})
 
-	_, err := claimWanted(store, "w-abc123", "my-rig")
+	_, err := claimWanted(store, "w-abc123", "my-rig", doltserver.DefaultClaimLease)
 	if err == nil {
 		t.Fatal("claimWanted() expected error for non-open item")
 	}
@@ -51,7 +51,7 @@ func TestClaimWanted_NotFound(t *testing.T) {
 	t.Parallel()
 	store := newFakeWLCommonsStore()
 
-	_, err := claimWanted(store, "w-nonexistent", "my-rig")
+	_, err := claimWanted(store, "w-nonexistent", "my-rig", doltserver.DefaultClaimLease)
 	if err == nil {
 		t.Fatal("claimWanted() expected error for missing item")
 	}
@@ -23,6 +23,8 @@ Inserts a completion record and updates the wanted item status to 'in_review'.
 The item must be claimed by your rig.
 
 The --evidence flag provides the evidence URL (PR link, commit hash, etc.).
+Another rig then reviews it with gt wl validate, gt wl reject or
+gt wl dispute.
 
 A completion ID is generated as c-<hash> where hash is derived from the
 wanted ID, rig handle, and timestamp.
@@ -70,7 +72,7 @@ func runWlDone(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Completion ID: %s\n", completionID)
 	fmt.Printf("  Completed by: %s\n", rigHandle)
 	fmt.Printf("  Evidence: %s\n", wlDoneEvidence)
-	fmt.Printf("  Status: in_review\n")
+	fmt.Printf("  Status: in_review (awaiting validation by another rig)\n")
 
 	return nil
 }
@@ -48,7 +48,7 @@ func TestSubmitDone_Success(t *testing.T) {
 		Title: "Fix bug",
 	})
 	// Claim it first
-	_ = store.ClaimWanted("w-abc", "my-rig")
+	_ = store.ClaimWanted("w-abc", "my-rig", doltserver.DefaultClaimLease)
 
 	err := submitDone(store, "w-abc", "my-rig", "https://github.com/pr/1", "c-test123")
 	if err != nil {
@@ -83,7 +83,7 @@ func TestSubmitDone_WrongClaimer(t *testing.T) {
 		ID:    "w-abc",
 		Title: "Fix bug",
 	})
-	_ = store.ClaimWanted("w-abc", "other-rig")
+	_ = store.ClaimWanted("w-abc", "other-rig", doltserver.DefaultClaimLease)
 
 	err := submitDone(store, "w-abc", "my-rig", "evidence", "c-test")
 	if err == nil {
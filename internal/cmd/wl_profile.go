@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wasteland"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var wlProfileJSON bool
+
+var wlProfileCmd = &cobra.Command{
+	Use:   "profile [handle]",
+	Short: "Show a rig's reputation on the wasteland",
+	Long: `Show the reputation of a rig, computed from the commons history.
+
+The profile reports how many wanted items the rig has posted and claimed,
+its completion rate (validated completions per claim), the median time
+from claim to the submission that was validated, its review pass rate,
+the stamps it has received by skill tag, and its badges.
+
+Claims that were released or rejected still count, because the profile is
+computed from the committed Dolt history of your local commons. Run
+gt wl sync first to include the latest upstream activity.
+
+Defaults to your own rig.
+
+Examples:
+  gt wl profile
+  gt wl profile alice-rig
+  gt wl profile alice-rig --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWlProfile,
+}
+
+func init() {
+	wlProfileCmd.Flags().BoolVar(&wlProfileJSON, "json", false, "Output as JSON")
+
+	wlCmd.AddCommand(wlProfileCmd)
+}
+
+func runWlProfile(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	handle := ""
+	if len(args) > 0 {
+		handle = args[0]
+	} else {
+		wlCfg, err := wasteland.LoadConfig(townRoot)
+		if err != nil {
+			return fmt.Errorf("loading wasteland config: %w", err)
+		}
+		handle = wlCfg.RigHandle
+	}
+
+	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDB) {
+		return fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDB)
+	}
+
+	rep, err := doltserver.QueryReputation(townRoot, handle)
+	if err != nil {
+		return err
+	}
+
+	if wlProfileJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	}
+
+	fmt.Print(formatWLProfile(rep))
+	return nil
+}
+
+// formatWLProfile renders a reputation for the terminal.
+func formatWLProfile(r *doltserver.Reputation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", style.Bold.Render(r.Handle))
+	median := "—"
+	if r.MedianTimeToComplete > 0 {
+		median = formatDuration(r.MedianTimeToComplete)
+	}
+	for _, row := range [][2]string{
+		{"Posted", fmt.Sprint(r.Posted)},
+		{"Claimed", fmt.Sprint(r.Claims)},
+		{"Completed", fmt.Sprintf("%d validated, %d pending, %d rejected", r.Validated, r.Pending, r.Rejected)},
+		{"Completion rate", wlFormatRate(r.CompletionRate, r.Claims)},
+		{"Review pass rate", wlFormatRate(r.PassRate, r.Validated+r.Rejected)},
+		{"Median time to complete", median},
+	} {
+		fmt.Fprintf(&b, "  %-25s %s\n", row[0]+":", row[1])
+	}
+	fmt.Fprintf(&b, "\n  Stamps: %d\n", r.Stamps)
+	tags := make([]string, 0, len(r.StampsBySkill))
+	for t := range r.StampsBySkill {
+		tags = append(tags, t)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if r.StampsBySkill[tags[i]] != r.StampsBySkill[tags[j]] {
+			return r.StampsBySkill[tags[i]] > r.StampsBySkill[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	for _, t := range tags {
+		fmt.Fprintf(&b, "    %-20s %d\n", t, r.StampsBySkill[t])
+	}
+
+	fmt.Fprintf(&b, "\n  Badges: %d\n", len(r.Badges))
+	for _, badge := range r.Badges {
+		awarded := ""
+		if !badge.AwardedAt.IsZero() {
+			awarded = badge.AwardedAt.Format("2006-01-02")
+		}
+		fmt.Fprintf(&b, "    %-20s %s\n", badge.Type, style.Dim.Render(awarded))
+	}
+	return b.String()
+}
+
+// wlFormatRate renders a rate as a percentage with its sample size, or a
+// dash when there is no sample.
+func wlFormatRate(rate float64, of int) string {
+	if of == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.0f%% (of %d)", rate*100, of)
+}
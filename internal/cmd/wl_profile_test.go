@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+func TestFormatWLProfile(t *testing.T) {
+	t.Parallel()
+	got := formatWLProfile(&doltserver.Reputation{
+		Handle:               "alice-rig",
+		Claims:               4,
+		Validated:            3,
+		Rejected:             1,
+		CompletionRate:       0.75,
+		PassRate:             0.75,
+		MedianTimeToComplete: 26 * time.Hour,
+		Stamps:               3,
+		StampsBySkill:        map[string]int{"go": 1, "sql": 2},
+		Badges:               []*doltserver.Badge{{ID: "b-1", Type: "first-blood"}},
+	})
+	for _, want := range []string{
+		"alice-rig",
+		"75% (of 4)",
+		"1d 2h 0m",
+		"3 validated, 0 pending, 1 rejected",
+		"first-blood",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("profile missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "sql") > strings.Index(got, "go ") {
+		t.Errorf("skill tags should be ordered by count:\n%s", got)
+	}
+}
+
+func TestFormatWLProfile_NoHistory(t *testing.T) {
+	t.Parallel()
+	got := formatWLProfile(&doltserver.Reputation{Handle: "new-rig"})
+	if strings.Count(got, "—") != 3 {
+		t.Errorf("rates and median should render as dashes with no history:\n%s", got)
+	}
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wasteland"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// wlClaimWarnBefore is how long before a lease expires the claimer is mailed.
+const wlClaimWarnBefore = 24 * time.Hour
+
+var wlRenewLease time.Duration
+
+var wlRenewCmd = &cobra.Command{
+	Use:   "renew <wanted-id>",
+	Short: "Extend the lease on a claimed wanted item",
+	Long: `Extend your claim on a wanted item.
+
+Claims are leases. Renewing restarts the lease from now, for --lease
+(default: claim_lease in mayor/wasteland.json, or 72h), and clears any
+expiry warning. Only the rig holding the claim can renew it. A lease that
+has run out can still be renewed until gt wl sync reaps it.
+
+Examples:
+  gt wl renew w-abc123
+  gt wl renew w-abc123 --lease 168h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWlRenew,
+}
+
+func init() {
+	wlRenewCmd.Flags().DurationVar(&wlRenewLease, "lease", 0, "New lease length from now (default from config, else 72h)")
+
+	wlCmd.AddCommand(wlRenewCmd)
+}
+
+func runWlRenew(cmd *cobra.Command, args []string) error {
+	wantedID := args[0]
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	wlCfg, err := wasteland.LoadConfig(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading wasteland config: %w", err)
+	}
+	lease, err := wlLease(wlCfg, wlRenewLease)
+	if err != nil {
+		return err
+	}
+
+	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDB) {
+		return fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDB)
+	}
+
+	store := doltserver.NewWLCommons(townRoot)
+	if err := renewClaim(store, wantedID, wlCfg.RigHandle, lease); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Renewed claim on %s\n", style.Bold.Render("✓"), wantedID)
+	fmt.Printf("  Expires: %s (in %s)\n", time.Now().Add(lease).Format("2006-01-02 15:04"), lease)
+
+	return nil
+}
+
+// wlLease picks the lease for a claim or renewal: the --lease flag, then
+// the wasteland config, then the commons default.
+func wlLease(cfg *wasteland.Config, flag time.Duration) (time.Duration, error) {
+	if flag < 0 {
+		return 0, fmt.Errorf("--lease must be positive, got %s", flag)
+	}
+	if flag > 0 {
+		return flag, nil
+	}
+	configured, err := cfg.ClaimLeaseDuration()
+	if err != nil {
+		return 0, err
+	}
+	if configured > 0 {
+		return configured, nil
+	}
+	return doltserver.DefaultClaimLease, nil
+}
+
+// renewClaim contains the testable business logic for renewing a claim.
+func renewClaim(store doltserver.WLCommonsStore, wantedID, rigHandle string, lease time.Duration) error {
+	item, err := store.QueryWanted(wantedID)
+	if err != nil {
+		return fmt.Errorf("querying wanted item: %w", err)
+	}
+
+	if item.Status != "claimed" {
+		return fmt.Errorf("wanted item %s is not claimed (status: %s)", wantedID, item.Status)
+	}
+
+	if item.ClaimedBy != rigHandle {
+		return fmt.Errorf("wanted item %s is claimed by %q, not %q", wantedID, item.ClaimedBy, rigHandle)
+	}
+
+	if err := store.RenewClaim(wantedID, rigHandle, lease); err != nil {
+		return fmt.Errorf("renewing claim: %w", err)
+	}
+
+	return nil
+}
+
+// claimReap is what reapClaims did.
+type claimReap struct {
+	Released []*doltserver.ClaimLease // Expired claims returned to open
+	Warned   []*doltserver.ClaimLease // Our claims close to expiry
+	Errors   []error
+}
+
+// reapClaims returns expired claims to open and warns about our own claims
+// that expire within wlClaimWarnBefore. Each lease is warned about once;
+// warn is called before the warning is recorded, so a failed mail is
+// retried on the next sync. Per-item failures are collected, not fatal.
+func reapClaims(store doltserver.WLCommonsStore, rigHandle string, now time.Time, warn func(*doltserver.ClaimLease) error) (*claimReap, error) {
+	leases, err := store.QueryClaimLeases()
+	if err != nil {
+		return nil, fmt.Errorf("querying claim leases: %w", err)
+	}
+
+	result := &claimReap{}
+	for _, l := range leases {
+		switch {
+		case l.Expired(now):
+			if err := store.ReleaseExpiredClaim(l.WantedID, l.RigHandle); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("releasing %s: %w", l.WantedID, err))
+				continue
+			}
+			result.Released = append(result.Released, l)
+		case l.RigHandle == rigHandle && l.WarnedAt.IsZero() && l.ExpiresAt.Sub(now) <= wlClaimWarnBefore:
+			if err := warn(l); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("warning about %s: %w", l.WantedID, err))
+				continue
+			}
+			if err := store.MarkClaimWarned(l.WantedID, l.RigHandle); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("recording warning for %s: %w", l.WantedID, err))
+				continue
+			}
+			result.Warned = append(result.Warned, l)
+		}
+	}
+	return result, nil
+}
+
+// claimExpiryMail is the warning sent to the mayor before one of our claims
+// expires.
+func claimExpiryMail(l *doltserver.ClaimLease, now time.Time) *mail.Message {
+	left := l.ExpiresAt.Sub(now).Round(time.Minute)
+	var body strings.Builder
+	fmt.Fprintf(&body, "Wanted: %s\n", l.WantedID)
+	if l.Title != "" {
+		fmt.Fprintf(&body, "Title: %s\n", l.Title)
+	}
+	fmt.Fprintf(&body, "Claimed by: %s\n", l.RigHandle)
+	fmt.Fprintf(&body, "Expires: %s (in %s)\n\n", l.ExpiresAt.Format(time.RFC3339), left)
+	fmt.Fprintf(&body, "Renew with: gt wl renew %s\n", l.WantedID)
+	fmt.Fprintf(&body, "Or finish with: gt wl done %s --evidence <url>\n", l.WantedID)
+	body.WriteString("If the lease runs out, the next gt wl sync returns the item to the board.")
+
+	return &mail.Message{
+		From:     "gt-wl",
+		To:       "mayor/",
+		Subject:  fmt.Sprintf("WL_CLAIM_EXPIRING: %s", l.WantedID),
+		Body:     body.String(),
+		Type:     mail.TypeNotification,
+		Priority: mail.PriorityHigh,
+	}
+}
+
+// runClaimReaper reaps claims in the local commons after a sync and prints
+// what it did. Failures are reported but do not fail the sync.
+func runClaimReaper(townRoot string) {
+	wlCfg, err := wasteland.LoadConfig(townRoot)
+	if err != nil || !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDB) {
+		return
+	}
+
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	now := time.Now()
+	result, err := reapClaims(doltserver.NewWLCommons(townRoot), wlCfg.RigHandle, now, func(l *doltserver.ClaimLease) error {
+		return router.Send(claimExpiryMail(l, now))
+	})
+	if err != nil {
+		style.PrintWarning("could not check claim leases: %v", err)
+		return
+	}
+
+	for _, l := range result.Released {
+		fmt.Printf("  %s Released expired claim on %s (was %s)\n", style.Warning.Render("↺"), l.WantedID, l.RigHandle)
+	}
+	for _, l := range result.Warned {
+		fmt.Printf("  %s Claim on %s expires %s — mailed mayor\n", style.Warning.Render("⚠"), l.WantedID, l.ExpiresAt.Format("2006-01-02 15:04"))
+	}
+	for _, e := range result.Errors {
+		style.PrintWarning("%v", e)
+	}
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/wasteland"
+)
+
+// newClaimedStore returns a fake store with wantedID claimed by rig for lease.
+func newClaimedStore(t *testing.T, wantedID, rig string, lease time.Duration) *fakeWLCommonsStore {
+	t.Helper()
+	store := newFakeWLCommonsStore()
+	if err := store.InsertWanted(&doltserver.WantedItem{ID: wantedID, Title: "Leased work"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := claimWanted(store, wantedID, rig, lease); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestRenewClaim_ExtendsLease(t *testing.T) {
+	t.Parallel()
+	store := newClaimedStore(t, "w-ren1", "my-rig", time.Hour)
+	start := store.now()
+
+	store.now = func() time.Time { return start.Add(50 * time.Minute) }
+	if err := renewClaim(store, "w-ren1", "my-rig", 2*time.Hour); err != nil {
+		t.Fatalf("renewClaim() error: %v", err)
+	}
+
+	leases, _ := store.QueryClaimLeases()
+	if len(leases) != 1 {
+		t.Fatalf("got %d leases, want 1", len(leases))
+	}
+	if want := start.Add(170 * time.Minute); !leases[0].ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", leases[0].ExpiresAt, want)
+	}
+}
+
+func TestRenewClaim_WrongRig(t *testing.T) {
+	t.Parallel()
+	store := newClaimedStore(t, "w-ren2", "my-rig", time.Hour)
+
+	if err := renewClaim(store, "w-ren2", "other-rig", time.Hour); err == nil {
+		t.Fatal("renewClaim() by another rig should fail")
+	}
+}
+
+func TestReapClaims_ReleasesExpired(t *testing.T) {
+	t.Parallel()
+	store := newClaimedStore(t, "w-reap1", "other-rig", time.Hour)
+	store.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	result, err := reapClaims(store, "my-rig", store.now(), func(*doltserver.ClaimLease) error {
+		t.Error("no warning expected for an expired claim")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("reapClaims() error: %v", err)
+	}
+	if len(result.Released) != 1 || result.Released[0].WantedID != "w-reap1" {
+		t.Fatalf("Released = %+v, want w-reap1", result.Released)
+	}
+
+	got, _ := store.QueryWanted("w-reap1")
+	if got.Status != "open" || got.ClaimedBy != "" {
+		t.Errorf("after reap: %+v, want open and unclaimed", got)
+	}
+	if _, err := claimWanted(store, "w-reap1", "my-rig", time.Hour); err != nil {
+		t.Errorf("reclaim after reap: %v", err)
+	}
+}
+
+func TestReapClaims_WarnsOwnClaimOnce(t *testing.T) {
+	t.Parallel()
+	store := newClaimedStore(t, "w-reap2", "my-rig", 12*time.Hour)
+	_ = store.InsertWanted(&doltserver.WantedItem{ID: "w-reap3", Title: "Someone else's"})
+	_ = store.ClaimWanted("w-reap3", "other-rig", 12*time.Hour)
+	_ = store.InsertWanted(&doltserver.WantedItem{ID: "w-reap4", Title: "Plenty of time"})
+	_ = store.ClaimWanted("w-reap4", "my-rig", 72*time.Hour)
+
+	var warned []string
+	warn := func(l *doltserver.ClaimLease) error {
+		warned = append(warned, l.WantedID)
+		return nil
+	}
+	if _, err := reapClaims(store, "my-rig", store.now(), warn); err != nil {
+		t.Fatalf("reapClaims() error: %v", err)
+	}
+	if len(warned) != 1 || warned[0] != "w-reap2" {
+		t.Fatalf("warned = %v, want [w-reap2]", warned)
+	}
+
+	// A second sync does not warn again; renewing re-arms the warning.
+	if _, err := reapClaims(store, "my-rig", store.now(), warn); err != nil {
+		t.Fatalf("second reapClaims() error: %v", err)
+	}
+	if len(warned) != 1 {
+		t.Errorf("warned again: %v", warned)
+	}
+	_ = renewClaim(store, "w-reap2", "my-rig", time.Hour)
+	if _, err := reapClaims(store, "my-rig", store.now(), warn); err != nil {
+		t.Fatalf("third reapClaims() error: %v", err)
+	}
+	if len(warned) != 2 {
+		t.Errorf("warned = %v, want a new warning after renewal", warned)
+	}
+}
+
+func TestReapClaims_FailedWarningIsRetried(t *testing.T) {
+	t.Parallel()
+	store := newClaimedStore(t, "w-reap5", "my-rig", time.Hour)
+
+	result, err := reapClaims(store, "my-rig", store.now(), func(*doltserver.ClaimLease) error {
+		return errors.New("mail down")
+	})
+	if err != nil {
+		t.Fatalf("reapClaims() error: %v", err)
+	}
+	if len(result.Errors) != 1 || len(result.Warned) != 0 {
+		t.Errorf("result = %+v, want one error and no warnings", result)
+	}
+	leases, _ := store.QueryClaimLeases()
+	if !leases[0].WarnedAt.IsZero() {
+		t.Error("failed warning must not be recorded")
+	}
+}
+
+func TestRejectCompletion_RestartsLease(t *testing.T) {
+	t.Parallel()
+	store := newInReviewStore(t, "w-reap6")
+	store.now = func() time.Time { return time.Now().Add(100 * time.Hour) }
+
+	if _, err := rejectCompletion(store, "w-reap6", "reviewer-rig", "redo", "r-1"); err != nil {
+		t.Fatalf("rejectCompletion() error: %v", err)
+	}
+	result, err := reapClaims(store, "doer-rig", store.now(), func(*doltserver.ClaimLease) error { return nil })
+	if err != nil {
+		t.Fatalf("reapClaims() error: %v", err)
+	}
+	if len(result.Released) != 0 {
+		t.Errorf("rejected item was reaped immediately: %+v", result.Released)
+	}
+}
+
+func TestWlLease_Precedence(t *testing.T) {
+	t.Parallel()
+	cfg := &wasteland.Config{}
+	if got, _ := wlLease(cfg, 0); got != doltserver.DefaultClaimLease {
+		t.Errorf("default lease = %s, want %s", got, doltserver.DefaultClaimLease)
+	}
+	cfg.ClaimLease = "48h"
+	if got, _ := wlLease(cfg, 0); got != 48*time.Hour {
+		t.Errorf("configured lease = %s, want 48h", got)
+	}
+	if got, _ := wlLease(cfg, time.Hour); got != time.Hour {
+		t.Errorf("flag lease = %s, want 1h", got)
+	}
+	cfg.ClaimLease = "soon"
+	if _, err := wlLease(cfg, 0); err == nil {
+		t.Error("expected error for invalid claim_lease")
+	}
+}
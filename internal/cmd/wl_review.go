@@ -0,0 +1,491 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wasteland"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	wlValidateQuality     int
+	wlValidateReliability int
+	wlValidateSeverity    string
+	wlValidateSkills      []string
+	wlValidateMessage     string
+	wlRejectReason        string
+	wlDisputeReason       string
+	wlDisputeQuorum       int
+)
+
+var wlValidateCmd = &cobra.Command{
+	Use:   "validate <wanted-id>",
+	Short: "Validate a completion and issue a stamp",
+	Long: `Validate the completion evidence of an in_review wanted item.
+
+Marks the completion validated by your rig, moves the item to 'completed'
+and issues a stamp: a reputation attestation from your rig to the rig that
+did the work. You cannot validate your own completion.
+
+The stamp scores the work on two dimensions, 1 (poor) to 5 (excellent):
+  --quality       How good the work is
+  --reliability   How well the rig delivered what it claimed
+
+--severity sets how much weight the stamp carries:
+  leaf     Routine work (default)
+  branch   Significant work others build on
+  root     Foundational work
+
+If the item is disputed, this casts a validate vote instead; the stamp is
+issued only if the dispute resolves in favor of the completion.
+
+Examples:
+  gt wl validate w-abc123
+  gt wl validate w-abc123 --quality 5 --reliability 4 --skill go --skill sql
+  gt wl validate w-abc123 --severity branch -m 'Clean fix, good tests'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWlValidate,
+}
+
+var wlRejectCmd = &cobra.Command{
+	Use:   "reject <wanted-id>",
+	Short: "Reject a completion and return the item to its claimer",
+	Long: `Reject the completion evidence of an in_review wanted item.
+
+The item goes back to 'claimed' by the same rig, which can rework it and
+submit again with gt wl done. The rejected evidence and your reasons are
+kept in the reviews table. You cannot reject your own completion.
+
+If the item is disputed, this casts a reject vote instead.
+
+Examples:
+  gt wl reject w-abc123 --reason 'Tests fail on main'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWlReject,
+}
+
+var wlDisputeCmd = &cobra.Command{
+	Use:   "dispute <wanted-id>",
+	Short: "Open a dispute on a completion",
+	Long: `Open a dispute on an in_review completion that one reviewer should not
+settle alone.
+
+The item moves to 'disputed'. Other rigs then vote with gt wl validate or
+gt wl reject; the first verdict to reach --quorum votes wins. A validated
+dispute completes the item and issues a stamp from every validating
+reviewer; a rejected dispute returns the item to its claimer.
+
+Opening a dispute does not cast a vote. The rig that did the work cannot
+open a dispute or vote on it.
+
+Examples:
+  gt wl dispute w-abc123 --reason 'Evidence link is a draft PR'
+  gt wl dispute w-abc123 --reason 'Partial fix' --quorum 5`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWlDispute,
+}
+
+var wlReviewCmd = &cobra.Command{
+	Use:   "review <wanted-id>",
+	Short: "Show the completion evidence and dispute votes for an item",
+	Long: `Show what a reviewer needs to judge a completion: the item, the rig that
+completed it, its evidence, and for disputed items the votes so far.
+
+Examples:
+  gt wl review w-abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWlReview,
+}
+
+func init() {
+	wlValidateCmd.Flags().IntVar(&wlValidateQuality, "quality", 3, "Quality score, 1-5")
+	wlValidateCmd.Flags().IntVar(&wlValidateReliability, "reliability", 3, "Reliability score, 1-5")
+	wlValidateCmd.Flags().StringVar(&wlValidateSeverity, "severity", "leaf", "Stamp weight: leaf, branch, root")
+	wlValidateCmd.Flags().StringArrayVar(&wlValidateSkills, "skill", nil, "Skill demonstrated by the work (repeatable)")
+	wlValidateCmd.Flags().StringVarP(&wlValidateMessage, "message", "m", "", "Note attached to the stamp")
+
+	wlRejectCmd.Flags().StringVar(&wlRejectReason, "reason", "", "Why the completion is rejected (required)")
+	_ = wlRejectCmd.MarkFlagRequired("reason")
+
+	wlDisputeCmd.Flags().StringVar(&wlDisputeReason, "reason", "", "Why the completion is disputed (required)")
+	wlDisputeCmd.Flags().IntVar(&wlDisputeQuorum, "quorum", 3, "Votes needed to settle the dispute")
+	_ = wlDisputeCmd.MarkFlagRequired("reason")
+
+	wlCmd.AddCommand(wlValidateCmd)
+	wlCmd.AddCommand(wlRejectCmd)
+	wlCmd.AddCommand(wlDisputeCmd)
+	wlCmd.AddCommand(wlReviewCmd)
+}
+
+// openWLReviewStore resolves the town, the local rig handle and the commons
+// store shared by the review commands.
+func openWLReviewStore() (*doltserver.WLCommons, string, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	wlCfg, err := wasteland.LoadConfig(townRoot)
+	if err != nil {
+		return nil, "", fmt.Errorf("loading wasteland config: %w", err)
+	}
+
+	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDB) {
+		return nil, "", fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDB)
+	}
+
+	return doltserver.NewWLCommons(townRoot), wlCfg.RigHandle, nil
+}
+
+func runWlValidate(cmd *cobra.Command, args []string) error {
+	wantedID := args[0]
+
+	stamp, err := buildStamp(wlValidateQuality, wlValidateReliability, wlValidateSeverity, wlValidateSkills, wlValidateMessage)
+	if err != nil {
+		return err
+	}
+
+	store, rigHandle, err := openWLReviewStore()
+	if err != nil {
+		return err
+	}
+	stamp.Author = rigHandle
+	stamp.ID = generateReviewID("s", wantedID, rigHandle)
+
+	tally, err := validateCompletion(store, wantedID, stamp, generateReviewID("r", wantedID, rigHandle))
+	if err != nil {
+		return err
+	}
+	if tally != nil {
+		printDisputeTally(wantedID, tally)
+		return nil
+	}
+
+	fmt.Printf("%s Validated %s\n", style.Bold.Render("✓"), wantedID)
+	fmt.Printf("  Stamp: %s → %s (%s)\n", rigHandle, stamp.Subject, stamp.ID)
+	fmt.Printf("  Quality: %d  Reliability: %d  Severity: %s\n",
+		stamp.Valence["quality"], stamp.Valence["reliability"], stamp.Severity)
+	fmt.Printf("  Status: completed\n")
+
+	return nil
+}
+
+func runWlReject(cmd *cobra.Command, args []string) error {
+	wantedID := args[0]
+
+	store, rigHandle, err := openWLReviewStore()
+	if err != nil {
+		return err
+	}
+
+	tally, err := rejectCompletion(store, wantedID, rigHandle, wlRejectReason, generateReviewID("r", wantedID, rigHandle))
+	if err != nil {
+		return err
+	}
+	if tally != nil {
+		printDisputeTally(wantedID, tally)
+		return nil
+	}
+
+	fmt.Printf("%s Rejected %s\n", style.Bold.Render("✓"), wantedID)
+	fmt.Printf("  Reason: %s\n", wlRejectReason)
+	fmt.Printf("  Status: claimed (returned to claimer for rework)\n")
+
+	return nil
+}
+
+func runWlDispute(cmd *cobra.Command, args []string) error {
+	wantedID := args[0]
+
+	store, rigHandle, err := openWLReviewStore()
+	if err != nil {
+		return err
+	}
+
+	d := &doltserver.Dispute{
+		ID:       generateReviewID("d", wantedID, rigHandle),
+		WantedID: wantedID,
+		OpenedBy: rigHandle,
+		Reason:   wlDisputeReason,
+		Quorum:   wlDisputeQuorum,
+	}
+	if err := openDispute(store, d); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Opened dispute %s on %s\n", style.Bold.Render("✓"), d.ID, wantedID)
+	fmt.Printf("  Reason: %s\n", d.Reason)
+	fmt.Printf("  Quorum: %d votes\n", d.Quorum)
+	fmt.Printf("  Status: disputed\n")
+	fmt.Printf("\n  Reviewers vote with: gt wl validate %s  or  gt wl reject %s --reason ...\n", wantedID, wantedID)
+
+	return nil
+}
+
+func runWlReview(cmd *cobra.Command, args []string) error {
+	wantedID := args[0]
+
+	store, _, err := openWLReviewStore()
+	if err != nil {
+		return err
+	}
+
+	item, err := store.QueryWanted(wantedID)
+	if err != nil {
+		return fmt.Errorf("querying wanted item: %w", err)
+	}
+	fmt.Printf("%s %s\n", style.Bold.Render(item.ID), item.Title)
+	fmt.Printf("  Status: %s\n", item.Status)
+
+	c, err := store.QueryCompletion(wantedID)
+	if err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render("No completion submitted"))
+		return nil
+	}
+	fmt.Printf("  Completed by: %s (%s)\n", c.CompletedBy, c.ID)
+	fmt.Printf("  Evidence: %s\n", c.Evidence)
+	if c.ValidatedBy != "" {
+		fmt.Printf("  Validated by: %s\n", c.ValidatedBy)
+	}
+
+	if item.Status != "disputed" {
+		return nil
+	}
+	d, votes, err := store.QueryOpenDispute(wantedID)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return nil
+	}
+	fmt.Printf("\n%s %s opened by %s\n", style.Warning.Render("Dispute"), d.ID, d.OpenedBy)
+	fmt.Printf("  Reason: %s\n", d.Reason)
+	validate, reject := tallyVotes(votes)
+	fmt.Printf("  Votes: %d validate, %d reject (quorum %d)\n", validate, reject, d.Quorum)
+	for _, v := range votes {
+		line := fmt.Sprintf("    %-8s %s", v.Verdict, v.Reviewer)
+		if v.Reason != "" {
+			line += style.Dim.Render(" — " + v.Reason)
+		}
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+// disputeTally is the state of a dispute after a vote.
+type disputeTally struct {
+	Dispute  *doltserver.Dispute
+	Validate int
+	Reject   int
+	Resolved string // Winning verdict, or "" if quorum is not reached yet
+}
+
+func printDisputeTally(wantedID string, t *disputeTally) {
+	fmt.Printf("%s Vote recorded on dispute %s\n", style.Bold.Render("✓"), t.Dispute.ID)
+	fmt.Printf("  Votes: %d validate, %d reject (quorum %d)\n", t.Validate, t.Reject, t.Dispute.Quorum)
+	switch t.Resolved {
+	case doltserver.VerdictValidate:
+		fmt.Printf("  %s Dispute resolved: %s is completed\n", style.Success.Render("✓"), wantedID)
+	case doltserver.VerdictReject:
+		fmt.Printf("  %s Dispute resolved: %s returned to its claimer\n", style.Warning.Render("✗"), wantedID)
+	default:
+		fmt.Printf("  Status: disputed\n")
+	}
+}
+
+// buildStamp validates the stamp flags and returns a stamp without author,
+// subject or ID.
+func buildStamp(quality, reliability int, severity string, skills []string, message string) (*doltserver.Stamp, error) {
+	for name, v := range map[string]int{"quality": quality, "reliability": reliability} {
+		if v < 1 || v > 5 {
+			return nil, fmt.Errorf("--%s must be between 1 and 5, got %d", name, v)
+		}
+	}
+	switch severity {
+	case "leaf", "branch", "root":
+	default:
+		return nil, fmt.Errorf("invalid --severity %q: must be leaf, branch or root", severity)
+	}
+	return &doltserver.Stamp{
+		Valence:    map[string]int{"quality": quality, "reliability": reliability},
+		Confidence: 1,
+		Severity:   severity,
+		SkillTags:  skills,
+		Message:    message,
+	}, nil
+}
+
+// validateCompletion contains the testable business logic for gt wl validate.
+// For disputed items it casts a validate vote and returns the tally; otherwise
+// it validates directly and returns nil.
+func validateCompletion(store doltserver.WLCommonsStore, wantedID string, stamp *doltserver.Stamp, reviewID string) (*disputeTally, error) {
+	item, err := store.QueryWanted(wantedID)
+	if err != nil {
+		return nil, fmt.Errorf("querying wanted item: %w", err)
+	}
+
+	switch item.Status {
+	case "in_review":
+	case "disputed":
+		return castDisputeVote(store, wantedID, &doltserver.ReviewVote{
+			Reviewer: stamp.Author,
+			Verdict:  doltserver.VerdictValidate,
+			Reason:   stamp.Message,
+			Stamp:    stamp,
+		})
+	default:
+		return nil, fmt.Errorf("wanted item %s is not in review (status: %s)", wantedID, item.Status)
+	}
+
+	c, err := store.QueryCompletion(wantedID)
+	if err != nil {
+		return nil, fmt.Errorf("querying completion: %w", err)
+	}
+	if c.CompletedBy == stamp.Author {
+		return nil, fmt.Errorf("cannot validate your own completion of %s", wantedID)
+	}
+
+	if err := store.ValidateCompletion(wantedID, reviewID, stamp); err != nil {
+		return nil, fmt.Errorf("validating completion: %w", err)
+	}
+	return nil, nil
+}
+
+// rejectCompletion contains the testable business logic for gt wl reject.
+// For disputed items it casts a reject vote and returns the tally.
+func rejectCompletion(store doltserver.WLCommonsStore, wantedID, reviewer, reason, reviewID string) (*disputeTally, error) {
+	item, err := store.QueryWanted(wantedID)
+	if err != nil {
+		return nil, fmt.Errorf("querying wanted item: %w", err)
+	}
+
+	switch item.Status {
+	case "in_review":
+	case "disputed":
+		return castDisputeVote(store, wantedID, &doltserver.ReviewVote{
+			Reviewer: reviewer,
+			Verdict:  doltserver.VerdictReject,
+			Reason:   reason,
+		})
+	default:
+		return nil, fmt.Errorf("wanted item %s is not in review (status: %s)", wantedID, item.Status)
+	}
+
+	c, err := store.QueryCompletion(wantedID)
+	if err != nil {
+		return nil, fmt.Errorf("querying completion: %w", err)
+	}
+	if c.CompletedBy == reviewer {
+		return nil, fmt.Errorf("cannot reject your own completion of %s", wantedID)
+	}
+
+	if err := store.RejectCompletion(wantedID, reviewID, reviewer, reason); err != nil {
+		return nil, fmt.Errorf("rejecting completion: %w", err)
+	}
+	return nil, nil
+}
+
+// openDispute contains the testable business logic for gt wl dispute.
+func openDispute(store doltserver.WLCommonsStore, d *doltserver.Dispute) error {
+	if d.Quorum < 1 {
+		return fmt.Errorf("--quorum must be at least 1, got %d", d.Quorum)
+	}
+
+	item, err := store.QueryWanted(d.WantedID)
+	if err != nil {
+		return fmt.Errorf("querying wanted item: %w", err)
+	}
+	if item.Status != "in_review" {
+		return fmt.Errorf("wanted item %s is not in review (status: %s)", d.WantedID, item.Status)
+	}
+
+	c, err := store.QueryCompletion(d.WantedID)
+	if err != nil {
+		return fmt.Errorf("querying completion: %w", err)
+	}
+	if c.CompletedBy == d.OpenedBy {
+		return fmt.Errorf("cannot dispute your own completion of %s", d.WantedID)
+	}
+
+	if err := store.OpenDispute(d); err != nil {
+		return fmt.Errorf("opening dispute: %w", err)
+	}
+	return nil
+}
+
+// castDisputeVote records a vote on the open dispute of wantedID and, if
+// either verdict has reached quorum, resolves the dispute. Validating
+// reviewers' stamps are issued on a validate resolution.
+func castDisputeVote(store doltserver.WLCommonsStore, wantedID string, vote *doltserver.ReviewVote) (*disputeTally, error) {
+	d, _, err := store.QueryOpenDispute(wantedID)
+	if err != nil {
+		return nil, fmt.Errorf("querying dispute: %w", err)
+	}
+	if d == nil {
+		return nil, fmt.Errorf("wanted item %s has no open dispute", wantedID)
+	}
+
+	vote.DisputeID = d.ID
+	// Deterministic per dispute and reviewer, so a second vote is a no-op
+	// in the database and an error here.
+	h := sha256.Sum256([]byte(d.ID + "|" + vote.Reviewer))
+	vote.ID = fmt.Sprintf("v-%x", h[:8])
+	if err := store.CastVote(vote); err != nil {
+		return nil, fmt.Errorf("casting vote: %w", err)
+	}
+
+	_, votes, err := store.QueryOpenDispute(wantedID)
+	if err != nil {
+		return nil, fmt.Errorf("querying dispute votes: %w", err)
+	}
+	tally := &disputeTally{Dispute: d}
+	tally.Validate, tally.Reject = tallyVotes(votes)
+
+	switch {
+	case tally.Validate >= d.Quorum:
+		tally.Resolved = doltserver.VerdictValidate
+	case tally.Reject >= d.Quorum:
+		tally.Resolved = doltserver.VerdictReject
+	default:
+		return tally, nil
+	}
+
+	var stamps []*doltserver.Stamp
+	if tally.Resolved == doltserver.VerdictValidate {
+		for _, v := range votes {
+			if v.Verdict == doltserver.VerdictValidate && v.Stamp != nil {
+				stamps = append(stamps, v.Stamp)
+			}
+		}
+	}
+	if err := store.ResolveDispute(d, tally.Resolved, stamps); err != nil {
+		return nil, fmt.Errorf("resolving dispute: %w", err)
+	}
+	return tally, nil
+}
+
+func tallyVotes(votes []*doltserver.ReviewVote) (validate, reject int) {
+	for _, v := range votes {
+		switch v.Verdict {
+		case doltserver.VerdictValidate:
+			validate++
+		case doltserver.VerdictReject:
+			reject++
+		}
+	}
+	return validate, reject
+}
+
+// generateReviewID returns a <prefix>-<hash> ID for reviews (r), stamps (s)
+// and disputes (d), like generateCompletionID.
+func generateReviewID(prefix, wantedID, rigHandle string) string {
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	h := sha256.Sum256([]byte(prefix + "|" + wantedID + "|" + rigHandle + "|" + now))
+	return fmt.Sprintf("%s-%x", prefix, h[:8])
+}
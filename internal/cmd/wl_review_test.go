@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// newInReviewStore returns a fake store with one item completed by doer-rig.
+func newInReviewStore(t *testing.T, wantedID string) *fakeWLCommonsStore {
+	t.Helper()
+	store := newFakeWLCommonsStore()
+	if err := store.InsertWanted(&doltserver.WantedItem{ID: wantedID, Title: "Review me"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ClaimWanted(wantedID, "doer-rig", doltserver.DefaultClaimLease); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SubmitCompletion("c-"+wantedID, wantedID, "doer-rig", "https://pr/1"); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func testStamp(t *testing.T, author string) *doltserver.Stamp {
+	t.Helper()
+	stamp, err := buildStamp(4, 5, "leaf", []string{"go"}, "nice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stamp.Author = author
+	stamp.ID = "s-" + author
+	return stamp
+}
+
+func TestValidateCompletion_IssuesStamp(t *testing.T) {
+	t.Parallel()
+	store := newInReviewStore(t, "w-val1")
+
+	tally, err := validateCompletion(store, "w-val1", testStamp(t, "reviewer-rig"), "r-1")
+	if err != nil {
+		t.Fatalf("validateCompletion() error: %v", err)
+	}
+	if tally != nil {
+		t.Errorf("tally = %+v, want nil for a direct validation", tally)
+	}
+
+	got, _ := store.QueryWanted("w-val1")
+	if got.Status != "completed" {
+		t.Errorf("Status = %q, want completed", got.Status)
+	}
+	c, _ := store.QueryCompletion("w-val1")
+	if c.ValidatedBy != "reviewer-rig" || c.StampID != "s-reviewer-rig" {
+		t.Errorf("completion = %+v, want validated by reviewer-rig with stamp", c)
+	}
+	if len(store.stamps) != 1 || store.stamps[0].Subject != "doer-rig" || store.stamps[0].Valence["quality"] != 4 {
+		t.Errorf("stamps = %+v, want one stamp for doer-rig with quality 4", store.stamps)
+	}
+}
+
+func TestValidateCompletion_OwnCompletion(t *testing.T) {
+	t.Parallel()
+	store := newInReviewStore(t, "w-val2")
+
+	_, err := validateCompletion(store, "w-val2", testStamp(t, "doer-rig"), "r-1")
+	if err == nil || !strings.Contains(err.Error(), "own completion") {
+		t.Fatalf("err = %v, want own-completion error", err)
+	}
+	got, _ := store.QueryWanted("w-val2")
+	if got.Status != "in_review" {
+		t.Errorf("Status = %q, want in_review", got.Status)
+	}
+}
+
+func TestValidateCompletion_NotInReview(t *testing.T) {
+	t.Parallel()
+	store := newFakeWLCommonsStore()
+	_ = store.InsertWanted(&doltserver.WantedItem{ID: "w-val3", Title: "Open"})
+
+	if _, err := validateCompletion(store, "w-val3", testStamp(t, "reviewer-rig"), "r-1"); err == nil {
+		t.Fatal("expected error validating an open item")
+	}
+}
+
+func TestBuildStamp_RejectsBadInput(t *testing.T) {
+	t.Parallel()
+	if _, err := buildStamp(0, 3, "leaf", nil, ""); err == nil {
+		t.Error("expected error for quality 0")
+	}
+	if _, err := buildStamp(3, 6, "leaf", nil, ""); err == nil {
+		t.Error("expected error for reliability 6")
+	}
+	if _, err := buildStamp(3, 3, "trunk", nil, ""); err == nil {
+		t.Error("expected error for unknown severity")
+	}
+}
+
+func TestRejectCompletion_AllowsResubmit(t *testing.T) {
+	t.Parallel()
+	store := newInReviewStore(t, "w-rej1")
+
+	if _, err := rejectCompletion(store, "w-rej1", "reviewer-rig", "tests fail", "r-1"); err != nil {
+		t.Fatalf("rejectCompletion() error: %v", err)
+	}
+	got, _ := store.QueryWanted("w-rej1")
+	if got.Status != "claimed" || got.ClaimedBy != "doer-rig" {
+		t.Fatalf("after reject: %+v, want claimed by doer-rig", got)
+	}
+
+	if err := submitDone(store, "w-rej1", "doer-rig", "https://pr/2", "c-again"); err != nil {
+		t.Fatalf("resubmit error: %v", err)
+	}
+	c, _ := store.QueryCompletion("w-rej1")
+	if c.ID != "c-again" || c.Evidence != "https://pr/2" {
+		t.Errorf("completion = %+v, want resubmitted evidence", c)
+	}
+}
+
+func TestRejectCompletion_OwnCompletion(t *testing.T) {
+	t.Parallel()
+	store := newInReviewStore(t, "w-rej2")
+
+	if _, err := rejectCompletion(store, "w-rej2", "doer-rig", "meh", "r-1"); err == nil {
+		t.Fatal("expected error rejecting own completion")
+	}
+}
+
+func TestDispute_QuorumValidates(t *testing.T) {
+	t.Parallel()
+	store := newInReviewStore(t, "w-dis1")
+
+	d := &doltserver.Dispute{ID: "d-1", WantedID: "w-dis1", OpenedBy: "skeptic-rig", Reason: "draft PR", Quorum: 2}
+	if err := openDispute(store, d); err != nil {
+		t.Fatalf("openDispute() error: %v", err)
+	}
+	got, _ := store.QueryWanted("w-dis1")
+	if got.Status != "disputed" {
+		t.Fatalf("Status = %q, want disputed", got.Status)
+	}
+
+	tally, err := validateCompletion(store, "w-dis1", testStamp(t, "rev-a"), "r-a")
+	if err != nil {
+		t.Fatalf("first vote error: %v", err)
+	}
+	if tally.Validate != 1 || tally.Resolved != "" {
+		t.Errorf("after first vote: %+v, want 1 validate, unresolved", tally)
+	}
+	if _, err := validateCompletion(store, "w-dis1", testStamp(t, "rev-a"), "r-a2"); err == nil {
+		t.Error("expected error for a second vote by the same reviewer")
+	}
+	if _, err := rejectCompletion(store, "w-dis1", "rev-b", "incomplete", "r-b"); err != nil {
+		t.Fatalf("reject vote error: %v", err)
+	}
+
+	tally, err = validateCompletion(store, "w-dis1", testStamp(t, "rev-c"), "r-c")
+	if err != nil {
+		t.Fatalf("third vote error: %v", err)
+	}
+	if tally.Resolved != doltserver.VerdictValidate {
+		t.Fatalf("tally = %+v, want resolved validate", tally)
+	}
+
+	got, _ = store.QueryWanted("w-dis1")
+	if got.Status != "completed" {
+		t.Errorf("Status = %q, want completed", got.Status)
+	}
+	if len(store.stamps) != 2 {
+		t.Errorf("got %d stamps, want one per validating reviewer", len(store.stamps))
+	}
+	if d, _, _ := store.QueryOpenDispute("w-dis1"); d != nil {
+		t.Errorf("dispute still open: %+v", d)
+	}
+}
+
+func TestDispute_QuorumRejects(t *testing.T) {
+	t.Parallel()
+	store := newInReviewStore(t, "w-dis2")
+
+	d := &doltserver.Dispute{ID: "d-2", WantedID: "w-dis2", OpenedBy: "skeptic-rig", Reason: "wrong fix", Quorum: 1}
+	if err := openDispute(store, d); err != nil {
+		t.Fatalf("openDispute() error: %v", err)
+	}
+	if _, err := rejectCompletion(store, "w-dis2", "doer-rig", "self vote", "r-x"); err == nil {
+		t.Error("expected error when the completer votes")
+	}
+
+	tally, err := rejectCompletion(store, "w-dis2", "rev-a", "wrong fix", "r-a")
+	if err != nil {
+		t.Fatalf("reject vote error: %v", err)
+	}
+	if tally.Resolved != doltserver.VerdictReject {
+		t.Fatalf("tally = %+v, want resolved reject", tally)
+	}
+	got, _ := store.QueryWanted("w-dis2")
+	if got.Status != "claimed" || got.ClaimedBy != "doer-rig" {
+		t.Errorf("after rejected dispute: %+v, want claimed by doer-rig", got)
+	}
+	if len(store.stamps) != 0 {
+		t.Errorf("stamps = %+v, want none", store.stamps)
+	}
+}
+
+func TestOpenDispute_Validation(t *testing.T) {
+	t.Parallel()
+	store := newInReviewStore(t, "w-dis3")
+
+	if err := openDispute(store, &doltserver.Dispute{ID: "d-3", WantedID: "w-dis3", OpenedBy: "doer-rig", Quorum: 3}); err == nil {
+		t.Error("expected error disputing own completion")
+	}
+	if err := openDispute(store, &doltserver.Dispute{ID: "d-3", WantedID: "w-dis3", OpenedBy: "skeptic-rig", Quorum: 0}); err == nil {
+		t.Error("expected error for quorum 0")
+	}
+}
+
+func TestGenerateReviewID_Format(t *testing.T) {
+	t.Parallel()
+	id := generateReviewID("s", "w-abc", "my-rig")
+	if !strings.HasPrefix(id, "s-") || len(id) != 18 {
+		t.Errorf("generateReviewID() = %q, want s- followed by 16 hex chars", id)
+	}
+}
@@ -24,6 +24,10 @@ var wlSyncCmd = &cobra.Command{
 If you have a local fork of wl-commons (created by gt wl join), this pulls
 the latest changes from upstream.
 
+After pulling, expired claim leases are released back to 'open', and the
+mayor is mailed about your own claims that expire within a day (renew them
+with gt wl renew).
+
 EXAMPLES:
   gt wl sync                # Pull upstream changes
   gt wl sync --dry-run      # Show what would change`,
@@ -116,6 +120,8 @@ func runWLSync(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	runClaimReaper(townRoot)
+
 	return nil
 }
 
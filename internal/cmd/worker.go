@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/worker"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	workerAgent    string
+	workerPrompt   string
+	workerSession  string
+	workerWorkItem string
+	workerJSON     bool
+)
+
+var workerCmd = &cobra.Command{
+	Use:     "worker",
+	GroupID: GroupAgents,
+	Short:   "Structured non-interactive worker mode",
+	RunE:    requireSubcommand,
+	Long: `Run and inspect agents in structured worker mode.
+
+In worker mode a polecat runs its agent non-interactively with streaming
+JSON output instead of an interactive TUI. Gas Town parses the stream
+directly: tool calls, token usage and the final result are recorded
+without send-keys prompt delivery or pane scraping.
+
+Each run leaves:
+  .runtime/worker/<session>.json    Result: status, tool calls, usage, steps
+  .runtime/worker/<session>.jsonl   Raw event stream from the agent
+
+Worker mode needs an agent with a streaming mode (claude, gemini, codex).
+Start a polecat in worker mode with 'gt sling <bead> <rig> --worker'.`,
+}
+
+var workerRunCmd = &cobra.Command{
+	Use:   "run [-- <agent argv...>]",
+	Short: "Run an agent as a structured worker (session entrypoint)",
+	Long: `Run an agent non-interactively and record its structured result.
+
+This is the command a worker-mode polecat session runs. The agent command
+line follows '--' (built from the agent preset's streaming flags at spawn
+time); alternatively pass --prompt and the preset's command is built here.
+It echoes progress while the agent runs and, on exit:
+  - writes the parsed result to .runtime/worker/<session>.json
+  - records the session cost to the costs log
+  - emits a worker_done or worker_failed event
+
+The exit status is non-zero when the agent failed, so the session's
+pane-died hook reports the failure like any other crash.
+
+Examples:
+  gt worker run --agent claude --prompt "Run gt prime and work your hook"
+  gt worker run --agent codex --session gt-toast -- codex exec --json "..."`,
+	RunE: runWorkerRun,
+}
+
+var workerStatusCmd = &cobra.Command{
+	Use:   "status [session]",
+	Short: "Show worker run results",
+	Long: `Show results of worker-mode runs.
+
+Without arguments, lists the most recent result of every worker session.
+With a session name, shows that run in detail.
+
+Examples:
+  gt worker status
+  gt worker status gt-toast
+  gt worker status gt-toast --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorkerStatus,
+}
+
+func init() {
+	workerRunCmd.Flags().StringVar(&workerAgent, "agent", "", "Agent preset to run (default: $GT_AGENT, then claude)")
+	workerRunCmd.Flags().StringVar(&workerPrompt, "prompt", "", "Prompt for the agent (when no argv follows --)")
+	workerRunCmd.Flags().StringVar(&workerSession, "session", "", "Session name to record the result under (default: detected)")
+	workerRunCmd.Flags().StringVar(&workerWorkItem, "work-item", "", "Work item to attribute the cost to")
+
+	workerStatusCmd.Flags().BoolVar(&workerJSON, "json", false, "Output as JSON")
+
+	workerCmd.AddCommand(workerRunCmd)
+	workerCmd.AddCommand(workerStatusCmd)
+	rootCmd.AddCommand(workerCmd)
+}
+
+func runWorkerRun(cmd *cobra.Command, args []string) error {
+	agent := workerAgent
+	if agent == "" {
+		agent = os.Getenv("GT_AGENT")
+	}
+	if agent == "" {
+		agent = string(config.AgentClaude)
+	}
+
+	townRoot := os.Getenv("GT_TOWN_ROOT")
+	if townRoot == "" {
+		var err error
+		townRoot, err = workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		}
+	}
+
+	argv := args
+	if len(argv) == 0 {
+		if workerPrompt == "" {
+			return fmt.Errorf("pass --prompt or the agent command after --")
+		}
+		rc, _, err := config.ResolveAgentConfigWithOverride(townRoot, "", agent)
+		if err != nil {
+			return fmt.Errorf("resolving agent %s: %w", agent, err)
+		}
+		argv = rc.BuildWorkerArgs(workerPrompt)
+		if argv == nil {
+			return fmt.Errorf("agent %q has no streaming mode; worker mode is unavailable", agent)
+		}
+	}
+
+	sess := workerSession
+	if sess == "" {
+		sess = deriveSessionName()
+	}
+	if sess == "" {
+		sess = detectCurrentTmuxSession()
+	}
+	if sess == "" {
+		return fmt.Errorf("cannot determine session name; pass --session")
+	}
+
+	logPath := worker.StreamLogPath(townRoot, sess)
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return fmt.Errorf("creating worker dir: %w", err)
+	}
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("creating stream log: %w", err)
+	}
+	defer logFile.Close()
+
+	c := exec.Command(argv[0], argv[1:]...) //nolint:gosec // argv comes from the agent preset registry
+	// GT_WORKER tells the agent's Stop hook that this run's cost is
+	// recorded here, from the stream, rather than from the transcript.
+	c.Env = append(os.Environ(), "GT_WORKER=1")
+	c.Stderr = os.Stderr
+	stdout, err := c.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("creating stdout pipe: %w", err)
+	}
+
+	fmt.Printf("%s Worker %s running %s\n", style.Bold.Render("▶"), sess, agent)
+	started := time.Now()
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("starting %s: %w", argv[0], err)
+	}
+
+	collector := worker.NewCollector(sess, agent, started)
+	collector.OnEvent = printWorkerEvent
+	streamErr := worker.Consume(stdout, logFile, collector)
+	waitErr := c.Wait()
+
+	exitCode := 0
+	if waitErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(waitErr, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		} else {
+			exitCode = -1
+		}
+	}
+	result := collector.Finish(exitCode, time.Now())
+	if streamErr != nil && result.Succeeded() {
+		result.Status = worker.StatusFailed
+		result.Error = fmt.Sprintf("reading agent output: %v", streamErr)
+	}
+
+	if err := worker.WriteResult(townRoot, result); err != nil {
+		style.PrintWarning("could not save worker result: %v", err)
+	}
+	recordWorkerCost(result)
+	logWorkerEvent(result)
+	printWorkerSummary(result)
+
+	if !result.Succeeded() {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// workerCost returns the USD cost of a run: the agent's own figure when it
+// reports one, otherwise the Claude price table for Claude runs. Other
+// providers have no pricing data, so their cost is recorded as zero.
+func workerCost(r *worker.Result) float64 {
+	if r.CostUSD > 0 {
+		return r.CostUSD
+	}
+	if r.Agent != string(config.AgentClaude) {
+		return 0
+	}
+	return calculateCost(&TokenUsage{
+		Model:                    r.Usage.Model,
+		InputTokens:              r.Usage.InputTokens,
+		CacheCreationInputTokens: r.Usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     r.Usage.CacheReadInputTokens,
+		OutputTokens:             r.Usage.OutputTokens,
+	})
+}
+
+func recordWorkerCost(r *worker.Result) {
+	role, rig, name := parseSessionName(r.Session)
+	entry := CostLogEntry{
+		SessionID: r.Session,
+		Role:      role,
+		Rig:       rig,
+		Worker:    name,
+		CostUSD:   workerCost(r),
+		EndedAt:   r.EndedAt,
+		WorkItem:  workerWorkItem,
+	}
+	if err := appendCostLogEntry(entry); err != nil {
+		style.PrintWarning("could not record worker cost: %v", err)
+	}
+}
+
+func logWorkerEvent(r *worker.Result) {
+	payload := map[string]interface{}{
+		"session":    r.Session,
+		"agent":      r.Agent,
+		"tool_calls": len(r.ToolCalls),
+		"tokens":     r.Usage.Total(),
+		"cost_usd":   workerCost(r),
+		"done":       r.Done,
+	}
+	if len(r.StepsCompleted) > 0 {
+		payload["steps"] = r.StepsCompleted
+	}
+	eventType := events.TypeWorkerDone
+	if !r.Succeeded() {
+		eventType = events.TypeWorkerFailed
+		payload["error"] = r.Error
+	}
+	_ = events.LogFeed(eventType, r.Session, payload)
+}
+
+// printWorkerEvent echoes stream progress so an attached pane shows what
+// the worker is doing.
+func printWorkerEvent(ev worker.Event) {
+	switch ev.Kind {
+	case worker.EventToolCall:
+		detail := ev.Command
+		if detail == "" {
+			detail = ev.Tool
+		} else {
+			detail = ev.Tool + ": " + detail
+		}
+		fmt.Printf("  %s %s\n", style.Dim.Render("→"), truncateWorkerLine(detail))
+	case worker.EventToolResult:
+		if ev.IsError {
+			fmt.Printf("  %s tool failed\n", style.Dim.Render("✗"))
+		}
+	case worker.EventError:
+		style.PrintWarning("%s", ev.Text)
+	}
+}
+
+func truncateWorkerLine(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) > 120 {
+		return s[:117] + "..."
+	}
+	return s
+}
+
+func printWorkerSummary(r *worker.Result) {
+	if r.Succeeded() {
+		fmt.Printf("%s Worker %s finished\n", style.Success.Render("✓"), r.Session)
+	} else {
+		fmt.Printf("%s Worker %s failed: %s\n", style.Error.Render("✗"), r.Session, r.Error)
+	}
+	fmt.Printf("  Duration:   %s\n", r.EndedAt.Sub(r.StartedAt).Round(time.Second))
+	fmt.Printf("  Tool calls: %d\n", len(r.ToolCalls))
+	fmt.Printf("  Tokens:     %d in / %d out", r.Usage.InputTokens, r.Usage.OutputTokens)
+	if cached := r.Usage.CacheReadInputTokens + r.Usage.CacheCreationInputTokens; cached > 0 {
+		fmt.Printf(" (%d cache)", cached)
+	}
+	fmt.Println()
+	if cost := workerCost(r); cost > 0 {
+		fmt.Printf("  Cost:       $%.2f\n", cost)
+	}
+	if len(r.StepsCompleted) > 0 {
+		fmt.Printf("  Steps done: %s\n", strings.Join(r.StepsCompleted, ", "))
+	}
+	if r.Done {
+		fmt.Printf("  Submitted:  gt done\n")
+	}
+}
+
+func runWorkerStatus(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if len(args) == 1 {
+		r, err := worker.ReadResult(townRoot, args[0])
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("no worker result for %s", args[0])
+			}
+			return err
+		}
+		if workerJSON {
+			return printWorkerJSON(r)
+		}
+		printWorkerSummary(r)
+		for _, call := range r.ToolCalls {
+			mark := "→"
+			if call.Failed {
+				mark = "✗"
+			}
+			detail := call.Name
+			if call.Command != "" {
+				detail += ": " + call.Command
+			}
+			fmt.Printf("    %s %s\n", style.Dim.Render(mark), truncateWorkerLine(detail))
+		}
+		return nil
+	}
+
+	entries, err := os.ReadDir(worker.ResultDir(townRoot))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading worker results: %w", err)
+	}
+	var results []*worker.Result
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		r, err := worker.ReadResult(townRoot, strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].EndedAt.After(results[j].EndedAt) })
+
+	if workerJSON {
+		return printWorkerJSON(results)
+	}
+	if len(results) == 0 {
+		fmt.Println("No worker runs recorded.")
+		return nil
+	}
+	for _, r := range results {
+		status := style.Success.Render("✓")
+		if !r.Succeeded() {
+			status = style.Error.Render("✗")
+		}
+		fmt.Printf("%s %-24s %-8s %3d tools  %8d tokens  %s\n",
+			status, r.Session, r.Agent, len(r.ToolCalls), r.Usage.Total(),
+			style.Dim.Render(r.EndedAt.Local().Format("2006-01-02 15:04")))
+		if !r.Succeeded() {
+			fmt.Printf("    %s\n", r.Error)
+		}
+	}
+	return nil
+}
+
+func printWorkerJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
@@ -12,11 +15,13 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"github.com/steveyegge/gastown/internal/worktree"
 )
 
 // Worktree command flags
 var (
 	worktreeNoCD bool
+	worktreeBead string
 )
 
 var worktreeCmd = &cobra.Command{
@@ -36,16 +41,28 @@ For example, if you're gastown/crew/joe and run 'gt worktree beads':
 - The worktree checks out main branch
 - Your identity (BD_ACTOR, GT_ROLE) remains gastown/crew/joe
 
+Every cross-rig worktree is recorded in the town registry
+(mayor/worktrees.json) with its owner, creation time and, with --bead, the
+bead it was created for. 'gt doctor' uses the registry to flag orphaned and
+stale worktrees, and 'gt worktree reclaim' removes them safely.
+
 Use --no-cd to just print the path without printing shell commands.
 
 Examples:
   gt worktree beads         # Create worktree in beads rig
   gt worktree gastown       # Create worktree in gastown rig (from another rig)
-  gt worktree beads --no-cd # Just print the path`,
+  gt worktree beads --no-cd # Just print the path
+  gt worktree beads --bead bd-a1b2  # Record the bead this work is for`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWorktree,
 }
 
+// Worktree list command flags
+var (
+	worktreeListAll  bool
+	worktreeListJSON bool
+)
+
 var worktreeListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all cross-rig worktrees owned by current crew member",
@@ -55,6 +72,11 @@ This command scans all rigs in the workspace and finds worktrees
 that belong to the current crew member. Each worktree is shown with
 its git status summary.
 
+With --all, lists every cross-rig worktree in the town from the registry,
+with its owner, source bead, last commit, unpushed work and health
+(ok, stale, orphaned or missing). Worktrees found on disk that predate the
+registry are added to it.
+
 Example output:
   Cross-rig worktrees for gastown/crew/joe:
 
@@ -83,13 +105,51 @@ Examples:
 	RunE: runWorktreeRemove,
 }
 
+// Worktree reclaim command flags
+var (
+	worktreeReclaimDryRun  bool
+	worktreeReclaimFlagged bool
+)
+
+var worktreeReclaimCmd = &cobra.Command{
+	Use:   "reclaim [worktree...]",
+	Short: "Safely remove cross-rig worktrees, rescuing unpushed work",
+	Long: `Remove cross-rig worktrees from any crew member without losing work.
+
+Worktrees are named by their path relative to the town root
+(e.g., beads/crew/gastown-joe), as shown by 'gt worktree list --all'.
+
+Before removal, uncommitted changes are committed as a WIP commit and any
+commits not on a remote are pushed to a rescue branch on origin
+(rescue/<source-rig>-<name>/<timestamp>). If the rescue push fails, the
+worktree is left in place.
+
+With --flagged, reclaims every worktree that is orphaned (its crew member
+or source rig is gone), stale (no commits for 14 days, or its source bead
+is closed) or missing from disk.
+
+Examples:
+  gt worktree reclaim beads/crew/gastown-joe
+  gt worktree reclaim --flagged --dry-run
+  gt worktree reclaim --flagged`,
+	RunE: runWorktreeReclaim,
+}
+
 func init() {
 	worktreeCmd.Flags().BoolVar(&worktreeNoCD, "no-cd", false, "Just print path (don't print cd command)")
+	worktreeCmd.Flags().StringVar(&worktreeBead, "bead", "", "Bead this worktree is for (recorded in the registry)")
+
+	worktreeListCmd.Flags().BoolVar(&worktreeListAll, "all", false, "List every cross-rig worktree in the town")
+	worktreeListCmd.Flags().BoolVar(&worktreeListJSON, "json", false, "Output as JSON (with --all)")
 	worktreeCmd.AddCommand(worktreeListCmd)
 
 	worktreeRemoveCmd.Flags().BoolVarP(&worktreeRemoveForce, "force", "f", false, "Force remove even with uncommitted changes")
 	worktreeCmd.AddCommand(worktreeRemoveCmd)
 
+	worktreeReclaimCmd.Flags().BoolVarP(&worktreeReclaimDryRun, "dry-run", "n", false, "Show what would be rescued and removed")
+	worktreeReclaimCmd.Flags().BoolVar(&worktreeReclaimFlagged, "flagged", false, "Reclaim all orphaned, stale and missing worktrees")
+	worktreeCmd.AddCommand(worktreeReclaimCmd)
+
 	rootCmd.AddCommand(worktreeCmd)
 }
 
@@ -111,7 +171,7 @@ func runWorktree(cmd *cobra.Command, args []string) error {
 	}
 
 	// Verify target rig exists
-	_, targetRigInfo, err := getRig(targetRig)
+	townRoot, targetRigInfo, err := getRig(targetRig)
 	if err != nil {
 		return fmt.Errorf("rig '%s' not found - run 'gt rig list' to see available rigs", targetRig)
 	}
@@ -122,7 +182,8 @@ func runWorktree(cmd *cobra.Command, args []string) error {
 
 	// Check if worktree already exists
 	if _, err := os.Stat(worktreePath); err == nil {
-		// Worktree exists
+		// Worktree exists - make sure it is registered (it may predate the registry)
+		registerWorktree(townRoot, targetRig, sourceRig, crewName)
 		if worktreeNoCD {
 			fmt.Println(worktreePath)
 		} else {
@@ -157,6 +218,8 @@ func runWorktree(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("creating worktree: %w", err)
 	}
 
+	registerWorktree(townRoot, targetRig, sourceRig, crewName)
+
 	// Configure git author for identity preservation
 	worktreeGit := git.NewGit(worktreePath)
 	bdActor := fmt.Sprintf("%s/crew/%s", sourceRig, crewName)
@@ -193,6 +256,17 @@ func runWorktree(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// registerWorktree records a cross-rig worktree in the town registry.
+// Failure is non-fatal: the worktree is usable, and doctor will discover it.
+func registerWorktree(townRoot, targetRig, sourceRig, crewName string) {
+	reg := worktree.NewRegistry(townRoot)
+	entry := reg.NewEntry(targetRig, sourceRig, crewName)
+	entry.SourceBead = worktreeBead
+	if _, err := reg.Register(entry); err != nil {
+		style.PrintWarning("could not register worktree: %v", err)
+	}
+}
+
 // setGitConfig sets a git config value in the specified worktree.
 func setGitConfig(worktreePath, key, value string) error {
 	cmd := exec.Command("git", "-C", worktreePath, "config", key, value)
@@ -200,6 +274,10 @@ func setGitConfig(worktreePath, key, value string) error {
 }
 
 func runWorktreeList(cmd *cobra.Command, args []string) error {
+	if worktreeListAll {
+		return runWorktreeListAll()
+	}
+
 	// Detect current crew identity from cwd
 	detected, err := detectCrewFromCwd()
 	if err != nil {
@@ -303,7 +381,7 @@ func runWorktreeRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	// Verify target rig exists
-	_, targetRigInfo, err := getRig(targetRig)
+	townRoot, targetRigInfo, err := getRig(targetRig)
 	if err != nil {
 		return fmt.Errorf("rig '%s' not found - run 'gt rig list' to see available rigs", targetRig)
 	}
@@ -313,7 +391,10 @@ func runWorktreeRemove(cmd *cobra.Command, args []string) error {
 	worktreePath := filepath.Join(constants.RigCrewPath(targetRigInfo.Path), worktreeName)
 
 	// Check if worktree exists
+	reg := worktree.NewRegistry(townRoot)
+	entry := reg.NewEntry(targetRig, sourceRig, crewName)
 	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
+		_ = reg.Unregister(entry.ID)
 		return fmt.Errorf("worktree does not exist at %s", worktreePath)
 	}
 
@@ -334,7 +415,175 @@ func runWorktreeRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("removing worktree: %w", err)
 	}
 
+	if err := reg.Unregister(entry.ID); err != nil {
+		style.PrintWarning("could not unregister worktree: %v", err)
+	}
+
 	fmt.Printf("%s Removed worktree at %s\n", style.Success.Render("✓"), worktreePath)
 
 	return nil
 }
+
+// worktreeListEntry is a registry entry with its health, for list --all.
+type worktreeListEntry struct {
+	*worktree.Entry
+	Owner  string          `json:"owner"`
+	Health worktree.Health `json:"health"`
+	Reason string          `json:"reason,omitempty"`
+}
+
+// syncWorktreeRegistry adopts unregistered worktrees into the registry,
+// refreshes git state, and assesses each worktree's health.
+func syncWorktreeRegistry() (*worktree.Registry, []worktreeListEntry, error) {
+	rigs, townRoot, err := getAllRigs()
+	if err != nil {
+		return nil, nil, err
+	}
+	rigNames := make([]string, 0, len(rigs))
+	for _, r := range rigs {
+		rigNames = append(rigNames, r.Name)
+	}
+
+	reg := worktree.NewRegistry(townRoot)
+	entries, err := reg.Sync(rigNames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	beadClosed := worktree.BeadClosed(townRoot)
+	result := make([]worktreeListEntry, 0, len(entries))
+	for _, e := range entries {
+		a := reg.Assess(e, now, worktree.DefaultStaleAfter, beadClosed)
+		result = append(result, worktreeListEntry{Entry: e, Owner: e.Owner(), Health: a.Health, Reason: a.Reason})
+	}
+	return reg, result, nil
+}
+
+func runWorktreeListAll() error {
+	_, entries, err := syncWorktreeRegistry()
+	if err != nil {
+		return err
+	}
+
+	if worktreeListJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No cross-rig worktrees")
+		return nil
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Cross-rig worktrees:"))
+	for _, e := range entries {
+		health := string(e.Health)
+		switch e.Health {
+		case worktree.HealthOK:
+			health = style.Success.Render(health)
+		default:
+			health = style.Warning.Render(health)
+		}
+		fmt.Printf("  %s  %s\n", style.Bold.Render(e.ID), health)
+		fmt.Printf("    Owner: %s", e.Owner)
+		if e.SourceBead != "" {
+			fmt.Printf("  Bead: %s", e.SourceBead)
+		}
+		fmt.Printf("  Created: %s\n", formatAge(e.CreatedAt))
+		if e.LastCommit != "" {
+			fmt.Printf("    Last commit: %s %s (%s)\n", e.LastCommit, e.LastCommitSubject, formatAge(e.LastCommitAt))
+		}
+		var state []string
+		if e.Dirty {
+			state = append(state, "uncommitted changes")
+		}
+		if e.Unpushed > 0 {
+			state = append(state, fmt.Sprintf("%d unpushed commit(s)", e.Unpushed))
+		}
+		if len(state) > 0 {
+			fmt.Printf("    %s\n", style.Warning.Render(strings.Join(state, ", ")))
+		}
+		if e.Reason != "" {
+			fmt.Printf("    %s\n", style.Dim.Render(e.Reason))
+		}
+	}
+	return nil
+}
+
+func runWorktreeReclaim(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !worktreeReclaimFlagged {
+		return fmt.Errorf("specify worktrees to reclaim or use --flagged")
+	}
+
+	reg, entries, err := syncWorktreeRegistry()
+	if err != nil {
+		return err
+	}
+
+	// --flagged and explicit args may name the same worktree; reclaim it once.
+	var targets []worktreeListEntry
+	seen := make(map[string]bool)
+	addTarget := func(e worktreeListEntry) {
+		if !seen[e.ID] {
+			seen[e.ID] = true
+			targets = append(targets, e)
+		}
+	}
+	if worktreeReclaimFlagged {
+		for _, e := range entries {
+			if e.Health != worktree.HealthOK {
+				addTarget(e)
+			}
+		}
+	}
+	for _, ref := range args {
+		found := false
+		for _, e := range entries {
+			if e.ID == strings.TrimSuffix(ref, "/") || e.Path == ref {
+				addTarget(e)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: %s - run 'gt worktree list --all' to see worktrees", worktree.ErrNotFound, ref)
+		}
+	}
+
+	if len(targets) == 0 {
+		fmt.Println("No worktrees to reclaim")
+		return nil
+	}
+
+	var failed int
+	for _, e := range targets {
+		result, err := reg.Reclaim(e.Entry, worktreeReclaimDryRun)
+		if err != nil {
+			fmt.Printf("%s %s: %v\n", style.Error.Render("✗"), e.ID, err)
+			failed++
+			continue
+		}
+
+		verb := "Reclaimed"
+		if worktreeReclaimDryRun {
+			verb = "Would reclaim"
+		}
+		fmt.Printf("%s %s %s\n", style.Success.Render("✓"), verb, e.ID)
+		if e.Reason != "" {
+			fmt.Printf("    %s\n", style.Dim.Render(e.Reason))
+		}
+		if result.CommittedDirty {
+			fmt.Printf("    Uncommitted changes committed as WIP\n")
+		}
+		if result.RescueBranch != "" {
+			fmt.Printf("    %d commit(s) pushed to origin/%s\n", result.RescuedCommits, result.RescueBranch)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d worktree(s) could not be reclaimed", failed)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -132,6 +133,17 @@ type AgentPresetInfo struct {
 	// EmitsPermissionWarning indicates the agent shows a bypass-permissions warning on startup
 	// that needs to be acknowledged via tmux.
 	EmitsPermissionWarning bool `json:"emits_permission_warning,omitempty"`
+
+	// --- Account pool fields ---
+
+	// CredentialEnv is the env var the agent reads its API credential from
+	// (e.g., "OPENAI_API_KEY"). Accounts for this provider inject their secret
+	// here. Empty means accounts are config-dir based (ConfigDirEnv).
+	CredentialEnv string `json:"credential_env,omitempty"`
+
+	// RateLimitPatterns are additional regexes (matched case-insensitively)
+	// that indicate this agent hit a provider rate limit or quota.
+	RateLimitPatterns []string `json:"rate_limit_patterns,omitempty"`
 }
 
 // NonInteractiveConfig contains settings for running agents non-interactively.
@@ -144,6 +156,12 @@ type NonInteractiveConfig struct {
 
 	// OutputFlag is the flag for structured output (e.g., "--json", "--output-format json").
 	OutputFlag string `json:"output_flag,omitempty"`
+
+	// StreamFlag is the flag for line-delimited JSON event output, used by
+	// worker mode to follow tool calls and usage as they happen
+	// (e.g., "--output-format stream-json --verbose"). Empty means the agent
+	// has no streaming mode and worker mode is unavailable for it.
+	StreamFlag string `json:"stream_flag,omitempty"`
 }
 
 // AgentRegistry contains all known agent presets.
@@ -173,7 +191,11 @@ var builtinPresets = map[AgentPreset]*AgentPresetInfo{
 		ResumeStyle:         "flag",
 		SupportsHooks:       true,
 		SupportsForkSession: true,
-		NonInteractive:      nil, // Claude is native non-interactive
+		NonInteractive: &NonInteractiveConfig{
+			PromptFlag: "-p",
+			OutputFlag: "--output-format json",
+			StreamFlag: "--output-format stream-json --verbose",
+		},
 		// Runtime defaults
 		PromptMode:             "arg",
 		ConfigDirEnv:           "CLAUDE_CONFIG_DIR",
@@ -199,6 +221,7 @@ var builtinPresets = map[AgentPreset]*AgentPresetInfo{
 		NonInteractive: &NonInteractiveConfig{
 			PromptFlag: "-p",
 			OutputFlag: "--output-format json",
+			StreamFlag: "--output-format stream-json",
 		},
 		// Runtime defaults
 		PromptMode:        "arg",
@@ -208,6 +231,11 @@ var builtinPresets = map[AgentPreset]*AgentPresetInfo{
 		HooksSettingsFile: "settings.json",
 		ReadyDelayMs:      5000,
 		InstructionsFile:  "AGENTS.md",
+		CredentialEnv:     "GEMINI_API_KEY",
+		RateLimitPatterns: []string{
+			`RESOURCE_EXHAUSTED`,              // Gemini API 429 status
+			`Quota exceeded for quota metric`, // Per-minute/day quota exhausted
+		},
 	},
 	AgentCodex: {
 		Name:                AgentCodex,
@@ -222,11 +250,17 @@ var builtinPresets = map[AgentPreset]*AgentPresetInfo{
 		NonInteractive: &NonInteractiveConfig{
 			Subcommand: "exec",
 			OutputFlag: "--json",
+			StreamFlag: "--json",
 		},
 		// Runtime defaults
 		PromptMode:       "none",
 		ReadyDelayMs:     3000,
 		InstructionsFile: "AGENTS.md",
+		CredentialEnv:    "OPENAI_API_KEY",
+		RateLimitPatterns: []string{
+			`exceeded your current quota`, // OpenAI insufficient_quota
+			`Rate limit reached for`,      // OpenAI 429 (per-model TPM/RPM)
+		},
 	},
 	AgentCursor: {
 		Name:                AgentCursor,
@@ -324,6 +358,10 @@ var builtinPresets = map[AgentPreset]*AgentPresetInfo{
 		ReadyPromptPrefix:  "❯ ",
 		ReadyDelayMs:       5000,
 		InstructionsFile:   "AGENTS.md",
+		CredentialEnv:      "COPILOT_GITHUB_TOKEN",
+		RateLimitPatterns: []string{
+			`rate limit exceeded`, // Copilot API 429
+		},
 	},
 	AgentPi: {
 		Name:                AgentPi,
@@ -577,6 +615,44 @@ func GetSessionIDEnvVar(agentName string) string {
 	return info.SessionIDEnv
 }
 
+// GetCredentialEnvVar returns the env var an agent reads its API credential
+// from, or "" for config-dir based agents (Claude) and unknown agents.
+func GetCredentialEnvVar(agentName string) string {
+	info := GetAgentPresetByName(agentName)
+	if info == nil {
+		return ""
+	}
+	return info.CredentialEnv
+}
+
+// GetNonInteractiveConfig returns the non-interactive settings for an agent,
+// or nil if the agent is unknown or has none.
+func GetNonInteractiveConfig(agentName string) *NonInteractiveConfig {
+	info := GetAgentPresetByName(agentName)
+	if info == nil {
+		return nil
+	}
+	return info.NonInteractive
+}
+
+// RateLimitPatternsForAgents returns the provider-specific rate-limit
+// patterns of all registered agents, sorted by agent name for stable order.
+func RateLimitPatternsForAgents() []string {
+	registryMu.Lock()
+	initRegistryLocked()
+	defer registryMu.Unlock()
+	names := make([]string, 0, len(globalRegistry.Agents))
+	for name := range globalRegistry.Agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var patterns []string
+	for _, name := range names {
+		patterns = append(patterns, globalRegistry.Agents[name].RateLimitPatterns...)
+	}
+	return patterns
+}
+
 // GetProcessNames returns the process names used to detect if an agent is running.
 // Used by tmux.IsAgentRunning to check pane_current_command.
 // Returns ["node"] for Claude (default) if agent is not found or has no ProcessNames.
@@ -1258,3 +1258,34 @@ func TestAllHookSupportingAgentsHaveHookFields(t *testing.T) {
 		}
 	}
 }
+
+func TestRuntimeConfigBuildWorkerArgs(t *testing.T) {
+	tests := []struct {
+		agent AgentPreset
+		want  []string
+	}{
+		{AgentClaude, []string{"claude", "--dangerously-skip-permissions", "--output-format", "stream-json", "--verbose", "-p", "go"}},
+		{AgentGemini, []string{"gemini", "--approval-mode", "yolo", "--output-format", "stream-json", "-p", "go"}},
+		{AgentCodex, []string{"codex", "exec", "--dangerously-bypass-approvals-and-sandbox", "--json", "go"}},
+		{AgentOpenCode, nil},
+	}
+	for _, tt := range tests {
+		rc := RuntimeConfigFromPreset(tt.agent)
+		got := rc.BuildWorkerArgs("go")
+		if len(got) > 0 {
+			got[0] = filepath.Base(got[0]) // claude may resolve to an absolute path
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") || (got == nil) != (tt.want == nil) {
+			t.Errorf("%s: BuildWorkerArgs = %q, want %q", tt.agent, got, tt.want)
+		}
+	}
+
+	// Custom agents built on a preset inherit its streaming flags.
+	custom := &RuntimeConfig{Provider: "claude", Command: "claude", Args: []string{"--model", "haiku"}, ResolvedAgent: "claude-haiku"}
+	got := custom.BuildWorkerArgs("go")
+	got[0] = filepath.Base(got[0])
+	want := "claude --model haiku --output-format stream-json --verbose -p go"
+	if strings.Join(got, " ") != want {
+		t.Errorf("custom BuildWorkerArgs = %q, want %q", strings.Join(got, " "), want)
+	}
+}
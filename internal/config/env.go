@@ -394,6 +394,18 @@ func ExportPrefix(env map[string]string) string {
 	return "export " + strings.Join(parts, " ") + " && "
 }
 
+// CredentialExportPrefix builds the statements that load an account's
+// credential into envVar when the agent starts, e.g.
+// OPENAI_API_KEY="$(gt account credential work)" || exit 1; export OPENAI_API_KEY GT_QUOTA_ACCOUNT=work && .
+// The secret is fetched inside the pane at exec time so it never appears in
+// the command line, tmux environment, or scrollback. The assignment is kept
+// apart from export, which would mask a failed lookup, so the agent never
+// starts with an empty credential.
+func CredentialExportPrefix(envVar, handle string) string {
+	return fmt.Sprintf(`%s="$(gt account credential %s)" || exit 1; export %s GT_QUOTA_ACCOUNT=%s && `,
+		envVar, ShellQuote(handle), envVar, ShellQuote(handle))
+}
+
 // BuildStartupCommandWithEnv builds a startup command with the given environment variables.
 // This combines the export prefix with the agent command and optional prompt.
 func BuildStartupCommandWithEnv(env map[string]string, agentCmd, prompt string) string {
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -483,6 +486,28 @@ func TestExportPrefix(t *testing.T) {
 	}
 }
 
+func TestCredentialExportPrefix_FailedLookupStopsStart(t *testing.T) {
+	t.Parallel()
+	bin := t.TempDir()
+	gt := "#!/bin/sh\n[ \"$3\" = good ] && echo sk-secret && exit 0\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(bin, "gt"), []byte(gt), 0755); err != nil {
+		t.Fatal(err)
+	}
+	run := func(handle string) (string, error) {
+		cmd := exec.Command("/bin/sh", "-c", CredentialExportPrefix("OPENAI_API_KEY", handle)+`echo "started:$OPENAI_API_KEY:$GT_QUOTA_ACCOUNT"`)
+		cmd.Env = append(os.Environ(), "PATH="+bin+":"+os.Getenv("PATH"))
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+
+	if out, err := run("good"); err != nil || out != "started:sk-secret:good" {
+		t.Errorf("good handle: %q, %v", out, err)
+	}
+	if out, err := run("missing"); err == nil || strings.Contains(out, "started") {
+		t.Errorf("failed credential lookup should stop the agent starting: %q, %v", out, err)
+	}
+}
+
 func TestBuildStartupCommandWithEnv(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
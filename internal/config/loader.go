@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/cli"
 	"github.com/steveyegge/gastown/internal/constants"
 )
 
@@ -219,6 +220,9 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if err := c.Maintenance.Validate(); err != nil {
+		return fmt.Errorf("maintenance: %w", err)
+	}
 	return nil
 }
 
@@ -740,8 +744,8 @@ func validateAccountsConfig(c *AccountsConfig) error {
 	}
 	// Validate each account has required fields
 	for handle, acct := range c.Accounts {
-		if acct.ConfigDir == "" {
-			return fmt.Errorf("%w: config_dir for account '%s'", ErrMissingField, handle)
+		if acct.ConfigDir == "" && !acct.UsesCredential() {
+			return fmt.Errorf("%w: config_dir or secret for account '%s'", ErrMissingField, handle)
 		}
 	}
 	return nil
@@ -771,6 +775,20 @@ func (c *AccountsConfig) GetDefaultAccount() *Account {
 	return c.GetAccount(c.Default)
 }
 
+// AccountsForProvider returns the accounts belonging to provider.
+func (c *AccountsConfig) AccountsForProvider(provider string) map[string]Account {
+	if provider == "" {
+		provider = string(AgentClaude)
+	}
+	out := make(map[string]Account)
+	for handle, acct := range c.Accounts {
+		if acct.ProviderName() == provider {
+			out[handle] = acct
+		}
+	}
+	return out
+}
+
 // ResolveAccountConfigDir resolves the CLAUDE_CONFIG_DIR for account selection.
 // Priority order:
 //  1. GT_ACCOUNT environment variable
@@ -961,6 +979,42 @@ func RigSettingsPath(rigPath string) string {
 	return filepath.Join(rigPath, "settings", "config.json")
 }
 
+// ResolveSandboxConfig returns the sandbox configuration for a role in a rig:
+// the role's own entry, else the rig's "default" entry, else nil (no
+// sandbox). Paths starting with ~/ are expanded.
+func ResolveSandboxConfig(rigPath, role string) (*SandboxConfig, error) {
+	settings, err := LoadRigSettings(RigSettingsPath(rigPath))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sc := settings.Sandbox[role]
+	if sc == nil {
+		sc = settings.Sandbox[SandboxDefaultKey]
+	}
+	if sc == nil {
+		return nil, nil
+	}
+	resolved := *sc
+	resolved.ReadOnlyPaths = expandPaths(sc.ReadOnlyPaths)
+	resolved.ReadWritePaths = expandPaths(sc.ReadWritePaths)
+	return &resolved, nil
+}
+
+// expandPaths applies expandPath to each element of paths.
+func expandPaths(paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = expandPath(p)
+	}
+	return out
+}
+
 // LoadOrCreateTownSettings loads town settings or creates defaults if missing.
 func LoadOrCreateTownSettings(path string) (*TownSettings, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
@@ -2020,6 +2074,61 @@ func PrependEnv(command string, envVars map[string]string) string {
 //  2. role_agents[GT_ROLE] (if GT_ROLE is in envVars)
 //  3. Default agent resolution (rig's Agent → town's DefaultAgent → "claude")
 func BuildStartupCommandWithAgentOverride(envVars map[string]string, rigPath, prompt, agentOverride string) (string, error) {
+	rc, cmd, err := resolveStartupCommand(envVars, rigPath, agentOverride)
+	if err != nil {
+		return "", err
+	}
+
+	if prompt != "" {
+		cmd += rc.BuildCommandWithPrompt(prompt)
+	} else {
+		cmd += rc.BuildCommand()
+	}
+
+	return cmd, nil
+}
+
+// BuildWorkerStartupCommand builds a startup command that runs the resolved
+// agent in structured worker mode: 'gt worker run' executes the agent
+// non-interactively on prompt with streaming JSON output and records the
+// result. Agent resolution and environment match BuildStartupCommandFromConfig.
+// Returns an error if the agent has no streaming mode.
+func BuildWorkerStartupCommand(cfg AgentEnvConfig, rigPath, prompt, agentOverride string) (string, error) {
+	envVars := AgentEnv(cfg)
+	envVars["GT_WORKER"] = "1"
+	rc, cmd, err := resolveStartupCommand(envVars, rigPath, agentOverride)
+	if err != nil {
+		return "", err
+	}
+	argv := rc.BuildWorkerArgs(prompt)
+	if argv == nil {
+		return "", fmt.Errorf("agent %q has no streaming mode; worker mode is unavailable", rc.ResolvedAgent)
+	}
+
+	agentName := agentOverride
+	if agentName == "" {
+		agentName = rc.ResolvedAgent
+	}
+	cmd += cli.Name() + " worker run"
+	if agentName != "" {
+		cmd += " --agent " + ShellQuote(agentName)
+	}
+	if cfg.SessionName != "" {
+		cmd += " --session " + ShellQuote(cfg.SessionName)
+	}
+	if cfg.Issue != "" {
+		cmd += " --work-item " + ShellQuote(cfg.Issue)
+	}
+	cmd += " --"
+	for _, arg := range argv {
+		cmd += " " + ShellQuote(arg)
+	}
+	return cmd, nil
+}
+
+// resolveStartupCommand resolves the runtime config for a startup command and
+// returns it with the "exec env ..." prefix carrying the session environment.
+func resolveStartupCommand(envVars map[string]string, rigPath, agentOverride string) (*RuntimeConfig, string, error) {
 	var rc *RuntimeConfig
 	var townRoot string
 
@@ -2032,7 +2141,7 @@ func BuildStartupCommandWithAgentOverride(envVars map[string]string, rigPath, pr
 			var err error
 			rc, _, err = ResolveAgentConfigWithOverride(townRoot, rigPath, agentOverride)
 			if err != nil {
-				return "", err
+				return nil, "", err
 			}
 		} else if role == "crew" && envVars["GT_CREW"] != "" {
 			// Per-worker agent resolution: check worker_agents before role_agents
@@ -2059,7 +2168,7 @@ func BuildStartupCommandWithAgentOverride(envVars map[string]string, rigPath, pr
 					if preset := GetAgentPresetByName(agentOverride); preset != nil {
 						rc = RuntimeConfigFromPreset(AgentPreset(agentOverride))
 					} else {
-						return "", fmt.Errorf("agent '%s' not found", agentOverride)
+						return nil, "", fmt.Errorf("agent '%s' not found", agentOverride)
 					}
 				} else {
 					rc = DefaultRuntimeConfig()
@@ -2071,7 +2180,7 @@ func BuildStartupCommandWithAgentOverride(envVars map[string]string, rigPath, pr
 				var resolveErr error
 				rc, _, resolveErr = ResolveAgentConfigWithOverride(townRoot, "", agentOverride)
 				if resolveErr != nil {
-					return "", resolveErr
+					return nil, "", resolveErr
 				}
 			} else if role != "" {
 				rc = ResolveRoleAgentConfig(role, townRoot, "")
@@ -2127,14 +2236,7 @@ func BuildStartupCommandWithAgentOverride(envVars map[string]string, rigPath, pr
 		// process, not child processes).
 		cmd = "exec env " + strings.Join(exports, " ") + " "
 	}
-
-	if prompt != "" {
-		cmd += rc.BuildCommandWithPrompt(prompt)
-	} else {
-		cmd += rc.BuildCommand()
-	}
-
-	return cmd, nil
+	return rc, cmd, nil
 }
 
 // BuildStartupCommandFromConfig builds a startup command from a complete AgentEnvConfig.
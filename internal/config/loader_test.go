@@ -439,6 +439,50 @@ func TestLoadRigSettingsNotFound(t *testing.T) {
 	}
 }
 
+func TestResolveSandboxConfig(t *testing.T) {
+	t.Parallel()
+	rigPath := t.TempDir()
+
+	sc, err := ResolveSandboxConfig(rigPath, "polecat")
+	if err != nil || sc != nil {
+		t.Fatalf("no settings: got %+v, %v; want nil, nil", sc, err)
+	}
+
+	settings := NewRigSettings()
+	settings.Sandbox = map[string]*SandboxConfig{
+		SandboxDefaultKey: {Driver: "none"},
+		"polecat": {
+			Driver:        "bubblewrap",
+			Network:       "allowlist",
+			AllowHosts:    []string{"github.com:443"},
+			ReadOnlyPaths: []string{"~/.gitconfig", "/srv/data"},
+		},
+	}
+	if err := SaveRigSettings(RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	sc, err = ResolveSandboxConfig(rigPath, "polecat")
+	if err != nil {
+		t.Fatalf("ResolveSandboxConfig: %v", err)
+	}
+	if sc.Driver != "bubblewrap" || sc.Network != "allowlist" {
+		t.Errorf("polecat sandbox = %+v", sc)
+	}
+	home, _ := os.UserHomeDir()
+	if want := filepath.Join(home, ".gitconfig"); sc.ReadOnlyPaths[0] != want {
+		t.Errorf("ReadOnlyPaths[0] = %q, want %q", sc.ReadOnlyPaths[0], want)
+	}
+	if sc.ReadOnlyPaths[1] != "/srv/data" {
+		t.Errorf("ReadOnlyPaths[1] = %q, want /srv/data", sc.ReadOnlyPaths[1])
+	}
+
+	sc, err = ResolveSandboxConfig(rigPath, "witness")
+	if err != nil || sc == nil || sc.Driver != "none" {
+		t.Errorf("witness sandbox = %+v, %v; want default entry", sc, err)
+	}
+}
+
 func TestMayorConfigRoundTrip(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -577,6 +621,16 @@ func TestAccountsConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "credential account without config_dir",
+			config: &AccountsConfig{
+				Version: 1,
+				Accounts: map[string]Account{
+					"openai": {Provider: "codex", Secret: "file:openai"},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -589,6 +643,32 @@ func TestAccountsConfigValidation(t *testing.T) {
 	}
 }
 
+func TestAccountsForProvider(t *testing.T) {
+	t.Parallel()
+	cfg := &AccountsConfig{
+		Accounts: map[string]Account{
+			"work":   {ConfigDir: "~/.claude-accounts/work"},
+			"openai": {Provider: "codex", Secret: "file:openai"},
+			"gem":    {Provider: "gemini", Secret: "env:GEMINI_KEY"},
+		},
+	}
+
+	claude := cfg.AccountsForProvider("")
+	if len(claude) != 1 || claude["work"].ProviderName() != "claude" {
+		t.Errorf("claude pool = %v, want only work", claude)
+	}
+	codex := cfg.AccountsForProvider("codex")
+	if _, ok := codex["openai"]; !ok || len(codex) != 1 {
+		t.Errorf("codex pool = %v, want only openai", codex)
+	}
+	if got := GetCredentialEnvVar("codex"); got != "OPENAI_API_KEY" {
+		t.Errorf("codex credential env = %q, want OPENAI_API_KEY", got)
+	}
+	if got := GetCredentialEnvVar("claude"); got != "" {
+		t.Errorf("claude credential env = %q, want empty (config-dir accounts)", got)
+	}
+}
+
 func TestLoadAccountsConfigNotFound(t *testing.T) {
 	t.Parallel()
 	_, err := LoadAccountsConfig("/nonexistent/path.json")
@@ -1174,6 +1254,48 @@ func TestBuildPolecatStartupCommandWithAgentOverride(t *testing.T) {
 	}
 }
 
+func TestBuildWorkerStartupCommand(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "testrig")
+
+	if err := SaveTownSettings(TownSettingsPath(townRoot), NewTownSettings()); err != nil {
+		t.Fatalf("SaveTownSettings: %v", err)
+	}
+	if err := SaveRigSettings(RigSettingsPath(rigPath), NewRigSettings()); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	cfg := AgentEnvConfig{
+		Role:        "polecat",
+		Rig:         "testrig",
+		AgentName:   "toast",
+		TownRoot:    townRoot,
+		Issue:       "gt-abc",
+		SessionName: "gt-toast",
+	}
+	cmd, err := BuildWorkerStartupCommand(cfg, rigPath, "work your hook", "codex")
+	if err != nil {
+		t.Fatalf("BuildWorkerStartupCommand: %v", err)
+	}
+	for _, want := range []string{
+		"GT_WORKER=1",
+		"GT_POLECAT=toast",
+		"GT_AGENT=codex",
+		" worker run --agent codex --session gt-toast --work-item gt-abc -- ",
+		"codex exec --dangerously-bypass-approvals-and-sandbox --json 'work your hook'",
+	} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("expected %q in command: %q", want, cmd)
+		}
+	}
+
+	// opencode has no streaming flag, so worker mode is unavailable.
+	if _, err := BuildWorkerStartupCommand(cfg, rigPath, "x", "opencode"); err == nil {
+		t.Error("expected error for agent without a streaming mode")
+	}
+}
+
 func TestBuildAgentStartupCommandWithAgentOverride(t *testing.T) {
 	townRoot := t.TempDir()
 
@@ -0,0 +1,290 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaintenanceDrain is how long polecats have to finish up after a
+// maintenance window opens before the rig is parked.
+const DefaultMaintenanceDrain = 15 * time.Minute
+
+// maxMaintenanceDuration bounds a window's length. ActiveAt scans back one
+// minute at a time, so this also bounds its cost.
+const maxMaintenanceDuration = 7 * 24 * time.Hour
+
+// MaintenanceConfig holds a rig's scheduled maintenance windows.
+// Stored under "maintenance" in the rig's settings/config.json.
+type MaintenanceConfig struct {
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period during which the rig takes no new
+// work. When the window opens, the scheduler stops dispatching to the rig
+// and running polecats get a drain notice. When the drain period ends the
+// rig is parked; it is unparked when the window closes.
+//
+// Example: {"name": "nightly-migrations", "schedule": "0 2 * * *",
+// "duration": "1h", "drain": "10m"}
+type MaintenanceWindow struct {
+	// Name identifies the window in status output and events.
+	Name string `json:"name,omitempty"`
+
+	// Schedule is a 5-field cron expression (minute hour day-of-month
+	// month day-of-week, local time) giving when the window opens.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open (e.g., "2h").
+	Duration string `json:"duration"`
+
+	// Drain is how long after the window opens polecats have to finish
+	// before the rig parks (default 15m). Must not exceed Duration.
+	Drain string `json:"drain,omitempty"`
+
+	// Reason is included in the drain notice sent to polecats.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Label returns the window's name, or its schedule if it has none.
+func (w MaintenanceWindow) Label() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.Schedule
+}
+
+// Validate checks the schedule and durations.
+func (w MaintenanceWindow) Validate() error {
+	if _, err := ParseCron(w.Schedule); err != nil {
+		return fmt.Errorf("window %s: %w", w.Label(), err)
+	}
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("window %s: invalid duration %q", w.Label(), w.Duration)
+	}
+	if d > maxMaintenanceDuration {
+		return fmt.Errorf("window %s: duration %s exceeds %s", w.Label(), d, maxMaintenanceDuration)
+	}
+	if w.Drain != "" {
+		drain, err := time.ParseDuration(w.Drain)
+		if err != nil || drain < 0 {
+			return fmt.Errorf("window %s: invalid drain %q", w.Label(), w.Drain)
+		}
+		if drain > d {
+			return fmt.Errorf("window %s: drain %s exceeds duration %s", w.Label(), drain, d)
+		}
+	}
+	return nil
+}
+
+// GetDuration returns the window length, or 0 if it is invalid.
+func (w MaintenanceWindow) GetDuration() time.Duration {
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil || d <= 0 || d > maxMaintenanceDuration {
+		return 0
+	}
+	return d
+}
+
+// GetDrain returns the drain period, capped at the window length.
+func (w MaintenanceWindow) GetDrain() time.Duration {
+	drain := ParseDurationOrDefault(w.Drain, DefaultMaintenanceDrain)
+	if d := w.GetDuration(); drain > d {
+		return d
+	}
+	return drain
+}
+
+// ActiveAt reports whether the window is open at t, and if so when the
+// current occurrence started.
+func (w MaintenanceWindow) ActiveAt(t time.Time) (time.Time, bool) {
+	sched, err := ParseCron(w.Schedule)
+	if err != nil {
+		return time.Time{}, false
+	}
+	d := w.GetDuration()
+	if d == 0 {
+		return time.Time{}, false
+	}
+	t = t.Truncate(time.Minute)
+	for start := t; t.Sub(start) < d; start = start.Add(-time.Minute) {
+		if sched.Matches(start) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Next returns when the window next opens after t, searching up to a year
+// ahead. Returns the zero time if it never opens in that range.
+func (w MaintenanceWindow) Next(t time.Time) time.Time {
+	sched, err := ParseCron(w.Schedule)
+	if err != nil {
+		return time.Time{}
+	}
+	start := t.Truncate(time.Minute).Add(time.Minute)
+	for m := start; m.Sub(start) < 366*24*time.Hour; m = m.Add(time.Minute) {
+		if sched.Matches(m) {
+			return m
+		}
+	}
+	return time.Time{}
+}
+
+// ActiveWindow returns the window open at t and when it started. If several
+// overlap, the one that started first wins. A nil config has no windows.
+func (c *MaintenanceConfig) ActiveWindow(t time.Time) (*MaintenanceWindow, time.Time, bool) {
+	if c == nil {
+		return nil, time.Time{}, false
+	}
+	var active *MaintenanceWindow
+	var activeStart time.Time
+	for i := range c.Windows {
+		start, ok := c.Windows[i].ActiveAt(t)
+		if ok && (active == nil || start.Before(activeStart)) {
+			active, activeStart = &c.Windows[i], start
+		}
+	}
+	return active, activeStart, active != nil
+}
+
+// Validate checks every window.
+func (c *MaintenanceConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, w := range c.Windows {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadRigMaintenance loads the maintenance windows from a rig's settings.
+// Returns nil (no windows) if the rig has no settings file.
+func LoadRigMaintenance(rigPath string) (*MaintenanceConfig, error) {
+	settings, err := LoadRigSettings(RigSettingsPath(rigPath))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings.Maintenance, nil
+}
+
+// CronSchedule is a parsed 5-field cron expression. Each field is a bitmask
+// of the values it matches.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// cronFields gives the value range of each cron field.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 7},
+}
+
+// ParseCron parses a standard 5-field cron expression. Fields accept *,
+// single values, ranges (1-5), lists (1,15) and steps (*/15, 0-30/10).
+// Day-of-week 0 and 7 both mean Sunday.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q must have %d fields", expr, len(cronFields))
+	}
+	var masks [5]uint64
+	for i, f := range fields {
+		mask, err := parseCronField(f, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("cron %s field %q: %w", cronFields[i].name, f, err)
+		}
+		masks[i] = mask
+	}
+	// Fold Sunday=7 onto 0.
+	if masks[4]&(1<<7) != 0 {
+		masks[4] = masks[4]&^(1<<7) | 1
+	}
+	return &CronSchedule{
+		minute:  masks[0],
+		hour:    masks[1],
+		dom:     masks[2],
+		month:   masks[3],
+		dow:     masks[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses one comma-separated cron field into a bitmask.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = strconv.Atoi(a); err != nil {
+				return 0, fmt.Errorf("invalid value %q", a)
+			}
+			if hi, err = strconv.Atoi(b); err != nil {
+				return 0, fmt.Errorf("invalid value %q", b)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = n, n
+			if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value out of range %d-%d", min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// Matches reports whether the schedule fires at t's minute. As in cron,
+// when both day-of-month and day-of-week are restricted, either may match.
+func (s *CronSchedule) Matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 ||
+		s.hour&(1<<uint(t.Hour())) == 0 ||
+		s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domOK := s.dom&(1<<uint(t.Day())) != 0
+	dowOK := s.dow&(1<<uint(t.Weekday())) != 0
+	if !s.domStar && !s.dowStar {
+		return domOK || dowOK
+	}
+	return domOK && dowOK
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	t.Parallel()
+	// 2026-03-02 is a Monday.
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 3, day, hour, min, 0, 0, time.Local)
+	}
+	tests := []struct {
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"0 2 * * *", at(2, 2, 0), true},
+		{"0 2 * * *", at(2, 2, 1), false},
+		{"*/15 * * * *", at(2, 9, 45), true},
+		{"*/15 * * * *", at(2, 9, 50), false},
+		{"30 22 * * 1-5", at(2, 22, 30), true},
+		{"30 22 * * 1-5", at(1, 22, 30), false}, // Sunday
+		{"0 0 * * 7", at(1, 0, 0), true},        // 7 is Sunday too
+		{"0 0 * * 0", at(1, 0, 0), true},
+		{"0 12 1,15 * *", at(15, 12, 0), true},
+		{"0 12 1,15 * *", at(14, 12, 0), false},
+		// Day-of-month and day-of-week both restricted: either matches.
+		{"0 12 15 * 1", at(2, 12, 0), true},
+		{"0 12 15 * 1", at(15, 12, 0), true},
+		{"0 12 15 * 1", at(3, 12, 0), false},
+		{"0 0-6/2 * * *", at(2, 4, 0), true},
+		{"0 0-6/2 * * *", at(2, 5, 0), false},
+	}
+	for _, tt := range tests {
+		sched, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error: %v", tt.expr, err)
+		}
+		if got := sched.Matches(tt.t); got != tt.want {
+			t.Errorf("ParseCron(%q).Matches(%s) = %v, want %v", tt.expr, tt.t.Format(time.RFC1123), got, tt.want)
+		}
+	}
+}
+
+func TestParseCronRejectsInvalid(t *testing.T) {
+	t.Parallel()
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestMaintenanceWindowActiveAt(t *testing.T) {
+	t.Parallel()
+	w := MaintenanceWindow{Name: "nightly", Schedule: "0 23 * * *", Duration: "2h"}
+	start := time.Date(2026, 3, 2, 23, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{start.Add(-time.Minute), false},
+		{start, true},
+		{start.Add(90 * time.Minute), true}, // Past midnight
+		{start.Add(2 * time.Hour), false},
+	}
+	for _, tt := range tests {
+		got, ok := w.ActiveAt(tt.at)
+		if ok != tt.want {
+			t.Errorf("ActiveAt(%s) active = %v, want %v", tt.at.Format(time.Kitchen), ok, tt.want)
+		}
+		if ok && !got.Equal(start) {
+			t.Errorf("ActiveAt(%s) start = %s, want %s", tt.at.Format(time.Kitchen), got, start)
+		}
+	}
+
+	if next := w.Next(start.Add(time.Hour)); !next.Equal(start.Add(24 * time.Hour)) {
+		t.Errorf("Next() = %s, want the following night", next)
+	}
+}
+
+func TestMaintenanceWindowDrain(t *testing.T) {
+	t.Parallel()
+	if got := (MaintenanceWindow{Duration: "1h"}).GetDrain(); got != DefaultMaintenanceDrain {
+		t.Errorf("default drain = %v, want %v", got, DefaultMaintenanceDrain)
+	}
+	if got := (MaintenanceWindow{Duration: "5m"}).GetDrain(); got != 5*time.Minute {
+		t.Errorf("drain for a 5m window = %v, want capped at 5m", got)
+	}
+}
+
+func TestMaintenanceConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		w       MaintenanceWindow
+		wantErr string
+	}{
+		{MaintenanceWindow{Schedule: "0 2 * * *", Duration: "1h", Drain: "10m"}, ""},
+		{MaintenanceWindow{Schedule: "0 2 * *", Duration: "1h"}, "5 fields"},
+		{MaintenanceWindow{Schedule: "0 2 * * *", Duration: "soon"}, "invalid duration"},
+		{MaintenanceWindow{Schedule: "0 2 * * *", Duration: "200h"}, "exceeds"},
+		{MaintenanceWindow{Schedule: "0 2 * * *", Duration: "1h", Drain: "2h"}, "drain 2h0m0s exceeds"},
+	}
+	for _, tt := range tests {
+		err := (&MaintenanceConfig{Windows: []MaintenanceWindow{tt.w}}).Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("Validate(%+v) error: %v", tt.w, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Validate(%+v) error = %v, want %q", tt.w, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadRigMaintenance(t *testing.T) {
+	t.Parallel()
+	rigPath := t.TempDir()
+	if cfg, err := LoadRigMaintenance(rigPath); err != nil || cfg != nil {
+		t.Fatalf("LoadRigMaintenance() without settings = %v, %v; want nil, nil", cfg, err)
+	}
+
+	settings := `{"type": "rig-settings", "version": 1, "maintenance": {"windows": [
+		{"name": "ci-freeze", "schedule": "0 18 * * 5", "duration": "4h"}]}}`
+	path := RigSettingsPath(rigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadRigMaintenance(rigPath)
+	if err != nil {
+		t.Fatalf("LoadRigMaintenance() error: %v", err)
+	}
+	if cfg == nil || len(cfg.Windows) != 1 || cfg.Windows[0].Label() != "ci-freeze" {
+		t.Errorf("LoadRigMaintenance() = %+v", cfg)
+	}
+}
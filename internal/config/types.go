@@ -96,6 +96,11 @@ type TownSettings struct {
 	// These were previously hardcoded as Go constants throughout the codebase.
 	// All values are optional — omitted values use compiled-in defaults.
 	Operational *OperationalConfig `json:"operational,omitempty"`
+
+	// Warrants configures when Boot may execute death warrants
+	// (second filers, human approval, appeal windows).
+	// Nil uses the built-in policy (see DefaultWarrantPolicy).
+	Warrants *WarrantPolicyConfig `json:"warrants,omitempty"`
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -595,8 +600,60 @@ type RigSettings struct {
 	// Takes precedence over RoleAgents["crew"] but is overridden by explicit --agent flags.
 	// Example: {"denali": "codex", "glacier": "gemini"}
 	WorkerAgents map[string]string `json:"worker_agents,omitempty"`
+
+	// WorkerSkills declares skill tags per worker for gt sling --auto.
+	// Keys are crew worker names, plus the special key "polecats" which
+	// applies to every polecat spawned in this rig. Skills are matched
+	// case-insensitively against bead labels.
+	// Example: {"polecats": ["go", "backend"], "denali": ["frontend", "css"]}
+	WorkerSkills map[string][]string `json:"worker_skills,omitempty"`
+
+	// WorkerMode starts this rig's polecats as structured non-interactive
+	// workers (see gt worker) instead of interactive TUI sessions. The rig's
+	// polecat agent must have a streaming mode (claude, gemini, codex).
+	WorkerMode bool `json:"worker_mode,omitempty"`
+
+	// Sandbox confines agent sessions, keyed by role name ("polecat") or
+	// SandboxDefaultKey for roles without their own entry.
+	// Example: {"polecat": {"driver": "bubblewrap", "network": "allowlist",
+	//           "allow_hosts": ["github.com:443"]}}
+	Sandbox map[string]*SandboxConfig `json:"sandbox,omitempty"`
+
+	// Maintenance schedules recurring windows during which the rig drains
+	// and parks itself (see MaintenanceWindow).
+	Maintenance *MaintenanceConfig `json:"maintenance,omitempty"`
 }
 
+// SandboxDefaultKey is the RigSettings.Sandbox key that applies to every role
+// without its own entry.
+const SandboxDefaultKey = "default"
+
+// SandboxConfig selects the sandbox driver for an agent session and what the
+// sandbox may reach. With the bubblewrap driver the session sees only its
+// worktree, the shared beads and git dirs, the agent's own state dir and the
+// paths listed here; the rest of $HOME (~/.ssh, cloud credentials) is hidden.
+type SandboxConfig struct {
+	// Driver is "none" (default) or "bubblewrap".
+	Driver string `json:"driver,omitempty"`
+
+	// ReadOnlyPaths are extra host paths visible read-only. "~/" expands
+	// to the user's home directory.
+	ReadOnlyPaths []string `json:"read_only_paths,omitempty"`
+
+	// ReadWritePaths are extra host paths visible read-write.
+	ReadWritePaths []string `json:"read_write_paths,omitempty"`
+
+	// Network is "host" (default: full network), "none", or "allowlist"
+	// (only AllowHosts and the town's Dolt server are reachable).
+	Network string `json:"network,omitempty"`
+
+	// AllowHosts lists host:port endpoints reachable in "allowlist" mode.
+	AllowHosts []string `json:"allow_hosts,omitempty"`
+}
+
+// PolecatSkillsKey is the WorkerSkills key that applies to all polecats in a rig.
+const PolecatSkillsKey = "polecats"
+
 // CrewConfig represents crew workspace settings for a rig.
 type CrewConfig struct {
 	// Startup is a natural language instruction for which crew to start on boot.
@@ -757,6 +814,33 @@ func (rc *RuntimeConfig) BuildCommandWithPrompt(prompt string) string {
 	return base + " " + quoteForShell(p)
 }
 
+// BuildWorkerArgs returns the argv that runs this runtime non-interactively
+// on prompt with streaming JSON output, for worker mode. The streaming flags
+// come from the agent preset (ResolvedAgent, then Provider), so custom agents
+// built on a preset inherit them. Returns nil if the agent has no streaming mode.
+func (rc *RuntimeConfig) BuildWorkerArgs(prompt string) []string {
+	resolved := normalizeRuntimeConfig(rc)
+	ni := GetNonInteractiveConfig(resolved.ResolvedAgent)
+	if ni == nil || ni.StreamFlag == "" {
+		ni = GetNonInteractiveConfig(resolved.Provider)
+	}
+	if ni == nil || ni.StreamFlag == "" {
+		return nil
+	}
+
+	args := []string{resolved.Command}
+	if ni.Subcommand != "" {
+		// e.g., "codex exec --dangerously-bypass-approvals-and-sandbox --json <prompt>"
+		args = append(args, ni.Subcommand)
+	}
+	args = append(args, resolved.Args...)
+	args = append(args, strings.Fields(ni.StreamFlag)...)
+	if ni.PromptFlag != "" {
+		args = append(args, ni.PromptFlag)
+	}
+	return append(args, prompt)
+}
+
 // BuildArgsWithPrompt returns the runtime command and args suitable for exec.
 func (rc *RuntimeConfig) BuildArgsWithPrompt(prompt string) []string {
 	resolved := normalizeRuntimeConfig(rc)
@@ -1226,19 +1310,53 @@ func DefaultNamepoolConfig() *NamepoolConfig {
 	}
 }
 
-// AccountsConfig represents Claude Code account configuration (mayor/accounts.json).
-// This enables Gas Town to manage multiple Claude Code accounts with easy switching.
+// AccountsConfig represents agent account configuration (mayor/accounts.json).
+// This enables Gas Town to manage pools of accounts per provider (Claude Code
+// config dirs, OpenAI/Gemini API keys, Copilot tokens) with easy switching.
 type AccountsConfig struct {
 	Version  int                `json:"version"`  // schema version
 	Accounts map[string]Account `json:"accounts"` // handle -> account details
 	Default  string             `json:"default"`  // default account handle
+
+	// Strategy selects how new polecats pick an account when none is given
+	// explicitly: "" (default account) or AccountStrategyBalanced.
+	Strategy string `json:"strategy,omitempty"`
+
+	// SecretBackend is the backend used for secret references without an
+	// explicit "<backend>:" prefix (default "file").
+	SecretBackend string `json:"secret_backend,omitempty"`
 }
 
-// Account represents a single Claude Code account.
+// AccountStrategyBalanced spreads new polecat sessions across available
+// accounts (fewest live sessions, then least recently used) instead of
+// piling them onto the default account.
+const AccountStrategyBalanced = "balanced"
+
+// Account represents a single agent account.
+// Claude Code accounts are config-dir based; other providers authenticate
+// with a credential stored in a secret backend and injected via the agent
+// preset's CredentialEnv.
 type Account struct {
 	Email       string `json:"email"`                 // account email
 	Description string `json:"description,omitempty"` // human description
 	ConfigDir   string `json:"config_dir"`            // path to CLAUDE_CONFIG_DIR
+	Provider    string `json:"provider,omitempty"`    // agent preset name (empty = claude)
+	Secret      string `json:"secret,omitempty"`      // secret reference, e.g. "file:openai-work"
+}
+
+// ProviderName returns the agent preset this account belongs to,
+// defaulting to claude for accounts created before providers existed.
+func (a Account) ProviderName() string {
+	if a.Provider == "" {
+		return string(AgentClaude)
+	}
+	return a.Provider
+}
+
+// UsesCredential reports whether the account authenticates with a secret
+// (API key or token) rather than a runtime config directory.
+func (a Account) UsesCredential() bool {
+	return a.Secret != ""
 }
 
 // CurrentAccountsVersion is the current schema version for AccountsConfig.
@@ -1276,10 +1394,12 @@ const (
 
 // AccountQuotaState tracks the quota status of a single account.
 type AccountQuotaState struct {
-	Status    AccountQuotaStatus `json:"status"`              // current status
-	LimitedAt string             `json:"limited_at,omitempty"` // RFC3339 when limit was detected
-	ResetsAt  string             `json:"resets_at,omitempty"`  // Human-readable reset time from provider (e.g. "7pm (America/Los_Angeles)")
-	LastUsed  string             `json:"last_used,omitempty"`  // RFC3339 when account was last assigned to a session
+	Status       AccountQuotaStatus `json:"status"`                   // current status
+	LimitedAt    string             `json:"limited_at,omitempty"`     // RFC3339 when limit was detected
+	ResetsAt     string             `json:"resets_at,omitempty"`      // Human-readable reset time from provider (e.g. "7pm (America/Los_Angeles)")
+	ResetsAtTime string             `json:"resets_at_time,omitempty"` // RFC3339 reset time resolved from ResetsAt at detection
+	LastUsed     string             `json:"last_used,omitempty"`      // RFC3339 when account was last assigned to a session
+	Provider     string             `json:"provider,omitempty"`       // agent preset the account belongs to (empty = claude)
 }
 
 // CurrentQuotaVersion is the current schema version for QuotaState.
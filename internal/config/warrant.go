@@ -0,0 +1,103 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// Warrant reason classes. A warrant's class is given when it is filed or
+// derived from its reason text.
+const (
+	WarrantClassZombie       = "zombie"       // No session, or session with no agent
+	WarrantClassStuck        = "stuck"        // Alive but making no progress
+	WarrantClassUnresponsive = "unresponsive" // Alive but not answering nudges or heartbeats
+	WarrantClassOther        = "other"
+)
+
+// DefaultWarrantAppealWindow is the appeal window of the built-in polecat and
+// dog rules.
+const DefaultWarrantAppealWindow = 10 * time.Minute
+
+// WarrantPolicyConfig configures what Boot requires before executing a death
+// warrant against a live session. Dead sessions are always reaped: there is
+// nothing left to protect.
+type WarrantPolicyConfig struct {
+	// Rules are matched in order; the first rule whose role and reason class
+	// match the warrant applies. A warrant matching no rule is executed
+	// immediately. Configured rules replace the built-in defaults.
+	Rules []WarrantPolicyRule `json:"rules,omitempty"`
+}
+
+// WarrantPolicyRule is the policy for warrants against one role and reason
+// class.
+type WarrantPolicyRule struct {
+	// Role is the target role: "polecat", "crew", "dog", "witness" or
+	// "refinery". Empty matches any role.
+	Role string `json:"role,omitempty"`
+
+	// ReasonClass is "zombie", "stuck", "unresponsive" or "other".
+	// Empty matches any class.
+	ReasonClass string `json:"reason_class,omitempty"`
+
+	// MinFilers is how many distinct agents must file (or co-sign) the
+	// warrant. Default 1.
+	MinFilers int `json:"min_filers,omitempty"`
+
+	// RequireApproval holds the warrant until a human runs gt warrant approve.
+	RequireApproval bool `json:"require_approval,omitempty"`
+
+	// AppealWindow is how long the target has, after being nudged, to prove
+	// liveness with gt warrant appeal (e.g., "10m"). Empty means no window.
+	AppealWindow string `json:"appeal_window,omitempty"`
+}
+
+// DefaultWarrantPolicy returns the built-in policy: crew warrants need human
+// approval, and polecats and dogs get an appeal window so a long but healthy
+// operation is not killed on one agent's say-so.
+func DefaultWarrantPolicy() *WarrantPolicyConfig {
+	window := DefaultWarrantAppealWindow.String()
+	return &WarrantPolicyConfig{
+		Rules: []WarrantPolicyRule{
+			{Role: "crew", RequireApproval: true},
+			{Role: "polecat", AppealWindow: window},
+			{Role: "dog", AppealWindow: window},
+		},
+	}
+}
+
+// Match returns the first rule matching role and class. A nil or empty
+// policy uses DefaultWarrantPolicy; no match yields the zero rule.
+func (p *WarrantPolicyConfig) Match(role, class string) WarrantPolicyRule {
+	if p == nil || len(p.Rules) == 0 {
+		p = DefaultWarrantPolicy()
+	}
+	for _, r := range p.Rules {
+		if (r.Role == "" || r.Role == role) && (r.ReasonClass == "" || r.ReasonClass == class) {
+			return r
+		}
+	}
+	return WarrantPolicyRule{}
+}
+
+// GetMinFilers returns the number of distinct filers required, at least 1.
+func (r WarrantPolicyRule) GetMinFilers() int {
+	if r.MinFilers < 1 {
+		return 1
+	}
+	return r.MinFilers
+}
+
+// GetAppealWindow returns the appeal window, or 0 for none.
+func (r WarrantPolicyRule) GetAppealWindow() time.Duration {
+	return ParseDurationOrDefault(r.AppealWindow, 0)
+}
+
+// LoadWarrantPolicy loads the warrant policy from a town root.
+// Returns nil (the built-in defaults) if none is configured.
+func LoadWarrantPolicy(townRoot string) *WarrantPolicyConfig {
+	ts, err := LoadOrCreateTownSettings(filepath.Join(townRoot, "settings", "config.json"))
+	if err != nil || ts == nil {
+		return nil
+	}
+	return ts.Warrants
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWarrantPolicyMatch(t *testing.T) {
+	t.Parallel()
+
+	var defaults *WarrantPolicyConfig
+	if r := defaults.Match("crew", WarrantClassStuck); !r.RequireApproval {
+		t.Error("default policy does not require approval for crew")
+	}
+	if r := defaults.Match("polecat", WarrantClassStuck); r.GetAppealWindow() != DefaultWarrantAppealWindow {
+		t.Errorf("default polecat appeal window = %v, want %v", r.GetAppealWindow(), DefaultWarrantAppealWindow)
+	}
+	if r := defaults.Match("witness", WarrantClassStuck); r.RequireApproval || r.GetAppealWindow() != 0 || r.GetMinFilers() != 1 {
+		t.Errorf("default witness rule = %+v, want immediate execution", r)
+	}
+
+	configured := &WarrantPolicyConfig{Rules: []WarrantPolicyRule{
+		{Role: "polecat", ReasonClass: WarrantClassStuck, MinFilers: 2},
+		{Role: "polecat", AppealWindow: "5m"},
+	}}
+	if r := configured.Match("polecat", WarrantClassStuck); r.GetMinFilers() != 2 {
+		t.Errorf("stuck polecat rule = %+v, want 2 filers", r)
+	}
+	if r := configured.Match("polecat", WarrantClassZombie); r.GetAppealWindow() != 5*time.Minute {
+		t.Errorf("zombie polecat rule = %+v, want 5m appeal window", r)
+	}
+	if r := configured.Match("crew", WarrantClassStuck); r.RequireApproval {
+		t.Error("configured rules should replace the defaults, but crew still requires approval")
+	}
+}
@@ -115,6 +115,9 @@ const (
 
 	// DirSettings is the rig settings directory (git-tracked).
 	DirSettings = "settings"
+
+	// DirSecrets is the file secret backend's directory in mayor/ (gitignored).
+	DirSecrets = "secrets"
 )
 
 // File names for configuration and state.
@@ -138,6 +141,9 @@ const (
 
 	// FileQuotaJSON is the quota state file in mayor/.
 	FileQuotaJSON = "quota.json"
+
+	// FileWorktreesJSON is the cross-rig worktree registry in mayor/.
+	FileWorktreesJSON = "worktrees.json"
 )
 
 // Beads configuration constants.
@@ -264,6 +270,9 @@ const (
 	// MolDogBackup is the Dolt backup dog formula name.
 	MolDogBackup = "mol-dog-backup"
 
+	// MolDogRestoreDrill is the backup restore drill dog formula name.
+	MolDogRestoreDrill = "mol-dog-restore-drill"
+
 	// MolConvoyFeed is the convoy feeder formula name.
 	MolConvoyFeed = "mol-convoy-feed"
 
@@ -362,6 +371,11 @@ func MayorQuotaPath(townRoot string) string {
 	return townRoot + "/" + DirMayor + "/" + FileQuotaJSON
 }
 
+// MayorWorktreesPath returns the path to mayor/worktrees.json within a town root.
+func MayorWorktreesPath(townRoot string) string {
+	return townRoot + "/" + DirMayor + "/" + FileWorktreesJSON
+}
+
 // DefaultRateLimitPatterns are the default patterns that indicate a session
 // is rate-limited. These are matched against tmux pane content.
 // Note: patterns are compiled with (?i) for case-insensitive matching.
@@ -461,6 +461,18 @@ func (d *Daemon) Run() error {
 		d.logger.Printf("Scheduled maintenance ticker started (check interval %v, window %s)", interval, window)
 	}
 
+	// Start restore drill ticker if configured.
+	// Restores the latest backups into a scratch dir and verifies them against live.
+	var restoreDrillTicker *time.Ticker
+	var restoreDrillChan <-chan time.Time
+	if IsPatrolEnabled(d.patrolConfig, "restore_drill") {
+		interval := restoreDrillInterval(d.patrolConfig)
+		restoreDrillTicker = time.NewTicker(interval)
+		restoreDrillChan = restoreDrillTicker.C
+		defer restoreDrillTicker.Stop()
+		d.logger.Printf("Restore drill ticker started (interval %v)", interval)
+	}
+
 	// Note: PATCH-010 uses per-session hooks in deacon/manager.go (SetAutoRespawnHook).
 	// Global pane-died hooks don't fire reliably in tmux 3.2a, so we rely on the
 	// per-session approach which has been tested to work for continuous recovery.
@@ -548,6 +560,13 @@ func (d *Daemon) Run() error {
 				d.runScheduledMaintenance()
 			}
 
+		case <-restoreDrillChan:
+			// Restore drill — proves backups actually restore by serving one
+			// from a scratch dir and comparing it against live.
+			if !d.isShutdownInProgress() {
+				d.runRestoreDrill()
+			}
+
 		case <-timer.C:
 			d.heartbeat(state)
 
@@ -670,6 +689,16 @@ func (d *Daemon) heartbeat(state *State) {
 	// branches persist indefinitely. This cleans them up periodically.
 	d.pruneStaleBranches()
 
+	// 13.5. Open, park and close rig maintenance windows. Runs before dispatch
+	// so a window that just opened stops new slings this heartbeat.
+	d.applyMaintenanceWindows()
+
+	// 13.6. Quota patrol: re-enable accounts whose rate limits have reset and
+	// rotate limited sessions onto available accounts. Runs before dispatch so
+	// new work lands on accounts that are actually usable. No-op without
+	// registered accounts.
+	d.runQuotaPatrol()
+
 	// 14. Dispatch scheduled work (capacity-controlled polecat dispatch).
 	// Shells out to `gt scheduler run` to avoid circular import between daemon and cmd.
 	d.dispatchQueuedWork()
@@ -732,6 +761,10 @@ func (d *Daemon) ensureDoltServerRunning() {
 			h.DiskUsageBytes,
 			h.Healthy,
 		)
+		// Share the sample with the scheduler's host pressure check.
+		if err := doltserver.SaveHealthSnapshot(d.config.TownRoot, h); err != nil {
+			d.logger.Printf("Warning: saving Dolt health snapshot: %v", err)
+		}
 	}
 }
 
@@ -2073,6 +2106,44 @@ func (d *Daemon) pruneStaleBranches() {
 	pruneInDir(d.config.TownRoot, "town-root")
 }
 
+// applyMaintenanceWindows shells out to `gt rig maintenance tick` when any rig
+// has maintenance windows configured or is mid-window. Skipping the
+// subprocess otherwise keeps heartbeats cheap for towns that don't use them.
+func (d *Daemon) applyMaintenanceWindows() {
+	due := false
+	for _, rigName := range d.getKnownRigs() {
+		cfg, err := config.LoadRigMaintenance(filepath.Join(d.config.TownRoot, rigName))
+		if err != nil {
+			d.logger.Printf("Maintenance: %s: %v", rigName, err)
+			continue
+		}
+		// "maintenance" is the wisp key gt rig maintenance tick records the
+		// rig's phase under; a leftover phase must be closed out even if the
+		// windows were removed from config.
+		if (cfg != nil && len(cfg.Windows) > 0) || wisp.NewConfig(d.config.TownRoot, rigName).GetString("maintenance") != "" {
+			due = true
+			break
+		}
+	}
+	if !due {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, "rig", "maintenance", "tick")
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = append(os.Environ(), "GT_DAEMON=1")
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		d.logger.Printf("Maintenance tick timed out after 2m")
+	} else if err != nil {
+		d.logger.Printf("Maintenance tick failed: %v (output: %s)", err, string(out))
+	} else if len(out) > 0 {
+		d.logger.Printf("Maintenance: %s", string(out))
+	}
+}
+
 // dispatchQueuedWork shells out to `gt scheduler run` to dispatch scheduled beads.
 // This avoids circular import between the daemon and cmd packages.
 // Uses a 5m timeout to allow multi-bead dispatch with formula cooking and hook retries.
@@ -1,7 +1,6 @@
 package daemon
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -15,6 +14,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/doltclient"
 	"github.com/steveyegge/gastown/internal/doltserver"
 )
 
@@ -214,53 +214,18 @@ func (m *DoltServerManager) IsExternal() bool {
 	return m.config != nil && m.config.External
 }
 
-// isRemote returns true when the daemon's Dolt config points to a non-local server.
-func (m *DoltServerManager) isRemote() bool {
-	if m.config == nil {
-		return false
-	}
-	switch strings.ToLower(m.config.Host) {
-	case "", "127.0.0.1", "localhost", "::1", "[::1]":
-		return false
-	}
-	return true
-}
-
-// buildDoltSQLCmd constructs a dolt sql command using daemon config, mirroring
-// the doltserver.buildDoltSQLCmd pattern for local-vs-remote command construction.
-func (m *DoltServerManager) buildDoltSQLCmd(ctx context.Context, args ...string) *exec.Cmd {
-	var fullArgs []string
-	fullArgs = append(fullArgs, "sql")
-
-	if m.isRemote() {
-		host := m.config.Host
-		if host == "" {
-			host = "127.0.0.1"
-		}
-		user := m.config.User
-		if user == "" {
-			user = "root"
-		}
-		fullArgs = append(fullArgs,
-			"--host", host,
-			"--port", strconv.Itoa(m.config.Port),
-			"--user", user,
-			"--no-tls",
-		)
-	}
-
-	fullArgs = append(fullArgs, args...)
-	cmd := exec.CommandContext(ctx, "dolt", fullArgs...)
-
-	if !m.isRemote() {
-		cmd.Dir = m.config.DataDir
-	}
-
-	if m.isRemote() && m.config.Password != "" {
-		cmd.Env = append(os.Environ(), "DOLT_CLI_PASSWORD="+m.config.Password)
-	}
-
-	return cmd
+// sqlClient returns a pooled SQL client for database ("" for none) on the
+// managed server. Health probes must report what they see, so the client
+// does not retry.
+func (m *DoltServerManager) sqlClient(database string) (*doltclient.Client, error) {
+	return doltclient.Open(doltclient.Config{
+		Host:     m.config.Host,
+		Port:     m.config.Port,
+		User:     m.config.User,
+		Password: m.config.Password,
+		Database: database,
+		Retry:    doltclient.RetryPolicy{MaxAttempts: 1},
+	})
 }
 
 // HealthCheckInterval returns the configured health check interval,
@@ -952,14 +917,13 @@ func (m *DoltServerManager) checkHealthLocked() error {
 	ctx, cancel := context.WithTimeout(context.Background(), doltCmdTimeout)
 	defer cancel()
 
+	client, err := m.sqlClient("")
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
 	start := time.Now()
-	cmd := m.buildDoltSQLCmd(ctx, "-q", "SELECT active_branch()")
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("health check failed: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	if _, err := client.Exec(ctx, "SELECT active_branch()"); err != nil {
+		return fmt.Errorf("health check failed: %w", err)
 	}
 
 	latency := time.Since(start)
@@ -1010,24 +974,15 @@ func (m *DoltServerManager) LastWarnings() []string {
 func (m *DoltServerManager) checkConnectionCount() string {
 	ctx, cancel := context.WithTimeout(context.Background(), doltCmdTimeout)
 	defer cancel()
-	cmd := m.buildDoltSQLCmd(ctx,
-		"-r", "csv",
-		"-q", "SELECT COUNT(*) AS cnt FROM information_schema.PROCESSLIST",
-	)
-
-	output, err := cmd.Output()
+	client, err := m.sqlClient("")
 	if err != nil {
 		return "" // non-fatal
 	}
-
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(lines) < 2 {
-		return ""
-	}
-	count, err := strconv.Atoi(strings.TrimSpace(lines[len(lines)-1]))
+	n, err := client.QueryInt(ctx, "SELECT COUNT(*) FROM information_schema.PROCESSLIST")
 	if err != nil {
-		return ""
+		return "" // non-fatal
 	}
+	count := int(n)
 
 	// Use the doltserver package default (50) as a reasonable cap reference
 	maxConn := 50
@@ -1169,23 +1124,23 @@ func (m *DoltServerManager) checkWriteHealthLocked() error {
 	// CREATE TABLE IF NOT EXISTS is idempotent (safe if table lingers from previous probe).
 	// REPLACE INTO always writes a row, testing the storage layer even if the table existed.
 	// DROP TABLE IF EXISTS cleans up.
-	// If ANY statement triggers "database is read only", the command fails and we detect it.
-	query := fmt.Sprintf(
-		"USE `%s`; CREATE TABLE IF NOT EXISTS `__gt_health_probe` (v INT PRIMARY KEY); REPLACE INTO `__gt_health_probe` VALUES (1); DROP TABLE IF EXISTS `__gt_health_probe`",
-		db,
-	)
-	cmd := m.buildDoltSQLCmd(ctx, "-q", query)
-
-	output, err := cmd.CombinedOutput()
+	// If ANY statement triggers "database is read only", the exec fails and we detect it.
+	client, err := m.sqlClient(db)
+	if err != nil {
+		return nil
+	}
+	_, err = client.Exec(ctx,
+		"CREATE TABLE IF NOT EXISTS `__gt_health_probe` (v INT PRIMARY KEY); "+
+			"REPLACE INTO `__gt_health_probe` VALUES (1); "+
+			"DROP TABLE IF EXISTS `__gt_health_probe`")
 	if err != nil {
-		errMsg := strings.TrimSpace(string(output))
-		if isReadOnlyError(errMsg) {
-			return fmt.Errorf("dolt server is in read-only mode: %s", errMsg)
+		if isReadOnlyError(err.Error()) {
+			return fmt.Errorf("dolt server is in read-only mode: %v", err)
 		}
 		// Non-read-only failures: log warning but don't fail health check.
 		// These could be transient issues (timeout, lock contention) that
 		// don't indicate a persistent read-only state.
-		m.logger("Warning: Dolt write probe failed (non-read-only): %v (%s)", err, errMsg)
+		m.logger("Warning: Dolt write probe failed (non-read-only): %v", err)
 	}
 
 	return nil
@@ -1224,32 +1179,21 @@ func (m *DoltServerManager) checkDatabaseIdentityLocked() error {
 	ctx, cancel := context.WithTimeout(context.Background(), doltCmdTimeout)
 	defer cancel()
 
+	client, err := m.sqlClient(db)
+	if err != nil {
+		return nil
+	}
+
 	// Try issues table first
 	issueCount := -1
-	query := fmt.Sprintf("SELECT COUNT(*) AS cnt FROM `%s`.`issues`", db)
-	cmd := m.buildDoltSQLCmd(ctx, "-r", "csv", "-q", query)
-	if output, queryErr := cmd.Output(); queryErr == nil {
-		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-		if len(lines) >= 2 {
-			if c, err := strconv.Atoi(strings.TrimSpace(lines[len(lines)-1])); err == nil {
-				issueCount = c
-			}
-		}
+	if c, err := client.QueryInt(ctx, "SELECT COUNT(*) FROM `issues`"); err == nil {
+		issueCount = int(c)
 	}
 
 	// Also try wisps table (may not exist yet)
 	wispCount := -1
-	wispCtx, wispCancel := context.WithTimeout(context.Background(), doltCmdTimeout)
-	defer wispCancel()
-	wispQuery := fmt.Sprintf("SELECT COUNT(*) AS cnt FROM `%s`.`wisps`", db)
-	wispCmd := m.buildDoltSQLCmd(wispCtx, "-r", "csv", "-q", wispQuery)
-	if wispOutput, wispErr := wispCmd.Output(); wispErr == nil {
-		wispLines := strings.Split(strings.TrimSpace(string(wispOutput)), "\n")
-		if len(wispLines) >= 2 {
-			if c, err := strconv.Atoi(strings.TrimSpace(wispLines[len(wispLines)-1])); err == nil {
-				wispCount = c
-			}
-		}
+	if c, err := client.QueryInt(ctx, "SELECT COUNT(*) FROM `wisps`"); err == nil {
+		wispCount = int(c)
 	}
 
 	// If neither table exists, that's OK (not all DBs have beads)
@@ -40,13 +40,7 @@ func (d *Daemon) syncDoltBackups() {
 	mol := d.pourDogMolecule(constants.MolDogBackup, nil)
 	defer mol.close()
 
-	// Resolve data dir: use DoltServerManager if available, else conventional path.
-	var dataDir string
-	if d.doltServer != nil && d.doltServer.IsEnabled() && d.doltServer.config.DataDir != "" {
-		dataDir = d.doltServer.config.DataDir
-	} else {
-		dataDir = filepath.Join(d.config.TownRoot, ".dolt-data")
-	}
+	dataDir := d.doltDataDir()
 	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
 		d.logger.Printf("dolt_backup: data dir %s does not exist, skipping", dataDir)
 		mol.failStep("sync", "data dir does not exist")
@@ -36,7 +36,8 @@ var (
 	maxDogPoolSize = config.DefaultMaxDogPoolSize
 )
 
-// handleDogs manages Dog lifecycle: cleanup stuck dogs, reap idle dogs, then dispatch plugins.
+// handleDogs manages Dog lifecycle: cleanup stuck dogs, reap idle dogs, queue
+// due plugins, then drain the dog job queue onto idle dogs.
 // This is the main entry point called from heartbeat.
 func (d *Daemon) handleDogs() {
 	rigsConfig, err := d.loadRigsConfig()
@@ -54,7 +55,8 @@ func (d *Daemon) handleDogs() {
 	d.cleanupStuckDogs(mgr, sm)
 	d.detectStaleWorkingDogs(mgr, sm, opCfg)
 	d.reapIdleDogs(mgr, sm, opCfg)
-	d.dispatchPlugins(mgr, sm, rigsConfig)
+	d.dispatchPlugins(mgr, rigsConfig)
+	d.drainDogQueue(mgr, sm)
 }
 
 // cleanupStuckDogs finds dogs in state=working whose tmux session is dead and
@@ -85,7 +87,9 @@ func (d *Daemon) cleanupStuckDogs(mgr *dog.Manager, sm *dog.SessionManager) {
 		d.logger.Printf("Handler: dog %s is working but session is dead, clearing work", dg.Name)
 		if err := mgr.ClearWork(dg.Name); err != nil {
 			d.logger.Printf("Handler: failed to clear work for dog %s: %v", dg.Name, err)
+			continue
 		}
+		d.failDogJob(mgr, dg.Name, "session died")
 	}
 }
 
@@ -119,6 +123,7 @@ func (d *Daemon) detectStaleWorkingDogs(mgr *dog.Manager, sm *dog.SessionManager
 			d.logger.Printf("Handler: failed to clear work for stale dog %s: %v", dg.Name, err)
 			continue
 		}
+		d.failDogJob(mgr, dg.Name, fmt.Sprintf("stuck working (inactive %v)", staleDuration.Truncate(time.Minute)))
 
 		// Kill the tmux session — it's not doing anything useful.
 		running, err := sm.IsRunning(dg.Name)
@@ -192,9 +197,10 @@ func (d *Daemon) reapIdleDogs(mgr *dog.Manager, sm *dog.SessionManager, daemonCf
 	}
 }
 
-// dispatchPlugins scans for plugins, evaluates cooldown gates, and dispatches
-// eligible plugins to idle dogs.
-func (d *Daemon) dispatchPlugins(mgr *dog.Manager, sm *dog.SessionManager, rigsConfig *config.RigsConfig) {
+// dispatchPlugins scans for plugins, evaluates cooldown gates, and queues
+// eligible plugins on the dog job queue. drainDogQueue hands them to dogs.
+// A plugin already queued or running is not queued again.
+func (d *Daemon) dispatchPlugins(mgr *dog.Manager, rigsConfig *config.RigsConfig) {
 	// Get rig names for scanner
 	var rigNames []string
 	if rigsConfig != nil {
@@ -215,7 +221,7 @@ func (d *Daemon) dispatchPlugins(mgr *dog.Manager, sm *dog.SessionManager, rigsC
 	}
 
 	recorder := plugin.NewRecorder(d.config.TownRoot)
-	router := mail.NewRouterWithTownRoot(d.config.TownRoot, d.config.TownRoot)
+	queue := mgr.Queue()
 
 	for _, p := range plugins {
 		// Only dispatch plugins with cooldown gates.
@@ -235,50 +241,122 @@ func (d *Daemon) dispatchPlugins(mgr *dog.Manager, sm *dog.SessionManager, rigsC
 			}
 		}
 
-		// Find an idle dog.
+		if _, err := queue.Enqueue(dog.Job{
+			Work:    fmt.Sprintf("plugin:%s", p.Name),
+			Subject: fmt.Sprintf("Plugin: %s", p.Name),
+			Body:    p.FormatMailBody(),
+		}); err != nil {
+			d.logger.Printf("Handler: failed to queue plugin %s: %v", p.Name, err)
+		}
+	}
+}
+
+// drainDogQueue hands queued jobs to idle dogs, highest priority first,
+// until the queue or the idle pool runs out. Running jobs whose dog has
+// moved on without reporting a failure are treated as complete.
+func (d *Daemon) drainDogQueue(mgr *dog.Manager, sm *dog.SessionManager) {
+	queue := mgr.Queue()
+	d.reconcileDogJobs(mgr, queue)
+
+	router := mail.NewRouterWithTownRoot(d.config.TownRoot, d.config.TownRoot)
+
+	for {
 		idleDog, err := mgr.GetIdleDog()
 		if err != nil {
 			d.logger.Printf("Handler: error finding idle dog: %v", err)
-			return // No point continuing if we can't list dogs
+			return
 		}
 		if idleDog == nil {
-			d.logger.Printf("Handler: no idle dogs available, deferring remaining plugins")
+			return // Remaining jobs wait for the next heartbeat
+		}
+
+		job, err := queue.Claim(idleDog.Name, time.Now())
+		if err != nil {
+			d.logger.Printf("Handler: failed to claim dog job: %v", err)
+			return
+		}
+		if job == nil {
 			return
 		}
 
 		// Assign work and start session.
-		workDesc := fmt.Sprintf("plugin:%s", p.Name)
-		if err := mgr.AssignWork(idleDog.Name, workDesc); err != nil {
-			d.logger.Printf("Handler: failed to assign work to dog %s: %v", idleDog.Name, err)
-			continue
+		if err := mgr.AssignWork(idleDog.Name, job.Work); err != nil {
+			d.logger.Printf("Handler: failed to assign job %s to dog %s: %v", job.ID, idleDog.Name, err)
+			d.failDogJob(mgr, idleDog.Name, fmt.Sprintf("assigning work: %v", err))
+			return
 		}
 
 		if err := sm.Start(idleDog.Name, dog.SessionStartOptions{
-			WorkDesc: workDesc,
+			WorkDesc: job.Work,
 		}); err != nil {
 			d.logger.Printf("Handler: failed to start session for dog %s: %v", idleDog.Name, err)
 			// Roll back assignment on session start failure.
 			if clearErr := mgr.ClearWork(idleDog.Name); clearErr != nil {
 				d.logger.Printf("Handler: failed to clear work after start failure for dog %s: %v", idleDog.Name, clearErr)
 			}
+			d.failDogJob(mgr, idleDog.Name, fmt.Sprintf("starting session: %v", err))
 			continue
 		}
 
-		// Send mail with plugin instructions.
-		msg := mail.NewMessage(
-			"daemon",
-			fmt.Sprintf("deacon/dogs/%s", idleDog.Name),
-			fmt.Sprintf("Plugin: %s", p.Name),
-			p.FormatMailBody(),
-		)
-		msg.Type = mail.TypeTask
-		msg.Timestamp = time.Now()
-		if err := router.Send(msg); err != nil {
-			d.logger.Printf("Handler: failed to send mail to dog %s: %v", idleDog.Name, err)
-			// Session is already started — dog will find no mail and idle out.
+		if job.Body != "" {
+			msg := mail.NewMessage(
+				"daemon",
+				fmt.Sprintf("deacon/dogs/%s", idleDog.Name),
+				job.Subject,
+				job.Body,
+			)
+			msg.Type = mail.TypeTask
+			msg.Timestamp = time.Now()
+			if err := router.Send(msg); err != nil {
+				d.logger.Printf("Handler: failed to send mail to dog %s: %v", idleDog.Name, err)
+				// Session is already started — dog will find no mail and idle
+				// out, and the stale-working check fails the job for retry.
+			}
+		}
+
+		d.logger.Printf("Handler: dispatched job %s (%s, attempt %d/%d) to dog %s",
+			job.ID, job.Work, job.Attempts, job.MaxAttempts, idleDog.Name)
+	}
+}
+
+// reconcileDogJobs completes running jobs whose dog is gone or no longer
+// holds the job's work. Dogs that finish with gt dog done complete their job
+// directly; this catches work cleared by other paths.
+func (d *Daemon) reconcileDogJobs(mgr *dog.Manager, queue *dog.Queue) {
+	jobs, err := queue.List()
+	if err != nil {
+		d.logger.Printf("Handler: failed to list dog queue: %v", err)
+		return
+	}
+	for _, job := range jobs {
+		if job.State != dog.JobRunning {
+			continue
 		}
+		dg, err := mgr.Get(job.Dog)
+		if err == nil && dg.State == dog.StateWorking && dg.Work == job.Work {
+			continue
+		}
+		if err := queue.Complete(job.Dog); err != nil {
+			d.logger.Printf("Handler: failed to complete job %s: %v", job.ID, err)
+		}
+	}
+}
 
-		d.logger.Printf("Handler: dispatched plugin %s to dog %s", p.Name, idleDog.Name)
+// failDogJob records a failed attempt for the queued job a dog was running,
+// if any, so it is retried with backoff or dead-lettered.
+func (d *Daemon) failDogJob(mgr *dog.Manager, dogName, reason string) {
+	job, err := mgr.Queue().Fail(dogName, reason, time.Now())
+	if err != nil {
+		d.logger.Printf("Handler: failed to record job failure for dog %s: %v", dogName, err)
+		return
+	}
+	if job == nil {
+		return
+	}
+	if job.State == dog.JobDead {
+		d.logger.Printf("Handler: job %s (%s) dead-lettered after %d attempts: %s", job.ID, job.Work, job.Attempts, reason)
+	} else {
+		d.logger.Printf("Handler: job %s (%s) will retry at %s: %s", job.ID, job.Work, job.NextAttemptAt.Format(time.RFC3339), reason)
 	}
 }
 
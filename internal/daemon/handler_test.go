@@ -173,6 +173,68 @@ func TestDetectStaleWorkingDogs_EmptyKennel(t *testing.T) {
 	d.detectStaleWorkingDogs(mgr, sm, &config.DaemonThresholds{})
 }
 
+func TestDetectStaleWorkingDogs_RequeuesQueuedJob(t *testing.T) {
+	townRoot := t.TempDir()
+	d := testHandlerDaemon(t, townRoot)
+
+	rigsConfig := &config.RigsConfig{Version: 1, Rigs: map[string]config.RigEntry{}}
+	mgr := dog.NewManager(townRoot, rigsConfig)
+	tm := tmux.NewTmux()
+	sm := dog.NewSessionManager(tm, townRoot, mgr)
+
+	queue := mgr.Queue()
+	if _, err := queue.Enqueue(dog.Job{Work: "plugin:dolt-gc"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queue.Claim("stale", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	testSetupWorkingDogState(t, townRoot, "stale", "plugin:dolt-gc", time.Now().Add(-3*time.Hour))
+
+	d.detectStaleWorkingDogs(mgr, sm, &config.DaemonThresholds{})
+
+	jobs, err := queue.List()
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].State != dog.JobQueued || jobs[0].Dog != "" {
+		t.Fatalf("jobs = %+v, want the stale dog's job back in the queue", jobs)
+	}
+	if jobs[0].NextAttemptAt.Before(time.Now()) {
+		t.Error("requeued job has no retry backoff")
+	}
+}
+
+func TestReconcileDogJobs_CompletesJobsDogsMovedOnFrom(t *testing.T) {
+	townRoot := t.TempDir()
+	d := testHandlerDaemon(t, townRoot)
+
+	rigsConfig := &config.RigsConfig{Version: 1, Rigs: map[string]config.RigEntry{}}
+	mgr := dog.NewManager(townRoot, rigsConfig)
+	queue := mgr.Queue()
+
+	for _, work := range []string{"plugin:busy", "plugin:finished"} {
+		if _, err := queue.Enqueue(dog.Job{Work: work}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := queue.Claim("busy", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queue.Claim("finished", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	testSetupWorkingDogState(t, townRoot, "busy", "plugin:busy", time.Now())
+	testSetupDogState(t, townRoot, "finished", dog.StateIdle, time.Now())
+
+	d.reconcileDogJobs(mgr, queue)
+
+	jobs, _ := queue.List()
+	if len(jobs) != 1 || jobs[0].Dog != "busy" {
+		t.Errorf("jobs = %+v, want only the busy dog's job left running", jobs)
+	}
+}
+
 func TestDetectStaleWorkingDogs_Constants(t *testing.T) {
 	if staleWorkingTimeout != 2*time.Hour {
 		t.Errorf("staleWorkingTimeout = %v, want 2h", staleWorkingTimeout)
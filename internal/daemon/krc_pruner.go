@@ -81,9 +81,11 @@ func (p *KRCPruner) prune() {
 		return
 	}
 
-	if result.EventsPruned > 0 {
-		p.logger("KRC pruned %d events (saved %d bytes) in %v",
+	if result.EventsPruned > 0 || result.ArchiveExpired > 0 {
+		p.logger("KRC pruned %d events (%d archived, %d expired from archive, saved %d bytes) in %v",
 			result.EventsPruned,
+			result.EventsArchived,
+			result.ArchiveExpired,
 			result.BytesBefore-result.BytesAfter,
 			result.Duration.Round(time.Millisecond))
 	}
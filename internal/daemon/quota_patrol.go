@@ -0,0 +1,157 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/quota"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// quotaRotatedPrompt is the beacon given to a session restarted on a
+// fresh account. The previous conversation lives in the old account's
+// config dir, so the agent re-primes from its hook instead of resuming.
+const quotaRotatedPrompt = "Your account was rotated to avoid a rate limit. Run gt prime and continue your hooked work."
+
+// QuotaPatrolConfig holds configuration for the quota patrol.
+// The patrol runs on every daemon heartbeat: it scans agent sessions for
+// rate-limit messages, records limited accounts with their reset times,
+// re-enables accounts once they reset, and rotates limited sessions onto
+// available accounts. It is enabled by default and does nothing until
+// accounts are registered.
+type QuotaPatrolConfig struct {
+	// Enabled controls whether the patrol runs.
+	Enabled bool `json:"enabled"`
+
+	// DryRun, when true, records quota state but never rotates sessions.
+	DryRun bool `json:"dry_run,omitempty"`
+
+	// RotateBusy, when true, rotates limited sessions even if the agent
+	// looks busy. By default only idle sessions are rotated so a session
+	// that is still draining output is not killed mid-tool-call.
+	RotateBusy bool `json:"rotate_busy,omitempty"`
+}
+
+// quotaLogger adapts the daemon logger to quota.Logger.
+type quotaLogger struct{ d *Daemon }
+
+func (l quotaLogger) Warn(format string, args ...interface{}) {
+	l.d.logger.Printf("quota: "+format, args...)
+}
+
+// runQuotaPatrol runs one quota patrol cycle: scan → record → rotate.
+// Towns with no registered accounts are skipped silently.
+func (d *Daemon) runQuotaPatrol() {
+	if !IsPatrolEnabled(d.patrolConfig, "quota") {
+		return
+	}
+	cfg := &QuotaPatrolConfig{Enabled: true}
+	if d.patrolConfig != nil && d.patrolConfig.Patrols != nil && d.patrolConfig.Patrols.Quota != nil {
+		cfg = d.patrolConfig.Patrols.Quota
+	}
+	townRoot := d.config.TownRoot
+
+	acctCfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot))
+	if err != nil || len(acctCfg.Accounts) == 0 {
+		return
+	}
+
+	scanner, err := quota.NewScanner(d.tmux, nil, acctCfg)
+	if err != nil {
+		d.logger.Printf("quota: creating scanner: %v", err)
+		return
+	}
+	mgr := quota.NewManager(townRoot)
+
+	p := &quota.Patrol{
+		Scanner:  scanner,
+		Manager:  mgr,
+		Accounts: acctCfg,
+		DryRun:   cfg.DryRun,
+		Rotator: quota.NewRotator(d.tmux, d.tmux, mgr, acctCfg,
+			d.quotaRestartCommand, quotaLogger{d}, townRoot, "", nil),
+	}
+	if !cfg.RotateBusy {
+		p.IsIdle = d.tmux.IsIdle
+	}
+
+	report, err := p.Run()
+	if err != nil {
+		d.logger.Printf("quota: patrol failed: %v", err)
+		return
+	}
+
+	for _, handle := range report.Cleared {
+		d.logger.Printf("quota: account %s reset, available again", handle)
+		_ = events.LogFeed(events.TypeQuotaReset, "daemon", map[string]interface{}{"account": handle})
+	}
+	for _, handle := range report.NewlyLimited {
+		d.logger.Printf("quota: account %s is rate-limited", handle)
+		_ = events.LogFeed(events.TypeQuotaLimited, "daemon", map[string]interface{}{"account": handle})
+	}
+	for _, res := range report.Results {
+		if res.Error != "" {
+			d.logger.Printf("quota: rotating %s failed: %s", res.Session, res.Error)
+			continue
+		}
+		if !res.Rotated {
+			continue
+		}
+		d.logger.Printf("quota: rotated %s from %s to %s", res.Session, res.OldAccount, res.NewAccount)
+		_ = events.LogFeed(events.TypeQuotaRotated, "daemon", map[string]interface{}{
+			"session": res.Session,
+			"from":    res.OldAccount,
+			"to":      res.NewAccount,
+		})
+	}
+	if report.SkippedBusy > 0 {
+		d.logger.Printf("quota: %d limited session(s) busy, will retry next cycle", report.SkippedBusy)
+	}
+}
+
+// quotaRestartCommand builds the respawn command for a rotated session.
+// Only rig workers (polecats and crew) are rotated automatically; the
+// Rotator prepends the new CLAUDE_CONFIG_DIR export, so AgentEnv is built
+// without a runtime config dir to avoid overriding it.
+func (d *Daemon) quotaRestartCommand(sessionName string) (string, error) {
+	identity, err := session.ParseSessionName(sessionName)
+	if err != nil {
+		return "", fmt.Errorf("parsing session name: %w", err)
+	}
+
+	townRoot := d.config.TownRoot
+	rigPath := filepath.Join(townRoot, identity.Rig)
+	var workDir string
+	switch identity.Role {
+	case session.RolePolecat:
+		// New structure: polecats/<name>/<rigname>/, old: polecats/<name>/
+		workDir = filepath.Join(rigPath, "polecats", identity.Name, identity.Rig)
+		if _, err := os.Stat(workDir); os.IsNotExist(err) {
+			workDir = filepath.Join(rigPath, "polecats", identity.Name)
+		}
+	case session.RoleCrew:
+		workDir = filepath.Join(rigPath, "crew", identity.Name)
+	default:
+		return "", fmt.Errorf("automatic rotation not supported for %s sessions", identity.Role)
+	}
+
+	envVars := config.AgentEnv(config.AgentEnvConfig{
+		Role:      string(identity.Role),
+		Rig:       identity.Rig,
+		AgentName: identity.Name,
+		TownRoot:  townRoot,
+	})
+	// Accounts rotate within a provider, so restart the agent the session
+	// was already running rather than the rig's configured default.
+	agent, _ := d.tmux.GetEnvironment(sessionName, "GT_AGENT")
+	startCmd, err := config.BuildStartupCommandWithAgentOverride(envVars, rigPath, quotaRotatedPrompt, strings.TrimSpace(agent))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("cd %s && %s", config.ShellQuote(workDir), startCmd), nil
+}
@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestIsPatrolEnabled_Quota(t *testing.T) {
+	// Enabled by default: the patrol itself skips towns without accounts.
+	if !IsPatrolEnabled(nil, "quota") {
+		t.Error("quota patrol should be enabled with nil config")
+	}
+	if !IsPatrolEnabled(&DaemonPatrolConfig{Patrols: &PatrolsConfig{}}, "quota") {
+		t.Error("quota patrol should be enabled when not configured")
+	}
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{Quota: &QuotaPatrolConfig{Enabled: false}},
+	}
+	if IsPatrolEnabled(config, "quota") {
+		t.Error("quota patrol should be disabled when turned off in config")
+	}
+}
+
+func TestRunQuotaPatrol_NoAccounts(t *testing.T) {
+	// Default-enabled patrol must be a no-op in towns without accounts.
+	d := &Daemon{config: &Config{TownRoot: t.TempDir()}, tmux: tmux.NewTmux()}
+	d.runQuotaPatrol()
+}
+
+func TestQuotaRestartCommand(t *testing.T) {
+	townRoot := t.TempDir()
+	workDir := filepath.Join(townRoot, "gastown", "polecats", "toast", "gastown")
+	if err := os.MkdirAll(workDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	r := session.NewPrefixRegistry()
+	r.Register("gt", "gastown")
+	oldRegistry := session.DefaultRegistry()
+	session.SetDefaultRegistry(r)
+	defer session.SetDefaultRegistry(oldRegistry)
+
+	d := &Daemon{config: &Config{TownRoot: townRoot}, tmux: tmux.NewTmux()}
+
+	polecat := (&session.AgentIdentity{Role: session.RolePolecat, Rig: "gastown", Name: "toast"}).SessionName()
+	cmd, err := d.quotaRestartCommand(polecat)
+	if err != nil {
+		t.Fatalf("quotaRestartCommand(%s): %v", polecat, err)
+	}
+	if !strings.HasPrefix(cmd, "cd "+config.ShellQuote(workDir)+" && ") {
+		t.Errorf("command should cd into the polecat worktree, got %q", cmd)
+	}
+	if !strings.Contains(cmd, "gt prime") {
+		t.Errorf("command should carry the rotation prompt, got %q", cmd)
+	}
+
+	witness := (&session.AgentIdentity{Role: session.RoleWitness, Rig: "gastown"}).SessionName()
+	if _, err := d.quotaRestartCommand(witness); err == nil {
+		t.Error("witness sessions should not be rotated automatically")
+	}
+}
@@ -0,0 +1,416 @@
+package daemon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/doltclient"
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+const (
+	defaultRestoreDrillInterval = 24 * time.Hour
+	// restoreDrillRestoreTimeout bounds `dolt backup restore` for one database.
+	restoreDrillRestoreTimeout = 10 * time.Minute
+	// restoreDrillStartTimeout is how long to wait for the scratch server to accept connections.
+	restoreDrillStartTimeout = 30 * time.Second
+	// restoreDrillQueryTimeout bounds the per-database verification queries.
+	restoreDrillQueryTimeout = 5 * time.Minute
+	// restoreDrillPortBase is where the scratch server port search starts.
+	// Kept well away from the production port so a drill never collides with it.
+	restoreDrillPortBase = 13307
+)
+
+// RestoreDrillConfig holds configuration for the restore_drill patrol.
+// The drill restores the latest backup of each database into a scratch data
+// dir, serves it from a throwaway dolt sql-server, and compares row counts
+// and checksums against the live server.
+type RestoreDrillConfig struct {
+	// Enabled controls whether restore drills run.
+	Enabled bool `json:"enabled"`
+
+	// IntervalStr is how often to drill, as a string (e.g., "24h").
+	IntervalStr string `json:"interval,omitempty"`
+
+	// Databases lists specific database names to drill.
+	// If empty, auto-discovers databases with configured backup remotes.
+	Databases []string `json:"databases,omitempty"`
+}
+
+// restoreDrillInterval returns the configured drill interval, or the default (24h).
+func restoreDrillInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.RestoreDrill != nil {
+		if config.Patrols.RestoreDrill.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.RestoreDrill.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultRestoreDrillInterval
+}
+
+// tableChecksum is the row count and order-independent content checksum of one table.
+type tableChecksum struct {
+	Rows int64
+	Sum  int64
+}
+
+// drillResult is the outcome of drilling one database.
+type drillResult struct {
+	Database string
+	// Commit is the HEAD commit of the restored backup.
+	Commit string
+	Tables int
+	// RestoreErr is set when the backup could not be restored or served.
+	RestoreErr error
+	// Mismatches lists tables whose restored contents differ from live.
+	Mismatches []string
+	// Unverified is set when the backup restored but could not be compared
+	// (e.g. its commit is no longer in live history after compaction).
+	Unverified string
+}
+
+// runRestoreDrill restores each database's latest backup into a scratch dir
+// and verifies it against live. Unrestorable backups and content mismatches
+// are escalated; everything else is logged and recorded on the molecule.
+func (d *Daemon) runRestoreDrill() {
+	if !IsPatrolEnabled(d.patrolConfig, "restore_drill") {
+		return
+	}
+
+	mol := d.pourDogMolecule(constants.MolDogRestoreDrill, nil)
+	defer mol.close()
+
+	dataDir := d.doltDataDir()
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		d.logger.Printf("restore_drill: data dir %s does not exist, skipping", dataDir)
+		mol.failStep("restore", "data dir does not exist")
+		return
+	}
+
+	databases := d.patrolConfig.Patrols.RestoreDrill.Databases
+	if len(databases) == 0 {
+		databases = d.discoverDatabasesWithBackups(dataDir)
+	}
+	if len(databases) == 0 {
+		d.logger.Printf("restore_drill: no databases with backup remotes found")
+		mol.failStep("restore", "no databases with backup remotes")
+		return
+	}
+
+	d.logger.Printf("restore_drill: drilling %d database(s)", len(databases))
+
+	var results []drillResult
+	for _, db := range databases {
+		results = append(results, d.drillDatabase(dataDir, db))
+	}
+
+	var unrestorable, mismatched, unverified []string
+	for _, r := range results {
+		switch {
+		case r.RestoreErr != nil:
+			d.logger.Printf("restore_drill: %s: UNRESTORABLE: %v", r.Database, r.RestoreErr)
+			d.escalate("restore_drill", fmt.Sprintf("Backup of %s is unrestorable: %v", r.Database, r.RestoreErr))
+			unrestorable = append(unrestorable, r.Database)
+		case len(r.Mismatches) > 0:
+			d.logger.Printf("restore_drill: %s: restored backup differs from live at %s: %s",
+				r.Database, shortCommit(r.Commit), strings.Join(r.Mismatches, "; "))
+			d.escalate("restore_drill", fmt.Sprintf("Restored backup of %s differs from live at %s: %s",
+				r.Database, shortCommit(r.Commit), strings.Join(r.Mismatches, "; ")))
+			mismatched = append(mismatched, r.Database)
+		case r.Unverified != "":
+			d.logger.Printf("restore_drill: %s: restored %d table(s), not verified: %s", r.Database, r.Tables, r.Unverified)
+			unverified = append(unverified, r.Database)
+		default:
+			d.logger.Printf("restore_drill: %s: restored and verified %d table(s) at %s",
+				r.Database, r.Tables, shortCommit(r.Commit))
+		}
+	}
+
+	if len(unrestorable) > 0 {
+		mol.failStep("restore", fmt.Sprintf("unrestorable: %s", strings.Join(unrestorable, ", ")))
+	} else {
+		mol.closeStep("restore")
+	}
+	if len(mismatched) > 0 {
+		mol.failStep("verify", fmt.Sprintf("mismatched: %s", strings.Join(mismatched, ", ")))
+	} else {
+		mol.closeStep("verify")
+	}
+
+	d.logger.Printf("restore_drill: cycle complete — ok=%d unrestorable=%d mismatched=%d unverified=%d",
+		len(results)-len(unrestorable)-len(mismatched)-len(unverified),
+		len(unrestorable), len(mismatched), len(unverified))
+	mol.closeStep("report")
+}
+
+// doltDataDir returns the live Dolt data dir: the managed server's if
+// configured, else the conventional <town>/.dolt-data.
+func (d *Daemon) doltDataDir() string {
+	if d.doltServer != nil && d.doltServer.IsEnabled() && d.doltServer.config.DataDir != "" {
+		return d.doltServer.config.DataDir
+	}
+	return filepath.Join(d.config.TownRoot, ".dolt-data")
+}
+
+// drillDatabase restores one database's backup into a scratch data dir,
+// serves it on a free port, and compares it table by table with live.
+func (d *Daemon) drillDatabase(dataDir, db string) drillResult {
+	result := drillResult{Database: db}
+
+	url, err := backupURL(dataDir, db, db+"-backup")
+	if err != nil {
+		result.RestoreErr = err
+		return result
+	}
+
+	scratch, err := os.MkdirTemp("", "gt-restore-drill-")
+	if err != nil {
+		result.RestoreErr = fmt.Errorf("creating scratch dir: %w", err)
+		return result
+	}
+	defer os.RemoveAll(scratch)
+
+	ctx, cancel := context.WithTimeout(context.Background(), restoreDrillRestoreTimeout)
+	defer cancel()
+	restore := exec.CommandContext(ctx, "dolt", "backup", "restore", url, db)
+	restore.Dir = scratch
+	if output, err := restore.CombinedOutput(); err != nil {
+		result.RestoreErr = fmt.Errorf("dolt backup restore: %v: %s", err, strings.TrimSpace(string(output)))
+		return result
+	}
+
+	port := doltserver.FindFreePort(restoreDrillPortBase)
+	if port == 0 {
+		result.RestoreErr = fmt.Errorf("no free port for scratch server")
+		return result
+	}
+	stop, err := startScratchServer(scratch, port)
+	if err != nil {
+		result.RestoreErr = err
+		return result
+	}
+	defer stop()
+
+	// The scratch server is started by us with dolt's default root user.
+	restored, err := sql.Open("mysql", fmt.Sprintf("root@tcp(127.0.0.1:%d)/%s?timeout=5s&readTimeout=60s", port, db))
+	if err != nil {
+		result.RestoreErr = err
+		return result
+	}
+	defer restored.Close()
+	liveClient, err := d.liveDoltClient(db)
+	if err != nil {
+		result.Unverified = fmt.Sprintf("opening live database: %v", err)
+		return result
+	}
+	live := liveClient.DB()
+
+	qctx, qcancel := context.WithTimeout(context.Background(), restoreDrillQueryTimeout)
+	defer qcancel()
+
+	if err := restored.QueryRowContext(qctx, "SELECT commit_hash FROM dolt_log LIMIT 1").Scan(&result.Commit); err != nil {
+		result.RestoreErr = fmt.Errorf("reading restored HEAD: %w", err)
+		return result
+	}
+	// The restored database is the schema as of the backup's commit; live's
+	// information_schema reflects its current schema, which may have moved on.
+	tables, err := tableColumns(qctx, restored, db)
+	if err != nil {
+		result.RestoreErr = fmt.Errorf("reading restored schema: %w", err)
+		return result
+	}
+	restoredSums, err := tableChecksums(qctx, restored, db, tables, "")
+	if err != nil {
+		result.RestoreErr = fmt.Errorf("reading restored tables: %w", err)
+		return result
+	}
+	result.Tables = len(restoredSums)
+
+	// Compare against live as of the backup's commit, so writes made since
+	// the last backup sync don't count as mismatches.
+	var found int
+	if err := live.QueryRowContext(qctx, "SELECT COUNT(*) FROM dolt_commits WHERE commit_hash = ?", result.Commit).Scan(&found); err != nil {
+		result.Unverified = fmt.Sprintf("reading live history: %v", err)
+		return result
+	}
+	if found == 0 {
+		result.Unverified = fmt.Sprintf("live has no commit %s (history compacted since backup?)", shortCommit(result.Commit))
+		return result
+	}
+	liveSums, err := tableChecksums(qctx, live, db, tables, result.Commit)
+	if err != nil {
+		result.Mismatches = []string{fmt.Sprintf("live at %s: %v", shortCommit(result.Commit), err)}
+		return result
+	}
+	result.Mismatches = compareChecksums(restoredSums, liveSums)
+	return result
+}
+
+// liveDoltClient returns a pooled client for db on the live Dolt server,
+// using the daemon-managed server's host and credentials when it has one.
+// Pooled clients are not closed by callers.
+func (d *Daemon) liveDoltClient(db string) (*doltclient.Client, error) {
+	if d.doltServer != nil && d.doltServer.config != nil {
+		return d.doltServer.sqlClient(db)
+	}
+	return doltserver.Client(d.config.TownRoot, db)
+}
+
+// startScratchServer starts a throwaway dolt sql-server over dataDir and
+// waits until it accepts connections. The returned func stops it.
+func startScratchServer(dataDir string, port int) (func(), error) {
+	cmd := exec.Command("dolt", "sql-server", "--host", "127.0.0.1",
+		"--port", fmt.Sprintf("%d", port), "--data-dir", dataDir)
+	cmd.Dir = dataDir
+	logFile, err := os.Create(filepath.Join(dataDir, "drill-server.log"))
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		return nil, fmt.Errorf("starting scratch server: %w", err)
+	}
+	stop := func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		logFile.Close()
+	}
+
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	deadline := time.Now().Add(restoreDrillStartTimeout)
+	for time.Now().Before(deadline) {
+		if conn, err := net.DialTimeout("tcp", addr, time.Second); err == nil {
+			conn.Close()
+			return stop, nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	stop()
+	return nil, fmt.Errorf("scratch server did not accept connections on %s within %v", addr, restoreDrillStartTimeout)
+}
+
+// backupURL returns the URL of the named backup of db, from `dolt backup -v`.
+func backupURL(dataDir, db, backupName string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "dolt", "backup", "-v")
+	cmd.Dir = filepath.Join(dataDir, db)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("listing backups: %w", err)
+	}
+	url := parseBackupURL(string(output), backupName)
+	if url == "" {
+		return "", fmt.Errorf("no backup named %s", backupName)
+	}
+	return url, nil
+}
+
+// parseBackupURL finds backupName in `dolt backup -v` output ("<name> <url> [params]").
+func parseBackupURL(output, backupName string) string {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == backupName {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// tableChecksums returns the checksum of each of tables (name to columns,
+// from tableColumns) in dbName. With a non-empty asOf, tables are read at
+// that commit.
+func tableChecksums(ctx context.Context, db *sql.DB, dbName string, tables map[string][]string, asOf string) (map[string]tableChecksum, error) {
+	sums := make(map[string]tableChecksum, len(tables))
+	for table, cols := range tables {
+		var sum tableChecksum
+		if err := db.QueryRowContext(ctx, checksumQuery(dbName, table, cols, asOf)).Scan(&sum.Rows, &sum.Sum); err != nil {
+			return nil, fmt.Errorf("checksum %s: %w", table, err)
+		}
+		sums[table] = sum
+	}
+	return sums, nil
+}
+
+// tableColumns maps each user table (excluding dolt system tables) to its
+// columns in ordinal order.
+func tableColumns(ctx context.Context, db *sql.DB, dbName string) (map[string][]string, error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT table_name, column_name FROM information_schema.columns "+
+			"WHERE table_schema = ? AND table_name NOT LIKE 'dolt\\_%' ORDER BY table_name, ordinal_position", dbName)
+	if err != nil {
+		return nil, fmt.Errorf("list columns: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[string][]string)
+	for rows.Next() {
+		var table, col string
+		if err := rows.Scan(&table, &col); err != nil {
+			return nil, err
+		}
+		tables[table] = append(tables[table], col)
+	}
+	return tables, rows.Err()
+}
+
+// checksumQuery builds a query returning (row count, sum of per-row CRC32).
+// The sum is order-independent, so it matches across servers regardless of
+// scan order. NULLs are mapped to a sentinel so they differ from empty strings.
+func checksumQuery(dbName, table string, cols []string, asOf string) string {
+	parts := make([]string, len(cols))
+	for i, c := range cols {
+		parts[i] = fmt.Sprintf("COALESCE(CAST(`%s` AS CHAR), '\\\\N')", c)
+	}
+	from := fmt.Sprintf("`%s`.`%s`", dbName, table)
+	if asOf != "" {
+		from += fmt.Sprintf(" AS OF '%s'", asOf)
+	}
+	return fmt.Sprintf("SELECT COUNT(*), COALESCE(SUM(CRC32(CONCAT_WS('|', %s))), 0) FROM %s",
+		strings.Join(parts, ", "), from)
+}
+
+// compareChecksums lists the differences between restored and live tables.
+func compareChecksums(restored, live map[string]tableChecksum) []string {
+	var mismatches []string
+	for table, r := range restored {
+		l, ok := live[table]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("%s: missing from live", table))
+		case r.Rows != l.Rows:
+			mismatches = append(mismatches, fmt.Sprintf("%s: %d rows restored, %d live", table, r.Rows, l.Rows))
+		case r.Sum != l.Sum:
+			mismatches = append(mismatches, fmt.Sprintf("%s: checksum differs (%d rows)", table, r.Rows))
+		}
+	}
+	for table := range live {
+		if _, ok := restored[table]; !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s: missing from backup", table))
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches
+}
+
+// shortCommit abbreviates a Dolt commit hash for log lines.
+func shortCommit(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
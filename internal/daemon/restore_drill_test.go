@@ -0,0 +1,74 @@
+package daemon
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBackupURL(t *testing.T) {
+	output := "hq-backup file:///town/.dolt-backup/hq {}\n" +
+		"gastown-backup file:///town/.dolt-backup/gastown {}\n"
+	if got := parseBackupURL(output, "gastown-backup"); got != "file:///town/.dolt-backup/gastown" {
+		t.Errorf("parseBackupURL = %q", got)
+	}
+	if got := parseBackupURL(output, "beads-backup"); got != "" {
+		t.Errorf("parseBackupURL(missing) = %q", got)
+	}
+}
+
+func TestCompareChecksums(t *testing.T) {
+	restored := map[string]tableChecksum{
+		"issues":   {Rows: 10, Sum: 100},
+		"labels":   {Rows: 5, Sum: 50},
+		"comments": {Rows: 3, Sum: 30},
+		"events":   {Rows: 1, Sum: 1},
+	}
+	live := map[string]tableChecksum{
+		"issues":   {Rows: 10, Sum: 100},
+		"labels":   {Rows: 6, Sum: 60},
+		"comments": {Rows: 3, Sum: 31},
+		"config":   {Rows: 1, Sum: 1},
+	}
+	want := []string{
+		"comments: checksum differs (3 rows)",
+		"config: missing from backup",
+		"events: missing from live",
+		"labels: 5 rows restored, 6 live",
+	}
+	if got := compareChecksums(restored, live); !reflect.DeepEqual(got, want) {
+		t.Errorf("compareChecksums = %v, want %v", got, want)
+	}
+	if got := compareChecksums(live, live); len(got) != 0 {
+		t.Errorf("identical tables reported mismatches: %v", got)
+	}
+}
+
+func TestChecksumQuery(t *testing.T) {
+	q := checksumQuery("hq", "issues", []string{"id", "title"}, "abc123")
+	for _, want := range []string{"`hq`.`issues` AS OF 'abc123'", "CAST(`id` AS CHAR)", "CAST(`title` AS CHAR)", "COUNT(*)"} {
+		if !strings.Contains(q, want) {
+			t.Errorf("query missing %q: %s", want, q)
+		}
+	}
+	if q := checksumQuery("hq", "issues", []string{"id"}, ""); strings.Contains(q, "AS OF") {
+		t.Errorf("live HEAD query should not use AS OF: %s", q)
+	}
+}
+
+func TestRestoreDrillInterval(t *testing.T) {
+	if got := restoreDrillInterval(nil); got != defaultRestoreDrillInterval {
+		t.Errorf("default interval = %v", got)
+	}
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{RestoreDrill: &RestoreDrillConfig{Enabled: true, IntervalStr: "6h"}}}
+	if got := restoreDrillInterval(config); got != 6*time.Hour {
+		t.Errorf("configured interval = %v", got)
+	}
+	if !IsPatrolEnabled(config, "restore_drill") {
+		t.Error("restore_drill should be enabled")
+	}
+	if IsPatrolEnabled(&DaemonPatrolConfig{}, "restore_drill") {
+		t.Error("restore_drill should be opt-in")
+	}
+}
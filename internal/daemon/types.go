@@ -128,6 +128,8 @@ type PatrolsConfig struct {
 	CompactorDog           *CompactorDogConfig            `json:"compactor_dog,omitempty"`
 	ScheduledMaintenance   *ScheduledMaintenanceConfig    `json:"scheduled_maintenance,omitempty"`
 	RestartTracker         *RestartTrackerConfig          `json:"restart_tracker,omitempty"`
+	Quota                  *QuotaPatrolConfig             `json:"quota,omitempty"`
+	RestoreDrill           *RestoreDrillConfig            `json:"restore_drill,omitempty"`
 }
 
 // DoltRemotesConfig holds configuration for the dolt_remotes patrol.
@@ -287,6 +289,12 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		}
 		return config.Patrols.ScheduledMaintenance.Enabled
 	}
+	if patrol == "restore_drill" {
+		if config == nil || config.Patrols == nil || config.Patrols.RestoreDrill == nil {
+			return false
+		}
+		return config.Patrols.RestoreDrill.Enabled
+	}
 
 	if config == nil || config.Patrols == nil {
 		return true // Default: enabled
@@ -309,6 +317,10 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		if config.Patrols.Handler != nil {
 			return config.Patrols.Handler.Enabled
 		}
+	case "quota":
+		if config.Patrols.Quota != nil {
+			return config.Patrols.Quota.Enabled
+		}
 	}
 	return true // Default: enabled
 }
@@ -241,6 +241,14 @@ func (c *PrefixMismatchCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the rigs.json Fix rewrites.
+func (c *PrefixMismatchCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	return &FixPlan{
+		Summary: "update rigs.json prefixes to match routes.jsonl",
+		Files:   []string{filepath.Join(ctx.TownRoot, "mayor", "rigs.json")},
+	}
+}
+
 // rigsConfigEntry is a local type for loading rigs.json without importing config package
 // to avoid circular dependencies and keep the check self-contained.
 type rigsConfigEntry struct {
@@ -421,6 +429,17 @@ func (c *RoleLabelCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the role beads Fix labels.
+func (c *RoleLabelCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingLabel) == 0 {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "add gt:role label to role beads",
+		Beads:   append([]string(nil), c.missingLabel...),
+	}
+}
+
 // DatabasePrefixCheck detects when a rig's database has a different issue_prefix
 // than what routes.jsonl specifies. This can happen when:
 // - The database was initialized with a different prefix
@@ -578,3 +597,15 @@ func (c *DatabasePrefixCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix describes the beads config changes Fix makes.
+func (c *DatabasePrefixCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.mismatches) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "set database issue_prefix to match routes.jsonl"}
+	for _, m := range c.mismatches {
+		plan.Actions = append(plan.Actions, "bd config set issue_prefix "+m.routesPrefix+" in "+m.rigPath)
+	}
+	return plan
+}
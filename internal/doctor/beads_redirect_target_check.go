@@ -226,6 +226,21 @@ func (c *BeadsRedirectTargetCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the redirect and config.yaml files Fix may rewrite.
+func (c *BeadsRedirectTargetCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.brokenTargets) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "repair broken beads redirects"}
+	for _, bt := range c.brokenTargets {
+		if strings.Contains(bt.reason, "no beads setup") && dirExists(bt.resolvedPath) {
+			plan.Files = append(plan.Files, filepath.Join(bt.resolvedPath, "config.yaml"))
+		}
+		plan.Files = append(plan.Files, filepath.Join(bt.worktreePath, ".beads", "redirect"))
+	}
+	return plan
+}
+
 // extractRigName derives the rig name from a worktree path within a town.
 // For example, "/town/myrig/refinery/rig" returns "myrig".
 func extractRigName(townRoot, worktreePath string) string {
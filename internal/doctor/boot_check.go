@@ -3,6 +3,7 @@ package doctor
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/boot"
@@ -43,6 +44,17 @@ func (c *BootHealthCheck) Fix(ctx *CheckContext) error {
 	return b.EnsureDir()
 }
 
+// PlanFix describes the boot directory Fix creates.
+func (c *BootHealthCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if !c.missingDir {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "create boot directory",
+		Actions: []string{"mkdir " + filepath.Join(ctx.TownRoot, "deacon", "dogs", "boot")},
+	}
+}
+
 // Run checks Boot health: directory, session, status, and marker freshness.
 func (c *BootHealthCheck) Run(ctx *CheckContext) *CheckResult {
 	b := boot.New(ctx.TownRoot)
@@ -117,6 +117,18 @@ func (c *BranchCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the directories Fix switches back to their expected branch.
+func (c *BranchCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.offMainDirs) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "switch role directories back to their default branch"}
+	for _, dir := range c.offMainDirs {
+		plan.Actions = append(plan.Actions, "git checkout "+c.expectedBranch(ctx.TownRoot, dir)+" && git pull --rebase in "+dir)
+	}
+	return plan
+}
+
 // checkoutWithWorktreeRetry attempts git checkout, and if it fails because the
 // branch is already checked out in another worktree (typically .repo.git), it
 // detaches that worktree's HEAD to free the branch and retries.
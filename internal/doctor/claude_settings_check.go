@@ -648,14 +648,21 @@ func (c *ClaudeSettingsCheck) hookHasPattern(hooks map[string]any, hookName, pat
 	return false
 }
 
-// Fix deletes stale settings files. Agents auto-install correct settings on restart.
-// Files with local modifications are skipped to avoid losing user changes.
-func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
-	var errors []string
-	var skipped []string
-	var needsRestart bool
-	t := tmux.NewTmux()
+// settingsFix is a stale settings file Fix deletes, and where it recreates
+// settings for the role afterwards.
+type settingsFix struct {
+	staleSettingsInfo
+	townRoot    bool // Town-root file: settings move to mayor/, not back here
+	recreate    bool // Whether Fix recreates settings after deleting the file
+	settingsDir string
+	workDir     string
+	rigPath     string
+}
 
+// settingsFixes selects the stale files Fix deletes and reports the tracked
+// ones it leaves for manual review. PlanFix and Fix share it so the journal
+// snapshots exactly the files Fix touches.
+func (c *ClaudeSettingsCheck) settingsFixes(townRoot string) (fixes []settingsFix, skipped []string) {
 	for _, sf := range c.staleSettings {
 		// Skip files that aren't stale (correct settings.json files)
 		if !sf.wrongLocation && len(sf.missing) == 0 {
@@ -678,6 +685,40 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 			continue
 		}
 
+		fix := settingsFix{staleSettingsInfo: sf, recreate: true}
+		// Town-root files (settings.json and settings.local.json) pollute ALL
+		// agents via directory traversal; .claude/ ones are recreated under
+		// mayor/ instead of at the root.
+		if sf.agentType == "mayor" && !strings.Contains(sf.path, "/mayor/") {
+			fix.townRoot = true
+			fix.recreate = strings.HasSuffix(filepath.Dir(sf.path), ".claude")
+			mayorDir := filepath.Join(townRoot, "mayor")
+			fix.settingsDir, fix.workDir, fix.rigPath = mayorDir, mayorDir, mayorDir
+		} else {
+			fix.settingsDir, fix.workDir, fix.rigPath = claudeSettingsTarget(townRoot, sf)
+		}
+		fixes = append(fixes, fix)
+	}
+	return fixes, skipped
+}
+
+// restartsSession reports whether Fix cycles the fixed agent's session when
+// --restart-sessions is set. Crew and polecats are spawned on-demand and
+// won't auto-restart anyway.
+func (f settingsFix) restartsSession() bool {
+	return !f.townRoot && (f.agentType == "witness" || f.agentType == "refinery" ||
+		f.agentType == "deacon" || f.agentType == "mayor")
+}
+
+// Fix deletes stale settings files. Agents auto-install correct settings on restart.
+// Files with local modifications are skipped to avoid losing user changes.
+func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
+	var errors []string
+	var needsRestart bool
+	t := tmux.NewTmux()
+
+	fixes, skipped := c.settingsFixes(ctx.TownRoot)
+	for _, sf := range fixes {
 		// Delete the stale settings file
 		if err := os.Remove(sf.path); err != nil {
 			errors = append(errors, fmt.Sprintf("failed to delete %s: %v", sf.path, err))
@@ -686,27 +727,20 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 		fmt.Printf("  Deleted stale: %s\n", sf.path)
 		needsRestart = true
 
-		claudeDir := filepath.Dir(sf.path)
-
 		// Only remove the parent .claude directory for wrong-location files.
 		// For correct-location stale files, the directory is the RIGHT place —
 		// removing it creates a race window where the daemon could recreate
 		// settings before the fix does (gt-99u).
 		if sf.wrongLocation {
-			_ = os.Remove(claudeDir) // Best-effort, will fail if not empty
+			_ = os.Remove(filepath.Dir(sf.path)) // Best-effort, will fail if not empty
 		}
 
 		// Handle town-root files: redirect to mayor/ instead of recreating at root.
-		// Town-root settings pollute ALL agents via directory traversal.
-		// This handles both settings.json and settings.local.json at the town root.
-		if sf.agentType == "mayor" && !strings.Contains(sf.path, "/mayor/") {
-			mayorDir := filepath.Join(ctx.TownRoot, "mayor")
-
-			if strings.HasSuffix(claudeDir, ".claude") {
-				// Town-root .claude/settings{.local}.json → recreate at mayor/.claude/
-				if err := os.MkdirAll(mayorDir, 0755); err == nil {
-					runtimeConfig := config.ResolveRoleAgentConfig("mayor", ctx.TownRoot, mayorDir)
-					_ = runtime.EnsureSettingsForRole(mayorDir, mayorDir, "mayor", runtimeConfig)
+		if sf.townRoot {
+			if sf.recreate {
+				if err := os.MkdirAll(sf.settingsDir, 0755); err == nil {
+					runtimeConfig := config.ResolveRoleAgentConfig("mayor", ctx.TownRoot, sf.rigPath)
+					_ = runtime.EnsureSettingsForRole(sf.settingsDir, sf.workDir, "mayor", runtimeConfig)
 				}
 			}
 
@@ -719,40 +753,20 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 		}
 
 		// Recreate settings at the correct location using EnsureSettingsForRole.
-		// For rig roles, compute settingsDir from role+rig path.
-		// For town-level roles (mayor/deacon), settingsDir == workDir.
-		settingsDir := filepath.Dir(claudeDir)
-		workDir := settingsDir
-		rigPath := ""
-		if sf.rigName != "" {
-			rigPath = filepath.Join(ctx.TownRoot, sf.rigName)
-			sd := config.RoleSettingsDir(sf.agentType, rigPath)
-			if sd != "" {
-				settingsDir = sd
-				// Use settingsDir as workDir too — the fix is about recreating settings
-				// at the correct location, not provisioning slash commands. The actual
-				// worktree workDir will be set correctly on agent restart.
-				workDir = sd
-			}
-		}
-		runtimeConfig := config.ResolveRoleAgentConfig(sf.agentType, ctx.TownRoot, rigPath)
-		if err := runtime.EnsureSettingsForRole(settingsDir, workDir, sf.agentType, runtimeConfig); err != nil {
+		runtimeConfig := config.ResolveRoleAgentConfig(sf.agentType, ctx.TownRoot, sf.rigPath)
+		if err := runtime.EnsureSettingsForRole(sf.settingsDir, sf.workDir, sf.agentType, runtimeConfig); err != nil {
 			errors = append(errors, fmt.Sprintf("failed to recreate settings for %s: %v", sf.path, err))
 			continue
 		}
 
 		// Only cycle patrol roles if --restart-sessions was explicitly passed.
 		// This prevents unexpected session restarts during routine --fix operations.
-		// Crew and polecats are spawned on-demand and won't auto-restart anyway.
-		if ctx.RestartSessions {
-			if sf.agentType == "witness" || sf.agentType == "refinery" ||
-				sf.agentType == "deacon" || sf.agentType == "mayor" {
-				running, _ := t.HasSession(sf.sessionName)
-				if running {
-					// Cycle the agent by killing and letting gt up restart it.
-					// Use KillSessionWithProcesses to ensure all descendant processes are killed.
-					_ = t.KillSessionWithProcesses(sf.sessionName)
-				}
+		if ctx.RestartSessions && sf.restartsSession() {
+			running, _ := t.HasSession(sf.sessionName)
+			if running {
+				// Cycle the agent by killing and letting gt up restart it.
+				// Use KillSessionWithProcesses to ensure all descendant processes are killed.
+				_ = t.KillSessionWithProcesses(sf.sessionName)
 			}
 		}
 	}
@@ -777,6 +791,56 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// claudeSettingsTarget returns where Fix recreates settings for a stale file.
+// For rig roles, settingsDir is computed from role+rig path. For town-level
+// roles (mayor/deacon), settingsDir == workDir.
+func claudeSettingsTarget(townRoot string, sf staleSettingsInfo) (settingsDir, workDir, rigPath string) {
+	settingsDir = filepath.Dir(filepath.Dir(sf.path))
+	workDir = settingsDir
+	if sf.rigName != "" {
+		rigPath = filepath.Join(townRoot, sf.rigName)
+		if sd := config.RoleSettingsDir(sf.agentType, rigPath); sd != "" {
+			settingsDir = sd
+			// Use settingsDir as workDir too — the fix is about recreating settings
+			// at the correct location, not provisioning slash commands. The actual
+			// worktree workDir will be set correctly on agent restart.
+			workDir = sd
+		}
+	}
+	return settingsDir, workDir, rigPath
+}
+
+// PlanFix lists the stale settings files Fix would delete and the files it
+// would recreate from templates.
+func (c *ClaudeSettingsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	plan := &FixPlan{}
+	seen := make(map[string]bool)
+	addFile := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			plan.Files = append(plan.Files, path)
+		}
+	}
+	fixes, _ := c.settingsFixes(ctx.TownRoot)
+	for _, sf := range fixes {
+		addFile(sf.path)
+		if sf.recreate {
+			rc := config.ResolveRoleAgentConfig(sf.agentType, ctx.TownRoot, sf.rigPath)
+			if rc != nil && rc.Hooks != nil && rc.Hooks.SettingsFile != "" {
+				addFile(filepath.Join(sf.settingsDir, rc.Hooks.Dir, rc.Hooks.SettingsFile))
+			}
+		}
+		if ctx.RestartSessions && sf.restartsSession() {
+			plan.Actions = append(plan.Actions, "restart session "+sf.sessionName+" if running")
+		}
+	}
+	if len(plan.Files) == 0 {
+		return nil
+	}
+	plan.Summary = fmt.Sprintf("delete %d stale settings file(s) and recreate them from templates", len(fixes))
+	return plan
+}
+
 // fileExists checks if a file exists.
 func fileExists(path string) bool {
 	info, err := os.Stat(path)
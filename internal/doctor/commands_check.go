@@ -2,6 +2,7 @@ package doctor
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/templates"
@@ -66,3 +67,15 @@ func (c *CommandsCheck) Fix(ctx *CheckContext) error {
 
 	return templates.ProvisionCommands(c.townRoot)
 }
+
+// PlanFix lists the slash command files Fix provisions.
+func (c *CommandsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingCommands) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "provision missing slash commands"}
+	for _, name := range c.missingCommands {
+		plan.Files = append(plan.Files, filepath.Join(c.townRoot, ".claude", "commands", name+".md"))
+	}
+	return plan
+}
@@ -96,6 +96,18 @@ func (c *SettingsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the settings/ directories Fix creates.
+func (c *SettingsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingSettings) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "create missing rig settings/ directories"}
+	for _, path := range c.missingSettings {
+		plan.Actions = append(plan.Actions, "mkdir "+path)
+	}
+	return plan
+}
+
 // RuntimeGitignoreCheck verifies .runtime/ is gitignored at town and rig levels.
 type RuntimeGitignoreCheck struct {
 	BaseCheck
@@ -262,6 +274,18 @@ func (c *LegacyGastownCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the legacy directories Fix removes.
+func (c *LegacyGastownCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.legacyDirs) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "remove legacy .gastown/ directories"}
+	for _, dir := range c.legacyDirs {
+		plan.Actions = append(plan.Actions, "rm -r "+dir)
+	}
+	return plan
+}
+
 // findRigs returns rig directories within the town.
 func (c *LegacyGastownCheck) findRigs(townRoot string) []string {
 	return findAllRigs(townRoot)
@@ -345,6 +369,17 @@ func (c *SessionHookCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the settings.json files Fix rewrites.
+func (c *SessionHookCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.filesToFix) == 0 {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "switch settings.json hooks to 'gt prime --hook'",
+		Files:   append([]string(nil), c.filesToFix...),
+	}
+}
+
 // fixSettingsFile updates a single settings.json file.
 func (c *SessionHookCheck) fixSettingsFile(path string) error {
 	// Read file
@@ -725,3 +760,14 @@ func (c *CustomTypesCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix describes the beads config change Fix makes.
+func (c *CustomTypesCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingTypes) == 0 {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "register custom types: " + strings.Join(c.missingTypes, ", "),
+		Actions: []string{"bd config set types.custom " + constants.BeadsCustomTypes + " in " + c.townRoot},
+	}
+}
@@ -259,6 +259,9 @@ func TestSessionHookCheck_Fix(t *testing.T) {
 		if result.Status != StatusWarning {
 			t.Errorf("expected StatusWarning before fix, got %v", result.Status)
 		}
+		if plan := check.PlanFix(ctx); plan == nil || len(plan.Files) != 1 || plan.Files[0] != settingsPath {
+			t.Errorf("PlanFix() = %+v, want %s", plan, settingsPath)
+		}
 
 		// Apply fix
 		if err := check.Fix(ctx); err != nil {
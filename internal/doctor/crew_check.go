@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/worktree"
 )
 
 // CrewStateCheck validates crew worker state.json files for completeness.
@@ -173,6 +174,18 @@ func (c *CrewStateCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the crew state.json files Fix rewrites.
+func (c *CrewStateCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.invalidCrews) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "rewrite invalid crew state files"}
+	for _, ic := range c.invalidCrews {
+		plan.Files = append(plan.Files, ic.stateFile)
+	}
+	return plan
+}
+
 type crewDir struct {
 	path     string
 	rigName  string
@@ -216,21 +229,15 @@ func (c *CrewStateCheck) findAllCrewDirs(townRoot string) []crewDir {
 	return dirs
 }
 
-// CrewWorktreeCheck detects stale cross-rig worktrees in crew directories.
-// Cross-rig worktrees are created by `gt worktree <rig>` and live in crew/
-// with names like `<source-rig>-<crewname>`. They should be cleaned up when
-// no longer needed to avoid confusion with regular crew workspaces.
+// CrewWorktreeCheck detects cross-rig worktrees that have outlived their
+// purpose. Cross-rig worktrees are created by `gt worktree <rig>` and live in
+// crew/ with names like `<source-rig>-<crewname>`; they are tracked in the
+// town's worktree registry (mayor/worktrees.json). A worktree is flagged when
+// its owner or source rig is gone (orphaned), when it has had no commits for
+// a long time (stale), or when it is registered but missing from disk.
 type CrewWorktreeCheck struct {
 	FixableCheck
-	staleWorktrees []staleWorktree
-}
-
-type staleWorktree struct {
-	path      string
-	rigName   string
-	name      string
-	sourceRig string
-	crewName  string
+	flagged []*worktree.Entry // Cached during Run for use in Fix
 }
 
 // NewCrewWorktreeCheck creates a new crew worktree check.
@@ -239,128 +246,95 @@ func NewCrewWorktreeCheck() *CrewWorktreeCheck {
 		FixableCheck: FixableCheck{
 			BaseCheck: BaseCheck{
 				CheckName:        "crew-worktrees",
-				CheckDescription: "Detect stale cross-rig worktrees in crew directories",
+				CheckDescription: "Detect orphaned and stale cross-rig worktrees",
 				CheckCategory:    CategoryCleanup,
 			},
 		},
 	}
 }
 
-// Run checks for cross-rig worktrees that may need cleanup.
+// Run checks registered and on-disk cross-rig worktrees for ones to reclaim.
 func (c *CrewWorktreeCheck) Run(ctx *CheckContext) *CheckResult {
-	c.staleWorktrees = nil
+	c.flagged = nil
 
-	worktrees := c.findCrewWorktrees(ctx.TownRoot)
-	if len(worktrees) == 0 {
+	rigs, _ := discoverRigs(ctx.TownRoot)
+	reg := worktree.NewRegistry(ctx.TownRoot)
+	entries, err := reg.Scan(rigs)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not read cross-rig worktree registry",
+			Details: []string{err.Error()},
+		}
+	}
+	if len(entries) == 0 {
 		return &CheckResult{
 			Name:    c.Name(),
 			Status:  StatusOK,
-			Message: "No cross-rig worktrees in crew directories",
+			Message: "No cross-rig worktrees",
 		}
 	}
 
-	c.staleWorktrees = worktrees
+	now := time.Now()
+	beadClosed := worktree.BeadClosed(ctx.TownRoot)
 	var details []string
-	for _, wt := range worktrees {
-		details = append(details, fmt.Sprintf("%s/crew/%s (from %s/crew/%s)",
-			wt.rigName, wt.name, wt.sourceRig, wt.crewName))
+	for _, e := range entries {
+		a := reg.Assess(e, now, worktree.DefaultStaleAfter, beadClosed)
+		if !a.Flagged() {
+			continue
+		}
+		c.flagged = append(c.flagged, e)
+		detail := fmt.Sprintf("%s (%s): %s", e.ID, a.Health, a.Reason)
+		if e.Dirty || e.Unpushed > 0 {
+			detail += " - has unpushed work"
+		}
+		details = append(details, detail)
+	}
+
+	if len(c.flagged) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d cross-rig worktree(s), none orphaned or stale", len(entries)),
+		}
 	}
 
 	return &CheckResult{
 		Name:    c.Name(),
 		Status:  StatusWarning,
-		Message: fmt.Sprintf("%d cross-rig worktree(s) in crew directories", len(worktrees)),
+		Message: fmt.Sprintf("%d of %d cross-rig worktree(s) orphaned or stale", len(c.flagged), len(entries)),
 		Details: details,
-		FixHint: "Run 'gt doctor --fix' to remove, or use 'gt crew remove <name> --purge'",
+		FixHint: "Run 'gt doctor --fix' or 'gt worktree reclaim --flagged' (unpushed work goes to a rescue/ branch)",
 	}
 }
 
-// Fix removes stale cross-rig worktrees.
+// Fix reclaims flagged worktrees. Uncommitted and unpushed work is pushed
+// to a rescue branch first; a worktree whose rescue push fails is kept.
 func (c *CrewWorktreeCheck) Fix(ctx *CheckContext) error {
-	if len(c.staleWorktrees) == 0 {
-		return nil
-	}
-
+	reg := worktree.NewRegistry(ctx.TownRoot)
 	var lastErr error
-	for _, wt := range c.staleWorktrees {
-		// Use git worktree remove to properly clean up
-		mayorRigPath := filepath.Join(ctx.TownRoot, wt.rigName, "mayor", "rig")
-		removeCmd := exec.Command("git", "worktree", "remove", "--force", wt.path)
-		removeCmd.Dir = mayorRigPath
-		if output, err := removeCmd.CombinedOutput(); err != nil {
-			lastErr = fmt.Errorf("%s/crew/%s: %v (%s)", wt.rigName, wt.name, err, strings.TrimSpace(string(output)))
+	for _, e := range c.flagged {
+		if _, err := reg.Reclaim(e, false); err != nil {
+			lastErr = err
 		}
 	}
-
 	return lastErr
 }
 
-// findCrewWorktrees finds cross-rig worktrees in crew directories.
-// These are worktrees with hyphenated names (e.g., "beads-dave") that
-// indicate they were created via `gt worktree` for cross-rig work.
-func (c *CrewWorktreeCheck) findCrewWorktrees(townRoot string) []staleWorktree {
-	var worktrees []staleWorktree
-
-	entries, err := os.ReadDir(townRoot)
-	if err != nil {
-		return worktrees
+// PlanFix lists the worktrees Fix reclaims. Removing a worktree and pushing
+// rescue branches can't be rolled back.
+func (c *CrewWorktreeCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.flagged) == 0 {
+		return nil
 	}
-
-	for _, entry := range entries {
-		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || entry.Name() == "mayor" {
-			continue
-		}
-
-		rigName := entry.Name()
-		crewPath := filepath.Join(townRoot, rigName, "crew")
-
-		crewEntries, err := os.ReadDir(crewPath)
-		if err != nil {
-			continue
-		}
-
-		for _, crew := range crewEntries {
-			if !crew.IsDir() || strings.HasPrefix(crew.Name(), ".") {
-				continue
-			}
-
-			name := crew.Name()
-			path := filepath.Join(crewPath, name)
-
-			// Check if it's a worktree (has .git file, not directory)
-			gitPath := filepath.Join(path, ".git")
-			info, err := os.Stat(gitPath)
-			if err != nil || info.IsDir() {
-				// Not a worktree (regular clone or error)
-				continue
-			}
-
-			// Check for hyphenated name pattern: <source-rig>-<crewname>
-			// This indicates a cross-rig worktree created by `gt worktree`
-			parts := strings.SplitN(name, "-", 2)
-			if len(parts) != 2 {
-				// Not a cross-rig worktree pattern
-				continue
-			}
-
-			sourceRig := parts[0]
-			crewName := parts[1]
-
-			// Verify the source rig exists (sanity check)
-			sourceRigPath := filepath.Join(townRoot, sourceRig)
-			if _, err := os.Stat(sourceRigPath); os.IsNotExist(err) {
-				// Source rig doesn't exist - definitely stale
-			}
-
-			worktrees = append(worktrees, staleWorktree{
-				path:      path,
-				rigName:   rigName,
-				name:      name,
-				sourceRig: sourceRig,
-				crewName:  crewName,
-			})
+	plan := &FixPlan{Summary: "reclaim orphaned and stale cross-rig worktrees"}
+	for _, e := range c.flagged {
+		action := "remove worktree " + e.ID
+		if e.Dirty || e.Unpushed > 0 {
+			action += " (unpushed work pushed to a rescue branch)"
 		}
+		plan.Actions = append(plan.Actions, action)
 	}
-
-	return worktrees
+	return plan
 }
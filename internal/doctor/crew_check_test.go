@@ -0,0 +1,89 @@
+package doctor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/worktree"
+)
+
+func TestCrewWorktreeCheck_NoWorktrees(t *testing.T) {
+	check := NewCrewWorktreeCheck()
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK with no worktrees, got %v: %s", result.Status, result.Message)
+	}
+	if plan := check.PlanFix(&CheckContext{}); plan != nil {
+		t.Errorf("expected no fix plan, got %+v", plan)
+	}
+}
+
+func TestCrewWorktreeCheck_HealthyWorktree(t *testing.T) {
+	townRoot := t.TempDir()
+	rigGit := filepath.Join(townRoot, "beads", "mayor", "rig")
+	if err := os.MkdirAll(rigGit, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "initial"},
+		{"worktree", "add", "-b", "gastown-joe", filepath.Join(townRoot, "beads", "crew", "gastown-joe")},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = rigGit
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(townRoot, "gastown", "crew", "joe"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	reg := worktree.NewRegistry(townRoot)
+	if _, err := reg.Register(reg.NewEntry("beads", "gastown", "joe")); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewCrewWorktreeCheck()
+	result := check.Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK for a healthy worktree, got %v: %s %v", result.Status, result.Message, result.Details)
+	}
+}
+
+func TestCrewWorktreeCheck_FixReclaimsMissingWorktree(t *testing.T) {
+	townRoot := t.TempDir()
+	reg := worktree.NewRegistry(townRoot)
+	if _, err := reg.Register(reg.NewEntry("beads", "gastown", "joe")); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewCrewWorktreeCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning for a missing worktree, got %v: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 1 || !strings.Contains(result.Details[0], "beads/crew/gastown-joe (missing)") {
+		t.Errorf("unexpected details: %v", result.Details)
+	}
+
+	plan := check.PlanFix(ctx)
+	if plan == nil || len(plan.Actions) != 1 || plan.Reversible() {
+		t.Fatalf("expected one irreversible reclaim action, got %+v", plan)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	if entries, _ := reg.List(); len(entries) != 0 {
+		t.Errorf("registry has %d entries after Fix, want 0", len(entries))
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("expected StatusOK after Fix, got %v: %s", result.Status, result.Message)
+	}
+}
@@ -86,6 +86,18 @@ func (c *DeprecatedMergeQueueKeysCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the rig settings files Fix rewrites.
+func (c *DeprecatedMergeQueueKeysCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.affectedFiles) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "remove deprecated merge_queue keys"}
+	for path := range c.affectedFiles {
+		plan.Files = append(plan.Files, path)
+	}
+	return plan
+}
+
 // findDeprecatedKeys reads a settings file and returns any deprecated merge_queue keys found.
 func findDeprecatedKeys(path string) []string {
 	data, err := os.ReadFile(path)
@@ -11,7 +11,8 @@ import (
 
 // Doctor manages and executes health checks.
 type Doctor struct {
-	checks []Check
+	checks  []Check
+	journal *Journal
 }
 
 // NewDoctor creates a new Doctor with no registered checks.
@@ -31,6 +32,12 @@ func (d *Doctor) RegisterAll(checks ...Check) {
 	d.checks = append(d.checks, checks...)
 }
 
+// SetJournal records every fix applied by Fix and FixStreaming in j, so the
+// run can later be undone with Rollback.
+func (d *Doctor) SetJournal(j *Journal) {
+	d.journal = j
+}
+
 // Checks returns the list of registered checks.
 func (d *Doctor) Checks() []Check {
 	return d.checks
@@ -163,7 +170,21 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 				fmt.Fprintf(w, "%s", ui.RenderMuted(" (fixing)..."))
 			}
 
+			var pending *pendingFix
+			if d.journal != nil {
+				plan := planFix(check, ctx, result)
+				if plan == nil {
+					// The check planned no change but Fix runs anyway;
+					// record whatever it does as undeclared.
+					plan = undeclaredPlan(result)
+				}
+				pending = d.journal.begin(plan)
+			}
 			err := safeFixCheck(check, ctx)
+			var journalErr error
+			if pending != nil {
+				journalErr = d.journal.finish(pending, err)
+			}
 			if err == nil {
 				// Re-run check to verify fix worked
 				result = check.Run(ctx)
@@ -186,6 +207,9 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 				// Fix failed, add error to details
 				result.Details = append(result.Details, "Fix failed: "+err.Error())
 			}
+			if journalErr != nil {
+				result.Details = append(result.Details, "Journal write failed: "+journalErr.Error())
+			}
 		}
 
 		// Record total elapsed time including any fix attempts
@@ -2,6 +2,7 @@ package doctor
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/formula"
@@ -128,3 +129,35 @@ func (c *FormulaCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the formula files Fix installs or updates.
+func (c *FormulaCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	files := formulaFixFiles(ctx.TownRoot, "outdated", "missing", "new", "untracked")
+	if len(files) == 0 {
+		return nil
+	}
+	return &FixPlan{Summary: "install and update embedded formulas", Files: files}
+}
+
+// formulaFixFiles returns the formula files under beadsPath whose health
+// status is one of statuses, plus the installed record that tracks them.
+func formulaFixFiles(beadsPath string, statuses ...string) []string {
+	report, err := formula.CheckFormulaHealth(beadsPath)
+	if err != nil {
+		return nil
+	}
+	formulasDir := filepath.Join(beadsPath, ".beads", "formulas")
+	var files []string
+	for _, f := range report.Formulas {
+		for _, s := range statuses {
+			if f.Status == s {
+				files = append(files, filepath.Join(formulasDir, f.Name))
+				break
+			}
+		}
+	}
+	if len(files) > 0 {
+		files = append(files, filepath.Join(formulasDir, ".installed.json"))
+	}
+	return files
+}
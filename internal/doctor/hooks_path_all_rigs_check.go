@@ -97,6 +97,18 @@ func (c *HooksPathAllRigsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the clones whose git config Fix updates.
+func (c *HooksPathAllRigsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.unconfiguredClones) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "set core.hooksPath=.githooks"}
+	for _, clonePath := range c.unconfiguredClones {
+		plan.Actions = append(plan.Actions, "git -C "+clonePath+" config core.hooksPath .githooks")
+	}
+	return plan
+}
+
 // findRigClones returns all git clone paths within a rig.
 func findRigClones(rigPath string) []string {
 	var clones []string
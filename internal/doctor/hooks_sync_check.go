@@ -140,3 +140,15 @@ func (c *HooksSyncCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the settings files Fix rewrites.
+func (c *HooksSyncCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.outOfSync) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "sync hooks in settings files with their computed configuration"}
+	for _, target := range c.outOfSync {
+		plan.Files = append(plan.Files, target.Path)
+	}
+	return plan
+}
@@ -0,0 +1,324 @@
+package doctor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Journal records what each fix in a gt doctor --fix run changed, so that
+// gt doctor --rollback <run-id> can restore the files afterwards. Files are
+// snapshotted from the check's FixPlan before the fix runs; fixes without a
+// plan are recorded as irreversible.
+//
+// Stored at <townRoot>/.runtime/doctor/journal/<run-id>/journal.json, with
+// the original file contents alongside it.
+type Journal struct {
+	RunID        string          `json:"run_id"`
+	StartedAt    time.Time       `json:"started_at"`
+	RolledBackAt *time.Time      `json:"rolled_back_at,omitempty"`
+	Entries      []*JournalEntry `json:"entries"`
+
+	dir   string
+	blobs int
+}
+
+// JournalEntry records one applied fix.
+type JournalEntry struct {
+	Check      string       `json:"check"`
+	Reversible bool         `json:"reversible"`
+	Error      string       `json:"error,omitempty"`
+	Files      []FileRecord `json:"files,omitempty"`
+	Beads      []string     `json:"beads,omitempty"`
+	Actions    []string     `json:"actions,omitempty"`
+}
+
+// FileRecord is the before/after state of one file touched by a fix.
+// An empty SHA means the file did not exist.
+type FileRecord struct {
+	Path      string      `json:"path"`
+	Mode      os.FileMode `json:"mode,omitempty"`
+	Backup    string      `json:"backup,omitempty"` // Original content, relative to the run dir
+	BeforeSHA string      `json:"before_sha256,omitempty"`
+	AfterSHA  string      `json:"after_sha256,omitempty"`
+}
+
+// pendingFix holds the snapshots taken before a fix runs.
+type pendingFix struct {
+	plan       *FixPlan
+	files      []FileRecord
+	reversible bool
+}
+
+// JournalRoot returns the directory holding all doctor fix journals.
+func JournalRoot(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "doctor", "journal")
+}
+
+// NewJournal starts a journal for a fix run. Nothing is written until the
+// first fix runs, so runs that fix nothing leave no journal behind.
+func NewJournal(townRoot string) *Journal {
+	now := time.Now().UTC()
+	root := JournalRoot(townRoot)
+	runID := now.Format("20060102-150405")
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(root, runID)); os.IsNotExist(err) {
+			break
+		}
+		runID = now.Format("20060102-150405") + "-" + strconv.Itoa(i)
+	}
+	return &Journal{
+		RunID:     runID,
+		StartedAt: now,
+		Entries:   make([]*JournalEntry, 0),
+		dir:       filepath.Join(root, runID),
+	}
+}
+
+// begin snapshots every file in the plan before the fix runs.
+func (j *Journal) begin(plan *FixPlan) *pendingFix {
+	p := &pendingFix{plan: plan, reversible: plan.Reversible()}
+	for _, path := range plan.Files {
+		rec, err := j.snapshot(path)
+		if err != nil {
+			// A file we cannot snapshot cannot be restored.
+			p.reversible = false
+			continue
+		}
+		p.files = append(p.files, rec)
+	}
+	return p
+}
+
+// snapshot copies path into the journal and returns its record.
+func (j *Journal) snapshot(path string) (FileRecord, error) {
+	rec := FileRecord{Path: path}
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return rec, nil
+	}
+	if err != nil {
+		return rec, err
+	}
+	if !info.Mode().IsRegular() {
+		return rec, fmt.Errorf("%s is not a regular file", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rec, err
+	}
+	if err := os.MkdirAll(filepath.Join(j.dir, "files"), 0755); err != nil {
+		return rec, err
+	}
+	j.blobs++
+	rec.Backup = filepath.Join("files", strconv.Itoa(j.blobs))
+	if err := os.WriteFile(filepath.Join(j.dir, rec.Backup), data, 0600); err != nil {
+		return rec, err
+	}
+	rec.Mode = info.Mode().Perm()
+	rec.BeforeSHA = sha256Hex(data)
+	return rec, nil
+}
+
+// finish records the outcome of a fix and saves the journal. Files the fix
+// left unchanged are dropped from the record.
+func (j *Journal) finish(p *pendingFix, fixErr error) error {
+	entry := &JournalEntry{
+		Check:      p.plan.Check,
+		Reversible: p.reversible,
+		Beads:      p.plan.Beads,
+		Actions:    p.plan.Actions,
+	}
+	if !p.plan.Declared {
+		entry.Actions = append(entry.Actions, "undeclared changes")
+	}
+	if fixErr != nil {
+		entry.Error = fixErr.Error()
+	}
+	for _, rec := range p.files {
+		rec.AfterSHA, _ = fileSHA(rec.Path)
+		if rec.AfterSHA == rec.BeforeSHA {
+			if rec.Backup != "" {
+				_ = os.Remove(filepath.Join(j.dir, rec.Backup))
+			}
+			continue
+		}
+		entry.Files = append(entry.Files, rec)
+	}
+	j.Entries = append(j.Entries, entry)
+	return j.save()
+}
+
+func (j *Journal) save() error {
+	if err := os.MkdirAll(j.dir, 0755); err != nil {
+		return fmt.Errorf("creating journal dir: %w", err)
+	}
+	return util.AtomicWriteJSON(filepath.Join(j.dir, "journal.json"), j)
+}
+
+// Changed reports whether any recorded fix modified a file.
+func (j *Journal) Changed() bool {
+	for _, e := range j.Entries {
+		if len(e.Files) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadJournal reads the journal of a fix run.
+func LoadJournal(townRoot, runID string) (*Journal, error) {
+	if runID == "" || filepath.Base(runID) != runID {
+		return nil, fmt.Errorf("invalid run id %q", runID)
+	}
+	dir := filepath.Join(JournalRoot(townRoot), runID)
+	data, err := os.ReadFile(filepath.Join(dir, "journal.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no doctor fix run %q", runID)
+		}
+		return nil, err
+	}
+	var j Journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("parsing journal %s: %w", runID, err)
+	}
+	j.dir = dir
+	return &j, nil
+}
+
+// ListJournals returns every recorded fix run, newest first.
+func ListJournals(townRoot string) ([]*Journal, error) {
+	entries, err := os.ReadDir(JournalRoot(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var journals []*Journal
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		j, err := LoadJournal(townRoot, e.Name())
+		if err != nil {
+			continue
+		}
+		journals = append(journals, j)
+	}
+	sort.Slice(journals, func(a, b int) bool {
+		return journals[a].StartedAt.After(journals[b].StartedAt)
+	})
+	return journals, nil
+}
+
+// RollbackResult reports what a rollback restored and what it left alone.
+type RollbackResult struct {
+	Restored     []string // Files written back or removed
+	Conflicts    []string // Files changed since the fix; left untouched
+	Irreversible []string // Fixes whose effects could not be undone
+}
+
+// ErrAlreadyRolledBack is returned when a run has already been rolled back.
+var ErrAlreadyRolledBack = errors.New("run already rolled back")
+
+// Rollback restores the files changed by a fix run, newest change first.
+// A file that was modified again after the fix is reported as a conflict
+// and left untouched rather than overwritten.
+func Rollback(townRoot, runID string) (*RollbackResult, error) {
+	j, err := LoadJournal(townRoot, runID)
+	if err != nil {
+		return nil, err
+	}
+	if j.RolledBackAt != nil {
+		return nil, fmt.Errorf("%s: %w at %s", runID, ErrAlreadyRolledBack, j.RolledBackAt.Format(time.RFC3339))
+	}
+
+	result := &RollbackResult{}
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		entry := j.Entries[i]
+		if !entry.Reversible {
+			var what []string
+			if len(entry.Beads) > 0 {
+				what = append(what, "beads: "+strings.Join(entry.Beads, ", "))
+			}
+			what = append(what, entry.Actions...)
+			if len(what) == 0 {
+				what = append(what, "some files could not be snapshotted")
+			}
+			result.Irreversible = append(result.Irreversible, fmt.Sprintf("%s (%s)", entry.Check, strings.Join(what, "; ")))
+		}
+		for k := len(entry.Files) - 1; k >= 0; k-- {
+			rec := entry.Files[k]
+			current, err := fileSHA(rec.Path)
+			if err != nil || current != rec.AfterSHA {
+				result.Conflicts = append(result.Conflicts, rec.Path)
+				continue
+			}
+			if err := j.restore(rec); err != nil {
+				return result, fmt.Errorf("restoring %s: %w", rec.Path, err)
+			}
+			result.Restored = append(result.Restored, rec.Path)
+		}
+	}
+
+	now := time.Now().UTC()
+	j.RolledBackAt = &now
+	if err := j.save(); err != nil {
+		return result, fmt.Errorf("marking journal rolled back: %w", err)
+	}
+	return result, nil
+}
+
+// restore puts a file back into its pre-fix state.
+func (j *Journal) restore(rec FileRecord) error {
+	if rec.BeforeSHA == "" {
+		if err := os.Remove(rec.Path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(j.dir, rec.Backup))
+	if err != nil {
+		return fmt.Errorf("reading backup: %w", err)
+	}
+	if sha256Hex(data) != rec.BeforeSHA {
+		return fmt.Errorf("backup %s is corrupt", rec.Backup)
+	}
+	if err := os.MkdirAll(filepath.Dir(rec.Path), 0755); err != nil {
+		return err
+	}
+	mode := rec.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+	return util.AtomicWriteFile(rec.Path, data, mode)
+}
+
+// fileSHA returns the SHA-256 of a file, or "" if it does not exist.
+func fileSHA(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return sha256Hex(data), nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,258 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fileFixCheck rewrites one file and creates another, declaring both.
+type fileFixCheck struct {
+	FixableCheck
+	edit, create string
+	fixed        bool
+}
+
+func (c *fileFixCheck) Run(ctx *CheckContext) *CheckResult {
+	if c.fixed {
+		return &CheckResult{Name: c.CheckName, Status: StatusOK, Message: "ok"}
+	}
+	return &CheckResult{Name: c.CheckName, Status: StatusWarning, Message: "stale"}
+}
+
+func (c *fileFixCheck) Fix(ctx *CheckContext) error {
+	c.fixed = true
+	if err := os.WriteFile(c.edit, []byte("rewritten\n"), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(c.create, []byte("new\n"), 0644)
+}
+
+func (c *fileFixCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	return &FixPlan{Summary: "rewrite settings", Files: []string{c.edit, c.create}}
+}
+
+func newFileFixCheck(dir string) *fileFixCheck {
+	c := &fileFixCheck{
+		edit:   filepath.Join(dir, "settings.json"),
+		create: filepath.Join(dir, "extra.json"),
+	}
+	c.CheckName = "file-fix"
+	c.CheckCategory = CategoryConfig
+	return c
+}
+
+func TestDoctor_Plan(t *testing.T) {
+	town := t.TempDir()
+	fc := newFileFixCheck(town)
+	undeclared := newMockCheck("undeclared", StatusError)
+	undeclared.fixable = true
+
+	d := NewDoctor()
+	d.RegisterAll(fc, undeclared, newMockCheck("healthy", StatusOK))
+	report, plans := d.Plan(&CheckContext{TownRoot: town})
+
+	if report.Summary.Total != 3 {
+		t.Errorf("Total = %d, want 3", report.Summary.Total)
+	}
+	if len(plans) != 2 {
+		t.Fatalf("got %d plans, want 2", len(plans))
+	}
+	if !plans[0].Declared || !plans[0].Reversible() || len(plans[0].Files) != 2 {
+		t.Errorf("file-fix plan = %+v, want declared reversible plan with 2 files", plans[0])
+	}
+	if plans[1].Declared || plans[1].Reversible() {
+		t.Errorf("undeclared plan = %+v, want undeclared and irreversible", plans[1])
+	}
+	if fc.fixed || undeclared.fixCount != 0 {
+		t.Error("Plan must not apply fixes")
+	}
+}
+
+func TestJournal_FixAndRollback(t *testing.T) {
+	town := t.TempDir()
+	fc := newFileFixCheck(town)
+	if err := os.WriteFile(fc.edit, []byte("original\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	journal := NewJournal(town)
+	d := NewDoctor()
+	d.SetJournal(journal)
+	d.Register(fc)
+	report := d.Fix(&CheckContext{TownRoot: town})
+	if report.Summary.Fixed != 1 {
+		t.Fatalf("Fixed = %d, want 1", report.Summary.Fixed)
+	}
+	if !journal.Changed() {
+		t.Fatal("journal recorded no file changes")
+	}
+
+	result, err := Rollback(town, journal.RunID)
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if len(result.Restored) != 2 || len(result.Conflicts) != 0 {
+		t.Errorf("result = %+v, want 2 restored, no conflicts", result)
+	}
+	data, err := os.ReadFile(fc.edit)
+	if err != nil || string(data) != "original\n" {
+		t.Errorf("settings.json = %q, %v; want original content", data, err)
+	}
+	if info, err := os.Stat(fc.edit); err == nil && info.Mode().Perm() != 0600 {
+		t.Errorf("settings.json mode = %v, want 0600", info.Mode().Perm())
+	}
+	if _, err := os.Stat(fc.create); !os.IsNotExist(err) {
+		t.Errorf("created file should be removed, stat err = %v", err)
+	}
+
+	if _, err := Rollback(town, journal.RunID); !errors.Is(err, ErrAlreadyRolledBack) {
+		t.Errorf("second rollback err = %v, want ErrAlreadyRolledBack", err)
+	}
+}
+
+func TestJournal_RollbackSkipsFilesEditedAfterFix(t *testing.T) {
+	town := t.TempDir()
+	fc := newFileFixCheck(town)
+	if err := os.WriteFile(fc.edit, []byte("original\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	journal := NewJournal(town)
+	d := NewDoctor()
+	d.SetJournal(journal)
+	d.Register(fc)
+	d.Fix(&CheckContext{TownRoot: town})
+
+	if err := os.WriteFile(fc.edit, []byte("hand edit\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Rollback(town, journal.RunID)
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0] != fc.edit {
+		t.Errorf("Conflicts = %v, want [%s]", result.Conflicts, fc.edit)
+	}
+	if data, _ := os.ReadFile(fc.edit); string(data) != "hand edit\n" {
+		t.Errorf("edited file was overwritten: %q", data)
+	}
+}
+
+func TestJournal_UndeclaredFixIsIrreversible(t *testing.T) {
+	town := t.TempDir()
+	check := newMockCheck("opaque", StatusWarning)
+	check.fixable = true
+
+	journal := NewJournal(town)
+	d := NewDoctor()
+	d.SetJournal(journal)
+	d.Register(check)
+	d.Fix(&CheckContext{TownRoot: town})
+
+	loaded, err := LoadJournal(town, journal.RunID)
+	if err != nil {
+		t.Fatalf("LoadJournal: %v", err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0].Reversible {
+		t.Fatalf("entries = %+v, want one irreversible entry", loaded.Entries)
+	}
+	result, err := Rollback(town, journal.RunID)
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if len(result.Irreversible) != 1 || !strings.HasPrefix(result.Irreversible[0], "opaque") {
+		t.Errorf("Irreversible = %v, want [opaque ...]", result.Irreversible)
+	}
+}
+
+// nilPlanCheck plans no change but still has a Fix that runs.
+type nilPlanCheck struct{ *mockCheck }
+
+func (c nilPlanCheck) PlanFix(ctx *CheckContext) *FixPlan { return nil }
+
+func TestJournal_FixWithNilPlanIsRecorded(t *testing.T) {
+	town := t.TempDir()
+	check := nilPlanCheck{newMockCheck("nil-plan", StatusWarning)}
+	check.fixable = true
+
+	journal := NewJournal(town)
+	d := NewDoctor()
+	d.SetJournal(journal)
+	d.Register(check)
+	d.Fix(&CheckContext{TownRoot: town})
+
+	if check.fixCount != 1 {
+		t.Fatalf("Fix ran %d times, want 1", check.fixCount)
+	}
+	loaded, err := LoadJournal(town, journal.RunID)
+	if err != nil {
+		t.Fatalf("LoadJournal: %v", err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0].Reversible || loaded.Entries[0].Check != "nil-plan" {
+		t.Fatalf("entries = %+v, want one irreversible nil-plan entry", loaded.Entries)
+	}
+	if !slices.Contains(loaded.Entries[0].Actions, "undeclared changes") {
+		t.Errorf("Actions = %v, want undeclared changes", loaded.Entries[0].Actions)
+	}
+}
+
+func TestLoadJournal_RejectsPathTraversal(t *testing.T) {
+	if _, err := LoadJournal(t.TempDir(), "../../etc"); err == nil {
+		t.Error("expected error for run id with path separators")
+	}
+}
+
+func TestReport_WriteJSONAndJUnit(t *testing.T) {
+	report := NewReport()
+	report.Add(&CheckResult{Name: "good", Status: StatusOK, Message: "fine", Category: CategoryCore})
+	report.Add(&CheckResult{Name: "meh", Status: StatusWarning, Message: "stale", Category: CategoryConfig})
+	report.Add(&CheckResult{Name: "bad", Status: StatusError, Message: "broken", FixHint: "repair it", Category: CategoryConfig})
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf, nil, "run-1"); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var decoded struct {
+		RunID   string `json:"run_id"`
+		Summary struct {
+			Errors  int  `json:"errors"`
+			Healthy bool `json:"healthy"`
+		} `json:"summary"`
+		Checks []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded.RunID != "run-1" || decoded.Summary.Errors != 1 || decoded.Summary.Healthy || len(decoded.Checks) != 3 || decoded.Checks[2].Status != "error" {
+		t.Errorf("decoded = %+v", decoded)
+	}
+
+	buf.Reset()
+	if err := report.WriteJUnit(&buf); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if suites.Tests != 3 || suites.Failures != 1 || len(suites.Suites) != 2 {
+		t.Fatalf("suites = %+v, want 3 tests, 1 failure, 2 suites", suites)
+	}
+	config := suites.Suites[1]
+	if config.Name != CategoryConfig || config.Failures != 1 || config.Cases[1].Failure == nil {
+		t.Errorf("Configuration suite = %+v, want failure on bad", config)
+	}
+	if !strings.Contains(config.Cases[1].Failure.Body, "Fix: repair it") {
+		t.Errorf("failure body = %q, want fix hint", config.Cases[1].Failure.Body)
+	}
+}
@@ -82,6 +82,18 @@ func (c *LandWorktreeGitignoreCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the rig .gitignore files Fix appends to.
+func (c *LandWorktreeGitignoreCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.affectedRigs) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "add .land-worktree/ to rig .gitignore"}
+	for _, rigPath := range c.affectedRigs {
+		plan.Files = append(plan.Files, filepath.Join(rigPath, ".gitignore"))
+	}
+	return plan
+}
+
 // hasGitignoreEntry checks if a .gitignore file contains the given entry.
 func hasGitignoreEntry(gitignorePath, entry string) bool {
 	file, err := os.Open(gitignorePath)
@@ -98,3 +98,15 @@ func (c *LifecycleDefaultsCheck) Run(ctx *CheckContext) *CheckResult {
 func (c *LifecycleDefaultsCheck) Fix(ctx *CheckContext) error {
 	return daemon.EnsureLifecycleConfigFile(ctx.TownRoot)
 }
+
+// PlanFix reports the daemon.json that Fix creates or extends.
+func (c *LifecycleDefaultsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	summary := "create daemon.json with lifecycle defaults"
+	if len(c.missing) > 0 {
+		summary = "add lifecycle defaults to daemon.json: " + strings.Join(c.missing, ", ")
+	}
+	return &FixPlan{
+		Summary: summary,
+		Files:   []string{daemon.PatrolConfigFile(ctx.TownRoot)},
+	}
+}
@@ -129,6 +129,18 @@ func (c *DoltMetadataCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the metadata.json files Fix writes.
+func (c *DoltMetadataCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingMetadata) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "write Dolt server config to metadata.json"}
+	for _, rigName := range c.missingMetadata {
+		plan.Files = append(plan.Files, filepath.Join(c.findRigBeadsDir(ctx.TownRoot, rigName), "metadata.json"))
+	}
+	return plan
+}
+
 // hasDoltMetadata checks if a beads directory has proper dolt server config.
 func (c *DoltMetadataCheck) hasDoltMetadata(beadsDir, expectedDB string) bool {
 	metadataPath := filepath.Join(beadsDir, "metadata.json")
@@ -424,6 +436,18 @@ func (c *DoltOrphanedDatabaseCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the orphaned databases Fix removes.
+func (c *DoltOrphanedDatabaseCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.orphanNames) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "remove orphaned Dolt databases"}
+	for _, name := range c.orphanNames {
+		plan.Actions = append(plan.Actions, "drop database "+name+" and remove its .dolt-data directory")
+	}
+	return plan
+}
+
 // formatBytes returns a human-readable size string.
 func formatBytes(b int64) string {
 	const unit = 1024
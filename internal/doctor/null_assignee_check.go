@@ -142,6 +142,23 @@ func (c *NullAssigneeCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the beads Fix resets and the databases it commits.
+func (c *NullAssigneeCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.affected) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "reset in_progress steps with no assignee to open"}
+	dbs := make(map[string]bool)
+	for _, row := range c.affected {
+		plan.Beads = append(plan.Beads, row.ID)
+		if !dbs[row.RigDB] {
+			dbs[row.RigDB] = true
+			plan.Actions = append(plan.Actions, "dolt commit in database "+row.RigDB)
+		}
+	}
+	return plan
+}
+
 // queryNullAssigneeBeads returns in_progress beads with NULL/empty assignee for a rig.
 // Uses bd sql --csv (raw SQL passthrough, not affected by bd ORM deserialization).
 func queryNullAssigneeBeads(rigDir string) ([]nullAssigneeRow, error) {
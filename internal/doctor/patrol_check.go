@@ -154,6 +154,27 @@ func (c *PatrolMoleculesExistCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the formula files Fix provisions for each rig.
+func (c *PatrolMoleculesExistCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingFormulas) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "provision missing patrol formulas"}
+	seen := make(map[string]bool)
+	for rigName := range c.missingFormulas {
+		rigPath := filepath.Join(ctx.TownRoot, rigName)
+		if _, statErr := os.Stat(rigPath); os.IsNotExist(statErr) {
+			rigPath = ctx.TownRoot
+		}
+		if seen[rigPath] {
+			continue
+		}
+		seen[rigPath] = true
+		plan.Files = append(plan.Files, formulaFixFiles(rigPath, "missing", "new")...)
+	}
+	return plan
+}
+
 // PatrolHooksWiredCheck verifies that hooks trigger patrol execution.
 type PatrolHooksWiredCheck struct {
 	FixableCheck
@@ -225,6 +246,15 @@ func (c *PatrolHooksWiredCheck) Fix(ctx *CheckContext) error {
 	return config.EnsureDaemonPatrolConfig(ctx.TownRoot)
 }
 
+// PlanFix lists the daemon.json Fix creates.
+func (c *PatrolHooksWiredCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	path := config.DaemonPatrolConfigPath(ctx.TownRoot)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	return &FixPlan{Summary: "create daemon.json with default patrols", Files: []string{path}}
+}
+
 // PatrolNotStuckCheck detects wisps that have been in_progress too long.
 type PatrolNotStuckCheck struct {
 	BaseCheck
@@ -418,6 +448,18 @@ func (c *PatrolPluginsAccessibleCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the plugins directories Fix creates.
+func (c *PatrolPluginsAccessibleCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingDirs) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "create missing plugins directories"}
+	for _, dir := range c.missingDirs {
+		plan.Actions = append(plan.Actions, "mkdir "+dir)
+	}
+	return plan
+}
+
 // discoverRigs finds all registered rigs.
 func discoverRigs(townRoot string) ([]string, error) {
 	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
@@ -0,0 +1,138 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// FixPlan describes what a check's Fix would change. It is shown by
+// gt doctor --plan and drives the rollback journal: every file listed in
+// Files is snapshotted before the fix runs and can be restored afterwards.
+type FixPlan struct {
+	Check    string   `json:"check"`
+	Category string   `json:"category,omitempty"`
+	Status   string   `json:"status"`
+	Summary  string   `json:"summary"`
+	Files    []string `json:"files,omitempty"`   // Files the fix may create, rewrite or delete
+	Beads    []string `json:"beads,omitempty"`   // Bead IDs the fix may create or update
+	Actions  []string `json:"actions,omitempty"` // Other side effects (sessions, processes, git, SQL)
+	// Declared is false when the check does not describe its fix; such fixes
+	// may touch anything and cannot be rolled back.
+	Declared bool `json:"declared"`
+}
+
+// Reversible reports whether gt doctor --rollback can fully undo the fix:
+// the check declared its changes and they are all file-level.
+func (p *FixPlan) Reversible() bool {
+	return p.Declared && len(p.Beads) == 0 && len(p.Actions) == 0
+}
+
+// FixPlanner is implemented by fixable checks that can describe their fix
+// before applying it. PlanFix is called after Run reported a problem, so it
+// may use state cached by Run. It returns nil when Fix would do nothing.
+type FixPlanner interface {
+	PlanFix(ctx *CheckContext) *FixPlan
+}
+
+// planFix returns the plan for fixing result, or nil if the check would not
+// attempt a fix. Checks without a FixPlanner get an undeclared plan.
+func planFix(check Check, ctx *CheckContext, result *CheckResult) *FixPlan {
+	if result.Status == StatusOK || !check.CanFix() {
+		return nil
+	}
+	planner, ok := check.(FixPlanner)
+	if !ok {
+		return undeclaredPlan(result)
+	}
+	plan := planner.PlanFix(ctx)
+	if plan == nil {
+		return nil
+	}
+	plan.Declared = true
+	return describePlan(plan, result)
+}
+
+// undeclaredPlan returns a plan for a fix whose changes are unknown, so its
+// journal entry is marked undeclared and irreversible.
+func undeclaredPlan(result *CheckResult) *FixPlan {
+	return describePlan(&FixPlan{}, result)
+}
+
+// describePlan fills in the check details of plan from result.
+func describePlan(plan *FixPlan, result *CheckResult) *FixPlan {
+	plan.Check = result.Name
+	plan.Category = result.Category
+	plan.Status = result.Status.String()
+	if plan.Summary == "" {
+		plan.Summary = result.Message
+	}
+	sort.Strings(plan.Files)
+	return plan
+}
+
+// Plan runs every check and returns the fixes --fix would attempt, in the
+// order it would attempt them. Nothing is modified.
+func (d *Doctor) Plan(ctx *CheckContext) (*Report, []*FixPlan) {
+	report := NewReport()
+	var plans []*FixPlan
+	for _, check := range d.checks {
+		result := runCheck(check, ctx)
+		report.Add(result)
+		if plan := planFix(check, ctx, result); plan != nil {
+			plans = append(plans, plan)
+		}
+	}
+	return report, plans
+}
+
+// runCheck runs a check and fills in the name and category if the check
+// left them empty.
+func runCheck(check Check, ctx *CheckContext) *CheckResult {
+	result := check.Run(ctx)
+	if result.Name == "" {
+		result.Name = check.Name()
+	}
+	if cg, ok := check.(categoryGetter); ok && result.Category == "" {
+		result.Category = cg.Category()
+	}
+	return result
+}
+
+// PrintPlan outputs the fixes --fix would apply, with what each one touches.
+func PrintPlan(w io.Writer, plans []*FixPlan) {
+	_, _ = fmt.Fprintln(w)
+	if len(plans) == 0 {
+		_, _ = fmt.Fprintln(w, ui.RenderPass(ui.IconPass+" Nothing to fix"))
+		return
+	}
+	_, _ = fmt.Fprintln(w, ui.RenderCategory(fmt.Sprintf("Fix plan (%d)", len(plans))))
+	reversible := 0
+	for i, p := range plans {
+		icon := ui.RenderWarnIcon()
+		if p.Status == StatusError.String() {
+			icon = ui.RenderFailIcon()
+		}
+		_, _ = fmt.Fprintf(w, "  %s  %d. %s%s\n", icon, i+1, p.Check, ui.RenderMuted(": "+p.Summary))
+		if !p.Declared {
+			_, _ = fmt.Fprintf(w, "        %s%s\n", ui.MutedStyle.Render(ui.TreeLast), ui.RenderWarn("changes not declared; cannot be rolled back"))
+			continue
+		}
+		for _, f := range p.Files {
+			_, _ = fmt.Fprintf(w, "        %sfile   %s\n", ui.MutedStyle.Render(ui.TreeLast), f)
+		}
+		for _, b := range p.Beads {
+			_, _ = fmt.Fprintf(w, "        %sbead   %s\n", ui.MutedStyle.Render(ui.TreeLast), b)
+		}
+		for _, a := range p.Actions {
+			_, _ = fmt.Fprintf(w, "        %saction %s\n", ui.MutedStyle.Render(ui.TreeLast), a)
+		}
+		if p.Reversible() {
+			reversible++
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "%d of %d fix(es) are fully reversible with gt doctor --rollback\n", reversible, len(plans))
+}
@@ -218,5 +218,22 @@ func (c *BranchProtectionCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the git hooks Fix writes or removes.
+func (c *BranchProtectionCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if !c.needsUpdate {
+		return nil
+	}
+	hooksDir := filepath.Join(ctx.TownRoot, ".git", "hooks")
+	plan := &FixPlan{
+		Summary: "install branch protection in post-checkout hook",
+		Files:   []string{filepath.Join(hooksDir, "post-checkout")},
+	}
+	preCheckoutPath := filepath.Join(hooksDir, "pre-checkout")
+	if content, err := os.ReadFile(preCheckoutPath); err == nil && strings.Contains(string(content), "Gas Town pre-checkout hook") {
+		plan.Files = append(plan.Files, preCheckoutPath)
+	}
+	return plan
+}
+
 // Legacy type alias for backwards compatibility
 type PreCheckoutHookCheck = BranchProtectionCheck
@@ -479,3 +479,55 @@ func (c *PrimingCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the settings, CLAUDE.md and PRIME.md files Fix writes or
+// removes for each fixable issue.
+func (c *PrimingCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	plan := &FixPlan{Summary: "repair agent priming"}
+	seen := make(map[string]bool)
+	addFile := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			plan.Files = append(plan.Files, path)
+		}
+	}
+	for _, issue := range c.issues {
+		if !issue.fixable {
+			continue
+		}
+		switch issue.issueType {
+		case "no_prime_hook":
+			settingsDir := filepath.Join(ctx.TownRoot, issue.location)
+			addFile(filepath.Join(settingsDir, ".claude", "settings.json"))
+			rigPath := ""
+			if issue.rigName != "" {
+				rigPath = filepath.Join(ctx.TownRoot, issue.rigName)
+			}
+			rc := config.ResolveRoleAgentConfig(issue.agentType, ctx.TownRoot, rigPath)
+			if rc != nil && rc.Hooks != nil && rc.Hooks.SettingsFile != "" {
+				addFile(filepath.Join(settingsDir, rc.Hooks.Dir, rc.Hooks.SettingsFile))
+			}
+		case "missing_town_claude_md":
+			addFile(filepath.Join(ctx.TownRoot, "CLAUDE.md"))
+		case "orphaned_beads_dir":
+			plan.Actions = append(plan.Actions, "rm -r "+filepath.Join(ctx.TownRoot, issue.location, ".beads"))
+		case "missing_prime_md":
+			worktreePath := filepath.Join(ctx.TownRoot, issue.location)
+			beadsDir := filepath.Join(worktreePath, constants.DirBeads)
+			if strings.Contains(issue.location, "/crew/") || strings.Contains(issue.location, "/polecats/") {
+				beadsDir = beads.ResolveBeadsDir(worktreePath)
+			}
+			addFile(filepath.Join(beadsDir, "PRIME.md"))
+		case "stale_intermediate_instructions_md":
+			for _, filename := range []string{"CLAUDE.md", "AGENTS.md"} {
+				if path := filepath.Join(ctx.TownRoot, issue.location, filename); fileExists(path) {
+					addFile(path)
+				}
+			}
+		}
+	}
+	if len(plan.Files) == 0 && len(plan.Actions) == 0 {
+		return nil
+	}
+	return plan
+}
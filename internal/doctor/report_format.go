@@ -0,0 +1,191 @@
+package doctor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Output formats for WriteFormat.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatJUnit = "junit"
+)
+
+// jsonCheck is the JSON form of a CheckResult.
+type jsonCheck struct {
+	Name      string   `json:"name"`
+	Category  string   `json:"category,omitempty"`
+	Status    string   `json:"status"`
+	Message   string   `json:"message,omitempty"`
+	Details   []string `json:"details,omitempty"`
+	FixHint   string   `json:"fix_hint,omitempty"`
+	Fixed     bool     `json:"fixed,omitempty"`
+	ElapsedMs int64    `json:"elapsed_ms"`
+}
+
+// jsonReport is the JSON form of a Report.
+type jsonReport struct {
+	Timestamp string      `json:"timestamp"`
+	Summary   jsonSummary `json:"summary"`
+	Checks    []jsonCheck `json:"checks"`
+	Plan      []*FixPlan  `json:"plan,omitempty"`
+	RunID     string      `json:"run_id,omitempty"`
+}
+
+type jsonSummary struct {
+	Total    int  `json:"total"`
+	OK       int  `json:"ok"`
+	Warnings int  `json:"warnings"`
+	Errors   int  `json:"errors"`
+	Fixed    int  `json:"fixed"`
+	Healthy  bool `json:"healthy"`
+}
+
+// WriteJSON writes the report as a single JSON document. plan (from
+// Doctor.Plan) and runID (the fix journal, if any) are included when set.
+func (r *Report) WriteJSON(w io.Writer, plan []*FixPlan, runID string) error {
+	out := jsonReport{
+		Timestamp: r.Timestamp.UTC().Format(time.RFC3339),
+		Summary: jsonSummary{
+			Total:    r.Summary.Total,
+			OK:       r.Summary.OK,
+			Warnings: r.Summary.Warnings,
+			Errors:   r.Summary.Errors,
+			Fixed:    r.Summary.Fixed,
+			Healthy:  r.IsHealthy(),
+		},
+		Checks: make([]jsonCheck, 0, len(r.Checks)),
+		Plan:   plan,
+		RunID:  runID,
+	}
+	for _, c := range r.Checks {
+		out.Checks = append(out.Checks, jsonCheck{
+			Name:      c.Name,
+			Category:  c.Category,
+			Status:    strings.ToLower(c.Status.String()),
+			Message:   c.Message,
+			Details:   c.Details,
+			FixHint:   c.FixHint,
+			Fixed:     c.Fixed,
+			ElapsedMs: c.Elapsed.Milliseconds(),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// JUnit XML, as understood by common CI systems (Jenkins, GitLab, GitHub
+// test reporters). Each category is a test suite and each check a test
+// case; errors are failures and warnings are reported as system-out with a
+// "[warning]" prefix so they are visible without failing the build.
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	byCategory := make(map[string]*junitSuite)
+	var order []string
+	var total time.Duration
+	for _, c := range r.Checks {
+		cat := c.Category
+		if cat == "" {
+			cat = "Other"
+		}
+		suite, ok := byCategory[cat]
+		if !ok {
+			suite = &junitSuite{Name: cat, Timestamp: r.Timestamp.UTC().Format("2006-01-02T15:04:05")}
+			byCategory[cat] = suite
+			order = append(order, cat)
+		}
+
+		tc := junitCase{
+			Name:      c.Name,
+			Classname: "gt.doctor." + strings.ToLower(cat),
+			Time:      junitSeconds(c.Elapsed),
+		}
+		body := strings.Join(append([]string{c.Message}, c.Details...), "\n")
+		if c.FixHint != "" {
+			body += "\nFix: " + c.FixHint
+		}
+		switch c.Status {
+		case StatusError:
+			tc.Failure = &junitFailure{Message: c.Message, Type: "error", Body: body}
+			suite.Failures++
+		case StatusWarning:
+			tc.SystemOut = "[warning] " + body
+		default:
+			tc.SystemOut = c.Message
+		}
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+		total += c.Elapsed
+	}
+
+	out := junitSuites{
+		Name:     "gt doctor",
+		Tests:    r.Summary.Total,
+		Failures: r.Summary.Errors,
+		Time:     junitSeconds(total),
+	}
+	for _, cat := range order {
+		suite := byCategory[cat]
+		var elapsed time.Duration
+		for _, c := range r.Checks {
+			if c.Category == cat || (c.Category == "" && cat == "Other") {
+				elapsed += c.Elapsed
+			}
+		}
+		suite.Time = junitSeconds(elapsed)
+		out.Suites = append(out.Suites, *suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
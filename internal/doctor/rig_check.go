@@ -243,6 +243,17 @@ func (c *GitExcludeConfiguredCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the exclude file Fix appends to.
+func (c *GitExcludeConfiguredCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingEntries) == 0 {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "add " + strings.Join(c.missingEntries, ", ") + " to .git/info/exclude",
+		Files:   []string{c.excludePath},
+	}
+}
+
 // HooksPathConfiguredCheck verifies all clones have core.hooksPath set to .githooks.
 // This ensures the pre-push hook blocks pushes to invalid branches (no internal PRs).
 type HooksPathConfiguredCheck struct {
@@ -364,6 +375,18 @@ func (c *HooksPathConfiguredCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the clones whose git config Fix updates.
+func (c *HooksPathConfiguredCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.unconfiguredClones) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "set core.hooksPath=.githooks"}
+	for _, clonePath := range c.unconfiguredClones {
+		plan.Actions = append(plan.Actions, "git -C "+clonePath+" config core.hooksPath .githooks")
+	}
+	return plan
+}
+
 // WitnessExistsCheck verifies the witness directory structure exists.
 type WitnessExistsCheck struct {
 	FixableCheck
@@ -471,6 +494,11 @@ func (c *WitnessExistsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the witness directory and inbox Fix creates.
+func (c *WitnessExistsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	return planAgentDirFix(c.rigPath, "witness", c.needsCreate, c.needsMail)
+}
+
 // RefineryExistsCheck verifies the refinery directory structure exists.
 type RefineryExistsCheck struct {
 	FixableCheck
@@ -578,6 +606,28 @@ func (c *RefineryExistsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the refinery directory and inbox Fix creates.
+func (c *RefineryExistsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	return planAgentDirFix(c.rigPath, "refinery", c.needsCreate, c.needsMail)
+}
+
+// planAgentDirFix describes creating a rig agent's directory and mail inbox.
+// Missing clones are not fixable, so they are not part of the plan.
+func planAgentDirFix(rigPath, agent string, needsCreate, needsMail bool) *FixPlan {
+	if !needsCreate && !needsMail {
+		return nil
+	}
+	dir := filepath.Join(rigPath, agent)
+	plan := &FixPlan{Summary: "create " + agent + "/ structure"}
+	if needsCreate {
+		plan.Actions = append(plan.Actions, "mkdir "+dir)
+	}
+	if needsMail {
+		plan.Files = append(plan.Files, filepath.Join(dir, "mail", "inbox.jsonl"))
+	}
+	return plan
+}
+
 // MayorCloneExistsCheck verifies the mayor/rig clone exists.
 type MayorCloneExistsCheck struct {
 	FixableCheck
@@ -665,6 +715,17 @@ func (c *MayorCloneExistsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the mayor/ directory Fix creates.
+func (c *MayorCloneExistsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if !c.needsCreate {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "create mayor/ directory",
+		Actions: []string{"mkdir " + filepath.Join(c.rigPath, "mayor")},
+	}
+}
+
 // PolecatClonesValidCheck verifies each polecat directory is a valid clone.
 type PolecatClonesValidCheck struct {
 	BaseCheck
@@ -866,6 +927,11 @@ func (c *BeadsConfigValidCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix returns nil: with the Dolt backend Fix has nothing to do.
+func (c *BeadsConfigValidCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	return nil
+}
+
 // BeadsRedirectCheck verifies that rig-level beads redirect exists for tracked beads.
 // When a repo has .beads/ tracked in git (at mayor/rig/.beads), the rig root needs
 // a redirect file pointing to that location.
@@ -1070,6 +1136,33 @@ func (c *BeadsRedirectCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix describes the redirect Fix writes, or the beads initialization it
+// runs when the rig has no beads at all.
+func (c *BeadsRedirectCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if ctx.RigName == "" {
+		return nil
+	}
+	rigPath := ctx.RigPath()
+	rigBeadsDir := filepath.Join(rigPath, ".beads")
+	if _, err := os.Stat(filepath.Join(rigPath, "mayor", "rig", ".beads")); os.IsNotExist(err) {
+		if _, err := os.Stat(rigBeadsDir); err == nil {
+			return nil
+		}
+		return &FixPlan{
+			Summary: "initialize rig beads",
+			Actions: []string{"bd init --server in " + rigPath},
+		}
+	}
+	plan := &FixPlan{
+		Summary: "redirect rig beads to mayor/rig/.beads",
+		Files:   []string{filepath.Join(rigBeadsDir, "redirect")},
+	}
+	if hasBeadsData(rigBeadsDir) {
+		plan.Actions = append(plan.Actions, "rm -r "+rigBeadsDir+" (conflicting local beads)")
+	}
+	return plan
+}
+
 // hasBeadsData checks if a beads directory has actual data (issues.db, config.yaml)
 // as opposed to just being a redirect-only directory.
 func hasBeadsData(beadsDir string) bool {
@@ -1181,6 +1274,18 @@ func (c *BareRepoRefspecCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix describes the git config change Fix makes on the bare repo.
+func (c *BareRepoRefspecCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if ctx.RigName == "" {
+		return nil
+	}
+	bareRepoPath := filepath.Join(ctx.RigPath(), ".repo.git")
+	return &FixPlan{
+		Summary: "set remote.origin.fetch refspec on .repo.git",
+		Actions: []string{"git -C " + bareRepoPath + " config remote.origin.fetch +refs/heads/*:refs/remotes/origin/*"},
+	}
+}
+
 // DefaultBranchExistsCheck verifies that the configured default_branch exists
 // as a remote tracking ref in the bare repo.
 type DefaultBranchExistsCheck struct {
@@ -1772,6 +1877,32 @@ func (c *BareRepoExistsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix describes the push URL update, bare clone and worktree
+// re-registration Fix performs.
+func (c *BareRepoExistsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if ctx.RigName == "" || (!c.pushURLMismatch && len(c.brokenWorktrees) == 0) {
+		return nil
+	}
+	bareRepoPath := filepath.Join(ctx.RigPath(), ".repo.git")
+	_, statErr := os.Stat(bareRepoPath)
+	plan := &FixPlan{Summary: "repair shared bare repo .repo.git"}
+	if c.pushURLMismatch && statErr == nil {
+		plan.Actions = append(plan.Actions, "set origin push URL on "+bareRepoPath+" from config.json")
+	}
+	if len(c.brokenWorktrees) > 0 {
+		if statErr != nil {
+			plan.Actions = append(plan.Actions, "git clone --bare "+bareRepoPath+" from config.json git_url")
+		}
+		for _, relPath := range c.brokenWorktrees {
+			plan.Actions = append(plan.Actions, "re-register worktree "+relPath+" in "+bareRepoPath)
+		}
+	}
+	if len(plan.Actions) == 0 {
+		return nil
+	}
+	return plan
+}
+
 // findWorktreeDirs returns paths to directories that may be git worktrees within a rig.
 // Checks refinery/rig and all polecat worktree directories.
 func (c *BareRepoExistsCheck) findWorktreeDirs(rigPath, rigName string) []string {
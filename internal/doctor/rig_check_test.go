@@ -240,13 +240,18 @@ func TestBeadsRedirectCheck_Fix(t *testing.T) {
 		t.Fatalf("expected StatusError before fix, got %v", result.Status)
 	}
 
+	redirectPath := filepath.Join(rigDir, ".beads", "redirect")
+	plan := check.PlanFix(ctx)
+	if plan == nil || len(plan.Files) != 1 || plan.Files[0] != redirectPath || len(plan.Actions) != 0 {
+		t.Errorf("PlanFix() = %+v, want only %s", plan, redirectPath)
+	}
+
 	// Apply fix
 	if err := check.Fix(ctx); err != nil {
 		t.Fatalf("Fix failed: %v", err)
 	}
 
 	// Verify redirect file was created
-	redirectPath := filepath.Join(rigDir, ".beads", "redirect")
 	content, err := os.ReadFile(redirectPath)
 	if err != nil {
 		t.Fatalf("redirect file not created: %v", err)
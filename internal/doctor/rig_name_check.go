@@ -166,3 +166,14 @@ func (c *RigNameMismatchCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the rig config.json Fix rewrites.
+func (c *RigNameMismatchCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if ctx.RigName == "" {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "update config.json name and prefix for rig " + ctx.RigName,
+		Files:   []string{filepath.Join(ctx.RigPath(), "config.json")},
+	}
+}
@@ -119,6 +119,18 @@ func (c *RigRoutesJSONLCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the rig-level routes.jsonl files Fix deletes.
+func (c *RigRoutesJSONLCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.affectedRigs) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "delete rig-level routes.jsonl files"}
+	for _, info := range c.affectedRigs {
+		plan.Files = append(plan.Files, info.routesPath)
+	}
+	return plan
+}
+
 // findRigDirectories finds all rig directories in the town.
 func (c *RigRoutesJSONLCheck) findRigDirectories(townRoot string) []string {
 	var rigDirs []string
@@ -418,3 +418,11 @@ func (c *RoutesCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the routes.jsonl Fix rewrites.
+func (c *RoutesCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	return &FixPlan{
+		Summary: "add missing routes and rewrite redirect-dependent ones",
+		Files:   []string{filepath.Join(ctx.TownRoot, ".beads", beads.RoutesFileName)},
+	}
+}
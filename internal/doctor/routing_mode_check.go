@@ -133,6 +133,18 @@ func (c *RoutingModeCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix describes the beads config changes Fix makes.
+func (c *RoutingModeCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	plan := &FixPlan{
+		Summary: "set routing.mode to explicit",
+		Actions: []string{"bd config set routing.mode explicit in " + filepath.Join(ctx.TownRoot, ".beads")},
+	}
+	if ctx.RigName != "" {
+		plan.Actions = append(plan.Actions, "bd config set routing.mode explicit in "+filepath.Join(ctx.RigPath(), ".beads"))
+	}
+	return plan
+}
+
 // setRoutingMode sets routing.mode to "explicit" in the specified beads directory.
 func (c *RoutingModeCheck) setRoutingMode(beadsDir string) error {
 	cmd := exec.Command("bd", "config", "set", "routing.mode", "explicit")
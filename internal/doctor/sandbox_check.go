@@ -0,0 +1,125 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/sandbox"
+)
+
+// SandboxCheck verifies each rig's sandbox settings: the driver is known and
+// usable on this host, the network mode and allowlist parse, and declared
+// paths exist. A rig that asks for a sandbox it cannot get fails to start
+// polecats, so a broken driver is an error rather than a warning.
+type SandboxCheck struct {
+	BaseCheck
+}
+
+// NewSandboxCheck creates a new sandbox check.
+func NewSandboxCheck() *SandboxCheck {
+	return &SandboxCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "sandbox",
+			CheckDescription: "Check polecat sandbox drivers are configured and available",
+			CheckCategory:    CategoryRig,
+		},
+	}
+}
+
+// Run checks the sandbox settings of every rig.
+func (c *SandboxCheck) Run(ctx *CheckContext) *CheckResult {
+	var errs, warnings []string
+	driverErrs := make(map[string]error)
+	sandboxed := 0
+
+	for _, rigPath := range findAllRigs(ctx.TownRoot) {
+		rigName := filepath.Base(rigPath)
+		settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+		if err != nil || len(settings.Sandbox) == 0 {
+			continue
+		}
+
+		roles := make([]string, 0, len(settings.Sandbox))
+		for role := range settings.Sandbox {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+
+		for _, role := range roles {
+			where := fmt.Sprintf("%s sandbox.%s", rigName, role)
+			if role != "polecat" && role != config.SandboxDefaultKey {
+				warnings = append(warnings, fmt.Sprintf("%s: only polecat sessions are sandboxed; this entry has no effect", where))
+				continue
+			}
+			sc, err := config.ResolveSandboxConfig(rigPath, role)
+			if err != nil || sc == nil {
+				continue
+			}
+
+			driver, err := sandbox.Get(sc.Driver)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", where, err))
+				continue
+			}
+			if driver.Name() == sandbox.DriverNone {
+				continue
+			}
+			sandboxed++
+			if _, checked := driverErrs[driver.Name()]; !checked {
+				driverErrs[driver.Name()] = driver.Check()
+			}
+			if err := driverErrs[driver.Name()]; err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", where, err))
+			}
+
+			if !sandbox.ValidNetwork(sc.Network) {
+				errs = append(errs, fmt.Sprintf("%s: unknown network mode %q (want host, none or allowlist)", where, sc.Network))
+			} else if sc.Network == sandbox.NetworkAllowlist {
+				if _, err := sandbox.ParseEndpoints(sc.AllowHosts); err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %v", where, err))
+				}
+			} else if len(sc.AllowHosts) > 0 {
+				warnings = append(warnings, fmt.Sprintf("%s: allow_hosts is ignored unless network is \"allowlist\"", where))
+			}
+
+			for _, p := range append(append([]string{}, sc.ReadOnlyPaths...), sc.ReadWritePaths...) {
+				if _, err := os.Stat(p); err != nil {
+					warnings = append(warnings, fmt.Sprintf("%s: path %s does not exist and will not be mounted", where, p))
+				}
+			}
+		}
+	}
+
+	switch {
+	case len(errs) > 0:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("%d sandbox problem(s); affected polecats will not start", len(errs)),
+			Details: append(errs, warnings...),
+			FixHint: "Install bubblewrap and enable unprivileged user namespaces, or fix the sandbox entry in <rig>/settings/config.json",
+		}
+	case len(warnings) > 0:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%d sandbox warning(s)", len(warnings)),
+			Details: warnings,
+		}
+	case sandboxed == 0:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No rigs sandbox their polecats",
+		}
+	default:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d sandbox config(s) valid and driver available", sandboxed),
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func writeSandboxSettings(t *testing.T, townRoot, rigName string, sandbox map[string]*config.SandboxConfig) {
+	t.Helper()
+	rigPath := filepath.Join(townRoot, rigName)
+	if err := os.MkdirAll(filepath.Join(rigPath, "polecats"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	settings := config.NewRigSettings()
+	settings.Sandbox = sandbox
+	if err := config.SaveRigSettings(config.RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSandboxCheck_NoSandbox(t *testing.T) {
+	townRoot := t.TempDir()
+	writeSandboxSettings(t, townRoot, "gastown", nil)
+
+	result := NewSandboxCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %s %v", result.Status, result.Message, result.Details)
+	}
+}
+
+func TestSandboxCheck_InvalidConfig(t *testing.T) {
+	townRoot := t.TempDir()
+	writeSandboxSettings(t, townRoot, "gastown", map[string]*config.SandboxConfig{
+		"polecat": {Driver: "jail"},
+		"witness": {Driver: "none"},
+	})
+
+	result := NewSandboxCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusError {
+		t.Fatalf("Status = %v, want Error", result.Status)
+	}
+	joined := strings.Join(result.Details, "\n")
+	if !strings.Contains(joined, `unknown sandbox driver "jail"`) {
+		t.Errorf("Details should name the bad driver:\n%s", joined)
+	}
+	if !strings.Contains(joined, "gastown sandbox.witness: only polecat sessions are sandboxed") {
+		t.Errorf("Details should flag the ignored witness entry:\n%s", joined)
+	}
+}
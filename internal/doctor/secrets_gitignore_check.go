@@ -0,0 +1,151 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// secretsGitignoreEntry is the town .gitignore entry covering the file
+// secret backend's directory.
+const secretsGitignoreEntry = constants.DirMayor + "/" + constants.DirSecrets + "/"
+
+// SecretsGitignoreCheck ensures the file secret backend's mayor/secrets/
+// directory can't be committed to the town repository. The backend stores
+// API keys as plaintext files, so a tracked or unignored directory leaks
+// credentials on the next `git add .`.
+type SecretsGitignoreCheck struct {
+	FixableCheck
+	needsIgnore bool     // Cached during Run for use in Fix
+	tracked     []string // Secret files in the git index
+}
+
+// NewSecretsGitignoreCheck creates a new secrets gitignore check.
+func NewSecretsGitignoreCheck() *SecretsGitignoreCheck {
+	return &SecretsGitignoreCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "secrets-gitignore",
+				CheckDescription: "Check that mayor/secrets/ is gitignored and untracked",
+				CheckCategory:    CategoryConfig,
+			},
+		},
+	}
+}
+
+// Run checks whether mayor/secrets/ is tracked or not ignored by the town repo.
+func (c *SecretsGitignoreCheck) Run(ctx *CheckContext) *CheckResult {
+	c.needsIgnore = false
+	c.tracked = nil
+
+	if _, err := os.Stat(filepath.Join(ctx.TownRoot, ".git")); err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "Town root is not a git repository",
+		}
+	}
+
+	out, err := exec.Command("git", "-C", ctx.TownRoot, "ls-files", "--", secretsGitignoreEntry).Output()
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not list tracked files: " + err.Error(),
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			c.tracked = append(c.tracked, line)
+		}
+	}
+
+	// check-ignore matches patterns, so the probe path need not exist.
+	// Exit status 1 means "not ignored".
+	probe := secretsGitignoreEntry + "probe"
+	if err := exec.Command("git", "-C", ctx.TownRoot, "check-ignore", "-q", "--", probe).Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+			return &CheckResult{
+				Name:    c.Name(),
+				Status:  StatusWarning,
+				Message: "Could not check .gitignore: " + err.Error(),
+			}
+		}
+		c.needsIgnore = true
+	}
+
+	if len(c.tracked) == 0 && !c.needsIgnore {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "mayor/secrets/ is gitignored",
+		}
+	}
+
+	var details []string
+	for _, f := range c.tracked {
+		details = append(details, f+" is tracked by git (rotate this key; it remains in history)")
+	}
+	if c.needsIgnore {
+		details = append(details, "Town .gitignore does not exclude "+secretsGitignoreEntry)
+	}
+
+	// An unignored directory is only an immediate leak risk once the file
+	// backend has stored something in it.
+	status := StatusError
+	if len(c.tracked) == 0 {
+		if _, err := os.Stat(filepath.Join(ctx.TownRoot, constants.DirMayor, constants.DirSecrets)); os.IsNotExist(err) {
+			status = StatusWarning
+		}
+	}
+
+	message := "mayor/secrets/ is not gitignored"
+	if len(c.tracked) > 0 {
+		message = fmt.Sprintf("%d secret file(s) tracked by git", len(c.tracked))
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  status,
+		Message: message,
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to ignore mayor/secrets/ and untrack secret files",
+	}
+}
+
+// Fix adds mayor/secrets/ to the town .gitignore and removes tracked secret
+// files from the index. The files stay on disk.
+func (c *SecretsGitignoreCheck) Fix(ctx *CheckContext) error {
+	if c.needsIgnore {
+		if err := appendGitignoreEntry(filepath.Join(ctx.TownRoot, ".gitignore"), secretsGitignoreEntry); err != nil {
+			return fmt.Errorf("updating .gitignore: %w", err)
+		}
+		c.needsIgnore = false
+	}
+	if len(c.tracked) > 0 {
+		cmd := exec.Command("git", "-C", ctx.TownRoot, "rm", "-r", "--cached", "-q", "--", secretsGitignoreEntry)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("untracking %s: %v (%s)", secretsGitignoreEntry, err, strings.TrimSpace(string(out)))
+		}
+		c.tracked = nil
+	}
+	return nil
+}
+
+// PlanFix lists the town .gitignore and the index change Fix makes.
+func (c *SecretsGitignoreCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if !c.needsIgnore && len(c.tracked) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "gitignore and untrack mayor/secrets/"}
+	if c.needsIgnore {
+		plan.Files = append(plan.Files, filepath.Join(ctx.TownRoot, ".gitignore"))
+	}
+	if len(c.tracked) > 0 {
+		plan.Actions = append(plan.Actions, "git rm --cached "+secretsGitignoreEntry+" (files stay on disk)")
+	}
+	return plan
+}
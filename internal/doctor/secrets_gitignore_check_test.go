@@ -0,0 +1,108 @@
+package doctor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSecret(t *testing.T, townRoot string) string {
+	t.Helper()
+	path := filepath.Join(townRoot, "mayor", "secrets", "anthropic-work")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("sk-test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSecretsGitignoreCheck_NotGitRepo(t *testing.T) {
+	townRoot := t.TempDir()
+	writeSecret(t, townRoot)
+
+	result := NewSecretsGitignoreCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK outside git, got %v: %s", result.Status, result.Message)
+	}
+}
+
+func TestSecretsGitignoreCheck_Ignored(t *testing.T) {
+	townRoot := t.TempDir()
+	initTestGitRepo(t, townRoot)
+	writeSecret(t, townRoot)
+	if err := os.WriteFile(filepath.Join(townRoot, ".gitignore"), []byte("mayor/secrets/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewSecretsGitignoreCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK when ignored, got %v: %s %v", result.Status, result.Message, result.Details)
+	}
+}
+
+func TestSecretsGitignoreCheck_NotIgnored(t *testing.T) {
+	townRoot := t.TempDir()
+	initTestGitRepo(t, townRoot)
+	ctx := &CheckContext{TownRoot: townRoot}
+
+	// No secrets yet: warn, since the next stored key would be exposed.
+	check := NewSecretsGitignoreCheck()
+	if result := check.Run(ctx); result.Status != StatusWarning {
+		t.Errorf("expected StatusWarning without secrets, got %v: %s", result.Status, result.Message)
+	}
+
+	writeSecret(t, townRoot)
+	result := check.Run(ctx)
+	if result.Status != StatusError {
+		t.Fatalf("expected StatusError with unignored secrets, got %v: %s", result.Status, result.Message)
+	}
+	plan := check.PlanFix(ctx)
+	if plan == nil || len(plan.Files) != 1 || plan.Files[0] != filepath.Join(townRoot, ".gitignore") {
+		t.Errorf("PlanFix() = %+v, want town .gitignore", plan)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("expected StatusOK after fix, got %v: %s %v", result.Status, result.Message, result.Details)
+	}
+}
+
+func TestSecretsGitignoreCheck_Tracked(t *testing.T) {
+	townRoot := t.TempDir()
+	initTestGitRepo(t, townRoot)
+	secret := writeSecret(t, townRoot)
+	gitAddAndCommit(t, townRoot, secret)
+	ctx := &CheckContext{TownRoot: townRoot}
+
+	check := NewSecretsGitignoreCheck()
+	result := check.Run(ctx)
+	if result.Status != StatusError || !strings.Contains(result.Message, "1 secret file(s) tracked") {
+		t.Fatalf("expected tracked error, got %v: %s", result.Status, result.Message)
+	}
+	if plan := check.PlanFix(ctx); plan == nil || len(plan.Actions) != 1 {
+		t.Errorf("PlanFix() = %+v, want a git rm --cached action", plan)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	out, err := exec.Command("git", "-C", townRoot, "ls-files", "mayor/secrets").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(out)) != "" {
+		t.Errorf("secret still tracked after fix: %s", out)
+	}
+	if _, err := os.Stat(secret); err != nil {
+		t.Errorf("fix deleted the secret file: %v", err)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("expected StatusOK after fix, got %v: %s %v", result.Status, result.Message, result.Details)
+	}
+}
@@ -177,3 +177,15 @@ func (c *SparseCheckoutCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the repos Fix removes sparse checkout from.
+func (c *SparseCheckoutCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.affectedRepos) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "disable legacy sparse checkout"}
+	for _, repoPath := range c.affectedRepos {
+		plan.Actions = append(plan.Actions, "disable sparse checkout and restore all files in "+repoPath)
+	}
+	return plan
+}
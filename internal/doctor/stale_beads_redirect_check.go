@@ -179,6 +179,22 @@ func (c *StaleBeadsRedirectCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the redirect files Fix writes and the .beads directories it
+// cleans.
+func (c *StaleBeadsRedirectCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.staleLocations) == 0 && len(c.missingRedirects) == 0 && len(c.incorrectRedirects) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "clean stale beads files and repair redirects"}
+	for _, relPath := range c.staleLocations {
+		plan.Actions = append(plan.Actions, "remove stale database files from "+filepath.Join(ctx.TownRoot, relPath))
+	}
+	for _, issue := range append(append([]redirectIssue(nil), c.missingRedirects...), c.incorrectRedirects...) {
+		plan.Files = append(plan.Files, filepath.Join(issue.worktreePath, ".beads", "redirect"))
+	}
+	return plan
+}
+
 // findRigDirs returns all rig directories in the town.
 func findRigDirs(townRoot string) ([]string, error) {
 	var rigs []string
@@ -174,3 +174,15 @@ func (c *StaleTaskDispatchCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the settings.json files Fix regenerates.
+func (c *StaleTaskDispatchCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.staleTargets) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "regenerate hooks in settings.json without the task-dispatch guard"}
+	for _, target := range c.staleTargets {
+		plan.Files = append(plan.Files, target.Path)
+	}
+	return plan
+}
@@ -215,3 +215,15 @@ func (c *TestutilSymlinkCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the testutil copies Fix replaces with symlinks.
+func (c *TestutilSymlinkCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.issues) == 0 {
+		return nil
+	}
+	plan := &FixPlan{Summary: "symlink internal/testutil to the canonical copy"}
+	for _, issue := range c.issues {
+		plan.Actions = append(plan.Actions, "replace "+issue.path+" with a symlink to "+canonicalTestutilPath(ctx.RigPath()))
+	}
+	return plan
+}
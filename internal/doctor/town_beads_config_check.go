@@ -85,3 +85,14 @@ func (c *TownBeadsConfigCheck) Fix(ctx *CheckContext) error {
 	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
 	return beads.EnsureConfigYAMLFromMetadataIfMissing(beadsDir, "hq")
 }
+
+// PlanFix reports the config.yaml that Fix creates.
+func (c *TownBeadsConfigCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if !c.missingConfig {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "create town .beads/config.yaml",
+		Files:   []string{filepath.Join(ctx.TownRoot, ".beads", "config.yaml")},
+	}
+}
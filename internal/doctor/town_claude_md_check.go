@@ -147,6 +147,14 @@ func (c *TownCLAUDEmdCheck) Fix(ctx *CheckContext) error {
 	return os.WriteFile(claudePath, []byte(updated), 0644)
 }
 
+// PlanFix reports the town-root CLAUDE.md that Fix rewrites.
+func (c *TownCLAUDEmdCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	return &FixPlan{
+		Summary: "add missing sections to the town-root CLAUDE.md",
+		Files:   []string{filepath.Join(ctx.TownRoot, "CLAUDE.md")},
+	}
+}
+
 // h2Section represents a section of markdown delimited by H2 headings.
 type h2Section struct {
 	heading string // The H2 heading line (e.g., "## Dolt Server — Operational Awareness")
@@ -116,3 +116,14 @@ func (c *TownRootBranchCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix describes the checkout Fix runs in the town root.
+func (c *TownRootBranchCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if c.currentBranch == "main" || c.currentBranch == "master" {
+		return nil
+	}
+	return &FixPlan{
+		Summary: "switch town root from " + c.currentBranch + " to main",
+		Actions: []string{"git checkout main in " + ctx.TownRoot},
+	}
+}
@@ -178,6 +178,14 @@ func (c *RigsRegistryExistsCheck) Fix(ctx *CheckContext) error {
 	return os.WriteFile(rigsPath, data, 0644)
 }
 
+// PlanFix reports the rigs.json that Fix creates.
+func (c *RigsRegistryExistsCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	return &FixPlan{
+		Summary: "create an empty mayor/rigs.json",
+		Files:   []string{filepath.Join(ctx.TownRoot, "mayor", "rigs.json")},
+	}
+}
+
 // RigsRegistryValidCheck verifies mayor/rigs.json is valid and rigs exist.
 type RigsRegistryValidCheck struct {
 	FixableCheck
@@ -313,6 +321,17 @@ func (c *RigsRegistryValidCheck) Fix(ctx *CheckContext) error {
 	return os.WriteFile(rigsPath, newData, 0644)
 }
 
+// PlanFix reports the rigs.json that Fix rewrites.
+func (c *RigsRegistryValidCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	if len(c.missingRigs) == 0 {
+		return nil
+	}
+	return &FixPlan{
+		Summary: fmt.Sprintf("remove %d missing rig(s) from mayor/rigs.json", len(c.missingRigs)),
+		Files:   []string{filepath.Join(ctx.TownRoot, "mayor", "rigs.json")},
+	}
+}
+
 // MayorExistsCheck verifies the mayor/ directory structure.
 type MayorExistsCheck struct {
 	BaseCheck
@@ -261,6 +261,24 @@ func (c *WorktreeGitdirCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the worktrees Fix re-creates from .repo.git.
+func (c *WorktreeGitdirCheck) PlanFix(ctx *CheckContext) *FixPlan {
+	plan := &FixPlan{Summary: "re-create worktrees with broken gitdir"}
+	for _, bw := range c.brokenWorktrees {
+		if bw.bareRepoPath == "" {
+			continue
+		}
+		if _, err := os.Stat(bw.bareRepoPath); err != nil {
+			continue
+		}
+		plan.Actions = append(plan.Actions, "git -C "+bw.bareRepoPath+" worktree add "+bw.worktreePath)
+	}
+	if len(plan.Actions) == 0 {
+		return nil
+	}
+	return plan
+}
+
 // isRigDir checks if a directory looks like a rig (has config.json or known subdirectories).
 func isRigDir(path string) bool {
 	// Check for config.json (most reliable indicator)
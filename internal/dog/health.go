@@ -70,6 +70,7 @@ func (hc *HealthChecker) Check(d *Dog, maxInactivity time.Duration, autoClear bo
 				if err := hc.mgr.ClearWork(d.Name); err == nil {
 					result.AutoCleared = true
 					result.Recommendation = "zombie auto-cleared (session dead)"
+					_, _ = hc.mgr.Queue().Fail(d.Name, "session died", time.Now())
 				}
 			}
 
@@ -82,6 +83,7 @@ func (hc *HealthChecker) Check(d *Dog, maxInactivity time.Duration, autoClear bo
 				if err := hc.mgr.ClearWork(d.Name); err == nil {
 					result.AutoCleared = true
 					result.Recommendation = "zombie auto-cleared (agent dead, session killed)"
+					_, _ = hc.mgr.Queue().Fail(d.Name, "agent died", time.Now())
 				}
 			}
 
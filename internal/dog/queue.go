@@ -0,0 +1,372 @@
+package dog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gofrs/flock"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Queue defaults.
+const (
+	// DefaultMaxAttempts is how many times a job is dispatched before it is
+	// dead-lettered.
+	DefaultMaxAttempts = 3
+
+	// retryBaseBackoff is the delay before the first retry; each further
+	// retry doubles it, up to retryMaxBackoff.
+	retryBaseBackoff = time.Minute
+	retryMaxBackoff  = 30 * time.Minute
+
+	// DeadLetterTTL is how long a dead-lettered job is kept. Until it
+	// expires, enqueueing the same work returns the dead job instead of
+	// queueing it again.
+	DeadLetterTTL = 7 * 24 * time.Hour
+)
+
+// Queue errors
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobNotDead  = errors.New("job is not dead-lettered")
+)
+
+// JobState represents where a queued job is in its lifecycle.
+type JobState string
+
+const (
+	// JobQueued means the job is waiting for an idle dog.
+	JobQueued JobState = "queued"
+	// JobRunning means the job is assigned to a dog.
+	JobRunning JobState = "running"
+	// JobDead means the job ran out of attempts or missed its deadline.
+	JobDead JobState = "dead"
+)
+
+// Job is a unit of dog work waiting in (or dispatched from) the queue.
+type Job struct {
+	ID            string    `json:"id"`
+	Work          string    `json:"work"`              // Work description assigned to the dog (e.g., "plugin:rebuild-gt")
+	Subject       string    `json:"subject,omitempty"` // Mail subject sent to the dog on dispatch
+	Body          string    `json:"body,omitempty"`    // Mail body with instructions; no mail is sent if empty
+	Priority      int       `json:"priority"`          // Higher runs first
+	Deadline      time.Time `json:"deadline,omitempty"`
+	State         JobState  `json:"state"`
+	Dog           string    `json:"dog,omitempty"` // Dog running the job
+	Attempts      int       `json:"attempts"`
+	MaxAttempts   int       `json:"max_attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at,omitempty"`
+	LastError     string    `json:"last_error,omitempty"`
+	EnqueuedAt    time.Time `json:"enqueued_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// queueFile is the persistent form of the queue.
+type queueFile struct {
+	NextID int    `json:"next_id"`
+	Jobs   []*Job `json:"jobs"`
+}
+
+// Queue is the town's persistent dog job queue. Work that cannot be
+// dispatched because every dog is busy waits here until the daemon drains
+// it. It is stored in deacon/dog-queue.json.
+type Queue struct {
+	path     string
+	lockPath string
+}
+
+// NewQueue returns the dog job queue for a town.
+func NewQueue(townRoot string) *Queue {
+	dir := filepath.Join(townRoot, "deacon")
+	return &Queue{
+		path:     filepath.Join(dir, "dog-queue.json"),
+		lockPath: filepath.Join(dir, ".dog-queue.lock"),
+	}
+}
+
+// Queue returns the job queue for the manager's town.
+func (m *Manager) Queue() *Queue {
+	return NewQueue(m.townRoot)
+}
+
+// RetryBackoff returns the delay before retrying a job that has failed
+// attempts times.
+func RetryBackoff(attempts int) time.Duration {
+	d := retryBaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= retryMaxBackoff {
+			return retryMaxBackoff
+		}
+	}
+	return d
+}
+
+// update runs fn on the queue under an exclusive file lock and saves the
+// result.
+func (q *Queue) update(fn func(qf *queueFile) error) error {
+	if err := os.MkdirAll(filepath.Dir(q.path), 0755); err != nil {
+		return fmt.Errorf("creating queue dir: %w", err)
+	}
+	fl := flock.New(q.lockPath)
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("acquiring dog queue lock: %w", err)
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	qf, err := q.load()
+	if err != nil {
+		return err
+	}
+	pruneDeadLetters(qf, time.Now())
+	if err := fn(qf); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(q.path, qf)
+}
+
+// pruneDeadLetters drops dead jobs that have been dead for DeadLetterTTL.
+func pruneDeadLetters(qf *queueFile, now time.Time) {
+	kept := qf.Jobs[:0]
+	for _, j := range qf.Jobs {
+		if j.State == JobDead && now.Sub(j.UpdatedAt) > DeadLetterTTL {
+			continue
+		}
+		kept = append(kept, j)
+	}
+	qf.Jobs = kept
+}
+
+// load reads the queue file. A missing file is an empty queue.
+func (q *Queue) load() (*queueFile, error) {
+	data, err := os.ReadFile(q.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &queueFile{NextID: 1}, nil
+		}
+		return nil, fmt.Errorf("reading dog queue: %w", err)
+	}
+	var qf queueFile
+	if err := json.Unmarshal(data, &qf); err != nil {
+		return nil, fmt.Errorf("parsing dog queue: %w", err)
+	}
+	if qf.NextID < 1 {
+		qf.NextID = 1
+	}
+	return &qf, nil
+}
+
+// Enqueue adds a job to the queue. Only Work is required; Priority,
+// Deadline, MaxAttempts, Subject and Body are taken from job.
+// If a job with the same Work already exists, that job is returned instead,
+// so repeated dispatch attempts do not pile up duplicates. This includes
+// dead letters: work that keeps failing stays dead until it is retried or
+// expires after DeadLetterTTL, rather than being requeued on every heartbeat.
+func (q *Queue) Enqueue(job Job) (*Job, error) {
+	if job.Work == "" {
+		return nil, fmt.Errorf("job work cannot be empty")
+	}
+	var result *Job
+	err := q.update(func(qf *queueFile) error {
+		for _, existing := range qf.Jobs {
+			if existing.Work == job.Work {
+				result = existing
+				return nil
+			}
+		}
+		now := time.Now()
+		j := job
+		j.ID = fmt.Sprintf("dj-%d", qf.NextID)
+		j.State = JobQueued
+		j.Dog = ""
+		j.Attempts = 0
+		j.NextAttemptAt = time.Time{}
+		j.LastError = ""
+		if j.MaxAttempts <= 0 {
+			j.MaxAttempts = DefaultMaxAttempts
+		}
+		j.EnqueuedAt = now
+		j.UpdatedAt = now
+		qf.NextID++
+		qf.Jobs = append(qf.Jobs, &j)
+		result = &j
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// List returns all jobs: running first, then queued in dispatch order, then
+// dead letters.
+func (q *Queue) List() ([]*Job, error) {
+	qf, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	jobs := qf.Jobs
+	rank := map[JobState]int{JobRunning: 0, JobQueued: 1, JobDead: 2}
+	sort.SliceStable(jobs, func(i, k int) bool {
+		if rank[jobs[i].State] != rank[jobs[k].State] {
+			return rank[jobs[i].State] < rank[jobs[k].State]
+		}
+		return dispatchesBefore(jobs[i], jobs[k])
+	})
+	return jobs, nil
+}
+
+// dispatchesBefore orders jobs by priority, then deadline (earliest first,
+// jobs without one last), then enqueue time.
+func dispatchesBefore(a, b *Job) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if !a.Deadline.Equal(b.Deadline) {
+		if a.Deadline.IsZero() || b.Deadline.IsZero() {
+			return !a.Deadline.IsZero()
+		}
+		return a.Deadline.Before(b.Deadline)
+	}
+	return a.EnqueuedAt.Before(b.EnqueuedAt)
+}
+
+// Claim assigns the next ready job to dogName and marks it running. Queued
+// jobs whose deadline has passed are dead-lettered along the way. Returns
+// nil if no job is ready.
+func (q *Queue) Claim(dogName string, now time.Time) (*Job, error) {
+	var claimed *Job
+	err := q.update(func(qf *queueFile) error {
+		for _, j := range qf.Jobs {
+			if j.State != JobQueued {
+				continue
+			}
+			if !j.Deadline.IsZero() && now.After(j.Deadline) {
+				j.State = JobDead
+				j.LastError = "deadline exceeded before dispatch"
+				j.UpdatedAt = now
+				continue
+			}
+			if now.Before(j.NextAttemptAt) {
+				continue
+			}
+			if claimed == nil || dispatchesBefore(j, claimed) {
+				claimed = j
+			}
+		}
+		if claimed == nil {
+			return nil
+		}
+		claimed.State = JobRunning
+		claimed.Dog = dogName
+		claimed.Attempts++
+		claimed.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// Complete removes the job running on dogName. It is a no-op if the dog's
+// work did not come from the queue.
+func (q *Queue) Complete(dogName string) error {
+	return q.update(func(qf *queueFile) error {
+		kept := qf.Jobs[:0]
+		for _, j := range qf.Jobs {
+			if j.State == JobRunning && j.Dog == dogName {
+				continue
+			}
+			kept = append(kept, j)
+		}
+		qf.Jobs = kept
+		return nil
+	})
+}
+
+// Fail records a failed attempt for the job running on dogName. The job is
+// requeued with exponential backoff, or dead-lettered once it has used all
+// its attempts or its deadline has passed. Returns the updated job, or nil
+// if the dog was not running a queued job.
+func (q *Queue) Fail(dogName, reason string, now time.Time) (*Job, error) {
+	var failed *Job
+	err := q.update(func(qf *queueFile) error {
+		for _, j := range qf.Jobs {
+			if j.State != JobRunning || j.Dog != dogName {
+				continue
+			}
+			j.Dog = ""
+			j.LastError = reason
+			j.UpdatedAt = now
+			switch {
+			case j.Attempts >= j.MaxAttempts:
+				j.State = JobDead
+			case !j.Deadline.IsZero() && now.After(j.Deadline):
+				j.State = JobDead
+				j.LastError = reason + " (deadline exceeded)"
+			default:
+				j.State = JobQueued
+				j.NextAttemptAt = now.Add(RetryBackoff(j.Attempts))
+			}
+			failed = j
+			return nil
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return failed, nil
+}
+
+// Retry moves a dead-lettered job back to the queue with a fresh set of
+// attempts. An expired deadline is cleared so the job can run.
+func (q *Queue) Retry(id string) (*Job, error) {
+	var retried *Job
+	err := q.update(func(qf *queueFile) error {
+		for _, j := range qf.Jobs {
+			if j.ID != id {
+				continue
+			}
+			if j.State != JobDead {
+				return fmt.Errorf("%w: %s is %s", ErrJobNotDead, id, j.State)
+			}
+			now := time.Now()
+			j.State = JobQueued
+			j.Attempts = 0
+			j.NextAttemptAt = time.Time{}
+			if !j.Deadline.IsZero() && now.After(j.Deadline) {
+				j.Deadline = time.Time{}
+			}
+			j.UpdatedAt = now
+			retried = j
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retried, nil
+}
+
+// Drop removes a job from the queue regardless of its state. Dropping a
+// running job does not stop the dog; it only forgets the job.
+func (q *Queue) Drop(id string) error {
+	return q.update(func(qf *queueFile) error {
+		for i, j := range qf.Jobs {
+			if j.ID == id {
+				qf.Jobs = append(qf.Jobs[:i], qf.Jobs[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	})
+}
@@ -0,0 +1,204 @@
+package dog
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func enqueue(t *testing.T, q *Queue, job Job) *Job {
+	t.Helper()
+	j, err := q.Enqueue(job)
+	if err != nil {
+		t.Fatalf("Enqueue(%s) error: %v", job.Work, err)
+	}
+	return j
+}
+
+func TestQueueEnqueueDeduplicatesActiveWork(t *testing.T) {
+	t.Parallel()
+	q := NewQueue(t.TempDir())
+
+	first := enqueue(t, q, Job{Work: "plugin:rebuild-gt"})
+	if first.ID != "dj-1" || first.State != JobQueued || first.MaxAttempts != DefaultMaxAttempts {
+		t.Errorf("Enqueue() = %+v, want dj-1 queued with default attempts", first)
+	}
+	again := enqueue(t, q, Job{Work: "plugin:rebuild-gt", Priority: 5})
+	if again.ID != first.ID {
+		t.Errorf("duplicate Enqueue() created %s, want existing %s", again.ID, first.ID)
+	}
+
+	if _, err := q.Enqueue(Job{}); err == nil {
+		t.Error("Enqueue() accepted a job without work")
+	}
+}
+
+func TestQueueClaimOrder(t *testing.T) {
+	t.Parallel()
+	q := NewQueue(t.TempDir())
+	now := time.Now()
+
+	enqueue(t, q, Job{Work: "low"})
+	enqueue(t, q, Job{Work: "high-late", Priority: 10})
+	enqueue(t, q, Job{Work: "high-deadline", Priority: 10, Deadline: now.Add(time.Hour)})
+
+	var got []string
+	for _, name := range []string{"alpha", "bravo", "charlie", "delta"} {
+		j, err := q.Claim(name, now)
+		if err != nil {
+			t.Fatalf("Claim() error: %v", err)
+		}
+		if j == nil {
+			break
+		}
+		if j.State != JobRunning || j.Dog != name || j.Attempts != 1 {
+			t.Errorf("claimed job = %+v, want running on %s with 1 attempt", j, name)
+		}
+		got = append(got, j.Work)
+	}
+	want := []string{"high-deadline", "high-late", "low"}
+	if len(got) != len(want) {
+		t.Fatalf("claimed %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("claimed %v, want %v", got, want)
+		}
+	}
+}
+
+func TestQueueFailRetriesWithBackoffThenDeadLetters(t *testing.T) {
+	t.Parallel()
+	q := NewQueue(t.TempDir())
+	now := time.Now()
+	enqueue(t, q, Job{Work: "plugin:dolt-gc", MaxAttempts: 2})
+
+	if _, err := q.Claim("alpha", now); err != nil {
+		t.Fatal(err)
+	}
+	j, err := q.Fail("alpha", "session died", now)
+	if err != nil {
+		t.Fatalf("Fail() error: %v", err)
+	}
+	if j.State != JobQueued || !j.NextAttemptAt.Equal(now.Add(RetryBackoff(1))) || j.LastError != "session died" {
+		t.Errorf("after first failure job = %+v, want queued with backoff", j)
+	}
+
+	if j, _ := q.Claim("alpha", now); j != nil {
+		t.Error("Claim() returned a job still in backoff")
+	}
+	later := now.Add(RetryBackoff(1))
+	if j, _ := q.Claim("alpha", later); j == nil || j.Attempts != 2 {
+		t.Fatalf("Claim() after backoff = %+v, want second attempt", j)
+	}
+	j, _ = q.Fail("alpha", "session died", later)
+	if j.State != JobDead {
+		t.Errorf("after last attempt state = %s, want dead", j.State)
+	}
+
+	if j, _ := q.Fail("bravo", "whatever", now); j != nil {
+		t.Error("Fail() for a dog without a queued job returned a job")
+	}
+}
+
+func TestQueueDeadLettersBlockRequeueUntilExpired(t *testing.T) {
+	t.Parallel()
+	q := NewQueue(t.TempDir())
+	now := time.Now()
+	enqueue(t, q, Job{Work: "plugin:broken", MaxAttempts: 1})
+	if _, err := q.Claim("alpha", now); err != nil {
+		t.Fatal(err)
+	}
+	dead, err := q.Fail("alpha", "exit 1", now)
+	if err != nil || dead.State != JobDead {
+		t.Fatalf("Fail() = %+v, %v; want dead", dead, err)
+	}
+
+	// The next heartbeat's dispatch must not queue the work again.
+	again := enqueue(t, q, Job{Work: "plugin:broken"})
+	if again.ID != dead.ID || again.State != JobDead {
+		t.Errorf("Enqueue() after dead letter = %+v, want existing dead %s", again, dead.ID)
+	}
+
+	// Once the dead letter expires it is pruned and the work can run again.
+	if err := q.update(func(qf *queueFile) error {
+		qf.Jobs[0].UpdatedAt = now.Add(-DeadLetterTTL - time.Minute)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	fresh := enqueue(t, q, Job{Work: "plugin:broken"})
+	if fresh.ID == dead.ID || fresh.State != JobQueued {
+		t.Errorf("Enqueue() after expiry = %+v, want a new queued job", fresh)
+	}
+	if jobs, _ := q.List(); len(jobs) != 1 {
+		t.Errorf("queue has %d jobs, want expired dead letter pruned", len(jobs))
+	}
+}
+
+func TestQueueDeadlineDeadLettersUndispatchedJobs(t *testing.T) {
+	t.Parallel()
+	q := NewQueue(t.TempDir())
+	now := time.Now()
+	expired := enqueue(t, q, Job{Work: "expired", Deadline: now.Add(-time.Minute)})
+
+	if j, _ := q.Claim("alpha", now); j != nil {
+		t.Errorf("Claim() dispatched %s past its deadline", j.Work)
+	}
+	jobs, _ := q.List()
+	if len(jobs) != 1 || jobs[0].State != JobDead {
+		t.Fatalf("jobs = %+v, want the expired job dead-lettered", jobs)
+	}
+
+	retried, err := q.Retry(expired.ID)
+	if err != nil {
+		t.Fatalf("Retry() error: %v", err)
+	}
+	if retried.State != JobQueued || !retried.Deadline.IsZero() {
+		t.Errorf("Retry() = %+v, want queued without the expired deadline", retried)
+	}
+	if _, err := q.Retry(expired.ID); !errors.Is(err, ErrJobNotDead) {
+		t.Errorf("Retry() of a queued job error = %v, want ErrJobNotDead", err)
+	}
+}
+
+func TestQueueCompleteAndDrop(t *testing.T) {
+	t.Parallel()
+	q := NewQueue(t.TempDir())
+	enqueue(t, q, Job{Work: "a"})
+	b := enqueue(t, q, Job{Work: "b"})
+
+	if _, err := q.Claim("alpha", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Complete("alpha"); err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if err := q.Drop(b.ID); err != nil {
+		t.Fatalf("Drop() error: %v", err)
+	}
+	if jobs, _ := q.List(); len(jobs) != 0 {
+		t.Errorf("queue has %d jobs, want none", len(jobs))
+	}
+	if err := q.Drop(b.ID); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Drop() of a missing job error = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{10, 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := RetryBackoff(tt.attempts); got != tt.want {
+			t.Errorf("RetryBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
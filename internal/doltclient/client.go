@@ -0,0 +1,339 @@
+// Package doltclient is a pooled, context-aware SQL client for the town's
+// Dolt server.
+//
+// It replaces `dolt sql` / `bd sql` subprocesses and hand-parsed CSV on hot
+// paths. Clients for the same server, user, and database share one
+// connection pool for the life of the process; queries retry on Dolt
+// optimistic-lock errors and are counted in per-client stats and telemetry.
+package doltclient
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/steveyegge/gastown/internal/telemetry"
+)
+
+const (
+	defaultHost         = "127.0.0.1"
+	defaultPort         = 3307
+	defaultUser         = "root"
+	defaultMaxOpenConns = 4
+	// connMaxIdleTime closes idle connections so a quiet daemon doesn't pin
+	// server connection slots between patrols.
+	connMaxIdleTime = time.Minute
+)
+
+// Config identifies a Dolt server and database. Zero fields take defaults.
+type Config struct {
+	Host     string // default 127.0.0.1
+	Port     int    // default 3307
+	User     string // default root
+	Password string
+	// Database is the default database. Empty means none; queries must
+	// qualify table names or USE one (in a Script).
+	Database string
+	// MaxOpenConns caps the pool (default 4).
+	MaxOpenConns int
+	// Retry controls retries on optimistic-lock errors (default DefaultRetry).
+	Retry RetryPolicy
+}
+
+// RetryPolicy controls how operations retry on transient Dolt write conflicts.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Retryable classifies errors worth retrying (default IsOptimisticLockError).
+	Retryable func(error) bool
+}
+
+// DefaultRetry is the retry policy used when Config.Retry is zero.
+var DefaultRetry = RetryPolicy{MaxAttempts: 3, BaseBackoff: 250 * time.Millisecond, MaxBackoff: 4 * time.Second}
+
+// backoff returns the delay before the given retry (1-based).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return d
+}
+
+func (c Config) withDefaults() Config {
+	if c.Host == "" {
+		c.Host = defaultHost
+	}
+	if c.Port == 0 {
+		c.Port = defaultPort
+	}
+	if c.User == "" {
+		c.User = defaultUser
+	}
+	if c.MaxOpenConns <= 0 {
+		c.MaxOpenConns = defaultMaxOpenConns
+	}
+	if c.Retry.MaxAttempts <= 0 {
+		retryable := c.Retry.Retryable
+		c.Retry = DefaultRetry
+		c.Retry.Retryable = retryable
+	}
+	if c.Retry.Retryable == nil {
+		c.Retry.Retryable = IsOptimisticLockError
+	}
+	return c
+}
+
+// DSN returns the MySQL driver DSN for the config. Multi-statement queries
+// are enabled so Script can run a whole script in one round trip.
+func (c Config) DSN() string {
+	c = c.withDefaults()
+	mc := mysql.NewConfig()
+	mc.User = c.User
+	mc.Passwd = c.Password
+	mc.Net = "tcp"
+	mc.Addr = c.Host + ":" + strconv.Itoa(c.Port)
+	mc.DBName = c.Database
+	mc.Timeout = 5 * time.Second
+	mc.ReadTimeout = 60 * time.Second
+	mc.WriteTimeout = 60 * time.Second
+	mc.MultiStatements = true
+	return mc.FormatDSN()
+}
+
+// Stats is a snapshot of a client's query counters.
+type Stats struct {
+	Queries int64
+	Errors  int64
+	Retries int64
+	Total   time.Duration
+}
+
+// Client runs queries against one Dolt database over a shared pool.
+type Client struct {
+	db  *sql.DB
+	cfg Config
+
+	queries atomic.Int64
+	errors  atomic.Int64
+	retries atomic.Int64
+	totalNs atomic.Int64
+}
+
+var pools = struct {
+	mu sync.Mutex
+	m  map[string]*sql.DB
+}{m: make(map[string]*sql.DB)}
+
+// Open returns a client for cfg. Clients with the same DSN share one
+// connection pool, created on first use with that Open's MaxOpenConns, which
+// lives until CloseAll; callers do not close clients. Retry policy and stats
+// are per client.
+func Open(cfg Config) (*Client, error) {
+	cfg = cfg.withDefaults()
+	dsn := cfg.DSN()
+
+	pools.mu.Lock()
+	defer pools.mu.Unlock()
+	db, ok := pools.m[dsn]
+	if !ok {
+		var err error
+		db, err = sql.Open("mysql", dsn)
+		if err != nil {
+			return nil, fmt.Errorf("opening dolt pool for %s:%d: %w", cfg.Host, cfg.Port, err)
+		}
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.MaxOpenConns)
+		db.SetConnMaxIdleTime(connMaxIdleTime)
+		pools.m[dsn] = db
+	}
+	return &Client{db: db, cfg: cfg}, nil
+}
+
+// CloseAll closes every pool. Clients obtained earlier must not be reused.
+func CloseAll() {
+	pools.mu.Lock()
+	defer pools.mu.Unlock()
+	for dsn, db := range pools.m {
+		_ = db.Close()
+		delete(pools.m, dsn)
+	}
+}
+
+// DB returns the underlying pool for callers that need database/sql directly.
+// Do not close it.
+func (c *Client) DB() *sql.DB { return c.db }
+
+// Database returns the client's default database.
+func (c *Client) Database() string { return c.cfg.Database }
+
+// Stats returns a snapshot of the client's query counters.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Queries: c.queries.Load(),
+		Errors:  c.errors.Load(),
+		Retries: c.retries.Load(),
+		Total:   time.Duration(c.totalNs.Load()),
+	}
+}
+
+// Exec runs a statement that returns no rows.
+func (c *Client) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	err := c.do(ctx, "exec", func() error {
+		var err error
+		res, err = c.db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return res, err
+}
+
+// QueryInt runs a query returning a single integer, such as COUNT(*).
+func (c *Client) QueryInt(ctx context.Context, query string, args ...any) (int64, error) {
+	var n sql.NullInt64
+	err := c.do(ctx, "query", func() error {
+		return c.db.QueryRowContext(ctx, query, args...).Scan(&n)
+	})
+	return n.Int64, err
+}
+
+// TableExists reports whether table exists in the client's database.
+func (c *Client) TableExists(ctx context.Context, table string) (bool, error) {
+	n, err := c.QueryInt(ctx,
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?", table)
+	return n > 0, err
+}
+
+// Script runs a multi-statement script on one connection, so session state
+// such as USE or DOLT_CHECKOUT carries across statements. The connection is
+// discarded afterwards rather than returned to the pool with that state.
+// The whole script is retried on optimistic-lock errors, so it must be
+// idempotent.
+func (c *Client) Script(ctx context.Context, script string) error {
+	return c.do(ctx, "script", func() error {
+		conn, err := c.db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, execErr := conn.ExecContext(ctx, script)
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		return execErr
+	})
+}
+
+// Tx runs fn in a transaction and commits it if fn succeeds. The transaction
+// is rolled back and fn re-run from scratch on optimistic-lock errors, so fn
+// must not have side effects outside tx.
+func (c *Client) Tx(ctx context.Context, fn func(*sql.Tx) error) error {
+	return c.do(ctx, "tx", func() error {
+		tx, err := c.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// Scanner is the subset of *sql.Rows used by Select scan functions.
+type Scanner interface {
+	Scan(dest ...any) error
+}
+
+// Select runs a query and scans each row with scan. All rows are read
+// before returning, so the query is safe to retry.
+func Select[T any](ctx context.Context, c *Client, scan func(Scanner) (T, error), query string, args ...any) ([]T, error) {
+	var out []T
+	err := c.do(ctx, "query", func() error {
+		out = out[:0]
+		rows, err := c.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			v, err := scan(rows)
+			if err != nil {
+				return err
+			}
+			out = append(out, v)
+		}
+		return rows.Err()
+	})
+	return out, err
+}
+
+// do runs fn with retry on retryable errors and records metrics.
+func (c *Client) do(ctx context.Context, op string, fn func() error) error {
+	start := time.Now()
+	retries := 0
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || !c.cfg.Retry.Retryable(err) || attempt >= c.cfg.Retry.MaxAttempts {
+			break
+		}
+		retries++
+		if waitErr := sleepCtx(ctx, c.cfg.Retry.backoff(retries)); waitErr != nil {
+			err = errors.Join(err, waitErr)
+			break
+		}
+	}
+
+	elapsed := time.Since(start)
+	c.queries.Add(1)
+	c.retries.Add(int64(retries))
+	c.totalNs.Add(int64(elapsed))
+	if err != nil {
+		c.errors.Add(1)
+		if retries > 0 {
+			err = fmt.Errorf("after %d retries: %w", retries, err)
+		}
+	}
+	telemetry.RecordDoltQuery(ctx, op, c.cfg.Database, float64(elapsed.Microseconds())/1000, retries, err)
+	return err
+}
+
+// sleepCtx waits for d or until ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// IsOptimisticLockError reports whether err is a transient Dolt write
+// conflict or serialization failure that is worth retrying.
+func IsOptimisticLockError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "optimistic lock") ||
+		strings.Contains(msg, "serialization failure") ||
+		strings.Contains(msg, "lock wait timeout") ||
+		strings.Contains(msg, "try restarting transaction") ||
+		strings.Contains(msg, "database is read only") ||
+		strings.Contains(msg, "cannot update manifest")
+}
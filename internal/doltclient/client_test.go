@@ -0,0 +1,138 @@
+package doltclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigDSN(t *testing.T) {
+	dsn := Config{Password: "p@ss:word", Database: "gastown"}.DSN()
+	for _, want := range []string{"root:p@ss:word@tcp(127.0.0.1:3307)/gastown", "multiStatements=true", "timeout=5s"} {
+		if !strings.Contains(dsn, want) {
+			t.Errorf("DSN %q missing %q", dsn, want)
+		}
+	}
+	if dsn := (Config{Host: "dolt.internal", Port: 4000, User: "gt"}).DSN(); !strings.HasPrefix(dsn, "gt@tcp(dolt.internal:4000)/") {
+		t.Errorf("DSN = %q", dsn)
+	}
+}
+
+func TestOpen_SharesPoolPerDSN(t *testing.T) {
+	defer CloseAll()
+	a, err := Open(Config{Database: "hq"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Open(Config{Database: "hq", Retry: RetryPolicy{MaxAttempts: 1}})
+	c, _ := Open(Config{Database: "gastown"})
+	if a.DB() != b.DB() {
+		t.Error("same DSN should share a pool")
+	}
+	if a.DB() == c.DB() {
+		t.Error("different databases should not share a pool")
+	}
+	if b.cfg.Retry.MaxAttempts != 1 || a.cfg.Retry.MaxAttempts != DefaultRetry.MaxAttempts {
+		t.Error("retry policy should be per client")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: 350 * time.Millisecond}
+	for retry, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 350 * time.Millisecond, 9: 350 * time.Millisecond} {
+		if got := p.backoff(retry); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", retry, got, want)
+		}
+	}
+}
+
+func testClient(maxAttempts int) *Client {
+	cfg := Config{Retry: RetryPolicy{MaxAttempts: maxAttempts, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}}
+	return &Client{cfg: cfg.withDefaults()}
+}
+
+func TestDo_RetriesOptimisticLockErrors(t *testing.T) {
+	c := testClient(3)
+	calls := 0
+	err := c.do(context.Background(), "exec", func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("Error 1105: optimistic lock failed on database Root update")
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("err = %v, calls = %d", err, calls)
+	}
+	if s := c.Stats(); s.Queries != 1 || s.Retries != 2 || s.Errors != 0 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestDo_GivesUpAfterMaxAttempts(t *testing.T) {
+	c := testClient(2)
+	calls := 0
+	err := c.do(context.Background(), "exec", func() error {
+		calls++
+		return errors.New("serialization failure")
+	})
+	if calls != 2 || err == nil || !strings.Contains(err.Error(), "after 1 retries") {
+		t.Errorf("err = %v, calls = %d", err, calls)
+	}
+	if s := c.Stats(); s.Errors != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestDo_DoesNotRetryOtherErrors(t *testing.T) {
+	c := testClient(3)
+	calls := 0
+	err := c.do(context.Background(), "query", func() error {
+		calls++
+		return errors.New("Error 1146: table not found: wisps")
+	})
+	if calls != 1 || err == nil || strings.Contains(err.Error(), "retries") {
+		t.Errorf("err = %v, calls = %d", err, calls)
+	}
+}
+
+func TestDo_CustomRetryable(t *testing.T) {
+	cfg := Config{Retry: RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond,
+		Retryable: func(err error) bool { return strings.Contains(err.Error(), "Unknown database") }}}
+	c := &Client{cfg: cfg.withDefaults()}
+	calls := 0
+	_ = c.do(context.Background(), "script", func() error {
+		calls++
+		return errors.New("Unknown database 'wl_commons'")
+	})
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestDo_StopsOnContextCancel(t *testing.T) {
+	cfg := Config{Retry: RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Hour, MaxBackoff: time.Hour}}
+	c := &Client{cfg: cfg.withDefaults()}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := c.do(ctx, "exec", func() error { return errors.New("optimistic lock") })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestIsOptimisticLockError(t *testing.T) {
+	if IsOptimisticLockError(nil) {
+		t.Error("nil is not a lock error")
+	}
+	for _, msg := range []string{"optimistic lock failed", "lock wait timeout exceeded; try restarting transaction", "cannot update manifest"} {
+		if !IsOptimisticLockError(errors.New(msg)) {
+			t.Errorf("%q should be retryable", msg)
+		}
+	}
+	if IsOptimisticLockError(errors.New("syntax error")) {
+		t.Error("syntax error is not a lock error")
+	}
+}
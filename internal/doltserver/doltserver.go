@@ -48,6 +48,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltclient"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/util"
 )
@@ -291,6 +292,31 @@ func (c *Config) HostPort() string {
 	return fmt.Sprintf("%s:%d", host, c.Port)
 }
 
+// ClientConfig returns the pooled SQL client config for database on this
+// server. It retries the same transient errors as doltSQLWithRetry,
+// including the post-CREATE DATABASE catalog race.
+func (c *Config) ClientConfig(database string) doltclient.Config {
+	return doltclient.Config{
+		Host:     c.Host,
+		Port:     c.Port,
+		User:     c.User,
+		Password: c.Password,
+		Database: database,
+		Retry: doltclient.RetryPolicy{
+			MaxAttempts: doltclient.DefaultRetry.MaxAttempts,
+			BaseBackoff: 500 * time.Millisecond,
+			MaxBackoff:  8 * time.Second,
+			Retryable:   isDoltRetryableError,
+		},
+	}
+}
+
+// Client returns the pooled SQL client for database on the town's Dolt
+// server. An empty database connects without a default database.
+func Client(townRoot, database string) (*doltclient.Client, error) {
+	return doltclient.Open(DefaultConfig(townRoot).ClientConfig(database))
+}
+
 // buildDoltSQLCmd constructs a dolt sql command that works for both local and remote servers.
 // For local: runs from config.DataDir so dolt auto-detects the running server.
 // For remote: prepends connection flags and passes password via DOLT_CLI_PASSWORD env var.
@@ -2503,6 +2529,42 @@ type HealthMetrics struct {
 	Warnings []string `json:"warnings,omitempty"`
 }
 
+// HealthSnapshot is the daemon's latest HealthMetrics, persisted so other gt
+// processes (e.g. the scheduler's dispatch cycle) can use it without probing
+// the server again.
+type HealthSnapshot struct {
+	HealthMetrics
+	SampledAt time.Time `json:"sampled_at"`
+}
+
+// HealthSnapshotFile returns the path to the persisted health snapshot.
+func HealthSnapshotFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "dolt-health.json")
+}
+
+// SaveHealthSnapshot persists health metrics sampled now.
+func SaveHealthSnapshot(townRoot string, h *HealthMetrics) error {
+	path := HealthSnapshotFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, HealthSnapshot{HealthMetrics: *h, SampledAt: time.Now().UTC()})
+}
+
+// LoadHealthSnapshot returns the persisted health snapshot, or nil if there
+// is none or it is older than maxAge.
+func LoadHealthSnapshot(townRoot string, maxAge time.Duration) *HealthSnapshot {
+	data, err := os.ReadFile(HealthSnapshotFile(townRoot))
+	if err != nil {
+		return nil
+	}
+	var snap HealthSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil || time.Since(snap.SampledAt) > maxAge {
+		return nil
+	}
+	return &snap
+}
+
 // GetHealthMetrics collects resource monitoring metrics from the Dolt server.
 // Returns partial metrics if some checks fail — always returns what it can.
 func GetHealthMetrics(townRoot string) *HealthMetrics {
@@ -2930,65 +2992,3 @@ func CommitServerWorkingSet(townRoot, rigDB, message string) error {
 	return nil
 }
 
-// doltSQLScript executes a multi-statement SQL script via a temp file.
-// Uses `dolt sql --file` for reliable multi-statement execution within a
-// single connection, preserving DOLT_CHECKOUT state across statements.
-func doltSQLScript(townRoot, script string) error {
-	config := DefaultConfig(townRoot)
-
-	tmpFile, err := os.CreateTemp("", "dolt-script-*.sql")
-	if err != nil {
-		return fmt.Errorf("creating temp SQL file: %w", err)
-	}
-	defer os.Remove(tmpFile.Name())
-
-	if _, err := tmpFile.WriteString(script); err != nil {
-		tmpFile.Close()
-		return fmt.Errorf("writing SQL script: %w", err)
-	}
-	tmpFile.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	cmd := buildDoltSQLCmd(ctx, config, "--file", tmpFile.Name())
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output)))
-	}
-	return nil
-}
-
-// doltSQLScriptWithRetry executes a SQL script with exponential backoff on transient errors.
-// Callers must ensure scripts are idempotent, as partial execution may have occurred
-// before the retry. Uses the same retry classification as doltSQLWithRetry but with
-// fewer retries and shorter backoff since multi-statement scripts are more expensive.
-func doltSQLScriptWithRetry(townRoot, script string) error {
-	const maxRetries = 3
-	const baseBackoff = 500 * time.Millisecond
-	const maxBackoff = 8 * time.Second
-
-	var lastErr error
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if err := doltSQLScript(townRoot, script); err != nil {
-			lastErr = err
-			if !isDoltRetryableError(err) {
-				return err
-			}
-			if attempt < maxRetries {
-				backoff := baseBackoff
-				for i := 1; i < attempt; i++ {
-					backoff *= 2
-					if backoff > maxBackoff {
-						backoff = maxBackoff
-						break
-					}
-				}
-				time.Sleep(backoff)
-			}
-			continue
-		}
-		return nil
-	}
-	return fmt.Errorf("after %d retries: %w", maxRetries, lastErr)
-}
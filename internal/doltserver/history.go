@@ -0,0 +1,870 @@
+// Package doltserver - history.go exposes bead history from Dolt commits.
+//
+// The dolt_diff_<table> system tables give per-commit, per-row diffs, which
+// is enough to show field-level history for a bead, revert a bead to an
+// earlier commit, and undo a burst of writes by one actor without restoring
+// the whole database. Writes are attributed to agents through bd's events
+// table rather than the commit's committer, since agents running with Dolt
+// auto-commit off have their writes swept into commits made by others.
+package doltserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltclient"
+)
+
+// Diff kinds reported by dolt_diff_<table>.diff_type.
+const (
+	DiffAdded    = "added"
+	DiffModified = "modified"
+	DiffRemoved  = "removed"
+)
+
+// LabelsField is the pseudo-field used to report label changes alongside
+// issue columns.
+const LabelsField = "labels"
+
+// historyIgnoredFields are issue columns that change on every write and
+// carry no meaning of their own.
+var historyIgnoredFields = map[string]bool{
+	"id":           true,
+	"content_hash": true,
+	"updated_at":   true,
+}
+
+// doltHashRe matches a full Dolt commit hash (base32, 32 chars);
+// doltHashPrefixRe matches an abbreviated one.
+var (
+	doltHashRe       = regexp.MustCompile(`^[0-9a-v]{32}$`)
+	doltHashPrefixRe = regexp.MustCompile(`^[0-9a-v]{4,32}$`)
+)
+
+// Row is a table row keyed by column name. A nil value is SQL NULL.
+type Row map[string]*string
+
+// Commit identifies a Dolt commit.
+type Commit struct {
+	Hash      string    `json:"hash"`
+	Committer string    `json:"committer"`
+	Date      time.Time `json:"date"`
+	Message   string    `json:"message"`
+}
+
+// FieldChange is one field's transition. A nil From/To is SQL NULL (or
+// absent, for labels).
+type FieldChange struct {
+	Field string  `json:"field"`
+	From  *string `json:"from"`
+	To    *string `json:"to"`
+}
+
+// BeadRevision is one commit's effect on a bead.
+type BeadRevision struct {
+	Commit  Commit        `json:"commit"`
+	Kind    string        `json:"kind"` // added, modified, removed
+	Changes []FieldChange `json:"changes"`
+}
+
+// BeadWrite is a raw row change to a bead made by one commit.
+type BeadWrite struct {
+	BeadID string
+	Commit Commit
+	Kind   string
+	From   Row // nil when the row was added
+	To     Row // nil when the row was removed
+
+	// Label changes in this commit (label → true if added, false if removed).
+	Labels map[string]bool
+
+	// Shared is set when another agent also changed the bead in the same
+	// commit, so the row diff can't be split between them.
+	Shared bool
+}
+
+// DatabaseForBeadsDir returns the Dolt database name configured in a
+// .beads directory's metadata.json, or "" if none.
+func DatabaseForBeadsDir(beadsDir string) string {
+	return readExistingDoltDatabase(beadsDir)
+}
+
+// BeadHistory returns the commits that changed a bead, oldest first, with
+// field-level diffs. Uncommitted working-set changes are not included.
+func BeadHistory(ctx context.Context, c *doltclient.Client, beadID string) ([]BeadRevision, error) {
+	writes, err := queryBeadWrites(ctx, c.DB(),
+		"(d.to_id = ? OR d.from_id = ?)", "(d.to_issue_id = ? OR d.from_issue_id = ?)",
+		beadID, beadID)
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]BeadRevision, 0, len(writes))
+	for _, w := range writes {
+		revisions = append(revisions, BeadRevision{
+			Commit:  w.Commit,
+			Kind:    w.Kind,
+			Changes: w.Changes(),
+		})
+	}
+	return revisions, nil
+}
+
+// Changes returns the field-level diff of a write, sorted by field.
+func (w *BeadWrite) Changes() []FieldChange {
+	changes := DiffRows(w.From, w.To)
+	var added, removed []string
+	for label, isAdd := range w.Labels {
+		if isAdd {
+			added = append(added, label)
+		} else {
+			removed = append(removed, label)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	for _, l := range removed {
+		l := l
+		changes = append(changes, FieldChange{Field: LabelsField, From: &l})
+	}
+	for _, l := range added {
+		l := l
+		changes = append(changes, FieldChange{Field: LabelsField, To: &l})
+	}
+	return changes
+}
+
+// DiffRows compares two versions of a row, ignoring bookkeeping columns.
+// Either row may be nil (added or removed). Changes are sorted by field.
+func DiffRows(from, to Row) []FieldChange {
+	fields := make(map[string]bool)
+	for f := range from {
+		fields[f] = true
+	}
+	for f := range to {
+		fields[f] = true
+	}
+	var changes []FieldChange
+	for f := range fields {
+		if historyIgnoredFields[f] {
+			continue
+		}
+		a, b := from[f], to[f]
+		if sameValue(a, b) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: f, From: a, To: b})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func sameValue(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// ResolveRevision turns a revision spec into a full commit hash. The spec
+// may be a commit hash (or unique prefix), a duration meaning "that long
+// ago" (e.g. 10m, 2h), or a timestamp (RFC 3339, "2006-01-02 15:04", or
+// "2006-01-02"). Times resolve to the newest commit at or before them.
+func ResolveRevision(ctx context.Context, c *doltclient.Client, spec string, now time.Time) (string, error) {
+	db := c.DB()
+	spec = strings.TrimSpace(spec)
+	if t, ok := ParseTimeSpec(spec, now); ok {
+		var hash string
+		err := db.QueryRowContext(ctx,
+			"SELECT commit_hash FROM dolt_log WHERE date <= ? ORDER BY date DESC LIMIT 1",
+			t.UTC().Format("2006-01-02 15:04:05.999999")).Scan(&hash)
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("no commit at or before %s", t.Format(time.RFC3339))
+		}
+		if err != nil {
+			return "", fmt.Errorf("resolving %s: %w", spec, err)
+		}
+		return hash, nil
+	}
+
+	if !doltHashPrefixRe.MatchString(spec) {
+		return "", fmt.Errorf("invalid revision %q: want a commit hash, duration, or time", spec)
+	}
+	rows, err := db.QueryContext(ctx,
+		"SELECT commit_hash FROM dolt_log WHERE commit_hash LIKE ? LIMIT 2", spec+"%")
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", spec, err)
+	}
+	defer rows.Close()
+	var matches []string
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			return "", err
+		}
+		matches = append(matches, h)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("commit %s not found", spec)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("commit prefix %s is ambiguous", spec)
+	}
+}
+
+// ParseTimeSpec parses a duration-ago or absolute time. Returns false if
+// spec is neither.
+func ParseTimeSpec(spec string, now time.Time) (time.Time, bool) {
+	if d, err := time.ParseDuration(spec); err == nil && d > 0 {
+		return now.Add(-d), true
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, spec, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// RevertBead restores a bead's fields and labels to their state at commit
+// and records the change as a new Dolt commit by author. With dryRun the
+// changes are computed but not written. The bead must exist both now and
+// at the target commit.
+func RevertBead(ctx context.Context, c *doltclient.Client, beadID, commit, author string, dryRun bool) ([]FieldChange, error) {
+	db := c.DB()
+	if !doltHashRe.MatchString(commit) {
+		return nil, fmt.Errorf("invalid commit hash %q", commit)
+	}
+	target, err := queryRow(ctx, db, fmt.Sprintf("SELECT * FROM issues AS OF '%s' WHERE id = ?", commit), beadID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("%s did not exist at commit %s", beadID, commit[:8])
+	}
+	current, err := queryRow(ctx, db, "SELECT * FROM issues WHERE id = ?", beadID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%s no longer exists", beadID)
+	}
+	targetLabels, err := queryLabels(ctx, db, fmt.Sprintf("SELECT label FROM labels AS OF '%s' WHERE issue_id = ?", commit), beadID)
+	if err != nil {
+		return nil, err
+	}
+	currentLabels, err := queryLabels(ctx, db, "SELECT label FROM labels WHERE issue_id = ?", beadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only columns present in both versions can be restored (schema may
+	// have gained columns since).
+	for f := range target {
+		if _, ok := current[f]; !ok {
+			delete(target, f)
+		}
+	}
+	w := BeadWrite{BeadID: beadID, From: current, To: target, Labels: labelDelta(currentLabels, targetLabels)}
+	changes := w.Changes()
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	msg := fmt.Sprintf("gt: revert %s to %s", beadID, commit[:8])
+	err = c.Tx(ctx, func(tx *sql.Tx) error {
+		if err := execBeadChanges(ctx, tx, beadID, changes); err != nil {
+			return err
+		}
+		return doltCommit(ctx, tx, msg, author)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// labelDelta returns the label changes that turn set from into set to.
+func labelDelta(from, to map[string]bool) map[string]bool {
+	delta := make(map[string]bool)
+	for l := range to {
+		if !from[l] {
+			delta[l] = true
+		}
+	}
+	for l := range from {
+		if !to[l] {
+			delta[l] = false
+		}
+	}
+	return delta
+}
+
+// ActorWrites returns every committed bead write by actor since the given
+// time, oldest first. actor is a full agent address such as
+// gastown/polecats/toast; it is never matched by its last segment alone,
+// since polecat names repeat across rigs.
+//
+// Polecats run with Dolt auto-commit off, so their writes are committed in
+// bulk by someone else. A bead's change in a commit is therefore attributed
+// to actor when that commit added a bd event by actor for the bead (bd
+// records the BD_ACTOR of every create, update, close and label change).
+// Commits whose committer is exactly actor are attributed whole, which also
+// covers deletions, for which bd records no event.
+func ActorWrites(ctx context.Context, c *doltclient.Client, actor string, since time.Time) ([]BeadWrite, error) {
+	db := c.DB()
+	sinceStr := since.UTC().Format("2006-01-02 15:04:05.999999")
+
+	own := make(map[string]bool) // commits committed by actor
+	rows, err := db.QueryContext(ctx,
+		"SELECT commit_hash FROM dolt_log WHERE committer = ? AND date >= ?", actor, sinceStr)
+	if err != nil {
+		return nil, fmt.Errorf("reading dolt_log: %w", err)
+	}
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		own[h] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var events []actorEvent
+	hasEvents, err := c.TableExists(ctx, "events")
+	if err != nil {
+		return nil, fmt.Errorf("checking for events table: %w", err)
+	}
+	if hasEvents {
+		events, err = queryActorEvents(ctx, db, sinceStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commits := make(map[string]bool)
+	for h := range own {
+		commits[h] = true
+	}
+	for _, e := range events {
+		if e.Actor == actor {
+			commits[e.Commit] = true
+		}
+	}
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	hashes := make([]any, 0, len(commits))
+	for _, h := range sortedKeys(commits) {
+		hashes = append(hashes, h)
+	}
+	in := "(" + strings.TrimSuffix(strings.Repeat("?,", len(hashes)), ",") + ")"
+	writes, err := queryBeadWrites(ctx, db, "d.to_commit IN "+in, "d.to_commit IN "+in, hashes...)
+	if err != nil {
+		return nil, err
+	}
+	return attributeWrites(writes, events, own, actor), nil
+}
+
+// actorEvent is a bd event added by a commit.
+type actorEvent struct {
+	Commit string
+	BeadID string
+	Actor  string
+}
+
+// queryActorEvents returns the bd events added by commits since the given
+// time.
+func queryActorEvents(ctx context.Context, db *sql.DB, since string) ([]actorEvent, error) {
+	rows, err := db.QueryContext(ctx, `SELECT d.to_commit, d.to_issue_id, d.to_actor
+FROM dolt_diff_events d JOIN dolt_log l ON l.commit_hash = d.to_commit
+WHERE d.diff_type = 'added' AND l.date >= ?`, since)
+	if err != nil {
+		return nil, fmt.Errorf("reading event history: %w", err)
+	}
+	defer rows.Close()
+	var events []actorEvent
+	for rows.Next() {
+		var e actorEvent
+		var id, actor sql.NullString
+		if err := rows.Scan(&e.Commit, &id, &actor); err != nil {
+			return nil, err
+		}
+		e.BeadID, e.Actor = id.String, actor.String
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// attributeWrites keeps the writes that belong to actor: every write in a
+// commit committed by actor (own), and writes to beads for which actor
+// recorded an event in that commit. A kept write is marked Shared when
+// another agent also recorded an event for the bead in the same commit.
+func attributeWrites(writes []BeadWrite, events []actorEvent, own map[string]bool, actor string) []BeadWrite {
+	mine := make(map[string]bool)
+	others := make(map[string]bool)
+	for _, e := range events {
+		key := e.Commit + "\x00" + e.BeadID
+		if e.Actor == actor {
+			mine[key] = true
+		} else {
+			others[key] = true
+		}
+	}
+	var out []BeadWrite
+	for _, w := range writes {
+		key := w.Commit.Hash + "\x00" + w.BeadID
+		if !own[w.Commit.Hash] && !mine[key] {
+			continue
+		}
+		w.Shared = others[key]
+		out = append(out, w)
+	}
+	return out
+}
+
+// UndoPlan describes how to reverse an actor's writes.
+type UndoPlan struct {
+	Beads []BeadUndo `json:"beads"`
+}
+
+// BeadUndo is the reversal for one bead.
+type BeadUndo struct {
+	BeadID string `json:"bead_id"`
+	// Changes restore fields the actor changed that nobody has touched since.
+	Changes []FieldChange `json:"changes,omitempty"`
+	// Restore re-creates a bead the actor deleted.
+	Restore Row `json:"-"`
+	// Conflicts lists fields the actor changed that were changed again
+	// afterwards; these are left alone.
+	Conflicts []string `json:"conflicts,omitempty"`
+	// Skipped explains why the bead is left untouched.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// Actionable reports whether applying the undo would write anything.
+func (b *BeadUndo) Actionable() bool {
+	return b.Skipped == "" && (len(b.Changes) > 0 || b.Restore != nil)
+}
+
+// PlanUndo computes the reversal of writes (oldest first). current holds
+// each touched bead's present row (absent if the bead no longer exists)
+// and currentLabels its present labels.
+//
+// A field is restored to its value before the actor's first write only if
+// it still holds the value the actor left; otherwise someone changed it
+// since and it is reported as a conflict. Beads the actor created are not
+// deleted; beads the actor deleted are re-created if still absent.
+func PlanUndo(writes []BeadWrite, current map[string]Row, currentLabels map[string]map[string]bool) UndoPlan {
+	byBead := make(map[string][]BeadWrite)
+	var order []string
+	for _, w := range writes {
+		if _, ok := byBead[w.BeadID]; !ok {
+			order = append(order, w.BeadID)
+		}
+		byBead[w.BeadID] = append(byBead[w.BeadID], w)
+	}
+	sort.Strings(order)
+
+	var plan UndoPlan
+	for _, id := range order {
+		ws := byBead[id]
+		first, last := ws[0], ws[len(ws)-1]
+		// Label-only writes carry no row; the row state before and after
+		// the burst comes from the first and last writes that changed it.
+		var before, after Row
+		for _, w := range ws {
+			if w.From != nil || w.To != nil {
+				if before == nil {
+					before = w.From
+				}
+				after = w.To
+			}
+		}
+		undo := BeadUndo{BeadID: id}
+		now, exists := current[id]
+		shared := false
+		for _, w := range ws {
+			shared = shared || w.Shared
+		}
+
+		switch {
+		case shared:
+			undo.Skipped = "changed by another agent in the same commit; use gt bead revert"
+		case first.Kind == DiffAdded:
+			undo.Skipped = "created by this actor; close it manually if unwanted"
+		case last.Kind == DiffRemoved && exists:
+			undo.Skipped = "deleted by this actor but re-created since"
+		case last.Kind == DiffRemoved:
+			undo.Restore = before
+		case !exists:
+			undo.Skipped = "deleted since by someone else"
+		default:
+			if before == nil {
+				break // labels only
+			}
+			for _, c := range DiffRows(before, after) {
+				if sameValue(now[c.Field], c.To) {
+					undo.Changes = append(undo.Changes, FieldChange{Field: c.Field, From: c.To, To: c.From})
+				} else {
+					undo.Conflicts = append(undo.Conflicts, c.Field)
+				}
+			}
+		}
+
+		if undo.Skipped == "" {
+			// Labels: net effect of the actor's label writes, reversed when
+			// still in place.
+			net := make(map[string]bool)
+			for _, w := range ws {
+				for l, added := range w.Labels {
+					if prev, ok := net[l]; ok && prev != added {
+						delete(net, l) // added then removed (or vice versa)
+						continue
+					}
+					net[l] = added
+				}
+			}
+			labels := sortedKeys(net)
+			for _, l := range labels {
+				l := l
+				has := currentLabels[id][l]
+				switch {
+				case net[l] && has:
+					undo.Changes = append(undo.Changes, FieldChange{Field: LabelsField, From: &l})
+				case !net[l] && !has:
+					undo.Changes = append(undo.Changes, FieldChange{Field: LabelsField, To: &l})
+				default:
+					undo.Conflicts = append(undo.Conflicts, LabelsField+":"+l)
+				}
+			}
+		}
+		plan.Beads = append(plan.Beads, undo)
+	}
+	return plan
+}
+
+// ApplyUndo writes an undo plan as a single Dolt commit by author.
+func ApplyUndo(ctx context.Context, c *doltclient.Client, plan UndoPlan, message, author string) error {
+	return c.Tx(ctx, func(tx *sql.Tx) error {
+		for _, b := range plan.Beads {
+			if !b.Actionable() {
+				continue
+			}
+			if b.Restore != nil {
+				if err := insertRow(ctx, tx, "issues", b.Restore); err != nil {
+					return fmt.Errorf("restoring %s: %w", b.BeadID, err)
+				}
+			}
+			if err := execBeadChanges(ctx, tx, b.BeadID, b.Changes); err != nil {
+				return err
+			}
+		}
+		return doltCommit(ctx, tx, message, author)
+	})
+}
+
+// CurrentState loads the present rows and labels for a set of beads.
+// Beads that no longer exist are absent from the returned row map.
+func CurrentState(ctx context.Context, c *doltclient.Client, beadIDs []string) (map[string]Row, map[string]map[string]bool, error) {
+	db := c.DB()
+	rows := make(map[string]Row)
+	labels := make(map[string]map[string]bool)
+	for _, id := range beadIDs {
+		r, err := queryRow(ctx, db, "SELECT * FROM issues WHERE id = ?", id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if r != nil {
+			rows[id] = r
+		}
+		l, err := queryLabels(ctx, db, "SELECT label FROM labels WHERE issue_id = ?", id)
+		if err != nil {
+			return nil, nil, err
+		}
+		labels[id] = l
+	}
+	return rows, labels, nil
+}
+
+func execBeadChanges(ctx context.Context, tx *sql.Tx, beadID string, changes []FieldChange) error {
+	var sets []string
+	var args []any
+	for _, c := range changes {
+		if c.Field == LabelsField {
+			var err error
+			if c.To != nil {
+				_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO labels (issue_id, label) VALUES (?, ?)", beadID, *c.To)
+			} else if c.From != nil {
+				_, err = tx.ExecContext(ctx, "DELETE FROM labels WHERE issue_id = ? AND label = ?", beadID, *c.From)
+			}
+			if err != nil {
+				return fmt.Errorf("updating labels on %s: %w", beadID, err)
+			}
+			continue
+		}
+		sets = append(sets, quoteIdent(c.Field)+" = ?")
+		args = append(args, c.To)
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+	args = append(args, beadID)
+	if _, err := tx.ExecContext(ctx, "UPDATE issues SET "+strings.Join(sets, ", ")+" WHERE id = ?", args...); err != nil { //nolint:gosec // G202: column names come from the table's own schema
+		return fmt.Errorf("updating %s: %w", beadID, err)
+	}
+	return nil
+}
+
+func insertRow(ctx context.Context, tx *sql.Tx, table string, row Row) error {
+	cols := sortedKeys(row)
+	quoted := make([]string, len(cols))
+	args := make([]any, len(cols))
+	for i, c := range cols {
+		quoted[i] = quoteIdent(c)
+		args[i] = row[c]
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdent(table),
+		strings.Join(quoted, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", "))
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// doltCommit records the transaction's writes as a Dolt commit by author.
+// The SQL transaction itself is committed by the caller.
+func doltCommit(ctx context.Context, tx *sql.Tx, message, author string) error {
+	if author == "" {
+		author = "gt"
+	}
+	authorStr := fmt.Sprintf("%s <%s@gastown.local>", author, strings.ReplaceAll(author, "/", "."))
+	// Stage only the tables history writes touch, so unrelated uncommitted
+	// changes in the working set aren't swept into this commit.
+	if _, err := tx.ExecContext(ctx, "CALL DOLT_ADD('issues', 'labels')"); err != nil {
+		return fmt.Errorf("dolt add: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "CALL DOLT_COMMIT('-m', ?, '--author', ?)", message, authorStr); err != nil && !isNothingToCommit(err) {
+		return fmt.Errorf("dolt commit: %w", err)
+	}
+	return nil
+}
+
+// queryBeadWrites reads issue and label diffs matching the given filters
+// (written against alias d) and merges them per (commit, bead).
+func queryBeadWrites(ctx context.Context, db *sql.DB, issueFilter, labelFilter string, args ...any) ([]BeadWrite, error) {
+	query := `SELECT d.*, l.committer AS gt_committer, l.message AS gt_message
+FROM dolt_diff_issues d JOIN dolt_log l ON l.commit_hash = d.to_commit
+WHERE ` + issueFilter
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reading issue history: %w", err)
+	}
+	raw, err := scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*BeadWrite)
+	var writes []*BeadWrite
+	get := func(commit Commit, beadID string) *BeadWrite {
+		key := commit.Hash + "\x00" + beadID
+		if w, ok := byKey[key]; ok {
+			return w
+		}
+		w := &BeadWrite{BeadID: beadID, Commit: commit, Kind: DiffModified}
+		byKey[key] = w
+		writes = append(writes, w)
+		return w
+	}
+
+	for _, r := range raw {
+		from, to, meta := splitDiffRow(r)
+		id := deref(to["id"])
+		if id == "" {
+			id = deref(from["id"])
+		}
+		w := get(diffCommit(meta), id)
+		w.Kind = deref(meta["diff_type"])
+		if w.Kind != DiffAdded {
+			w.From = from
+		}
+		if w.Kind != DiffRemoved {
+			w.To = to
+		}
+	}
+
+	query = `SELECT d.to_issue_id, d.from_issue_id, d.to_label, d.from_label, d.diff_type,
+	d.to_commit, d.to_commit_date, l.committer AS gt_committer, l.message AS gt_message
+FROM dolt_diff_labels d JOIN dolt_log l ON l.commit_hash = d.to_commit
+WHERE ` + labelFilter
+	rows, err = db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reading label history: %w", err)
+	}
+	raw, err = scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range raw {
+		commit := diffCommit(r)
+		switch deref(r["diff_type"]) {
+		case DiffAdded:
+			w := get(commit, deref(r["to_issue_id"]))
+			if w.Labels == nil {
+				w.Labels = make(map[string]bool)
+			}
+			w.Labels[deref(r["to_label"])] = true
+		case DiffRemoved:
+			w := get(commit, deref(r["from_issue_id"]))
+			if w.Labels == nil {
+				w.Labels = make(map[string]bool)
+			}
+			w.Labels[deref(r["from_label"])] = false
+		}
+	}
+
+	sort.SliceStable(writes, func(i, j int) bool {
+		if !writes[i].Commit.Date.Equal(writes[j].Commit.Date) {
+			return writes[i].Commit.Date.Before(writes[j].Commit.Date)
+		}
+		return writes[i].BeadID < writes[j].BeadID
+	})
+	out := make([]BeadWrite, len(writes))
+	for i, w := range writes {
+		// A label-only write leaves the row unchanged.
+		if w.From == nil && w.To == nil {
+			w.Kind = DiffModified
+		}
+		out[i] = *w
+	}
+	return out, nil
+}
+
+// splitDiffRow separates a dolt_diff row into from_/to_ column maps and the
+// remaining metadata (diff_type, commits, joined columns).
+func splitDiffRow(r Row) (from, to, meta Row) {
+	from, to, meta = Row{}, Row{}, Row{}
+	for col, v := range r {
+		switch {
+		case col == "from_commit" || col == "from_commit_date" || col == "to_commit" || col == "to_commit_date":
+			meta[col] = v
+		case strings.HasPrefix(col, "from_"):
+			from[strings.TrimPrefix(col, "from_")] = v
+		case strings.HasPrefix(col, "to_"):
+			to[strings.TrimPrefix(col, "to_")] = v
+		default:
+			meta[col] = v
+		}
+	}
+	return from, to, meta
+}
+
+func diffCommit(meta Row) Commit {
+	return Commit{
+		Hash:      deref(meta["to_commit"]),
+		Committer: deref(meta["gt_committer"]),
+		Date:      parseDoltTime(deref(meta["to_commit_date"])),
+		Message:   deref(meta["gt_message"]),
+	}
+}
+
+func queryRow(ctx context.Context, db *sql.DB, query string, args ...any) (Row, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	all, err := scanRows(rows)
+	if err != nil || len(all) == 0 {
+		return nil, err
+	}
+	return all[0], nil
+}
+
+func queryLabels(ctx context.Context, db *sql.DB, query string, args ...any) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	all, err := scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]bool, len(all))
+	for _, r := range all {
+		labels[deref(r["label"])] = true
+	}
+	return labels, nil
+}
+
+// scanRows reads all rows into column-keyed maps and closes rows.
+func scanRows(rows *sql.Rows) ([]Row, error) {
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var out []Row
+	for rows.Next() {
+		vals := make([]sql.NullString, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		r := make(Row, len(cols))
+		for i, c := range cols {
+			if vals[i].Valid {
+				s := vals[i].String
+				r[c] = &s
+			} else {
+				r[c] = nil
+			}
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// parseDoltTime parses a DATETIME string as returned without parseTime.
+func parseDoltTime(s string) time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999", time.RFC3339Nano} {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func quoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,200 @@
+package doltserver
+
+import (
+	"testing"
+	"time"
+)
+
+func sp(s string) *string { return &s }
+
+func TestDiffRows(t *testing.T) {
+	from := Row{"id": sp("gt-1"), "status": sp("open"), "closed_at": nil, "updated_at": sp("a"), "title": sp("T")}
+	to := Row{"id": sp("gt-1"), "status": sp("closed"), "closed_at": sp("2026-10-18 10:00:00"), "updated_at": sp("b"), "title": sp("T")}
+
+	changes := DiffRows(from, to)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2: %+v", len(changes), changes)
+	}
+	if changes[0].Field != "closed_at" || changes[0].From != nil || *changes[0].To != "2026-10-18 10:00:00" {
+		t.Errorf("changes[0] = %+v", changes[0])
+	}
+	if changes[1].Field != "status" || *changes[1].From != "open" || *changes[1].To != "closed" {
+		t.Errorf("changes[1] = %+v", changes[1])
+	}
+
+	// Added row: every non-ignored field appears with a nil From.
+	if got := DiffRows(nil, to); len(got) != 3 {
+		t.Errorf("DiffRows(nil, to) = %+v", got)
+	}
+}
+
+func TestBeadWriteChanges_Labels(t *testing.T) {
+	w := BeadWrite{Labels: map[string]bool{"gt:task": true, "stale": false}}
+	changes := w.Changes()
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if changes[0].Field != LabelsField || *changes[0].From != "stale" || changes[0].To != nil {
+		t.Errorf("removed label = %+v", changes[0])
+	}
+	if *changes[1].To != "gt:task" || changes[1].From != nil {
+		t.Errorf("added label = %+v", changes[1])
+	}
+}
+
+func TestSplitDiffRow(t *testing.T) {
+	from, to, meta := splitDiffRow(Row{
+		"from_id": sp("gt-1"), "to_id": sp("gt-1"),
+		"from_status": sp("open"), "to_status": sp("closed"),
+		"to_commit": sp("abc"), "from_commit": sp("def"), "to_commit_date": sp("2026-10-18 10:00:00"),
+		"diff_type": sp("modified"), "gt_committer": sp("toast"),
+	})
+	if *from["status"] != "open" || *to["status"] != "closed" {
+		t.Errorf("from/to = %v / %v", from, to)
+	}
+	if _, ok := to["commit"]; ok {
+		t.Error("commit metadata leaked into row")
+	}
+	c := diffCommit(meta)
+	if c.Hash != "abc" || c.Committer != "toast" || c.Date.IsZero() {
+		t.Errorf("commit = %+v", c)
+	}
+}
+
+func TestAttributeWrites(t *testing.T) {
+	const actor = "gastown/polecats/toast"
+	write := func(hash, bead string) BeadWrite {
+		return BeadWrite{BeadID: bead, Commit: Commit{Hash: hash}, Kind: DiffModified}
+	}
+	writes := []BeadWrite{
+		write("bulk", "gt-1"),  // toast's event in a bulk commit by someone else
+		write("bulk", "gt-2"),  // only another agent's event
+		write("bulk", "gt-3"),  // toast and another agent in the same commit
+		write("own", "gt-4"),   // commit made by the actor itself (e.g. a delete)
+		write("other", "gt-5"), // same-named polecat in another rig
+	}
+	events := []actorEvent{
+		{Commit: "bulk", BeadID: "gt-1", Actor: actor},
+		{Commit: "bulk", BeadID: "gt-2", Actor: "gastown/witness"},
+		{Commit: "bulk", BeadID: "gt-3", Actor: actor},
+		{Commit: "bulk", BeadID: "gt-3", Actor: "gastown/refinery"},
+		{Commit: "other", BeadID: "gt-5", Actor: "beads/polecats/toast"},
+	}
+
+	got := attributeWrites(writes, events, map[string]bool{"own": true}, actor)
+	shared := make(map[string]bool)
+	for _, w := range got {
+		shared[w.BeadID] = w.Shared
+	}
+	want := map[string]bool{"gt-1": false, "gt-3": true, "gt-4": false}
+	if len(shared) != len(want) {
+		t.Fatalf("attributed beads = %v, want %v", shared, want)
+	}
+	for id, w := range want {
+		if s, ok := shared[id]; !ok || s != w {
+			t.Errorf("%s: attributed %v, shared %v; want attributed, shared %v", id, ok, s, w)
+		}
+	}
+}
+
+func TestParseTimeSpec(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	if got, ok := ParseTimeSpec("10m", now); !ok || !got.Equal(now.Add(-10*time.Minute)) {
+		t.Errorf("10m = %v, %v", got, ok)
+	}
+	if got, ok := ParseTimeSpec("2026-10-18T11:00:00Z", now); !ok || got.Hour() != 11 {
+		t.Errorf("RFC3339 = %v, %v", got, ok)
+	}
+	if _, ok := ParseTimeSpec("2026-10-17", now); !ok {
+		t.Error("date not parsed")
+	}
+	if _, ok := ParseTimeSpec("a1b2c3d4", now); ok {
+		t.Error("commit hash parsed as time")
+	}
+}
+
+func TestPlanUndo(t *testing.T) {
+	commit := func(h string) Commit { return Commit{Hash: h, Committer: "toast"} }
+	open := Row{"id": sp("gt-1"), "status": sp("open"), "closed_at": nil, "assignee": sp("")}
+	closed := Row{"id": sp("gt-1"), "status": sp("closed"), "closed_at": sp("t1"), "assignee": sp("")}
+	writes := []BeadWrite{
+		// gt-1: closed by the actor, untouched since → reopened.
+		{BeadID: "gt-1", Commit: commit("c1"), Kind: DiffModified, From: open, To: closed,
+			Labels: map[string]bool{"done": true}},
+		// gt-2: closed by the actor, then someone reassigned and reopened it.
+		{BeadID: "gt-2", Commit: commit("c1"), Kind: DiffModified,
+			From: Row{"status": sp("open"), "assignee": sp("a")},
+			To:   Row{"status": sp("closed"), "assignee": sp("")}},
+		// gt-3: created by the actor.
+		{BeadID: "gt-3", Commit: commit("c2"), Kind: DiffAdded, To: open},
+		// gt-4: deleted by the actor.
+		{BeadID: "gt-4", Commit: commit("c2"), Kind: DiffRemoved, From: Row{"id": sp("gt-4")}},
+		// gt-5: label-only write.
+		{BeadID: "gt-5", Commit: commit("c3"), Kind: DiffModified, Labels: map[string]bool{"urgent": false}},
+		// gt-6: changed by the actor and another agent in the same commit.
+		{BeadID: "gt-6", Commit: commit("c3"), Kind: DiffModified, Shared: true,
+			From: Row{"status": sp("open")}, To: Row{"status": sp("closed")}},
+	}
+	current := map[string]Row{
+		"gt-1": closed,
+		"gt-2": {"status": sp("open"), "assignee": sp("")},
+		"gt-3": open,
+		"gt-5": {"status": sp("open")},
+		"gt-6": {"status": sp("closed")},
+	}
+	labels := map[string]map[string]bool{"gt-1": {"done": true}}
+
+	plan := PlanUndo(writes, current, labels)
+	if len(plan.Beads) != 6 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	byID := make(map[string]BeadUndo)
+	for _, b := range plan.Beads {
+		byID[b.BeadID] = b
+	}
+
+	b1 := byID["gt-1"]
+	if !b1.Actionable() || len(b1.Changes) != 3 || len(b1.Conflicts) != 0 {
+		t.Errorf("gt-1 = %+v", b1)
+	}
+	for _, c := range b1.Changes {
+		switch c.Field {
+		case "status":
+			if *c.To != "open" {
+				t.Errorf("gt-1 status restored to %q", *c.To)
+			}
+		case "closed_at":
+			if c.To != nil {
+				t.Errorf("gt-1 closed_at should be restored to NULL, got %q", *c.To)
+			}
+		case LabelsField:
+			if c.From == nil || *c.From != "done" || c.To != nil {
+				t.Errorf("gt-1 label change = %+v", c)
+			}
+		}
+	}
+
+	// gt-2: status was changed again since → conflict; assignee still ""
+	// as the actor left it → restored.
+	b2 := byID["gt-2"]
+	if len(b2.Changes) != 1 || b2.Changes[0].Field != "assignee" || *b2.Changes[0].To != "a" {
+		t.Errorf("gt-2 changes = %+v", b2.Changes)
+	}
+	if len(b2.Conflicts) != 1 || b2.Conflicts[0] != "status" {
+		t.Errorf("gt-2 conflicts = %v", b2.Conflicts)
+	}
+
+	if b3 := byID["gt-3"]; b3.Actionable() || b3.Skipped == "" {
+		t.Errorf("gt-3 should be skipped: %+v", b3)
+	}
+	if b4 := byID["gt-4"]; b4.Restore == nil || !b4.Actionable() {
+		t.Errorf("gt-4 should be restored: %+v", b4)
+	}
+	b5 := byID["gt-5"]
+	if len(b5.Changes) != 1 || b5.Changes[0].To == nil || *b5.Changes[0].To != "urgent" {
+		t.Errorf("gt-5 should re-add label: %+v", b5)
+	}
+	if b6 := byID["gt-6"]; b6.Actionable() || b6.Skipped == "" {
+		t.Errorf("gt-6 should be skipped as shared: %+v", b6)
+	}
+}
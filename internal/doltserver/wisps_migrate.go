@@ -9,17 +9,29 @@
 //   3. Copies associated labels, comments, events, and dependencies
 //   4. Closes the originals in the issues table
 //
-// The migration uses `bd sql` for all database operations because the `dolt sql` CLI
-// can crash when operating on dolt_ignored tables. The `bd` tool properly connects to
-// the running Dolt server and handles the dolt_ignore semantics.
+// The migration runs its SQL over the pooled doltclient connection to the running
+// server, never the `dolt sql` CLI, which can crash when operating on dolt_ignored
+// tables. `bd migrate` is still run first to set up the dolt_ignore entries.
 package doltserver
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltclient"
 )
 
+// wispSQLTimeout bounds each migration statement. The copy statements scan
+// whole tables, so this is generous.
+const wispSQLTimeout = 2 * time.Minute
+
 // MigrateWispsResult holds migration statistics.
 type MigrateWispsResult struct {
 	WispsTableCreated bool
@@ -40,6 +52,11 @@ type MigrateWispsResult struct {
 func MigrateAgentBeadsToWisps(townRoot, workDir string, dryRun bool) (*MigrateWispsResult, error) {
 	result := &MigrateWispsResult{}
 
+	c, err := workDirClient(townRoot, workDir)
+	if err != nil {
+		return nil, err
+	}
+
 	// Step 1: Ensure bd migrate has been run (sets up dolt_ignore entries)
 	if err := bdExec(workDir, "migrate", "--yes"); err != nil {
 		// Non-fatal: might already be up to date
@@ -49,63 +66,80 @@ func MigrateAgentBeadsToWisps(townRoot, workDir string, dryRun bool) (*MigrateWi
 	}
 
 	// Step 2: Create wisps table if it doesn't exist
-	created, err := ensureWispsTable(workDir)
+	created, err := ensureWispsTable(c)
 	if err != nil {
 		return nil, fmt.Errorf("creating wisps table: %w", err)
 	}
 	result.WispsTableCreated = created
 
 	// Step 3: Create auxiliary tables
-	auxTables, err := ensureWispAuxTables(workDir)
+	auxTables, err := ensureWispAuxTables(c)
 	if err != nil {
 		return nil, fmt.Errorf("creating auxiliary tables: %w", err)
 	}
 	result.AuxTablesCreated = auxTables
 
 	if dryRun {
-		cnt, _ := bdSQLCount(workDir, "SELECT COUNT(*) as cnt FROM issues WHERE issue_type = 'agent' AND status = 'open'")
+		cnt, _ := wispSQLCount(c, "SELECT COUNT(*) as cnt FROM issues WHERE issue_type = 'agent' AND status = 'open'")
 		result.AgentsCopied = cnt
 		return result, nil
 	}
 
 	// Step 4: Copy agent beads from issues to wisps
-	if err := copyAgentBeadsToWisps(workDir, result); err != nil {
+	if err := copyAgentBeadsToWisps(c, result); err != nil {
 		return nil, fmt.Errorf("copying agent beads: %w", err)
 	}
 
 	// Step 5: Copy auxiliary data
-	if err := copyAuxiliaryData(workDir, result); err != nil {
+	if err := copyAuxiliaryData(c, result); err != nil {
 		return nil, fmt.Errorf("copying auxiliary data: %w", err)
 	}
 
 	// Step 6: Close originals in issues table
-	if err := closeOriginalAgentBeads(workDir, result); err != nil {
+	if err := closeOriginalAgentBeads(c, result); err != nil {
 		return nil, fmt.Errorf("closing originals: %w", err)
 	}
 
 	return result, nil
 }
 
-// bdSQL executes a SQL query via `bd sql`.
-func bdSQL(workDir, query string) error {
-	cmd := exec.Command("bd", "sql", query)
-	cmd.Dir = workDir
-	output, err := cmd.CombinedOutput()
+// workDirClient returns the pooled client for the beads database bd uses from
+// workDir, following .beads/redirect. A server host or port in metadata.json
+// overrides the town's server config.
+func workDirClient(townRoot, workDir string) (*doltclient.Client, error) {
+	beadsDir := beads.ResolveBeadsDir(workDir)
+	var meta struct {
+		Database string `json:"dolt_database"`
+		Host     string `json:"dolt_server_host"`
+		Port     int    `json:"dolt_server_port"`
+	}
+	data, err := os.ReadFile(filepath.Join(beadsDir, "metadata.json"))
 	if err != nil {
-		return fmt.Errorf("bd sql: %s: %w", strings.TrimSpace(string(output)), err)
+		return nil, fmt.Errorf("reading beads metadata: %w", err)
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Join(beadsDir, "metadata.json"), err)
+	}
+	if meta.Database == "" {
+		return nil, fmt.Errorf("no dolt_database in %s", filepath.Join(beadsDir, "metadata.json"))
 	}
-	return nil
-}
 
-// bdSQLCSV executes a SQL query via `bd sql --csv` and returns the output.
-func bdSQLCSV(workDir, query string) (string, error) {
-	cmd := exec.Command("bd", "sql", "--csv", query)
-	cmd.Dir = workDir
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("bd sql: %s: %w", strings.TrimSpace(string(output)), err)
+	cfg := DefaultConfig(townRoot).ClientConfig(meta.Database)
+	if meta.Host != "" {
+		cfg.Host = meta.Host
+	}
+	if meta.Port != 0 {
+		cfg.Port = meta.Port
 	}
-	return string(output), nil
+	return doltclient.Open(cfg)
+}
+
+// wispSQL executes a migration statement.
+func wispSQL(c *doltclient.Client, query string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), wispSQLTimeout)
+	defer cancel()
+	_, err := c.Exec(ctx, query)
+	return err
 }
 
 // bdExec executes a bd command.
@@ -119,38 +153,32 @@ func bdExec(workDir string, args ...string) error {
 	return nil
 }
 
-// bdSQLCount executes a COUNT query and returns the integer result.
-func bdSQLCount(workDir, query string) (int, error) {
-	output, err := bdSQLCSV(workDir, query)
-	if err != nil {
-		return 0, err
-	}
-	// Parse CSV output: header\nvalue\n
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	if len(lines) < 2 {
-		return 0, nil
-	}
-	cnt := 0
-	fmt.Sscanf(strings.TrimSpace(lines[1]), "%d", &cnt)
-	return cnt, nil
+// wispSQLCount executes a COUNT query and returns the integer result.
+func wispSQLCount(c *doltclient.Client, query string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), wispSQLTimeout)
+	defer cancel()
+	n, err := c.QueryInt(ctx, query)
+	return int(n), err
 }
 
-// bdTableExists checks if a table exists by attempting to query it.
-func bdTableExists(workDir, tableName string) bool {
-	err := bdSQL(workDir, fmt.Sprintf("SELECT 1 FROM `%s` LIMIT 1", tableName))
-	return err == nil
+// wispTableExists checks if a table exists in the migration's database.
+func wispTableExists(c *doltclient.Client, tableName string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), wispSQLTimeout)
+	defer cancel()
+	exists, err := c.TableExists(ctx, tableName)
+	return err == nil && exists
 }
 
 // ensureWispsTable creates the wisps table with the core columns needed for agent beads.
-func ensureWispsTable(workDir string) (bool, error) {
-	if bdTableExists(workDir, "wisps") {
+func ensureWispsTable(c *doltclient.Client) (bool, error) {
+	if wispTableExists(c, "wisps") {
 		return false, nil
 	}
 
 	// Create the wisps table with all columns the bd tool expects.
 	// We use individual column definitions instead of CREATE TABLE LIKE because
 	// LIKE can cause Dolt server crashes with dolt_ignored tables.
-	err := bdSQL(workDir, `CREATE TABLE wisps (
+	err := wispSQL(c, `CREATE TABLE wisps (
   id varchar(255) NOT NULL,
   content_hash varchar(64),
   title varchar(500) NOT NULL,
@@ -220,7 +248,7 @@ func ensureWispsTable(workDir string) (bool, error) {
 }
 
 // ensureWispAuxTables creates auxiliary tables for wisps.
-func ensureWispAuxTables(workDir string) ([]string, error) {
+func ensureWispAuxTables(c *doltclient.Client) ([]string, error) {
 	var created []string
 
 	auxTables := []struct {
@@ -280,10 +308,10 @@ func ensureWispAuxTables(workDir string) ([]string, error) {
 	}
 
 	for _, t := range auxTables {
-		if bdTableExists(workDir, t.name) {
+		if wispTableExists(c, t.name) {
 			continue
 		}
-		if err := bdSQL(workDir, t.ddl); err != nil {
+		if err := wispSQL(c, t.ddl); err != nil {
 			return created, fmt.Errorf("creating %s: %w", t.name, err)
 		}
 		created = append(created, t.name)
@@ -293,78 +321,78 @@ func ensureWispAuxTables(workDir string) ([]string, error) {
 }
 
 // copyAgentBeadsToWisps inserts agent beads from issues into wisps, skipping duplicates.
-func copyAgentBeadsToWisps(workDir string, result *MigrateWispsResult) error {
+func copyAgentBeadsToWisps(c *doltclient.Client, result *MigrateWispsResult) error {
 	// INSERT IGNORE skips rows where the primary key already exists in wisps.
 	// We use explicit column list to handle any schema differences.
-	err := bdSQL(workDir,
+	err := wispSQL(c,
 		"INSERT IGNORE INTO wisps (id, title, description, status, issue_type, agent_state, role_type, rig, hook_bead, role_bead, created_at, updated_at, created_by, owner, assignee, priority, ephemeral, wisp_type, mol_type, metadata) "+
 			"SELECT id, title, description, status, issue_type, agent_state, role_type, rig, hook_bead, role_bead, created_at, updated_at, created_by, owner, assignee, priority, 1, wisp_type, mol_type, metadata FROM issues WHERE issue_type = 'agent'")
 	if err != nil {
 		return err
 	}
 
-	cnt, _ := bdSQLCount(workDir, "SELECT COUNT(*) as cnt FROM wisps WHERE issue_type = 'agent'")
+	cnt, _ := wispSQLCount(c, "SELECT COUNT(*) as cnt FROM wisps WHERE issue_type = 'agent'")
 	result.AgentsCopied = cnt
 
 	return nil
 }
 
 // copyAuxiliaryData copies labels, comments, events, and dependencies for agent beads.
-func copyAuxiliaryData(workDir string, result *MigrateWispsResult) error {
+func copyAuxiliaryData(c *doltclient.Client, result *MigrateWispsResult) error {
 	// Copy labels
-	if err := bdSQL(workDir,
+	if err := wispSQL(c,
 		"INSERT IGNORE INTO wisp_labels (issue_id, label) SELECT l.issue_id, l.label FROM labels l INNER JOIN wisps w ON l.issue_id = w.id"); err != nil {
 		// Non-fatal if no matching labels
 		if !strings.Contains(err.Error(), "nothing") {
 			return fmt.Errorf("copying labels: %w", err)
 		}
 	}
-	cnt, _ := bdSQLCount(workDir, "SELECT COUNT(*) as cnt FROM wisp_labels")
+	cnt, _ := wispSQLCount(c, "SELECT COUNT(*) as cnt FROM wisp_labels")
 	result.LabelsCopied = cnt
 
 	// Copy comments
-	if err := bdSQL(workDir,
+	if err := wispSQL(c,
 		"INSERT IGNORE INTO wisp_comments (issue_id, author, text, created_at) SELECT c.issue_id, c.author, c.text, c.created_at FROM comments c INNER JOIN wisps w ON c.issue_id = w.id"); err != nil {
 		if !strings.Contains(err.Error(), "nothing") {
 			return fmt.Errorf("copying comments: %w", err)
 		}
 	}
-	cnt, _ = bdSQLCount(workDir, "SELECT COUNT(*) as cnt FROM wisp_comments")
+	cnt, _ = wispSQLCount(c, "SELECT COUNT(*) as cnt FROM wisp_comments")
 	result.CommentsCopied = cnt
 
 	// Copy events
-	if err := bdSQL(workDir,
+	if err := wispSQL(c,
 		"INSERT IGNORE INTO wisp_events (issue_id, event_type, actor, old_value, new_value, comment, created_at) SELECT e.issue_id, e.event_type, e.actor, e.old_value, e.new_value, e.comment, e.created_at FROM events e INNER JOIN wisps w ON e.issue_id = w.id"); err != nil {
 		if !strings.Contains(err.Error(), "nothing") {
 			return fmt.Errorf("copying events: %w", err)
 		}
 	}
-	cnt, _ = bdSQLCount(workDir, "SELECT COUNT(*) as cnt FROM wisp_events")
+	cnt, _ = wispSQLCount(c, "SELECT COUNT(*) as cnt FROM wisp_events")
 	result.EventsCopied = cnt
 
 	// Copy dependencies
-	if err := bdSQL(workDir,
+	if err := wispSQL(c,
 		"INSERT IGNORE INTO wisp_dependencies (issue_id, depends_on_id, type, created_at, created_by, metadata, thread_id) SELECT d.issue_id, d.depends_on_id, d.type, d.created_at, d.created_by, d.metadata, d.thread_id FROM dependencies d INNER JOIN wisps w ON d.issue_id = w.id"); err != nil {
 		if !strings.Contains(err.Error(), "nothing") {
 			return fmt.Errorf("copying dependencies: %w", err)
 		}
 	}
-	cnt, _ = bdSQLCount(workDir, "SELECT COUNT(*) as cnt FROM wisp_dependencies")
+	cnt, _ = wispSQLCount(c, "SELECT COUNT(*) as cnt FROM wisp_dependencies")
 	result.DepsCopied = cnt
 
 	return nil
 }
 
 // closeOriginalAgentBeads closes the original agent beads in the issues table.
-func closeOriginalAgentBeads(workDir string, result *MigrateWispsResult) error {
+func closeOriginalAgentBeads(c *doltclient.Client, result *MigrateWispsResult) error {
 	// Close all open agent beads. We don't use a cross-table subquery because
 	// that can crash the Dolt server when mixing regular and dolt_ignored tables.
-	if err := bdSQL(workDir,
+	if err := wispSQL(c,
 		"UPDATE issues SET status = 'closed', closed_at = NOW() WHERE issue_type = 'agent' AND status = 'open'"); err != nil {
 		return err
 	}
 
-	cnt, _ := bdSQLCount(workDir, "SELECT COUNT(*) as cnt FROM issues WHERE issue_type = 'agent' AND status = 'closed'")
+	cnt, _ := wispSQLCount(c, "SELECT COUNT(*) as cnt FROM issues WHERE issue_type = 'agent' AND status = 'closed'")
 	result.AgentsClosed = cnt
 
 	return nil
@@ -28,7 +28,7 @@ func setupBdWorkDir(t *testing.T, port int) string {
 		t.Fatalf("creating .beads dir: %v", err)
 	}
 
-	metadata := fmt.Sprintf(`{"backend":"dolt","database":"beads_test","dolt_mode":"server","dolt_server_host":"127.0.0.1","dolt_server_port":%d}`, port)
+	metadata := fmt.Sprintf(`{"backend":"dolt","database":"dolt","dolt_database":"beads_test","dolt_mode":"server","dolt_server_host":"127.0.0.1","dolt_server_port":%d}`, port)
 	if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(metadata), 0644); err != nil {
 		t.Fatalf("writing metadata.json: %v", err)
 	}
@@ -72,20 +72,24 @@ func TestMigrateWisps_TableCreation(t *testing.T) {
 	port, _ := strconv.Atoi(portStr)
 	workDir := setupBdWorkDir(t, port)
 
-	// Verify we can talk to the database.
-	err := bdSQL(workDir, "SELECT 1")
+	c, err := workDirClient(t.TempDir(), workDir)
 	if err != nil {
-		t.Skipf("bd sql not working against isolated server: %v", err)
+		t.Fatalf("workDirClient: %v", err)
+	}
+
+	// Verify we can talk to the database.
+	if err := wispSQL(c, "SELECT 1"); err != nil {
+		t.Skipf("sql not working against isolated server: %v", err)
 	}
 
 	// Test table existence check.
-	exists := bdTableExists(workDir, "issues")
+	exists := wispTableExists(c, "issues")
 	if !exists {
 		t.Skip("issues table not found in isolated database")
 	}
 
 	// If wisps table already exists, just verify it works.
-	if bdTableExists(workDir, "wisps") {
+	if wispTableExists(c, "wisps") {
 		t.Log("wisps table already exists — verifying bd mol wisp list works")
 		cmd := exec.Command("bd", "mol", "wisp", "list")
 		cmd.Dir = workDir
@@ -100,19 +104,20 @@ func TestMigrateWisps_TableCreation(t *testing.T) {
 	t.Log("wisps table does not exist — would need to create (skipping actual creation in test)")
 }
 
-// TestBdSQLCount verifies the count helper works.
-func TestBdSQLCount(t *testing.T) {
+// TestWispSQLCount verifies the count helper works.
+func TestWispSQLCount(t *testing.T) {
 	portStr := testutil.StartIsolatedDoltContainer(t)
-	if _, err := exec.LookPath("bd"); err != nil {
-		t.Skip("bd not found in PATH — skipping integration test")
-	}
 
 	port, _ := strconv.Atoi(portStr)
 	workDir := setupBdWorkDir(t, port)
+	c, err := workDirClient(t.TempDir(), workDir)
+	if err != nil {
+		t.Fatalf("workDirClient: %v", err)
+	}
 
-	cnt, err := bdSQLCount(workDir, "SELECT COUNT(*) as cnt FROM issues")
+	cnt, err := wispSQLCount(c, "SELECT COUNT(*) as cnt FROM issues")
 	if err != nil {
-		t.Skipf("bd sql not working against isolated server: %v", err)
+		t.Fatalf("wispSQLCount: %v", err)
 	}
 	t.Logf("issues count: %d", cnt)
 	if cnt < 0 {
@@ -0,0 +1,139 @@
+// Package doltserver - wl_clone.go gives wl-commons clones (the fork made by
+// gt wl join, the scratch clone gt wl browse reads) the same bound-parameter
+// access as the town's own commons. The dolt CLI cannot bind parameters, so
+// each clone is served by a throwaway dolt sql-server for the operation.
+package doltserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltclient"
+)
+
+// cloneServerStartTimeout is how long to wait for a clone's sql-server to
+// accept connections.
+const cloneServerStartTimeout = 30 * time.Second
+
+// ServeClone starts a throwaway dolt sql-server over the Dolt clone at dir
+// and returns a client for the clone's database. The returned func stops the
+// server; the client must not be used after that.
+func ServeClone(dir string) (*doltclient.Client, func(), error) {
+	port, err := freeLocalPort()
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding a port for the clone server: %w", err)
+	}
+	cmd := exec.Command("dolt", "sql-server", "--host", "127.0.0.1", "--port", strconv.Itoa(port))
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("starting dolt sql-server for %s: %w", dir, err)
+	}
+	stop := func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}
+
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	ready := false
+	for deadline := time.Now().Add(cloneServerStartTimeout); time.Now().Before(deadline); {
+		if conn, err := net.DialTimeout("tcp", addr, time.Second); err == nil {
+			conn.Close()
+			ready = true
+			break
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	if !ready {
+		stop()
+		return nil, nil, fmt.Errorf("dolt sql-server for %s did not start within %v: %s", dir, cloneServerStartTimeout, strings.TrimSpace(out.String()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := cloneClient(ctx, port)
+	if err != nil {
+		stop()
+		return nil, nil, fmt.Errorf("connecting to clone %s: %w", dir, err)
+	}
+	return c, stop, nil
+}
+
+// cloneClient returns a client for the one user database served on port.
+// Dolt derives its name from the clone's directory.
+func cloneClient(ctx context.Context, port int) (*doltclient.Client, error) {
+	server, err := doltclient.Open(doltclient.Config{Port: port})
+	if err != nil {
+		return nil, err
+	}
+	names, err := doltclient.Select(ctx, server, func(row doltclient.Scanner) (string, error) {
+		var name string
+		return name, row.Scan(&name)
+	}, "SHOW DATABASES")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if name != "information_schema" && name != "mysql" {
+			return doltclient.Open(doltclient.Config{Port: port, Database: name})
+		}
+	}
+	return nil, fmt.Errorf("server has no database")
+}
+
+// freeLocalPort returns a loopback TCP port that was free a moment ago.
+func freeLocalPort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// WLRig is a rig's entry in the commons rigs table.
+type WLRig struct {
+	Handle      string
+	DisplayName string
+	DoltHubOrg  string
+	OwnerEmail  string
+	GTVersion   string
+}
+
+// RegisterRigInClone registers rig in the wl-commons clone at dir, or
+// refreshes its last_seen and gt_version if already registered, and commits
+// the change to the clone.
+func RegisterRigInClone(dir string, rig WLRig) error {
+	c, stop, err := ServeClone(dir)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	r, err := newWLRepo(ctx, c)
+	if err != nil {
+		return err
+	}
+	return r.registerRig(ctx, rig)
+}
+
+// registerRig upserts rig into the rigs table.
+func (r *wlRepo) registerRig(ctx context.Context, rig WLRig) error {
+	return r.mutate(ctx, "Register rig: "+rig.Handle, func(t *wlTx) error {
+		_, err := t.exec(`INSERT INTO rigs (handle, display_name, dolthub_org, owner_email, gt_version, trust_level, registered_at, last_seen)
+  VALUES (?, ?, ?, ?, ?, 1, NOW(), NOW())
+  ON DUPLICATE KEY UPDATE last_seen = NOW(), gt_version = ?`,
+			rig.Handle, rig.DisplayName, rig.DoltHubOrg, rig.OwnerEmail, rig.GTVersion, rig.GTVersion)
+		return err
+	})
+}
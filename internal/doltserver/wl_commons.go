@@ -9,11 +9,15 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/doltclient"
 )
 
 // WLCommonsDB is the database name for the wl-commons shared wanted board.
@@ -24,9 +28,24 @@ type WLCommonsStore interface {
 	EnsureDB() error
 	DatabaseExists(dbName string) bool
 	InsertWanted(item *WantedItem) error
-	ClaimWanted(wantedID, rigHandle string) error
+	ClaimWanted(wantedID, rigHandle string, lease time.Duration) error
 	SubmitCompletion(completionID, wantedID, rigHandle, evidence string) error
 	QueryWanted(wantedID string) (*WantedItem, error)
+
+	// Review lifecycle (see wl_review.go).
+	QueryCompletion(wantedID string) (*Completion, error)
+	ValidateCompletion(wantedID, reviewID string, stamp *Stamp) error
+	RejectCompletion(wantedID, reviewID, reviewer, reason string) error
+	OpenDispute(d *Dispute) error
+	CastVote(v *ReviewVote) error
+	QueryOpenDispute(wantedID string) (*Dispute, []*ReviewVote, error)
+	ResolveDispute(d *Dispute, verdict string, stamps []*Stamp) error
+
+	// Claim leases (see wl_leases.go).
+	RenewClaim(wantedID, rigHandle string, lease time.Duration) error
+	QueryClaimLeases() ([]*ClaimLease, error)
+	ReleaseExpiredClaim(wantedID, rigHandle string) error
+	MarkClaimWarned(wantedID, rigHandle string) error
 }
 
 // WLCommons implements WLCommonsStore using the real Dolt server.
@@ -38,8 +57,8 @@ func NewWLCommons(townRoot string) *WLCommons { return &WLCommons{townRoot: town
 func (w *WLCommons) EnsureDB() error           { return EnsureWLCommons(w.townRoot) }
 func (w *WLCommons) DatabaseExists(db string) bool { return DatabaseExists(w.townRoot, db) }
 func (w *WLCommons) InsertWanted(item *WantedItem) error { return InsertWanted(w.townRoot, item) }
-func (w *WLCommons) ClaimWanted(wantedID, rigHandle string) error {
-	return ClaimWanted(w.townRoot, wantedID, rigHandle)
+func (w *WLCommons) ClaimWanted(wantedID, rigHandle string, lease time.Duration) error {
+	return ClaimWanted(w.townRoot, wantedID, rigHandle, lease)
 }
 func (w *WLCommons) SubmitCompletion(completionID, wantedID, rigHandle, evidence string) error {
 	return SubmitCompletion(w.townRoot, completionID, wantedID, rigHandle, evidence)
@@ -74,6 +93,8 @@ func isNothingToCommit(err error) bool {
 // EscapeSQL escapes backslashes and single quotes for SQL string literals.
 // Dolt (MySQL-compatible) treats \ as an escape character, so a trailing
 // backslash in user input would escape the closing quote and break the query.
+// wl-commons statements do not use it: they bind their values, including
+// against clones (see wl_repo.go and wl_clone.go).
 func EscapeSQL(s string) string {
 	s = strings.ReplaceAll(s, `\`, `\\`)
 	return strings.ReplaceAll(s, "'", "''")
@@ -117,14 +138,12 @@ func EnsureWLCommons(townRoot string) error {
 }
 
 func initWLCommonsSchema(townRoot string) error {
-	schema := fmt.Sprintf(`USE %s;
-
-CREATE TABLE IF NOT EXISTS _meta (
+	schema := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS _meta (
     %s VARCHAR(64) PRIMARY KEY,
     value TEXT
 );
 
-INSERT IGNORE INTO _meta (%s, value) VALUES ('schema_version', '1.0');
+INSERT IGNORE INTO _meta (%s, value) VALUES ('schema_version', '%s');
 INSERT IGNORE INTO _meta (%s, value) VALUES ('wasteland_name', 'Gas Town Wasteland');
 
 CREATE TABLE IF NOT EXISTS rigs (
@@ -209,13 +228,19 @@ CREATE TABLE IF NOT EXISTS chain_meta (
     dolt_database VARCHAR(255),
     created_at TIMESTAMP
 );
-
+%s
 CALL DOLT_ADD('-A');
-CALL DOLT_COMMIT('--allow-empty', '-m', 'Initialize wl-commons schema v1.0');
-`, WLCommonsDB,
-		backtickKey(), backtickKey(), backtickKey())
+CALL DOLT_COMMIT('--allow-empty', '-m', 'Initialize wl-commons schema v%s');
+`, backtickKey(), backtickKey(), WLSchemaVersion, backtickKey(),
+		wlReviewTablesDDL+wlLeaseTableDDL+wlReputationViewDDL, WLSchemaVersion)
 
-	return doltSQLScriptWithRetry(townRoot, schema)
+	c, err := Client(townRoot, WLCommonsDB)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return c.Script(ctx, schema)
 }
 
 func backtickKey() string {
@@ -231,215 +256,121 @@ func InsertWanted(townRoot string, item *WantedItem) error {
 		return fmt.Errorf("wanted item title cannot be empty")
 	}
 
-	now := time.Now().UTC().Format("2006-01-02 15:04:05")
-
-	tagsJSON := "NULL"
+	var tags any
 	if len(item.Tags) > 0 {
-		escaped := make([]string, len(item.Tags))
-		for i, t := range item.Tags {
-			t = strings.ReplaceAll(t, `\`, `\\`)
-			t = strings.ReplaceAll(t, `"`, `\"`)
-			t = strings.ReplaceAll(t, "'", "''")
-			escaped[i] = t
+		b, err := json.Marshal(item.Tags)
+		if err != nil {
+			return fmt.Errorf("encoding tags: %w", err)
 		}
-		tagsJSON = fmt.Sprintf("'[\"%s\"]'", strings.Join(escaped, `","`))
-	}
-
-	descField := "NULL"
-	if item.Description != "" {
-		descField = fmt.Sprintf("'%s'", EscapeSQL(item.Description))
-	}
-	projectField := "NULL"
-	if item.Project != "" {
-		projectField = fmt.Sprintf("'%s'", EscapeSQL(item.Project))
+		tags = string(b)
 	}
-	typeField := "NULL"
-	if item.Type != "" {
-		typeField = fmt.Sprintf("'%s'", EscapeSQL(item.Type))
+	effort := item.EffortLevel
+	if effort == "" {
+		effort = "medium"
 	}
-	postedByField := "NULL"
-	if item.PostedBy != "" {
-		postedByField = fmt.Sprintf("'%s'", EscapeSQL(item.PostedBy))
+	status := item.Status
+	if status == "" {
+		status = "open"
 	}
-	effortField := "'medium'"
-	if item.EffortLevel != "" {
-		effortField = fmt.Sprintf("'%s'", EscapeSQL(item.EffortLevel))
-	}
-	status := "'open'"
-	if item.Status != "" {
-		status = fmt.Sprintf("'%s'", EscapeSQL(item.Status))
-	}
-
-	script := fmt.Sprintf(`USE %s;
-
-INSERT INTO wanted (id, title, description, project, type, priority, tags, posted_by, status, effort_level, created_at, updated_at)
-VALUES ('%s', '%s', %s, %s, %s, %d, %s, %s, %s, %s, '%s', '%s');
+	now := time.Now().UTC().Format("2006-01-02 15:04:05")
 
-CALL DOLT_ADD('-A');
-CALL DOLT_COMMIT('-m', 'wl post: %s');
-`,
-		WLCommonsDB,
-		EscapeSQL(item.ID), EscapeSQL(item.Title), descField, projectField, typeField,
-		item.Priority, tagsJSON, postedByField, status, effortField,
-		now, now,
-		EscapeSQL(item.Title))
-
-	return doltSQLScriptWithRetry(townRoot, script)
+	return withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		return r.mutate(ctx, "wl post: "+item.Title, func(t *wlTx) error {
+			_, err := t.exec(`INSERT INTO wanted (id, title, description, project, type, priority, tags, posted_by, status, effort_level, created_at, updated_at)
+  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				item.ID, item.Title, nullIfEmpty(item.Description), nullIfEmpty(item.Project), nullIfEmpty(item.Type),
+				item.Priority, tags, nullIfEmpty(item.PostedBy), status, effort, now, now)
+			return err
+		})
+	})
 }
 
-// ClaimWanted updates a wanted item's status to claimed.
+// ClaimWanted updates a wanted item's status to claimed and starts a claim
+// lease (DefaultClaimLease if lease is zero; see wl_leases.go).
 // Returns an error if the item does not exist or is not open.
 //
-// Uses a single-script approach: UPDATE + DOLT_ADD + DOLT_COMMIT in one
-// invocation. If the UPDATE matches 0 rows (item not open), the working set
-// is unchanged and DOLT_COMMIT fails with "nothing to commit" — which we
-// map to a precondition error. This avoids splitting into separate sessions
-// and eliminates the need for DOLT_RESET on failure.
-func ClaimWanted(townRoot, wantedID, rigHandle string) error {
-	script := fmt.Sprintf(`USE %s;
-UPDATE wanted SET claimed_by='%s', status='claimed', updated_at=NOW()
-  WHERE id='%s' AND status='open';
-CALL DOLT_ADD('-A');
-CALL DOLT_COMMIT('-m', 'wl claim: %s');
-`, WLCommonsDB, EscapeSQL(rigHandle), EscapeSQL(wantedID), EscapeSQL(wantedID))
-
-	err := doltSQLScriptWithRetry(townRoot, script)
-	if err == nil {
-		return nil
-	}
-	if isNothingToCommit(err) {
+// The status change is guarded on status='open' in the same transaction as
+// the lease, so of two rigs racing for an item exactly one wins.
+func ClaimWanted(townRoot, wantedID, rigHandle string, lease time.Duration) error {
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureLeaseSchema(ctx); err != nil {
+			return err
+		}
+		return r.mutate(ctx, "wl claim: "+wantedID, func(t *wlTx) error {
+			if err := t.require(`UPDATE wanted SET claimed_by = ?, status = 'claimed', updated_at = NOW()
+  WHERE id = ? AND status = 'open'`, rigHandle, wantedID); err != nil {
+				return err
+			}
+			_, err := t.exec(`INSERT INTO claim_leases (wanted_id, rig_handle, claimed_at, expires_at)
+  VALUES (?, ?, NOW(), DATE_ADD(NOW(), INTERVAL ? SECOND))
+  ON DUPLICATE KEY UPDATE rig_handle = VALUES(rig_handle), claimed_at = VALUES(claimed_at),
+    renewed_at = NULL, expires_at = VALUES(expires_at), warned_at = NULL`,
+				wantedID, rigHandle, leaseSeconds(lease))
+			return err
+		})
+	})
+	if errors.Is(err, errWLPrecondition) {
 		return fmt.Errorf("wanted item %q is not open or does not exist", wantedID)
 	}
-	return fmt.Errorf("claim failed: %w", err)
+	if err != nil {
+		return fmt.Errorf("claim failed: %w", err)
+	}
+	return nil
 }
 
 // SubmitCompletion inserts a completion record and updates the wanted status.
 // The item must have status='claimed' AND claimed_by=rigHandle to prevent
 // completing an item claimed by another rig.
 //
-// Uses a single-script approach like ClaimWanted. The INSERT uses INSERT IGNORE
-// with a SELECT conditional on status='in_review' AND claimed_by AND NOT EXISTS
-// (prior completion). INSERT IGNORE makes the script idempotent on retry since
-// completions.id is a PRIMARY KEY. NOT EXISTS prevents multiple completions per
-// wanted item, ensuring the lifecycle is strictly post→claim→done.
+// At most one completion exists per wanted item, so the lifecycle is strictly
+// post→claim→done; review removes a rejected completion before the claimer
+// resubmits. The claim lease ends with submission; if review sends the item
+// back to claimed, the claimer gets a fresh default lease from that point.
 func SubmitCompletion(townRoot, completionID, wantedID, rigHandle, evidence string) error {
-	script := fmt.Sprintf(`USE %s;
-UPDATE wanted SET status='in_review', evidence_url='%s', updated_at=NOW()
-  WHERE id='%s' AND status='claimed' AND claimed_by='%s';
-INSERT IGNORE INTO completions (id, wanted_id, completed_by, evidence, completed_at)
-  SELECT '%s', '%s', '%s', '%s', NOW()
-  FROM wanted WHERE id='%s' AND status='in_review' AND claimed_by='%s'
-  AND NOT EXISTS (SELECT 1 FROM completions WHERE wanted_id='%s');
-CALL DOLT_ADD('-A');
-CALL DOLT_COMMIT('-m', 'wl done: %s');
-`,
-		WLCommonsDB,
-		EscapeSQL(evidence), EscapeSQL(wantedID), EscapeSQL(rigHandle),
-		EscapeSQL(completionID), EscapeSQL(wantedID), EscapeSQL(rigHandle), EscapeSQL(evidence),
-		EscapeSQL(wantedID), EscapeSQL(rigHandle), EscapeSQL(wantedID),
-		EscapeSQL(wantedID))
-
-	err := doltSQLScriptWithRetry(townRoot, script)
-	if err == nil {
-		return nil
-	}
-	if isNothingToCommit(err) {
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureLeaseSchema(ctx); err != nil {
+			return err
+		}
+		return r.mutate(ctx, "wl done: "+wantedID, func(t *wlTx) error {
+			if err := t.require(`UPDATE wanted SET status = 'in_review', evidence_url = ?, updated_at = NOW()
+  WHERE id = ? AND status = 'claimed' AND claimed_by = ?
+  AND NOT EXISTS (SELECT 1 FROM completions WHERE wanted_id = ?)`,
+				evidence, wantedID, rigHandle, wantedID); err != nil {
+				return err
+			}
+			if _, err := t.exec(`INSERT INTO completions (id, wanted_id, completed_by, evidence, completed_at)
+  VALUES (?, ?, ?, ?, NOW())`, completionID, wantedID, rigHandle, evidence); err != nil {
+				return err
+			}
+			_, err := t.exec(`DELETE FROM claim_leases WHERE wanted_id = ?`, wantedID)
+			return err
+		})
+	})
+	if errors.Is(err, errWLPrecondition) {
 		return fmt.Errorf("wanted item %q is not claimed by %q or does not exist", wantedID, rigHandle)
 	}
-	return fmt.Errorf("completion failed: %w", err)
+	if err != nil {
+		return fmt.Errorf("completion failed: %w", err)
+	}
+	return nil
 }
 
 // QueryWanted fetches a wanted item by ID. Returns nil if not found.
 func QueryWanted(townRoot, wantedID string) (*WantedItem, error) {
-	query := fmt.Sprintf(`USE %s; SELECT id, title, status, COALESCE(claimed_by, '') as claimed_by FROM wanted WHERE id='%s';`,
-		WLCommonsDB, EscapeSQL(wantedID))
-
-	output, err := doltSQLQuery(townRoot, query)
+	var items []*WantedItem
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		var err error
+		items, err = doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (*WantedItem, error) {
+			item := &WantedItem{}
+			return item, row.Scan(&item.ID, &item.Title, &item.Status, &item.ClaimedBy)
+		}, "SELECT id, COALESCE(title, ''), COALESCE(status, ''), COALESCE(claimed_by, '') FROM wanted WHERE id = ?", wantedID)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("querying wanted item: %w", err)
 	}
-
-	rows := parseSimpleCSV(output)
-	if len(rows) == 0 {
+	if len(items) == 0 {
 		return nil, fmt.Errorf("wanted item %q not found", wantedID)
 	}
-
-	row := rows[0]
-	item := &WantedItem{
-		ID:        row["id"],
-		Title:     row["title"],
-		Status:    row["status"],
-		ClaimedBy: row["claimed_by"],
-	}
-	return item, nil
-}
-
-// doltSQLQuery executes a SQL query and returns the raw CSV output.
-func doltSQLQuery(townRoot, query string) (string, error) {
-	config := DefaultConfig(townRoot)
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
-
-	cmd := buildDoltSQLCmd(ctx, config, "-r", "csv", "-q", query)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("dolt sql query failed: %w (%s)", err, strings.TrimSpace(string(output)))
-	}
-	return string(output), nil
-}
-
-// parseSimpleCSV parses CSV output from dolt sql into a slice of maps.
-// Handles quoted fields containing commas and escaped quotes.
-func parseSimpleCSV(data string) []map[string]string {
-	lines := strings.Split(strings.TrimSpace(data), "\n")
-	if len(lines) < 2 {
-		return nil
-	}
-
-	headers := parseCSVLine(lines[0])
-	var result []map[string]string
-
-	for _, line := range lines[1:] {
-		if line == "" {
-			continue
-		}
-		fields := parseCSVLine(line)
-		row := make(map[string]string)
-		for i, h := range headers {
-			if i < len(fields) {
-				row[strings.TrimSpace(h)] = strings.TrimSpace(fields[i])
-			}
-		}
-		result = append(result, row)
-	}
-	return result
-}
-
-// parseCSVLine parses a single CSV line, handling quoted fields.
-func parseCSVLine(line string) []string {
-	var fields []string
-	var field strings.Builder
-	inQuote := false
-
-	for i := 0; i < len(line); i++ {
-		ch := line[i]
-		switch {
-		case ch == '"' && !inQuote:
-			inQuote = true
-		case ch == '"' && inQuote:
-			if i+1 < len(line) && line[i+1] == '"' {
-				field.WriteByte('"')
-				i++
-			} else {
-				inQuote = false
-			}
-		case ch == ',' && !inQuote:
-			fields = append(fields, field.String())
-			field.Reset()
-		default:
-			field.WriteByte(ch)
-		}
-	}
-	fields = append(fields, field.String())
-	return fields
+	return items[0], nil
 }
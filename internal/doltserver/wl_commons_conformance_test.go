@@ -3,6 +3,7 @@ package doltserver
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 // wlCommonsConformance is a shared test suite that validates any WLCommonsStore
@@ -49,7 +50,7 @@ func wlCommonsConformance(t *testing.T, newStore func(t *testing.T) WLCommonsSto
 		if err := store.InsertWanted(&WantedItem{ID: "w-conf02", Title: "Claimable"}); err != nil {
 			t.Fatalf("InsertWanted() error: %v", err)
 		}
-		if err := store.ClaimWanted("w-conf02", "claimer-rig"); err != nil {
+		if err := store.ClaimWanted("w-conf02", "claimer-rig", DefaultClaimLease); err != nil {
 			t.Fatalf("ClaimWanted() error: %v", err)
 		}
 
@@ -72,14 +73,14 @@ func wlCommonsConformance(t *testing.T, newStore func(t *testing.T) WLCommonsSto
 		if err := store.InsertWanted(&WantedItem{ID: "w-conf03", Title: "Already claimed"}); err != nil {
 			t.Fatalf("InsertWanted() error: %v", err)
 		}
-		if err := store.ClaimWanted("w-conf03", "rig-1"); err != nil {
+		if err := store.ClaimWanted("w-conf03", "rig-1", DefaultClaimLease); err != nil {
 			t.Fatalf("first ClaimWanted() error: %v", err)
 		}
 
 		// Second claim on non-open item must return an error.
 		// Both fake and real now enforce this: the real SQL checks
 		// ROW_COUNT() after the UPDATE to detect 0 rows affected.
-		err := store.ClaimWanted("w-conf03", "rig-2")
+		err := store.ClaimWanted("w-conf03", "rig-2", DefaultClaimLease)
 		if err == nil {
 			t.Error("ClaimWanted on non-open item should return an error")
 		}
@@ -100,7 +101,7 @@ func wlCommonsConformance(t *testing.T, newStore func(t *testing.T) WLCommonsSto
 		if err := store.InsertWanted(&WantedItem{ID: "w-conf04", Title: "Completable"}); err != nil {
 			t.Fatalf("InsertWanted() error: %v", err)
 		}
-		if err := store.ClaimWanted("w-conf04", "worker-rig"); err != nil {
+		if err := store.ClaimWanted("w-conf04", "worker-rig", DefaultClaimLease); err != nil {
 			t.Fatalf("ClaimWanted() error: %v", err)
 		}
 
@@ -234,7 +235,7 @@ func wlCommonsConformance(t *testing.T, newStore func(t *testing.T) WLCommonsSto
 		if err := store.InsertWanted(&WantedItem{ID: "w-conf09", Title: "Wrong rig item"}); err != nil {
 			t.Fatalf("InsertWanted() error: %v", err)
 		}
-		if err := store.ClaimWanted("w-conf09", "rig-alpha"); err != nil {
+		if err := store.ClaimWanted("w-conf09", "rig-alpha", DefaultClaimLease); err != nil {
 			t.Fatalf("ClaimWanted() error: %v", err)
 		}
 
@@ -261,7 +262,7 @@ func wlCommonsConformance(t *testing.T, newStore func(t *testing.T) WLCommonsSto
 		if err := store.InsertWanted(&WantedItem{ID: "w-conf11", Title: "Already done"}); err != nil {
 			t.Fatalf("InsertWanted() error: %v", err)
 		}
-		if err := store.ClaimWanted("w-conf11", "worker-rig"); err != nil {
+		if err := store.ClaimWanted("w-conf11", "worker-rig", DefaultClaimLease); err != nil {
 			t.Fatalf("ClaimWanted() error: %v", err)
 		}
 		if err := store.SubmitCompletion("c-conf04", "w-conf11", "worker-rig", "https://pr/4"); err != nil {
@@ -317,7 +318,7 @@ func wlCommonsConformance(t *testing.T, newStore func(t *testing.T) WLCommonsSto
 		if err := store.InsertWanted(&WantedItem{ID: "w-conf07", Title: "Check claimer"}); err != nil {
 			t.Fatalf("InsertWanted() error: %v", err)
 		}
-		if err := store.ClaimWanted("w-conf07", "specific-rig"); err != nil {
+		if err := store.ClaimWanted("w-conf07", "specific-rig", DefaultClaimLease); err != nil {
 			t.Fatalf("ClaimWanted() error: %v", err)
 		}
 
@@ -329,6 +330,176 @@ func wlCommonsConformance(t *testing.T, newStore func(t *testing.T) WLCommonsSto
 			t.Errorf("ClaimedBy = %q, want to contain %q", got.ClaimedBy, "specific-rig")
 		}
 	})
+
+	// submitted inserts, claims and completes an item as worker-rig.
+	submitted := func(t *testing.T, store WLCommonsStore, wantedID string) {
+		t.Helper()
+		if err := store.InsertWanted(&WantedItem{ID: wantedID, Title: "Reviewable"}); err != nil {
+			t.Fatalf("InsertWanted() error: %v", err)
+		}
+		if err := store.ClaimWanted(wantedID, "worker-rig", DefaultClaimLease); err != nil {
+			t.Fatalf("ClaimWanted() error: %v", err)
+		}
+		if err := store.SubmitCompletion("c-"+wantedID, wantedID, "worker-rig", "https://pr/1"); err != nil {
+			t.Fatalf("SubmitCompletion() error: %v", err)
+		}
+	}
+
+	t.Run("ValidateCompletion", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		submitted(t, store, "w-conf10")
+
+		stamp := &Stamp{ID: "s-conf10", Author: "reviewer-rig", Valence: map[string]int{"quality": 4}}
+		if err := store.ValidateCompletion("w-conf10", "r-conf10", stamp); err != nil {
+			t.Fatalf("ValidateCompletion() error: %v", err)
+		}
+
+		got, err := store.QueryWanted("w-conf10")
+		if err != nil {
+			t.Fatalf("QueryWanted() error: %v", err)
+		}
+		if got.Status != "completed" {
+			t.Errorf("Status = %q, want %q", got.Status, "completed")
+		}
+		c, err := store.QueryCompletion("w-conf10")
+		if err != nil {
+			t.Fatalf("QueryCompletion() error: %v", err)
+		}
+		if c.ValidatedBy != "reviewer-rig" || c.StampID != "s-conf10" {
+			t.Errorf("completion = %+v, want validated by reviewer-rig with stamp s-conf10", c)
+		}
+
+		// Already completed: a second validation must fail.
+		again := &Stamp{ID: "s-conf10b", Author: "other-rig", Valence: map[string]int{"quality": 1}}
+		if err := store.ValidateCompletion("w-conf10", "r-conf10b", again); err == nil {
+			t.Error("second ValidateCompletion() should fail")
+		}
+	})
+
+	t.Run("ValidateOwnCompletionFails", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		submitted(t, store, "w-conf11")
+
+		stamp := &Stamp{ID: "s-conf11", Author: "worker-rig", Valence: map[string]int{"quality": 5}}
+		if err := store.ValidateCompletion("w-conf11", "r-conf11", stamp); err == nil {
+			t.Fatal("ValidateCompletion() by the completer should fail")
+		}
+		got, _ := store.QueryWanted("w-conf11")
+		if got.Status != "in_review" {
+			t.Errorf("Status = %q, want %q", got.Status, "in_review")
+		}
+	})
+
+	t.Run("RejectAllowsResubmit", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		submitted(t, store, "w-conf12")
+
+		if err := store.RejectCompletion("w-conf12", "r-conf12", "reviewer-rig", "tests fail"); err != nil {
+			t.Fatalf("RejectCompletion() error: %v", err)
+		}
+		got, _ := store.QueryWanted("w-conf12")
+		if got.Status != "claimed" {
+			t.Errorf("Status = %q, want %q", got.Status, "claimed")
+		}
+		if _, err := store.QueryCompletion("w-conf12"); err == nil {
+			t.Error("rejected completion should be removed")
+		}
+		if err := store.SubmitCompletion("c-conf12b", "w-conf12", "worker-rig", "https://pr/2"); err != nil {
+			t.Fatalf("resubmit error: %v", err)
+		}
+	})
+
+	t.Run("DisputeLifecycle", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		submitted(t, store, "w-conf13")
+
+		d := &Dispute{ID: "d-conf13", WantedID: "w-conf13", OpenedBy: "skeptic-rig", Reason: "draft", Quorum: 1}
+		if err := store.OpenDispute(d); err != nil {
+			t.Fatalf("OpenDispute() error: %v", err)
+		}
+		if err := store.OpenDispute(&Dispute{ID: "d-conf13b", WantedID: "w-conf13", OpenedBy: "other-rig", Quorum: 1}); err == nil {
+			t.Error("second OpenDispute() should fail")
+		}
+
+		stamp := &Stamp{ID: "s-conf13", Author: "rev-a", Valence: map[string]int{"quality": 3}}
+		vote := &ReviewVote{ID: "v-conf13", DisputeID: "d-conf13", Reviewer: "rev-a", Verdict: VerdictValidate, Stamp: stamp}
+		if err := store.CastVote(vote); err != nil {
+			t.Fatalf("CastVote() error: %v", err)
+		}
+		if err := store.CastVote(vote); err == nil {
+			t.Error("duplicate CastVote() should fail")
+		}
+
+		open, votes, err := store.QueryOpenDispute("w-conf13")
+		if err != nil {
+			t.Fatalf("QueryOpenDispute() error: %v", err)
+		}
+		if open == nil || open.CompletionID != "c-w-conf13" || len(votes) != 1 || votes[0].Stamp == nil {
+			t.Fatalf("dispute = %+v, votes = %+v", open, votes)
+		}
+
+		if err := store.ResolveDispute(open, VerdictValidate, []*Stamp{votes[0].Stamp}); err != nil {
+			t.Fatalf("ResolveDispute() error: %v", err)
+		}
+		if err := store.ResolveDispute(open, VerdictValidate, nil); err == nil {
+			t.Error("resolving twice should fail")
+		}
+		got, _ := store.QueryWanted("w-conf13")
+		if got.Status != "completed" {
+			t.Errorf("Status = %q, want %q", got.Status, "completed")
+		}
+		if open, _, _ := store.QueryOpenDispute("w-conf13"); open != nil {
+			t.Errorf("dispute still open: %+v", open)
+		}
+	})
+
+	t.Run("ClaimLease", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+
+		if err := store.InsertWanted(&WantedItem{ID: "w-conf14", Title: "Leased"}); err != nil {
+			t.Fatalf("InsertWanted() error: %v", err)
+		}
+		before := time.Now()
+		if err := store.ClaimWanted("w-conf14", "lease-rig", time.Hour); err != nil {
+			t.Fatalf("ClaimWanted() error: %v", err)
+		}
+
+		var lease *ClaimLease
+		leases, err := store.QueryClaimLeases()
+		if err != nil {
+			t.Fatalf("QueryClaimLeases() error: %v", err)
+		}
+		for _, l := range leases {
+			if l.WantedID == "w-conf14" {
+				lease = l
+			}
+		}
+		if lease == nil || lease.RigHandle != "lease-rig" || !lease.Leased {
+			t.Fatalf("lease = %+v, want lease held by lease-rig", lease)
+		}
+		if d := lease.ExpiresAt.Sub(before); d < 59*time.Minute || d > 61*time.Minute {
+			t.Errorf("lease expires in %s, want about 1h", d)
+		}
+
+		if err := store.RenewClaim("w-conf14", "other-rig", time.Hour); err == nil {
+			t.Error("RenewClaim() by another rig should fail")
+		}
+		if err := store.RenewClaim("w-conf14", "lease-rig", 2*time.Hour); err != nil {
+			t.Fatalf("RenewClaim() error: %v", err)
+		}
+		if err := store.ReleaseExpiredClaim("w-conf14", "lease-rig"); err == nil {
+			t.Error("ReleaseExpiredClaim() on a live lease should fail")
+		}
+		got, _ := store.QueryWanted("w-conf14")
+		if got.Status != "claimed" {
+			t.Errorf("Status = %q, want %q", got.Status, "claimed")
+		}
+	})
 }
 
 // TestFakeWLCommonsStore_Conformance runs the conformance suite against the fake.
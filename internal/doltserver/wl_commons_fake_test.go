@@ -2,7 +2,10 @@ package doltserver
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 // fakeWLCommonsStore is an in-memory implementation of WLCommonsStore for testing.
@@ -12,18 +15,32 @@ type fakeWLCommonsStore struct {
 	items map[string]*WantedItem
 	dbOK  bool
 
+	completions map[string]*Completion // By wanted ID
+	stamps      []*Stamp
+	disputes    map[string]*Dispute      // By wanted ID
+	votes       map[string][]*ReviewVote // By dispute ID
+	leases      map[string]*ClaimLease   // By wanted ID
+	now         func() time.Time
+
 	// Error injection fields
-	EnsureDBErr         error
-	InsertWantedErr     error
-	ClaimWantedErr      error
-	SubmitCompletionErr error
-	QueryWantedErr      error
+	EnsureDBErr           error
+	InsertWantedErr       error
+	ClaimWantedErr        error
+	SubmitCompletionErr   error
+	QueryWantedErr        error
+	ValidateCompletionErr error
 }
 
 func newFakeWLCommonsStore() *fakeWLCommonsStore {
 	return &fakeWLCommonsStore{
 		items: make(map[string]*WantedItem),
 		dbOK:  true,
+
+		completions: make(map[string]*Completion),
+		disputes:    make(map[string]*Dispute),
+		votes:       make(map[string][]*ReviewVote),
+		leases:      make(map[string]*ClaimLease),
+		now:         time.Now,
 	}
 }
 
@@ -65,7 +82,7 @@ func (f *fakeWLCommonsStore) InsertWanted(item *WantedItem) error {
 	return nil
 }
 
-func (f *fakeWLCommonsStore) ClaimWanted(wantedID, rigHandle string) error {
+func (f *fakeWLCommonsStore) ClaimWanted(wantedID, rigHandle string, lease time.Duration) error {
 	if f.ClaimWantedErr != nil {
 		return f.ClaimWantedErr
 	}
@@ -82,6 +99,7 @@ func (f *fakeWLCommonsStore) ClaimWanted(wantedID, rigHandle string) error {
 	}
 	item.Status = "claimed"
 	item.ClaimedBy = rigHandle
+	f.leaseFor(wantedID, rigHandle, lease)
 	return nil
 }
 
@@ -104,6 +122,8 @@ func (f *fakeWLCommonsStore) SubmitCompletion(completionID, wantedID, rigHandle,
 		return fmt.Errorf("wanted item %q is not claimed by %q (claimed by %q)", wantedID, rigHandle, item.ClaimedBy)
 	}
 	item.Status = "in_review"
+	delete(f.leases, wantedID)
+	f.completions[wantedID] = &Completion{ID: completionID, WantedID: wantedID, CompletedBy: rigHandle, Evidence: evidence}
 	return nil
 }
 
@@ -122,3 +142,228 @@ func (f *fakeWLCommonsStore) QueryWanted(wantedID string) (*WantedItem, error) {
 	cp := *item
 	return &cp, nil
 }
+
+func (f *fakeWLCommonsStore) QueryCompletion(wantedID string) (*Completion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.completions[wantedID]
+	if !ok {
+		return nil, fmt.Errorf("no completion submitted for %q", wantedID)
+	}
+	cp := *c
+	return &cp, nil
+}
+
+// pendingCompletion returns the unvalidated completion of an item in status
+// that reviewer may act on. Callers hold f.mu.
+func (f *fakeWLCommonsStore) pendingCompletion(wantedID, status, reviewer string) (*WantedItem, *Completion, error) {
+	item, ok := f.items[wantedID]
+	c := f.completions[wantedID]
+	if !ok || item.Status != status || c == nil || c.ValidatedBy != "" {
+		return nil, nil, fmt.Errorf("wanted item %q is not %s", wantedID, status)
+	}
+	if c.CompletedBy == reviewer {
+		return nil, nil, fmt.Errorf("%q completed wanted item %q and cannot review it", reviewer, wantedID)
+	}
+	return item, c, nil
+}
+
+func (f *fakeWLCommonsStore) ValidateCompletion(wantedID, reviewID string, stamp *Stamp) error {
+	if f.ValidateCompletionErr != nil {
+		return f.ValidateCompletionErr
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	item, c, err := f.pendingCompletion(wantedID, "in_review", stamp.Author)
+	if err != nil {
+		return err
+	}
+	stamp.Subject = c.CompletedBy
+	c.ValidatedBy = stamp.Author
+	c.StampID = stamp.ID
+	item.Status = "completed"
+	s := *stamp
+	f.stamps = append(f.stamps, &s)
+	return nil
+}
+
+func (f *fakeWLCommonsStore) RejectCompletion(wantedID, reviewID, reviewer, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	item, _, err := f.pendingCompletion(wantedID, "in_review", reviewer)
+	if err != nil {
+		return err
+	}
+	item.Status = "claimed"
+	delete(f.completions, wantedID)
+	f.leaseFor(wantedID, item.ClaimedBy, 0)
+	return nil
+}
+
+func (f *fakeWLCommonsStore) OpenDispute(d *Dispute) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	item, c, err := f.pendingCompletion(d.WantedID, "in_review", d.OpenedBy)
+	if err != nil {
+		return err
+	}
+	stored := *d
+	stored.CompletionID = c.ID
+	stored.Status = DisputeOpen
+	f.disputes[d.WantedID] = &stored
+	item.Status = "disputed"
+	return nil
+}
+
+func (f *fakeWLCommonsStore) CastVote(v *ReviewVote) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var d *Dispute
+	for _, candidate := range f.disputes {
+		if candidate.ID == v.DisputeID && candidate.Status == DisputeOpen {
+			d = candidate
+		}
+	}
+	if d == nil {
+		return fmt.Errorf("dispute %q is not open", v.DisputeID)
+	}
+	if c := f.completions[d.WantedID]; c != nil && c.CompletedBy == v.Reviewer {
+		return fmt.Errorf("%q completed the item and cannot vote", v.Reviewer)
+	}
+	for _, existing := range f.votes[d.ID] {
+		if existing.Reviewer == v.Reviewer {
+			return fmt.Errorf("%q already voted on dispute %q", v.Reviewer, d.ID)
+		}
+	}
+	stored := *v
+	f.votes[d.ID] = append(f.votes[d.ID], &stored)
+	return nil
+}
+
+func (f *fakeWLCommonsStore) QueryOpenDispute(wantedID string) (*Dispute, []*ReviewVote, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	d, ok := f.disputes[wantedID]
+	if !ok || d.Status != DisputeOpen {
+		return nil, nil, nil
+	}
+	cp := *d
+	votes := make([]*ReviewVote, 0, len(f.votes[d.ID]))
+	for _, v := range f.votes[d.ID] {
+		vc := *v
+		votes = append(votes, &vc)
+	}
+	return &cp, votes, nil
+}
+
+func (f *fakeWLCommonsStore) ResolveDispute(d *Dispute, verdict string, stamps []*Stamp) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.disputes[d.WantedID]
+	if !ok || stored.ID != d.ID || stored.Status != DisputeOpen {
+		return fmt.Errorf("dispute %q is already resolved", d.ID)
+	}
+	item := f.items[d.WantedID]
+	switch verdict {
+	case VerdictValidate:
+		stored.Status = DisputeValidated
+		item.Status = "completed"
+		c := f.completions[d.WantedID]
+		var authors []string
+		for _, s := range stamps {
+			authors = append(authors, s.Author)
+			sc := *s
+			sc.Subject = c.CompletedBy
+			f.stamps = append(f.stamps, &sc)
+		}
+		c.ValidatedBy = strings.Join(authors, ",")
+		if len(stamps) > 0 {
+			c.StampID = stamps[0].ID
+		}
+	case VerdictReject:
+		stored.Status = DisputeRejected
+		item.Status = "claimed"
+		delete(f.completions, d.WantedID)
+		f.leaseFor(d.WantedID, item.ClaimedBy, 0)
+	default:
+		return fmt.Errorf("unknown verdict %q", verdict)
+	}
+	return nil
+}
+
+// leaseFor starts a lease on wantedID for rigHandle. Callers hold f.mu.
+func (f *fakeWLCommonsStore) leaseFor(wantedID, rigHandle string, lease time.Duration) {
+	if lease <= 0 {
+		lease = DefaultClaimLease
+	}
+	f.leases[wantedID] = &ClaimLease{
+		WantedID:  wantedID,
+		Title:     f.items[wantedID].Title,
+		RigHandle: rigHandle,
+		ExpiresAt: f.now().Add(lease),
+		Leased:    true,
+	}
+}
+
+func (f *fakeWLCommonsStore) RenewClaim(wantedID, rigHandle string, lease time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	item, ok := f.items[wantedID]
+	if !ok || item.Status != "claimed" || item.ClaimedBy != rigHandle {
+		return fmt.Errorf("wanted item %q is not claimed by %q", wantedID, rigHandle)
+	}
+	f.leaseFor(wantedID, rigHandle, lease)
+	return nil
+}
+
+func (f *fakeWLCommonsStore) QueryClaimLeases() ([]*ClaimLease, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var leases []*ClaimLease
+	for id, item := range f.items {
+		if item.Status != "claimed" {
+			continue
+		}
+		if l, ok := f.leases[id]; ok && l.RigHandle == item.ClaimedBy {
+			cp := *l
+			leases = append(leases, &cp)
+		}
+	}
+	sort.Slice(leases, func(i, j int) bool { return leases[i].ExpiresAt.Before(leases[j].ExpiresAt) })
+	return leases, nil
+}
+
+func (f *fakeWLCommonsStore) ReleaseExpiredClaim(wantedID, rigHandle string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	item, ok := f.items[wantedID]
+	l := f.leases[wantedID]
+	if !ok || item.Status != "claimed" || item.ClaimedBy != rigHandle || l == nil || !l.Expired(f.now()) {
+		return fmt.Errorf("claim on %q by %q has not expired", wantedID, rigHandle)
+	}
+	item.Status = "open"
+	item.ClaimedBy = ""
+	delete(f.leases, wantedID)
+	return nil
+}
+
+func (f *fakeWLCommonsStore) MarkClaimWarned(wantedID, rigHandle string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if l, ok := f.leases[wantedID]; ok && l.RigHandle == rigHandle {
+		l.WarnedAt = f.now()
+	}
+	return nil
+}
@@ -3,6 +3,7 @@
 package doltserver
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -53,13 +54,18 @@ func TestIsNothingToCommit_RealDolt(t *testing.T) {
 	townRoot := startIsolatedDoltContainer(t)
 
 	// Create a database and table so we have a valid context for DOLT_COMMIT.
+	admin, err := Client(townRoot, "")
+	if err != nil {
+		t.Fatalf("Client() error: %v", err)
+	}
+	ctx := context.Background()
 	initScript := fmt.Sprintf(`CREATE DATABASE IF NOT EXISTS %s;
 USE %s;
 CREATE TABLE IF NOT EXISTS _ping (id INT PRIMARY KEY);
 CALL DOLT_ADD('-A');
 CALL DOLT_COMMIT('-m', 'init ping table');
 `, WLCommonsDB, WLCommonsDB)
-	if err := doltSQLScript(townRoot, initScript); err != nil {
+	if err := admin.Script(ctx, initScript); err != nil {
 		t.Fatalf("init script error: %v", err)
 	}
 
@@ -68,7 +74,7 @@ CALL DOLT_COMMIT('-m', 'init ping table');
 CALL DOLT_ADD('-A');
 CALL DOLT_COMMIT('-m', 'noop');
 `, WLCommonsDB)
-	err := doltSQLScript(townRoot, noopScript)
+	err = admin.Script(ctx, noopScript)
 	if err == nil {
 		t.Fatal("expected error from DOLT_COMMIT with no changes, got nil")
 	}
@@ -5,91 +5,6 @@ import (
 	"testing"
 )
 
-func TestParseSimpleCSV_Empty(t *testing.T) {
-	t.Parallel()
-	got := parseSimpleCSV("")
-	if got != nil {
-		t.Errorf("parseSimpleCSV(\"\") = %v, want nil", got)
-	}
-}
-
-func TestParseSimpleCSV_HeaderOnly(t *testing.T) {
-	t.Parallel()
-	got := parseSimpleCSV("id,title,status\n")
-	if got != nil {
-		t.Errorf("parseSimpleCSV(header-only) = %v, want nil", got)
-	}
-}
-
-func TestParseSimpleCSV_SingleRow(t *testing.T) {
-	t.Parallel()
-	data := "id,title,status\nw-abc,Fix bug,open"
-	got := parseSimpleCSV(data)
-	if len(got) != 1 {
-		t.Fatalf("got %d rows, want 1", len(got))
-	}
-	if got[0]["id"] != "w-abc" {
-		t.Errorf("id = %q, want %q", got[0]["id"], "w-abc")
-	}
-	if got[0]["title"] != "Fix bug" {
-		t.Errorf("title = %q, want %q", got[0]["title"], "Fix bug")
-	}
-	if got[0]["status"] != "open" {
-		t.Errorf("status = %q, want %q", got[0]["status"], "open")
-	}
-}
-
-func TestParseSimpleCSV_MultiRow(t *testing.T) {
-	t.Parallel()
-	data := "id,title\nw-1,First\nw-2,Second\nw-3,Third"
-	got := parseSimpleCSV(data)
-	if len(got) != 3 {
-		t.Fatalf("got %d rows, want 3", len(got))
-	}
-	for i, wantID := range []string{"w-1", "w-2", "w-3"} {
-		if got[i]["id"] != wantID {
-			t.Errorf("row %d id = %q, want %q", i, got[i]["id"], wantID)
-		}
-	}
-}
-
-func TestParseSimpleCSV_MissingFields(t *testing.T) {
-	t.Parallel()
-	data := "id,title,status\nw-abc,Fix bug"
-	got := parseSimpleCSV(data)
-	if len(got) != 1 {
-		t.Fatalf("got %d rows, want 1", len(got))
-	}
-	// Missing 'status' field should not be present in map
-	if _, ok := got[0]["status"]; ok {
-		t.Error("expected missing 'status' field to not be present")
-	}
-}
-
-func TestParseSimpleCSV_SkipsBlankLines(t *testing.T) {
-	t.Parallel()
-	data := "id,title\nw-1,First\n\nw-2,Second\n"
-	got := parseSimpleCSV(data)
-	if len(got) != 2 {
-		t.Fatalf("got %d rows, want 2", len(got))
-	}
-}
-
-func TestParseSimpleCSV_TrimsWhitespace(t *testing.T) {
-	t.Parallel()
-	data := " id , title \n w-abc , Fix bug "
-	got := parseSimpleCSV(data)
-	if len(got) != 1 {
-		t.Fatalf("got %d rows, want 1", len(got))
-	}
-	if got[0]["id"] != "w-abc" {
-		t.Errorf("id = %q, want %q", got[0]["id"], "w-abc")
-	}
-	if got[0]["title"] != "Fix bug" {
-		t.Errorf("title = %q, want %q", got[0]["title"], "Fix bug")
-	}
-}
-
 func TestEscapeSQL_SingleQuotes(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -0,0 +1,193 @@
+// Package doltserver - wl_leases.go provides claim leases for wl-commons
+// wanted items. A claim holds for its lease duration; the claimer extends it
+// with gt wl renew, and gt wl sync reaps expired claims back to 'open' so
+// abandoned work does not block other rigs.
+package doltserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltclient"
+)
+
+// DefaultClaimLease is how long a claim holds without renewal. Claims made
+// before leases existed (no claim_leases row) expire this long after the
+// item was last updated.
+const DefaultClaimLease = 72 * time.Hour
+
+// ClaimLease is the lease on a claimed wanted item.
+type ClaimLease struct {
+	WantedID  string
+	Title     string
+	RigHandle string
+	ExpiresAt time.Time
+	WarnedAt  time.Time // Zero if the claimer has not been warned
+	Leased    bool      // False for claims made before leases existed
+}
+
+// Expired reports whether the lease has run out at now.
+func (l *ClaimLease) Expired(now time.Time) bool { return !now.Before(l.ExpiresAt) }
+
+// RenewClaim extends the claimer's lease.
+func (w *WLCommons) RenewClaim(wantedID, rigHandle string, lease time.Duration) error {
+	return RenewClaim(w.townRoot, wantedID, rigHandle, lease)
+}
+
+// QueryClaimLeases returns the leases of all claimed items.
+func (w *WLCommons) QueryClaimLeases() ([]*ClaimLease, error) { return QueryClaimLeases(w.townRoot) }
+
+// ReleaseExpiredClaim returns an expired claim to open.
+func (w *WLCommons) ReleaseExpiredClaim(wantedID, rigHandle string) error {
+	return ReleaseExpiredClaim(w.townRoot, wantedID, rigHandle)
+}
+
+// MarkClaimWarned records that the claimer was warned of expiry.
+func (w *WLCommons) MarkClaimWarned(wantedID, rigHandle string) error {
+	return MarkClaimWarned(w.townRoot, wantedID, rigHandle)
+}
+
+const wlLeaseTableDDL = `
+CREATE TABLE IF NOT EXISTS claim_leases (
+    wanted_id VARCHAR(64) PRIMARY KEY,
+    rig_handle VARCHAR(255),
+    claimed_at TIMESTAMP,
+    renewed_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    warned_at TIMESTAMP
+);
+`
+
+// ensureLeaseSchema creates the claim_leases table in commons cloned
+// before it existed.
+func (r *wlRepo) ensureLeaseSchema(ctx context.Context) error {
+	if err := r.ensureSchema(ctx, wlLeaseTableDDL, "wl-commons: add claim_leases table"); err != nil {
+		return fmt.Errorf("creating claim_leases table: %w", err)
+	}
+	return nil
+}
+
+// leaseSeconds converts a lease to whole seconds, applying the default.
+func leaseSeconds(lease time.Duration) int64 {
+	if lease <= 0 {
+		lease = DefaultClaimLease
+	}
+	return int64(lease / time.Second)
+}
+
+// RenewClaim extends the lease on a claimed item to lease from now and
+// clears any expiry warning. Only the claimer can renew, and only while the
+// item is still claimed; an expired lease can be renewed until it is reaped.
+func RenewClaim(townRoot, wantedID, rigHandle string, lease time.Duration) error {
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureLeaseSchema(ctx); err != nil {
+			return err
+		}
+		return r.mutate(ctx, "wl renew: "+wantedID, func(t *wlTx) error {
+			return t.require(`INSERT INTO claim_leases (wanted_id, rig_handle, claimed_at, renewed_at, expires_at, warned_at)
+  SELECT id, claimed_by, updated_at, NOW(), DATE_ADD(NOW(), INTERVAL ? SECOND), NULL
+  FROM wanted WHERE id = ? AND status = 'claimed' AND claimed_by = ?
+  ON DUPLICATE KEY UPDATE rig_handle = VALUES(rig_handle), renewed_at = NOW(),
+    expires_at = VALUES(expires_at), warned_at = NULL`,
+				leaseSeconds(lease), wantedID, rigHandle)
+		})
+	})
+	if errors.Is(err, errWLPrecondition) {
+		return fmt.Errorf("wanted item %q is not claimed by %q", wantedID, rigHandle)
+	}
+	if err != nil {
+		return fmt.Errorf("renew failed: %w", err)
+	}
+	return nil
+}
+
+// QueryClaimLeases returns the lease of every claimed item, soonest expiry
+// first. Claims without a lease row get the default lease from their last
+// update.
+func QueryClaimLeases(townRoot string) ([]*ClaimLease, error) {
+	var leases []*ClaimLease
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureLeaseSchema(ctx); err != nil {
+			return err
+		}
+		var err error
+		leases, err = doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (*ClaimLease, error) {
+			l := &ClaimLease{}
+			var expires, warned int64
+			if err := row.Scan(&l.WantedID, &l.Title, &l.RigHandle, &expires, &warned, &l.Leased); err != nil {
+				return nil, err
+			}
+			l.ExpiresAt = time.Unix(expires, 0)
+			if warned > 0 {
+				l.WarnedAt = time.Unix(warned, 0)
+			}
+			return l, nil
+		}, `SELECT w.id, COALESCE(w.title, ''), COALESCE(w.claimed_by, ''),
+	UNIX_TIMESTAMP(COALESCE(l.expires_at, DATE_ADD(w.updated_at, INTERVAL ? SECOND))),
+	COALESCE(UNIX_TIMESTAMP(l.warned_at), 0), l.wanted_id IS NOT NULL
+	FROM wanted w LEFT JOIN claim_leases l ON l.wanted_id = w.id AND l.rig_handle = w.claimed_by
+	WHERE w.status = 'claimed'
+	ORDER BY 4, w.id`, leaseSeconds(DefaultClaimLease))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying claim leases: %w", err)
+	}
+	return leases, nil
+}
+
+// ReleaseExpiredClaim returns a claimed item to open if its lease has
+// expired. The expiry is re-checked in the same transaction, so a renewal
+// that lands first wins and the release fails.
+func ReleaseExpiredClaim(townRoot, wantedID, rigHandle string) error {
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureLeaseSchema(ctx); err != nil {
+			return err
+		}
+		return r.mutate(ctx, fmt.Sprintf("wl reap: %s (claim by %s expired)", wantedID, rigHandle), func(t *wlTx) error {
+			if err := t.require(`UPDATE wanted SET status = 'open', claimed_by = NULL, updated_at = NOW()
+  WHERE id = ? AND status = 'claimed' AND claimed_by = ?
+  AND (EXISTS (SELECT 1 FROM claim_leases WHERE wanted_id = ? AND rig_handle = ? AND expires_at <= NOW())
+    OR (NOT EXISTS (SELECT 1 FROM claim_leases WHERE wanted_id = ? AND rig_handle = ?)
+      AND updated_at <= DATE_SUB(NOW(), INTERVAL ? SECOND)))`,
+				wantedID, rigHandle, wantedID, rigHandle, wantedID, rigHandle, leaseSeconds(DefaultClaimLease)); err != nil {
+				return err
+			}
+			_, err := t.exec(`DELETE FROM claim_leases WHERE wanted_id = ?`, wantedID)
+			return err
+		})
+	})
+	if errors.Is(err, errWLPrecondition) {
+		return fmt.Errorf("claim on %q by %q has not expired", wantedID, rigHandle)
+	}
+	if err != nil {
+		return fmt.Errorf("releasing claim failed: %w", err)
+	}
+	return nil
+}
+
+// MarkClaimWarned records that the claimer was warned about an upcoming
+// expiry, so the warning is sent once per lease. Renewing clears it.
+func MarkClaimWarned(townRoot, wantedID, rigHandle string) error {
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureLeaseSchema(ctx); err != nil {
+			return err
+		}
+		return r.mutate(ctx, "wl lease warning: "+wantedID, func(t *wlTx) error {
+			_, err := t.exec(`INSERT INTO claim_leases (wanted_id, rig_handle, claimed_at, expires_at, warned_at)
+  SELECT id, claimed_by, updated_at, DATE_ADD(updated_at, INTERVAL ? SECOND), NOW()
+  FROM wanted WHERE id = ? AND status = 'claimed' AND claimed_by = ?
+  ON DUPLICATE KEY UPDATE
+    expires_at = IF(rig_handle = VALUES(rig_handle), expires_at, VALUES(expires_at)),
+    rig_handle = VALUES(rig_handle), warned_at = NOW()`,
+				leaseSeconds(DefaultClaimLease), wantedID, rigHandle)
+			return err
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("recording lease warning: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Package doltserver - wl_repo.go is the typed repository behind every
+// wl-commons operation. Statements bind their values as parameters instead
+// of splicing escaped strings into SQL: titles, descriptions and evidence
+// arrive from other towns via sync, so they are untrusted input.
+//
+// Each mutation runs in one transaction that ends in a Dolt commit. Guarded
+// statements (claim only if open, validate only if in review, ...) report a
+// precondition failure when they match no rows, and the whole transaction is
+// rolled back.
+package doltserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltclient"
+)
+
+// WLSchemaVersion is the wl-commons schema version this gt writes. Commons
+// with a newer major version are refused rather than written to with a
+// stale idea of the schema.
+const WLSchemaVersion = "1.0"
+
+// errWLPrecondition marks a mutation whose guarded statement matched no rows.
+var errWLPrecondition = errors.New("precondition not met")
+
+// wlRepo runs wl-commons queries and mutations on the pooled client.
+type wlRepo struct {
+	c *doltclient.Client
+}
+
+// openWLRepo connects to wl-commons and checks its schema version.
+func openWLRepo(ctx context.Context, townRoot string) (*wlRepo, error) {
+	c, err := Client(townRoot, WLCommonsDB)
+	if err != nil {
+		return nil, err
+	}
+	return newWLRepo(ctx, c)
+}
+
+// newWLRepo wraps a client for a wl-commons database, such as a served
+// clone, after checking its schema version.
+func newWLRepo(ctx context.Context, c *doltclient.Client) (*wlRepo, error) {
+	versions, err := doltclient.Select(ctx, c, func(row doltclient.Scanner) (string, error) {
+		var v string
+		return v, row.Scan(&v)
+	}, "SELECT COALESCE(value, '') FROM _meta WHERE `key` = 'schema_version'")
+	if err != nil {
+		return nil, fmt.Errorf("reading wl-commons schema version: %w", err)
+	}
+	version := ""
+	if len(versions) > 0 {
+		version = versions[0]
+	}
+	if err := checkWLSchemaVersion(version); err != nil {
+		return nil, err
+	}
+	return &wlRepo{c: c}, nil
+}
+
+// checkWLSchemaVersion accepts commons whose major schema version is at most
+// WLSchemaVersion's. Minor versions only add tables and columns.
+func checkWLSchemaVersion(version string) error {
+	if version == "" {
+		return fmt.Errorf("wl-commons has no schema_version in _meta")
+	}
+	have, err := schemaMajor(version)
+	if err != nil {
+		return fmt.Errorf("wl-commons schema_version %q: %w", version, err)
+	}
+	want, _ := schemaMajor(WLSchemaVersion)
+	if have > want {
+		return fmt.Errorf("wl-commons schema v%s is newer than this gt supports (v%s) — upgrade gt", version, WLSchemaVersion)
+	}
+	return nil
+}
+
+func schemaMajor(version string) (int, error) {
+	major, _, _ := strings.Cut(version, ".")
+	return strconv.Atoi(major)
+}
+
+// wlTx is a wl-commons mutation in progress.
+type wlTx struct {
+	ctx      context.Context
+	tx       *sql.Tx
+	affected int64
+}
+
+// exec runs a statement and returns the rows it affected.
+func (t *wlTx) exec(query string, args ...any) (int64, error) {
+	res, err := t.tx.ExecContext(t.ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	t.affected += n
+	return n, nil
+}
+
+// require runs a guarded statement and fails the mutation with
+// errWLPrecondition if it affected no rows.
+func (t *wlTx) require(query string, args ...any) error {
+	n, err := t.exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errWLPrecondition
+	}
+	return nil
+}
+
+// mutate runs fn in a transaction and records it as a Dolt commit with
+// message. If fn changed no rows there is nothing to commit and mutate
+// returns nil. The transaction is retried on optimistic-lock errors.
+func (r *wlRepo) mutate(ctx context.Context, message string, fn func(*wlTx) error) error {
+	return r.c.Tx(ctx, func(tx *sql.Tx) error {
+		t := &wlTx{ctx: ctx, tx: tx}
+		if err := fn(t); err != nil {
+			return err
+		}
+		if t.affected == 0 {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, "CALL DOLT_COMMIT('-Am', ?)", message); err != nil {
+			return fmt.Errorf("dolt commit: %w", err)
+		}
+		return nil
+	})
+}
+
+// ensureSchema runs idempotent DDL and commits it if anything was created.
+// DDL carries no user input, so it runs as a plain script; the commit
+// message is bound like any other value.
+func (r *wlRepo) ensureSchema(ctx context.Context, ddl, message string) error {
+	if err := r.c.Script(ctx, ddl); err != nil {
+		return err
+	}
+	if _, err := r.c.Exec(ctx, "CALL DOLT_COMMIT('-Am', ?)", message); err != nil && !isNothingToCommit(err) {
+		return fmt.Errorf("dolt commit: %w", err)
+	}
+	return nil
+}
+
+// withWLRepo opens the repository and runs fn with the standard timeout.
+func withWLRepo(townRoot string, fn func(context.Context, *wlRepo) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	r, err := openWLRepo(ctx, townRoot)
+	if err != nil {
+		return err
+	}
+	return fn(ctx, r)
+}
+
+// nullIfEmpty binds an empty string as SQL NULL.
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
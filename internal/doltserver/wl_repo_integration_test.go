@@ -0,0 +1,204 @@
+//go:build integration
+
+package doltserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltclient"
+	"github.com/steveyegge/gastown/internal/testutil"
+)
+
+// startLocalWLCommons starts a local dolt sql-server for a fresh town and
+// creates wl-commons in it.
+func startLocalWLCommons(t *testing.T) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	testutil.StartLocalDoltServer(t, DefaultConfig(townRoot).DataDir)
+	if err := NewWLCommons(townRoot).EnsureDB(); err != nil {
+		t.Fatalf("EnsureDB() error: %v", err)
+	}
+	return townRoot
+}
+
+// TestLocalWLCommonsStore_Conformance runs the conformance suite against a
+// local dolt sql-server.
+func TestLocalWLCommonsStore_Conformance(t *testing.T) {
+	townRoot := startLocalWLCommons(t)
+	wlCommonsConformance(t, func(t *testing.T) WLCommonsStore {
+		return NewWLCommons(townRoot)
+	})
+}
+
+// TestWLRepo_HostileInput round-trips values that would break or subvert
+// string-built SQL through the full post → claim → done lifecycle.
+func TestWLRepo_HostileInput(t *testing.T) {
+	townRoot := startLocalWLCommons(t)
+	store := NewWLCommons(townRoot)
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"single quote", "it's"},
+		{"statement injection", "x'); DROP TABLE wanted; --"},
+		{"trailing backslash", `path\`},
+		{"escaped quote", `\'`},
+		{"json breakout", `"], "admin": true, "x": ["`},
+		{"sql wildcards", "100% _done_"},
+		{"unicode", "✓ 完成 🚀"},
+		{"newlines", "line one\nline two\r\n"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := GenerateWantedID(tt.name)
+			item := &WantedItem{
+				ID:          id,
+				Title:       tt.value,
+				Description: tt.value,
+				Tags:        []string{tt.value, "plain"},
+				PostedBy:    "poster-rig",
+			}
+			if err := store.InsertWanted(item); err != nil {
+				t.Fatalf("InsertWanted() error: %v", err)
+			}
+			if err := store.ClaimWanted(id, tt.value, 0); err != nil {
+				t.Fatalf("ClaimWanted() error: %v", err)
+			}
+			if err := store.SubmitCompletion(fmt.Sprintf("c-hostile-%d", i), id, tt.value, tt.value); err != nil {
+				t.Fatalf("SubmitCompletion() error: %v", err)
+			}
+
+			got, err := store.QueryWanted(id)
+			if err != nil {
+				t.Fatalf("QueryWanted() error: %v", err)
+			}
+			if got.Title != tt.value || got.ClaimedBy != tt.value || got.Status != "in_review" {
+				t.Errorf("QueryWanted() = %+v, want title and claimer %q in review", got, tt.value)
+			}
+			c, err := store.QueryCompletion(id)
+			if err != nil {
+				t.Fatalf("QueryCompletion() error: %v", err)
+			}
+			if c.Evidence != tt.value {
+				t.Errorf("Evidence = %q, want %q", c.Evidence, tt.value)
+			}
+		})
+	}
+
+	c, err := Client(townRoot, WLCommonsDB)
+	if err != nil {
+		t.Fatalf("Client() error: %v", err)
+	}
+	n, err := c.QueryInt(context.Background(), "SELECT COUNT(*) FROM wanted")
+	if err != nil {
+		t.Fatalf("wanted table unreadable after hostile input: %v", err)
+	}
+	if int(n) != len(tests) {
+		t.Errorf("wanted has %d rows, want %d", n, len(tests))
+	}
+}
+
+// TestWLRepo_SchemaVersion checks that commons with a newer major schema
+// version are refused.
+func TestWLRepo_SchemaVersion(t *testing.T) {
+	townRoot := startLocalWLCommons(t)
+	c, err := Client(townRoot, WLCommonsDB)
+	if err != nil {
+		t.Fatalf("Client() error: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		version string
+		wantErr string
+	}{
+		{"1.0", ""},
+		{"1.7", ""},
+		{"2.0", "newer than this gt supports"},
+		{"", "no schema_version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			var err error
+			if tt.version == "" {
+				_, err = c.Exec(ctx, "DELETE FROM _meta WHERE `key` = 'schema_version'")
+			} else {
+				_, err = c.Exec(ctx, "REPLACE INTO _meta (`key`, value) VALUES ('schema_version', ?)", tt.version)
+			}
+			if err != nil {
+				t.Fatalf("setting schema_version: %v", err)
+			}
+
+			_, err = openWLRepo(ctx, townRoot)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("openWLRepo() with v%s error: %v", tt.version, err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("openWLRepo() with v%q error = %v, want %q", tt.version, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestWLRepo_RollsBackFailedMutation checks that a mutation whose later
+// guard fails leaves no partial writes and no Dolt commit.
+func TestWLRepo_RollsBackFailedMutation(t *testing.T) {
+	townRoot := startLocalWLCommons(t)
+	store := NewWLCommons(townRoot)
+
+	if err := store.InsertWanted(&WantedItem{ID: "w-rollback", Title: "Roll back"}); err != nil {
+		t.Fatalf("InsertWanted() error: %v", err)
+	}
+	if err := store.ClaimWanted("w-rollback", "worker-rig", 0); err != nil {
+		t.Fatalf("ClaimWanted() error: %v", err)
+	}
+	if err := store.SubmitCompletion("c-rollback", "w-rollback", "worker-rig", "https://example.com/pr/1"); err != nil {
+		t.Fatalf("SubmitCompletion() error: %v", err)
+	}
+
+	c, err := Client(townRoot, WLCommonsDB)
+	if err != nil {
+		t.Fatalf("Client() error: %v", err)
+	}
+	ctx := context.Background()
+	commits := func() int64 {
+		n, err := c.QueryInt(ctx, "SELECT COUNT(*) FROM dolt_log")
+		if err != nil {
+			t.Fatalf("counting commits: %v", err)
+		}
+		return n
+	}
+	before := commits()
+
+	// The item is in review, so the status guard passes, but the completer
+	// cannot validate their own work: the completions guard fails.
+	stamp := &Stamp{ID: "s-rollback", Author: "worker-rig", Valence: map[string]int{"quality": 5}}
+	if err := store.ValidateCompletion("w-rollback", "r-rollback", stamp); err == nil {
+		t.Fatal("ValidateCompletion() by the completer succeeded")
+	}
+
+	got, err := store.QueryWanted("w-rollback")
+	if err != nil {
+		t.Fatalf("QueryWanted() error: %v", err)
+	}
+	if got.Status != "in_review" {
+		t.Errorf("Status = %q after failed validation, want in_review", got.Status)
+	}
+	stamps, err := doltclient.Select(ctx, c, func(row doltclient.Scanner) (string, error) {
+		var id string
+		return id, row.Scan(&id)
+	}, "SELECT id FROM stamps WHERE id = ?", stamp.ID)
+	if err != nil {
+		t.Fatalf("querying stamps: %v", err)
+	}
+	if len(stamps) != 0 {
+		t.Errorf("failed validation left stamp %v behind", stamps)
+	}
+	if after := commits(); after != before {
+		t.Errorf("failed validation made %d Dolt commits, want 0", after-before)
+	}
+}
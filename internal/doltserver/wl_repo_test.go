@@ -0,0 +1,48 @@
+package doltserver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckWLSchemaVersion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version string
+		wantErr string
+	}{
+		{"1.0", ""},
+		{"1.4", ""},
+		{"0.9", ""},
+		{"1", ""},
+		{"2.0", "newer than this gt supports"},
+		{"10.1", "newer than this gt supports"},
+		{"", "no schema_version"},
+		{"v1", "schema_version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+			err := checkWLSchemaVersion(tt.version)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkWLSchemaVersion(%q) = %v, want nil", tt.version, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkWLSchemaVersion(%q) = %v, want error containing %q", tt.version, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNullIfEmpty(t *testing.T) {
+	t.Parallel()
+	if got := nullIfEmpty(""); got != nil {
+		t.Errorf("nullIfEmpty(\"\") = %v, want nil", got)
+	}
+	if got := nullIfEmpty("x"); got != "x" {
+		t.Errorf("nullIfEmpty(\"x\") = %v, want \"x\"", got)
+	}
+}
@@ -0,0 +1,204 @@
+// Package doltserver - wl_reputation.go computes rig reputation from the
+// wl-commons history: how often a rig finishes what it claims, how long it
+// takes, how its completions fare in review, and the stamps and badges it
+// has been awarded.
+//
+// Counts come from the reputation view, which reads the dolt_history_*
+// system tables so claims that were later released or rejected still count.
+// Only committed rows are visible there, which is every row written by the
+// wl commands.
+package doltserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltclient"
+)
+
+// WLReputationSelect computes per-rig reputation counts and rates. It is the
+// body of the reputation view, and is also usable inline against commons
+// clones that predate the view.
+const WLReputationSelect = `SELECT r.*,
+  r.validated / NULLIF(r.claims, 0) AS completion_rate,
+  r.validated / NULLIF(r.submitted - r.pending, 0) AS pass_rate
+FROM (
+  SELECT h.handle AS handle,
+    (SELECT COUNT(*) FROM wanted WHERE posted_by = h.handle) AS posted,
+    (SELECT COUNT(DISTINCT id) FROM dolt_history_wanted WHERE claimed_by = h.handle) AS claims,
+    (SELECT COUNT(DISTINCT id) FROM dolt_history_completions WHERE completed_by = h.handle) AS submitted,
+    (SELECT COUNT(*) FROM completions WHERE completed_by = h.handle AND validated_by IS NOT NULL) AS validated,
+    (SELECT COUNT(*) FROM completions WHERE completed_by = h.handle AND validated_by IS NULL) AS pending,
+    (SELECT COUNT(*) FROM stamps WHERE subject = h.handle) AS stamps,
+    (SELECT COUNT(*) FROM badges WHERE rig_handle = h.handle) AS badges
+  FROM (
+    SELECT handle FROM rigs
+    UNION SELECT posted_by FROM wanted WHERE posted_by IS NOT NULL
+    UNION SELECT claimed_by FROM dolt_history_wanted WHERE claimed_by IS NOT NULL
+  ) h
+) r`
+
+// Reputation is a rig's track record on the wanted board.
+type Reputation struct {
+	Handle    string `json:"handle"`
+	Posted    int    `json:"posted"`    // Wanted items posted
+	Claims    int    `json:"claims"`    // Distinct items ever claimed
+	Submitted int    `json:"submitted"` // Completions submitted, including rejected ones
+	Validated int    `json:"validated"` // Completions that passed review
+	Pending   int    `json:"pending"`   // Completions awaiting review or in dispute
+	Rejected  int    `json:"rejected"`  // Completions sent back by review
+
+	// CompletionRate is Validated/Claims; PassRate is Validated over
+	// reviewed submissions. Both are 0 when there is nothing to divide by.
+	CompletionRate float64 `json:"completion_rate"`
+	PassRate       float64 `json:"pass_rate"`
+
+	// MedianTimeToComplete runs from a rig's first claim on an item to the
+	// submission that was validated.
+	MedianTimeToComplete time.Duration `json:"median_time_to_complete"`
+
+	Stamps        int            `json:"stamps"`
+	StampsBySkill map[string]int `json:"stamps_by_skill,omitempty"`
+	Badges        []*Badge       `json:"badges,omitempty"`
+}
+
+// Badge is a row in the badges table.
+type Badge struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	AwardedAt time.Time `json:"awarded_at"`
+	Evidence  string    `json:"evidence,omitempty"`
+}
+
+const wlReputationViewDDL = "CREATE OR REPLACE VIEW reputation AS " + WLReputationSelect + ";\n"
+
+// ensureReputationView creates or refreshes the reputation view. It commits
+// only when the view definition changed.
+func (r *wlRepo) ensureReputationView(ctx context.Context) error {
+	if err := r.ensureSchema(ctx, wlReputationViewDDL, "wl-commons: add reputation view"); err != nil {
+		return fmt.Errorf("creating reputation view: %w", err)
+	}
+	return nil
+}
+
+// QueryReputation computes the reputation of a rig. Returns an error if the
+// handle has never registered, posted or claimed on this commons.
+func QueryReputation(townRoot, handle string) (*Reputation, error) {
+	var rep *Reputation
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureReputationView(ctx); err != nil {
+			return err
+		}
+		var err error
+		rep, err = r.queryReputation(ctx, handle)
+		return err
+	})
+	return rep, err
+}
+
+func (r *wlRepo) queryReputation(ctx context.Context, handle string) (*Reputation, error) {
+	reps, err := doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (*Reputation, error) {
+		rep := &Reputation{}
+		return rep, row.Scan(&rep.Handle, &rep.Posted, &rep.Claims, &rep.Submitted, &rep.Validated, &rep.Pending, &rep.Stamps)
+	}, `SELECT handle, posted, claims, submitted, validated, pending, stamps FROM reputation WHERE handle = ?`, handle)
+	if err != nil {
+		return nil, fmt.Errorf("querying reputation: %w", err)
+	}
+	if len(reps) == 0 {
+		return nil, fmt.Errorf("no wasteland history for %q", handle)
+	}
+	rep := reps[0]
+	rep.computeRates()
+
+	durations, err := doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (time.Duration, error) {
+		var secs int64
+		err := row.Scan(&secs)
+		return time.Duration(secs) * time.Second, err
+	}, `SELECT UNIX_TIMESTAMP(c.completed_at) - UNIX_TIMESTAMP(MIN(h.commit_date))
+	FROM completions c JOIN dolt_history_wanted h ON h.id = c.wanted_id AND h.claimed_by = c.completed_by
+	WHERE c.completed_by = ? AND c.validated_by IS NOT NULL AND c.completed_at IS NOT NULL
+	GROUP BY c.id, c.completed_at`, handle)
+	if err != nil {
+		return nil, fmt.Errorf("querying completion times: %w", err)
+	}
+	rep.MedianTimeToComplete = medianDuration(durations)
+
+	tags, err := doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (string, error) {
+		var s string
+		return s, row.Scan(&s)
+	}, `SELECT COALESCE(CAST(skill_tags AS CHAR), '') FROM stamps WHERE subject = ?`, handle)
+	if err != nil {
+		return nil, fmt.Errorf("querying stamps: %w", err)
+	}
+	if rep.StampsBySkill, err = countSkillTags(tags); err != nil {
+		return nil, err
+	}
+
+	rep.Badges, err = doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (*Badge, error) {
+		b := &Badge{}
+		var awarded int64
+		if err := row.Scan(&b.ID, &b.Type, &awarded, &b.Evidence); err != nil {
+			return nil, err
+		}
+		if awarded > 0 {
+			b.AwardedAt = time.Unix(awarded, 0)
+		}
+		return b, nil
+	}, `SELECT id, COALESCE(badge_type, ''), COALESCE(UNIX_TIMESTAMP(awarded_at), 0), COALESCE(evidence, '')
+	FROM badges WHERE rig_handle = ? ORDER BY awarded_at, id`, handle)
+	if err != nil {
+		return nil, fmt.Errorf("querying badges: %w", err)
+	}
+	return rep, nil
+}
+
+// computeRates derives Rejected and the rates from the counts.
+func (r *Reputation) computeRates() {
+	r.Rejected = r.Submitted - r.Validated - r.Pending
+	if r.Rejected < 0 {
+		r.Rejected = 0
+	}
+	r.CompletionRate, r.PassRate = 0, 0
+	if r.Claims > 0 {
+		r.CompletionRate = float64(r.Validated) / float64(r.Claims)
+	}
+	if reviewed := r.Validated + r.Rejected; reviewed > 0 {
+		r.PassRate = float64(r.Validated) / float64(reviewed)
+	}
+}
+
+// medianDuration returns the median of ds, or 0 if ds is empty.
+func medianDuration(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// countSkillTags tallies stamps per skill tag from their JSON skill_tags
+// arrays. Empty values (stamps without tags) are skipped.
+func countSkillTags(tagArrays []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, raw := range tagArrays {
+		if raw == "" {
+			continue
+		}
+		var tags []string
+		if err := json.Unmarshal([]byte(raw), &tags); err != nil {
+			return nil, fmt.Errorf("parsing stamp skill tags %q: %w", raw, err)
+		}
+		for _, t := range tags {
+			counts[t]++
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,73 @@
+package doltserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMedianDuration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		in   []time.Duration
+		want time.Duration
+	}{
+		{"empty", nil, 0},
+		{"one", []time.Duration{time.Hour}, time.Hour},
+		{"odd unsorted", []time.Duration{5 * time.Hour, time.Hour, 3 * time.Hour}, 3 * time.Hour},
+		{"even", []time.Duration{time.Hour, 4 * time.Hour, 2 * time.Hour, 10 * time.Hour}, 3 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := medianDuration(tt.in); got != tt.want {
+				t.Errorf("medianDuration(%v) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMedianDuration_DoesNotReorderInput(t *testing.T) {
+	t.Parallel()
+	in := []time.Duration{3, 1, 2}
+	medianDuration(in)
+	if in[0] != 3 || in[1] != 1 || in[2] != 2 {
+		t.Errorf("medianDuration reordered its input: %v", in)
+	}
+}
+
+func TestCountSkillTags(t *testing.T) {
+	t.Parallel()
+	got, err := countSkillTags([]string{`["go","sql"]`, "", `["go"]`, `[]`})
+	if err != nil {
+		t.Fatalf("countSkillTags: %v", err)
+	}
+	if len(got) != 2 || got["go"] != 2 || got["sql"] != 1 {
+		t.Errorf("countSkillTags = %v, want map[go:2 sql:1]", got)
+	}
+
+	if _, err := countSkillTags([]string{"not json"}); err == nil {
+		t.Error("countSkillTags accepted malformed skill_tags")
+	}
+}
+
+func TestReputation_ComputeRates(t *testing.T) {
+	t.Parallel()
+	r := &Reputation{Claims: 4, Submitted: 4, Validated: 2, Pending: 1}
+	r.computeRates()
+	if r.Rejected != 1 {
+		t.Errorf("Rejected = %d, want 1", r.Rejected)
+	}
+	if r.CompletionRate != 0.5 {
+		t.Errorf("CompletionRate = %v, want 0.5", r.CompletionRate)
+	}
+	if r.PassRate != 2.0/3.0 {
+		t.Errorf("PassRate = %v, want 2/3", r.PassRate)
+	}
+
+	empty := &Reputation{}
+	empty.computeRates()
+	if empty.CompletionRate != 0 || empty.PassRate != 0 || empty.Rejected != 0 {
+		t.Errorf("empty reputation rates = %+v, want zeros", empty)
+	}
+}
@@ -0,0 +1,445 @@
+// Package doltserver - wl_review.go provides the wl-commons review lifecycle:
+// validating completions (with a reputation stamp), rejecting them back to
+// the claimer, and settling disputed completions by reviewer quorum.
+//
+//	claimed ──done──▶ in_review ──validate──▶ completed (+ stamp)
+//	   ▲                  │  │
+//	   └──────reject──────┘  └──dispute──▶ disputed ──quorum validate──▶ completed (+ stamps)
+//	   ▲                                      │
+//	   └──────────────quorum reject───────────┘
+package doltserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/doltclient"
+)
+
+// Review verdicts.
+const (
+	VerdictValidate = "validate"
+	VerdictReject   = "reject"
+)
+
+// Dispute states.
+const (
+	DisputeOpen      = "open"
+	DisputeValidated = "validated"
+	DisputeRejected  = "rejected"
+)
+
+// Completion represents a row in the completions table.
+type Completion struct {
+	ID          string
+	WantedID    string
+	CompletedBy string
+	Evidence    string
+	ValidatedBy string
+	StampID     string
+}
+
+// Stamp is a reputation attestation by one rig (Author) about another
+// (Subject), issued when Author validates Subject's completion.
+type Stamp struct {
+	ID         string         `json:"id"`
+	Author     string         `json:"author"`
+	Subject    string         `json:"subject,omitempty"`
+	Valence    map[string]int `json:"valence"` // Score per dimension, e.g. {"quality": 4}
+	Confidence float64        `json:"confidence,omitempty"`
+	Severity   string         `json:"severity,omitempty"` // leaf, branch or root
+	SkillTags  []string       `json:"skill_tags,omitempty"`
+	Message    string         `json:"message,omitempty"`
+}
+
+// Dispute is a contested completion awaiting a quorum of reviewer votes.
+type Dispute struct {
+	ID           string
+	WantedID     string
+	CompletionID string
+	OpenedBy     string
+	Reason       string
+	Quorum       int
+	Status       string
+}
+
+// ReviewVote is one reviewer's verdict on a disputed completion. Validate
+// votes carry the stamp the reviewer issues if the dispute is validated.
+type ReviewVote struct {
+	ID        string
+	DisputeID string
+	Reviewer  string
+	Verdict   string
+	Reason    string
+	Stamp     *Stamp // Only for validate votes
+}
+
+// ValidateCompletion validates the completion of an in_review item, marks
+// the item completed and records the reviewer's stamp for the completer.
+// Reviewers cannot validate their own completion.
+func (w *WLCommons) ValidateCompletion(wantedID, reviewID string, stamp *Stamp) error {
+	return ValidateCompletion(w.townRoot, wantedID, reviewID, stamp)
+}
+
+// RejectCompletion returns an in_review item to its claimer.
+func (w *WLCommons) RejectCompletion(wantedID, reviewID, reviewer, reason string) error {
+	return RejectCompletion(w.townRoot, wantedID, reviewID, reviewer, reason)
+}
+
+// OpenDispute moves an in_review item to disputed.
+func (w *WLCommons) OpenDispute(d *Dispute) error { return OpenDispute(w.townRoot, d) }
+
+// CastVote records a reviewer's vote on an open dispute.
+func (w *WLCommons) CastVote(v *ReviewVote) error { return CastVote(w.townRoot, v) }
+
+// ResolveDispute settles an open dispute.
+func (w *WLCommons) ResolveDispute(d *Dispute, verdict string, stamps []*Stamp) error {
+	return ResolveDispute(w.townRoot, d, verdict, stamps)
+}
+
+// QueryCompletion returns the pending or validated completion of a wanted item.
+func (w *WLCommons) QueryCompletion(wantedID string) (*Completion, error) {
+	return QueryCompletion(w.townRoot, wantedID)
+}
+
+// QueryOpenDispute returns the open dispute on a wanted item and its votes.
+func (w *WLCommons) QueryOpenDispute(wantedID string) (*Dispute, []*ReviewVote, error) {
+	return QueryOpenDispute(w.townRoot, wantedID)
+}
+
+// ensureReviewSchema creates the review tables in commons cloned before
+// they existed. It commits only when a table was actually created.
+func (r *wlRepo) ensureReviewSchema(ctx context.Context) error {
+	if err := r.ensureSchema(ctx, wlReviewTablesDDL, "wl-commons: add review tables"); err != nil {
+		return fmt.Errorf("creating review tables: %w", err)
+	}
+	return nil
+}
+
+// wlReviewTablesDDL defines the tables behind the review lifecycle. Rejected
+// completions are deleted from completions (so the claimer can resubmit) and
+// survive only as a reviews row with a copy of their evidence.
+const wlReviewTablesDDL = `
+CREATE TABLE IF NOT EXISTS reviews (
+    id VARCHAR(64) PRIMARY KEY,
+    wanted_id VARCHAR(64),
+    completion_id VARCHAR(64),
+    dispute_id VARCHAR(64),
+    reviewer VARCHAR(255),
+    verdict VARCHAR(16),
+    reason TEXT,
+    evidence TEXT,
+    stamp JSON,
+    created_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS disputes (
+    id VARCHAR(64) PRIMARY KEY,
+    wanted_id VARCHAR(64),
+    completion_id VARCHAR(64),
+    opened_by VARCHAR(255),
+    reason TEXT,
+    quorum INT DEFAULT 3,
+    status VARCHAR(16) DEFAULT 'open',
+    opened_at TIMESTAMP,
+    resolved_at TIMESTAMP
+);
+`
+
+// insertStamp inserts a stamp on completion completionID.
+func (t *wlTx) insertStamp(s *Stamp, completionID string) error {
+	valence, err := json.Marshal(s.Valence)
+	if err != nil {
+		return fmt.Errorf("encoding stamp valence: %w", err)
+	}
+	var tags any
+	if len(s.SkillTags) > 0 {
+		b, err := json.Marshal(s.SkillTags)
+		if err != nil {
+			return fmt.Errorf("encoding stamp skill tags: %w", err)
+		}
+		tags = string(b)
+	}
+	severity := s.Severity
+	if severity == "" {
+		severity = "leaf"
+	}
+	confidence := s.Confidence
+	if confidence <= 0 {
+		confidence = 1
+	}
+	_, err = t.exec(`INSERT IGNORE INTO stamps (id, author, subject, valence, confidence, severity, context_id, context_type, skill_tags, message, created_at)
+  VALUES (?, ?, ?, ?, ?, ?, ?, 'completion', ?, ?, NOW())`,
+		s.ID, s.Author, s.Subject, string(valence), confidence, severity, completionID, tags, s.Message)
+	return err
+}
+
+// reviewMutation opens the repository, ensures the review tables and runs
+// one mutation.
+func reviewMutation(townRoot, message string, fn func(*wlTx) error) error {
+	return withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureReviewSchema(ctx); err != nil {
+			return err
+		}
+		return r.mutate(ctx, message, fn)
+	})
+}
+
+// ValidateCompletion validates the completion of an in_review item: the
+// completion records the reviewer and stamp, the stamp is inserted and the
+// item moves to completed. The stamp's Author is the reviewer; Subject is
+// filled in from the completion.
+//
+// If the item is not in_review or the reviewer completed it themselves,
+// nothing changes.
+func ValidateCompletion(townRoot, wantedID, reviewID string, stamp *Stamp) error {
+	c, err := QueryCompletion(townRoot, wantedID)
+	if err != nil {
+		return err
+	}
+	stamp.Subject = c.CompletedBy
+
+	err = reviewMutation(townRoot, "wl validate: "+wantedID, func(t *wlTx) error {
+		if err := t.require(`UPDATE wanted SET status = 'completed', updated_at = NOW()
+  WHERE id = ? AND status = 'in_review'`, wantedID); err != nil {
+			return err
+		}
+		if err := t.require(`UPDATE completions SET validated_by = ?, validated_at = NOW(), stamp_id = ?
+  WHERE id = ? AND wanted_id = ? AND validated_by IS NULL AND completed_by <> ?`,
+			stamp.Author, stamp.ID, c.ID, wantedID, stamp.Author); err != nil {
+			return err
+		}
+		if err := t.insertStamp(stamp, c.ID); err != nil {
+			return err
+		}
+		_, err := t.exec(`INSERT IGNORE INTO reviews (id, wanted_id, completion_id, reviewer, verdict, reason, created_at)
+  VALUES (?, ?, ?, ?, 'validate', ?, NOW())`, reviewID, wantedID, c.ID, stamp.Author, stamp.Message)
+		return err
+	})
+	if errors.Is(err, errWLPrecondition) {
+		return fmt.Errorf("wanted item %q is not in review, or %q completed it and cannot validate it", wantedID, stamp.Author)
+	}
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return nil
+}
+
+// RejectCompletion returns an in_review item to claimed so its claimer can
+// rework and resubmit. The completion is removed; the review row keeps the
+// rejected evidence and the reviewer's reasons.
+func RejectCompletion(townRoot, wantedID, reviewID, reviewer, reason string) error {
+	err := reviewMutation(townRoot, "wl reject: "+wantedID, func(t *wlTx) error {
+		if err := t.require(`UPDATE wanted SET status = 'claimed', evidence_url = NULL, updated_at = NOW()
+  WHERE id = ? AND status = 'in_review'
+  AND EXISTS (SELECT 1 FROM completions WHERE wanted_id = ? AND validated_by IS NULL AND completed_by <> ?)`,
+			wantedID, wantedID, reviewer); err != nil {
+			return err
+		}
+		if _, err := t.exec(`INSERT IGNORE INTO reviews (id, wanted_id, completion_id, reviewer, verdict, reason, evidence, created_at)
+  SELECT ?, wanted_id, id, ?, 'reject', ?, evidence, NOW()
+  FROM completions WHERE wanted_id = ? AND validated_by IS NULL`,
+			reviewID, reviewer, reason, wantedID); err != nil {
+			return err
+		}
+		_, err := t.exec(`DELETE FROM completions WHERE wanted_id = ? AND validated_by IS NULL`, wantedID)
+		return err
+	})
+	if errors.Is(err, errWLPrecondition) {
+		return fmt.Errorf("wanted item %q is not in review, or %q completed it and cannot reject it", wantedID, reviewer)
+	}
+	if err != nil {
+		return fmt.Errorf("rejection failed: %w", err)
+	}
+	return nil
+}
+
+// OpenDispute moves an in_review item to disputed and records the dispute.
+// Only one dispute can be open per item, and the completer cannot open one.
+func OpenDispute(townRoot string, d *Dispute) error {
+	err := reviewMutation(townRoot, "wl dispute: "+d.WantedID, func(t *wlTx) error {
+		if err := t.require(`UPDATE wanted SET status = 'disputed', updated_at = NOW()
+  WHERE id = ? AND status = 'in_review'
+  AND EXISTS (SELECT 1 FROM completions WHERE wanted_id = ? AND validated_by IS NULL AND completed_by <> ?)
+  AND NOT EXISTS (SELECT 1 FROM disputes WHERE wanted_id = ? AND status = 'open')`,
+			d.WantedID, d.WantedID, d.OpenedBy, d.WantedID); err != nil {
+			return err
+		}
+		return t.require(`INSERT INTO disputes (id, wanted_id, completion_id, opened_by, reason, quorum, status, opened_at)
+  SELECT ?, wanted_id, id, ?, ?, ?, 'open', NOW()
+  FROM completions WHERE wanted_id = ? AND validated_by IS NULL`,
+			d.ID, d.OpenedBy, d.Reason, d.Quorum, d.WantedID)
+	})
+	if errors.Is(err, errWLPrecondition) {
+		return fmt.Errorf("wanted item %q is not in review, or %q completed it and cannot dispute it", d.WantedID, d.OpenedBy)
+	}
+	if err != nil {
+		return fmt.Errorf("opening dispute failed: %w", err)
+	}
+	return nil
+}
+
+// CastVote records a vote on an open dispute. Vote IDs are derived from the
+// dispute and reviewer by the caller, so a second vote by the same reviewer
+// is ignored and reported as an error.
+func CastVote(townRoot string, v *ReviewVote) error {
+	var stamp any
+	if v.Stamp != nil {
+		b, err := json.Marshal(v.Stamp)
+		if err != nil {
+			return fmt.Errorf("encoding vote stamp: %w", err)
+		}
+		stamp = string(b)
+	}
+	err := reviewMutation(townRoot, fmt.Sprintf("wl vote: %s %s", v.DisputeID, v.Verdict), func(t *wlTx) error {
+		return t.require(`INSERT IGNORE INTO reviews (id, wanted_id, completion_id, dispute_id, reviewer, verdict, reason, stamp, created_at)
+  SELECT ?, d.wanted_id, d.completion_id, d.id, ?, ?, ?, ?, NOW()
+  FROM disputes d JOIN completions c ON c.id = d.completion_id
+  WHERE d.id = ? AND d.status = 'open' AND c.completed_by <> ?`,
+			v.ID, v.Reviewer, v.Verdict, v.Reason, stamp, v.DisputeID, v.Reviewer)
+	})
+	if errors.Is(err, errWLPrecondition) {
+		return fmt.Errorf("dispute %q is not open, %q already voted, or %q completed the item", v.DisputeID, v.Reviewer, v.Reviewer)
+	}
+	if err != nil {
+		return fmt.Errorf("vote failed: %w", err)
+	}
+	return nil
+}
+
+// ResolveDispute settles an open dispute once a verdict reached quorum.
+// Validated disputes complete the item and insert one stamp per validating
+// reviewer; rejected disputes return the item to its claimer. Resolving an
+// already-resolved dispute is an error, so concurrent resolvers are safe.
+func ResolveDispute(townRoot string, d *Dispute, verdict string, stamps []*Stamp) error {
+	var resolve func(*wlTx) error
+	switch verdict {
+	case VerdictValidate:
+		completion, err := QueryCompletion(townRoot, d.WantedID)
+		if err != nil {
+			return err
+		}
+		var authors []string
+		for _, s := range stamps {
+			s.Subject = completion.CompletedBy
+			authors = append(authors, s.Author)
+		}
+		firstStamp := ""
+		if len(stamps) > 0 {
+			firstStamp = stamps[0].ID
+		}
+		resolve = func(t *wlTx) error {
+			if err := t.require(`UPDATE disputes SET status = 'validated', resolved_at = NOW() WHERE id = ? AND status = 'open'`, d.ID); err != nil {
+				return err
+			}
+			if _, err := t.exec(`UPDATE wanted SET status = 'completed', updated_at = NOW() WHERE id = ? AND status = 'disputed'`, d.WantedID); err != nil {
+				return err
+			}
+			if _, err := t.exec(`UPDATE completions SET validated_by = ?, validated_at = NOW(), stamp_id = ?
+  WHERE id = ? AND validated_by IS NULL`, strings.Join(authors, ","), firstStamp, d.CompletionID); err != nil {
+				return err
+			}
+			for _, s := range stamps {
+				if err := t.insertStamp(s, d.CompletionID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case VerdictReject:
+		resolve = func(t *wlTx) error {
+			if err := t.require(`UPDATE disputes SET status = 'rejected', resolved_at = NOW() WHERE id = ? AND status = 'open'`, d.ID); err != nil {
+				return err
+			}
+			if _, err := t.exec(`UPDATE wanted SET status = 'claimed', evidence_url = NULL, updated_at = NOW()
+  WHERE id = ? AND status = 'disputed'`, d.WantedID); err != nil {
+				return err
+			}
+			_, err := t.exec(`DELETE FROM completions WHERE id = ? AND validated_by IS NULL`, d.CompletionID)
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown verdict %q", verdict)
+	}
+
+	err := reviewMutation(townRoot, fmt.Sprintf("wl dispute resolved: %s %s", d.WantedID, verdict), resolve)
+	if errors.Is(err, errWLPrecondition) {
+		return fmt.Errorf("dispute %q is already resolved", d.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("resolving dispute failed: %w", err)
+	}
+	return nil
+}
+
+// QueryCompletion returns the completion recorded for a wanted item.
+func QueryCompletion(townRoot, wantedID string) (*Completion, error) {
+	var rows []*Completion
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		var err error
+		rows, err = doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (*Completion, error) {
+			cp := &Completion{}
+			return cp, row.Scan(&cp.ID, &cp.WantedID, &cp.CompletedBy, &cp.Evidence, &cp.ValidatedBy, &cp.StampID)
+		}, `SELECT id, COALESCE(wanted_id, ''), COALESCE(completed_by, ''), COALESCE(evidence, ''),
+	COALESCE(validated_by, ''), COALESCE(stamp_id, '') FROM completions WHERE wanted_id = ?`, wantedID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying completion: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no completion submitted for %q", wantedID)
+	}
+	return rows[0], nil
+}
+
+// QueryOpenDispute returns the open dispute on a wanted item with its votes,
+// oldest first. Returns nil and no error if the item has no open dispute.
+func QueryOpenDispute(townRoot, wantedID string) (*Dispute, []*ReviewVote, error) {
+	var d *Dispute
+	var votes []*ReviewVote
+	err := withWLRepo(townRoot, func(ctx context.Context, r *wlRepo) error {
+		if err := r.ensureReviewSchema(ctx); err != nil {
+			return err
+		}
+		disputes, err := doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (*Dispute, error) {
+			d := &Dispute{}
+			return d, row.Scan(&d.ID, &d.WantedID, &d.CompletionID, &d.OpenedBy, &d.Reason, &d.Quorum, &d.Status)
+		}, `SELECT id, wanted_id, COALESCE(completion_id, ''), COALESCE(opened_by, ''), COALESCE(reason, ''),
+	COALESCE(quorum, 3), status FROM disputes WHERE wanted_id = ? AND status = 'open'`, wantedID)
+		if err != nil {
+			return fmt.Errorf("querying dispute: %w", err)
+		}
+		if len(disputes) == 0 {
+			return nil
+		}
+		d = disputes[0]
+
+		votes, err = doltclient.Select(ctx, r.c, func(row doltclient.Scanner) (*ReviewVote, error) {
+			v := &ReviewVote{DisputeID: d.ID}
+			var stamp string
+			if err := row.Scan(&v.ID, &v.Reviewer, &v.Verdict, &v.Reason, &stamp); err != nil {
+				return nil, err
+			}
+			if stamp != "" {
+				v.Stamp = &Stamp{}
+				if err := json.Unmarshal([]byte(stamp), v.Stamp); err != nil {
+					return nil, fmt.Errorf("parsing stamp of vote %s: %w", v.ID, err)
+				}
+			}
+			return v, nil
+		}, `SELECT id, COALESCE(reviewer, ''), COALESCE(verdict, ''), COALESCE(reason, ''), COALESCE(CAST(stamp AS CHAR), '')
+	FROM reviews WHERE dispute_id = ? ORDER BY created_at, id`, d.ID)
+		if err != nil {
+			return fmt.Errorf("querying dispute votes: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, votes, nil
+}
@@ -0,0 +1,506 @@
+// Package eventchan implements the durable, file-based event channels under
+// ~/gt/events/<channel>/.
+//
+// Each event is a JSON file named <nanos>-<offset>-<pid>.event. The offset is
+// assigned from a per-channel counter under an advisory lock, so it is unique
+// and increases in emit order. Consumer groups record the highest offset they
+// have acknowledged in .consumers/<group>.offset; a group is delivered every
+// event above its offset until it acknowledges, which gives at-least-once
+// delivery to each group independently. Events are pruned once every group
+// has acknowledged them, or when they exceed the channel's retention limits.
+//
+// Readers without a group see every .event file in the directory, as before
+// consumer groups existed.
+package eventchan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/lock"
+)
+
+const (
+	eventSuffix   = ".event"
+	seqFile       = ".seq"
+	lockFile      = ".lock"
+	consumersDir  = ".consumers"
+	offsetSuffix  = ".offset"
+	retentionFile = ".retention.json"
+)
+
+// validName restricts channel and group names to safe characters (no path
+// traversal).
+var validName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ValidName reports whether name is a valid channel or consumer group name.
+func ValidName(name string) bool { return validName.MatchString(name) }
+
+// emitMu serializes emits within one process; the flock serializes them
+// across processes (and is a no-op on Windows).
+var emitMu sync.Mutex
+
+// Event is an event file in a channel.
+type Event struct {
+	Offset  uint64          `json:"offset,omitempty"`
+	Path    string          `json:"path"`
+	Content json.RawMessage `json:"content"`
+}
+
+// Retention bounds how many events a channel keeps regardless of
+// acknowledgements, so a consumer that never acks cannot fill the disk.
+// Zero values mean no limit.
+type Retention struct {
+	MaxEvents int           `json:"max_events,omitempty"`
+	MaxAge    time.Duration `json:"max_age,omitempty"`
+}
+
+// Channel is one event channel directory.
+type Channel struct {
+	name string
+	dir  string
+}
+
+// Open returns the channel name in townRoot/events, creating its directory.
+func Open(townRoot, name string) (*Channel, error) {
+	if !ValidName(name) {
+		return nil, fmt.Errorf("invalid channel name %q: must match [a-zA-Z0-9_-]", name)
+	}
+	return OpenDir(filepath.Join(townRoot, "events", name), name)
+}
+
+// OpenDir returns a channel stored in dir, creating it.
+func OpenDir(dir, name string) (*Channel, error) {
+	if !ValidName(name) {
+		return nil, fmt.Errorf("invalid channel name %q: must match [a-zA-Z0-9_-]", name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating event directory: %w", err)
+	}
+	return &Channel{name: name, dir: dir}, nil
+}
+
+// Name returns the channel name.
+func (c *Channel) Name() string { return c.name }
+
+// Dir returns the channel directory.
+func (c *Channel) Dir() string { return c.dir }
+
+// withLock runs fn holding the channel's advisory lock.
+func (c *Channel) withLock(fn func() error) error {
+	emitMu.Lock()
+	defer emitMu.Unlock()
+	unlock, err := lock.FlockAcquire(filepath.Join(c.dir, lockFile))
+	if err != nil {
+		return fmt.Errorf("locking channel %s: %w", c.name, err)
+	}
+	defer unlock()
+	return fn()
+}
+
+// Emit writes an event with the next offset and returns it. If the channel
+// has retention limits or consumer groups, it is pruned afterwards.
+func (c *Channel) Emit(eventType string, payload map[string]string) (*Event, error) {
+	var ev *Event
+	err := c.withLock(func() error {
+		offset, err := c.nextOffset()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"type":      eventType,
+			"channel":   c.name,
+			"offset":    offset,
+			"timestamp": now.Format(time.RFC3339),
+			"payload":   payload,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling event: %w", err)
+		}
+
+		path := filepath.Join(c.dir, fmt.Sprintf("%d-%d-%d%s", now.UnixNano(), offset, os.Getpid(), eventSuffix))
+		if err := writeFileAtomic(path, data); err != nil {
+			return fmt.Errorf("writing event file: %w", err)
+		}
+		ev = &Event{Offset: offset, Path: path, Content: data}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.Prune(); err != nil {
+		return ev, fmt.Errorf("pruning channel %s: %w", c.name, err)
+	}
+	return ev, nil
+}
+
+// nextOffset increments and returns the channel's offset counter. A channel
+// without a counter starts above the highest offset already on disk, so
+// events written before counters existed keep their order. Caller must hold
+// the channel lock.
+func (c *Channel) nextOffset() (uint64, error) {
+	path := filepath.Join(c.dir, seqFile)
+	var last uint64
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		last, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	case os.IsNotExist(err):
+		events, err := c.list()
+		if err != nil {
+			return 0, err
+		}
+		for _, e := range events {
+			if e.Offset > last {
+				last = e.Offset
+			}
+		}
+	default:
+		return 0, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	next := last + 1
+	if err := writeFileAtomic(path, []byte(strconv.FormatUint(next, 10)+"\n")); err != nil {
+		return 0, fmt.Errorf("writing %s: %w", path, err)
+	}
+	return next, nil
+}
+
+// eventFile is an event file's location and offset, without its content.
+type eventFile struct {
+	Offset uint64
+	Path   string
+	Nanos  int64
+}
+
+// parseEventName extracts the emit time and offset from an event file name.
+// ok is false for names not in <nanos>-<offset>-<pid>.event form.
+func parseEventName(name string) (nanos int64, offset uint64, ok bool) {
+	parts := strings.Split(strings.TrimSuffix(name, eventSuffix), "-")
+	if len(parts) != 3 {
+		return 0, 0, false
+	}
+	nanos, err1 := strconv.ParseInt(parts[0], 10, 64)
+	offset, err2 := strconv.ParseUint(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return nanos, offset, true
+}
+
+// OffsetOf returns the offset encoded in an event file's name, or 0 if the
+// name carries none.
+func OffsetOf(path string) uint64 {
+	_, offset, _ := parseEventName(filepath.Base(path))
+	return offset
+}
+
+// list returns the channel's event files in offset order. Files whose name
+// carries no offset sort first with offset 0.
+func (c *Channel) list() ([]eventFile, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var files []eventFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), eventSuffix) {
+			continue
+		}
+		nanos, offset, _ := parseEventName(entry.Name())
+		files = append(files, eventFile{Offset: offset, Path: filepath.Join(c.dir, entry.Name()), Nanos: nanos})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Offset != files[j].Offset {
+			return files[i].Offset < files[j].Offset
+		}
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+// Read returns the events with an offset above after, oldest first.
+// Unreadable files (e.g., removed by a concurrent prune) are skipped.
+func (c *Channel) Read(after uint64) ([]Event, error) {
+	files, err := c.list()
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	for _, f := range files {
+		if f.Offset <= after {
+			continue
+		}
+		data, err := os.ReadFile(f.Path)
+		if err != nil {
+			continue
+		}
+		events = append(events, Event{Offset: f.Offset, Path: f.Path, Content: json.RawMessage(data)})
+	}
+	return events, nil
+}
+
+// ReadGroup returns the events group has not yet acknowledged.
+func (c *Channel) ReadGroup(group string) ([]Event, error) {
+	offset, err := c.Offset(group)
+	if err != nil {
+		return nil, err
+	}
+	return c.Read(offset)
+}
+
+func (c *Channel) offsetPath(group string) (string, error) {
+	if !ValidName(group) {
+		return "", fmt.Errorf("invalid consumer group %q: must match [a-zA-Z0-9_-]", group)
+	}
+	return filepath.Join(c.dir, consumersDir, group+offsetSuffix), nil
+}
+
+// Offset returns the highest offset group has acknowledged; 0 for a group
+// that has not acknowledged anything.
+func (c *Channel) Offset(group string) (uint64, error) {
+	path, err := c.offsetPath(group)
+	if err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading offset for group %s: %w", group, err)
+	}
+	offset, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing offset for group %s: %w", group, err)
+	}
+	return offset, nil
+}
+
+// Join registers group so that events are retained until it acknowledges
+// them. A new group starts at the beginning of the channel, so it is
+// delivered every retained event, including ones emitted before it joined.
+// A group that already exists keeps its offset.
+func (c *Channel) Join(group string) error {
+	path, err := c.offsetPath(group)
+	if err != nil {
+		return err
+	}
+	return c.withLock(func() error {
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		}
+		return c.writeOffset(path, 0)
+	})
+}
+
+// Ack records that group has processed every event up to and including
+// offset. Acknowledgements never move a group backwards; use Seek to replay.
+func (c *Channel) Ack(group string, offset uint64) error {
+	path, err := c.offsetPath(group)
+	if err != nil {
+		return err
+	}
+	return c.withLock(func() error {
+		current, err := c.Offset(group)
+		if err != nil {
+			return err
+		}
+		if offset <= current {
+			return nil
+		}
+		return c.writeOffset(path, offset)
+	})
+}
+
+// Seek sets group's offset so that the next read starts at from. Events
+// already pruned cannot be replayed.
+func (c *Channel) Seek(group string, from uint64) error {
+	path, err := c.offsetPath(group)
+	if err != nil {
+		return err
+	}
+	if from > 0 {
+		from--
+	}
+	return c.withLock(func() error { return c.writeOffset(path, from) })
+}
+
+// Leave removes group, so events are no longer retained for it.
+func (c *Channel) Leave(group string) error {
+	path, err := c.offsetPath(group)
+	if err != nil {
+		return err
+	}
+	return c.withLock(func() error {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing group %s: %w", group, err)
+		}
+		return nil
+	})
+}
+
+func (c *Channel) writeOffset(path string, offset uint64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating consumers directory: %w", err)
+	}
+	if err := writeFileAtomic(path, []byte(strconv.FormatUint(offset, 10)+"\n")); err != nil {
+		return fmt.Errorf("writing offset: %w", err)
+	}
+	return nil
+}
+
+// Groups returns every consumer group and its acknowledged offset.
+func (c *Channel) Groups() (map[string]uint64, error) {
+	entries, err := os.ReadDir(filepath.Join(c.dir, consumersDir))
+	if os.IsNotExist(err) {
+		return map[string]uint64{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing consumer groups: %w", err)
+	}
+	groups := make(map[string]uint64, len(entries))
+	for _, entry := range entries {
+		group, ok := strings.CutSuffix(entry.Name(), offsetSuffix)
+		if !ok || !ValidName(group) {
+			continue
+		}
+		offset, err := c.Offset(group)
+		if err != nil {
+			return nil, err
+		}
+		groups[group] = offset
+	}
+	return groups, nil
+}
+
+// Retention returns the channel's retention limits.
+func (c *Channel) Retention() (Retention, error) {
+	var r Retention
+	data, err := os.ReadFile(filepath.Join(c.dir, retentionFile))
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return r, fmt.Errorf("reading retention: %w", err)
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return r, fmt.Errorf("parsing retention: %w", err)
+	}
+	return r, nil
+}
+
+// SetRetention stores the channel's retention limits. They apply on every
+// later emit, whichever process emits.
+func (c *Channel) SetRetention(r Retention) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return c.withLock(func() error {
+		if err := writeFileAtomic(filepath.Join(c.dir, retentionFile), data); err != nil {
+			return fmt.Errorf("writing retention: %w", err)
+		}
+		return nil
+	})
+}
+
+// Prune deletes events every consumer group has acknowledged, and events
+// beyond the channel's retention limits. Channels without groups keep
+// acknowledged-event pruning off, since their consumers delete events
+// themselves. Returns the number of events deleted.
+func (c *Channel) Prune() (int, error) {
+	var removed int
+	err := c.withLock(func() error {
+		files, err := c.list()
+		if err != nil {
+			return err
+		}
+		groups, err := c.Groups()
+		if err != nil {
+			return err
+		}
+		retention, err := c.Retention()
+		if err != nil {
+			return err
+		}
+		for _, f := range pruneCandidates(files, groups, retention, time.Now()) {
+			if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("removing %s: %w", f.Path, err)
+			}
+			removed++
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// pruneCandidates selects the events to delete from files (in offset order).
+func pruneCandidates(files []eventFile, groups map[string]uint64, r Retention, now time.Time) []eventFile {
+	acked := uint64(0)
+	if len(groups) > 0 {
+		first := true
+		for _, offset := range groups {
+			if first || offset < acked {
+				acked, first = offset, false
+			}
+		}
+	}
+
+	var prune []eventFile
+	for i, f := range files {
+		switch {
+		case len(groups) > 0 && f.Offset <= acked:
+		case r.MaxEvents > 0 && len(files)-i > r.MaxEvents:
+		case r.MaxAge > 0 && f.Nanos > 0 && now.Sub(time.Unix(0, f.Nanos)) > r.MaxAge:
+		default:
+			continue
+		}
+		prune = append(prune, f)
+	}
+	return prune
+}
+
+// writeFileAtomic writes data via a temp file and rename, so readers never
+// see a partial event or offset.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package eventchan
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestChannel(t *testing.T) *Channel {
+	t.Helper()
+	c, err := Open(t.TempDir(), "test")
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	return c
+}
+
+func emitN(t *testing.T, c *Channel, n int) []*Event {
+	t.Helper()
+	var events []*Event
+	for i := 0; i < n; i++ {
+		ev, err := c.Emit("TEST", map[string]string{"i": string(rune('a' + i))})
+		if err != nil {
+			t.Fatalf("Emit() error: %v", err)
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+func offsets(events []Event) []uint64 {
+	var out []uint64
+	for _, e := range events {
+		out = append(out, e.Offset)
+	}
+	return out
+}
+
+func TestOpenRejectsInvalidNames(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"", "../etc", "foo/bar", "a b"} {
+		if _, err := Open(t.TempDir(), name); err == nil {
+			t.Errorf("Open(%q) succeeded", name)
+		}
+	}
+	c := newTestChannel(t)
+	if _, err := c.Offset("../x"); err == nil {
+		t.Error("Offset() accepted an invalid group name")
+	}
+}
+
+func TestEmitAssignsIncreasingOffsets(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	events := emitN(t, c, 3)
+	for i, ev := range events {
+		if ev.Offset != uint64(i+1) {
+			t.Errorf("event %d offset = %d, want %d", i, ev.Offset, i+1)
+		}
+	}
+	if _, offset, ok := parseEventName(filepath.Base(events[2].Path)); !ok || offset != 3 {
+		t.Errorf("file name %q does not carry offset 3", filepath.Base(events[2].Path))
+	}
+}
+
+func TestEmitConcurrentOffsetsUnique(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Emit("TEST", nil); err != nil {
+				t.Errorf("Emit() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	events, err := c.Read(0)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if len(events) != n {
+		t.Fatalf("Read() returned %d events, want %d", len(events), n)
+	}
+	for i, e := range events {
+		if e.Offset != uint64(i+1) {
+			t.Fatalf("offsets = %v, want 1..%d without gaps or duplicates", offsets(events), n)
+		}
+	}
+}
+
+func TestNextOffsetContinuesFromLegacyFiles(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	if err := os.WriteFile(filepath.Join(c.Dir(), "1792329672168063378-11-16047.event"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ev, err := c.Emit("TEST", nil)
+	if err != nil {
+		t.Fatalf("Emit() error: %v", err)
+	}
+	if ev.Offset != 12 {
+		t.Errorf("first offset after legacy seq 11 = %d, want 12", ev.Offset)
+	}
+}
+
+func TestConsumerGroupsAreIndependent(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	for _, g := range []string{"alpha", "beta"} {
+		if err := c.Join(g); err != nil {
+			t.Fatalf("Join(%s) error: %v", g, err)
+		}
+	}
+	emitN(t, c, 3)
+
+	if err := c.Ack("alpha", 2); err != nil {
+		t.Fatalf("Ack() error: %v", err)
+	}
+
+	alpha, _ := c.ReadGroup("alpha")
+	beta, _ := c.ReadGroup("beta")
+	if got := offsets(alpha); len(got) != 1 || got[0] != 3 {
+		t.Errorf("alpha pending = %v, want [3]", got)
+	}
+	if got := offsets(beta); len(got) != 3 {
+		t.Errorf("beta pending = %v, want all 3 events", got)
+	}
+}
+
+func TestUnackedEventsAreRedelivered(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	if err := c.Join("g"); err != nil {
+		t.Fatal(err)
+	}
+	emitN(t, c, 2)
+	first, _ := c.ReadGroup("g")
+	second, _ := c.ReadGroup("g")
+	if len(first) != 2 || len(second) != 2 {
+		t.Errorf("reads without ack returned %d then %d events, want 2 and 2", len(first), len(second))
+	}
+}
+
+func TestAckIsMonotonicAndSeekReplays(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	emitN(t, c, 4)
+
+	if err := c.Ack("g", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Ack("g", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.Offset("g"); got != 3 {
+		t.Errorf("offset after Ack(3), Ack(1) = %d, want 3", got)
+	}
+
+	if err := c.Seek("g", 2); err != nil {
+		t.Fatal(err)
+	}
+	events, _ := c.ReadGroup("g")
+	if got := offsets(events); len(got) != 3 || got[0] != 2 {
+		t.Errorf("after Seek(2) pending = %v, want [2 3 4]", got)
+	}
+}
+
+func TestJoinKeepsExistingOffset(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	emitN(t, c, 2)
+	if err := c.Ack("g", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Join("g"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.Offset("g"); got != 2 {
+		t.Errorf("offset after re-Join = %d, want 2", got)
+	}
+}
+
+func TestPruneAckedByAllGroups(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	for _, g := range []string{"alpha", "beta"} {
+		if err := c.Join(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	emitN(t, c, 3)
+	_ = c.Ack("alpha", 3)
+	_ = c.Ack("beta", 1)
+
+	removed, err := c.Prune()
+	if err != nil {
+		t.Fatalf("Prune() error: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Prune() removed %d events, want 1 (acked by both groups)", removed)
+	}
+	events, _ := c.Read(0)
+	if got := offsets(events); len(got) != 2 || got[0] != 2 {
+		t.Errorf("remaining = %v, want [2 3]", got)
+	}
+
+	if err := c.Leave("beta"); err != nil {
+		t.Fatal(err)
+	}
+	if removed, _ := c.Prune(); removed != 2 {
+		t.Errorf("Prune() after beta left removed %d, want 2", removed)
+	}
+}
+
+func TestPruneWithoutGroupsKeepsEvents(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	emitN(t, c, 3)
+	if removed, _ := c.Prune(); removed != 0 {
+		t.Errorf("Prune() without groups or limits removed %d events", removed)
+	}
+}
+
+func TestRetentionLimits(t *testing.T) {
+	t.Parallel()
+	c := newTestChannel(t)
+	if err := c.Join("slow"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetRetention(Retention{MaxEvents: 2}); err != nil {
+		t.Fatal(err)
+	}
+	emitN(t, c, 5)
+
+	events, _ := c.Read(0)
+	if got := offsets(events); len(got) != 2 || got[0] != 4 {
+		t.Errorf("retained = %v, want the newest 2 [4 5]", got)
+	}
+}
+
+func TestPruneCandidatesMaxAge(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	files := []eventFile{
+		{Offset: 1, Path: "old", Nanos: now.Add(-2 * time.Hour).UnixNano()},
+		{Offset: 2, Path: "new", Nanos: now.Add(-time.Minute).UnixNano()},
+	}
+	got := pruneCandidates(files, nil, Retention{MaxAge: time.Hour}, now)
+	if len(got) != 1 || got[0].Path != "old" {
+		t.Errorf("pruneCandidates() = %v, want only the event older than an hour", got)
+	}
+}
@@ -74,6 +74,29 @@ const (
 	TypeSchedulerDispatch       = "scheduler_dispatch"        // Bead dispatched from scheduler
 	TypeSchedulerDispatchFailed = "scheduler_dispatch_failed" // Bead dispatch failed (requeued)
 	TypeSchedulerCloseRetry     = "scheduler_close_retry"     // Context close needed last-resort attempt
+
+	// Quota events (daemon quota patrol)
+	TypeQuotaLimited = "quota_limited" // Account detected as rate-limited
+	TypeQuotaReset   = "quota_reset"   // Account re-enabled after its reset time
+	TypeQuotaRotated = "quota_rotated" // Session rotated to another account
+
+	// Worker mode events (structured non-interactive polecats)
+	TypeWorkerDone   = "worker_done"   // Worker run finished successfully
+	TypeWorkerFailed = "worker_failed" // Worker run failed or exited without a result
+
+	// Death warrant events (policy decisions by gt warrant and Boot)
+	TypeWarrantFiled        = "warrant_filed"         // Warrant filed against a target
+	TypeWarrantCosigned     = "warrant_cosigned"      // Another agent filed the same warrant
+	TypeWarrantApproved     = "warrant_approved"      // Human approved a held warrant
+	TypeWarrantHeld         = "warrant_held"          // Boot held a warrant (awaiting co-signer or approval)
+	TypeWarrantAppealWindow = "warrant_appeal_window" // Target nudged; appeal window opened
+	TypeWarrantAppealed     = "warrant_appealed"      // Target proved liveness; warrant dismissed
+	TypeWarrantExecuted     = "warrant_executed"      // Warrant executed
+
+	// Rig maintenance window events (gt rig maintenance tick, run by the daemon)
+	TypeMaintenanceStarted = "maintenance_started" // Window opened; dispatch stopped, polecats told to drain
+	TypeMaintenanceParked  = "maintenance_parked"  // Drain ended; rig parked
+	TypeMaintenanceEnded   = "maintenance_ended"   // Window closed; rig unparked
 )
 
 // EventsFile is the name of the raw events log.
@@ -368,3 +391,28 @@ func SchedulerDispatchFailedPayload(beadID, rig, errMsg string) map[string]inter
 		"error": errMsg,
 	}
 }
+
+// WarrantPayload creates a payload for death warrant events.
+func WarrantPayload(warrantID, target, reason, detail string) map[string]interface{} {
+	p := map[string]interface{}{
+		"warrant": warrantID,
+		"target":  target,
+		"reason":  reason,
+	}
+	if detail != "" {
+		p["detail"] = detail
+	}
+	return p
+}
+
+// MaintenancePayload creates a payload for rig maintenance window events.
+func MaintenancePayload(rig, window, detail string) map[string]interface{} {
+	p := map[string]interface{}{
+		"rig":    rig,
+		"window": window,
+	}
+	if detail != "" {
+		p["detail"] = detail
+	}
+	return p
+}
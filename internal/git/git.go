@@ -9,7 +9,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // GitError contains raw output from a git command for agent observation.
@@ -470,6 +472,14 @@ func (g *Git) PushWithEnv(remote, branch string, force bool, env []string) error
 	return err
 }
 
+// PushForceWithLease force-pushes refspec, but only if the remote branch
+// still points at expected. Used to roll back a push without clobbering
+// anything pushed after it.
+func (g *Git) PushForceWithLease(remote, refspec, branch, expected string) error {
+	_, err := g.run("push", "--force-with-lease="+branch+":"+expected, remote, refspec)
+	return err
+}
+
 // Add stages files for commit.
 func (g *Git) Add(paths ...string) error {
 	args := append([]string{"add"}, paths...)
@@ -729,6 +739,36 @@ func (g *Git) RecentCommits(n int) (string, error) {
 	return g.run("log", "--oneline", fmt.Sprintf("-%d", n))
 }
 
+// CommitFiles is a commit subject together with the files the commit touched.
+type CommitFiles struct {
+	Subject string
+	Files   []string
+}
+
+// RecentCommitFiles returns the subjects and touched files of the last n
+// commits on HEAD, newest first. Merge commits are listed without files.
+func (g *Git) RecentCommitFiles(n int) ([]CommitFiles, error) {
+	out, err := g.run("log", fmt.Sprintf("-%d", n), "--name-only", "--format=%x00%s")
+	if err != nil {
+		return nil, err
+	}
+	var commits []CommitFiles
+	for _, block := range strings.Split(out, "\x00") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		if len(lines) == 0 || lines[0] == "" {
+			continue
+		}
+		c := CommitFiles{Subject: lines[0]}
+		for _, f := range lines[1:] {
+			if f = strings.TrimSpace(f); f != "" {
+				c.Files = append(c.Files, f)
+			}
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
 // DeleteRemoteBranch deletes a branch on the remote.
 func (g *Git) DeleteRemoteBranch(remote, branch string) error {
 	_, err := g.run("push", remote, "--delete", branch)
@@ -1320,6 +1360,39 @@ func (g *Git) UnpushedCommits() (int, error) {
 	return count, nil
 }
 
+// CommitsNotOnRemotes returns the number of commits reachable from HEAD that
+// no remote-tracking branch contains. Unlike UnpushedCommits, this does not
+// need an upstream, so it also covers detached HEADs and local-only branches.
+func (g *Git) CommitsNotOnRemotes() (int, error) {
+	out, err := g.run("rev-list", "--count", "HEAD", "--not", "--remotes")
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if _, err := fmt.Sscanf(out, "%d", &count); err != nil {
+		return 0, fmt.Errorf("parsing commit count: %w", err)
+	}
+	return count, nil
+}
+
+// LastCommit returns the short hash, subject and commit time of HEAD.
+func (g *Git) LastCommit() (hash, subject string, when time.Time, err error) {
+	out, err := g.run("log", "-1", "--format=%h%x00%ct%x00%s")
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	parts := strings.SplitN(out, "\x00", 3)
+	if len(parts) != 3 {
+		return "", "", time.Time{}, fmt.Errorf("unexpected git log output %q", out)
+	}
+	secs, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("parsing commit time: %w", err)
+	}
+	return parts[0], parts[2], time.Unix(secs, 0), nil
+}
+
 // UncommittedWorkStatus contains information about uncommitted work in a repo.
 type UncommittedWorkStatus struct {
 	HasUncommittedChanges bool
@@ -1604,8 +1677,9 @@ func InitSubmodules(repoPath string) error {
 // SubmoduleChanges detects submodule pointer changes between two refs.
 // Returns nil if no submodules changed or if the repo has no submodules.
 func (g *Git) SubmoduleChanges(base, head string) ([]SubmoduleChange, error) {
-	// git diff --raw shows mode 160000 for gitlink (submodule) entries
-	out, err := g.run("diff", "--raw", base, head)
+	// git diff --raw shows mode 160000 for gitlink (submodule) entries; full
+	// SHAs, since callers push them and print their first 8 characters.
+	out, err := g.run("diff", "--raw", "--no-abbrev", base, head)
 	if err != nil {
 		return nil, fmt.Errorf("diffing for submodule changes: %w", err)
 	}
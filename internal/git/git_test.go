@@ -1587,3 +1587,40 @@ func TestClearPushURL(t *testing.T) {
 		t.Errorf("ClearPushURL (idempotent) should not error, got: %v", err)
 	}
 }
+
+func TestRecentCommitFiles(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "router"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"router/router.go", "router/routes.go"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte("package router\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Add("."); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := g.Commit("router: add routes"); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	commits, err := g.RecentCommitFiles(10)
+	if err != nil {
+		t.Fatalf("RecentCommitFiles: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2: %+v", len(commits), commits)
+	}
+	if commits[0].Subject != "router: add routes" {
+		t.Errorf("Subject = %q", commits[0].Subject)
+	}
+	if strings.Join(commits[0].Files, ",") != "router/router.go,router/routes.go" {
+		t.Errorf("Files = %v", commits[0].Files)
+	}
+	if commits[1].Subject != "initial" || len(commits[1].Files) != 1 {
+		t.Errorf("commits[1] = %+v", commits[1])
+	}
+}
@@ -0,0 +1,431 @@
+package krc
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// ArchiveDir is the cold-storage directory under the town root. Events that
+// expire from .events.jsonl are moved here rather than deleted, one
+// gzip-compressed JSONL segment per UTC day (YYYY-MM-DD.jsonl.gz), alongside
+// an index of what each segment holds.
+const ArchiveDir = ".krc-archive"
+
+const (
+	archiveIndexFile = "index.json"
+	segmentSuffix    = ".jsonl.gz"
+	segmentDayLayout = "2006-01-02"
+)
+
+// SegmentInfo describes one archive segment.
+type SegmentInfo struct {
+	Events int            `json:"events"`
+	ByType map[string]int `json:"by_type"`
+	Bytes  int64          `json:"bytes"`
+}
+
+// ArchiveIndex lists the archive's segments keyed by day (YYYY-MM-DD, UTC).
+type ArchiveIndex struct {
+	Segments map[string]*SegmentInfo `json:"segments"`
+}
+
+// archivedEvent is an expired live event on its way into the archive.
+type archivedEvent struct {
+	ts   time.Time
+	typ  string
+	line string
+}
+
+func archiveIndexPath(townRoot string) string {
+	return filepath.Join(townRoot, ArchiveDir, archiveIndexFile)
+}
+
+func segmentPath(townRoot, day string) string {
+	return filepath.Join(townRoot, ArchiveDir, day+segmentSuffix)
+}
+
+// LoadArchiveIndex reads the archive index. A missing index is empty.
+func LoadArchiveIndex(townRoot string) (*ArchiveIndex, error) {
+	idx := &ArchiveIndex{Segments: make(map[string]*SegmentInfo)}
+	data, err := os.ReadFile(archiveIndexPath(townRoot))
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading archive index: %w", err)
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, fmt.Errorf("parsing archive index: %w", err)
+	}
+	if idx.Segments == nil {
+		idx.Segments = make(map[string]*SegmentInfo)
+	}
+	return idx, nil
+}
+
+func saveArchiveIndex(townRoot string, idx *ArchiveIndex) error {
+	if err := util.AtomicWriteJSON(archiveIndexPath(townRoot), idx); err != nil {
+		return fmt.Errorf("writing archive index: %w", err)
+	}
+	return nil
+}
+
+// appendToArchive appends events to their day segments. The index is saved
+// before any segment is written, so an interrupted append leaves it
+// over-reporting (costing Query a wasted scan) rather than hiding events.
+func appendToArchive(townRoot string, evs []archivedEvent) error {
+	if len(evs) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(townRoot, ArchiveDir), 0755); err != nil {
+		return fmt.Errorf("creating archive dir: %w", err)
+	}
+
+	idx, err := LoadArchiveIndex(townRoot)
+	if err != nil {
+		return err
+	}
+
+	byDay := make(map[string][]string)
+	for _, ev := range evs {
+		day := ev.ts.UTC().Format(segmentDayLayout)
+		byDay[day] = append(byDay[day], ev.line)
+		seg := idx.Segments[day]
+		if seg == nil {
+			seg = &SegmentInfo{ByType: make(map[string]int)}
+			idx.Segments[day] = seg
+		}
+		seg.Events++
+		seg.ByType[ev.typ]++
+	}
+	if err := saveArchiveIndex(townRoot, idx); err != nil {
+		return err
+	}
+
+	for day, lines := range byDay {
+		path := segmentPath(townRoot, day)
+		if err := appendSegment(path, lines); err != nil {
+			return fmt.Errorf("appending to segment %s: %w", day, err)
+		}
+		if info, err := os.Stat(path); err == nil {
+			idx.Segments[day].Bytes = info.Size()
+		}
+	}
+	return saveArchiveIndex(townRoot, idx)
+}
+
+// appendSegment writes lines to the end of a segment as a new gzip member.
+// Concatenated members form a valid gzip stream, so segments can grow
+// without being rewritten.
+func appendSegment(path string, lines []string) (err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	for _, line := range lines {
+		if _, err := io.WriteString(gz, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return gz.Close()
+}
+
+// readSegment calls fn for each line of a segment.
+func readSegment(path string, fn func(line string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			fn(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// expireArchive drops archived events whose archive retention has passed and
+// returns how many were dropped. Retention is applied per whole day: a type
+// leaves a segment once the end of that day is older than its retention, so a
+// segment is rewritten at most once per distinct retention it holds.
+func expireArchive(townRoot string, config *Config, now time.Time) (int, error) {
+	idx, err := LoadArchiveIndex(townRoot)
+	if err != nil || len(idx.Segments) == 0 {
+		return 0, err
+	}
+
+	dropped := 0
+	changed := false
+	for day, seg := range idx.Segments {
+		start, err := time.Parse(segmentDayLayout, day)
+		if err != nil {
+			continue
+		}
+		age := now.Sub(start.Add(24 * time.Hour))
+
+		expired := make(map[string]bool)
+		for typ := range seg.ByType {
+			if age > config.GetArchiveRetention(typ) {
+				expired[typ] = true
+			}
+		}
+		if len(expired) == 0 {
+			continue
+		}
+		changed = true
+
+		path := segmentPath(townRoot, day)
+		if len(expired) == len(seg.ByType) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return dropped, fmt.Errorf("removing segment %s: %w", day, err)
+			}
+			dropped += seg.Events
+			delete(idx.Segments, day)
+			continue
+		}
+
+		kept, err := rewriteSegment(path, func(typ string) bool { return !expired[typ] })
+		if err != nil {
+			return dropped, fmt.Errorf("rewriting segment %s: %w", day, err)
+		}
+		dropped += seg.Events - kept.Events
+		idx.Segments[day] = kept
+	}
+
+	if !changed {
+		return 0, nil
+	}
+	return dropped, saveArchiveIndex(townRoot, idx)
+}
+
+// rewriteSegment replaces a segment with the lines whose type passes keep,
+// and returns the recounted segment info.
+func rewriteSegment(path string, keep func(typ string) bool) (*SegmentInfo, error) {
+	info := &SegmentInfo{ByType: make(map[string]int)}
+	var lines []string
+	err := readSegment(path, func(line string) {
+		typ := eventType(line)
+		if keep(typ) {
+			lines = append(lines, line)
+			info.Events++
+			info.ByType[typ]++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tmpPath := path + ".tmp"
+	_ = os.Remove(tmpPath)
+	if err := appendSegment(tmpPath, lines); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+	if st, err := os.Stat(path); err == nil {
+		info.Bytes = st.Size()
+	}
+	return info, nil
+}
+
+// eventType returns the type field of a JSONL event line, or "".
+func eventType(line string) string {
+	var event struct {
+		Type string `json:"type"`
+	}
+	_ = json.Unmarshal([]byte(line), &event)
+	return event.Type
+}
+
+// ArchiveStats summarizes the cold-storage archive.
+type ArchiveStats struct {
+	Segments  int            `json:"segments"`
+	Events    int            `json:"events"`
+	Bytes     int64          `json:"bytes"`
+	OldestDay string         `json:"oldest_day,omitempty"`
+	NewestDay string         `json:"newest_day,omitempty"`
+	ByType    map[string]int `json:"by_type,omitempty"`
+}
+
+// GetArchiveStats summarizes the archive from its index.
+func GetArchiveStats(townRoot string) (*ArchiveStats, error) {
+	idx, err := LoadArchiveIndex(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	stats := &ArchiveStats{ByType: make(map[string]int)}
+	for day, seg := range idx.Segments {
+		stats.Segments++
+		stats.Events += seg.Events
+		stats.Bytes += seg.Bytes
+		for typ, n := range seg.ByType {
+			stats.ByType[typ] += n
+		}
+		if stats.OldestDay == "" || day < stats.OldestDay {
+			stats.OldestDay = day
+		}
+		if day > stats.NewestDay {
+			stats.NewestDay = day
+		}
+	}
+	return stats, nil
+}
+
+// QueryOptions selects events for Query.
+type QueryOptions struct {
+	// Types are event type patterns (glob-style, * only). Empty matches all.
+	Types []string
+	// Since and Until bound the event timestamp. Zero means unbounded.
+	Since time.Time
+	Until time.Time
+	// Limit keeps only the most recent N matches. Zero means no limit.
+	Limit int
+}
+
+func (o QueryOptions) matchType(typ string) bool {
+	if len(o.Types) == 0 {
+		return true
+	}
+	for _, pattern := range o.Types {
+		if pattern == typ || (strings.Contains(pattern, "*") && matchGlob(pattern, typ)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o QueryOptions) matchTime(ts time.Time) bool {
+	return (o.Since.IsZero() || !ts.Before(o.Since)) && (o.Until.IsZero() || !ts.After(o.Until))
+}
+
+// matchDay reports whether a segment day can hold events in the time range.
+func (o QueryOptions) matchDay(day time.Time) bool {
+	return (o.Since.IsZero() || day.Add(24*time.Hour).After(o.Since)) && (o.Until.IsZero() || !day.After(o.Until))
+}
+
+// Record is one event returned by Query.
+type Record struct {
+	Timestamp time.Time       `json:"ts"`
+	Type      string          `json:"type"`
+	Archived  bool            `json:"archived"`
+	Event     json.RawMessage `json:"event"`
+}
+
+// Query searches the live events file and the archive together and returns
+// matching events oldest first. Segments are skipped by day and, when the
+// index covers them, by type. An event present in both places (a prune
+// interrupted after archiving) is returned once.
+func Query(townRoot string, opts QueryOptions) ([]Record, error) {
+	var records []Record
+	seen := make(map[string]bool)
+	collect := func(line string, archived bool) {
+		var event struct {
+			Timestamp string `json:"ts"`
+			Type      string `json:"type"`
+		}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return
+		}
+		ts, err := time.Parse(time.RFC3339, event.Timestamp)
+		if err != nil || !opts.matchTime(ts) || !opts.matchType(event.Type) || seen[line] {
+			return
+		}
+		seen[line] = true
+		records = append(records, Record{Timestamp: ts, Type: event.Type, Archived: archived, Event: json.RawMessage(line)})
+	}
+
+	idx, err := LoadArchiveIndex(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(townRoot, ArchiveDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("listing archive: %w", err)
+	}
+	for _, entry := range entries {
+		day, ok := strings.CutSuffix(entry.Name(), segmentSuffix)
+		if !ok {
+			continue
+		}
+		start, err := time.Parse(segmentDayLayout, day)
+		if err != nil || !opts.matchDay(start) {
+			continue
+		}
+		if seg := idx.Segments[day]; seg != nil && !segmentHasType(seg, opts) {
+			continue
+		}
+		err = readSegment(filepath.Join(townRoot, ArchiveDir, entry.Name()), func(line string) { collect(line, true) })
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	live, err := os.Open(filepath.Join(townRoot, events.EventsFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if live != nil {
+		defer live.Close()
+		scanner := bufio.NewScanner(live)
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				collect(line, false)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("reading events: %w", err)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	if opts.Limit > 0 && len(records) > opts.Limit {
+		records = records[len(records)-opts.Limit:]
+	}
+	return records, nil
+}
+
+func segmentHasType(seg *SegmentInfo, opts QueryOptions) bool {
+	for typ := range seg.ByType {
+		if opts.matchType(typ) {
+			return true
+		}
+	}
+	return false
+}
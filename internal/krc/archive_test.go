@@ -0,0 +1,192 @@
+package krc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeEvents(t *testing.T, path string, evs []map[string]interface{}) {
+	t.Helper()
+	var b strings.Builder
+	for _, e := range evs {
+		data, err := json.Marshal(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func event(ts time.Time, typ, actor string) map[string]interface{} {
+	return map[string]interface{}{"ts": ts.Format(time.RFC3339), "type": typ, "actor": actor}
+}
+
+func TestGetArchiveRetention(t *testing.T) {
+	config := DefaultConfig()
+	if got := config.GetArchiveRetention("session_death"); got != 365*24*time.Hour {
+		t.Errorf("session_death retention = %v", got)
+	}
+	if got := config.GetArchiveRetention("patrol_started"); got != 0 {
+		t.Errorf("patrol_started should not be archived, got %v", got)
+	}
+	if got := config.GetArchiveRetention("something_else"); got != config.DefaultArchiveRetention {
+		t.Errorf("default retention = %v", got)
+	}
+}
+
+func TestPruner_ArchivesExpiredEvents(t *testing.T) {
+	tmpDir := t.TempDir()
+	now := time.Now().UTC()
+	writeEvents(t, filepath.Join(tmpDir, ".events.jsonl"), []map[string]interface{}{
+		event(now.Add(-40*24*time.Hour), "session_death", "gastown/polecats/nux"), // expired, archived
+		event(now.Add(-3*24*time.Hour), "patrol_started", "deacon"),               // expired, not archived
+		event(now.Add(-400*24*time.Hour), "mass_death", "daemon"),                 // past archive retention
+		event(now.Add(-time.Hour), "session_death", "gastown/polecats/toast"),     // fresh
+	})
+	writeEvents(t, filepath.Join(tmpDir, ".feed.jsonl"), []map[string]interface{}{
+		event(now.Add(-40*24*time.Hour), "session_death", "gastown/polecats/nux"),
+	})
+
+	result, err := NewPruner(tmpDir, DefaultConfig()).Prune()
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if result.EventsPruned != 4 || result.EventsArchived != 1 {
+		t.Errorf("pruned %d, archived %d; want 4, 1", result.EventsPruned, result.EventsArchived)
+	}
+
+	idx, err := LoadArchiveIndex(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := now.Add(-40 * 24 * time.Hour).Format(segmentDayLayout)
+	seg := idx.Segments[day]
+	if len(idx.Segments) != 1 || seg == nil || seg.ByType["session_death"] != 1 || seg.Bytes == 0 {
+		t.Fatalf("index = %+v", idx.Segments)
+	}
+
+	records, err := Query(tmpDir, QueryOptions{Types: []string{"session_death"}, Since: now.Add(-60 * 24 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || !records[0].Archived || records[1].Archived {
+		t.Fatalf("records = %+v", records)
+	}
+	if !strings.Contains(string(records[0].Event), "gastown/polecats/nux") {
+		t.Errorf("archived record = %s", records[0].Event)
+	}
+
+	// A second prune appends to the same segment without losing events.
+	writeEvents(t, filepath.Join(tmpDir, ".events.jsonl"), []map[string]interface{}{
+		event(now.Add(-40*24*time.Hour), "mass_death", "daemon"),
+	})
+	if _, err := NewPruner(tmpDir, DefaultConfig()).Prune(); err != nil {
+		t.Fatal(err)
+	}
+	records, err = Query(tmpDir, QueryOptions{Types: []string{"*_death"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Errorf("expected 2 archived deaths after second prune, got %d", len(records))
+	}
+}
+
+func TestExpireArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-100 * 24 * time.Hour)
+	recent := now.Add(-10 * 24 * time.Hour)
+	err := appendToArchive(tmpDir, []archivedEvent{
+		{ts: old, typ: "session_death", line: `{"ts":"` + old.Format(time.RFC3339) + `","type":"session_death"}`},
+		{ts: old, typ: "mail", line: `{"ts":"` + old.Format(time.RFC3339) + `","type":"mail"}`},
+		{ts: recent, typ: "mail", line: `{"ts":"` + recent.Format(time.RFC3339) + `","type":"mail"}`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultConfig()
+	config.ArchiveRetention["mail"] = 30 * 24 * time.Hour
+	dropped, err := expireArchive(tmpDir, config, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dropped != 1 {
+		t.Errorf("dropped = %d, want 1", dropped)
+	}
+
+	idx, _ := LoadArchiveIndex(tmpDir)
+	oldSeg := idx.Segments[old.Format(segmentDayLayout)]
+	if oldSeg == nil || oldSeg.Events != 1 || oldSeg.ByType["mail"] != 0 || oldSeg.ByType["session_death"] != 1 {
+		t.Errorf("old segment after expiry = %+v", oldSeg)
+	}
+
+	// Once nothing in a segment is retained, the segment is removed.
+	config.ArchiveRetention["session_death"] = 0
+	if _, err := expireArchive(tmpDir, config, now); err != nil {
+		t.Fatal(err)
+	}
+	idx, _ = LoadArchiveIndex(tmpDir)
+	if _, ok := idx.Segments[old.Format(segmentDayLayout)]; ok {
+		t.Error("fully expired segment should be dropped from the index")
+	}
+	if _, err := os.Stat(segmentPath(tmpDir, old.Format(segmentDayLayout))); !os.IsNotExist(err) {
+		t.Errorf("fully expired segment file should be removed: %v", err)
+	}
+	if idx.Segments[recent.Format(segmentDayLayout)] == nil {
+		t.Error("recent segment should be kept")
+	}
+}
+
+func TestQuery_DedupesAndLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+	now := time.Now().UTC()
+	var live []map[string]interface{}
+	var archived []archivedEvent
+	for i := 5; i > 0; i-- {
+		e := event(now.Add(-time.Duration(i)*time.Hour), "session_death", "actor")
+		live = append(live, e)
+		if i > 3 {
+			// Simulate a prune interrupted after archiving.
+			line, _ := json.Marshal(e)
+			archived = append(archived, archivedEvent{ts: now.Add(-time.Duration(i) * time.Hour), typ: "session_death", line: string(line)})
+		}
+	}
+	live = append(live, event(now, "mail", "actor"))
+	writeEvents(t, filepath.Join(tmpDir, ".events.jsonl"), live)
+	if err := appendToArchive(tmpDir, archived); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := Query(tmpDir, QueryOptions{Types: []string{"session_death"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("expected 5 deduped records, got %d", len(records))
+	}
+	for i := 1; i < len(records); i++ {
+		if records[i].Timestamp.Before(records[i-1].Timestamp) {
+			t.Fatal("records should be oldest first")
+		}
+	}
+
+	records, _ = Query(tmpDir, QueryOptions{Limit: 2})
+	if len(records) != 2 || records[1].Type != "mail" {
+		t.Errorf("limit should keep the most recent events, got %+v", records)
+	}
+
+	records, _ = Query(tmpDir, QueryOptions{Since: now.Add(-150 * time.Minute), Until: now.Add(-30 * time.Minute)})
+	if len(records) != 2 {
+		t.Errorf("time range matched %d records, want 2", len(records))
+	}
+}
@@ -10,6 +10,7 @@
 // KRC provides:
 // - Configurable TTLs per event type (default: 7 days)
 // - Auto-pruning on daemon startup and periodic intervals
+// - Cold storage: expired events archived in compressed day segments, searchable via Query
 // - Stats and visibility into ephemeral data lifecycle
 package krc
 
@@ -45,6 +46,17 @@ type Config struct {
 	// MinRetainCount keeps at least N events even if expired (for debugging).
 	// Default: 100
 	MinRetainCount int `json:"min_retain_count"`
+
+	// DefaultArchiveRetention is how long expired events of unspecified types
+	// are kept in the cold-storage archive, measured from the event timestamp.
+	// Zero drops them without archiving.
+	// Default: 90 days
+	DefaultArchiveRetention time.Duration `json:"default_archive_retention"`
+
+	// ArchiveRetention maps event type patterns to their archive retention.
+	// Patterns match the same way as TTLs. A retention no longer than the
+	// type's TTL means the type is never archived.
+	ArchiveRetention map[string]time.Duration `json:"archive_retention"`
 }
 
 // DefaultConfig returns the default KRC configuration.
@@ -81,6 +93,18 @@ func DefaultConfig() *Config {
 			// Merge events - important for audit
 			"merge_*":       30 * 24 * time.Hour, // 30 days
 		},
+		DefaultArchiveRetention: 90 * 24 * time.Hour, // 90 days
+		ArchiveRetention: map[string]time.Duration{
+			// Heartbeats are noise once expired - don't archive
+			"patrol_*":        0,
+			"polecat_checked": 0,
+			"polecat_nudged":  0,
+
+			// Crash investigations reach back months
+			"session_death": 365 * 24 * time.Hour, // 1 year
+			"mass_death":    365 * 24 * time.Hour, // 1 year
+			"merge_*":       180 * 24 * time.Hour, // 180 days
+		},
 	}
 }
 
@@ -125,14 +149,26 @@ func SaveConfig(townRoot string, config *Config) error {
 // GetTTL returns the TTL for a given event type based on config.
 // Matches patterns in order of specificity (exact match > glob > default).
 func (c *Config) GetTTL(eventType string) time.Duration {
+	return lookupDuration(c.TTLs, c.DefaultTTL, eventType)
+}
+
+// GetArchiveRetention returns how long an expired event of the given type is
+// kept in the archive, using the same matching rules as GetTTL.
+func (c *Config) GetArchiveRetention(eventType string) time.Duration {
+	return lookupDuration(c.ArchiveRetention, c.DefaultArchiveRetention, eventType)
+}
+
+// lookupDuration resolves eventType against a pattern map: exact match,
+// then the longest matching glob, then def.
+func lookupDuration(m map[string]time.Duration, def time.Duration, eventType string) time.Duration {
 	// Check for exact match first
-	if ttl, ok := c.TTLs[eventType]; ok {
-		return ttl
+	if d, ok := m[eventType]; ok {
+		return d
 	}
 
 	// Check for glob patterns (sorted by length for specificity)
 	var patterns []string
-	for pattern := range c.TTLs {
+	for pattern := range m {
 		if strings.Contains(pattern, "*") {
 			patterns = append(patterns, pattern)
 		}
@@ -143,11 +179,11 @@ func (c *Config) GetTTL(eventType string) time.Duration {
 
 	for _, pattern := range patterns {
 		if matchGlob(pattern, eventType) {
-			return c.TTLs[pattern]
+			return m[pattern]
 		}
 	}
 
-	return c.DefaultTTL
+	return def
 }
 
 // matchGlob performs simple glob matching (only * is supported).
@@ -170,6 +206,8 @@ type PruneResult struct {
 	BytesBefore     int64          `json:"bytes_before"`
 	BytesAfter      int64          `json:"bytes_after"`
 	PrunedByType    map[string]int `json:"pruned_by_type"`
+	EventsArchived  int            `json:"events_archived"` // pruned events moved to the archive
+	ArchiveExpired  int            `json:"archive_expired"` // archived events past their retention
 	Duration        time.Duration  `json:"duration"`
 }
 
@@ -189,6 +227,10 @@ func NewPruner(townRoot string, config *Config) *Pruner {
 
 // Prune removes expired events from the events and feed files.
 // It operates atomically by writing to temp files then renaming.
+// Expired events from the events file are moved to the archive when their
+// type's archive retention outlasts its TTL; the feed is a curated view of
+// the same events, so its expired lines are simply dropped. Archived events
+// past their retention are then removed from the archive.
 func (p *Pruner) Prune() (*PruneResult, error) {
 	start := time.Now()
 	result := &PruneResult{
@@ -196,7 +238,7 @@ func (p *Pruner) Prune() (*PruneResult, error) {
 	}
 
 	// Prune events file
-	eventsResult, err := p.pruneFile(filepath.Join(p.townRoot, events.EventsFile))
+	eventsResult, err := p.pruneFile(filepath.Join(p.townRoot, events.EventsFile), true)
 	if err != nil {
 		return nil, fmt.Errorf("pruning events: %w", err)
 	}
@@ -205,12 +247,13 @@ func (p *Pruner) Prune() (*PruneResult, error) {
 	result.EventsRetained += eventsResult.EventsRetained
 	result.BytesBefore += eventsResult.BytesBefore
 	result.BytesAfter += eventsResult.BytesAfter
+	result.EventsArchived += eventsResult.EventsArchived
 	for k, v := range eventsResult.PrunedByType {
 		result.PrunedByType[k] += v
 	}
 
 	// Prune feed file
-	feedResult, err := p.pruneFile(filepath.Join(p.townRoot, ".feed.jsonl"))
+	feedResult, err := p.pruneFile(filepath.Join(p.townRoot, ".feed.jsonl"), false)
 	if err != nil {
 		return nil, fmt.Errorf("pruning feed: %w", err)
 	}
@@ -223,12 +266,20 @@ func (p *Pruner) Prune() (*PruneResult, error) {
 		result.PrunedByType[k] += v
 	}
 
+	// Expire the archive tier
+	expired, err := expireArchive(p.townRoot, p.config, start)
+	if err != nil {
+		return nil, fmt.Errorf("expiring archive: %w", err)
+	}
+	result.ArchiveExpired = expired
+
 	result.Duration = time.Since(start)
 	return result, nil
 }
 
-// pruneFile prunes a single JSONL file.
-func (p *Pruner) pruneFile(filePath string) (result *PruneResult, err error) {
+// pruneFile prunes a single JSONL file, moving expired events to the archive
+// if archive is set.
+func (p *Pruner) pruneFile(filePath string, archive bool) (result *PruneResult, err error) {
 	result = &PruneResult{
 		PrunedByType: make(map[string]int),
 	}
@@ -285,6 +336,7 @@ func (p *Pruner) pruneFile(filePath string) (result *PruneResult, err error) {
 	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
 
 	var retained []string
+	var toArchive []archivedEvent
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -314,9 +366,12 @@ func (p *Pruner) pruneFile(filePath string) (result *PruneResult, err error) {
 
 		// Check if event has expired
 		ttl := p.config.GetTTL(event.Type)
-		if now.Sub(ts) > ttl {
+		if age := now.Sub(ts); age > ttl {
 			result.EventsPruned++
 			result.PrunedByType[event.Type]++
+			if archive && age <= p.config.GetArchiveRetention(event.Type) {
+				toArchive = append(toArchive, archivedEvent{ts: ts, typ: event.Type, line: line})
+			}
 		} else {
 			retained = append(retained, line)
 		}
@@ -360,6 +415,13 @@ func (p *Pruner) pruneFile(filePath string) (result *PruneResult, err error) {
 	}
 	srcClosed = true
 
+	// Archive before replacing, so a failure leaves events duplicated
+	// (Query dedupes) rather than lost
+	if err := appendToArchive(p.townRoot, toArchive); err != nil {
+		return nil, fmt.Errorf("archiving: %w", err)
+	}
+	result.EventsArchived = len(toArchive)
+
 	// Atomic replace
 	if err := os.Rename(tmpPath, filePath); err != nil {
 		return nil, fmt.Errorf("replacing file: %w", err)
@@ -377,6 +439,7 @@ type Stats struct {
 	OldestEvent  time.Time          `json:"oldest_event"`
 	NewestEvent  time.Time          `json:"newest_event"`
 	TTLBreakdown map[string]TTLInfo `json:"ttl_breakdown"`
+	Archive      *ArchiveStats      `json:"archive,omitempty"`
 }
 
 // FileStats contains statistics for a single file.
@@ -432,6 +495,15 @@ func GetStats(townRoot string, config *Config) (*Stats, error) {
 		stats.NewestEvent = newest2
 	}
 
+	// Archive tier
+	archive, err := GetArchiveStats(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	if archive.Segments > 0 {
+		stats.Archive = archive
+	}
+
 	return stats, nil
 }
 
@@ -18,7 +18,9 @@ import (
 	"github.com/gofrs/flock"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/doltclient"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -61,16 +63,7 @@ func doltBackoff(attempt int) time.Duration {
 // isDoltOptimisticLockError returns true if the error is an optimistic lock / serialization failure.
 // These indicate transient write conflicts from concurrent Dolt operations — worth retrying.
 func isDoltOptimisticLockError(err error) bool {
-	if err == nil {
-		return false
-	}
-	msg := err.Error()
-	return strings.Contains(msg, "optimistic lock") ||
-		strings.Contains(msg, "serialization failure") ||
-		strings.Contains(msg, "lock wait timeout") ||
-		strings.Contains(msg, "try restarting transaction") ||
-		strings.Contains(msg, "database is read only") ||
-		strings.Contains(msg, "cannot update manifest")
+	return doltclient.IsOptimisticLockError(err)
 }
 
 // isDoltConfigError returns true if the error indicates a configuration or initialization
@@ -703,6 +696,7 @@ func (m *Manager) addWithOptionsLocked(name string, opts AddOptions, polecatDir
 			if rg, repoErr := m.repoBase(); repoErr == nil {
 				_ = rg.WorktreeRemove(clonePath, true)
 			}
+			m.dropMemberWorktrees(polecatDir, m.rig.MemberRepos())
 		}
 
 		_ = os.RemoveAll(polecatDir)
@@ -752,6 +746,11 @@ func (m *Manager) addWithOptionsLocked(name string, opts AddOptions, polecatDir
 	}
 	worktreeCreated = true
 
+	if err := m.addMemberWorktrees(polecatDir, branchName, opts.BaseBranch); err != nil {
+		cleanupOnError()
+		return nil, err
+	}
+
 	if err := m.setupSharedBeads(clonePath); err != nil {
 		style.PrintWarning("could not set up shared beads: %v", err)
 	}
@@ -856,6 +855,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 			if rg, repoErr := m.repoBase(); repoErr == nil {
 				_ = rg.WorktreeRemove(clonePath, true)
 			}
+			m.dropMemberWorktrees(polecatDir, m.rig.MemberRepos())
 		}
 
 		// Remove polecat directory
@@ -916,6 +916,11 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 	}
 	worktreeCreated = true
 
+	if err := m.addMemberWorktrees(polecatDir, branchName, opts.BaseBranch); err != nil {
+		cleanupOnError()
+		return nil, err
+	}
+
 	// NOTE: No per-directory CLAUDE.md or AGENTS.md is created here.
 	// Only ~/gt/CLAUDE.md (town-root identity anchor) exists on disk.
 	// Full context is injected ephemerally via SessionStart hook (gt prime).
@@ -1121,6 +1126,10 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear, selfNuke bool)
 		}
 	}
 
+	// Preserve any uncommitted work before the worktree goes away
+	m.snapshotUncommitted(name, clonePath, "nuke")
+	m.removeMemberWorktrees(name, polecatDir, "nuke")
+
 	// Get repo base to remove the worktree properly
 	repoGit, err := m.repoBase()
 	if err != nil {
@@ -1179,6 +1188,19 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear, selfNuke bool)
 	return nil
 }
 
+// snapshotUncommitted saves a worktree's uncommitted changes under
+// refs/gastown/checkpoints/<name>/ before it is removed, so a force-nuke or
+// repair doesn't destroy work in progress. Failures only warn: the caller has
+// already decided to remove the worktree.
+func (m *Manager) snapshotUncommitted(name, clonePath, reason string) {
+	if _, err := os.Stat(clonePath); err != nil {
+		return
+	}
+	if _, err := checkpoint.TakeSnapshot(clonePath, name, reason); err != nil {
+		style.PrintWarning("could not snapshot uncommitted work for %s: %v", name, err)
+	}
+}
+
 // verifyRemovalComplete checks that polecat directories were actually removed.
 // If they still exist, it attempts more aggressive cleanup and returns an error
 // describing what couldn't be removed.
@@ -1392,6 +1414,10 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 		return nil, fmt.Errorf("creating fresh worktree from %s: %w", startPoint, err)
 	}
 
+	// Preserve any uncommitted work before the old worktree goes away
+	m.snapshotUncommitted(name, oldClonePath, "repair")
+	m.removeMemberWorktrees(name, polecatDir, "repair")
+
 	// New worktree created successfully — now safe to remove old worktree and reset bead.
 	// Remove old worktree BEFORE resetting bead to prevent name collision if a new
 	// spawn sees the clean bead while the old worktree still exists.
@@ -1423,6 +1449,11 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 		return nil, fmt.Errorf("moving repaired worktree to final path: %w", err)
 	}
 
+	// Recreate member repo worktrees on the new branch
+	if err := m.addMemberWorktrees(polecatDir, branchName, opts.BaseBranch); err != nil {
+		style.PrintWarning("could not recreate member repo worktrees: %v", err)
+	}
+
 	// NOTE: No per-directory CLAUDE.md or AGENTS.md is created here.
 	// Only ~/gt/CLAUDE.md (town-root identity anchor) exists on disk.
 	// Full context is injected ephemerally via SessionStart hook (gt prime).
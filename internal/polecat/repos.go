@@ -0,0 +1,65 @@
+package polecat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// addMemberWorktrees gives a polecat a worktree of each of the rig's member
+// repos, next to the primary worktree and on the same branch name, so a
+// change spanning several repos travels as one MR. baseBranch is the start
+// point used for the primary; members use it when they have a ref of that
+// name and fall back to their own default branch otherwise. On failure, the
+// member worktrees created so far are removed again.
+func (m *Manager) addMemberWorktrees(polecatDir, branchName, baseBranch string) error {
+	var created []rig.MemberRepo
+	for _, repo := range m.rig.MemberRepos() {
+		bareGit := git.NewGitWithDir(rig.MemberBarePath(m.rig.Path, repo.Name), "")
+		if err := bareGit.Fetch("origin"); err != nil {
+			style.PrintWarning("could not fetch origin for repo %s: %v", repo.Name, err)
+		}
+
+		startPoint := "origin/" + repo.Branch()
+		if baseBranch != "" {
+			if exists, _ := bareGit.RefExists(baseBranch); exists {
+				startPoint = baseBranch
+			}
+		}
+
+		path := filepath.Join(polecatDir, repo.Name)
+		if err := bareGit.WorktreeAddFromRef(path, branchName, startPoint); err != nil {
+			m.dropMemberWorktrees(polecatDir, created)
+			return fmt.Errorf("creating %s worktree from %s: %w", repo.Name, startPoint, err)
+		}
+		created = append(created, repo)
+	}
+	return nil
+}
+
+// removeMemberWorktrees snapshots uncommitted work in each member worktree
+// of a polecat and then removes them.
+func (m *Manager) removeMemberWorktrees(name, polecatDir, reason string) {
+	repos := m.rig.MemberRepos()
+	for _, repo := range repos {
+		m.snapshotUncommitted(name, filepath.Join(polecatDir, repo.Name), reason)
+	}
+	m.dropMemberWorktrees(polecatDir, repos)
+}
+
+// dropMemberWorktrees removes member worktrees without preserving their work.
+func (m *Manager) dropMemberWorktrees(polecatDir string, repos []rig.MemberRepo) {
+	for _, repo := range repos {
+		path := filepath.Join(polecatDir, repo.Name)
+		bareGit := git.NewGitWithDir(rig.MemberBarePath(m.rig.Path, repo.Name), "")
+		if _, err := os.Stat(path); err == nil {
+			_ = bareGit.WorktreeRemove(path, true)
+			_ = os.RemoveAll(path)
+		}
+		_ = bareGit.WorktreePrune()
+	}
+}
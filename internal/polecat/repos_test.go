@@ -0,0 +1,81 @@
+package polecat
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// initRepoWithCommit creates a repo on main with a single committed file.
+func initRepoWithCommit(t *testing.T, dir, file string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "init", "--initial-branch", "main")
+	runGit(t, dir, "config", "user.email", "test@test.com")
+	runGit(t, dir, "config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(dir, file), []byte("x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", file)
+	runGit(t, dir, "commit", "-m", "init")
+}
+
+func TestAddRemove_MemberRepoWorktrees(t *testing.T) {
+	installMockBd(t)
+	root := t.TempDir()
+
+	// Primary repo: mayor/rig acts as repo base, with itself as origin.
+	mayorRig := filepath.Join(root, "mayor", "rig")
+	initRepoWithCommit(t, mayorRig, "api.go")
+	runGit(t, mayorRig, "remote", "add", "origin", mayorRig)
+	runGit(t, mayorRig, "update-ref", "refs/remotes/origin/main", "HEAD")
+
+	// Member repo: shared bare clone under .repos/.
+	src := filepath.Join(t.TempDir(), "web")
+	initRepoWithCommit(t, src, "web.ts")
+	if err := git.NewGit(root).CloneBare(src, rig.MemberBarePath(root, "web")); err != nil {
+		t.Fatalf("CloneBare: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "config.json"),
+		[]byte(`{"type":"rig","name":"rig","repos":[{"name":"web","git_url":"`+src+`"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rig.Rig{Name: "rig", Path: root}
+	m := NewManager(r, git.NewGit(root), nil)
+	p, err := m.AddWithOptions("Toast", AddOptions{})
+	if err != nil {
+		t.Fatalf("AddWithOptions: %v", err)
+	}
+
+	memberPath := filepath.Join(m.polecatDir("Toast"), "web")
+	if _, err := os.Stat(filepath.Join(memberPath, "web.ts")); err != nil {
+		t.Fatalf("member worktree not created: %v", err)
+	}
+	branch, err := git.NewGit(memberPath).CurrentBranch()
+	if err != nil || branch != p.Branch {
+		t.Errorf("member branch = %q (%v), want %q", branch, err, p.Branch)
+	}
+
+	if err := m.RemoveWithOptions("Toast", true, true, false); err != nil {
+		t.Fatalf("RemoveWithOptions: %v", err)
+	}
+	if _, err := os.Stat(memberPath); !os.IsNotExist(err) {
+		t.Errorf("member worktree should be removed: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package polecat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/sandbox"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// sandboxCommand wraps a polecat startup command in the rig's configured
+// sandbox. With no sandbox configured (or driver "none") the command is
+// returned unchanged. A configured driver that cannot run on this host is an
+// error: the session must not silently start unconfined.
+func (m *SessionManager) sandboxCommand(command, polecat, workDir string, runtimeConfig *config.RuntimeConfig, runtimeConfigDir string) (string, error) {
+	sc, err := config.ResolveSandboxConfig(m.rig.Path, "polecat")
+	if err != nil {
+		return "", fmt.Errorf("loading sandbox config: %w", err)
+	}
+	if sc == nil || sc.Driver == "" || sc.Driver == sandbox.DriverNone {
+		return command, nil
+	}
+	driver, err := sandbox.Get(sc.Driver)
+	if err != nil {
+		return "", err
+	}
+	if err := driver.Check(); err != nil {
+		return "", fmt.Errorf("sandbox driver %s unavailable: %w", driver.Name(), err)
+	}
+
+	// Missing read-write paths are skipped rather than bound, and gt done
+	// writes its refinery events into this one.
+	townRoot := filepath.Dir(m.rig.Path)
+	if err := os.MkdirAll(filepath.Join(townRoot, "events"), 0755); err != nil {
+		return "", fmt.Errorf("creating events dir: %w", err)
+	}
+
+	spec := m.sandboxSpec(polecat, workDir, runtimeConfig, runtimeConfigDir)
+	spec.Network = sc.Network
+	spec.ReadWrite = append(spec.ReadWrite, sc.ReadWritePaths...)
+	spec.ReadOnly = append(spec.ReadOnly, sc.ReadOnlyPaths...)
+	if sc.Network == sandbox.NetworkAllowlist {
+		dolt := "127.0.0.1:" + strconv.Itoa(doltserver.DefaultConfig(townRoot).Port)
+		spec.Allow = append(append([]string{}, sc.AllowHosts...), dolt)
+	}
+	return driver.Wrap(command, spec)
+}
+
+// sandboxSpec lists what a polecat session needs: its own directory (all of
+// its worktrees), the shared git object stores its worktrees commit into,
+// the beads database, the town event channels and tmux socket that gt done
+// and nudges use, the agent's login state, and read-only access to the town
+// and rig configuration and the tools it runs. Other polecats, other rigs and
+// the rest of $HOME stay hidden, so the git identity is set explicitly.
+func (m *SessionManager) sandboxSpec(polecat, workDir string, runtimeConfig *config.RuntimeConfig, runtimeConfigDir string) sandbox.Spec {
+	townRoot := filepath.Dir(m.rig.Path)
+	polecatDir := m.polecatDir(polecat)
+	home, _ := os.UserHomeDir()
+
+	gitDir := filepath.Join(m.rig.Path, ".repo.git")
+	if _, err := os.Stat(gitDir); err != nil {
+		gitDir = filepath.Join(m.rig.Path, "mayor", "rig", ".git") // Legacy rigs
+	}
+
+	readWrite := []string{
+		polecatDir,
+		gitDir,
+		filepath.Join(m.rig.Path, ".repos"),
+		beads.ResolveBeadsDir(workDir),
+		filepath.Join(townRoot, ".beads"),
+		heartbeatsDir(townRoot),
+		constants.RigRuntimePath(m.rig.Path),
+		filepath.Join(townRoot, "logs"),
+		filepath.Join(townRoot, ".events.jsonl"),
+		filepath.Join(townRoot, "events"),
+		tmux.SocketDir(),
+	}
+	if runtimeConfigDir != "" {
+		readWrite = append(readWrite, runtimeConfigDir)
+	}
+	readWrite = append(readWrite, sandbox.AgentStatePaths(runtimeConfig.ResolvedAgent, home)...)
+
+	// Only the town's identity files from mayor/: it also holds secrets.
+	mayorDir := filepath.Join(townRoot, constants.DirMayor)
+	readOnly := []string{
+		filepath.Join(mayorDir, constants.FileTownJSON),
+		filepath.Join(mayorDir, constants.FileConfigJSON),
+		filepath.Join(mayorDir, constants.FileRigsJSON),
+		filepath.Join(townRoot, constants.DirSettings),
+		filepath.Join(m.rig.Path, constants.FileConfigJSON),
+		filepath.Join(m.rig.Path, constants.DirSettings),
+	}
+	// Hook settings are shared by all polecats in the parent polecats/ dir;
+	// bind just the hooks dir, not the sibling polecats.
+	if runtimeConfig.Hooks != nil && runtimeConfig.Hooks.Dir != "" {
+		readOnly = append(readOnly, filepath.Join(config.RoleSettingsDir("polecat", m.rig.Path), runtimeConfig.Hooks.Dir))
+	}
+	tools := []string{"gt", "bd", "git", runtimeConfig.Command, "node"}
+	if exe, err := os.Executable(); err == nil {
+		tools = append(tools, exe)
+	}
+	readOnly = append(readOnly, sandbox.ExecutablePaths(tools...)...)
+
+	// GIT_AUTHOR_NAME comes from the session env; ~/.gitconfig is hidden,
+	// so supply the rest the way gt commit does for agents.
+	domain := "gastown.local"
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil && settings.AgentEmailDomain != "" {
+		domain = settings.AgentEmailDomain
+	}
+	email := fmt.Sprintf("%s.polecats.%s@%s", m.rig.Name, polecat, domain)
+
+	return sandbox.Spec{
+		WorkDir:    workDir,
+		ReadWrite:  readWrite,
+		ReadOnly:   readOnly,
+		RuntimeDir: filepath.Join(polecatDir, ".sandbox"),
+		Env: map[string]string{
+			"GIT_AUTHOR_EMAIL":    email,
+			"GIT_COMMITTER_NAME":  polecat,
+			"GIT_COMMITTER_EMAIL": email,
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package polecat
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestSandboxCommand_NoConfig(t *testing.T) {
+	rigPath := filepath.Join(t.TempDir(), "gastown")
+	m := NewSessionManager(nil, &rig.Rig{Name: "gastown", Path: rigPath})
+
+	got, err := m.sandboxCommand("claude", "nux", rigPath, &config.RuntimeConfig{}, "")
+	if err != nil || got != "claude" {
+		t.Errorf("sandboxCommand = %q, %v; want command unchanged", got, err)
+	}
+}
+
+func TestSandboxCommand_UnknownDriverFails(t *testing.T) {
+	rigPath := filepath.Join(t.TempDir(), "gastown")
+	settings := config.NewRigSettings()
+	settings.Sandbox = map[string]*config.SandboxConfig{"polecat": {Driver: "jail"}}
+	if err := config.SaveRigSettings(config.RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatal(err)
+	}
+	m := NewSessionManager(nil, &rig.Rig{Name: "gastown", Path: rigPath})
+
+	if _, err := m.sandboxCommand("claude", "nux", rigPath, &config.RuntimeConfig{}, ""); err == nil {
+		t.Error("sandboxCommand should refuse to start with an unknown driver")
+	}
+}
+
+func TestSandboxSpec(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, ".repo.git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := NewSessionManager(nil, &rig.Rig{Name: "gastown", Path: rigPath})
+	workDir := filepath.Join(rigPath, "polecats", "nux", "gastown")
+	home, _ := os.UserHomeDir()
+
+	spec := m.sandboxSpec("nux", workDir, &config.RuntimeConfig{ResolvedAgent: "claude"}, "")
+
+	for _, want := range []string{
+		filepath.Join(rigPath, "polecats", "nux"),
+		filepath.Join(rigPath, ".repo.git"),
+		filepath.Join(home, ".claude"),
+	} {
+		if !slices.Contains(spec.ReadWrite, want) {
+			t.Errorf("ReadWrite missing %s: %v", want, spec.ReadWrite)
+		}
+	}
+	if !slices.Contains(spec.ReadOnly, filepath.Join(townRoot, "mayor", "town.json")) {
+		t.Errorf("ReadOnly missing mayor/town.json: %v", spec.ReadOnly)
+	}
+	for _, p := range append(spec.ReadWrite, spec.ReadOnly...) {
+		if p == filepath.Join(townRoot, "mayor") || strings.HasPrefix(p, filepath.Join(townRoot, "mayor", "secrets")) {
+			t.Errorf("sandbox must not expose %s", p)
+		}
+		if p == filepath.Join(rigPath, "polecats") || p == home {
+			t.Errorf("sandbox must not expose %s", p)
+		}
+	}
+}
+
+// TestSandboxSpec_CoversDoneWrites checks that everything gt done and nudges
+// write from inside the sandbox lands on a read-write bind, not in the
+// throwaway sandbox root.
+func TestSandboxSpec_CoversDoneWrites(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	m := NewSessionManager(nil, &rig.Rig{Name: "gastown", Path: rigPath})
+	workDir := filepath.Join(rigPath, "polecats", "nux", "gastown")
+
+	spec := m.sandboxSpec("nux", workDir, &config.RuntimeConfig{ResolvedAgent: "claude"}, "")
+
+	writes := []string{
+		filepath.Join(workDir, "README.md"),
+		filepath.Join(townRoot, "events", "refinery", "0000000001.event"), // MQ_SUBMIT for the refinery
+		filepath.Join(townRoot, events.EventsFile),
+		filepath.Join(townRoot, ".beads", "issues.jsonl"),
+		heartbeatFile(townRoot, "gt-gastown-nux"),
+		filepath.Join(tmux.SocketDir(), "default"), // nudges connect to the tmux server
+	}
+	for _, w := range writes {
+		covered := false
+		for _, p := range append([]string{spec.WorkDir}, spec.ReadWrite...) {
+			if w == p || strings.HasPrefix(w, p+string(filepath.Separator)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			t.Errorf("%s is not under a read-write bind: %v", w, spec.ReadWrite)
+		}
+	}
+
+	// ~/.gitconfig is hidden, so commits need the identity from the spec.
+	for _, k := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL"} {
+		if spec.Env[k] == "" {
+			t.Errorf("spec.Env missing %s: %v", k, spec.Env)
+		}
+	}
+	if got := spec.Env["GIT_AUTHOR_EMAIL"]; got != "gastown.polecats.nux@gastown.local" {
+		t.Errorf("GIT_AUTHOR_EMAIL = %q", got)
+	}
+}
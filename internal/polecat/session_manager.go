@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/cli"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
@@ -63,6 +64,15 @@ type SessionStartOptions struct {
 	// Command overrides the default "claude" command.
 	Command string
 
+	// CommandPrefix is prepended to the startup command (e.g., an API
+	// credential export for credential accounts).
+	CommandPrefix string
+
+	// Worker runs the agent in structured worker mode: non-interactively
+	// under 'gt worker run' with streaming JSON output, instead of an
+	// interactive TUI that receives its prompt via tmux.
+	Worker bool
+
 	// Account specifies the account handle to use (overrides default).
 	Account string
 
@@ -263,10 +273,29 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 		IncludePrimeInstruction: fallbackInfo.IncludePrimeInBeacon,
 		ExcludeWorkInstructions: fallbackInfo.SendStartupNudge,
 	}
+	if opts.Worker {
+		// Worker runs get everything in one prompt: there is no TUI to nudge.
+		beaconConfig.IncludePrimeInstruction = false
+		beaconConfig.ExcludeWorkInstructions = false
+	}
 	beacon := session.FormatStartupBeacon(beaconConfig)
 
 	command := opts.Command
-	if command == "" {
+	if command == "" && opts.Worker {
+		var err error
+		command, err = config.BuildWorkerStartupCommand(config.AgentEnvConfig{
+			Role:        "polecat",
+			Rig:         m.rig.Name,
+			AgentName:   polecat,
+			TownRoot:    townRoot,
+			Issue:       opts.Issue,
+			Topic:       "assigned",
+			SessionName: sessionID,
+		}, m.rig.Path, workerPrompt(beacon), opts.Agent)
+		if err != nil {
+			return fmt.Errorf("building worker command: %w", err)
+		}
+	} else if command == "" {
 		var err error
 		command, err = config.BuildStartupCommandFromConfig(config.AgentEnvConfig{
 			Role:        "polecat",
@@ -282,6 +311,7 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 			return fmt.Errorf("building startup command: %w", err)
 		}
 	}
+	command = opts.CommandPrefix + command
 	// Prepend runtime config dir env if needed
 	if runtimeConfig.Session != nil && runtimeConfig.Session.ConfigDirEnv != "" && opts.RuntimeConfigDir != "" {
 		command = config.PrependEnv(command, map[string]string{runtimeConfig.Session.ConfigDirEnv: opts.RuntimeConfigDir})
@@ -316,6 +346,12 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	}
 	command = config.PrependEnv(command, envVarsToInject)
 
+	// Confine the whole command, env exports included, to the rig's sandbox.
+	command, err = m.sandboxCommand(command, polecat, workDir, runtimeConfig, opts.RuntimeConfigDir)
+	if err != nil {
+		return err
+	}
+
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
 	if err := m.tmux.NewSessionWithCommand(sessionID, workDir, command); err != nil {
@@ -360,6 +396,11 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// This ensures respawned processes also inherit the setting.
 	debugSession("SetEnvironment BD_DOLT_AUTO_COMMIT", m.tmux.SetEnvironment(sessionID, "BD_DOLT_AUTO_COMMIT", "off"))
 
+	if opts.Worker {
+		// Lets patrols tell worker sessions (no TUI, no prompt) from interactive ones.
+		debugSession("SetEnvironment GT_WORKER", m.tmux.SetEnvironment(sessionID, "GT_WORKER", "1"))
+	}
+
 	// Set GT_PROCESS_NAMES for accurate liveness detection. Custom agents may
 	// shadow built-in preset names (e.g., custom "codex" running "opencode"),
 	// so we resolve process names from both agent name and actual command.
@@ -384,6 +425,47 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// Wait for Claude to start (non-fatal)
 	debugSession("WaitForCommand", m.tmux.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout))
 
+	// Worker runs already have their prompt and no TUI: skip dialog
+	// handling, readiness polling and nudges.
+	if !opts.Worker {
+		m.deliverStartupPrompt(sessionID, beacon, runtimeConfig, fallbackInfo)
+	}
+
+	// Verify session survived startup - if the command crashed, the session may have died.
+	// Without this check, Start() would return success even if the pane died during initialization.
+	running, err = m.tmux.HasSession(sessionID)
+	if err != nil {
+		return fmt.Errorf("verifying session: %w", err)
+	}
+	if !running {
+		return fmt.Errorf("session %s died during startup (agent command may have failed)", sessionID)
+	}
+
+	// Validate GT_AGENT is set. Without GT_AGENT, IsAgentAlive falls back to
+	// ["node", "claude"] process detection and witness patrol will auto-nuke
+	// polecats running non-Claude agents (e.g., opencode). Fail fast.
+	gtAgent, _ := m.tmux.GetEnvironment(sessionID, "GT_AGENT")
+	if gtAgent == "" {
+		_ = m.tmux.KillSessionWithProcesses(sessionID)
+		return fmt.Errorf("GT_AGENT not set in session %s (command=%q); "+
+			"witness patrol will misidentify this polecat as a zombie and auto-nuke it. "+
+			"Ensure RuntimeConfig.ResolvedAgent is set during agent config resolution",
+			sessionID, runtimeConfig.Command)
+	}
+
+	// Track PID for defense-in-depth orphan cleanup (non-fatal)
+	_ = session.TrackSessionPID(townRoot, sessionID, m.tmux)
+
+	// Touch initial heartbeat so liveness detection works from the start (gt-qjtq).
+	// Subsequent touches happen on every gt command via persistentPreRun.
+	TouchSessionHeartbeat(townRoot, sessionID)
+
+	return nil
+}
+
+// deliverStartupPrompt gets an interactive agent to its prompt and delivers
+// the beacon and work instructions its capabilities call for.
+func (m *SessionManager) deliverStartupPrompt(sessionID, beacon string, runtimeConfig *config.RuntimeConfig, fallbackInfo *runtime.StartupFallbackInfo) {
 	// Accept startup dialogs (workspace trust + bypass permissions) if they appear
 	debugSession("AcceptStartupDialogs", m.tmux.AcceptStartupDialogs(sessionID))
 
@@ -426,37 +508,13 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 
 	// Legacy fallback for other startup paths (non-fatal)
 	_ = runtime.RunStartupFallback(m.tmux, sessionID, "polecat", runtimeConfig)
+}
 
-	// Verify session survived startup - if the command crashed, the session may have died.
-	// Without this check, Start() would return success even if the pane died during initialization.
-	running, err = m.tmux.HasSession(sessionID)
-	if err != nil {
-		return fmt.Errorf("verifying session: %w", err)
-	}
-	if !running {
-		return fmt.Errorf("session %s died during startup (agent command may have failed)", sessionID)
-	}
-
-	// Validate GT_AGENT is set. Without GT_AGENT, IsAgentAlive falls back to
-	// ["node", "claude"] process detection and witness patrol will auto-nuke
-	// polecats running non-Claude agents (e.g., opencode). Fail fast.
-	gtAgent, _ := m.tmux.GetEnvironment(sessionID, "GT_AGENT")
-	if gtAgent == "" {
-		_ = m.tmux.KillSessionWithProcesses(sessionID)
-		return fmt.Errorf("GT_AGENT not set in session %s (command=%q); "+
-			"witness patrol will misidentify this polecat as a zombie and auto-nuke it. "+
-			"Ensure RuntimeConfig.ResolvedAgent is set during agent config resolution",
-			sessionID, runtimeConfig.Command)
-	}
-
-	// Track PID for defense-in-depth orphan cleanup (non-fatal)
-	_ = session.TrackSessionPID(townRoot, sessionID, m.tmux)
-
-	// Touch initial heartbeat so liveness detection works from the start (gt-qjtq).
-	// Subsequent touches happen on every gt command via persistentPreRun.
-	TouchSessionHeartbeat(townRoot, sessionID)
-
-	return nil
+// workerPrompt extends the startup beacon for a worker-mode run, which has
+// no one attached to answer questions.
+func workerPrompt(beacon string) string {
+	return beacon + "\n\nYou are running non-interactively; nobody can answer questions. " +
+		"Work your hook to completion and finish with `" + cli.Name() + " done`."
 }
 
 // isSessionStale checks if a tmux session's pane process has died.
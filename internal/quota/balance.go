@@ -0,0 +1,92 @@
+package quota
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// AccountUsage counts live Gas Town sessions per account handle.
+// Unlike ScanAll it only reads session environment (no pane capture), so it
+// is cheap enough to call on every spawn.
+func (s *Scanner) AccountUsage() (map[string]int, error) {
+	sessions, err := s.tmux.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	usage := make(map[string]int)
+	for _, sess := range sessions {
+		if !isGasTownSession(sess) {
+			continue
+		}
+		if handle := s.resolveAccountHandle(sess); handle != "" {
+			usage[handle]++
+		}
+	}
+	return usage, nil
+}
+
+// PickBalancedAccount chooses the account a new session should use so load
+// spreads across the pool instead of piling onto the default account.
+// Only available accounts are considered; among them the one with the fewest
+// live sessions wins, then the least recently used, then handle order.
+// Returns "" if no account is available.
+func PickBalancedAccount(state *config.QuotaState, accounts map[string]config.Account, usage map[string]int) string {
+	var candidates []string
+	for handle := range accounts {
+		st, tracked := state.Accounts[handle]
+		if tracked && st.Status != config.QuotaStatusAvailable && st.Status != "" {
+			continue
+		}
+		candidates = append(candidates, handle)
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if usage[a] != usage[b] {
+			return usage[a] < usage[b]
+		}
+		la, lb := state.Accounts[a].LastUsed, state.Accounts[b].LastUsed
+		if la != lb {
+			return la < lb
+		}
+		return a < b
+	})
+	return candidates[0]
+}
+
+// SelectBalancedAccount picks and reserves an account for a new session:
+// expired limits are cleared, the least-loaded available account is chosen,
+// and its LastUsed is stamped so concurrent spawns spread out. Holds the
+// quota lock for the whole read-modify-write.
+func (m *Manager) SelectBalancedAccount(acctCfg *config.AccountsConfig, usage map[string]int) (string, error) {
+	if acctCfg == nil || len(acctCfg.Accounts) == 0 {
+		return "", nil
+	}
+	var chosen string
+	err := m.WithLock(func() error {
+		state, err := m.Load()
+		if err != nil {
+			return err
+		}
+		m.EnsureAccountsTracked(state, acctCfg.Accounts)
+		m.ClearExpired(state)
+
+		chosen = PickBalancedAccount(state, acctCfg.Accounts, usage)
+		if chosen == "" {
+			return nil
+		}
+		st := state.Accounts[chosen]
+		st.LastUsed = time.Now().UTC().Format(time.RFC3339)
+		state.Accounts[chosen] = st
+		return m.SaveUnlocked(state)
+	})
+	if err != nil {
+		return "", err
+	}
+	return chosen, nil
+}
@@ -0,0 +1,89 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestPickBalancedAccount(t *testing.T) {
+	accounts := map[string]config.Account{"a": {}, "b": {}, "c": {}}
+	state := &config.QuotaState{Accounts: map[string]config.AccountQuotaState{
+		"a": {Status: config.QuotaStatusAvailable, LastUsed: "2026-01-01T00:00:00Z"},
+		"b": {Status: config.QuotaStatusAvailable, LastUsed: "2026-01-02T00:00:00Z"},
+		"c": {Status: config.QuotaStatusLimited},
+	}}
+
+	if got := PickBalancedAccount(state, accounts, map[string]int{"a": 3, "b": 1}); got != "b" {
+		t.Errorf("least loaded: got %q, want b", got)
+	}
+	if got := PickBalancedAccount(state, accounts, nil); got != "a" {
+		t.Errorf("tie on load should fall back to LRU: got %q, want a", got)
+	}
+
+	state.Accounts["a"] = config.AccountQuotaState{Status: config.QuotaStatusLimited}
+	state.Accounts["b"] = config.AccountQuotaState{Status: config.QuotaStatusLimited}
+	if got := PickBalancedAccount(state, accounts, nil); got != "" {
+		t.Errorf("all limited: got %q, want empty", got)
+	}
+}
+
+func TestSelectBalancedAccount_StampsLastUsed(t *testing.T) {
+	mgr := NewManager(setupTestTown(t))
+	acctCfg := &config.AccountsConfig{Accounts: map[string]config.Account{"a": {}, "b": {}}}
+
+	first, err := mgr.SelectBalancedAccount(acctCfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := mgr.SelectBalancedAccount(acctCfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == "" || second == "" || first == second {
+		t.Errorf("consecutive selections should spread across accounts: %q then %q", first, second)
+	}
+}
+
+func TestResolveResetTime_RollsOver(t *testing.T) {
+	la, _ := time.LoadLocation("America/Los_Angeles")
+	detected := time.Date(2026, 2, 18, 21, 0, 0, 0, la)
+
+	got, err := ResolveResetTime("7am (America/Los_Angeles)", detected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 2, 19, 7, 0, 0, 0, la); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got, err = ResolveResetTime("11pm (America/Los_Angeles)", detected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 2, 18, 23, 0, 0, 0, la); !got.Equal(want) {
+		t.Errorf("same-day reset: got %v, want %v", got, want)
+	}
+}
+
+func TestClearExpired_UsesLimitedAtForRollover(t *testing.T) {
+	la, _ := time.LoadLocation("America/Los_Angeles")
+	limitedAt := time.Date(2026, 2, 18, 21, 0, 0, 0, la)
+	state := &config.QuotaState{Accounts: map[string]config.AccountQuotaState{
+		"work": {
+			Status:    config.QuotaStatusLimited,
+			LimitedAt: limitedAt.UTC().Format(time.RFC3339),
+			ResetsAt:  "7am (America/Los_Angeles)",
+		},
+	}}
+
+	// 10pm same evening: the 7am reset is tomorrow, so still limited.
+	if n := clearExpiredAt(nil, state, limitedAt.Add(time.Hour)); n != 0 {
+		t.Errorf("cleared %d before reset, want 0", n)
+	}
+	// 8am next morning: reset has passed.
+	if n := clearExpiredAt(nil, state, time.Date(2026, 2, 19, 8, 0, 0, 0, la)); n != 1 {
+		t.Errorf("cleared %d after reset, want 1", n)
+	}
+}
@@ -153,8 +153,15 @@ func (r *Rotator) executeOne(state *config.QuotaState, mu *sync.Mutex, session,
 	// --- Validation phase: read-only, no side effects ---
 
 	// 1. Resolve old account from tmux session environment.
+	// GT_QUOTA_ACCOUNT (credential accounts, earlier rotations) wins over
+	// matching CLAUDE_CONFIG_DIR.
+	if override, err := r.tmuxClient.GetEnvironment(session, "GT_QUOTA_ACCOUNT"); err == nil {
+		if _, ok := r.accounts.Accounts[override]; ok {
+			result.OldAccount = override
+		}
+	}
 	oldConfigDir, err := r.tmuxClient.GetEnvironment(session, "CLAUDE_CONFIG_DIR")
-	if err == nil {
+	if err == nil && result.OldAccount == "" {
 		for handle, acct := range r.accounts.Accounts {
 			if acct.ConfigDir == oldConfigDir || util.ExpandHome(acct.ConfigDir) == oldConfigDir {
 				result.OldAccount = handle
@@ -163,13 +170,21 @@ func (r *Rotator) executeOne(state *config.QuotaState, mu *sync.Mutex, session,
 		}
 	}
 
-	// 2. Resolve new account config dir.
+	// 2. Resolve new account config dir (or credential env for API-key accounts).
 	newAcct, ok := r.accounts.Accounts[newAccount]
 	if !ok {
 		result.Error = fmt.Sprintf("account %q not found in config", newAccount)
 		return result
 	}
 	newConfigDir := util.ExpandHome(newAcct.ConfigDir)
+	var credentialEnv string
+	if newAcct.UsesCredential() {
+		credentialEnv = config.GetCredentialEnvVar(newAcct.ProviderName())
+		if credentialEnv == "" {
+			result.Error = fmt.Sprintf("provider %q has no credential env var for account %q", newAcct.ProviderName(), newAccount)
+			return result
+		}
+	}
 
 	// 3. Read CLAUDE_SESSION_ID from tmux session environment for resume support.
 	var sessionID string
@@ -186,7 +201,9 @@ func (r *Rotator) executeOne(state *config.QuotaState, mu *sync.Mutex, session,
 	}
 
 	// 5. If session ID found + linker available, attempt resume command.
-	if sessionID != "" && r.sessionLinker != nil {
+	// Resume relies on config-dir session files, so credential accounts
+	// always start fresh.
+	if sessionID != "" && r.sessionLinker != nil && credentialEnv == "" {
 		cleanup, linkErr := r.sessionLinker(r.townRoot, sessionID, newConfigDir)
 		if linkErr != nil {
 			r.log.Warn("could not symlink session for resume in %s: %v (falling back to fresh start)", session, linkErr)
@@ -202,8 +219,13 @@ func (r *Rotator) executeOne(state *config.QuotaState, mu *sync.Mutex, session,
 		}
 	}
 
-	// 6. Prepend CLAUDE_CONFIG_DIR export.
-	respawnCmd = fmt.Sprintf("export CLAUDE_CONFIG_DIR=%q && %s", newConfigDir, respawnCmd)
+	// 6. Prepend CLAUDE_CONFIG_DIR export, or the credential export for
+	// API-key accounts.
+	if credentialEnv != "" {
+		respawnCmd = config.CredentialExportPrefix(credentialEnv, newAccount) + respawnCmd
+	} else {
+		respawnCmd = fmt.Sprintf("export CLAUDE_CONFIG_DIR=%q && %s", newConfigDir, respawnCmd)
+	}
 
 	// 7. Validate target pane exists.
 	pane, err := r.tmuxExec.GetPaneID(session)
@@ -215,9 +237,15 @@ func (r *Rotator) executeOne(state *config.QuotaState, mu *sync.Mutex, session,
 	// --- Mutation phase: all validation passed ---
 
 	// 8. Set new CLAUDE_CONFIG_DIR in tmux session environment.
-	if err := r.tmuxExec.SetEnvironment(session, "CLAUDE_CONFIG_DIR", newConfigDir); err != nil {
-		result.Error = fmt.Sprintf("setting CLAUDE_CONFIG_DIR: %v", err)
-		return result
+	if credentialEnv == "" {
+		if err := r.tmuxExec.SetEnvironment(session, "CLAUDE_CONFIG_DIR", newConfigDir); err != nil {
+			result.Error = fmt.Sprintf("setting CLAUDE_CONFIG_DIR: %v", err)
+			return result
+		}
+	}
+	// Record the active account so the scanner attributes future limits to it.
+	if err := r.tmuxExec.SetEnvironment(session, "GT_QUOTA_ACCOUNT", newAccount); err != nil {
+		r.log.Warn("could not set GT_QUOTA_ACCOUNT for %s: %v", session, err)
 	}
 
 	// Set remain-on-exit to prevent pane destruction during restart.
@@ -933,3 +933,53 @@ func TestExecute_ResumeSymlinkFails_FallsBack(t *testing.T) {
 		t.Errorf("expected warning about symlink failure, got %v", log.warnings)
 	}
 }
+
+func TestExecute_CredentialAccount(t *testing.T) {
+	setupTestRegistry(t)
+	townRoot := setupTestTown(t)
+	mgr := NewManager(townRoot)
+
+	tmuxClient := &mockTmux{
+		envVars: map[string]map[string]string{
+			"gt-crew-bear": {"GT_QUOTA_ACCOUNT": "openai-a", "GT_AGENT": "codex"},
+		},
+	}
+	exec := newMockExecutor()
+	exec.paneIDs["gt-crew-bear"] = "%0"
+
+	accounts := &config.AccountsConfig{
+		Accounts: map[string]config.Account{
+			"openai-a": {Provider: "codex", Secret: "file:openai-a"},
+			"openai-b": {Provider: "codex", Secret: "file:openai-b"},
+		},
+	}
+
+	rotator := NewRotator(tmuxClient, exec, mgr, accounts,
+		func(s string) (string, error) { return "codex", nil },
+		&mockLogger{}, "", "", nil,
+	)
+	plan := &RotatePlan{Assignments: map[string]string{"gt-crew-bear": "openai-b"}}
+
+	results := rotator.Execute(plan, []string{"gt-crew-bear"})
+	if len(results) != 1 || !results[0].Rotated {
+		t.Fatalf("expected rotation, got %+v", results)
+	}
+	if results[0].OldAccount != "openai-a" {
+		t.Errorf("OldAccount = %q, want openai-a (from GT_QUOTA_ACCOUNT)", results[0].OldAccount)
+	}
+
+	cmd := exec.respawned["%0"]
+	if !strings.Contains(cmd, `OPENAI_API_KEY="$(gt account credential openai-b)"`) {
+		t.Errorf("respawn command should load the credential, got %q", cmd)
+	}
+	if strings.Contains(cmd, "CLAUDE_CONFIG_DIR") {
+		t.Errorf("credential rotation should not touch CLAUDE_CONFIG_DIR, got %q", cmd)
+	}
+	env := exec.envSets["gt-crew-bear"]
+	if env["GT_QUOTA_ACCOUNT"] != "openai-b" {
+		t.Errorf("GT_QUOTA_ACCOUNT = %q, want openai-b", env["GT_QUOTA_ACCOUNT"])
+	}
+	if _, ok := env["CLAUDE_CONFIG_DIR"]; ok {
+		t.Error("CLAUDE_CONFIG_DIR should not be set for credential accounts")
+	}
+}
@@ -0,0 +1,134 @@
+package quota
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// PatrolReport summarizes one automatic quota patrol cycle.
+type PatrolReport struct {
+	// Cleared are accounts re-enabled because their reset time passed.
+	Cleared []string
+
+	// NewlyLimited are accounts marked rate-limited during this cycle.
+	NewlyLimited []string
+
+	// LimitedSessions is the number of sessions detected as rate-limited.
+	LimitedSessions int
+
+	// SkippedBusy is the number of limited sessions left alone because
+	// they were not idle.
+	SkippedBusy int
+
+	// Results are the per-session rotation outcomes.
+	Results []RotateResult
+}
+
+// Rotated returns the number of sessions successfully rotated.
+func (r *PatrolReport) Rotated() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Rotated {
+			n++
+		}
+	}
+	return n
+}
+
+// Patrol runs the scan → record → plan → rotate loop without a human in it.
+// It is what the daemon's quota patrol drives each tick; the CLI commands
+// (gt quota scan/rotate) remain available for manual use.
+type Patrol struct {
+	Scanner  *Scanner
+	Manager  *Manager
+	Rotator  *Rotator
+	Accounts *config.AccountsConfig
+
+	// IsIdle, when set, restricts rotation to idle sessions so working
+	// agents are not interrupted mid-tool-call. Limited sessions that are
+	// busy are retried on the next cycle.
+	IsIdle func(session string) bool
+
+	// DryRun plans and records state but does not rotate sessions.
+	DryRun bool
+
+	// Now is the clock (defaults to time.Now).
+	Now func() time.Time
+}
+
+// Run executes one patrol cycle.
+func (p *Patrol) Run() (*PatrolReport, error) {
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	report := &PatrolReport{}
+
+	results, err := p.Scanner.ScanAll()
+	if err != nil {
+		return nil, fmt.Errorf("scanning sessions: %w", err)
+	}
+
+	// Re-enable accounts whose limits have reset, and record accounts that
+	// are limited right now. A session is only trusted as evidence if it
+	// still resolves to a registered account.
+	err = p.Manager.WithLock(func() error {
+		state, err := p.Manager.Load()
+		if err != nil {
+			return err
+		}
+		p.Manager.EnsureAccountsTracked(state, p.Accounts.Accounts)
+
+		t := now()
+		report.Cleared = clearExpiredHandles(state, t)
+		for _, r := range results {
+			if !r.RateLimited || r.AccountHandle == "" {
+				continue
+			}
+			if state.Accounts[r.AccountHandle].Status == config.QuotaStatusLimited {
+				continue
+			}
+			state.Accounts[r.AccountHandle] = LimitedState(state.Accounts[r.AccountHandle], r.ResetsAt, t)
+			report.NewlyLimited = append(report.NewlyLimited, r.AccountHandle)
+		}
+		return p.Manager.SaveUnlocked(state)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("updating quota state: %w", err)
+	}
+
+	// Rotation needs somewhere to rotate to.
+	if p.Rotator == nil || len(p.Accounts.Accounts) < 2 {
+		for _, r := range results {
+			if r.RateLimited {
+				report.LimitedSessions++
+			}
+		}
+		return report, nil
+	}
+
+	plan, err := PlanRotationFromResults(results, p.Manager, p.Accounts, "")
+	if err != nil {
+		return nil, fmt.Errorf("planning rotation: %w", err)
+	}
+	report.LimitedSessions = len(plan.LimitedSessions)
+
+	if p.IsIdle != nil {
+		for sess := range plan.Assignments {
+			if !p.IsIdle(sess) {
+				delete(plan.Assignments, sess)
+				report.SkippedBusy++
+			}
+		}
+	}
+	if len(plan.Assignments) == 0 || p.DryRun {
+		return report, nil
+	}
+
+	report.Results = p.Rotator.Execute(plan, slices.Sorted(maps.Keys(plan.Assignments)))
+	return report, nil
+}
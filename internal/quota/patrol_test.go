@@ -0,0 +1,120 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestPatrol_RecordsLimitAndRotates(t *testing.T) {
+	setupTestRegistry(t)
+	townRoot := setupTestTown(t)
+	mgr := NewManager(townRoot)
+
+	accounts := &config.AccountsConfig{
+		Accounts: map[string]config.Account{
+			"work":     {ConfigDir: "/home/user/.claude-accounts/work"},
+			"personal": {ConfigDir: "/home/user/.claude-accounts/personal"},
+		},
+	}
+	tmuxClient := &mockTmux{
+		sessions: []string{"gt-crew-bear", "gt-crew-wolf"},
+		paneContent: map[string]string{
+			"gt-crew-bear": "You've hit your limit · resets 7pm (America/Los_Angeles)",
+			"gt-crew-wolf": "working...",
+		},
+		envVars: map[string]map[string]string{
+			"gt-crew-bear": {"CLAUDE_CONFIG_DIR": "/home/user/.claude-accounts/work"},
+			"gt-crew-wolf": {"CLAUDE_CONFIG_DIR": "/home/user/.claude-accounts/personal"},
+		},
+	}
+	scanner, err := NewScanner(tmuxClient, nil, accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec := newMockExecutor()
+	exec.paneIDs["gt-crew-bear"] = "%0"
+	rotator := NewRotator(tmuxClient, exec, mgr, accounts,
+		func(string) (string, error) { return "claude", nil },
+		&mockLogger{}, townRoot, "", nil)
+
+	la, _ := time.LoadLocation("America/Los_Angeles")
+	now := time.Date(2026, 2, 18, 20, 0, 0, 0, la) // 8pm: "7pm" means tomorrow
+
+	p := &Patrol{Scanner: scanner, Manager: mgr, Rotator: rotator, Accounts: accounts,
+		Now: func() time.Time { return now }}
+	report, err := p.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.NewlyLimited) != 1 || report.NewlyLimited[0] != "work" {
+		t.Errorf("NewlyLimited = %v, want [work]", report.NewlyLimited)
+	}
+	if report.Rotated() != 1 {
+		t.Errorf("Rotated = %d, want 1 (results %+v)", report.Rotated(), report.Results)
+	}
+
+	state, err := mgr.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	work := state.Accounts["work"]
+	if work.Status != config.QuotaStatusLimited {
+		t.Fatalf("work status = %s, want limited", work.Status)
+	}
+	resetAt, err := time.Parse(time.RFC3339, work.ResetsAtTime)
+	if err != nil {
+		t.Fatalf("ResetsAtTime %q not RFC3339: %v", work.ResetsAtTime, err)
+	}
+	if want := time.Date(2026, 2, 19, 19, 0, 0, 0, la); !resetAt.Equal(want) {
+		t.Errorf("ResetsAtTime = %v, want %v", resetAt, want)
+	}
+}
+
+func TestPatrol_ClearsExpiredAndSkipsBusy(t *testing.T) {
+	setupTestRegistry(t)
+	townRoot := setupTestTown(t)
+	mgr := NewManager(townRoot)
+	if err := mgr.Save(&config.QuotaState{Accounts: map[string]config.AccountQuotaState{
+		"work":     {Status: config.QuotaStatusAvailable},
+		"personal": {Status: config.QuotaStatusLimited, ResetsAtTime: "2026-02-18T10:00:00Z"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	accounts := &config.AccountsConfig{
+		Accounts: map[string]config.Account{
+			"work":     {ConfigDir: "/w"},
+			"personal": {ConfigDir: "/p"},
+		},
+	}
+	tmuxClient := &mockTmux{
+		sessions:    []string{"gt-crew-bear"},
+		paneContent: map[string]string{"gt-crew-bear": "API Error: Rate limit reached"},
+		envVars:     map[string]map[string]string{"gt-crew-bear": {"CLAUDE_CONFIG_DIR": "/w"}},
+	}
+	scanner, _ := NewScanner(tmuxClient, nil, accounts)
+	exec := newMockExecutor()
+	exec.paneIDs["gt-crew-bear"] = "%0"
+	rotator := NewRotator(tmuxClient, exec, mgr, accounts,
+		func(string) (string, error) { return "claude", nil },
+		&mockLogger{}, townRoot, "", nil)
+
+	p := &Patrol{Scanner: scanner, Manager: mgr, Rotator: rotator, Accounts: accounts,
+		IsIdle: func(string) bool { return false },
+		Now:    func() time.Time { return time.Date(2026, 2, 18, 12, 0, 0, 0, time.UTC) }}
+	report, err := p.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.Cleared) != 1 || report.Cleared[0] != "personal" {
+		t.Errorf("Cleared = %v, want [personal]", report.Cleared)
+	}
+	if report.SkippedBusy != 1 || report.Rotated() != 0 {
+		t.Errorf("busy session should be skipped: skipped=%d rotated=%d", report.SkippedBusy, report.Rotated())
+	}
+	if len(exec.respawned) != 0 {
+		t.Errorf("no pane should be respawned, got %v", exec.respawned)
+	}
+}
@@ -32,6 +32,7 @@ type RotatePlan struct {
 	// ConfigDirSwaps maps config_dir -> new account handle.
 	// One keychain swap per config dir, not per session.
 	// All sessions sharing a config dir get the same assignment.
+	// Credential (API key) accounts never appear here.
 	ConfigDirSwaps map[string]string
 
 	// SkippedAccounts maps handle -> reason for accounts that were
@@ -49,7 +50,13 @@ func PlanRotation(scanner *Scanner, mgr *Manager, acctCfg *config.AccountsConfig
 	if err != nil {
 		return nil, fmt.Errorf("scanning sessions: %w", err)
 	}
+	return PlanRotationFromResults(results, mgr, acctCfg, fromAccount)
+}
 
+// PlanRotationFromResults plans account assignments from an existing scan.
+// Used by callers that already scanned (e.g., the daemon quota patrol, which
+// records detected limits before planning) to avoid capturing every pane twice.
+func PlanRotationFromResults(results []ScanResult, mgr *Manager, acctCfg *config.AccountsConfig, fromAccount string) (*RotatePlan, error) {
 	// Load quota state
 	state, err := mgr.Load()
 	if err != nil {
@@ -89,8 +96,10 @@ func PlanRotation(scanner *Scanner, mgr *Manager, acctCfg *config.AccountsConfig
 	// Validate tokens for available accounts — skip accounts with expired or
 	// revoked tokens. This prevents swapping a bad token into the target's
 	// keychain entry, which would leave the session non-functional.
+	// Credential (API key) accounts have no keychain entry to validate.
 	skipped := make(map[string]string)
 	var validAvailable []string
+	availableByProvider := make(map[string][]string) // provider -> handles, LRU order
 	for _, handle := range available {
 		if handle == fromAccount {
 			continue // rotating away from this account, not a candidate
@@ -99,81 +108,89 @@ func PlanRotation(scanner *Scanner, mgr *Manager, acctCfg *config.AccountsConfig
 		if !ok {
 			continue
 		}
-		configDir := util.ExpandHome(acct.ConfigDir)
-		if err := ValidateKeychainToken(configDir); err != nil {
-			skipped[handle] = err.Error()
-			continue
+		if !acct.UsesCredential() {
+			configDir := util.ExpandHome(acct.ConfigDir)
+			if err := ValidateKeychainToken(configDir); err != nil {
+				skipped[handle] = err.Error()
+				continue
+			}
 		}
 		validAvailable = append(validAvailable, handle)
+		availableByProvider[acct.ProviderName()] = append(availableByProvider[acct.ProviderName()], handle)
 	}
 	available = validAvailable
 
-	// Collect unique config dirs from limited sessions.
-	// Multiple sessions can share the same config dir (via the same account).
-	// We only need one keychain swap per config dir.
-	// Sessions with unknown accounts are included if they have a CLAUDE_CONFIG_DIR.
-	type configDirInfo struct {
-		configDir     string // resolved config dir path
-		accountHandle string // the limited account using this config dir (may be empty)
+	// Collect unique credential units from limited sessions. For config-dir
+	// accounts the unit is the config dir: multiple sessions can share it and
+	// only one keychain swap is needed. For credential accounts the unit is
+	// the account itself. Sessions with unknown accounts are included if they
+	// run Claude with a CLAUDE_CONFIG_DIR.
+	type swapUnit struct {
+		accountHandle string // the limited account using this unit (may be empty)
+		provider      string // accounts are only rotated within a provider
+		configDir     bool   // unit key is a config dir (keychain swap)
 	}
-	uniqueConfigDirs := make(map[string]*configDirInfo) // configDir -> info
-	for _, r := range limitedSessions {
-		var configDir string
+	unitKey := func(r ScanResult) (string, *swapUnit, bool) {
 		if r.AccountHandle != "" {
 			acct, ok := acctCfg.Accounts[r.AccountHandle]
 			if !ok {
-				continue
+				return "", nil, false
+			}
+			if acct.UsesCredential() {
+				return "account:" + r.AccountHandle, &swapUnit{r.AccountHandle, acct.ProviderName(), false}, true
 			}
-			configDir = util.ExpandHome(acct.ConfigDir)
-		} else if r.ConfigDir != "" {
+			return util.ExpandHome(acct.ConfigDir), &swapUnit{r.AccountHandle, acct.ProviderName(), true}, true
+		}
+		if r.ConfigDir != "" && (r.Provider == "" || r.Provider == string(config.AgentClaude)) {
 			// Unknown account but we have the config dir from tmux
-			configDir = r.ConfigDir
-		} else {
-			continue // No account and no config dir — can't rotate
+			return r.ConfigDir, &swapUnit{"", string(config.AgentClaude), true}, true
 		}
-		if _, exists := uniqueConfigDirs[configDir]; !exists {
-			uniqueConfigDirs[configDir] = &configDirInfo{
-				configDir:     configDir,
-				accountHandle: r.AccountHandle,
-			}
+		return "", nil, false // No account and no config dir — can't rotate
+	}
+	units := make(map[string]*swapUnit)
+	var unitOrder []string
+	for _, r := range limitedSessions {
+		key, unit, ok := unitKey(r)
+		if !ok {
+			continue
+		}
+		if _, exists := units[key]; !exists {
+			units[key] = unit
+			unitOrder = append(unitOrder, key)
 		}
 	}
 
-	// Assign available accounts to unique config dirs (round-robin, skip same-account).
+	// Assign available accounts of the same provider to units
+	// (round-robin, skip same-account).
 	configDirSwaps := make(map[string]string) // configDir -> new account handle
-	availIdx := 0
-	for configDir, info := range uniqueConfigDirs {
-		if availIdx >= len(available) {
-			break
+	unitAssignments := make(map[string]string)
+	availIdx := make(map[string]int) // provider -> next candidate index
+	for _, key := range unitOrder {
+		unit := units[key]
+		pool := availableByProvider[unit.provider]
+		idx := availIdx[unit.provider]
+		if idx < len(pool) && pool[idx] == unit.accountHandle {
+			idx++
 		}
-		candidate := available[availIdx]
-		if candidate == info.accountHandle {
-			availIdx++
-			if availIdx >= len(available) {
-				break
-			}
-			candidate = available[availIdx] // re-read after skip
+		if idx >= len(pool) {
+			availIdx[unit.provider] = idx
+			continue
+		}
+		unitAssignments[key] = pool[idx]
+		if unit.configDir {
+			configDirSwaps[key] = pool[idx]
 		}
-		configDirSwaps[configDir] = candidate
-		availIdx++
+		availIdx[unit.provider] = idx + 1
 	}
 
-	// Expand config dir assignments to session-level assignments.
+	// Expand unit assignments to session-level assignments.
 	assignments := make(map[string]string)
 	for _, r := range limitedSessions {
-		var configDir string
-		if r.AccountHandle != "" {
-			acct, ok := acctCfg.Accounts[r.AccountHandle]
-			if !ok {
-				continue
-			}
-			configDir = util.ExpandHome(acct.ConfigDir)
-		} else if r.ConfigDir != "" {
-			configDir = r.ConfigDir
-		} else {
+		key, _, ok := unitKey(r)
+		if !ok {
 			continue
 		}
-		if newAccount, ok := configDirSwaps[configDir]; ok {
+		if newAccount, ok := unitAssignments[key]; ok {
 			assignments[r.Session] = newAccount
 		}
 	}
@@ -661,3 +661,68 @@ func TestPlanRotation_PreemptiveFromAccount_NoSessions(t *testing.T) {
 		t.Errorf("expected 0 assignments, got %d", len(plan.Assignments))
 	}
 }
+
+func TestPlanRotation_RotatesWithinProvider(t *testing.T) {
+	setupTestRegistry(t)
+
+	tmux := &mockTmux{
+		sessions: []string{"gt-crew-bear", "gt-crew-wolf"},
+		paneContent: map[string]string{
+			"gt-crew-bear": "You've hit your limit · resets 7pm (America/Los_Angeles)",
+			"gt-crew-wolf": "stream error: You exceeded your current quota, please check your plan",
+		},
+		envVars: map[string]map[string]string{
+			"gt-crew-bear": {"CLAUDE_CONFIG_DIR": "/home/user/.claude-accounts/work"},
+			"gt-crew-wolf": {"GT_QUOTA_ACCOUNT": "openai-a", "GT_AGENT": "codex"},
+		},
+	}
+
+	accounts := &config.AccountsConfig{
+		Accounts: map[string]config.Account{
+			"work":     {ConfigDir: "/home/user/.claude-accounts/work"},
+			"personal": {ConfigDir: "/home/user/.claude-accounts/personal"},
+			"openai-a": {Provider: "codex", Secret: "file:openai-a"},
+			"openai-b": {Provider: "codex", Secret: "file:openai-b"},
+		},
+	}
+
+	scanner, err := NewScanner(tmux, nil, accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	townRoot := setupTestTown(t)
+	mgr := NewManager(townRoot)
+
+	// The codex account is least recently used overall; it must still not
+	// be handed to the Claude session.
+	state := &config.QuotaState{
+		Version: config.CurrentQuotaVersion,
+		Accounts: map[string]config.AccountQuotaState{
+			"work":     {Status: config.QuotaStatusLimited},
+			"personal": {Status: config.QuotaStatusAvailable, LastUsed: "2025-01-01T05:00:00Z"},
+			"openai-a": {Status: config.QuotaStatusLimited},
+			"openai-b": {Status: config.QuotaStatusAvailable, LastUsed: "2025-01-01T01:00:00Z"},
+		},
+	}
+	if err := mgr.Save(state); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := PlanRotation(scanner, mgr, accounts, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := plan.Assignments["gt-crew-bear"]; got != "personal" {
+		t.Errorf("claude session assigned %q, want personal", got)
+	}
+	if got := plan.Assignments["gt-crew-wolf"]; got != "openai-b" {
+		t.Errorf("codex session assigned %q, want openai-b", got)
+	}
+	if _, ok := plan.ConfigDirSwaps["account:openai-a"]; ok {
+		t.Error("credential accounts should not produce keychain config-dir swaps")
+	}
+	if len(plan.ConfigDirSwaps) != 1 {
+		t.Errorf("expected 1 config dir swap, got %v", plan.ConfigDirSwaps)
+	}
+}
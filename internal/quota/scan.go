@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
@@ -20,6 +21,7 @@ type ScanResult struct {
 	RateLimited   bool   `json:"rate_limited"`             // whether rate-limit was detected
 	MatchedLine   string `json:"matched_line,omitempty"`   // the line that matched
 	ResetsAt      string `json:"resets_at,omitempty"`      // parsed reset time if available
+	Provider      string `json:"provider,omitempty"`       // agent preset running in the session
 }
 
 // TmuxClient is the interface for tmux operations needed by the scanner.
@@ -38,10 +40,11 @@ type Scanner struct {
 }
 
 // NewScanner creates a scanner with the given tmux client and rate-limit patterns.
-// If patterns is nil, DefaultRateLimitPatterns are used.
+// If patterns is nil, DefaultRateLimitPatterns plus every agent preset's
+// RateLimitPatterns are used, so limits are detected for all providers.
 func NewScanner(tmux TmuxClient, patterns []string, accounts *config.AccountsConfig) (*Scanner, error) {
 	if len(patterns) == 0 {
-		patterns = constants.DefaultRateLimitPatterns
+		patterns = append(slices.Clone(constants.DefaultRateLimitPatterns), config.RateLimitPatternsForAgents()...)
 	}
 
 	compiled := make([]*regexp.Regexp, 0, len(patterns))
@@ -112,8 +115,9 @@ func (s *Scanner) scanSession(session string) ScanResult {
 		}
 	}
 
-	// Derive account from CLAUDE_CONFIG_DIR
+	// Derive account from GT_QUOTA_ACCOUNT / CLAUDE_CONFIG_DIR
 	result.AccountHandle = s.resolveAccountHandle(session)
+	result.Provider = s.resolveProvider(session, result.AccountHandle)
 
 	// Capture pane content
 	content, err := s.tmux.CapturePane(session, scanLines)
@@ -183,6 +187,22 @@ func (s *Scanner) resolveAccountHandle(session string) string {
 	return "" // CLAUDE_CONFIG_DIR doesn't match any registered account
 }
 
+// resolveProvider returns the agent preset a session runs: the provider of
+// its account when known, else the session's GT_AGENT, else claude.
+func (s *Scanner) resolveProvider(session, handle string) string {
+	if s.accounts != nil {
+		if acct, ok := s.accounts.Accounts[handle]; ok {
+			return acct.ProviderName()
+		}
+	}
+	if agent, err := s.tmux.GetEnvironment(session, "GT_AGENT"); err == nil {
+		if agent = strings.TrimSpace(agent); agent != "" {
+			return agent
+		}
+	}
+	return string(config.AgentClaude)
+}
+
 // isGasTownSession returns true if the session name belongs to Gas Town.
 // Uses the prefix registry to check for known rig prefixes (gt-, bd-, etc.)
 // and the hq- prefix for town-level services.
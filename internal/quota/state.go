@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -123,15 +124,29 @@ func (m *Manager) MarkLimited(handle string, resetsAt string) error {
 		return err
 	}
 
-	now := time.Now().UTC().Format(time.RFC3339)
-	state.Accounts[handle] = config.AccountQuotaState{
+	state.Accounts[handle] = LimitedState(state.Accounts[handle], resetsAt, time.Now())
+
+	return util.EnsureDirAndWriteJSON(m.statePath(), state)
+}
+
+// LimitedState returns the quota state for an account that was just detected
+// as rate-limited. The provider's human-readable reset time is kept for
+// display and also resolved to an absolute RFC3339 time, so later checks
+// don't have to guess which day "7pm" referred to.
+func LimitedState(prev config.AccountQuotaState, resetsAt string, now time.Time) config.AccountQuotaState {
+	st := config.AccountQuotaState{
 		Status:    config.QuotaStatusLimited,
-		LimitedAt: now,
+		LimitedAt: now.UTC().Format(time.RFC3339),
 		ResetsAt:  resetsAt,
-		LastUsed:  state.Accounts[handle].LastUsed,
+		LastUsed:  prev.LastUsed,
+		Provider:  prev.Provider,
 	}
-
-	return util.EnsureDirAndWriteJSON(m.statePath(), state)
+	if resetsAt != "" {
+		if t, err := ResolveResetTime(resetsAt, now); err == nil {
+			st.ResetsAtTime = t.UTC().Format(time.RFC3339)
+		}
+	}
+	return st
 }
 
 // MarkAvailable marks an account as available (not rate-limited).
@@ -147,15 +162,21 @@ func (m *Manager) MarkAvailable(handle string) error {
 		return err
 	}
 
-	existing := state.Accounts[handle]
-	state.Accounts[handle] = config.AccountQuotaState{
-		Status:   config.QuotaStatusAvailable,
-		LastUsed: existing.LastUsed,
-	}
+	state.Accounts[handle] = availableState(state.Accounts[handle])
 
 	return util.EnsureDirAndWriteJSON(m.statePath(), state)
 }
 
+// availableState returns prev with its rate limit cleared. When the account
+// was last used and which provider it belongs to carry over.
+func availableState(prev config.AccountQuotaState) config.AccountQuotaState {
+	return config.AccountQuotaState{
+		Status:   config.QuotaStatusAvailable,
+		LastUsed: prev.LastUsed,
+		Provider: prev.Provider,
+	}
+}
+
 // AvailableAccounts returns account handles that are not rate-limited,
 // sorted by least-recently-used first.
 func (m *Manager) AvailableAccounts(state *config.QuotaState) []string {
@@ -196,14 +217,16 @@ func sortByLastUsed(handles []string, state *config.QuotaState) {
 }
 
 // EnsureAccountsTracked adds any registered accounts that are missing from
-// quota state. Called during scan to keep state in sync with accounts.json.
+// quota state and records each account's provider, so quota is tracked per
+// provider. Called during scan to keep state in sync with accounts.json.
 func (m *Manager) EnsureAccountsTracked(state *config.QuotaState, accounts map[string]config.Account) {
-	for handle := range accounts {
-		if _, exists := state.Accounts[handle]; !exists {
-			state.Accounts[handle] = config.AccountQuotaState{
-				Status: config.QuotaStatusAvailable,
-			}
+	for handle, acct := range accounts {
+		st, exists := state.Accounts[handle]
+		if !exists {
+			st.Status = config.QuotaStatusAvailable
 		}
+		st.Provider = acct.Provider
+		state.Accounts[handle] = st
 	}
 }
 
@@ -216,29 +239,69 @@ func (m *Manager) ClearExpired(state *config.QuotaState) int {
 
 // clearExpiredAt is the testable core of ClearExpired, accepting a reference time.
 func clearExpiredAt(_ *Manager, state *config.QuotaState, now time.Time) int {
-	cleared := 0
+	return len(clearExpiredHandles(state, now))
+}
+
+// clearExpiredHandles marks limited accounts available once their reset time
+// has passed and returns the handles that were cleared.
+func clearExpiredHandles(state *config.QuotaState, now time.Time) []string {
+	var cleared []string
 	for handle, acctState := range state.Accounts {
 		if acctState.Status != config.QuotaStatusLimited {
 			continue
 		}
-		if acctState.ResetsAt == "" {
-			continue
-		}
-		resetTime, err := ParseResetTime(acctState.ResetsAt, now)
-		if err != nil {
-			continue // can't parse — leave as-is
+		resetTime, ok := accountResetTime(acctState, now)
+		if !ok {
+			continue // no reset time or can't parse — leave as-is
 		}
 		if now.After(resetTime) {
-			state.Accounts[handle] = config.AccountQuotaState{
-				Status:   config.QuotaStatusAvailable,
-				LastUsed: acctState.LastUsed,
-			}
-			cleared++
+			state.Accounts[handle] = availableState(acctState)
+			cleared = append(cleared, handle)
 		}
 	}
+	sort.Strings(cleared)
 	return cleared
 }
 
+// accountResetTime returns the absolute reset time for a limited account.
+// Prefers the resolved ResetsAtTime; otherwise resolves ResetsAt relative to
+// LimitedAt (so "7am" seen at 9pm means tomorrow), falling back to now for
+// legacy state written without LimitedAt.
+func accountResetTime(acctState config.AccountQuotaState, now time.Time) (time.Time, bool) {
+	if acctState.ResetsAtTime != "" {
+		if t, err := time.Parse(time.RFC3339, acctState.ResetsAtTime); err == nil {
+			return t, true
+		}
+	}
+	if acctState.ResetsAt == "" {
+		return time.Time{}, false
+	}
+	if limitedAt, err := time.Parse(time.RFC3339, acctState.LimitedAt); err == nil {
+		t, err := ResolveResetTime(acctState.ResetsAt, limitedAt)
+		return t, err == nil
+	}
+	t, err := ParseResetTime(acctState.ResetsAt, now)
+	return t, err == nil
+}
+
+// ResolveResetTime converts a provider reset string into the next absolute
+// time at or after detectedAt. Unlike ParseResetTime, which always answers
+// "today", a reset hour that has already passed on the detection day rolls
+// over to the following day. RFC3339 input is accepted as-is.
+func ResolveResetTime(resetsAt string, detectedAt time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(resetsAt)); err == nil {
+		return t, nil
+	}
+	t, err := ParseResetTime(resetsAt, detectedAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.Before(detectedAt) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
 // parseResetTimePattern matches formats like "7pm", "11am", "3:30pm", "7:00pm"
 var parseResetTimePattern = regexp.MustCompile(`(?i)^(\d{1,2})(?::(\d{2}))?\s*(am|pm)\b`)
 
@@ -141,6 +141,7 @@ func TestMarkAvailable(t *testing.T) {
 				Status:    config.QuotaStatusLimited,
 				LimitedAt: "2025-01-01T12:00:00Z",
 				LastUsed:  "2025-01-01T11:00:00Z",
+				Provider:  "gemini",
 			},
 		},
 	}
@@ -168,6 +169,9 @@ func TestMarkAvailable(t *testing.T) {
 	if acct.LastUsed != "2025-01-01T11:00:00Z" {
 		t.Errorf("expected LastUsed preserved, got %q", acct.LastUsed)
 	}
+	if acct.Provider != "gemini" {
+		t.Errorf("expected Provider preserved, got %q", acct.Provider)
+	}
 }
 
 func TestAvailableAccounts(t *testing.T) {
@@ -459,6 +463,7 @@ func TestClearExpired_ClearsPassedResetTime(t *testing.T) {
 				Status:   config.QuotaStatusLimited,
 				ResetsAt: "11am (America/Los_Angeles)", // 11am < 3pm = expired
 				LastUsed: "2026-02-18T10:00:00Z",
+				Provider: "codex",
 			},
 			"still_limited": {
 				Status:   config.QuotaStatusLimited,
@@ -483,6 +488,9 @@ func TestClearExpired_ClearsPassedResetTime(t *testing.T) {
 	if state.Accounts["expired"].LastUsed != "2026-02-18T10:00:00Z" {
 		t.Errorf("expected LastUsed preserved, got %q", state.Accounts["expired"].LastUsed)
 	}
+	if state.Accounts["expired"].Provider != "codex" {
+		t.Errorf("expected Provider preserved, got %q", state.Accounts["expired"].Provider)
+	}
 	if state.Accounts["still_limited"].Status != config.QuotaStatusLimited {
 		t.Errorf("expected still_limited to remain limited, got %s", state.Accounts["still_limited"].Status)
 	}
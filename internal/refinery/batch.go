@@ -69,6 +69,10 @@ func (e *Engineer) AssembleBatch(readyMRs []*MRInfo, config *BatchConfig) []*MRI
 		if len(batch) >= maxSize {
 			break
 		}
+		// Multi-repo MRs land on their own (see doMergeSet)
+		if len(mr.RepoBranches) > 0 {
+			continue
+		}
 		// Skip MRs blocked by something not already in this batch
 		if mr.BlockedBy != "" {
 			inBatch := false
@@ -362,6 +362,7 @@ func (m *Manager) issueToMR(issue *beads.Issue) *MergeRequest {
 		TargetBranch: target,
 		Status:       MROpen,
 		CreatedAt:    parseTime(issue.CreatedAt),
+		RepoBranches: fields.RepoBranches,
 	}
 }
 
@@ -0,0 +1,291 @@
+package refinery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// landing is one repository's share of a multi-repo MR.
+type landing struct {
+	repo   string // Repo name used in messages (rig name for the primary)
+	git    *git.Git
+	branch string
+	target string
+	base   string // Target SHA before the squash merge
+	merged string // Target SHA after the squash merge; empty if nothing to merge
+
+	submodules []git.SubmoduleChange // Submodule pointers the branch changes
+}
+
+// GetMRInfo loads a single merge request bead as MRInfo.
+func (e *Engineer) GetMRInfo(id string) (*MRInfo, error) {
+	issue, err := e.beads.Show(id)
+	if err != nil {
+		return nil, err
+	}
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		return nil, fmt.Errorf("%s has no merge request fields", id)
+	}
+	return issueToMRInfo(issue, fields), nil
+}
+
+// doMergeSet lands a multi-repo MR atomically: the primary branch and every
+// member repo branch are squash-merged locally, the quality gates run once
+// against the whole set, submodule commits the branches point to are pushed,
+// and then each target is pushed. If any push fails,
+// targets already pushed are force-pushed back to their previous SHA (with a
+// lease, so later pushes by others are never clobbered) and every local
+// target is reset, so either all repos move or none do.
+func (e *Engineer) doMergeSet(ctx context.Context, mr *MRInfo) ProcessResult {
+	landings, err := e.planLandings(mr)
+	if err != nil {
+		return ProcessResult{Success: false, Error: err.Error()}
+	}
+
+	// Step 1: Merge every repo locally; nothing is pushed yet.
+	for _, l := range landings {
+		if result := e.mergeLanding(l, mr.SourceIssue); !result.Success {
+			e.resetLandings(landings)
+			return result
+		}
+	}
+	var anyMerged bool
+	for _, l := range landings {
+		anyMerged = anyMerged || l.merged != ""
+	}
+	if !anyMerged {
+		return ProcessResult{Success: false, Error: "no commits to merge in any repo"}
+	}
+
+	// Step 2: Gate the set together. Member repos are checked out next to
+	// the primary under refinery/repos/<name>, so gates can build across them.
+	if result := e.runQualityChecks(ctx); !result.Success {
+		e.resetLandings(landings)
+		return result
+	}
+
+	// Step 3: Serialize pushes to the default branch, as doMerge does.
+	var pushHolder string
+	if landings[0].target == e.rig.DefaultBranch() {
+		var slotErr error
+		pushHolder, slotErr = e.acquireMainPushSlot(ctx)
+		if slotErr != nil {
+			e.resetLandings(landings)
+			return ProcessResult{
+				Success:     false,
+				SlotTimeout: errors.Is(slotErr, errMergeSlotTimeout),
+				Error:       fmt.Sprintf("failed to acquire merge slot before push: %v", slotErr),
+			}
+		}
+		defer func() {
+			if pushHolder != "" {
+				if releaseErr := e.mergeSlotRelease(pushHolder); releaseErr != nil {
+					_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to release merge slot for push (%s): %v\n", pushHolder, releaseErr)
+				}
+			}
+		}()
+	}
+
+	// Step 3.5: Push submodule commits in every repo, as doMerge does, before
+	// any target moves; otherwise a target gets dangling submodule pointers.
+	for _, l := range landings {
+		if result := e.pushLandingSubmodules(l); !result.Success {
+			e.resetLandings(landings)
+			return result
+		}
+	}
+
+	// Step 4: Push each repo; roll back the pushed ones on the first failure.
+	for i, l := range landings {
+		if l.merged == "" {
+			continue
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Pushing %s to origin/%s...\n", l.repo, l.target)
+		if err := l.git.Push("origin", l.target, false); err != nil {
+			msg := fmt.Sprintf("failed to push %s to origin/%s: %v", l.repo, l.target, err)
+			if stuck := e.rollbackLandings(landings[:i]); len(stuck) > 0 {
+				msg += fmt.Sprintf("; ROLLBACK FAILED, fix by hand: %s", strings.Join(stuck, "; "))
+			} else if i > 0 {
+				msg += "; rolled back the repos already pushed"
+			}
+			e.resetLandings(landings)
+			return ProcessResult{Success: false, Error: msg}
+		}
+	}
+
+	mergeCommit := ""
+	for _, l := range landings {
+		if l.merged != "" {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Successfully merged %s: %s\n", l.repo, l.merged[:8])
+			if mergeCommit == "" {
+				mergeCommit = l.merged
+			}
+		}
+	}
+	return ProcessResult{Success: true, MergeCommit: mergeCommit}
+}
+
+// planLandings resolves the repos of a multi-repo MR. The primary comes
+// first. A member targets its own default branch when the MR targets the
+// rig's default branch, and a branch of the same name otherwise (e.g. an
+// integration branch).
+func (e *Engineer) planLandings(mr *MRInfo) ([]*landing, error) {
+	target := mr.Target
+	if target == "" {
+		target = e.rig.DefaultBranch()
+	}
+	landings := []*landing{{repo: e.rig.Name, git: e.git, branch: mr.Branch, target: target}}
+
+	members := make(map[string]rig.MemberRepo)
+	for _, m := range e.rig.MemberRepos() {
+		members[m.Name] = m
+	}
+	for _, rb := range mr.RepoBranches {
+		member, ok := members[rb.Repo]
+		if !ok {
+			return nil, fmt.Errorf("MR references repo %q, which is not a member of rig %s", rb.Repo, e.rig.Name)
+		}
+		memberTarget := target
+		if target == e.rig.DefaultBranch() {
+			memberTarget = member.Branch()
+		}
+		landings = append(landings, &landing{
+			repo:   rb.Repo,
+			git:    git.NewGit(rig.MemberRefineryPath(e.rig.Path, rb.Repo)),
+			branch: rb.Branch,
+			target: memberTarget,
+		})
+	}
+	return landings, nil
+}
+
+// mergeLanding squash-merges one repo's branch into its checked-out target.
+// A branch with no commits ahead of the target is left alone.
+func (e *Engineer) mergeLanding(l *landing, sourceIssue string) ProcessResult {
+	_, _ = fmt.Fprintf(e.output, "[Engineer] [%s] Checking local branch %s...\n", l.repo, l.branch)
+	exists, err := l.git.BranchExists(l.branch)
+	if err != nil {
+		return ProcessResult{Success: false, Error: fmt.Sprintf("[%s] failed to check branch %s: %v", l.repo, l.branch, err)}
+	}
+	if !exists {
+		return ProcessResult{Success: false, Error: fmt.Sprintf("[%s] branch %s not found locally", l.repo, l.branch)}
+	}
+
+	if err := l.git.Checkout(l.target); err != nil {
+		return ProcessResult{Success: false, Error: fmt.Sprintf("[%s] failed to checkout target %s: %v", l.repo, l.target, err)}
+	}
+	if err := l.git.Pull("origin", l.target); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] [%s] Warning: pull from origin/%s: %v (continuing)\n", l.repo, l.target, err)
+	}
+	base, err := l.git.Rev("HEAD")
+	if err != nil {
+		return ProcessResult{Success: false, Error: fmt.Sprintf("[%s] failed to get target SHA: %v", l.repo, err)}
+	}
+	l.base = base
+
+	if ahead, err := l.git.CommitsAhead(l.target, l.branch); err == nil && ahead == 0 {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] [%s] No commits ahead of %s, skipping\n", l.repo, l.target)
+		return ProcessResult{Success: true}
+	}
+
+	conflicts, err := l.git.CheckConflicts(l.branch, l.target)
+	if err != nil {
+		return ProcessResult{Success: false, Conflict: true, Error: fmt.Sprintf("[%s] conflict check failed: %v", l.repo, err)}
+	}
+	if len(conflicts) > 0 {
+		return ProcessResult{Success: false, Conflict: true, Error: fmt.Sprintf("[%s] merge conflicts in: %v", l.repo, conflicts)}
+	}
+
+	// Submodules are initialized while the target still has its old
+	// pointers; the new commits are pushed with the set (Step 3.5).
+	subChanges, err := l.git.SubmoduleChanges(l.target, l.branch)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] [%s] Warning: could not check submodule changes: %v\n", l.repo, err)
+	}
+	if len(subChanges) > 0 {
+		if err := git.InitSubmodules(l.git.WorkDir()); err != nil {
+			return ProcessResult{Success: false, Error: fmt.Sprintf("[%s] failed to init submodules in refinery worktree: %v", l.repo, err)}
+		}
+		l.submodules = subChanges
+	}
+
+	msg, err := l.git.GetBranchCommitMessage(l.branch)
+	if err != nil {
+		msg = fmt.Sprintf("Squash merge %s into %s", l.branch, l.target)
+		if sourceIssue != "" {
+			msg = fmt.Sprintf("Squash merge %s into %s (%s)", l.branch, l.target, sourceIssue)
+		}
+	}
+	if err := l.git.MergeSquash(l.branch, msg); err != nil {
+		conflicts, conflictErr := l.git.GetConflictingFiles()
+		_ = l.git.AbortMerge()
+		if conflictErr == nil && len(conflicts) > 0 {
+			return ProcessResult{Success: false, Conflict: true, Error: fmt.Sprintf("[%s] merge conflict during actual merge", l.repo)}
+		}
+		return ProcessResult{Success: false, Error: fmt.Sprintf("[%s] merge failed: %v", l.repo, err)}
+	}
+
+	merged, err := l.git.Rev("HEAD")
+	if err != nil {
+		return ProcessResult{Success: false, Error: fmt.Sprintf("[%s] failed to get merge commit SHA: %v", l.repo, err)}
+	}
+	l.merged = merged
+	return ProcessResult{Success: true}
+}
+
+// pushLandingSubmodules pushes the submodule commits a landing's branch
+// points to, so they exist upstream before the target is pushed.
+func (e *Engineer) pushLandingSubmodules(l *landing) ProcessResult {
+	if l.merged == "" || len(l.submodules) == 0 {
+		return ProcessResult{Success: true}
+	}
+	for _, sc := range l.submodules {
+		if sc.NewSHA == "" {
+			continue // Submodule removed, nothing to push
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] [%s] Pushing submodule %s (commit %s)...\n", l.repo, sc.Path, sc.NewSHA[:8])
+		if err := l.git.PushSubmoduleCommit(sc.Path, sc.NewSHA, "origin"); err != nil {
+			return ProcessResult{Success: false, Error: fmt.Sprintf("[%s] failed to push submodule %s: %v", l.repo, sc.Path, err)}
+		}
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] [%s] Pushed %d submodule(s)\n", l.repo, len(l.submodules))
+	return ProcessResult{Success: true}
+}
+
+// rollbackLandings moves already-pushed targets back to their base SHA. The
+// lease makes the force-push fail rather than discard a commit someone else
+// pushed in the meantime. Returns a description of each repo it could not
+// roll back.
+func (e *Engineer) rollbackLandings(pushed []*landing) []string {
+	var stuck []string
+	for _, l := range pushed {
+		if l.merged == "" {
+			continue
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Rolling back %s origin/%s to %s...\n", l.repo, l.target, l.base[:8])
+		ref := "refs/heads/" + l.target
+		if err := l.git.PushForceWithLease("origin", l.base+":"+ref, ref, l.merged); err != nil {
+			stuck = append(stuck, fmt.Sprintf("%s origin/%s should be %s: %v", l.repo, l.target, l.base, err))
+		}
+	}
+	return stuck
+}
+
+// resetLandings discards local squash merges in every repo of the set.
+func (e *Engineer) resetLandings(landings []*landing) {
+	for _, l := range landings {
+		if l.base == "" {
+			continue
+		}
+		if err := l.git.ResetHard(l.base); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to reset %s %s: %v\n", l.repo, l.target, err)
+		}
+	}
+}
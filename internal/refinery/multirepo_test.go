@@ -0,0 +1,159 @@
+package refinery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// testMultiRepoEngineer sets up a rig with a primary repo and a "web" member
+// repo, each with a bare origin and a refinery checkout, and a feature
+// branch in both. Returns the engineer and the member's origin.
+func testMultiRepoEngineer(t *testing.T) (*Engineer, string, string) {
+	t.Helper()
+	primaryDir, g, _ := testGitRepo(t)
+	createFeatureBranch(t, primaryDir, "polecat/nux", "api.go", "package api\n")
+
+	rigPath := t.TempDir()
+	memberOrigin := filepath.Join(t.TempDir(), "web.git")
+	memberDir := rig.MemberRefineryPath(rigPath, "web")
+	run(t, rigPath, "git", "init", "--bare", "--initial-branch=main", memberOrigin)
+	run(t, rigPath, "git", "clone", memberOrigin, memberDir)
+	run(t, memberDir, "git", "config", "user.email", "test@test.com")
+	run(t, memberDir, "git", "config", "user.name", "Test")
+	run(t, memberDir, "git", "checkout", "-b", "main")
+	writeFile(t, memberDir, "index.ts", "export {}\n")
+	run(t, memberDir, "git", "add", ".")
+	run(t, memberDir, "git", "commit", "-m", "initial commit")
+	run(t, memberDir, "git", "push", "-u", "origin", "main")
+	createFeatureBranch(t, memberDir, "polecat/nux", "client.ts", "export const x = 1\n")
+
+	writeFile(t, rigPath, "config.json",
+		`{"type":"rig","name":"test-rig","repos":[{"name":"web","git_url":"`+memberOrigin+`"}]}`)
+
+	e := newTestEngineer(t, primaryDir, g)
+	e.rig = &rig.Rig{Name: "test-rig", Path: rigPath}
+	return e, primaryDir, memberOrigin
+}
+
+func multiRepoMR() *MRInfo {
+	mr := makeMR("gt-mr1", "polecat/nux", "main")
+	mr.RepoBranches = []beads.RepoBranch{{Repo: "web", Branch: "polecat/nux"}}
+	return mr
+}
+
+func TestProcessMRInfo_MultiRepoLandsAll(t *testing.T) {
+	e, primaryDir, memberOrigin := testMultiRepoEngineer(t)
+
+	result := e.ProcessMRInfo(context.Background(), multiRepoMR())
+	if !result.Success {
+		t.Fatalf("ProcessMRInfo failed: %s", result.Error)
+	}
+
+	run(t, primaryDir, "git", "fetch", "origin")
+	if out := run(t, primaryDir, "git", "ls-tree", "--name-only", "origin/main"); !strings.Contains(out, "api.go") {
+		t.Errorf("primary origin/main missing api.go:\n%s", out)
+	}
+	if out := run(t, memberOrigin, "git", "ls-tree", "--name-only", "main"); !strings.Contains(out, "client.ts") {
+		t.Errorf("member origin main missing client.ts:\n%s", out)
+	}
+}
+
+func TestProcessMRInfo_MultiRepoRollsBackOnPushFailure(t *testing.T) {
+	e, primaryDir, memberOrigin := testMultiRepoEngineer(t)
+	primaryBefore := run(t, primaryDir, "git", "rev-parse", "origin/main")
+
+	// The member origin rejects every push, after the primary has landed.
+	hook := filepath.Join(memberOrigin, "hooks", "pre-receive")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result := e.ProcessMRInfo(context.Background(), multiRepoMR())
+	if result.Success {
+		t.Fatal("ProcessMRInfo should fail when a member push is rejected")
+	}
+	if !strings.Contains(result.Error, "rolled back") {
+		t.Errorf("error should report the rollback: %s", result.Error)
+	}
+
+	run(t, primaryDir, "git", "fetch", "origin")
+	if after := run(t, primaryDir, "git", "rev-parse", "origin/main"); after != primaryBefore {
+		t.Errorf("primary origin/main = %s, want rolled back to %s", after, primaryBefore)
+	}
+	if head := run(t, primaryDir, "git", "rev-parse", "HEAD"); head != primaryBefore {
+		t.Errorf("primary local main = %s, want reset to %s", head, primaryBefore)
+	}
+}
+
+func TestProcessMRInfo_MultiRepoPushesMemberSubmodules(t *testing.T) {
+	// Local submodule URLs need the file protocol, which git blocks by default.
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+
+	e, _, memberOrigin := testMultiRepoEngineer(t)
+	memberDir := rig.MemberRefineryPath(e.rig.Path, "web")
+
+	libOrigin := filepath.Join(t.TempDir(), "lib.git")
+	libSeed := filepath.Join(t.TempDir(), "lib")
+	run(t, memberDir, "git", "init", "--bare", "--initial-branch=main", libOrigin)
+	run(t, memberDir, "git", "clone", libOrigin, libSeed)
+	run(t, libSeed, "git", "checkout", "-b", "main")
+	writeFile(t, libSeed, "lib.go", "package lib\n")
+	run(t, libSeed, "git", "add", ".")
+	run(t, libSeed, "git", "-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "-m", "initial lib")
+	run(t, libSeed, "git", "push", "-u", "origin", "main")
+
+	// main gets the submodule; the feature branch bumps it to a commit that
+	// only exists in the refinery's submodule checkout.
+	run(t, memberDir, "git", "checkout", "main")
+	run(t, memberDir, "git", "submodule", "add", libOrigin, "lib")
+	run(t, memberDir, "git", "commit", "-m", "add lib submodule")
+	run(t, memberDir, "git", "push", "origin", "main")
+	run(t, memberDir, "git", "checkout", "polecat/nux")
+	run(t, memberDir, "git", "merge", "--no-edit", "main")
+	libDir := filepath.Join(memberDir, "lib")
+	writeFile(t, libDir, "lib.go", "package lib\n\nconst V = 2\n")
+	run(t, libDir, "git", "-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "-am", "bump lib")
+	libHead := run(t, libDir, "git", "rev-parse", "HEAD")
+	run(t, memberDir, "git", "commit", "-am", "bump lib submodule")
+	run(t, memberDir, "git", "checkout", "main")
+
+	result := e.ProcessMRInfo(context.Background(), multiRepoMR())
+	if !result.Success {
+		t.Fatalf("ProcessMRInfo failed: %s", result.Error)
+	}
+	if got := run(t, libOrigin, "git", "rev-parse", "main"); got != libHead {
+		t.Errorf("lib origin main = %s, want submodule commit %s pushed", got, libHead)
+	}
+	if out := run(t, memberOrigin, "git", "ls-tree", "main", "lib"); !strings.Contains(out, libHead) {
+		t.Errorf("member origin main should point lib at %s:\n%s", libHead, out)
+	}
+}
+
+func TestProcessMRInfo_MultiRepoUnknownRepo(t *testing.T) {
+	e, _, _ := testMultiRepoEngineer(t)
+	mr := multiRepoMR()
+	mr.RepoBranches[0].Repo = "proto"
+
+	result := e.ProcessMRInfo(context.Background(), mr)
+	if result.Success || !strings.Contains(result.Error, "not a member") {
+		t.Errorf("ProcessMRInfo = %+v, want unknown repo error", result)
+	}
+}
+
+func TestAssembleBatch_SkipsMultiRepoMRs(t *testing.T) {
+	workDir, g, _ := testGitRepo(t)
+	e := newTestEngineer(t, workDir, g)
+	single := makeMR("gt-1", "polecat/a", "main")
+	batch := e.AssembleBatch([]*MRInfo{multiRepoMR(), single}, &BatchConfig{MaxBatchSize: 5})
+	if len(batch) != 1 || batch[0] != single {
+		t.Errorf("AssembleBatch = %v, want only the single-repo MR", batch)
+	}
+}
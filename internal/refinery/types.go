@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
 )
 
 // MergeRequest represents a branch waiting to be merged.
@@ -41,6 +43,10 @@ type MergeRequest struct {
 
 	// Error contains error details if the MR failed.
 	Error string `json:"error,omitempty"`
+
+	// RepoBranches lists member repo branches that land with Branch
+	// (multi-repo rigs).
+	RepoBranches []beads.RepoBranch `json:"repo_branches,omitempty"`
 }
 
 // MRStatus represents the status of a merge request.
@@ -97,6 +97,10 @@ type RigConfig struct {
 	// PolecatNames optionally specifies fixed names (overrides theme-based naming).
 	PolecatPoolSize int      `json:"polecat_pool_size,omitempty"`
 	PolecatNames    []string `json:"polecat_names,omitempty"`
+
+	// Repos lists additional member repositories for multi-repo rigs.
+	// See MemberRepo.
+	Repos []MemberRepo `json:"repos,omitempty"`
 }
 
 // BeadsConfig represents beads configuration for the rig.
@@ -0,0 +1,213 @@
+package rig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// MemberRepo is an additional repository in a multi-repo rig. The rig's own
+// repository (git_url) stays the primary; each member gets its own shared
+// bare repo and refinery worktree, and every polecat gets a worktree of it on
+// the same branch as the primary, so one MR spans all repos.
+type MemberRepo struct {
+	// Name is the member's directory name inside polecat workspaces.
+	Name          string `json:"name"`
+	GitURL        string `json:"git_url"`
+	PushURL       string `json:"push_url,omitempty"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+}
+
+// Branch returns the member's default branch, falling back to "main".
+func (r MemberRepo) Branch() string {
+	if r.DefaultBranch == "" {
+		return "main"
+	}
+	return r.DefaultBranch
+}
+
+// memberRepoName restricts member names to safe directory names.
+var memberRepoName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// MemberBarePath returns the shared bare repo for a member repository.
+func MemberBarePath(rigPath, name string) string {
+	return filepath.Join(rigPath, ".repos", name+".git")
+}
+
+// MemberRefineryPath returns the refinery's worktree of a member repository.
+func MemberRefineryPath(rigPath, name string) string {
+	return filepath.Join(rigPath, "refinery", "repos", name)
+}
+
+// LoadMemberRepos returns the member repositories declared in the rig's
+// config.json. A rig without a config or without members returns nil.
+func LoadMemberRepos(rigPath string) ([]MemberRepo, error) {
+	cfg, err := LoadRigConfig(rigPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cfg.Repos, nil
+}
+
+// MemberRepos returns the rig's member repositories, or nil if the rig has
+// none or its config cannot be read.
+func (r *Rig) MemberRepos() []MemberRepo {
+	repos, _ := LoadMemberRepos(r.Path)
+	return repos
+}
+
+// ValidateMemberRepoName checks that name can be used as a member directory
+// alongside the primary worktree (which is named after the rig).
+func ValidateMemberRepoName(rigName, name string) error {
+	if !memberRepoName.MatchString(name) {
+		return fmt.Errorf("invalid repo name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	if name == rigName || name == "rig" {
+		return fmt.Errorf("repo name %q is reserved", name)
+	}
+	return nil
+}
+
+// AddMemberRepo clones a member repository into a rig: a shared bare repo
+// under .repos/ and a refinery worktree on its default branch. New polecats
+// get a worktree of it; existing ones pick it up on their next spawn.
+func (m *Manager) AddMemberRepo(rigName string, repo MemberRepo) (retErr error) {
+	rigPath := filepath.Join(m.townRoot, rigName)
+	cfg, err := LoadRigConfig(rigPath)
+	if err != nil {
+		return fmt.Errorf("loading rig config: %w", err)
+	}
+	if err := ValidateMemberRepoName(rigName, repo.Name); err != nil {
+		return err
+	}
+	for _, existing := range cfg.Repos {
+		if existing.Name == repo.Name {
+			return fmt.Errorf("rig %s already has a repo named %q", rigName, repo.Name)
+		}
+	}
+
+	barePath := MemberBarePath(rigPath, repo.Name)
+	refineryPath := MemberRefineryPath(rigPath, repo.Name)
+	if _, err := os.Stat(barePath); err == nil {
+		return fmt.Errorf("%s already exists", barePath)
+	}
+	defer func() {
+		if retErr != nil {
+			_ = os.RemoveAll(refineryPath)
+			_ = os.RemoveAll(barePath)
+		}
+	}()
+
+	if err := os.MkdirAll(filepath.Dir(barePath), 0755); err != nil {
+		return fmt.Errorf("creating repos dir: %w", err)
+	}
+	if err := m.git.CloneBare(repo.GitURL, barePath); err != nil {
+		return wrapCloneError(err, repo.GitURL)
+	}
+	bareGit := git.NewGitWithDir(barePath, "")
+	if empty, err := bareGit.IsEmpty(); err != nil {
+		return fmt.Errorf("checking if repository is empty: %w", err)
+	} else if empty {
+		return fmt.Errorf("repository %s is empty (no commits)", repo.GitURL)
+	}
+	if repo.PushURL != "" {
+		if err := bareGit.ConfigurePushURL("origin", repo.PushURL); err != nil {
+			return fmt.Errorf("configuring push URL: %w", err)
+		}
+	}
+
+	if repo.DefaultBranch == "" {
+		repo.DefaultBranch = bareGit.RemoteDefaultBranch()
+		if repo.DefaultBranch == "" {
+			repo.DefaultBranch = bareGit.DefaultBranch()
+		}
+	} else if exists, _ := bareGit.RefExists("origin/" + repo.DefaultBranch); !exists {
+		if err := bareGit.FetchBranchShallow("origin", repo.DefaultBranch); err != nil {
+			return fmt.Errorf("branch %q does not exist on remote or could not be fetched: %w", repo.DefaultBranch, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(refineryPath), 0755); err != nil {
+		return fmt.Errorf("creating refinery repos dir: %w", err)
+	}
+	if err := bareGit.WorktreeAddExisting(refineryPath, repo.DefaultBranch); err != nil {
+		return fmt.Errorf("creating refinery worktree: %w", err)
+	}
+	if err := git.NewGit(refineryPath).ConfigureHooksPath(); err != nil {
+		return fmt.Errorf("configuring hooks for refinery: %w", err)
+	}
+
+	return writeMemberRepos(rigPath, append(cfg.Repos, repo))
+}
+
+// RemoveMemberRepo removes a member repository from a rig. It refuses while
+// any polecat still has a worktree of the repo.
+func (m *Manager) RemoveMemberRepo(rigName, name string) error {
+	rigPath := filepath.Join(m.townRoot, rigName)
+	cfg, err := LoadRigConfig(rigPath)
+	if err != nil {
+		return fmt.Errorf("loading rig config: %w", err)
+	}
+	var kept []MemberRepo
+	found := false
+	for _, r := range cfg.Repos {
+		if r.Name == name {
+			found = true
+			continue
+		}
+		kept = append(kept, r)
+	}
+	if !found {
+		return fmt.Errorf("rig %s has no repo named %q", rigName, name)
+	}
+
+	polecatWorktrees, _ := filepath.Glob(filepath.Join(rigPath, "polecats", "*", name))
+	if len(polecatWorktrees) > 0 {
+		return fmt.Errorf("polecat worktree still uses %s: %s (nuke or finish those polecats first)",
+			name, polecatWorktrees[0])
+	}
+
+	barePath := MemberBarePath(rigPath, name)
+	refineryPath := MemberRefineryPath(rigPath, name)
+	if _, err := os.Stat(refineryPath); err == nil {
+		if err := git.NewGitWithDir(barePath, "").WorktreeRemove(refineryPath, true); err != nil {
+			_ = os.RemoveAll(refineryPath)
+		}
+	}
+	if err := os.RemoveAll(barePath); err != nil {
+		return fmt.Errorf("removing %s: %w", barePath, err)
+	}
+	return writeMemberRepos(rigPath, kept)
+}
+
+// writeMemberRepos updates the repos key of the rig's config.json, leaving
+// every other key (merge_queue etc.) untouched.
+func writeMemberRepos(rigPath string, repos []MemberRepo) error {
+	configPath := filepath.Join(rigPath, "config.json")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("parsing %s: %w", configPath, err)
+	}
+	if len(repos) == 0 {
+		delete(raw, "repos")
+	} else {
+		encoded, err := json.Marshal(repos)
+		if err != nil {
+			return err
+		}
+		raw["repos"] = encoded
+	}
+	return util.AtomicWriteJSON(configPath, raw)
+}
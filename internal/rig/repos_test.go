@@ -0,0 +1,76 @@
+package rig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+func TestValidateMemberRepoName(t *testing.T) {
+	for _, name := range []string{"web", "proto-v2", "api.server"} {
+		if err := ValidateMemberRepoName("gastown", name); err != nil {
+			t.Errorf("ValidateMemberRepoName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "gastown", "rig", "../x", "a/b", ".hidden"} {
+		if err := ValidateMemberRepoName("gastown", name); err == nil {
+			t.Errorf("ValidateMemberRepoName(%q) should fail", name)
+		}
+	}
+}
+
+func TestAddRemoveMemberRepo(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	rigPath := filepath.Join(root, "product")
+	if err := os.MkdirAll(filepath.Join(rigPath, "polecats"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"),
+		[]byte(`{"type":"rig","name":"product","merge_queue":{"enabled":true}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	remote := createTestGitRepoForRig(t, "web")
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	if err := manager.AddMemberRepo("product", MemberRepo{Name: "web", GitURL: remote}); err != nil {
+		t.Fatalf("AddMemberRepo: %v", err)
+	}
+	repos, err := LoadMemberRepos(rigPath)
+	if err != nil || len(repos) != 1 || repos[0].Name != "web" || repos[0].Branch() != "main" {
+		t.Fatalf("LoadMemberRepos = %+v, %v", repos, err)
+	}
+	if _, err := os.Stat(filepath.Join(MemberRefineryPath(rigPath, "web"), "README.md")); err != nil {
+		t.Errorf("refinery worktree not checked out: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(rigPath, "config.json"))
+	if !strings.Contains(string(data), "merge_queue") {
+		t.Errorf("config.json lost unrelated keys:\n%s", data)
+	}
+
+	if err := manager.AddMemberRepo("product", MemberRepo{Name: "web", GitURL: remote}); err == nil {
+		t.Error("adding a duplicate repo should fail")
+	}
+
+	// A polecat worktree of the member blocks removal.
+	polecatWorktree := filepath.Join(rigPath, "polecats", "nux", "web")
+	if err := os.MkdirAll(polecatWorktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.RemoveMemberRepo("product", "web"); err == nil {
+		t.Error("RemoveMemberRepo should refuse while a polecat uses the repo")
+	}
+	_ = os.RemoveAll(polecatWorktree)
+
+	if err := manager.RemoveMemberRepo("product", "web"); err != nil {
+		t.Fatalf("RemoveMemberRepo: %v", err)
+	}
+	if repos, _ := LoadMemberRepos(rigPath); len(repos) != 0 {
+		t.Errorf("repos after removal = %+v", repos)
+	}
+	if _, err := os.Stat(MemberBarePath(rigPath, "web")); !os.IsNotExist(err) {
+		t.Errorf("bare repo should be removed: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// systemDirs are bound read-only into every bubblewrap sandbox so that
+// shells, git and language runtimes work. Missing ones are skipped.
+var systemDirs = []string{"/usr", "/bin", "/sbin", "/lib", "/lib64", "/lib32", "/etc", "/opt"}
+
+// Bubblewrap runs commands under bwrap (https://github.com/containers/bubblewrap).
+// It needs unprivileged user namespaces, which most distributions enable.
+type Bubblewrap struct {
+	// Binary overrides the bwrap path (default: "bwrap" from PATH).
+	Binary string
+}
+
+// Name returns "bubblewrap".
+func (b *Bubblewrap) Name() string { return DriverBubblewrap }
+
+func (b *Bubblewrap) binary() string {
+	if b.Binary != "" {
+		return b.Binary
+	}
+	return "bwrap"
+}
+
+// Check verifies that bwrap is installed and can create namespaces.
+func (b *Bubblewrap) Check() error {
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("bubblewrap sandbox requires Linux (running on %s)", runtime.GOOS)
+	}
+	path, err := exec.LookPath(b.binary())
+	if err != nil {
+		return fmt.Errorf("bwrap not found on PATH (install the bubblewrap package)")
+	}
+	out, err := exec.Command(path, "--ro-bind", "/", "/", "--unshare-all", "true").CombinedOutput() //nolint:gosec // G204: fixed arguments
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("bwrap cannot create namespaces: %s (check that kernel.unprivileged_userns_clone=1 and that no AppArmor profile restricts unprivileged user namespaces)", msg)
+	}
+	return nil
+}
+
+// Wrap returns a command that runs command under bwrap. The sandbox gets
+// fresh user, pid, ipc and uts namespaces, read-only system dirs, an empty
+// /tmp and $HOME, and only the binds and environment listed in spec.
+// Read-write binds are applied before read-only ones, so a read-only path
+// inside a read-write tree stays read-only.
+func (b *Bubblewrap) Wrap(command string, spec Spec) (string, error) {
+	if spec.WorkDir == "" {
+		return "", fmt.Errorf("sandbox spec has no working directory")
+	}
+	if !ValidNetwork(spec.Network) {
+		return "", fmt.Errorf("unknown sandbox network mode %q", spec.Network)
+	}
+
+	args := []string{b.binary(), "--die-with-parent", "--unshare-all"}
+	if spec.Network == "" || spec.Network == NetworkHost {
+		args = append(args, "--share-net")
+	}
+	args = append(args, "--proc", "/proc", "--dev", "/dev", "--tmpfs", "/tmp")
+	for _, dir := range systemDirs {
+		args = append(args, "--ro-bind-try", dir, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil && home != "/" {
+		args = append(args, "--tmpfs", home)
+	}
+
+	var prefix string
+	if spec.Network == NetworkAllowlist {
+		if spec.RuntimeDir == "" {
+			return "", fmt.Errorf("sandbox allowlist network needs a runtime directory")
+		}
+		eps, err := ParseEndpoints(spec.Allow)
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(spec.RuntimeDir, 0700); err != nil {
+			return "", fmt.Errorf("creating sandbox runtime dir: %w", err)
+		}
+		hostsPath := filepath.Join(spec.RuntimeDir, "hosts")
+		if err := os.WriteFile(hostsPath, []byte(HostsFile(eps)), 0644); err != nil { //nolint:gosec // G306: hosts file is not secret
+			return "", fmt.Errorf("writing sandbox hosts file: %w", err)
+		}
+		gt, err := gtBinary(spec)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, "--ro-bind", gt, gt, "--bind", spec.RuntimeDir, spec.RuntimeDir, "--ro-bind", hostsPath, "/etc/hosts")
+
+		// The relay runs outside the sandbox and dials the real endpoints;
+		// the forwarder runs inside and listens on the sandbox's loopback.
+		// Both exit when their parent (bwrap, or the sandboxed shell) does.
+		allowArgs := relayArgs(spec.RuntimeDir, spec.Allow)
+		prefix = config.ShellQuote(gt) + " sandbox relay" + allowArgs + " & "
+		command = config.ShellQuote(gt) + " sandbox forward" + allowArgs + " & " + command
+	}
+
+	args = append(args, "--bind", spec.WorkDir, spec.WorkDir)
+	for _, p := range spec.ReadWrite {
+		args = append(args, "--bind-try", p, p)
+	}
+	for _, p := range spec.ReadOnly {
+		args = append(args, "--ro-bind-try", p, p)
+	}
+	keys := make([]string, 0, len(spec.Env))
+	for k := range spec.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--setenv", k, spec.Env[k])
+	}
+	args = append(args, "--chdir", spec.WorkDir, "--", "/bin/sh", "-c", command)
+
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = config.ShellQuote(a)
+	}
+	return prefix + "exec " + strings.Join(quoted, " "), nil
+}
+
+// relayArgs renders the shared flags of the relay and forward commands.
+func relayArgs(dir string, allow []string) string {
+	var sb strings.Builder
+	sb.WriteString(" --dir " + config.ShellQuote(dir))
+	for _, a := range allow {
+		sb.WriteString(" --allow " + config.ShellQuote(a))
+	}
+	return sb.String()
+}
@@ -0,0 +1,176 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Endpoint is one allowlisted host:port. Inside the sandbox it is served on
+// ListenIP:Port, and the sandbox's /etc/hosts maps Host to ListenIP.
+type Endpoint struct {
+	Host     string
+	Port     int
+	ListenIP string
+}
+
+// Addr returns the real host:port the relay dials.
+func (e Endpoint) Addr() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
+// ParseEndpoints parses host:port allowlist entries. Loopback hosts keep
+// their address inside the sandbox (so 127.0.0.1:3307 still reaches Dolt);
+// every other host gets its own 127.0.1.N address.
+func ParseEndpoints(allow []string) ([]Endpoint, error) {
+	hostIPs := make(map[string]string)
+	next := 1
+	eps := make([]Endpoint, 0, len(allow))
+	for _, entry := range allow {
+		host, portStr, err := net.SplitHostPort(entry)
+		if err != nil || host == "" {
+			return nil, fmt.Errorf("invalid sandbox allow entry %q: want host:port", entry)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid port in sandbox allow entry %q", entry)
+		}
+		ip, ok := hostIPs[host]
+		if !ok {
+			switch {
+			case host == "localhost":
+				ip = "127.0.0.1"
+			case net.ParseIP(host) != nil && net.ParseIP(host).IsLoopback():
+				ip = host
+			default:
+				if next > 254 {
+					return nil, fmt.Errorf("too many sandbox allow hosts (max 254)")
+				}
+				ip = fmt.Sprintf("127.0.1.%d", next)
+				next++
+			}
+			hostIPs[host] = ip
+		}
+		eps = append(eps, Endpoint{Host: host, Port: port, ListenIP: ip})
+	}
+	return eps, nil
+}
+
+// HostsFile renders the sandbox's /etc/hosts. Hosts that are not
+// allowlisted do not resolve at all.
+func HostsFile(eps []Endpoint) string {
+	var sb strings.Builder
+	sb.WriteString("127.0.0.1\tlocalhost\n::1\tlocalhost\n")
+	seen := make(map[string]bool)
+	for _, ep := range eps {
+		if seen[ep.Host] || net.ParseIP(ep.Host) != nil || ep.Host == "localhost" {
+			continue
+		}
+		seen[ep.Host] = true
+		fmt.Fprintf(&sb, "%s\t%s\n", ep.ListenIP, ep.Host)
+	}
+	return sb.String()
+}
+
+// socketPath returns the unix socket for the i-th endpoint.
+func socketPath(dir string, i int) string {
+	return filepath.Join(dir, strconv.Itoa(i)+".sock")
+}
+
+// Relay runs outside the sandbox: it listens on one unix socket per
+// endpoint in dir and connects each accepted stream to the real endpoint.
+// It returns when ctx is done.
+func Relay(ctx context.Context, dir string, eps []Endpoint) error {
+	listeners := make([]net.Listener, 0, len(eps))
+	for i, ep := range eps {
+		path := socketPath(dir, i)
+		_ = os.Remove(path)
+		ln, err := net.Listen("unix", path)
+		if err != nil {
+			closeAll(listeners)
+			return fmt.Errorf("relay for %s: %w", ep.Addr(), err)
+		}
+		listeners = append(listeners, ln)
+	}
+	return serve(ctx, listeners, func(i int) (net.Conn, error) {
+		return net.Dial("tcp", eps[i].Addr())
+	})
+}
+
+// Forward runs inside the sandbox: it listens on each endpoint's ListenIP
+// and port and passes connections to the relay's unix sockets in dir.
+// It returns when ctx is done.
+func Forward(ctx context.Context, dir string, eps []Endpoint) error {
+	listeners := make([]net.Listener, 0, len(eps))
+	for _, ep := range eps {
+		ln, err := net.Listen("tcp", net.JoinHostPort(ep.ListenIP, strconv.Itoa(ep.Port)))
+		if err != nil {
+			closeAll(listeners)
+			return fmt.Errorf("forward for %s: %w", ep.Addr(), err)
+		}
+		listeners = append(listeners, ln)
+	}
+	return serve(ctx, listeners, func(i int) (net.Conn, error) {
+		return net.Dial("unix", socketPath(dir, i))
+	})
+}
+
+// serve accepts on every listener and pipes each connection to the stream
+// returned by dial for that listener's index, until ctx is done.
+func serve(ctx context.Context, listeners []net.Listener, dial func(i int) (net.Conn, error)) error {
+	var wg sync.WaitGroup
+	for i, ln := range listeners {
+		wg.Add(1)
+		go func(i int, ln net.Listener) {
+			defer wg.Done()
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					upstream, err := dial(i)
+					if err != nil {
+						_ = conn.Close()
+						return
+					}
+					pipe(conn, upstream)
+				}()
+			}
+		}(i, ln)
+	}
+	<-ctx.Done()
+	closeAll(listeners)
+	wg.Wait()
+	return nil
+}
+
+// pipe copies in both directions until either side closes.
+func pipe(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	cp := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+			_ = cw.CloseWrite()
+		}
+		done <- struct{}{}
+	}
+	go cp(a, b)
+	go cp(b, a)
+	<-done
+	<-done
+	_ = a.Close()
+	_ = b.Close()
+}
+
+func closeAll(listeners []net.Listener) {
+	for _, ln := range listeners {
+		_ = ln.Close()
+	}
+}
@@ -0,0 +1,182 @@
+// Package sandbox confines agent sessions to the files and network they
+// need. A Driver wraps the shell command that starts the agent; the "none"
+// driver leaves it alone and the "bubblewrap" driver runs it in Linux user,
+// mount, pid and (optionally) network namespaces via bwrap.
+//
+// Inside a bubblewrap sandbox, $HOME is an empty tmpfs: the agent sees only
+// the system directories, the paths the Spec grants, and nothing else from
+// the user's home (no ~/.ssh, cloud credentials or other rigs).
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Driver names.
+const (
+	DriverNone       = "none"
+	DriverBubblewrap = "bubblewrap"
+)
+
+// Network modes.
+const (
+	NetworkHost      = "host"      // Full host network
+	NetworkNone      = "none"      // Loopback only
+	NetworkAllowlist = "allowlist" // Only Spec.Allow endpoints, via relay sockets
+)
+
+// Spec describes what a sandboxed session may reach.
+type Spec struct {
+	WorkDir   string   // Starting directory; always read-write
+	ReadWrite []string // Host paths bound read-write (missing paths are skipped)
+	ReadOnly  []string // Host paths bound read-only (missing paths are skipped)
+	Network   string   // NetworkHost (default), NetworkNone or NetworkAllowlist
+	Allow     []string // host:port endpoints for NetworkAllowlist
+
+	// Env is set inside the sandbox, for settings the hidden $HOME would
+	// otherwise provide (such as the git identity from ~/.gitconfig).
+	Env map[string]string
+
+	// RuntimeDir holds the relay sockets and generated hosts file in
+	// allowlist mode. It is created if missing.
+	RuntimeDir string
+
+	// GTBinary is the gt executable that runs the relay and forwarder.
+	// Defaults to os.Executable().
+	GTBinary string
+}
+
+// Driver wraps agent startup commands in a sandbox.
+type Driver interface {
+	// Name returns the driver name used in settings.
+	Name() string
+
+	// Wrap returns a shell command that runs command inside the sandbox.
+	Wrap(command string, spec Spec) (string, error)
+
+	// Check reports whether the driver can run on this host.
+	Check() error
+}
+
+var drivers = map[string]Driver{
+	DriverNone:       noneDriver{},
+	DriverBubblewrap: &Bubblewrap{},
+}
+
+// Drivers returns the known driver names, sorted.
+func Drivers() []string {
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named driver. An empty name selects the none driver.
+func Get(name string) (Driver, error) {
+	if name == "" {
+		name = DriverNone
+	}
+	d, ok := drivers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown sandbox driver %q (available: %s)", name, strings.Join(Drivers(), ", "))
+	}
+	return d, nil
+}
+
+// ValidNetwork reports whether mode is a known network mode ("" means host).
+func ValidNetwork(mode string) bool {
+	switch mode {
+	case "", NetworkHost, NetworkNone, NetworkAllowlist:
+		return true
+	}
+	return false
+}
+
+// noneDriver runs commands unconfined.
+type noneDriver struct{}
+
+func (noneDriver) Name() string                                { return DriverNone }
+func (noneDriver) Wrap(command string, _ Spec) (string, error) { return command, nil }
+func (noneDriver) Check() error                                { return nil }
+
+// ExecutablePaths returns the directories holding the named executables,
+// both as found on PATH and after resolving symlinks, so a sandbox that
+// hides $HOME can still run tools installed under it (e.g. ~/go/bin/gt,
+// ~/.local/bin/claude). Names may also be paths. Names not found are
+// skipped, as are directories that would expose all of $HOME.
+func ExecutablePaths(names ...string) []string {
+	home, _ := os.UserHomeDir()
+	seen := make(map[string]bool)
+	var dirs []string
+	addDir := func(dir string) {
+		if dir == "/" || (home != "" && (dir == home || strings.HasPrefix(home, dir+"/"))) {
+			return
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	for _, name := range names {
+		path, err := exec.LookPath(name)
+		if err != nil {
+			continue
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		addDir(filepath.Dir(path))
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			continue
+		}
+		// npm installs link bin/<tool> into lib/node_modules/<pkg>/; the
+		// whole node_modules tree must be visible for the tool to load.
+		if i := strings.Index(resolved, "/node_modules/"); i >= 0 {
+			addDir(resolved[:i+len("/node_modules")])
+		} else {
+			addDir(filepath.Dir(resolved))
+		}
+	}
+	return dirs
+}
+
+// AgentStatePaths returns the per-user state an agent CLI needs read-write
+// access to (login tokens, settings, session history), under home.
+func AgentStatePaths(agent, home string) []string {
+	var rel []string
+	switch agent {
+	case "claude":
+		rel = []string{".claude", ".claude.json"}
+	case "codex":
+		rel = []string{".codex"}
+	case "gemini":
+		rel = []string{".gemini"}
+	case "opencode":
+		rel = []string{".config/opencode", ".local/share/opencode"}
+	}
+	paths := make([]string, 0, len(rel))
+	for _, r := range rel {
+		paths = append(paths, filepath.Join(home, r))
+	}
+	return paths
+}
+
+// gtBinary returns spec.GTBinary or the running executable.
+func gtBinary(spec Spec) (string, error) {
+	if spec.GTBinary != "" {
+		return spec.GTBinary, nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("locating gt binary for sandbox relay: %w", err)
+	}
+	return exe, nil
+}
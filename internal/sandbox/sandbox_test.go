@@ -0,0 +1,199 @@
+package sandbox
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	d, err := Get("")
+	if err != nil || d.Name() != DriverNone {
+		t.Fatalf("Get(\"\") = %v, %v; want none driver", d, err)
+	}
+	if out, _ := d.Wrap("claude", Spec{}); out != "claude" {
+		t.Errorf("none driver Wrap = %q, want unchanged", out)
+	}
+	if _, err := Get("docker"); err == nil {
+		t.Error("Get(docker) should fail")
+	}
+}
+
+func TestBubblewrapWrap(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	b := &Bubblewrap{Binary: "/usr/bin/bwrap"}
+	spec := Spec{
+		WorkDir:   "/town/rig/polecats/nux/rig",
+		ReadWrite: []string{"/town/.beads"},
+		ReadOnly:  []string{"/town/rig/settings"},
+		Env:       map[string]string{"GIT_COMMITTER_NAME": "nux", "GIT_AUTHOR_EMAIL": "rig.polecats.nux@gastown.local"},
+	}
+
+	out, err := b.Wrap("export GT_ROLE=polecat && claude", spec)
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+	for _, want := range []string{
+		"exec /usr/bin/bwrap --die-with-parent --unshare-all --share-net",
+		"--tmpfs " + home,
+		"--bind /town/rig/polecats/nux/rig /town/rig/polecats/nux/rig",
+		"--bind-try /town/.beads /town/.beads",
+		"--ro-bind-try /town/rig/settings /town/rig/settings",
+		"--setenv GIT_AUTHOR_EMAIL rig.polecats.nux@gastown.local --setenv GIT_COMMITTER_NAME nux",
+		"--chdir /town/rig/polecats/nux/rig -- /bin/sh -c 'export GT_ROLE=polecat && claude'",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Wrap output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "--bind-try /town/.beads") > strings.Index(out, "--ro-bind-try /town/rig/settings") {
+		t.Error("read-write binds must come before read-only binds")
+	}
+
+	spec.Network = NetworkNone
+	out, _ = b.Wrap("claude", spec)
+	if strings.Contains(out, "--share-net") {
+		t.Errorf("network none should not share the host network:\n%s", out)
+	}
+}
+
+func TestBubblewrapWrap_Allowlist(t *testing.T) {
+	rt := filepath.Join(t.TempDir(), ".sandbox")
+	b := &Bubblewrap{Binary: "bwrap"}
+	out, err := b.Wrap("claude", Spec{
+		WorkDir:    "/work",
+		Network:    NetworkAllowlist,
+		Allow:      []string{"github.com:443", "127.0.0.1:3307"},
+		RuntimeDir: rt,
+		GTBinary:   "/opt/gt",
+	})
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+	if !strings.HasPrefix(out, "/opt/gt sandbox relay --dir "+rt+" --allow github.com:443 --allow 127.0.0.1:3307 & exec bwrap") {
+		t.Errorf("allowlist Wrap should start the relay first:\n%s", out)
+	}
+	if strings.Contains(out, "--share-net") {
+		t.Errorf("allowlist mode must not share the host network:\n%s", out)
+	}
+	if !strings.Contains(out, "--ro-bind "+filepath.Join(rt, "hosts")+" /etc/hosts") {
+		t.Errorf("allowlist Wrap should bind the generated hosts file:\n%s", out)
+	}
+	if !strings.Contains(out, "'/opt/gt sandbox forward --dir "+rt) {
+		t.Errorf("allowlist Wrap should start the forwarder inside:\n%s", out)
+	}
+	hosts, err := os.ReadFile(filepath.Join(rt, "hosts"))
+	if err != nil || !strings.Contains(string(hosts), "127.0.1.1\tgithub.com") {
+		t.Errorf("hosts file = %q, %v", hosts, err)
+	}
+
+	if _, err := b.Wrap("claude", Spec{WorkDir: "/work", Network: NetworkAllowlist, Allow: []string{"github.com"}, RuntimeDir: rt}); err == nil {
+		t.Error("Wrap should reject an allow entry without a port")
+	}
+}
+
+func TestParseEndpoints(t *testing.T) {
+	eps, err := ParseEndpoints([]string{"github.com:443", "github.com:22", "localhost:3307", "api.anthropic.com:443"})
+	if err != nil {
+		t.Fatalf("ParseEndpoints: %v", err)
+	}
+	want := []string{"127.0.1.1", "127.0.1.1", "127.0.0.1", "127.0.1.2"}
+	for i, ep := range eps {
+		if ep.ListenIP != want[i] {
+			t.Errorf("eps[%d] = %+v, want ListenIP %s", i, ep, want[i])
+		}
+	}
+	hosts := HostsFile(eps)
+	if strings.Count(hosts, "github.com") != 1 || !strings.Contains(hosts, "127.0.1.2\tapi.anthropic.com") {
+		t.Errorf("HostsFile =\n%s", hosts)
+	}
+
+	for _, bad := range []string{"github.com", ":443", "github.com:0", "github.com:https"} {
+		if _, err := ParseEndpoints([]string{bad}); err == nil {
+			t.Errorf("ParseEndpoints(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRelayForward(t *testing.T) {
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer echo.Close()
+	go func() {
+		for {
+			conn, err := echo.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				line, _ := bufio.NewReader(conn).ReadString('\n')
+				_, _ = conn.Write([]byte("echo " + line))
+				_ = conn.Close()
+			}()
+		}
+	}()
+
+	port := echo.Addr().(*net.TCPAddr).Port
+	eps, err := ParseEndpoints([]string{"127.0.0.1:" + strconv.Itoa(port)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Outside a network namespace the forwarder cannot take the echo
+	// server's own address, so serve it on another loopback address.
+	eps[0].ListenIP = "127.0.1.1"
+
+	dir, err := os.MkdirTemp("", "gt-sandbox-") // Short path: unix socket names are limited
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = Relay(ctx, dir, eps) }()
+	go func() { _ = Forward(ctx, dir, eps) }()
+
+	addr := net.JoinHostPort("127.0.1.1", strconv.Itoa(port))
+	var conn net.Conn
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err = net.Dial("tcp", addr)
+		if err == nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("dial forwarder: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("ping\n")); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil || reply != "echo ping\n" {
+		t.Errorf("reply = %q, %v; want %q", reply, err, "echo ping\n")
+	}
+}
+
+func TestExecutablePaths_NeverExposesHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	bin := filepath.Join(home, "agent")
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if dirs := ExecutablePaths(bin, "sh"); len(dirs) == 0 || slices.Contains(dirs, home) {
+		t.Errorf("ExecutablePaths = %v; want sh's dir and not %s", dirs, home)
+	}
+}
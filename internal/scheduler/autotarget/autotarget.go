@@ -0,0 +1,236 @@
+// Package autotarget scores dispatch candidates for capability-based target
+// selection (gt sling --auto). Like the capacity package, it holds only types
+// and pure functions: gathering candidates, skills, history and load from
+// beads/tmux stays in cmd, which feeds the results into Rank.
+package autotarget
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Scoring weights. A declared skill match dominates, history refines the
+// choice between equally-skilled candidates, and load breaks remaining ties
+// by steering work away from busy workers.
+const (
+	// WeightSkill is awarded per bead label matched by a declared skill.
+	WeightSkill = 10.0
+
+	// WeightHistory scales the smoothed success rate on a matching label.
+	// Applied per label, in the range [-WeightHistory, +WeightHistory].
+	WeightHistory = 6.0
+
+	// WeightLoad is the penalty at full utilization (Active == Capacity).
+	WeightLoad = 4.0
+
+	// PolecatPoolSuffix is the history key suffix that groups all polecats in
+	// a rig. Polecat names are recycled from a pool, so per-name history would
+	// be noise; the pool as a whole builds the track record.
+	PolecatPoolSuffix = "/polecats"
+)
+
+// Kind identifies what sort of worker a candidate is.
+type Kind string
+
+const (
+	// KindPolecat is a fresh polecat spawned in a rig (target = rig name).
+	KindPolecat Kind = "polecat"
+
+	// KindCrew is an existing crew member (target = <rig>/crew/<name>).
+	KindCrew Kind = "crew"
+)
+
+// Candidate is a possible dispatch target.
+type Candidate struct {
+	// Target is the sling target spec (e.g., "gastown" or "gastown/crew/max").
+	Target string
+
+	// Kind is the worker kind.
+	Kind Kind
+
+	// HistoryKey is the key used to look up past outcomes (see HistoryKey).
+	HistoryKey string
+
+	// Skills are the declared skill tags for this worker.
+	Skills []string
+
+	// Active is the amount of work the candidate currently holds.
+	Active int
+
+	// Capacity is the maximum concurrent work. 0 means unlimited.
+	Capacity int
+
+	// Unavailable, when non-empty, excludes the candidate and explains why
+	// (e.g., "session not running", "rig parked").
+	Unavailable string
+}
+
+// Outcome counts past results for one worker on one label.
+type Outcome struct {
+	Successes int
+	Failures  int
+}
+
+// History maps a bead label to per-worker outcomes, keyed by HistoryKey.
+type History map[string]map[string]Outcome
+
+// Record adds a single outcome for the given label and assignee.
+func (h History) Record(label, assignee string, success bool) {
+	key := HistoryKey(assignee)
+	if label == "" || key == "" {
+		return
+	}
+	byWorker, ok := h[label]
+	if !ok {
+		byWorker = make(map[string]Outcome)
+		h[label] = byWorker
+	}
+	o := byWorker[key]
+	if success {
+		o.Successes++
+	} else {
+		o.Failures++
+	}
+	byWorker[key] = o
+}
+
+// HistoryKey normalizes an assignee address to the key used for history.
+// Polecats collapse to their rig's pool ("gastown/polecats/Toast" ->
+// "gastown/polecats"); everyone else keeps their full address.
+func HistoryKey(assignee string) string {
+	assignee = strings.TrimSuffix(assignee, "/")
+	parts := strings.Split(assignee, "/")
+	if len(parts) >= 2 && parts[1] == "polecats" {
+		return parts[0] + PolecatPoolSuffix
+	}
+	return assignee
+}
+
+// Choice is a scored candidate with the reasoning behind its score.
+type Choice struct {
+	Candidate
+	Score   float64
+	Reasons []string
+}
+
+// Eligible reports whether the candidate can accept work right now.
+func (c Choice) Eligible() bool {
+	return c.Unavailable == ""
+}
+
+// MatchableLabels filters out Gas Town bookkeeping labels (gt:*), which say
+// nothing about the skills a bead needs.
+func MatchableLabels(labels []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, l := range labels {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l == "" || strings.HasPrefix(l, "gt:") || seen[l] {
+			continue
+		}
+		seen[l] = true
+		out = append(out, l)
+	}
+	return out
+}
+
+// Rank scores every candidate against the bead's labels and returns them best
+// first. Unavailable or full candidates are kept (so the output can explain
+// why they were passed over) but always sort after eligible ones.
+func Rank(labels []string, candidates []Candidate, history History) []Choice {
+	labels = MatchableLabels(labels)
+
+	choices := make([]Choice, 0, len(candidates))
+	for _, cand := range candidates {
+		choices = append(choices, score(labels, cand, history))
+	}
+
+	sort.SliceStable(choices, func(i, j int) bool {
+		ei, ej := choices[i].Eligible(), choices[j].Eligible()
+		if ei != ej {
+			return ei
+		}
+		if choices[i].Score != choices[j].Score {
+			return choices[i].Score > choices[j].Score
+		}
+		// Prefer a fresh polecat over interrupting a crew member on ties.
+		if choices[i].Kind != choices[j].Kind {
+			return choices[i].Kind == KindPolecat
+		}
+		return choices[i].Target < choices[j].Target
+	})
+	return choices
+}
+
+// Best returns the highest-ranked eligible choice, or false if none is eligible.
+func Best(choices []Choice) (Choice, bool) {
+	for _, c := range choices {
+		if c.Eligible() {
+			return c, true
+		}
+	}
+	return Choice{}, false
+}
+
+func score(labels []string, cand Candidate, history History) Choice {
+	choice := Choice{Candidate: cand}
+
+	if cand.Capacity > 0 && cand.Active >= cand.Capacity && cand.Unavailable == "" {
+		choice.Unavailable = fmt.Sprintf("at capacity (%d/%d)", cand.Active, cand.Capacity)
+	}
+
+	// Skills
+	skills := make(map[string]bool, len(cand.Skills))
+	for _, s := range cand.Skills {
+		skills[strings.ToLower(strings.TrimSpace(s))] = true
+	}
+	var matched []string
+	for _, l := range labels {
+		if skills[l] {
+			matched = append(matched, l)
+		}
+	}
+	if len(matched) > 0 {
+		choice.Score += WeightSkill * float64(len(matched))
+		choice.Reasons = append(choice.Reasons,
+			fmt.Sprintf("skills match %s (+%.1f)", strings.Join(matched, ", "), WeightSkill*float64(len(matched))))
+	} else if len(labels) > 0 {
+		choice.Reasons = append(choice.Reasons, "no declared skill matches")
+	}
+
+	// History
+	key := cand.HistoryKey
+	if key == "" {
+		key = HistoryKey(cand.Target)
+	}
+	for _, l := range labels {
+		o, ok := history[l][key]
+		if !ok || o.Successes+o.Failures == 0 {
+			continue
+		}
+		// Smoothed net success rate: one phantom neutral observation keeps a
+		// single lucky (or unlucky) result from swinging the score fully.
+		rate := float64(o.Successes-o.Failures) / float64(o.Successes+o.Failures+1)
+		delta := WeightHistory * rate
+		choice.Score += delta
+		choice.Reasons = append(choice.Reasons,
+			fmt.Sprintf("history on %s: %d ok, %d failed (%+.1f)", l, o.Successes, o.Failures, delta))
+	}
+
+	// Load
+	if cand.Capacity > 0 {
+		util := float64(cand.Active) / float64(cand.Capacity)
+		if util > 1 {
+			util = 1
+		}
+		penalty := WeightLoad * util
+		choice.Score -= penalty
+		choice.Reasons = append(choice.Reasons,
+			fmt.Sprintf("load %d/%d (-%.1f)", cand.Active, cand.Capacity, penalty))
+	} else if cand.Active > 0 {
+		choice.Reasons = append(choice.Reasons, fmt.Sprintf("load %d (unbounded)", cand.Active))
+	}
+
+	return choice
+}
@@ -0,0 +1,105 @@
+package autotarget
+
+import "testing"
+
+func TestHistoryKey(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"gastown/polecats/Toast", "gastown/polecats"},
+		{"gastown/polecats/Toast/", "gastown/polecats"},
+		{"gastown/crew/max", "gastown/crew/max"},
+		{"mayor/", "mayor"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := HistoryKey(tt.in); got != tt.want {
+			t.Errorf("HistoryKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatchableLabels(t *testing.T) {
+	got := MatchableLabels([]string{"gt:queued", "Frontend", "frontend", " css ", ""})
+	if len(got) != 2 || got[0] != "frontend" || got[1] != "css" {
+		t.Errorf("MatchableLabels = %v, want [frontend css]", got)
+	}
+}
+
+func TestRank_SkillMatchWins(t *testing.T) {
+	cands := []Candidate{
+		{Target: "gastown", Kind: KindPolecat, Capacity: 4},
+		{Target: "gastown/crew/max", Kind: KindCrew, Skills: []string{"frontend"}, Capacity: 1},
+	}
+	choices := Rank([]string{"frontend"}, cands, History{})
+	best, ok := Best(choices)
+	if !ok {
+		t.Fatal("expected an eligible choice")
+	}
+	if best.Target != "gastown/crew/max" {
+		t.Errorf("best = %s, want gastown/crew/max", best.Target)
+	}
+	if len(best.Reasons) == 0 {
+		t.Error("expected reasons to be populated")
+	}
+}
+
+func TestRank_HistoryBreaksSkillTie(t *testing.T) {
+	h := History{}
+	for i := 0; i < 3; i++ {
+		h.Record("db", "gastown/polecats/Toast", true)
+	}
+	h.Record("db", "gastown/crew/max", false)
+
+	cands := []Candidate{
+		{Target: "gastown/crew/max", Kind: KindCrew, Skills: []string{"db"}, Capacity: 1},
+		{Target: "gastown", Kind: KindPolecat, HistoryKey: "gastown/polecats", Skills: []string{"db"}, Capacity: 4},
+	}
+	best, _ := Best(Rank([]string{"db"}, cands, h))
+	if best.Target != "gastown" {
+		t.Errorf("best = %s, want gastown (better history)", best.Target)
+	}
+}
+
+func TestRank_FullAndUnavailableExcluded(t *testing.T) {
+	cands := []Candidate{
+		{Target: "gastown", Kind: KindPolecat, Skills: []string{"go"}, Active: 4, Capacity: 4},
+		{Target: "gastown/crew/max", Kind: KindCrew, Skills: []string{"go"}, Unavailable: "session not running"},
+		{Target: "gastown/crew/joe", Kind: KindCrew, Capacity: 1},
+	}
+	choices := Rank([]string{"go"}, cands, nil)
+	best, ok := Best(choices)
+	if !ok || best.Target != "gastown/crew/joe" {
+		t.Fatalf("best = %+v, want gastown/crew/joe", best)
+	}
+	if choices[1].Unavailable == "" || choices[2].Unavailable == "" {
+		t.Errorf("ineligible candidates should sort last with a reason: %+v", choices)
+	}
+	if choices[1].Target != "gastown" && choices[2].Target != "gastown" {
+		t.Errorf("full polecat pool missing from ranking")
+	}
+}
+
+func TestRank_LoadBreaksTieAndPolecatPreferred(t *testing.T) {
+	cands := []Candidate{
+		{Target: "gastown/crew/max", Kind: KindCrew, Capacity: 2, Active: 1},
+		{Target: "gastown", Kind: KindPolecat, Capacity: 4, Active: 0},
+	}
+	best, _ := Best(Rank(nil, cands, nil))
+	if best.Target != "gastown" {
+		t.Errorf("best = %s, want gastown (less loaded)", best.Target)
+	}
+
+	cands[0].Active = 0
+	best, _ = Best(Rank(nil, cands, nil))
+	if best.Target != "gastown" {
+		t.Errorf("best = %s, want gastown (polecat preferred on tie)", best.Target)
+	}
+}
+
+func TestBest_NoneEligible(t *testing.T) {
+	_, ok := Best(Rank(nil, []Candidate{{Target: "x", Unavailable: "parked"}}, nil))
+	if ok {
+		t.Error("Best should report no eligible candidate")
+	}
+}
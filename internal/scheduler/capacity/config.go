@@ -28,6 +28,10 @@ type SchedulerConfig struct {
 	// SpawnDelay is the delay between spawns to prevent Dolt lock contention.
 	// Default: "0s".
 	SpawnDelay string `json:"spawn_delay,omitempty"`
+
+	// Pressure sets host load watermarks checked before each dispatch cycle
+	// (CPU load, memory, disk, Dolt latency, session memory). nil = defaults.
+	Pressure *PressureConfig `json:"pressure,omitempty"`
 }
 
 // DefaultSchedulerConfig returns a SchedulerConfig with sensible defaults.
@@ -35,6 +35,10 @@ type DispatchCycle struct {
 	// OnFailure is called after failed dispatch.
 	OnFailure func(PendingBead, error)
 
+	// HostPressure reports whether the host is too loaded to spawn more
+	// polecats. Optional; only consulted when the plan would dispatch.
+	HostPressure func() PressureVerdict
+
 	// BatchSize caps items dispatched per cycle.
 	BatchSize int
 
@@ -47,7 +51,8 @@ type DispatchReport struct {
 	Dispatched int
 	Failed     int
 	Skipped    int
-	Reason     string // "capacity" | "batch" | "ready" | "none"
+	Reason     string // "capacity" | "batch" | "ready" | "pressure" | "none"
+	Pressure   string // Host pressure detail when Reason is "pressure"
 }
 
 // Plan returns the dispatch plan without executing. Used for dry-run.
@@ -62,7 +67,17 @@ func (c *DispatchCycle) Plan() (DispatchPlan, error) {
 		return DispatchPlan{}, fmt.Errorf("querying pending: %w", err)
 	}
 
-	return PlanDispatch(cap, c.BatchSize, pending), nil
+	plan := PlanDispatch(cap, c.BatchSize, pending)
+	if len(plan.ToDispatch) > 0 && c.HostPressure != nil {
+		if v := c.HostPressure(); v.Throttled {
+			plan = DispatchPlan{
+				Skipped:  len(plan.ToDispatch) + plan.Skipped,
+				Reason:   "pressure",
+				Pressure: v.Reason,
+			}
+		}
+	}
+	return plan, nil
 }
 
 // onSuccessRetries is the number of times to retry OnSuccess before giving up.
@@ -76,8 +91,9 @@ func (c *DispatchCycle) Run() (DispatchReport, error) {
 	}
 
 	report := DispatchReport{
-		Skipped: plan.Skipped,
-		Reason:  plan.Reason,
+		Skipped:  plan.Skipped,
+		Reason:   plan.Reason,
+		Pressure: plan.Pressure,
 	}
 
 	for i, b := range plan.ToDispatch {
@@ -279,3 +279,44 @@ func TestDispatchCycle_Run_SpawnDelay(t *testing.T) {
 		t.Errorf("elapsed = %v, expected at least ~20ms for 2 delays", elapsed)
 	}
 }
+
+func TestDispatchCycle_Plan_HostPressure(t *testing.T) {
+	executed := 0
+	cycle := &DispatchCycle{
+		AvailableCapacity: func() (int, error) { return 5, nil },
+		QueryPending: func() ([]PendingBead, error) {
+			return []PendingBead{{ID: "a"}, {ID: "b"}}, nil
+		},
+		Execute: func(PendingBead) error { executed++; return nil },
+		HostPressure: func() PressureVerdict {
+			return PressureVerdict{Throttled: true, Reason: "memory_used_pct 95 >= high 90"}
+		},
+		BatchSize: 1,
+	}
+
+	report, err := cycle.Run()
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if executed != 0 || report.Dispatched != 0 {
+		t.Errorf("dispatched %d under host pressure, want 0", executed)
+	}
+	if report.Reason != "pressure" || report.Skipped != 2 || report.Pressure == "" {
+		t.Errorf("report = %+v, want reason pressure with 2 skipped and detail", report)
+	}
+}
+
+func TestDispatchCycle_Plan_HostPressureNotSampledWithoutWork(t *testing.T) {
+	cycle := &DispatchCycle{
+		AvailableCapacity: func() (int, error) { return 0, nil },
+		QueryPending:      func() ([]PendingBead, error) { return []PendingBead{{ID: "a"}}, nil },
+		HostPressure: func() PressureVerdict {
+			t.Error("HostPressure should not be sampled when nothing would dispatch")
+			return PressureVerdict{}
+		},
+		BatchSize: 1,
+	}
+	if plan, _ := cycle.Plan(); plan.Reason != "capacity" {
+		t.Errorf("Reason = %q, want capacity", plan.Reason)
+	}
+}
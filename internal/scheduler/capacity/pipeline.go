@@ -43,7 +43,8 @@ const LabelSlingContext = "gt:sling-context"
 type DispatchPlan struct {
 	ToDispatch []PendingBead
 	Skipped    int
-	Reason     string // "capacity" | "batch" | "ready" | "none"
+	Reason     string // "capacity" | "batch" | "ready" | "pressure" | "none"
+	Pressure   string // Host pressure detail when Reason is "pressure"
 }
 
 // FailureAction indicates what to do after a dispatch failure.
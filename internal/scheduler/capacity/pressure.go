@@ -0,0 +1,157 @@
+package capacity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Host pressure metric names. Higher values always mean more pressure.
+const (
+	MetricLoadPerCPU      = "load_per_cpu"      // 1-minute load average divided by CPU count
+	MetricMemoryUsedPct   = "memory_used_pct"   // Percent of RAM not available to new processes
+	MetricDiskUsedPct     = "disk_used_pct"     // Percent used on the town root's filesystem
+	MetricDoltLatencyMs   = "dolt_latency_ms"   // Dolt SELECT round-trip in milliseconds
+	MetricSessionMemoryMB = "session_memory_mb" // Total RSS of tracked agent sessions, in MB
+)
+
+// Watermark is a hysteresis band for one pressure metric. Dispatch stops when
+// the metric reaches High and resumes only once it falls back to Low or
+// below, so a host hovering at the limit does not flap between spawning and
+// stalling. High <= 0 disables the metric; Low <= 0 means Low = High.
+type Watermark struct {
+	High float64 `json:"high"`
+	Low  float64 `json:"low,omitempty"`
+}
+
+// PressureConfig sets the host pressure watermarks for deferred dispatch.
+// A nil field uses the default watermark for that metric.
+type PressureConfig struct {
+	LoadPerCPU      *Watermark `json:"load_per_cpu,omitempty"`
+	MemoryUsedPct   *Watermark `json:"memory_used_pct,omitempty"`
+	DiskUsedPct     *Watermark `json:"disk_used_pct,omitempty"`
+	DoltLatencyMs   *Watermark `json:"dolt_latency_ms,omitempty"`
+	SessionMemoryMB *Watermark `json:"session_memory_mb,omitempty"`
+}
+
+// MetricWatermark pairs a metric name with its effective watermark.
+type MetricWatermark struct {
+	Metric string
+	Watermark
+}
+
+// DefaultWatermarks are used for metrics a PressureConfig leaves unset.
+// Session memory has no default: the right total depends on the machine.
+var DefaultWatermarks = map[string]Watermark{
+	MetricLoadPerCPU:    {High: 1.5, Low: 1.0},
+	MetricMemoryUsedPct: {High: 90, Low: 80},
+	MetricDiskUsedPct:   {High: 95, Low: 90},
+	MetricDoltLatencyMs: {High: 1000, Low: 500},
+}
+
+// PressureMetrics lists every metric name in display order.
+var PressureMetrics = []string{
+	MetricLoadPerCPU, MetricMemoryUsedPct, MetricDiskUsedPct, MetricDoltLatencyMs, MetricSessionMemoryMB,
+}
+
+// field returns the configured watermark pointer for a metric.
+func (c *PressureConfig) field(metric string) **Watermark {
+	switch metric {
+	case MetricLoadPerCPU:
+		return &c.LoadPerCPU
+	case MetricMemoryUsedPct:
+		return &c.MemoryUsedPct
+	case MetricDiskUsedPct:
+		return &c.DiskUsedPct
+	case MetricDoltLatencyMs:
+		return &c.DoltLatencyMs
+	case MetricSessionMemoryMB:
+		return &c.SessionMemoryMB
+	}
+	return nil
+}
+
+// SetWatermark sets one bound ("high" or "low") of a metric's watermark,
+// starting from the default when the metric is not configured yet.
+func (c *PressureConfig) SetWatermark(metric, bound string, value float64) error {
+	f := c.field(metric)
+	if f == nil {
+		return fmt.Errorf("unknown pressure metric %q (known: %s)", metric, strings.Join(PressureMetrics, ", "))
+	}
+	if *f == nil {
+		w := DefaultWatermarks[metric]
+		*f = &w
+	}
+	switch bound {
+	case "high":
+		(*f).High = value
+	case "low":
+		(*f).Low = value
+	default:
+		return fmt.Errorf("unknown watermark bound %q (want high or low)", bound)
+	}
+	return nil
+}
+
+// GetPressureWatermarks returns the effective watermark of every metric, in
+// display order, including disabled ones (High <= 0).
+func (c *SchedulerConfig) GetPressureWatermarks() []MetricWatermark {
+	marks := make([]MetricWatermark, 0, len(PressureMetrics))
+	for _, metric := range PressureMetrics {
+		w := DefaultWatermarks[metric]
+		if c != nil && c.Pressure != nil {
+			if f := c.Pressure.field(metric); *f != nil {
+				w = **f
+			}
+		}
+		if w.Low <= 0 || w.Low > w.High {
+			w.Low = w.High
+		}
+		marks = append(marks, MetricWatermark{Metric: metric, Watermark: w})
+	}
+	return marks
+}
+
+// HostSample maps metric names to measured values. Metrics that could not be
+// measured on this host are absent and never throttle dispatch.
+type HostSample map[string]float64
+
+// PressureVerdict is the outcome of comparing a HostSample to watermarks.
+type PressureVerdict struct {
+	Throttled bool
+	Reason    string // Which metrics hold dispatch back; empty when not throttled
+}
+
+// EvaluatePressure decides whether host pressure should hold back dispatch.
+// Any metric at or above its high watermark throttles. A scheduler that was
+// already throttled stays throttled while any metric remains above its low
+// watermark.
+func EvaluatePressure(marks []MetricWatermark, sample HostSample, wasThrottled bool) PressureVerdict {
+	var over, elevated []string
+	for _, m := range marks {
+		v, ok := sample[m.Metric]
+		if !ok || m.High <= 0 {
+			continue
+		}
+		switch {
+		case v >= m.High:
+			over = append(over, fmt.Sprintf("%s %s >= high %s", m.Metric, FormatMetric(v), FormatMetric(m.High)))
+		case v > m.Low:
+			elevated = append(elevated, fmt.Sprintf("%s %s > low %s", m.Metric, FormatMetric(v), FormatMetric(m.Low)))
+		}
+	}
+	if len(over) > 0 {
+		return PressureVerdict{Throttled: true, Reason: strings.Join(over, ", ")}
+	}
+	if wasThrottled && len(elevated) > 0 {
+		return PressureVerdict{Throttled: true, Reason: "recovering: " + strings.Join(elevated, ", ")}
+	}
+	return PressureVerdict{}
+}
+
+// FormatMetric renders a metric value compactly (integers without decimals).
+func FormatMetric(v float64) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.2f", v)
+}
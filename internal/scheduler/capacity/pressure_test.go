@@ -0,0 +1,76 @@
+package capacity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetPressureWatermarks(t *testing.T) {
+	marks := DefaultSchedulerConfig().GetPressureWatermarks()
+	if len(marks) != len(PressureMetrics) {
+		t.Fatalf("got %d watermarks, want %d", len(marks), len(PressureMetrics))
+	}
+	for _, m := range marks {
+		if m.Metric == MetricSessionMemoryMB && m.High != 0 {
+			t.Errorf("session memory should be disabled by default, got %+v", m)
+		}
+		if m.Metric == MetricMemoryUsedPct && (m.High != 90 || m.Low != 80) {
+			t.Errorf("memory default = %+v, want 90/80", m)
+		}
+	}
+
+	cfg := &SchedulerConfig{Pressure: &PressureConfig{}}
+	if err := cfg.Pressure.SetWatermark(MetricSessionMemoryMB, "high", 8192); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Pressure.SetWatermark(MetricLoadPerCPU, "low", 5); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range cfg.GetPressureWatermarks() {
+		switch m.Metric {
+		case MetricSessionMemoryMB:
+			if m.High != 8192 || m.Low != 8192 {
+				t.Errorf("session memory = %+v, want high 8192 and low defaulting to high", m)
+			}
+		case MetricLoadPerCPU:
+			if m.Low != m.High {
+				t.Errorf("low above high should clamp to high, got %+v", m)
+			}
+		}
+	}
+
+	if err := cfg.Pressure.SetWatermark("swap", "high", 1); err == nil {
+		t.Error("SetWatermark should reject unknown metrics")
+	}
+	if err := cfg.Pressure.SetWatermark(MetricDiskUsedPct, "mid", 1); err == nil {
+		t.Error("SetWatermark should reject unknown bounds")
+	}
+}
+
+func TestEvaluatePressure(t *testing.T) {
+	marks := DefaultSchedulerConfig().GetPressureWatermarks()
+
+	tests := []struct {
+		name         string
+		sample       HostSample
+		wasThrottled bool
+		throttled    bool
+		reason       string
+	}{
+		{"calm", HostSample{MetricMemoryUsedPct: 50, MetricLoadPerCPU: 0.3}, false, false, ""},
+		{"over high", HostSample{MetricMemoryUsedPct: 93.5}, false, true, "memory_used_pct 93.50 >= high 90"},
+		{"between, was calm", HostSample{MetricMemoryUsedPct: 85}, false, false, ""},
+		{"between, was throttled", HostSample{MetricMemoryUsedPct: 85}, true, true, "recovering: memory_used_pct 85 > low 80"},
+		{"back under low", HostSample{MetricMemoryUsedPct: 79}, true, false, ""},
+		{"unmeasured metrics ignored", HostSample{}, true, false, ""},
+		{"disabled metric ignored", HostSample{MetricSessionMemoryMB: 1e9}, false, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := EvaluatePressure(marks, tt.sample, tt.wasThrottled)
+			if v.Throttled != tt.throttled || !strings.Contains(v.Reason, tt.reason) {
+				t.Errorf("EvaluatePressure = %+v, want throttled=%v reason containing %q", v, tt.throttled, tt.reason)
+			}
+		})
+	}
+}
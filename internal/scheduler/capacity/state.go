@@ -16,6 +16,14 @@ type SchedulerState struct {
 	PausedAt          string `json:"paused_at,omitempty"`
 	LastDispatchAt    string `json:"last_dispatch_at,omitempty"`
 	LastDispatchCount int    `json:"last_dispatch_count,omitempty"`
+
+	// Host pressure as of the last dispatch cycle. Throttled persists
+	// between cycles so the low watermark can apply (see EvaluatePressure).
+	Throttled      bool       `json:"throttled,omitempty"`
+	ThrottleReason string     `json:"throttle_reason,omitempty"`
+	ThrottledSince string     `json:"throttled_since,omitempty"`
+	LastSample     HostSample `json:"last_sample,omitempty"`
+	LastSampleAt   string     `json:"last_sample_at,omitempty"`
 }
 
 // stateFile returns the path to the scheduler state file.
@@ -116,3 +124,18 @@ func (s *SchedulerState) RecordDispatch(count int) {
 	s.LastDispatchAt = time.Now().UTC().Format(time.RFC3339)
 	s.LastDispatchCount = count
 }
+
+// RecordPressure records the host sample and verdict of a dispatch cycle.
+func (s *SchedulerState) RecordPressure(sample HostSample, v PressureVerdict) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	if v.Throttled && !s.Throttled {
+		s.ThrottledSince = now
+	}
+	if !v.Throttled {
+		s.ThrottledSince = ""
+	}
+	s.Throttled = v.Throttled
+	s.ThrottleReason = v.Reason
+	s.LastSample = sample
+	s.LastSampleAt = now
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package hostload
+
+import "syscall"
+
+// diskUsedPct returns the percent of the filesystem holding path that is
+// used, counting space reserved for root as used (as df does).
+func diskUsedPct(path string) (float64, bool) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, false
+	}
+	total := uint64(st.Blocks)
+	if total == 0 {
+		return 0, false
+	}
+	return 100 * (1 - float64(uint64(st.Bavail))/float64(total)), true
+}
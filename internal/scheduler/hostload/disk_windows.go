@@ -0,0 +1,6 @@
+//go:build windows
+
+package hostload
+
+// diskUsedPct is not measured on Windows.
+func diskUsedPct(string) (float64, bool) { return 0, false }
@@ -0,0 +1,131 @@
+// Package hostload samples host pressure (CPU load, memory, disk, agent
+// session memory) for the capacity scheduler. Every probe is best-effort:
+// a metric that cannot be read on this platform is left out of the sample
+// and never holds back dispatch.
+package hostload
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+// Sample measures host pressure. diskPath selects the filesystem for the
+// disk metric (normally the town root); sessionPIDs are the tracked pane
+// PIDs of agent sessions, whose process trees are summed for session memory.
+func Sample(diskPath string, sessionPIDs []int) capacity.HostSample {
+	sample := capacity.HostSample{}
+	if load, ok := loadAverage(); ok && runtime.NumCPU() > 0 {
+		sample[capacity.MetricLoadPerCPU] = load / float64(runtime.NumCPU())
+	}
+	if data, err := os.ReadFile("/proc/meminfo"); err == nil {
+		if pct, ok := parseMeminfo(string(data)); ok {
+			sample[capacity.MetricMemoryUsedPct] = pct
+		}
+	}
+	if pct, ok := diskUsedPct(diskPath); ok {
+		sample[capacity.MetricDiskUsedPct] = pct
+	}
+	if len(sessionPIDs) > 0 {
+		out, err := exec.Command("ps", "-A", "-o", "pid=,ppid=,rss=").Output()
+		if err == nil {
+			sample[capacity.MetricSessionMemoryMB] = float64(treeRSSKB(string(out), sessionPIDs)) / 1024
+		}
+	}
+	return sample
+}
+
+// loadAverage returns the 1-minute load average from /proc (Linux) or
+// sysctl (macOS, BSD).
+func loadAverage() (float64, bool) {
+	if data, err := os.ReadFile("/proc/loadavg"); err == nil {
+		return parseLoadavg(string(data))
+	}
+	if out, err := exec.Command("sysctl", "-n", "vm.loadavg").Output(); err == nil {
+		return parseLoadavg(string(out))
+	}
+	return 0, false
+}
+
+// parseLoadavg reads the first number of /proc/loadavg ("0.52 0.58 0.59 ...")
+// or of sysctl vm.loadavg ("{ 0.52 0.58 0.59 }").
+func parseLoadavg(s string) (float64, bool) {
+	for _, f := range strings.Fields(s) {
+		if f == "{" {
+			continue
+		}
+		v, err := strconv.ParseFloat(f, 64)
+		return v, err == nil
+	}
+	return 0, false
+}
+
+// parseMeminfo returns the percent of memory not available to new processes
+// (100 * (1 - MemAvailable/MemTotal)) from /proc/meminfo.
+func parseMeminfo(s string) (float64, bool) {
+	var total, avail float64
+	var haveAvail bool
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		v, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total = v
+		case "MemAvailable:":
+			avail, haveAvail = v, true
+		}
+	}
+	if total <= 0 || !haveAvail {
+		return 0, false
+	}
+	return 100 * (1 - avail/total), true
+}
+
+// treeRSSKB sums the RSS (in KB) of each root PID and all its descendants,
+// given `ps -A -o pid=,ppid=,rss=` output. Processes under several roots are
+// counted once.
+func treeRSSKB(psOut string, roots []int) int64 {
+	rss := make(map[int]int64)
+	children := make(map[int][]int)
+	for _, line := range strings.Split(psOut, "\n") {
+		f := strings.Fields(line)
+		if len(f) != 3 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(f[0])
+		ppid, err2 := strconv.Atoi(f[1])
+		kb, err3 := strconv.ParseInt(f[2], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		rss[pid] = kb
+		children[ppid] = append(children[ppid], pid)
+	}
+
+	seen := make(map[int]bool)
+	var total int64
+	stack := append([]int{}, roots...)
+	for len(stack) > 0 {
+		pid := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		total += rss[pid]
+		stack = append(stack, children[pid]...)
+	}
+	return total
+}
@@ -0,0 +1,62 @@
+package hostload
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+func TestParseLoadavg(t *testing.T) {
+	for in, want := range map[string]float64{
+		"0.52 0.58 0.59 1/467 12345\n": 0.52,
+		"{ 3.10 2.00 1.50 }\n":         3.10,
+	} {
+		if got, ok := parseLoadavg(in); !ok || got != want {
+			t.Errorf("parseLoadavg(%q) = %v, %v; want %v", in, got, ok, want)
+		}
+	}
+	if _, ok := parseLoadavg(""); ok {
+		t.Error("parseLoadavg(\"\") should fail")
+	}
+}
+
+func TestParseMeminfo(t *testing.T) {
+	got, ok := parseMeminfo("MemTotal:       16000000 kB\nMemFree:  1000000 kB\nMemAvailable:    4000000 kB\n")
+	if !ok || math.Abs(got-75) > 0.001 {
+		t.Errorf("parseMeminfo = %v, %v; want 75", got, ok)
+	}
+	if _, ok := parseMeminfo("MemTotal: 16000000 kB\n"); ok {
+		t.Error("parseMeminfo without MemAvailable should fail")
+	}
+}
+
+func TestTreeRSSKB(t *testing.T) {
+	ps := `    1     0   1000
+  100     1   2000
+  101   100  50000
+  102   101  30000
+  200     1   4000
+  201   200   7000
+`
+	if got := treeRSSKB(ps, []int{100}); got != 82000 {
+		t.Errorf("treeRSSKB(100) = %d, want 82000", got)
+	}
+	if got := treeRSSKB(ps, []int{100, 101, 200}); got != 93000 {
+		t.Errorf("treeRSSKB(100,101,200) = %d, want 93000 (no double counting)", got)
+	}
+	if got := treeRSSKB(ps, []int{999}); got != 0 {
+		t.Errorf("treeRSSKB(missing) = %d, want 0", got)
+	}
+}
+
+func TestSample_Disk(t *testing.T) {
+	sample := Sample(t.TempDir(), []int{os.Getpid()})
+	if v, ok := sample[capacity.MetricDiskUsedPct]; ok && (v < 0 || v > 100) {
+		t.Errorf("disk_used_pct = %v, want 0-100", v)
+	}
+	if v, ok := sample[capacity.MetricSessionMemoryMB]; ok && v <= 0 {
+		t.Errorf("session_memory_mb = %v for the test process, want > 0", v)
+	}
+}
@@ -0,0 +1,27 @@
+package secrets
+
+import "os"
+
+func init() {
+	Register("env", func(string) Backend { return EnvBackend{} })
+}
+
+// EnvBackend reads secrets from the environment of the gt process, for
+// towns that already inject credentials from an external secret manager.
+// It is read-only.
+type EnvBackend struct{}
+
+// Get returns the value of the environment variable named key.
+func (EnvBackend) Get(key string) (string, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+// Set is not supported.
+func (EnvBackend) Set(string, string) error { return ErrReadOnly }
+
+// Delete is not supported.
+func (EnvBackend) Delete(string) error { return ErrReadOnly }
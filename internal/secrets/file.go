@@ -0,0 +1,75 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+func init() {
+	Register("file", func(townRoot string) Backend {
+		return &FileBackend{Dir: filepath.Join(townRoot, constants.DirMayor, constants.DirSecrets)}
+	})
+}
+
+// validFileKey restricts file keys to safe single path components.
+var validFileKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// FileBackend stores each secret as a 0600 file in a 0700 mayor/secrets/
+// directory. It is the default backend because it has no external
+// dependencies.
+type FileBackend struct {
+	Dir string
+}
+
+func (b *FileBackend) path(key string) (string, error) {
+	if !validFileKey.MatchString(key) {
+		return "", fmt.Errorf("invalid secret key %q", key)
+	}
+	return filepath.Join(b.Dir, key), nil
+}
+
+// Get returns the secret stored under key.
+func (b *FileBackend) Get(key string) (string, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Set stores value under key with owner-only permissions.
+func (b *FileBackend) Set(key, value string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(b.Dir, 0700); err != nil {
+		return err
+	}
+	return util.AtomicWriteFile(path, []byte(value), 0600)
+}
+
+// Delete removes the secret stored under key.
+func (b *FileBackend) Delete(key string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+//go:build darwin
+
+package secrets
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// keychainService is the macOS keychain service name for Gas Town secrets.
+const keychainService = "gastown-secrets"
+
+func init() {
+	Register("keychain", func(string) Backend { return KeychainBackend{} })
+}
+
+// KeychainBackend stores secrets as generic passwords in the macOS login
+// keychain, keyed by account name under a shared Gas Town service.
+type KeychainBackend struct{}
+
+// Get returns the secret stored under key.
+func (KeychainBackend) Get(key string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", keychainService, "-a", key, "-w").Output()
+	if err != nil {
+		return "", ErrNotFound
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// Set stores value under key, replacing any existing entry. The value is fed
+// to security's password prompt on stdin (a bare trailing -w) so it never
+// appears in the process list.
+func (KeychainBackend) Set(key, value string) error {
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("keychain secrets cannot contain newlines")
+	}
+	cmd := exec.Command("security", "add-generic-password", "-U", "-s", keychainService, "-a", key, "-w")
+	// security prompts for the password and then to retype it.
+	cmd.Stdin = strings.NewReader(value + "\n" + value + "\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("security add-generic-password: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Delete removes the secret stored under key.
+func (KeychainBackend) Delete(key string) error {
+	_ = exec.Command("security", "delete-generic-password", "-s", keychainService, "-a", key).Run()
+	return nil
+}
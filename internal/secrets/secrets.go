@@ -0,0 +1,135 @@
+// Package secrets stores account credentials (API keys, tokens) behind
+// pluggable backends so accounts.json only ever holds references.
+//
+// A reference has the form "<backend>:<key>", e.g. "file:openai-work" or
+// "env:OPENAI_API_KEY_WORK". References without a backend prefix use the
+// town's configured default backend.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBackend is the backend used when neither the reference nor the
+// accounts config names one.
+const DefaultBackend = "file"
+
+// ErrNotFound is returned when a backend has no secret for a key.
+var ErrNotFound = errors.New("secret not found")
+
+// ErrReadOnly is returned by backends that cannot store secrets.
+var ErrReadOnly = errors.New("secret backend is read-only")
+
+// Backend stores and retrieves secrets by key.
+type Backend interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// Factory creates a backend bound to a town root.
+type Factory func(townRoot string) Backend
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a backend available under name. Backends register
+// themselves from init; later registrations replace earlier ones.
+func Register(name string, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = f
+}
+
+// Backends returns the registered backend names, sorted.
+func Backends() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open returns the named backend for townRoot.
+func Open(townRoot, name string) (Backend, error) {
+	registryMu.Lock()
+	f, ok := registry[name]
+	registryMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown secret backend %q (available: %s)", name, strings.Join(Backends(), ", "))
+	}
+	return f(townRoot), nil
+}
+
+// Ref is a parsed secret reference.
+type Ref struct {
+	Backend string
+	Key     string
+}
+
+// String returns the canonical "<backend>:<key>" form.
+func (r Ref) String() string {
+	return r.Backend + ":" + r.Key
+}
+
+// ParseRef parses a secret reference, filling in defaultBackend (or
+// DefaultBackend) when the reference has no backend prefix.
+func ParseRef(ref, defaultBackend string) (Ref, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return Ref{}, fmt.Errorf("empty secret reference")
+	}
+	if defaultBackend == "" {
+		defaultBackend = DefaultBackend
+	}
+	backend, key, found := strings.Cut(ref, ":")
+	if !found {
+		backend, key = defaultBackend, ref
+	}
+	if backend == "" || key == "" {
+		return Ref{}, fmt.Errorf("invalid secret reference %q (want <backend>:<key>)", ref)
+	}
+	return Ref{Backend: backend, Key: key}, nil
+}
+
+// Resolve looks up the secret a reference points at.
+func Resolve(townRoot, ref, defaultBackend string) (string, error) {
+	r, err := ParseRef(ref, defaultBackend)
+	if err != nil {
+		return "", err
+	}
+	b, err := Open(townRoot, r.Backend)
+	if err != nil {
+		return "", err
+	}
+	value, err := b.Get(r.Key)
+	if err != nil {
+		return "", fmt.Errorf("reading secret %s: %w", r, err)
+	}
+	return value, nil
+}
+
+// Store writes value to the location a reference points at.
+func Store(townRoot, ref, defaultBackend, value string) error {
+	r, err := ParseRef(ref, defaultBackend)
+	if err != nil {
+		return err
+	}
+	b, err := Open(townRoot, r.Backend)
+	if err != nil {
+		return err
+	}
+	if err := b.Set(r.Key, value); err != nil {
+		return fmt.Errorf("storing secret %s: %w", r, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		ref, def string
+		want     Ref
+		wantErr  bool
+	}{
+		{ref: "file:openai-work", want: Ref{"file", "openai-work"}},
+		{ref: "env:OPENAI_API_KEY", want: Ref{"env", "OPENAI_API_KEY"}},
+		{ref: "openai-work", want: Ref{DefaultBackend, "openai-work"}},
+		{ref: "openai-work", def: "keychain", want: Ref{"keychain", "openai-work"}},
+		{ref: "", wantErr: true},
+		{ref: "file:", wantErr: true},
+		{ref: ":key", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRef(tt.ref, tt.def)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRef(%q) err = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseRef(%q) = %+v, want %+v", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestFileBackend_RoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if _, err := Resolve(townRoot, "file:work", ""); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing secret err = %v, want ErrNotFound", err)
+	}
+	if err := Store(townRoot, "work", "", "sk-test\n"); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	got, err := Resolve(townRoot, "file:work", "")
+	if err != nil || got != "sk-test" {
+		t.Fatalf("Resolve = %q, %v; want sk-test", got, err)
+	}
+
+	info, err := os.Stat(filepath.Join(townRoot, "mayor", "secrets", "work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("secret file perm = %o, want 600", perm)
+	}
+
+	if err := Store(townRoot, "file:../escape", "", "x"); err == nil {
+		t.Error("path traversal key should be rejected")
+	}
+}
+
+func TestEnvBackend(t *testing.T) {
+	t.Setenv("GT_TEST_SECRET", "tok")
+	got, err := Resolve("", "env:GT_TEST_SECRET", "")
+	if err != nil || got != "tok" {
+		t.Fatalf("Resolve = %q, %v; want tok", got, err)
+	}
+	if err := Store("", "env:GT_TEST_SECRET", "", "x"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Store to env err = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestOpen_UnknownBackend(t *testing.T) {
+	if _, err := Open("", "vault"); err == nil {
+		t.Error("unknown backend should error")
+	}
+}
@@ -159,6 +159,41 @@ func KillTrackedPIDs(townRoot string) (killed int, errSessions []string) {
 	return killed, errSessions
 }
 
+// TrackedPIDs returns the live tracked pane PID of each session, keyed by
+// session name. Dead processes and PIDs that were reused by another process
+// (start time changed) are left out; their tracking files are left alone.
+func TrackedPIDs(townRoot string) map[string]int {
+	entries, err := os.ReadDir(pidsDir(townRoot))
+	if err != nil {
+		return nil
+	}
+	pids := make(map[string]int)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".pid") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(pidsDir(townRoot), entry.Name()))
+		if err != nil {
+			continue
+		}
+		record, err := parseTrackedPID(strings.TrimSpace(string(data)))
+		if err != nil {
+			continue
+		}
+		proc, err := os.FindProcess(record.PID)
+		if err != nil || proc.Signal(syscall.Signal(0)) != nil {
+			continue
+		}
+		if record.StartTime != "" {
+			if start, err := pidStartTimeFunc(record.PID); err != nil || start != record.StartTime {
+				continue
+			}
+		}
+		pids[strings.TrimSuffix(entry.Name(), ".pid")] = record.PID
+	}
+	return pids
+}
+
 func parseTrackedPID(value string) (trackedPID, error) {
 	if value == "" {
 		return trackedPID{}, fmt.Errorf("empty pid record")
@@ -276,3 +276,38 @@ func TestPidFile_Path(t *testing.T) {
 		t.Errorf("pidFile() = %q, want %q", got, want)
 	}
 }
+
+func TestTrackedPIDs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Signal(0) liveness check not supported on Windows")
+	}
+	townRoot := t.TempDir()
+	dir := pidsDir(townRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	myPID := os.Getpid()
+	files := map[string]string{
+		"gt-live.pid":    fmt.Sprintf("%d|start\n", myPID),
+		"gt-reused.pid":  fmt.Sprintf("%d|old-start\n", myPID),
+		"gt-dead.pid":    "999999999\n",
+		"gt-corrupt.pid": "nope\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	originalStartFn := pidStartTimeFunc
+	t.Cleanup(func() { pidStartTimeFunc = originalStartFn })
+	pidStartTimeFunc = func(pid int) (string, error) { return "start", nil }
+
+	pids := TrackedPIDs(townRoot)
+	if len(pids) != 1 || pids["gt-live"] != myPID {
+		t.Errorf("TrackedPIDs = %v, want only gt-live=%d", pids, myPID)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "gt-dead.pid")); err != nil {
+		t.Error("TrackedPIDs must not remove tracking files")
+	}
+}
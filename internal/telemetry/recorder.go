@@ -43,9 +43,12 @@ type recorderInstruments struct {
 	daemonRestartTotal metric.Int64Counter
 	formulaTotal       metric.Int64Counter
 	convoyTotal        metric.Int64Counter
+	doltQueryTotal     metric.Int64Counter
+	doltRetryTotal     metric.Int64Counter
 
 	// Histograms
-	bdDurationHist metric.Float64Histogram
+	bdDurationHist        metric.Float64Histogram
+	doltQueryDurationHist metric.Float64Histogram
 }
 
 var (
@@ -112,12 +115,22 @@ func initInstruments() {
 		inst.convoyTotal, _ = m.Int64Counter("gastown.convoy.creates.total",
 			metric.WithDescription("Total auto-convoy creations"),
 		)
+		inst.doltQueryTotal, _ = m.Int64Counter("gastown.dolt.queries.total",
+			metric.WithDescription("Total pooled Dolt SQL client operations"),
+		)
+		inst.doltRetryTotal, _ = m.Int64Counter("gastown.dolt.retries.total",
+			metric.WithDescription("Total Dolt SQL retries after optimistic-lock errors"),
+		)
 
 		// Histograms
 		inst.bdDurationHist, _ = m.Float64Histogram("gastown.bd.duration_ms",
 			metric.WithDescription("bd CLI call round-trip latency in milliseconds"),
 			metric.WithUnit("ms"),
 		)
+		inst.doltQueryDurationHist, _ = m.Float64Histogram("gastown.dolt.query.duration_ms",
+			metric.WithDescription("Pooled Dolt SQL operation latency in milliseconds, including retries"),
+			metric.WithUnit("ms"),
+		)
 	})
 }
 
@@ -214,6 +227,34 @@ func RecordBDCall(ctx context.Context, args []string, durationMs float64, err er
 	emit(ctx, "bd.call", severity(err), kvs...)
 }
 
+// RecordDoltQuery records a pooled Dolt SQL client operation (metrics, plus a
+// log event on failure). op is "query", "exec", or "script". Successful
+// queries are not logged individually: they are far too frequent.
+func RecordDoltQuery(ctx context.Context, op, database string, durationMs float64, retries int, err error) {
+	initInstruments()
+	status := statusStr(err)
+	attrs := metric.WithAttributes(
+		attribute.String("status", status),
+		attribute.String("op", op),
+		attribute.String("database", database),
+	)
+	inst.doltQueryTotal.Add(ctx, 1, attrs)
+	inst.doltQueryDurationHist.Record(ctx, durationMs, attrs)
+	if retries > 0 {
+		inst.doltRetryTotal.Add(ctx, int64(retries), attrs)
+	}
+	if err != nil {
+		emit(ctx, "dolt.query", severity(err),
+			otellog.String("op", op),
+			otellog.String("database", database),
+			otellog.Float64("duration_ms", durationMs),
+			otellog.Int("retries", retries),
+			otellog.String("status", status),
+			errKV(err),
+		)
+	}
+}
+
 // RecordSessionStart records an agent session start (metrics + log event).
 func RecordSessionStart(ctx context.Context, sessionID, role string, err error) {
 	initInstruments()
@@ -0,0 +1,91 @@
+package testutil
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// StartLocalDoltServer starts a dolt sql-server from the dolt binary on PATH,
+// serving dataDir, and returns its port. It is the Docker-free fixture for
+// tests that need a real server. GT_DOLT_PORT is set via t.Setenv and the
+// server is stopped when the test finishes. Skips if dolt is not installed.
+func StartLocalDoltServer(t *testing.T, dataDir string) string {
+	t.Helper()
+	doltPath, err := exec.LookPath("dolt")
+	if err != nil {
+		t.Skip("dolt not found in PATH, skipping test")
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("creating dolt data dir: %v", err)
+	}
+
+	// Commits need an identity; keep it out of the user's global config.
+	rootPath := t.TempDir()
+	env := append(os.Environ(), "DOLT_ROOT_PATH="+rootPath)
+	for _, kv := range [][2]string{{"user.name", "gt-test"}, {"user.email", "gt-test@gastown.local"}} {
+		cfg := exec.Command(doltPath, "config", "--global", "--add", kv[0], kv[1])
+		cfg.Env = env
+		if out, err := cfg.CombinedOutput(); err != nil {
+			t.Fatalf("dolt config %s: %v (%s)", kv[0], err, out)
+		}
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatalf("finding a free port: %v", err)
+	}
+	portStr := strconv.Itoa(port)
+
+	logPath := filepath.Join(rootPath, "sql-server.log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		t.Fatalf("creating server log: %v", err)
+	}
+	cmd := exec.Command(doltPath, "sql-server", "--host", "127.0.0.1", "--port", portStr, "--data-dir", dataDir)
+	cmd.Env = env
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		_ = logFile.Close()
+		t.Fatalf("starting dolt sql-server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		_ = logFile.Close()
+	})
+
+	addr := net.JoinHostPort("127.0.0.1", portStr)
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			_ = conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			serverLog, _ := os.ReadFile(logPath)
+			t.Fatalf("dolt sql-server did not accept connections on %s: %v\n%s", addr, err, serverLog)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Setenv("GT_DOLT_PORT", portStr)
+	return portStr
+}
+
+// freePort asks the kernel for an unused TCP port on the loopback interface.
+func freePort() (int, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("listening: %w", err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port, nil
+}
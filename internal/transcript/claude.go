@@ -0,0 +1,160 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// claudeReader reads Claude Code transcripts: one JSON object per line under
+// <config dir>/projects/<encoded work dir>/<session id>.jsonl.
+type claudeReader struct{}
+
+// nonAlnum matches the characters Claude Code replaces with "-" when it
+// names a project directory after the work dir.
+var nonAlnum = regexp.MustCompile(`[^A-Za-z0-9]`)
+
+// ClaudeProjectDir returns the directory holding Claude transcripts for
+// workDir, honoring CLAUDE_CONFIG_DIR.
+func ClaudeProjectDir(workDir string) (string, error) {
+	configDir := os.Getenv("CLAUDE_CONFIG_DIR")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		configDir = filepath.Join(home, ".claude")
+	}
+	return filepath.Join(configDir, "projects", nonAlnum.ReplaceAllString(workDir, "-")), nil
+}
+
+func (claudeReader) Locate(workDir, sessionID string) (string, error) {
+	dir, err := ClaudeProjectDir(workDir)
+	if err != nil {
+		return "", err
+	}
+	if sessionID != "" {
+		path := filepath.Join(dir, sessionID+".jsonl")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	return latestFile(paths)
+}
+
+type claudeLine struct {
+	Type    string `json:"type"`
+	Message *struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+type claudeBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	IsError   bool            `json:"is_error"`
+	Content   json.RawMessage `json:"content"`
+}
+
+type claudeToolInput struct {
+	Command      string `json:"command"`
+	FilePath     string `json:"file_path"`
+	NotebookPath string `json:"notebook_path"`
+	Plan         string `json:"plan"`
+	Todos        []struct {
+		Content string `json:"content"`
+		Status  string `json:"status"`
+	} `json:"todos"`
+	Questions []struct {
+		Question string `json:"question"`
+	} `json:"questions"`
+}
+
+func (claudeReader) Parse(path string) (*Summary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b := newBuilder()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line claudeLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil || line.Message == nil {
+			continue // Skip malformed lines and non-message records
+		}
+		var blocks []claudeBlock
+		if err := json.Unmarshal(line.Message.Content, &blocks); err != nil {
+			continue // Plain-string content carries no tool calls
+		}
+		for _, block := range blocks {
+			switch {
+			case line.Type == "assistant" && block.Type == "text":
+				b.assistantText(block.Text)
+			case line.Type == "assistant" && block.Type == "tool_use":
+				claudeToolUse(b, block)
+			case line.Type == "user" && block.Type == "tool_result":
+				b.result(block.ToolUseID, block.IsError, claudeResultText(block.Content))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b.summary(), nil
+}
+
+func claudeToolUse(b *builder, block claudeBlock) {
+	var in claudeToolInput
+	_ = json.Unmarshal(block.Input, &in)
+	switch block.Name {
+	case "Bash":
+		b.command(block.ID, in.Command)
+	case "Edit", "MultiEdit", "Write":
+		b.touch(in.FilePath)
+	case "NotebookEdit":
+		b.touch(in.NotebookPath)
+	case "TodoWrite":
+		items := make([]planItem, 0, len(in.Todos))
+		for _, t := range in.Todos {
+			items = append(items, planItem{Text: t.Content, Status: t.Status})
+		}
+		b.setPlan(formatPlan(items))
+	case "ExitPlanMode":
+		b.setPlan(in.Plan)
+	case "AskUserQuestion":
+		for _, q := range in.Questions {
+			b.question(q.Question)
+		}
+	}
+}
+
+// claudeResultText flattens tool_result content, which is either a string
+// or a list of text blocks.
+func claudeResultText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var blocks []claudeBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "text" {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,181 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// codexReader reads Codex CLI rollout logs under
+// $CODEX_HOME/sessions/YYYY/MM/DD/rollout-*.jsonl. Each rollout begins with a
+// session_meta record naming the session's cwd.
+type codexReader struct{}
+
+// codexPatchFile matches the file headers of an apply_patch payload.
+var codexPatchFile = regexp.MustCompile(`(?m)^\*\*\* (?:Add|Update|Delete) File: (.+)$`)
+
+func codexSessionsDir() (string, error) {
+	home := os.Getenv("CODEX_HOME")
+	if home == "" {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		home = filepath.Join(userHome, ".codex")
+	}
+	return filepath.Join(home, "sessions"), nil
+}
+
+func (codexReader) Locate(workDir, sessionID string) (string, error) {
+	dir, err := codexSessionsDir()
+	if err != nil {
+		return "", err
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*", "*", "*", "rollout-*.jsonl"))
+	if sessionID != "" {
+		for _, p := range paths {
+			if strings.Contains(filepath.Base(p), sessionID) {
+				return p, nil
+			}
+		}
+	}
+	var matching []string
+	for _, p := range paths {
+		if codexRolloutCWD(p) == workDir {
+			matching = append(matching, p)
+		}
+	}
+	return latestFile(matching)
+}
+
+type codexLine struct {
+	Type    string `json:"type"`
+	Payload struct {
+		Type      string `json:"type"`
+		CWD       string `json:"cwd"`
+		Role      string `json:"role"`
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+		Input     string `json:"input"`
+		CallID    string `json:"call_id"`
+		Output    string `json:"output"`
+		Content   []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	} `json:"payload"`
+}
+
+// codexRolloutCWD returns the cwd recorded in a rollout's session_meta.
+func codexRolloutCWD(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		return ""
+	}
+	var line codexLine
+	if err := json.Unmarshal(scanner.Bytes(), &line); err != nil || line.Type != "session_meta" {
+		return ""
+	}
+	return line.Payload.CWD
+}
+
+func (codexReader) Parse(path string) (*Summary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b := newBuilder()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line codexLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil || line.Type != "response_item" {
+			continue
+		}
+		p := line.Payload
+		switch p.Type {
+		case "message":
+			if p.Role != "assistant" {
+				continue
+			}
+			for _, c := range p.Content {
+				b.assistantText(c.Text)
+			}
+		case "function_call":
+			codexFunctionCall(b, p.CallID, p.Name, p.Arguments)
+		case "custom_tool_call":
+			if p.Name == "apply_patch" {
+				codexPatch(b, p.Input)
+			}
+		case "function_call_output":
+			exitCode, output := codexOutput(p.Output)
+			b.result(p.CallID, exitCode != 0, output)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b.summary(), nil
+}
+
+func codexFunctionCall(b *builder, callID, name, arguments string) {
+	var args struct {
+		Command []string `json:"command"`
+		Plan    []struct {
+			Step   string `json:"step"`
+			Status string `json:"status"`
+		} `json:"plan"`
+	}
+	_ = json.Unmarshal([]byte(arguments), &args)
+	switch name {
+	case "shell", "container.exec":
+		cmd := args.Command
+		// Unwrap ["bash", "-lc", "<script>"].
+		if len(cmd) == 3 && (cmd[1] == "-lc" || cmd[1] == "-c") {
+			cmd = cmd[2:]
+		}
+		if len(cmd) > 1 && cmd[0] == "apply_patch" {
+			codexPatch(b, cmd[1])
+			return
+		}
+		b.command(callID, strings.Join(cmd, " "))
+	case "update_plan":
+		items := make([]planItem, 0, len(args.Plan))
+		for _, s := range args.Plan {
+			items = append(items, planItem{Text: s.Step, Status: s.Status})
+		}
+		b.setPlan(formatPlan(items))
+	}
+}
+
+func codexPatch(b *builder, patch string) {
+	for _, m := range codexPatchFile.FindAllStringSubmatch(patch, -1) {
+		b.touch(strings.TrimSpace(m[1]))
+	}
+}
+
+// codexOutput decodes a function_call_output, which wraps the command output
+// and its exit code in a JSON string.
+func codexOutput(raw string) (int, string) {
+	var out struct {
+		Output   string `json:"output"`
+		Metadata struct {
+			ExitCode int `json:"exit_code"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return 0, raw
+	}
+	return out.Metadata.ExitCode, out.Output
+}
@@ -0,0 +1,101 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SectionTitle heads the transcript section of a handoff mail.
+const SectionTitle = "## Previous Session Transcript"
+
+// Markers delimit the section so gt prime can find it in a mail body.
+const (
+	beginMarker = "<!-- gt:transcript-summary -->"
+	endMarker   = "<!-- /gt:transcript-summary -->"
+)
+
+// Render formats the summary as markdown for a successor session.
+func Render(s *Summary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Source: %s transcript (%s)\n", s.Agent, s.Source)
+
+	if len(s.FilesTouched) > 0 {
+		fmt.Fprintf(&b, "\n### Files touched (%d)\n", len(s.FilesTouched))
+		files := s.FilesTouched
+		if len(files) > maxFiles {
+			files = files[:maxFiles]
+		}
+		for _, f := range files {
+			fmt.Fprintf(&b, "- %s\n", f)
+		}
+		if more := len(s.FilesTouched) - len(files); more > 0 {
+			fmt.Fprintf(&b, "- ... and %d more\n", more)
+		}
+	}
+
+	if len(s.Commands) > 0 {
+		failures := s.Failures()
+		fmt.Fprintf(&b, "\n### Commands (%d run, %d failed)\n", len(s.Commands), len(failures))
+		if len(failures) > 0 {
+			b.WriteString("Failed — already tried, do not repeat without a new idea:\n")
+			if len(failures) > maxFailures {
+				fmt.Fprintf(&b, "- ... %d earlier failures omitted\n", len(failures)-maxFailures)
+				failures = failures[len(failures)-maxFailures:]
+			}
+			for _, c := range failures {
+				if c.Error != "" {
+					fmt.Fprintf(&b, "- `%s` → %s\n", c.Command, c.Error)
+				} else {
+					fmt.Fprintf(&b, "- `%s`\n", c.Command)
+				}
+			}
+		}
+		recent := s.Commands
+		if len(recent) > maxRecent {
+			recent = recent[len(recent)-maxRecent:]
+		}
+		b.WriteString("Most recent:\n")
+		for _, c := range recent {
+			status := "ok"
+			if c.Failed {
+				status = "failed"
+			}
+			fmt.Fprintf(&b, "- `%s` (%s)\n", c.Command, status)
+		}
+	}
+
+	if len(s.OpenQuestions) > 0 {
+		b.WriteString("\n### Open questions\n")
+		for _, q := range s.OpenQuestions {
+			fmt.Fprintf(&b, "- %s\n", q)
+		}
+	}
+
+	if s.LastPlan != "" {
+		b.WriteString("\n### Last plan\n")
+		b.WriteString(s.LastPlan)
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// Section wraps Render in the title and markers used in handoff mail.
+func Section(s *Summary) string {
+	return SectionTitle + "\n" + beginMarker + "\n" + Render(s) + "\n" + endMarker
+}
+
+// Extract returns the rendered summary embedded in text by Section, if any.
+func Extract(text string) (string, bool) {
+	start := strings.Index(text, beginMarker)
+	if start < 0 {
+		return "", false
+	}
+	rest := text[start+len(beginMarker):]
+	end := strings.Index(rest, endMarker)
+	if end < 0 {
+		return "", false
+	}
+	body := strings.TrimSpace(rest[:end])
+	return body, body != ""
+}
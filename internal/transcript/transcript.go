@@ -0,0 +1,286 @@
+// Package transcript distills an agent session's log into a handoff summary:
+// the files it changed, the commands it ran (and which failed), the questions
+// it left open, and its last plan. The summary travels in the handoff mail so
+// a successor session does not repeat approaches its predecessor ruled out.
+//
+// Each agent preset stores its log differently; a Reader knows how to find
+// and parse one. Claude Code's JSONL transcripts and Codex rollout logs are
+// supported; other presets can Register their own.
+package transcript
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Limits keep the summary small enough to read at session start.
+const (
+	maxFiles        = 30
+	maxFailures     = 10
+	maxRecent       = 5
+	maxQuestions    = 5
+	maxCommandLen   = 200
+	maxErrorLen     = 200
+	maxPlanLen      = 2000
+	maxQuestionLen  = 300
+	questionMinimum = 10
+)
+
+// ErrNoTranscript is returned when no log exists for the session.
+var ErrNoTranscript = errors.New("no transcript found")
+
+// Command is a shell command the session ran.
+type Command struct {
+	Command string `json:"command"`
+	Failed  bool   `json:"failed,omitempty"`
+	// Error is the first line of output of a failed command.
+	Error string `json:"error,omitempty"`
+}
+
+// Summary is what a successor needs to know about a previous session.
+type Summary struct {
+	Agent         string    `json:"agent"`
+	Source        string    `json:"source"`
+	FilesTouched  []string  `json:"files_touched,omitempty"`
+	Commands      []Command `json:"commands,omitempty"`
+	OpenQuestions []string  `json:"open_questions,omitempty"`
+	LastPlan      string    `json:"last_plan,omitempty"`
+}
+
+// Failures returns the commands that failed, oldest first.
+func (s *Summary) Failures() []Command {
+	var failed []Command
+	for _, c := range s.Commands {
+		if c.Failed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// IsEmpty reports whether the summary has nothing worth handing off.
+func (s *Summary) IsEmpty() bool {
+	return len(s.FilesTouched) == 0 && len(s.Commands) == 0 &&
+		len(s.OpenQuestions) == 0 && s.LastPlan == ""
+}
+
+// Reader locates and parses one agent's session logs.
+type Reader interface {
+	// Locate returns the log for sessionID, or the most recent log for
+	// workDir when sessionID is empty or unknown.
+	Locate(workDir, sessionID string) (string, error)
+	// Parse summarizes the log at path.
+	Parse(path string) (*Summary, error)
+}
+
+var (
+	readersMu sync.RWMutex
+	readers   = map[string]Reader{
+		"claude": claudeReader{},
+		"codex":  codexReader{},
+	}
+)
+
+// Register installs the Reader for an agent preset, replacing any existing one.
+func Register(agent string, r Reader) {
+	readersMu.Lock()
+	defer readersMu.Unlock()
+	readers[agent] = r
+}
+
+// ReaderFor returns the Reader for an agent preset. An empty agent means
+// Claude, the default preset.
+func ReaderFor(agent string) (Reader, bool) {
+	if agent == "" {
+		agent = "claude"
+	}
+	readersMu.RLock()
+	defer readersMu.RUnlock()
+	r, ok := readers[agent]
+	return r, ok
+}
+
+// Summarize finds and parses the session log for agent in workDir.
+func Summarize(agent, workDir, sessionID string) (*Summary, error) {
+	r, ok := ReaderFor(agent)
+	if !ok {
+		return nil, fmt.Errorf("no transcript reader for agent %q", agent)
+	}
+	path, err := r.Locate(workDir, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := r.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if agent == "" {
+		agent = "claude"
+	}
+	s.Agent = agent
+	s.Source = path
+	for i, f := range s.FilesTouched {
+		if rel, err := filepath.Rel(workDir, f); err == nil && filepath.IsAbs(f) && !strings.HasPrefix(rel, "..") {
+			s.FilesTouched[i] = rel
+		}
+	}
+	return s, nil
+}
+
+// builder accumulates a Summary while a log is parsed.
+type builder struct {
+	files     map[string]bool
+	commands  []Command
+	pending   map[string]int // tool call ID -> index into commands
+	questions []string
+	plan      string
+}
+
+func newBuilder() *builder {
+	return &builder{files: make(map[string]bool), pending: make(map[string]int)}
+}
+
+func (b *builder) touch(path string) {
+	if path != "" {
+		b.files[path] = true
+	}
+}
+
+func (b *builder) command(callID, cmd string) {
+	cmd = strings.TrimSpace(cmd)
+	if cmd == "" {
+		return
+	}
+	b.commands = append(b.commands, Command{Command: truncate(oneLine(cmd), maxCommandLen)})
+	if callID != "" {
+		b.pending[callID] = len(b.commands) - 1
+	}
+}
+
+// result records the outcome of the command started by callID.
+func (b *builder) result(callID string, failed bool, output string) {
+	i, ok := b.pending[callID]
+	if !ok {
+		return
+	}
+	delete(b.pending, callID)
+	if failed {
+		b.commands[i].Failed = true
+		b.commands[i].Error = truncate(errorLine(output), maxErrorLen)
+	}
+}
+
+// assistantText scans assistant prose for questions. Only the most recent
+// questions are kept; earlier ones were likely answered.
+func (b *builder) assistantText(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*> "))
+		if len(line) >= questionMinimum && strings.HasSuffix(line, "?") {
+			b.question(line)
+		}
+	}
+}
+
+func (b *builder) question(q string) {
+	q = truncate(oneLine(q), maxQuestionLen)
+	for i, existing := range b.questions {
+		if existing == q {
+			b.questions = append(b.questions[:i], b.questions[i+1:]...)
+			break
+		}
+	}
+	b.questions = append(b.questions, q)
+	if len(b.questions) > maxQuestions {
+		b.questions = b.questions[len(b.questions)-maxQuestions:]
+	}
+}
+
+func (b *builder) setPlan(plan string) {
+	if plan = strings.TrimSpace(plan); plan != "" {
+		b.plan = truncate(plan, maxPlanLen)
+	}
+}
+
+func (b *builder) summary() *Summary {
+	s := &Summary{Commands: b.commands, OpenQuestions: b.questions, LastPlan: b.plan}
+	for f := range b.files {
+		s.FilesTouched = append(s.FilesTouched, f)
+	}
+	sort.Strings(s.FilesTouched)
+	return s
+}
+
+// planItem is one entry of a todo-list style plan.
+type planItem struct {
+	Text   string
+	Status string
+}
+
+func formatPlan(items []planItem) string {
+	var lines []string
+	for _, it := range items {
+		mark := "[ ]"
+		switch it.Status {
+		case "completed":
+			mark = "[x]"
+		case "in_progress":
+			mark = "[~]"
+		}
+		lines = append(lines, fmt.Sprintf("- %s %s", mark, oneLine(it.Text)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// latestFile returns the most recently modified file in paths.
+func latestFile(paths []string) (string, error) {
+	var latest string
+	var latestMod int64
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if mod := info.ModTime().UnixNano(); latest == "" || mod > latestMod {
+			latest, latestMod = p, mod
+		}
+	}
+	if latest == "" {
+		return "", ErrNoTranscript
+	}
+	return latest, nil
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// errorLine picks the most telling line of a failed command's output. A bare
+// "Exit code N" header is joined with the line after it.
+func errorLine(output string) string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	if len(lines) > 1 && strings.HasPrefix(lines[0], "Exit code ") {
+		return lines[0] + ": " + lines[1]
+	}
+	return lines[0]
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
@@ -0,0 +1,133 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const claudeSample = `{"type":"queue-operation","operation":"enqueue"}
+{"type":"user","message":{"role":"user","content":"Fix the flaky test"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Let me look."},{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"go test ./internal/foo/..."}}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","is_error":true,"content":"Exit code 1\n--- FAIL: TestFoo (0.01s)"}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Edit","input":{"file_path":"/town/gastown/polecats/nux/foo.go","old_string":"a","new_string":"b"}},{"type":"tool_use","id":"t3","name":"Read","input":{"file_path":"/work/README.md"}}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"t4","name":"Bash","input":{"command":"go build ./..."}}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t4","content":[{"type":"text","text":"ok"}]}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"t5","name":"TodoWrite","input":{"todos":[{"content":"Reproduce failure","status":"completed"},{"content":"Fix race in foo","status":"in_progress"},{"content":"Run full suite","status":"pending"}]}}]}}
+not json
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"The race looks upstream.\nShould the mutex live in Bar instead of Foo?"}]}}
+`
+
+func writeTranscript(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClaudeParse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.jsonl")
+	writeTranscript(t, path, claudeSample)
+
+	s, err := claudeReader{}.Parse(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.FilesTouched) != 1 || s.FilesTouched[0] != "/town/gastown/polecats/nux/foo.go" {
+		t.Errorf("FilesTouched = %v (reads should not count)", s.FilesTouched)
+	}
+	if len(s.Commands) != 2 {
+		t.Fatalf("Commands = %+v", s.Commands)
+	}
+	failed := s.Failures()
+	if len(failed) != 1 || failed[0].Command != "go test ./internal/foo/..." ||
+		failed[0].Error != "Exit code 1: --- FAIL: TestFoo (0.01s)" {
+		t.Errorf("Failures = %+v", failed)
+	}
+	if len(s.OpenQuestions) != 1 || !strings.Contains(s.OpenQuestions[0], "mutex live in Bar") {
+		t.Errorf("OpenQuestions = %v", s.OpenQuestions)
+	}
+	if !strings.Contains(s.LastPlan, "[x] Reproduce failure") || !strings.Contains(s.LastPlan, "[~] Fix race in foo") {
+		t.Errorf("LastPlan = %q", s.LastPlan)
+	}
+}
+
+func TestClaudeLocate(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+	workDir := "/town/gastown/polecats/nux"
+
+	if _, err := (claudeReader{}).Locate(workDir, ""); err != ErrNoTranscript {
+		t.Errorf("Locate with no transcripts = %v, want ErrNoTranscript", err)
+	}
+
+	dir := filepath.Join(configDir, "projects", "-town-gastown-polecats-nux")
+	writeTranscript(t, filepath.Join(dir, "abc.jsonl"), claudeSample)
+	got, err := Summarize("", workDir, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Agent != "claude" || got.Source != filepath.Join(dir, "abc.jsonl") {
+		t.Errorf("Summarize = agent %q source %q", got.Agent, got.Source)
+	}
+	if len(got.FilesTouched) != 1 || got.FilesTouched[0] != "foo.go" {
+		t.Errorf("FilesTouched should be relative to the work dir, got %v", got.FilesTouched)
+	}
+}
+
+func TestCodexParse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rollout-1.jsonl")
+	writeTranscript(t, path, `{"type":"session_meta","payload":{"id":"x","cwd":"/work"}}
+{"type":"response_item","payload":{"type":"function_call","name":"shell","arguments":"{\"command\":[\"bash\",\"-lc\",\"make test\"]}","call_id":"c1"}}
+{"type":"response_item","payload":{"type":"function_call_output","call_id":"c1","output":"{\"output\":\"make: *** [test] Error 2\",\"metadata\":{\"exit_code\":2}}"}}
+{"type":"response_item","payload":{"type":"function_call","name":"shell","arguments":"{\"command\":[\"apply_patch\",\"*** Begin Patch\\n*** Update File: src/main.go\\n@@\\n*** End Patch\"]}","call_id":"c2"}}
+{"type":"response_item","payload":{"type":"function_call","name":"update_plan","arguments":"{\"plan\":[{\"step\":\"Fix build\",\"status\":\"in_progress\"}]}","call_id":"c3"}}
+`)
+	if cwd := codexRolloutCWD(path); cwd != "/work" {
+		t.Errorf("codexRolloutCWD = %q", cwd)
+	}
+	s, err := codexReader{}.Parse(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := s.Failures(); len(f) != 1 || f[0].Command != "make test" {
+		t.Errorf("Failures = %+v", f)
+	}
+	if len(s.FilesTouched) != 1 || s.FilesTouched[0] != "src/main.go" {
+		t.Errorf("FilesTouched = %v", s.FilesTouched)
+	}
+	if s.LastPlan != "- [~] Fix build" {
+		t.Errorf("LastPlan = %q", s.LastPlan)
+	}
+}
+
+func TestSectionRoundTrip(t *testing.T) {
+	s := &Summary{
+		Agent:         "claude",
+		Source:        "/tmp/s.jsonl",
+		FilesTouched:  []string{"a.go"},
+		Commands:      []Command{{Command: "go test", Failed: true, Error: "FAIL"}, {Command: "go vet"}},
+		OpenQuestions: []string{"Is the cache needed?"},
+		LastPlan:      "- [ ] ship it",
+	}
+	mail := "## Hooked Work\nnone\n\n" + Section(s) + "\n\n## Inbox\nempty"
+	body, ok := Extract(mail)
+	if !ok {
+		t.Fatal("Extract found no section")
+	}
+	for _, want := range []string{"a.go", "`go test` → FAIL", "`go vet` (ok)", "Is the cache needed?", "ship it"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("section missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "Inbox") {
+		t.Error("Extract should stop at the end marker")
+	}
+	if _, ok := Extract("no summary here"); ok {
+		t.Error("Extract should report missing section")
+	}
+}
@@ -0,0 +1,175 @@
+package convoy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// actionTimeout bounds a gt/bd subprocess started from the TUI. Sling and
+// synthesis can spawn sessions, so this is longer than a plain bd query.
+const actionTimeout = 2 * time.Minute
+
+// peekLines is how much polecat output a peek shows.
+const peekLines = 30
+
+// actionKind identifies an operation on the selected row.
+type actionKind int
+
+const (
+	actionSling actionKind = iota
+	actionNudge
+	actionPeek
+	actionRelease
+	actionClose
+	actionReopen
+	actionSynthesis
+)
+
+// action is an operation on the selected issue or convoy, awaiting
+// confirmation (and input, for sling and nudge) or running.
+type action struct {
+	kind   actionKind
+	target string // Issue or convoy ID, or agent address for nudge/peek
+	prompt string // Confirmation question shown in the modal
+	input  string // Label for free-text input; empty if none is needed
+}
+
+// needsConfirm reports whether the action changes state and so must be
+// confirmed. Peek is read-only and runs immediately.
+func (a action) needsConfirm() bool { return a.kind != actionPeek }
+
+// refreshes reports whether convoy data should be refetched afterwards.
+func (a action) refreshes() bool { return a.kind != actionPeek && a.kind != actionNudge }
+
+// command returns the binary and arguments that carry out the action, given
+// the text entered in the modal. They are the same commands an operator
+// would type outside the TUI.
+func (a action) command(input string) (string, []string) {
+	switch a.kind {
+	case actionSling:
+		return "gt", []string{"sling", a.target, input}
+	case actionNudge:
+		return "gt", []string{"nudge", a.target, input}
+	case actionPeek:
+		return "gt", []string{"peek", a.target, fmt.Sprint(peekLines)}
+	case actionRelease:
+		return "gt", []string{"release", a.target, "-r", "released from convoy TUI"}
+	case actionClose:
+		return "gt", []string{"convoy", "close", a.target}
+	case actionReopen:
+		return "bd", []string{"reopen", a.target}
+	case actionSynthesis:
+		return "gt", []string{"synthesis", "start", a.target}
+	}
+	return "", nil
+}
+
+// actionResultMsg is the result of running an action.
+type actionResultMsg struct {
+	action action
+	output string
+	err    error
+}
+
+// runAction runs the action's command from the town root (bd commands run
+// in the town beads directory) and reports its combined output.
+func runAction(townBeads string, a action, input string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+
+		name, args := a.command(input)
+		cmd := exec.CommandContext(ctx, name, args...) //nolint:gosec // G204: args are validated IDs and operator input
+		cmd.Dir = filepath.Dir(townBeads)
+		if name == "bd" {
+			cmd.Dir = townBeads
+		}
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+
+		err := cmd.Run()
+		output := strings.TrimRight(out.String(), "\n")
+		if err != nil {
+			err = fmt.Errorf("%s %s: %w", name, args[0], err)
+		}
+		return actionResultMsg{action: a, output: output, err: err}
+	}
+}
+
+// agentAddress converts a bead assignee ("rig/polecats/name") to the short
+// address gt nudge and gt peek accept ("rig/name"). Other assignees (crew,
+// town-level agents) are already addresses.
+func agentAddress(assignee string) string {
+	if rig, name, ok := strings.Cut(assignee, "/polecats/"); ok {
+		return rig + "/" + name
+	}
+	return assignee
+}
+
+// selectedActionLocked builds the action for kind on the row under the
+// cursor. It returns an error if the action does not apply to that row.
+// Caller must hold m.mu (read or write).
+func (m *Model) selectedActionLocked(kind actionKind) (action, error) {
+	ci, ii := m.cursorToConvoyIndexLocked()
+	if ci < 0 {
+		return action{}, fmt.Errorf("nothing selected")
+	}
+	c := m.convoys[ci]
+
+	switch kind {
+	case actionClose, actionReopen, actionSynthesis:
+		if ii != -1 {
+			return action{}, fmt.Errorf("select a convoy row")
+		}
+		switch kind {
+		case actionClose:
+			if c.Status == "closed" {
+				return action{}, fmt.Errorf("convoy %s is already closed", c.ID)
+			}
+			return action{kind: kind, target: c.ID, prompt: fmt.Sprintf("Close convoy %s?", c.ID)}, nil
+		case actionReopen:
+			if c.Status != "closed" {
+				return action{}, fmt.Errorf("convoy %s is not closed", c.ID)
+			}
+			return action{kind: kind, target: c.ID, prompt: fmt.Sprintf("Reopen convoy %s?", c.ID)}, nil
+		default:
+			return action{kind: kind, target: c.ID, prompt: fmt.Sprintf("Start synthesis for convoy %s?", c.ID)}, nil
+		}
+	}
+
+	if ii == -1 {
+		return action{}, fmt.Errorf("select an issue row")
+	}
+	issue := c.Issues[ii]
+
+	switch kind {
+	case actionSling:
+		if issue.Status == "closed" {
+			return action{}, fmt.Errorf("issue %s is closed", issue.ID)
+		}
+		return action{kind: kind, target: issue.ID, prompt: fmt.Sprintf("Sling %s to rig:", issue.ID), input: "rig"}, nil
+	case actionRelease:
+		if issue.Status != "in_progress" && issue.Status != "hooked" {
+			return action{}, fmt.Errorf("issue %s is %s, not in progress", issue.ID, issue.Status)
+		}
+		return action{kind: kind, target: issue.ID, prompt: fmt.Sprintf("Release %s back to open?", issue.ID)}, nil
+	case actionNudge, actionPeek:
+		if issue.Assignee == "" {
+			return action{}, fmt.Errorf("issue %s has no assignee", issue.ID)
+		}
+		addr := agentAddress(issue.Assignee)
+		if kind == actionPeek {
+			return action{kind: kind, target: addr}, nil
+		}
+		return action{kind: kind, target: addr, prompt: fmt.Sprintf("Nudge %s with message:", addr), input: "message"}, nil
+	}
+	return action{}, fmt.Errorf("unknown action")
+}
@@ -0,0 +1,211 @@
+package convoy
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newActionTestModel() *Model {
+	m := New("/town/.beads")
+	m.convoys = []ConvoyItem{
+		{ID: "hq-open", Title: "Open convoy", Status: "open", Expanded: true, Issues: []IssueItem{
+			{ID: "gt-1", Title: "Stuck", Status: "in_progress", Assignee: "gastown/polecats/furiosa"},
+			{ID: "gt-2", Title: "Waiting", Status: "open"},
+			{ID: "gt-3", Title: "Done", Status: "closed"},
+		}},
+		{ID: "hq-done", Title: "Closed convoy", Status: "closed"},
+	}
+	return m
+}
+
+func keyMsg(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEscape}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestAgentAddress(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		assignee string
+		want     string
+	}{
+		{"gastown/polecats/furiosa", "gastown/furiosa"},
+		{"beads/crew/dave", "beads/crew/dave"},
+		{"mayor", "mayor"},
+	}
+	for _, tt := range tests {
+		if got := agentAddress(tt.assignee); got != tt.want {
+			t.Errorf("agentAddress(%q) = %q, want %q", tt.assignee, got, tt.want)
+		}
+	}
+}
+
+func TestActionCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a     action
+		input string
+		want  []string
+	}{
+		{action{kind: actionSling, target: "gt-1"}, "greenplace", []string{"gt", "sling", "gt-1", "greenplace"}},
+		{action{kind: actionNudge, target: "gastown/furiosa"}, "status?", []string{"gt", "nudge", "gastown/furiosa", "status?"}},
+		{action{kind: actionPeek, target: "gastown/furiosa"}, "", []string{"gt", "peek", "gastown/furiosa", "30"}},
+		{action{kind: actionRelease, target: "gt-1"}, "", []string{"gt", "release", "gt-1", "-r", "released from convoy TUI"}},
+		{action{kind: actionClose, target: "hq-open"}, "", []string{"gt", "convoy", "close", "hq-open"}},
+		{action{kind: actionReopen, target: "hq-done"}, "", []string{"bd", "reopen", "hq-done"}},
+		{action{kind: actionSynthesis, target: "hq-open"}, "", []string{"gt", "synthesis", "start", "hq-open"}},
+	}
+	for _, tt := range tests {
+		name, args := tt.a.command(tt.input)
+		if got := append([]string{name}, args...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("command() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestSelectedActionLocked(t *testing.T) {
+	t.Parallel()
+	// Rows: 0 hq-open, 1 gt-1 (in progress), 2 gt-2 (open, unassigned),
+	// 3 gt-3 (closed), 4 hq-done (closed convoy).
+	tests := []struct {
+		name    string
+		cursor  int
+		kind    actionKind
+		target  string
+		wantErr string
+	}{
+		{"sling open issue", 2, actionSling, "gt-2", ""},
+		{"sling closed issue", 3, actionSling, "", "is closed"},
+		{"sling from convoy row", 0, actionSling, "", "select an issue row"},
+		{"release in progress", 1, actionRelease, "gt-1", ""},
+		{"release open issue", 2, actionRelease, "", "not in progress"},
+		{"nudge assignee", 1, actionNudge, "gastown/furiosa", ""},
+		{"peek assignee", 1, actionPeek, "gastown/furiosa", ""},
+		{"peek unassigned", 2, actionPeek, "", "no assignee"},
+		{"close open convoy", 0, actionClose, "hq-open", ""},
+		{"close closed convoy", 4, actionClose, "", "already closed"},
+		{"close from issue row", 1, actionClose, "", "select a convoy row"},
+		{"reopen closed convoy", 4, actionReopen, "hq-done", ""},
+		{"reopen open convoy", 0, actionReopen, "", "not closed"},
+		{"synthesis", 0, actionSynthesis, "hq-open", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newActionTestModel()
+			m.cursor = tt.cursor
+			a, err := m.selectedActionLocked(tt.kind)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("selectedActionLocked() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectedActionLocked() error: %v", err)
+			}
+			if a.kind != tt.kind || a.target != tt.target {
+				t.Errorf("selectedActionLocked() = %+v, want kind %d on %s", a, tt.kind, tt.target)
+			}
+		})
+	}
+}
+
+func TestConfirmModal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancel closes modal without running", func(t *testing.T) {
+		t.Parallel()
+		m := newActionTestModel()
+		m.Update(keyMsg("c"))
+		if m.pending == nil {
+			t.Fatal("close key did not open the confirmation modal")
+		}
+		_, cmd := m.Update(keyMsg("esc"))
+		if m.pending != nil || cmd != nil || m.running != "" {
+			t.Errorf("esc left pending=%v cmd=%v running=%q", m.pending, cmd != nil, m.running)
+		}
+	})
+
+	t.Run("confirm runs action", func(t *testing.T) {
+		t.Parallel()
+		m := newActionTestModel()
+		m.Update(keyMsg("c"))
+		_, cmd := m.Update(keyMsg("y"))
+		if cmd == nil || m.pending != nil {
+			t.Fatal("y did not run the confirmed action")
+		}
+		if m.running != "gt convoy close hq-open" {
+			t.Errorf("running = %q", m.running)
+		}
+	})
+
+	t.Run("input modal only confirms on enter", func(t *testing.T) {
+		t.Parallel()
+		m := newActionTestModel()
+		m.cursor = 2
+		m.Update(keyMsg("s"))
+		m.Update(keyMsg("y"))
+		if m.pending == nil {
+			t.Fatal("typing y into the rig input confirmed the sling")
+		}
+		m.Update(keyMsg("enter"))
+		if m.running != "gt sling gt-2 y" {
+			t.Errorf("running = %q, want sling to rig y", m.running)
+		}
+	})
+
+	t.Run("input modal ignores empty input", func(t *testing.T) {
+		t.Parallel()
+		m := newActionTestModel()
+		m.cursor = 2
+		m.Update(keyMsg("s"))
+		if _, cmd := m.Update(keyMsg("enter")); cmd != nil || m.pending == nil {
+			t.Error("enter with no rig ran the sling")
+		}
+	})
+
+	t.Run("inapplicable action sets status", func(t *testing.T) {
+		t.Parallel()
+		m := newActionTestModel()
+		m.Update(keyMsg("r"))
+		if m.pending != nil || !m.failed || !strings.Contains(m.status, "select an issue row") {
+			t.Errorf("pending=%v failed=%v status=%q", m.pending, m.failed, m.status)
+		}
+	})
+}
+
+func TestActionResult(t *testing.T) {
+	t.Parallel()
+
+	m := newActionTestModel()
+	m.running = "gt release gt-1"
+	_, cmd := m.Update(actionResultMsg{action: action{kind: actionRelease}, err: errors.New("gt release: exit status 1"), output: "Checking...\nissue is not in_progress\n"})
+	if !m.failed || m.status != "gt release: exit status 1: issue is not in_progress" {
+		t.Errorf("status = %q failed = %v", m.status, m.failed)
+	}
+	if cmd == nil {
+		t.Error("release result did not refetch convoys")
+	}
+
+	_, cmd = m.Update(actionResultMsg{action: action{kind: actionPeek}, output: "working on it"})
+	if m.peek != "working on it" || cmd != nil {
+		t.Errorf("peek = %q, refetch = %v", m.peek, cmd != nil)
+	}
+	if view := m.View(); !strings.Contains(view, "working on it") {
+		t.Error("View() does not show peek output")
+	}
+	m.Update(keyMsg("esc"))
+	if m.peek != "" {
+		t.Error("esc did not dismiss peek output")
+	}
+}
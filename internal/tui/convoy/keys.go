@@ -13,6 +13,19 @@ type KeyMap struct {
 	Toggle   key.Binding // expand/collapse
 	Help     key.Binding
 	Quit     key.Binding
+
+	// Actions on the selected row
+	Sling     key.Binding
+	Nudge     key.Binding
+	Peek      key.Binding
+	Release   key.Binding
+	Close     key.Binding
+	Reopen    key.Binding
+	Synthesis key.Binding
+
+	// Modal bindings
+	Confirm key.Binding
+	Cancel  key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -54,6 +67,42 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("q", "esc", "ctrl+c"),
 			key.WithHelp("q", "quit"),
 		),
+		Sling: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sling issue"),
+		),
+		Nudge: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "nudge assignee"),
+		),
+		Peek: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "peek assignee"),
+		),
+		Release: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "release issue"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "close convoy"),
+		),
+		Reopen: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "reopen convoy"),
+		),
+		Synthesis: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "start synthesis"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("enter", "y"),
+			key.WithHelp("enter/y", "confirm"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc", "ctrl+c"),
+			key.WithHelp("esc", "cancel"),
+		),
 	}
 }
 
@@ -67,6 +116,8 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.PageUp, k.PageDown},
 		{k.Top, k.Bottom, k.Toggle},
+		{k.Sling, k.Release, k.Nudge, k.Peek},
+		{k.Close, k.Reopen, k.Synthesis},
 		{k.Help, k.Quit},
 	}
 }
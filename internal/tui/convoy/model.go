@@ -8,10 +8,12 @@ import (
 	"os/exec"
 	"regexp"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -23,9 +25,10 @@ var convoyIDPattern = regexp.MustCompile(`^hq-[a-zA-Z0-9-]+$`)
 
 // IssueItem represents a tracked issue within a convoy.
 type IssueItem struct {
-	ID     string
-	Title  string
-	Status string
+	ID       string
+	Title    string
+	Status   string
+	Assignee string // e.g., "gastown/polecats/furiosa"; empty if unassigned
 }
 
 // ConvoyItem represents a convoy with its tracked issues.
@@ -52,8 +55,16 @@ type Model struct {
 	width    int
 	height   int
 
+	// Action state
+	pending *action         // Action awaiting confirmation; nil when no modal is open
+	input   textinput.Model // Text entry for actions that take input
+	running string          // Description of the action in flight
+	status  string          // Result of the last action
+	failed  bool            // Whether the last action failed
+	peek    string          // Output of the last peek; shown until dismissed
+
 	// mu protects all fields read by View() from concurrent access:
-	// convoys, cursor, err, showHelp, help, width, height.
+	// convoys, cursor, err, showHelp, help, width, height, and action state.
 	// Write lock is held during Update mutations; read lock during View/render.
 	mu sync.RWMutex
 }
@@ -64,6 +75,7 @@ func New(townBeads string) *Model {
 		townBeads: townBeads,
 		keys:      DefaultKeyMap(),
 		help:      help.New(),
+		input:     textinput.New(),
 		convoys:   make([]ConvoyItem, 0),
 	}
 }
@@ -147,11 +159,7 @@ func loadTrackedIssues(townBeads, convoyID string) ([]IssueItem, int, int) {
 		return nil, 0, 0
 	}
 
-	var tracked []struct {
-		ID     string `json:"id"`
-		Title  string `json:"title"`
-		Status string `json:"status"`
-	}
+	var tracked []trackedIssue
 	if err := json.Unmarshal(stdout.Bytes(), &tracked); err != nil {
 		return nil, 0, 0
 	}
@@ -162,19 +170,20 @@ func loadTrackedIssues(townBeads, convoyID string) ([]IssueItem, int, int) {
 	for i := range tracked {
 		tracked[i].ID = beads.ExtractIssueID(tracked[i].ID)
 	}
-	freshStatus := refreshIssueStatus(ctx, tracked)
+	fresh := refreshIssueStatus(ctx, tracked)
 
 	issues := make([]IssueItem, 0, len(tracked))
 	completed := 0
 	for _, t := range tracked {
-		status := t.Status
-		if fresh, ok := freshStatus[t.ID]; ok {
-			status = fresh
+		status, assignee := t.Status, t.Assignee
+		if f, ok := fresh[t.ID]; ok {
+			status, assignee = f.Status, f.Assignee
 		}
 		issues = append(issues, IssueItem{
-			ID:     t.ID,
-			Title:  t.Title,
-			Status: status,
+			ID:       t.ID,
+			Title:    t.Title,
+			Status:   status,
+			Assignee: assignee,
 		})
 		if status == "closed" {
 			completed++
@@ -192,13 +201,17 @@ func loadTrackedIssues(townBeads, convoyID string) ([]IssueItem, int, int) {
 	return issues, completed, len(issues)
 }
 
-// refreshIssueStatus does a batch bd show to get current status for tracked issues.
-// Returns a map from issue ID to current status.
-func refreshIssueStatus(ctx context.Context, tracked []struct {
-	ID     string `json:"id"`
-	Title  string `json:"title"`
-	Status string `json:"status"`
-}) map[string]string {
+// trackedIssue is an issue as reported by bd dep list and bd show.
+type trackedIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee"`
+}
+
+// refreshIssueStatus does a batch bd show to get current status and assignee
+// for tracked issues. Returns a map from issue ID to the current issue.
+func refreshIssueStatus(ctx context.Context, tracked []trackedIssue) map[string]trackedIssue {
 	if len(tracked) == 0 {
 		return nil
 	}
@@ -217,17 +230,14 @@ func refreshIssueStatus(ctx context.Context, tracked []struct {
 		return nil
 	}
 
-	var issues []struct {
-		ID     string `json:"id"`
-		Status string `json:"status"`
-	}
+	var issues []trackedIssue
 	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
 		return nil
 	}
 
-	result := make(map[string]string, len(issues))
+	result := make(map[string]trackedIssue, len(issues))
 	for _, issue := range issues {
-		result[issue.ID] = issue.Status
+		result[issue.ID] = issue
 	}
 	return result
 }
@@ -250,7 +260,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mu.Unlock()
 		return m, nil
 
+	case actionResultMsg:
+		m.mu.Lock()
+		m.finishActionLocked(msg)
+		m.mu.Unlock()
+		if msg.action.refreshes() {
+			return m, m.fetchConvoys
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		m.mu.RLock()
+		modal := m.pending != nil
+		peeking := m.peek != ""
+		m.mu.RUnlock()
+		if modal {
+			return m.updateModal(msg)
+		}
+		if peeking && key.Matches(msg, m.keys.Cancel) {
+			m.mu.Lock()
+			m.peek = ""
+			m.mu.Unlock()
+			return m, nil
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
@@ -296,6 +329,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.mu.Unlock()
 			return m, nil
 
+		case key.Matches(msg, m.keys.Sling):
+			return m, m.startAction(actionSling)
+		case key.Matches(msg, m.keys.Nudge):
+			return m, m.startAction(actionNudge)
+		case key.Matches(msg, m.keys.Peek):
+			return m, m.startAction(actionPeek)
+		case key.Matches(msg, m.keys.Release):
+			return m, m.startAction(actionRelease)
+		case key.Matches(msg, m.keys.Close):
+			return m, m.startAction(actionClose)
+		case key.Matches(msg, m.keys.Reopen):
+			return m, m.startAction(actionReopen)
+		case key.Matches(msg, m.keys.Synthesis):
+			return m, m.startAction(actionSynthesis)
+
 		// Number keys for direct convoy access
 		case msg.String() >= "1" && msg.String() <= "9":
 			n := int(msg.String()[0] - '0')
@@ -311,6 +359,110 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// startAction opens the confirmation modal for kind on the selected row,
+// or runs it directly if it needs no confirmation. If the action does not
+// apply to the row, the reason is shown in the status line.
+func (m *Model) startAction(kind actionKind) tea.Cmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.running != "" {
+		m.status, m.failed = fmt.Sprintf("waiting for %s to finish", m.running), true
+		return nil
+	}
+	a, err := m.selectedActionLocked(kind)
+	if err != nil {
+		m.status, m.failed = err.Error(), true
+		return nil
+	}
+	if !a.needsConfirm() {
+		return m.runLocked(a, "")
+	}
+
+	m.pending = &a
+	m.status = ""
+	m.input.Reset()
+	m.input.Placeholder = a.input
+	if a.input != "" {
+		m.input.Focus()
+	} else {
+		m.input.Blur()
+	}
+	return nil
+}
+
+// updateModal handles keys while the confirmation modal is open. Actions
+// that take input only confirm on enter, so typing "y" into the input
+// does not submit it.
+func (m *Model) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a := *m.pending
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.pending = nil
+		return m, nil
+
+	case msg.Type == tea.KeyEnter || (a.input == "" && key.Matches(msg, m.keys.Confirm)):
+		value := strings.TrimSpace(m.input.Value())
+		if a.input != "" && value == "" {
+			return m, nil
+		}
+		m.pending = nil
+		return m, m.runLocked(a, value)
+
+	case a.input == "" && msg.String() == "n":
+		m.pending = nil
+		return m, nil
+	}
+
+	if a.input == "" {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// runLocked marks a as running and returns the command that runs it.
+// Caller must hold m.mu write lock.
+func (m *Model) runLocked(a action, input string) tea.Cmd {
+	name, args := a.command(input)
+	m.running = name + " " + strings.Join(args, " ")
+	m.status, m.failed = "", false
+	return runAction(m.townBeads, a, input)
+}
+
+// finishActionLocked records the result of an action.
+// Caller must hold m.mu write lock.
+func (m *Model) finishActionLocked(msg actionResultMsg) {
+	ran := m.running
+	m.running = ""
+	switch {
+	case msg.err != nil:
+		m.status, m.failed = msg.err.Error(), true
+		if line := lastLine(msg.output); line != "" {
+			m.status += ": " + line
+		}
+	case msg.action.kind == actionPeek:
+		m.peek = msg.output
+		if m.peek == "" {
+			m.peek = "(no output)"
+		}
+		m.status, m.failed = "", false
+	default:
+		m.status, m.failed = "✓ "+ran, false
+	}
+}
+
+// lastLine returns the last non-empty line of command output, which is
+// where gt reports why a command failed.
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
 // maxCursorLocked returns the maximum valid cursor position.
 // Caller must hold m.mu (read or write).
 func (m *Model) maxCursorLocked() int {
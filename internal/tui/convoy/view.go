@@ -35,6 +35,16 @@ var (
 
 	errorStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("9")) // red
+
+	modalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("11")).
+			Padding(0, 1)
+
+	peekStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("8")).
+			Padding(0, 1)
 )
 
 // renderView renders the entire view.
@@ -111,6 +121,9 @@ func (m *Model) renderView() string {
 					issue.ID,
 					truncate(issue.Title, 50),
 				)
+				if issue.Assignee != "" {
+					issueLine += " @" + agentAddress(issue.Assignee)
+				}
 
 				if isIssueSelected {
 					b.WriteString(selectedStyle.Render(issueLine))
@@ -123,17 +136,66 @@ func (m *Model) renderView() string {
 		}
 	}
 
+	// Peek output
+	if m.peek != "" {
+		b.WriteString("\n")
+		b.WriteString(peekStyle.Render(m.peek))
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("esc:close peek"))
+		b.WriteString("\n")
+	}
+
+	// Confirmation modal
+	if m.pending != nil {
+		b.WriteString("\n")
+		b.WriteString(modalStyle.Render(m.renderModal()))
+		b.WriteString("\n")
+	}
+
+	// Action status
+	switch {
+	case m.running != "":
+		b.WriteString("\n")
+		b.WriteString(progressStyle.Render("Running: " + m.running))
+		b.WriteString("\n")
+	case m.status != "" && m.failed:
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render(m.status))
+		b.WriteString("\n")
+	case m.status != "":
+		b.WriteString("\n")
+		b.WriteString(issueClosedStyle.Render(m.status))
+		b.WriteString("\n")
+	}
+
 	// Help footer
 	b.WriteString("\n")
 	if m.showHelp {
 		b.WriteString(m.help.View(m.keys))
 	} else {
-		b.WriteString(helpStyle.Render("j/k:navigate  enter:expand  1-9:jump  q:quit  ?:help"))
+		b.WriteString(helpStyle.Render("j/k:navigate  enter:expand  1-9:jump  s/r/n/p:issue  c/o/S:convoy  q:quit  ?:help"))
 	}
 
 	return b.String()
 }
 
+// renderModal renders the confirmation modal for the pending action.
+// Caller must hold m.mu.
+func (m *Model) renderModal() string {
+	var b strings.Builder
+	b.WriteString(m.pending.prompt)
+	if m.pending.input != "" {
+		b.WriteString(" ")
+		b.WriteString(m.input.View())
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("enter:confirm  esc:cancel"))
+	} else {
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("y/enter:confirm  n/esc:cancel"))
+	}
+	return b.String()
+}
+
 // statusToIcon converts a status string to an icon.
 func statusToIcon(status string) string {
 	switch status {
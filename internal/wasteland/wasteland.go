@@ -19,6 +19,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
 )
 
 // ErrNotJoined indicates the rig has not joined a wasteland.
@@ -43,6 +45,23 @@ type Config struct {
 
 	// JoinedAt is when the town joined the wasteland.
 	JoinedAt time.Time `json:"joined_at"`
+
+	// ClaimLease is how long claims by this rig hold without renewal, as a
+	// Go duration (e.g., "48h"). Empty means the commons default (72h).
+	ClaimLease string `json:"claim_lease,omitempty"`
+}
+
+// ClaimLeaseDuration parses ClaimLease. It returns 0 when unset, which the
+// commons treats as its default lease.
+func (c *Config) ClaimLeaseDuration() (time.Duration, error) {
+	if c.ClaimLease == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.ClaimLease)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid claim_lease %q in %s: must be a positive duration like 48h", c.ClaimLease, "mayor/wasteland.json")
+	}
+	return d, nil
 }
 
 // ConfigPath returns the path to the wasteland config file for a town.
@@ -163,47 +182,16 @@ func CloneLocally(org, db, targetDir string) error {
 }
 
 // RegisterRig inserts a row into the rigs table on the local clone.
-// For Phase 1 (wild-west mode), writes directly to main.
+// For Phase 1 (wild-west mode), writes directly to main. Values are bound
+// as parameters through a server over the clone, never spliced into SQL.
 func RegisterRig(localDir string, handle, dolthubOrg, displayName, ownerEmail, gtVersion string) error {
-	sql := fmt.Sprintf(
-		`INSERT INTO rigs (handle, display_name, dolthub_org, owner_email, gt_version, trust_level, registered_at, last_seen) `+
-			`VALUES ('%s', '%s', '%s', '%s', '%s', 1, NOW(), NOW()) `+
-			`ON DUPLICATE KEY UPDATE last_seen = NOW(), gt_version = '%s'`,
-		escapeSQLString(handle),
-		escapeSQLString(displayName),
-		escapeSQLString(dolthubOrg),
-		escapeSQLString(ownerEmail),
-		escapeSQLString(gtVersion),
-		escapeSQLString(gtVersion),
-	)
-
-	cmd := exec.Command("dolt", "sql", "-q", sql)
-	cmd.Dir = localDir
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("inserting rig registration: %w (%s)", err, strings.TrimSpace(string(output)))
-	}
-
-	// Stage and commit
-	addCmd := exec.Command("dolt", "add", ".")
-	addCmd.Dir = localDir
-	if output, err := addCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("dolt add: %w (%s)", err, strings.TrimSpace(string(output)))
-	}
-
-	commitCmd := exec.Command("dolt", "commit", "-m", fmt.Sprintf("Register rig: %s", handle))
-	commitCmd.Dir = localDir
-	output, err = commitCmd.CombinedOutput()
-	if err != nil {
-		msg := strings.TrimSpace(string(output))
-		lower := strings.ToLower(msg)
-		if strings.Contains(lower, "nothing to commit") || strings.Contains(lower, "no changes added") {
-			return nil // already registered
-		}
-		return fmt.Errorf("dolt commit: %w (%s)", err, msg)
-	}
-
-	return nil
+	return doltserver.RegisterRigInClone(localDir, doltserver.WLRig{
+		Handle:      handle,
+		DisplayName: displayName,
+		DoltHubOrg:  dolthubOrg,
+		OwnerEmail:  ownerEmail,
+		GTVersion:   gtVersion,
+	})
 }
 
 // PushToOrigin pushes the local clone to origin main.
@@ -256,12 +244,6 @@ func LocalCloneDir(townRoot, upstreamOrg, upstreamDB string) string {
 	return filepath.Join(WastelandDir(townRoot), upstreamOrg, upstreamDB)
 }
 
-// escapeSQLString escapes backslashes and single quotes for SQL string literals.
-func escapeSQLString(s string) string {
-	s = strings.ReplaceAll(s, `\`, `\\`)
-	return strings.ReplaceAll(s, "'", "''")
-}
-
 // DoltHubAPI abstracts DoltHub REST API operations.
 type DoltHubAPI interface {
 	ForkRepo(fromOrg, fromDB, toOrg, token string) error
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestParseUpstream(t *testing.T) {
@@ -87,6 +88,23 @@ func TestConfigSaveLoad(t *testing.T) {
 	}
 }
 
+func TestConfigClaimLeaseDuration(t *testing.T) {
+	cfg := &Config{}
+	if d, err := cfg.ClaimLeaseDuration(); err != nil || d != 0 {
+		t.Errorf("unset: got %v, %v; want 0, nil", d, err)
+	}
+	cfg.ClaimLease = "36h"
+	if d, err := cfg.ClaimLeaseDuration(); err != nil || d != 36*time.Hour {
+		t.Errorf("36h: got %v, %v", d, err)
+	}
+	for _, bad := range []string{"tomorrow", "-1h", "0s"} {
+		cfg.ClaimLease = bad
+		if _, err := cfg.ClaimLeaseDuration(); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
+
 func TestLoadConfigNotFound(t *testing.T) {
 	tmpDir := t.TempDir()
 	_, err := LoadConfig(tmpDir)
@@ -95,24 +113,6 @@ func TestLoadConfigNotFound(t *testing.T) {
 	}
 }
 
-func TestEscapeSQLString(t *testing.T) {
-	tests := []struct {
-		input string
-		want  string
-	}{
-		{"hello", "hello"},
-		{"it's", "it''s"},
-		{"it''s", "it''''s"},
-		{"", ""},
-	}
-	for _, tt := range tests {
-		got := escapeSQLString(tt.input)
-		if got != tt.want {
-			t.Errorf("escapeSQLString(%q) = %q, want %q", tt.input, got, tt.want)
-		}
-	}
-}
-
 func TestForkDoltHubRepo(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
@@ -364,7 +365,7 @@ func HandleMerged(bd *BdCli, workDir, rigName string, msg *mail.Message) *Handle
 	}
 
 	// Verify the polecat's commit is actually on main before allowing nuke.
-	onMain, err := verifyCommitOnMain(workDir, rigName, payload.PolecatName)
+	mergedCommit, onMain, err := verifyCommitOnMain(workDir, rigName, payload.PolecatName)
 	if err != nil {
 		result.Action = fmt.Sprintf("warning: couldn't verify commit on main for %s: %v", payload.PolecatName, err)
 	} else if !onMain {
@@ -377,9 +378,37 @@ func HandleMerged(bd *BdCli, workDir, rigName string, msg *mail.Message) *Handle
 
 	cleanupStatus := getCleanupStatus(bd, workDir, rigName, payload.PolecatName)
 	handleMergedCleanupStatus(workDir, rigName, payload.PolecatName, cleanupStatus, wispID, result)
+
+	// The merged work supersedes any checkpoint snapshots of it.
+	// Only prune once the commit is verified on main.
+	if err == nil && onMain {
+		if n := pruneCheckpointSnapshots(workDir, rigName, payload.PolecatName, payload.Branch, mergedCommit); n > 0 {
+			result.Action += fmt.Sprintf(", pruned %d checkpoint snapshot(s)", n)
+		}
+	}
 	return result
 }
 
+// pruneCheckpointSnapshots deletes the checkpoint snapshot refs superseded by
+// a polecat's merge of branch at mergedCommit from the rig's shared
+// repository. Polecat names are reused, so snapshots of the polecat's other
+// work are kept. Returns the number removed (0 on any error).
+func pruneCheckpointSnapshots(workDir, rigName, polecatName, branch, mergedCommit string) int {
+	townRoot, err := workspace.Find(workDir)
+	if err != nil || townRoot == "" {
+		return 0
+	}
+	rigPath := filepath.Join(townRoot, rigName)
+	// Snapshot refs are shared by every worktree, so any checkout of the rig
+	// repo will do: prefer the bare repo, fall back to the mayor's clone.
+	repoDir := filepath.Join(rigPath, ".repo.git")
+	if _, err := os.Stat(repoDir); err != nil {
+		repoDir = filepath.Join(rigPath, "mayor", "rig")
+	}
+	n, _ := checkpoint.DeleteMergedSnapshots(repoDir, polecatName, branch, mergedCommit)
+	return n
+}
+
 // handleMergedCleanupStatus acknowledges merge completion for persistent polecats.
 // Persistent model (gt-4ac): polecats go idle after merge, sandbox preserved.
 // ZFC (gt-5rne): Reports cleanup_status as data. The witness agent decides
@@ -807,15 +836,15 @@ func AutoNukeIfClean(workDir, rigName, polecatName string) *NukePolecatResult {
 // (e.g., "gastown" for gastown.git). This function checks ALL remotes to find
 // the one containing the default branch with the merged commit.
 //
-// Returns:
+// Returns the polecat's HEAD commit along with:
 //   - true, nil: commit is verified on default branch
 //   - false, nil: commit is NOT on default branch (don't nuke!)
 //   - false, error: couldn't verify (treat as unsafe)
-func verifyCommitOnMain(workDir, rigName, polecatName string) (bool, error) {
+func verifyCommitOnMain(workDir, rigName, polecatName string) (string, bool, error) {
 	// Find town root from workDir
 	townRoot, err := workspace.Find(workDir)
 	if err != nil || townRoot == "" {
-		return false, fmt.Errorf("finding town root: %v", err)
+		return "", false, fmt.Errorf("finding town root: %v", err)
 	}
 
 	// Get configured default branch for this rig
@@ -839,7 +868,7 @@ func verifyCommitOnMain(workDir, rigName, polecatName string) (bool, error) {
 	// Get the current HEAD commit SHA
 	commitSHA, err := g.Rev("HEAD")
 	if err != nil {
-		return false, fmt.Errorf("getting polecat HEAD: %w", err)
+		return "", false, fmt.Errorf("getting polecat HEAD: %w", err)
 	}
 
 	// Get all configured remotes and check each one for the commit
@@ -849,9 +878,9 @@ func verifyCommitOnMain(workDir, rigName, polecatName string) (bool, error) {
 		// If we can't list remotes, fall back to checking just the local branch
 		isOnDefaultBranch, err := g.IsAncestor(commitSHA, defaultBranch)
 		if err != nil {
-			return false, fmt.Errorf("checking if commit is on %s: %w", defaultBranch, err)
+			return commitSHA, false, fmt.Errorf("checking if commit is on %s: %w", defaultBranch, err)
 		}
-		return isOnDefaultBranch, nil
+		return commitSHA, isOnDefaultBranch, nil
 	}
 
 	// Try each remote/<defaultBranch> until we find one where commit is an ancestor
@@ -859,18 +888,18 @@ func verifyCommitOnMain(workDir, rigName, polecatName string) (bool, error) {
 		remoteBranch := remote + "/" + defaultBranch
 		isOnRemote, err := g.IsAncestor(commitSHA, remoteBranch)
 		if err == nil && isOnRemote {
-			return true, nil
+			return commitSHA, true, nil
 		}
 	}
 
 	// Also try the local default branch (in case we're not tracking a remote)
 	isOnDefaultBranch, err := g.IsAncestor(commitSHA, defaultBranch)
 	if err == nil && isOnDefaultBranch {
-		return true, nil
+		return commitSHA, true, nil
 	}
 
 	// Commit is not on any remote's default branch
-	return false, nil
+	return commitSHA, false, nil
 }
 
 // ZombieClassification categorizes why a polecat was classified as a zombie.
@@ -0,0 +1,222 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Status values for a finished worker run.
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// ToolCall records one tool invocation seen in the stream.
+type ToolCall struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Command string `json:"command,omitempty"`
+	Failed  bool   `json:"failed,omitempty"`
+}
+
+// Result is the outcome of a worker run, derived entirely from the agent's
+// event stream and exit status.
+type Result struct {
+	Session   string    `json:"session"`
+	Agent     string    `json:"agent"`
+	SessionID string    `json:"session_id,omitempty"` // agent's own session/thread ID
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Text      string    `json:"text,omitempty"` // final answer
+	ExitCode  int       `json:"exit_code"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	Usage     Usage      `json:"usage"`
+	CostUSD   float64    `json:"cost_usd,omitempty"` // as reported by the agent, 0 if not
+	NumTurns  int        `json:"num_turns,omitempty"`
+
+	// StepsCompleted lists molecule steps closed via 'gt mol step done'.
+	StepsCompleted []string `json:"steps_completed,omitempty"`
+	// Done is true when the agent ran 'gt done' to submit its work.
+	Done bool `json:"done,omitempty"`
+}
+
+// Succeeded reports whether the run finished without error.
+func (r *Result) Succeeded() bool {
+	return r.Status == StatusSuccess
+}
+
+var (
+	stepDoneRe = regexp.MustCompile(`\bgt\s+(?:mol|molecule)\s+step\s+done\s+([A-Za-z0-9][\w.-]*)`)
+	gtDoneRe   = regexp.MustCompile(`(?:^|[;&|]\s*|\s)gt\s+done\b`)
+)
+
+// Collector folds stream events into a Result.
+type Collector struct {
+	// OnEvent, when set, is called for every event (e.g., to print progress).
+	OnEvent func(Event)
+
+	result       Result
+	toolIndex    map[string]int
+	sawResult    bool
+	resultUsage  *Usage
+	streamErrors []string
+}
+
+// NewCollector returns a Collector for a run of agent in session.
+func NewCollector(session, agent string, started time.Time) *Collector {
+	return &Collector{
+		result:    Result{Session: session, Agent: agent, StartedAt: started},
+		toolIndex: make(map[string]int),
+	}
+}
+
+// Add records one event.
+func (c *Collector) Add(ev Event) {
+	if c.OnEvent != nil {
+		c.OnEvent(ev)
+	}
+	r := &c.result
+	switch ev.Kind {
+	case EventInit:
+		if ev.SessionID != "" {
+			r.SessionID = ev.SessionID
+		}
+	case EventText:
+		r.Text = ev.Text
+	case EventToolCall:
+		if ev.ToolID != "" {
+			c.toolIndex[ev.ToolID] = len(r.ToolCalls)
+		}
+		r.ToolCalls = append(r.ToolCalls, ToolCall{ID: ev.ToolID, Name: ev.Tool, Command: ev.Command})
+	case EventToolResult:
+		i, ok := c.toolIndex[ev.ToolID]
+		if !ok {
+			if ev.Tool == "" {
+				return
+			}
+			// Some streams only report completed items.
+			i = len(r.ToolCalls)
+			r.ToolCalls = append(r.ToolCalls, ToolCall{ID: ev.ToolID, Name: ev.Tool})
+		}
+		call := &r.ToolCalls[i]
+		call.Failed = ev.IsError
+		if call.Command == "" {
+			call.Command = ev.Command
+		}
+		if !ev.IsError {
+			c.noteCommand(call.Command)
+		}
+	case EventUsage:
+		if ev.Usage != nil {
+			r.Usage.Add(*ev.Usage)
+		}
+	case EventError:
+		c.streamErrors = append(c.streamErrors, ev.Text)
+	case EventResult:
+		c.sawResult = true
+		if ev.SessionID != "" {
+			r.SessionID = ev.SessionID
+		}
+		if ev.IsError {
+			r.Status = StatusFailed
+			r.Error = ev.Text
+		} else {
+			r.Status = StatusSuccess
+			if ev.Text != "" {
+				r.Text = ev.Text
+			}
+		}
+		r.CostUSD = ev.CostUSD
+		r.NumTurns = ev.NumTurns
+		if ev.Usage != nil {
+			c.resultUsage = ev.Usage
+		}
+	}
+}
+
+// noteCommand maps successful shell commands onto work progress.
+func (c *Collector) noteCommand(cmd string) {
+	for _, m := range stepDoneRe.FindAllStringSubmatch(cmd, -1) {
+		c.result.StepsCompleted = append(c.result.StepsCompleted, m[1])
+	}
+	if gtDoneRe.MatchString(cmd) {
+		c.result.Done = true
+	}
+}
+
+// Finish completes the Result once the agent process has exited.
+// A run without a terminal result event, or whose process exited non-zero,
+// is a failure even if the stream looked healthy.
+func (c *Collector) Finish(exitCode int, ended time.Time) *Result {
+	r := c.result
+	r.ExitCode = exitCode
+	r.EndedAt = ended
+	if c.resultUsage != nil {
+		// Cumulative usage from the agent is authoritative.
+		model := r.Usage.Model
+		r.Usage = *c.resultUsage
+		if r.Usage.Model == "" {
+			r.Usage.Model = model
+		}
+	}
+	switch {
+	case !c.sawResult:
+		r.Status = StatusFailed
+		if r.Error == "" {
+			r.Error = "agent exited without a result"
+		}
+	case exitCode != 0 && r.Status == StatusSuccess:
+		r.Status = StatusFailed
+		r.Error = fmt.Sprintf("agent exited with status %d", exitCode)
+	}
+	if r.Status == StatusFailed && r.Error == "" && len(c.streamErrors) > 0 {
+		r.Error = c.streamErrors[len(c.streamErrors)-1]
+	}
+	if r.Status != StatusFailed {
+		r.Error = ""
+	}
+	return &r
+}
+
+// ResultDir returns the directory holding worker results for a town.
+func ResultDir(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "worker")
+}
+
+// ResultPath returns the result file path for a session.
+func ResultPath(townRoot, session string) string {
+	return filepath.Join(ResultDir(townRoot), session+".json")
+}
+
+// StreamLogPath returns the raw event stream log path for a session.
+func StreamLogPath(townRoot, session string) string {
+	return filepath.Join(ResultDir(townRoot), session+".jsonl")
+}
+
+// WriteResult atomically saves a Result under the town's runtime directory.
+func WriteResult(townRoot string, r *Result) error {
+	return util.EnsureDirAndWriteJSON(ResultPath(townRoot, r.Session), r)
+}
+
+// ReadResult loads the saved Result for a session.
+func ReadResult(townRoot, session string) (*Result, error) {
+	data, err := os.ReadFile(ResultPath(townRoot, session))
+	if err != nil {
+		return nil, err
+	}
+	var r Result
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing worker result: %w", err)
+	}
+	return &r, nil
+}
@@ -0,0 +1,389 @@
+// Package worker runs agents as structured non-interactive workers.
+//
+// In worker mode an agent is started with its preset's non-interactive
+// flags and streams line-delimited JSON events instead of drawing a TUI.
+// This package parses those streams (Claude stream-json, Gemini
+// stream-json and Codex exec --json) into a provider-neutral event model
+// and folds them into a Result: tool calls, token usage, the final answer,
+// molecule steps completed, and whether the run failed. Gas Town reads the
+// Result instead of scraping pane content.
+package worker
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// EventKind classifies a parsed stream event.
+type EventKind string
+
+const (
+	// EventInit marks the start of a run and carries the agent session ID.
+	EventInit EventKind = "init"
+	// EventText is assistant text output.
+	EventText EventKind = "text"
+	// EventToolCall is a tool invocation by the agent.
+	EventToolCall EventKind = "tool_call"
+	// EventToolResult reports the outcome of an earlier tool call.
+	EventToolResult EventKind = "tool_result"
+	// EventUsage carries incremental token usage.
+	EventUsage EventKind = "usage"
+	// EventResult is the terminal event of a run.
+	EventResult EventKind = "result"
+	// EventError is a non-terminal error reported by the agent.
+	EventError EventKind = "error"
+)
+
+// Usage is token usage, either incremental (EventUsage) or cumulative (Result).
+type Usage struct {
+	Model                    string `json:"model,omitempty"`
+	InputTokens              int    `json:"input_tokens"`
+	OutputTokens             int    `json:"output_tokens"`
+	CacheReadInputTokens     int    `json:"cache_read_input_tokens,omitempty"`
+	CacheCreationInputTokens int    `json:"cache_creation_input_tokens,omitempty"`
+}
+
+// Add accumulates o into u. The model is kept from the first usage seen.
+func (u *Usage) Add(o Usage) {
+	if u.Model == "" {
+		u.Model = o.Model
+	}
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CacheReadInputTokens += o.CacheReadInputTokens
+	u.CacheCreationInputTokens += o.CacheCreationInputTokens
+}
+
+// Total returns the total number of tokens, including cache traffic.
+func (u Usage) Total() int {
+	return u.InputTokens + u.OutputTokens + u.CacheReadInputTokens + u.CacheCreationInputTokens
+}
+
+// Event is one provider-neutral stream event.
+type Event struct {
+	Kind      EventKind
+	SessionID string
+	Text      string
+
+	// Tool call / result fields.
+	ToolID  string
+	Tool    string
+	Command string // shell command, for shell tools
+	Input   json.RawMessage
+	IsError bool
+
+	// Usage is set on EventUsage and, when the agent reports it, EventResult.
+	Usage *Usage
+
+	// Result-only fields.
+	CostUSD    float64
+	NumTurns   int
+	DurationMs int64
+}
+
+// rawEvent is the union of the fields used by the supported stream formats.
+type rawEvent struct {
+	Type      string `json:"type"`
+	Subtype   string `json:"subtype"`
+	SessionID string `json:"session_id"`
+	ThreadID  string `json:"thread_id"`
+	Model     string `json:"model"`
+
+	// Claude: message object on assistant/user events.
+	// Codex/Gemini: error message string on error events.
+	Message json.RawMessage `json:"message"`
+
+	// Claude result fields.
+	Result       string  `json:"result"`
+	IsError      bool    `json:"is_error"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+	NumTurns     int     `json:"num_turns"`
+	DurationMs   int64   `json:"duration_ms"`
+	Usage        *struct {
+		InputTokens              int `json:"input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CachedInputTokens        int `json:"cached_input_tokens"` // Codex
+	} `json:"usage"`
+
+	// Gemini fields.
+	Role       string          `json:"role"`
+	Content    string          `json:"content"`
+	ToolName   string          `json:"tool_name"`
+	ToolID     string          `json:"tool_id"`
+	Parameters json.RawMessage `json:"parameters"`
+	Status     string          `json:"status"`
+	Error      json.RawMessage `json:"error"`
+	Stats      *struct {
+		InputTokens  int   `json:"input_tokens"`
+		OutputTokens int   `json:"output_tokens"`
+		DurationMs   int64 `json:"duration_ms"`
+	} `json:"stats"`
+
+	// Codex item events.
+	Item *codexItem `json:"item"`
+}
+
+type claudeMessage struct {
+	ID      string `json:"id"`
+	Model   string `json:"model"`
+	Content []struct {
+		Type      string          `json:"type"`
+		Text      string          `json:"text"`
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		Input     json.RawMessage `json:"input"`
+		ToolUseID string          `json:"tool_use_id"`
+		IsError   bool            `json:"is_error"`
+	} `json:"content"`
+	Usage *Usage `json:"usage"`
+}
+
+type codexItem struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Command  string `json:"command"`
+	ExitCode *int   `json:"exit_code"`
+	Status   string `json:"status"`
+	Server   string `json:"server"`
+	Tool     string `json:"tool"`
+	Message  string `json:"message"`
+}
+
+// Parser converts stream lines into Events. It keeps the small amount of
+// state needed to avoid double-counting usage: Claude repeats a message's
+// usage on every content block event of that message.
+type Parser struct {
+	lastMessageID string
+}
+
+// ParseLine parses one line of stream output. Lines that are not JSON or
+// carry no information Gas Town uses yield no events.
+func (p *Parser) ParseLine(line []byte) []Event {
+	var raw rawEvent
+	if err := json.Unmarshal(line, &raw); err != nil || raw.Type == "" {
+		return nil
+	}
+
+	switch raw.Type {
+	// Claude stream-json.
+	case "system":
+		if raw.Subtype == "init" {
+			return []Event{{Kind: EventInit, SessionID: raw.SessionID}}
+		}
+	case "assistant", "user":
+		return p.parseClaudeMessage(raw)
+	case "result":
+		if raw.Status != "" {
+			return parseGeminiResult(raw)
+		}
+		ev := Event{
+			Kind:       EventResult,
+			SessionID:  raw.SessionID,
+			Text:       raw.Result,
+			IsError:    raw.IsError || (raw.Subtype != "" && raw.Subtype != "success"),
+			CostUSD:    raw.TotalCostUSD,
+			NumTurns:   raw.NumTurns,
+			DurationMs: raw.DurationMs,
+		}
+		if ev.IsError && ev.Text == "" {
+			ev.Text = raw.Subtype
+		}
+		if raw.Usage != nil {
+			ev.Usage = &Usage{
+				InputTokens:              raw.Usage.InputTokens,
+				OutputTokens:             raw.Usage.OutputTokens,
+				CacheReadInputTokens:     raw.Usage.CacheReadInputTokens,
+				CacheCreationInputTokens: raw.Usage.CacheCreationInputTokens,
+			}
+		}
+		return []Event{ev}
+
+	// Gemini stream-json.
+	case "init":
+		return []Event{{Kind: EventInit, SessionID: raw.SessionID}}
+	case "message":
+		if raw.Role == "assistant" && raw.Content != "" {
+			return []Event{{Kind: EventText, Text: raw.Content}}
+		}
+	case "tool_use":
+		return []Event{{
+			Kind:    EventToolCall,
+			ToolID:  raw.ToolID,
+			Tool:    raw.ToolName,
+			Input:   raw.Parameters,
+			Command: commandFromInput(raw.Parameters),
+		}}
+	case "tool_result":
+		return []Event{{Kind: EventToolResult, ToolID: raw.ToolID, IsError: raw.Status == "error"}}
+
+	// Codex exec --json.
+	case "thread.started":
+		return []Event{{Kind: EventInit, SessionID: raw.ThreadID}}
+	case "item.started":
+		if raw.Item != nil {
+			return parseCodexItemStarted(raw.Item)
+		}
+	case "item.completed":
+		if raw.Item != nil {
+			return parseCodexItemCompleted(raw.Item)
+		}
+	case "turn.completed":
+		var evs []Event
+		if raw.Usage != nil {
+			evs = append(evs, Event{Kind: EventUsage, Usage: &Usage{
+				InputTokens:          raw.Usage.InputTokens - raw.Usage.CachedInputTokens,
+				OutputTokens:         raw.Usage.OutputTokens,
+				CacheReadInputTokens: raw.Usage.CachedInputTokens,
+			}})
+		}
+		return append(evs, Event{Kind: EventResult})
+	case "turn.failed":
+		return []Event{{Kind: EventResult, IsError: true, Text: errorText(raw.Error)}}
+
+	// Shared by Gemini and Codex.
+	case "error":
+		msg := errorText(raw.Message)
+		if msg == "" {
+			msg = errorText(raw.Error)
+		}
+		return []Event{{Kind: EventError, Text: msg, IsError: true}}
+	}
+	return nil
+}
+
+func (p *Parser) parseClaudeMessage(raw rawEvent) []Event {
+	var msg claudeMessage
+	if len(raw.Message) == 0 || json.Unmarshal(raw.Message, &msg) != nil {
+		return nil
+	}
+	var evs []Event
+	for _, c := range msg.Content {
+		switch c.Type {
+		case "text":
+			if raw.Type == "assistant" && c.Text != "" {
+				evs = append(evs, Event{Kind: EventText, Text: c.Text})
+			}
+		case "tool_use":
+			evs = append(evs, Event{
+				Kind:    EventToolCall,
+				ToolID:  c.ID,
+				Tool:    c.Name,
+				Input:   c.Input,
+				Command: commandFromInput(c.Input),
+			})
+		case "tool_result":
+			evs = append(evs, Event{Kind: EventToolResult, ToolID: c.ToolUseID, IsError: c.IsError})
+		}
+	}
+	if raw.Type == "assistant" && msg.Usage != nil && (msg.ID == "" || msg.ID != p.lastMessageID) {
+		u := *msg.Usage
+		u.Model = msg.Model
+		evs = append(evs, Event{Kind: EventUsage, Usage: &u})
+	}
+	if msg.ID != "" {
+		p.lastMessageID = msg.ID
+	}
+	return evs
+}
+
+func parseGeminiResult(raw rawEvent) []Event {
+	ev := Event{Kind: EventResult, IsError: raw.Status != "success"}
+	if ev.IsError {
+		ev.Text = errorText(raw.Error)
+		if ev.Text == "" {
+			ev.Text = raw.Status
+		}
+	}
+	if raw.Stats != nil {
+		ev.DurationMs = raw.Stats.DurationMs
+		ev.Usage = &Usage{InputTokens: raw.Stats.InputTokens, OutputTokens: raw.Stats.OutputTokens}
+	}
+	return []Event{ev}
+}
+
+func parseCodexItemStarted(item *codexItem) []Event {
+	switch item.Type {
+	case "command_execution":
+		return []Event{{Kind: EventToolCall, ToolID: item.ID, Tool: "shell", Command: item.Command}}
+	case "mcp_tool_call":
+		return []Event{{Kind: EventToolCall, ToolID: item.ID, Tool: item.Server + "." + item.Tool}}
+	}
+	return nil
+}
+
+func parseCodexItemCompleted(item *codexItem) []Event {
+	switch item.Type {
+	case "agent_message":
+		return []Event{{Kind: EventText, Text: item.Text}}
+	case "command_execution":
+		failed := item.Status == "failed" || (item.ExitCode != nil && *item.ExitCode != 0)
+		return []Event{{Kind: EventToolResult, ToolID: item.ID, Tool: "shell", Command: item.Command, IsError: failed}}
+	case "mcp_tool_call":
+		return []Event{{Kind: EventToolResult, ToolID: item.ID, IsError: item.Status == "failed"}}
+	case "error":
+		return []Event{{Kind: EventError, Text: item.Message, IsError: true}}
+	}
+	return nil
+}
+
+// commandFromInput extracts the shell command from a tool input object,
+// e.g. Claude's Bash tool {"command": "..."}.
+func commandFromInput(input json.RawMessage) string {
+	if len(input) == 0 {
+		return ""
+	}
+	var v struct {
+		Command string `json:"command"`
+	}
+	if json.Unmarshal(input, &v) != nil {
+		return ""
+	}
+	return v.Command
+}
+
+// errorText extracts a message from an error field that is either a JSON
+// string or an object with a "message" field.
+func errorText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var v struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &v) == nil {
+		return v.Message
+	}
+	return ""
+}
+
+// Consume reads a stream to EOF, parsing each line and feeding the events
+// to c. Every line is copied unchanged to raw when raw is non-nil, so the
+// original stream is kept for later inspection.
+func Consume(r io.Reader, raw io.Writer, c *Collector) error {
+	var p Parser
+	scanner := bufio.NewScanner(r)
+	// Tool results can embed whole files; allow large lines.
+	scanner.Buffer(make([]byte, 0, 256*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if raw != nil {
+			_, _ = raw.Write(append(append([]byte(nil), line...), '\n'))
+		}
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		for _, ev := range p.ParseLine(line) {
+			c.Add(ev)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,220 @@
+package worker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func consume(t *testing.T, stream string, exitCode int) *Result {
+	t.Helper()
+	c := NewCollector("gt-toast", "claude", time.Unix(0, 0))
+	var raw bytes.Buffer
+	if err := Consume(strings.NewReader(stream), &raw, c); err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	if raw.String() != stream {
+		t.Errorf("raw copy differs from input:\n%q\n%q", raw.String(), stream)
+	}
+	return c.Finish(exitCode, time.Unix(60, 0))
+}
+
+const claudeStream = `{"type":"system","subtype":"init","session_id":"sess-1","model":"claude-sonnet-4-20250514"}
+{"type":"assistant","message":{"id":"msg_1","model":"claude-sonnet-4-20250514","content":[{"type":"text","text":"Priming."}],"usage":{"input_tokens":10,"output_tokens":5}}}
+{"type":"assistant","message":{"id":"msg_1","model":"claude-sonnet-4-20250514","content":[{"type":"tool_use","id":"tu_1","name":"Bash","input":{"command":"gt mol step done gt-abc.1"}}],"usage":{"input_tokens":10,"output_tokens":5}}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"tu_1","content":"ok"}]}}
+{"type":"assistant","message":{"id":"msg_2","model":"claude-sonnet-4-20250514","content":[{"type":"tool_use","id":"tu_2","name":"Bash","input":{"command":"go test ./..."}}],"usage":{"input_tokens":20,"output_tokens":7}}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"tu_2","is_error":true,"content":"FAIL"}]}}
+{"type":"assistant","message":{"id":"msg_3","model":"claude-sonnet-4-20250514","content":[{"type":"tool_use","id":"tu_3","name":"Bash","input":{"command":"git push && gt done"}}],"usage":{"input_tokens":5,"output_tokens":3}}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"tu_3","content":"submitted"}]}}
+{"type":"result","subtype":"success","is_error":false,"result":"All done.","session_id":"sess-1","total_cost_usd":0.42,"num_turns":4,"duration_ms":1234,"usage":{"input_tokens":35,"output_tokens":15,"cache_read_input_tokens":100}}
+`
+
+func TestConsume_ClaudeStream(t *testing.T) {
+	r := consume(t, claudeStream, 0)
+
+	if !r.Succeeded() {
+		t.Fatalf("status = %s (%s), want success", r.Status, r.Error)
+	}
+	if r.SessionID != "sess-1" {
+		t.Errorf("SessionID = %q", r.SessionID)
+	}
+	if r.Text != "All done." {
+		t.Errorf("Text = %q", r.Text)
+	}
+	if len(r.ToolCalls) != 3 {
+		t.Fatalf("ToolCalls = %d, want 3", len(r.ToolCalls))
+	}
+	if r.ToolCalls[0].Command != "gt mol step done gt-abc.1" || r.ToolCalls[0].Failed {
+		t.Errorf("ToolCalls[0] = %+v", r.ToolCalls[0])
+	}
+	if !r.ToolCalls[1].Failed {
+		t.Errorf("ToolCalls[1] should be failed: %+v", r.ToolCalls[1])
+	}
+	if len(r.StepsCompleted) != 1 || r.StepsCompleted[0] != "gt-abc.1" {
+		t.Errorf("StepsCompleted = %v", r.StepsCompleted)
+	}
+	if !r.Done {
+		t.Error("Done = false, want true after gt done")
+	}
+	// Result usage is authoritative; the model comes from the messages.
+	if r.Usage.InputTokens != 35 || r.Usage.OutputTokens != 15 || r.Usage.CacheReadInputTokens != 100 {
+		t.Errorf("Usage = %+v", r.Usage)
+	}
+	if r.Usage.Model != "claude-sonnet-4-20250514" {
+		t.Errorf("Usage.Model = %q", r.Usage.Model)
+	}
+	if r.CostUSD != 0.42 || r.NumTurns != 4 {
+		t.Errorf("CostUSD = %v, NumTurns = %d", r.CostUSD, r.NumTurns)
+	}
+}
+
+func TestConsume_ClaudeUsageNotDoubleCounted(t *testing.T) {
+	// Without a result event usage comes from messages; msg_1's usage is
+	// repeated on each of its content blocks and must count once.
+	stream := strings.Join(strings.Split(claudeStream, "\n")[:8], "\n") + "\n"
+	r := consume(t, stream, 0)
+	if r.Usage.InputTokens != 35 || r.Usage.OutputTokens != 15 {
+		t.Errorf("Usage = %+v, want 35 in / 15 out", r.Usage)
+	}
+}
+
+func TestConsume_ClaudeErrorResult(t *testing.T) {
+	stream := `{"type":"system","subtype":"init","session_id":"s"}
+{"type":"result","subtype":"error_max_turns","is_error":true,"num_turns":50}
+`
+	r := consume(t, stream, 1)
+	if r.Succeeded() {
+		t.Fatal("expected failure")
+	}
+	if r.Error != "error_max_turns" {
+		t.Errorf("Error = %q", r.Error)
+	}
+}
+
+func TestConsume_GeminiStream(t *testing.T) {
+	stream := `{"type":"init","session_id":"g-1","model":"gemini-2.5-pro"}
+{"type":"message","role":"user","content":"go"}
+{"type":"tool_use","tool_name":"run_shell_command","tool_id":"t1","parameters":{"command":"gt done"}}
+{"type":"tool_result","tool_id":"t1","status":"success"}
+{"type":"message","role":"assistant","content":"Submitted."}
+{"type":"result","status":"success","stats":{"total_tokens":30,"input_tokens":20,"output_tokens":10,"duration_ms":900}}
+`
+	r := consume(t, stream, 0)
+	if !r.Succeeded() {
+		t.Fatalf("status = %s (%s)", r.Status, r.Error)
+	}
+	if r.SessionID != "g-1" || r.Text != "Submitted." || !r.Done {
+		t.Errorf("result = %+v", r)
+	}
+	if r.Usage.InputTokens != 20 || r.Usage.OutputTokens != 10 {
+		t.Errorf("Usage = %+v", r.Usage)
+	}
+}
+
+func TestConsume_GeminiErrorResult(t *testing.T) {
+	stream := `{"type":"init","session_id":"g-1"}
+{"type":"result","status":"error","error":{"type":"FatalError","message":"quota exhausted"}}
+`
+	r := consume(t, stream, 1)
+	if r.Succeeded() || r.Error != "quota exhausted" {
+		t.Errorf("status = %s, error = %q", r.Status, r.Error)
+	}
+}
+
+func TestConsume_CodexStream(t *testing.T) {
+	stream := `{"type":"thread.started","thread_id":"th-1"}
+{"type":"turn.started"}
+{"type":"item.started","item":{"id":"i1","type":"command_execution","command":"bash -lc 'gt mol step done gt-x.2'","status":"in_progress"}}
+{"type":"item.completed","item":{"id":"i1","type":"command_execution","command":"bash -lc 'gt mol step done gt-x.2'","exit_code":0,"status":"completed"}}
+{"type":"item.completed","item":{"id":"i2","type":"command_execution","command":"make lint","exit_code":2,"status":"failed"}}
+{"type":"item.completed","item":{"id":"i3","type":"agent_message","text":"Finished step 2."}}
+{"type":"turn.completed","usage":{"input_tokens":120,"cached_input_tokens":100,"output_tokens":9}}
+`
+	r := consume(t, stream, 0)
+	if !r.Succeeded() {
+		t.Fatalf("status = %s (%s)", r.Status, r.Error)
+	}
+	if r.SessionID != "th-1" || r.Text != "Finished step 2." {
+		t.Errorf("result = %+v", r)
+	}
+	if len(r.ToolCalls) != 2 || r.ToolCalls[0].Failed || !r.ToolCalls[1].Failed {
+		t.Errorf("ToolCalls = %+v", r.ToolCalls)
+	}
+	if len(r.StepsCompleted) != 1 || r.StepsCompleted[0] != "gt-x.2" {
+		t.Errorf("StepsCompleted = %v", r.StepsCompleted)
+	}
+	if r.Usage.InputTokens != 20 || r.Usage.CacheReadInputTokens != 100 || r.Usage.OutputTokens != 9 {
+		t.Errorf("Usage = %+v", r.Usage)
+	}
+}
+
+func TestConsume_CodexTurnFailed(t *testing.T) {
+	stream := `{"type":"thread.started","thread_id":"th-1"}
+{"type":"error","message":"stream disconnected"}
+{"type":"turn.failed","error":{"message":"stream disconnected before completion"}}
+`
+	r := consume(t, stream, 1)
+	if r.Succeeded() || r.Error != "stream disconnected before completion" {
+		t.Errorf("status = %s, error = %q", r.Status, r.Error)
+	}
+}
+
+func TestFinish_NoResultIsFailure(t *testing.T) {
+	stream := `{"type":"system","subtype":"init","session_id":"s"}
+not json at all
+{"type":"assistant","message":{"id":"m","content":[{"type":"text","text":"working"}]}}
+`
+	r := consume(t, stream, 0)
+	if r.Succeeded() {
+		t.Fatal("a run without a result event must fail")
+	}
+	if r.Error != "agent exited without a result" {
+		t.Errorf("Error = %q", r.Error)
+	}
+}
+
+func TestFinish_NonZeroExitOverridesSuccess(t *testing.T) {
+	stream := `{"type":"result","subtype":"success","result":"ok"}
+`
+	r := consume(t, stream, 137)
+	if r.Succeeded() || r.ExitCode != 137 {
+		t.Errorf("status = %s, exit = %d", r.Status, r.ExitCode)
+	}
+}
+
+func TestNoteCommand_GtDoneMatching(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		done bool
+	}{
+		{"gt done", true},
+		{"cd /x && gt done --status COMPLETED", true},
+		{"echo not-gt done", false},
+		{"gt doneish", false},
+		{"gt mol step done gt-1", false},
+	}
+	for _, tt := range tests {
+		c := NewCollector("s", "claude", time.Time{})
+		c.noteCommand(tt.cmd)
+		if c.result.Done != tt.done {
+			t.Errorf("noteCommand(%q): Done = %v, want %v", tt.cmd, c.result.Done, tt.done)
+		}
+	}
+}
+
+func TestWriteReadResult(t *testing.T) {
+	townRoot := t.TempDir()
+	r := &Result{Session: "gt-toast", Agent: "codex", Status: StatusSuccess, Usage: Usage{InputTokens: 3}}
+	if err := WriteResult(townRoot, r); err != nil {
+		t.Fatalf("WriteResult: %v", err)
+	}
+	got, err := ReadResult(townRoot, "gt-toast")
+	if err != nil {
+		t.Fatalf("ReadResult: %v", err)
+	}
+	if got.Agent != "codex" || got.Usage.InputTokens != 3 || !got.Succeeded() {
+		t.Errorf("round trip = %+v", got)
+	}
+}
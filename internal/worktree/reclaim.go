@@ -0,0 +1,165 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// Health classifies a registered worktree.
+type Health string
+
+const (
+	HealthOK       Health = "ok"
+	HealthMissing  Health = "missing"  // Registered but gone from disk
+	HealthOrphaned Health = "orphaned" // Owning crew member or rig no longer exists
+	HealthStale    Health = "stale"    // No commits for too long, or its bead is closed
+)
+
+// Assessment is the result of checking a worktree's health.
+type Assessment struct {
+	Health Health
+	Reason string
+}
+
+// Flagged reports whether the worktree should be reclaimed.
+func (a Assessment) Flagged() bool {
+	return a.Health != HealthOK
+}
+
+// BeadClosed returns the predicate Assess uses to check a worktree's source
+// bead, resolving the bead through the town's routes. A bead that can't be
+// looked up is treated as open.
+func BeadClosed(townRoot string) func(id string) bool {
+	b := beads.New(townRoot)
+	return func(id string) bool {
+		issue, err := b.Show(id)
+		return err == nil && issue.Status == "closed"
+	}
+}
+
+// Assess classifies an inspected entry. beadClosed, if non-nil, reports
+// whether the worktree's source bead has been closed.
+func (r *Registry) Assess(e *Entry, now time.Time, staleAfter time.Duration, beadClosed func(id string) bool) Assessment {
+	if e.Missing {
+		return Assessment{HealthMissing, "worktree directory no longer exists"}
+	}
+	if _, err := os.Stat(filepath.Join(r.townRoot, e.SourceRig)); os.IsNotExist(err) {
+		return Assessment{HealthOrphaned, fmt.Sprintf("source rig %s no longer exists", e.SourceRig)}
+	}
+	if _, err := os.Stat(filepath.Join(r.townRoot, e.SourceRig, constants.DirCrew, e.CrewName)); os.IsNotExist(err) {
+		return Assessment{HealthOrphaned, fmt.Sprintf("crew member %s no longer exists", e.Owner())}
+	}
+	if e.SourceBead != "" && beadClosed != nil && beadClosed(e.SourceBead) {
+		return Assessment{HealthStale, fmt.Sprintf("source bead %s is closed", e.SourceBead)}
+	}
+	last := e.LastCommitAt
+	if e.CreatedAt.After(last) {
+		last = e.CreatedAt
+	}
+	if !last.IsZero() && now.Sub(last) > staleAfter {
+		return Assessment{HealthStale, fmt.Sprintf("no activity for %s", formatAge(now.Sub(last)))}
+	}
+	return Assessment{HealthOK, ""}
+}
+
+// formatAge renders a duration in whole days, or hours if under a day.
+func formatAge(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+// ReclaimResult describes what reclaiming a worktree did (or would do, for
+// a dry run).
+type ReclaimResult struct {
+	ID             string `json:"id"`
+	CommittedDirty bool   `json:"committed_dirty,omitempty"` // Uncommitted changes were committed as WIP
+	RescueBranch   string `json:"rescue_branch,omitempty"`   // Branch unpushed commits were pushed to
+	RescuedCommits int    `json:"rescued_commits,omitempty"`
+	Removed        bool   `json:"removed"`
+	DryRun         bool   `json:"dry_run,omitempty"`
+}
+
+// RescueBranch returns the branch a reclaimed worktree's unpushed work is
+// pushed to (e.g., "rescue/gastown-joe/20260301-150405").
+func RescueBranch(e *Entry, now time.Time) string {
+	return "rescue/" + Name(e.SourceRig, e.CrewName) + "/" + now.Format("20060102-150405")
+}
+
+// Reclaim removes a worktree without losing work. Uncommitted changes are
+// committed as a WIP commit, and any commits not on a remote are pushed to
+// a rescue branch on origin. If the rescue push fails the worktree is left
+// in place. On success the worktree is removed and unregistered.
+func (r *Registry) Reclaim(e *Entry, dryRun bool) (*ReclaimResult, error) {
+	result := &ReclaimResult{ID: e.ID, DryRun: dryRun}
+	rigGit := git.NewGit(constants.RigMayorPath(filepath.Join(r.townRoot, e.Rig)))
+
+	if _, err := os.Stat(e.Path); os.IsNotExist(err) {
+		// Nothing left to rescue; just drop the stale bookkeeping.
+		result.Removed = true
+		if dryRun {
+			return result, nil
+		}
+		_ = rigGit.WorktreePrune()
+		return result, r.Unregister(e.ID)
+	}
+
+	g := git.NewGit(e.Path)
+	status, err := g.Status()
+	if err != nil {
+		return nil, fmt.Errorf("checking status of %s: %w", e.ID, err)
+	}
+	if !status.Clean {
+		result.CommittedDirty = true
+		if !dryRun {
+			if err := g.Add("-A"); err != nil {
+				return nil, fmt.Errorf("staging changes in %s: %w", e.ID, err)
+			}
+			msg := fmt.Sprintf("WIP: rescued from %s before reclamation", e.ID)
+			if err := g.Commit(msg); err != nil {
+				return nil, fmt.Errorf("committing changes in %s: %w", e.ID, err)
+			}
+		}
+	}
+
+	unpushed, err := g.CommitsNotOnRemotes()
+	if err != nil {
+		return nil, fmt.Errorf("counting unpushed commits in %s: %w", e.ID, err)
+	}
+	if result.CommittedDirty && dryRun {
+		unpushed++
+	}
+	if unpushed > 0 {
+		result.RescuedCommits = unpushed
+		result.RescueBranch = RescueBranch(e, time.Now())
+		if !dryRun {
+			if err := g.Push("origin", "HEAD:refs/heads/"+result.RescueBranch, false); err != nil {
+				return nil, fmt.Errorf("pushing %s to rescue branch %s (worktree kept): %w",
+					e.ID, result.RescueBranch, err)
+			}
+		}
+	}
+
+	result.Removed = true
+	if dryRun {
+		return result, nil
+	}
+	if err := rigGit.WorktreeRemove(e.Path, true); err != nil {
+		if !strings.Contains(err.Error(), "is not a working tree") {
+			return nil, fmt.Errorf("removing worktree %s: %w", e.ID, err)
+		}
+		// Git no longer knows about it; the work is safe, so remove the directory.
+		if err := os.RemoveAll(e.Path); err != nil {
+			return nil, fmt.Errorf("removing %s: %w", e.Path, err)
+		}
+	}
+	return result, r.Unregister(e.ID)
+}
@@ -0,0 +1,337 @@
+// Package worktree tracks cross-rig worktrees created by `gt worktree <rig>`.
+//
+// A crew member working on another rig gets a worktree at
+// <target-rig>/crew/<source-rig>-<name>/. The town-wide registry in
+// mayor/worktrees.json records who owns each one, what bead it was for and
+// when it was created, and caches its last commit and dirty state so stale
+// and orphaned worktrees can be found and reclaimed without losing work.
+package worktree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// DefaultStaleAfter is how long a cross-rig worktree can go without a new
+// commit before it is considered stale.
+const DefaultStaleAfter = 14 * 24 * time.Hour
+
+// ErrNotFound is returned when no registered worktree matches.
+var ErrNotFound = errors.New("cross-rig worktree not found")
+
+// Entry is a registered cross-rig worktree.
+type Entry struct {
+	// ID is the worktree's path relative to the town root
+	// (e.g., "beads/crew/gastown-joe").
+	ID         string    `json:"id"`
+	Path       string    `json:"path"`
+	Rig        string    `json:"rig"`        // Rig the worktree is in
+	SourceRig  string    `json:"source_rig"` // Rig of the owning crew member
+	CrewName   string    `json:"crew_name"`
+	SourceBead string    `json:"source_bead,omitempty"` // Bead the worktree was created for
+	CreatedAt  time.Time `json:"created_at"`
+	Discovered bool      `json:"discovered,omitempty"` // Found on disk rather than registered at creation
+
+	// Git state, refreshed by Inspect.
+	Branch            string    `json:"branch,omitempty"`
+	LastCommit        string    `json:"last_commit,omitempty"`
+	LastCommitSubject string    `json:"last_commit_subject,omitempty"`
+	LastCommitAt      time.Time `json:"last_commit_at,omitempty"`
+	Dirty             bool      `json:"dirty"`
+	Unpushed          int       `json:"unpushed"` // Commits on no remote branch
+	Missing           bool      `json:"missing,omitempty"`
+	CheckedAt         time.Time `json:"checked_at,omitempty"`
+}
+
+// Owner returns the owning crew member's address (e.g., "gastown/crew/joe").
+func (e *Entry) Owner() string {
+	return e.SourceRig + "/crew/" + e.CrewName
+}
+
+// registryFile is the persistent form of the registry.
+type registryFile struct {
+	Version   int      `json:"version"`
+	Worktrees []*Entry `json:"worktrees"`
+}
+
+// Registry is the town's cross-rig worktree registry.
+type Registry struct {
+	townRoot string
+}
+
+// NewRegistry returns the cross-rig worktree registry for a town.
+func NewRegistry(townRoot string) *Registry {
+	return &Registry{townRoot: townRoot}
+}
+
+// Name returns the crew directory name of a cross-rig worktree.
+func Name(sourceRig, crewName string) string {
+	return sourceRig + "-" + crewName
+}
+
+// NewEntry builds an entry for the worktree sourceRig/crew/crewName has in
+// rig.
+func (r *Registry) NewEntry(rig, sourceRig, crewName string) Entry {
+	id := filepath.ToSlash(filepath.Join(rig, constants.DirCrew, Name(sourceRig, crewName)))
+	return Entry{
+		ID:        id,
+		Path:      filepath.Join(r.townRoot, filepath.FromSlash(id)),
+		Rig:       rig,
+		SourceRig: sourceRig,
+		CrewName:  crewName,
+	}
+}
+
+func (r *Registry) path() string {
+	return constants.MayorWorktreesPath(r.townRoot)
+}
+
+// update runs fn on the registry under an exclusive file lock and saves the
+// result.
+func (r *Registry) update(fn func(rf *registryFile) error) error {
+	lockPath := filepath.Join(r.townRoot, constants.DirMayor, constants.DirRuntime, "worktrees.lock")
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return fmt.Errorf("creating worktree registry lock dir: %w", err)
+	}
+	fl := flock.New(lockPath)
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("acquiring worktree registry lock: %w", err)
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	rf, err := r.load()
+	if err != nil {
+		return err
+	}
+	if err := fn(rf); err != nil {
+		return err
+	}
+	sort.Slice(rf.Worktrees, func(i, j int) bool { return rf.Worktrees[i].ID < rf.Worktrees[j].ID })
+	return util.AtomicWriteJSON(r.path(), rf)
+}
+
+// load reads the registry. A missing file is an empty registry.
+func (r *Registry) load() (*registryFile, error) {
+	data, err := os.ReadFile(r.path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &registryFile{Version: 1}, nil
+		}
+		return nil, fmt.Errorf("reading worktree registry: %w", err)
+	}
+	var rf registryFile
+	if err := json.Unmarshal(data, &rf); err != nil {
+		return nil, fmt.Errorf("parsing worktree registry: %w", err)
+	}
+	return &rf, nil
+}
+
+// List returns all registered worktrees, ordered by ID.
+func (r *Registry) List() ([]*Entry, error) {
+	rf, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rf.Worktrees, func(i, j int) bool { return rf.Worktrees[i].ID < rf.Worktrees[j].ID })
+	return rf.Worktrees, nil
+}
+
+// Get finds a registered worktree by ID or path.
+func (r *Registry) Get(ref string) (*Entry, error) {
+	entries, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	ref = strings.TrimSuffix(filepath.ToSlash(ref), "/")
+	for _, e := range entries {
+		if e.ID == ref || filepath.ToSlash(e.Path) == ref {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, ref)
+}
+
+// Register records a worktree. Re-registering an existing worktree keeps
+// its creation time and fills in a source bead if one is given.
+func (r *Registry) Register(e Entry) (*Entry, error) {
+	var result *Entry
+	err := r.update(func(rf *registryFile) error {
+		for _, existing := range rf.Worktrees {
+			if existing.ID == e.ID {
+				if e.SourceBead != "" {
+					existing.SourceBead = e.SourceBead
+				}
+				existing.Discovered = existing.Discovered && e.Discovered
+				result = existing
+				return nil
+			}
+		}
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = time.Now()
+		}
+		rf.Worktrees = append(rf.Worktrees, &e)
+		result = &e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Unregister removes a worktree from the registry. It does not touch the
+// worktree itself.
+func (r *Registry) Unregister(id string) error {
+	return r.update(func(rf *registryFile) error {
+		for i, e := range rf.Worktrees {
+			if e.ID == id {
+				rf.Worktrees = append(rf.Worktrees[:i], rf.Worktrees[i+1:]...)
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// Inspect refreshes an entry's git state from disk.
+func Inspect(e *Entry) {
+	e.CheckedAt = time.Now()
+	if _, err := os.Stat(e.Path); os.IsNotExist(err) {
+		e.Missing = true
+		return
+	}
+	e.Missing = false
+
+	g := git.NewGit(e.Path)
+	if branch, err := g.CurrentBranch(); err == nil {
+		e.Branch = branch
+	}
+	if hash, subject, when, err := g.LastCommit(); err == nil {
+		e.LastCommit, e.LastCommitSubject, e.LastCommitAt = hash, subject, when
+	}
+	if status, err := g.Status(); err == nil {
+		e.Dirty = !status.Clean
+	}
+	if n, err := g.CommitsNotOnRemotes(); err == nil {
+		e.Unpushed = n
+	}
+}
+
+// Discover scans rigs' crew directories for cross-rig worktrees: entries
+// named <source-rig>-<name> whose .git is a file (a worktree, not a clone).
+// Worktrees created before the registry existed are found this way.
+func (r *Registry) Discover(rigs []string) []Entry {
+	known := make(map[string]bool, len(rigs))
+	for _, rig := range rigs {
+		known[rig] = true
+	}
+
+	var found []Entry
+	for _, rig := range rigs {
+		crewPath := filepath.Join(r.townRoot, rig, constants.DirCrew)
+		dirs, err := os.ReadDir(crewPath)
+		if err != nil {
+			continue
+		}
+		for _, d := range dirs {
+			if !d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+				continue
+			}
+			info, err := os.Stat(filepath.Join(crewPath, d.Name(), ".git"))
+			if err != nil || info.IsDir() {
+				continue
+			}
+			sourceRig, crewName, ok := splitName(d.Name(), known)
+			if !ok || sourceRig == rig {
+				continue
+			}
+			e := r.NewEntry(rig, sourceRig, crewName)
+			e.CreatedAt = info.ModTime()
+			e.Discovered = true
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// splitName splits a worktree directory name into its source rig and crew
+// name. Rig names may themselves contain hyphens, so the longest known rig
+// prefix wins. If no known rig matches (e.g., the source rig was removed),
+// the name is split at its first hyphen.
+func splitName(name string, rigs map[string]bool) (string, string, bool) {
+	best := ""
+	for i := strings.Index(name, "-"); i > 0; {
+		if rigs[name[:i]] {
+			best = name[:i]
+		}
+		next := strings.Index(name[i+1:], "-")
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	if best == "" {
+		best, _, _ = strings.Cut(name, "-")
+	}
+	if best == "" || len(best)+1 >= len(name) {
+		return "", "", false
+	}
+	return best, name[len(best)+1:], true
+}
+
+// Scan returns every registered worktree plus any found on disk that are
+// not registered yet, with git state refreshed. The registry is not
+// modified.
+func (r *Registry) Scan(rigs []string) ([]*Entry, error) {
+	rf, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.merge(rf, rigs)
+	return rf.Worktrees, nil
+}
+
+// Sync is Scan, saving newly discovered worktrees and refreshed git state
+// to the registry.
+func (r *Registry) Sync(rigs []string) ([]*Entry, error) {
+	var entries []*Entry
+	err := r.update(func(rf *registryFile) error {
+		r.merge(rf, rigs)
+		entries = rf.Worktrees
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// merge adds unregistered worktrees found on disk to rf and inspects every
+// entry.
+func (r *Registry) merge(rf *registryFile, rigs []string) {
+	registered := make(map[string]bool, len(rf.Worktrees))
+	for _, e := range rf.Worktrees {
+		registered[e.ID] = true
+	}
+	discovered := r.Discover(rigs)
+	for i := range discovered {
+		if !registered[discovered[i].ID] {
+			rf.Worktrees = append(rf.Worktrees, &discovered[i])
+		}
+	}
+	for _, e := range rf.Worktrees {
+		Inspect(e)
+	}
+	sort.Slice(rf.Worktrees, func(i, j int) bool { return rf.Worktrees[i].ID < rf.Worktrees[j].ID })
+}
@@ -0,0 +1,205 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// setupTown creates a town with rigs "gastown" and "beads", crew member
+// gastown/crew/joe, and joe's cross-rig worktree at beads/crew/gastown-joe.
+// Returns the town root and the bare remote of the beads rig.
+func setupTown(t *testing.T) (string, string) {
+	t.Helper()
+	town := t.TempDir()
+	remote := filepath.Join(t.TempDir(), "beads.git")
+	runGit(t, town, "init", "--bare", "-b", "main", remote)
+
+	mayorRig := filepath.Join(town, "beads", "mayor", "rig")
+	runGit(t, town, "clone", remote, mayorRig)
+	runGit(t, mayorRig, "config", "user.email", "test@test.com")
+	runGit(t, mayorRig, "config", "user.name", "Test User")
+	runGit(t, mayorRig, "checkout", "-B", "main")
+	if err := os.WriteFile(filepath.Join(mayorRig, "README.md"), []byte("beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, mayorRig, "add", ".")
+	runGit(t, mayorRig, "commit", "-m", "initial")
+	runGit(t, mayorRig, "push", "origin", "main")
+
+	if err := os.MkdirAll(filepath.Join(town, "beads", "crew"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, mayorRig, "worktree", "add", "--force", filepath.Join(town, "beads", "crew", "gastown-joe"), "main")
+	if err := os.MkdirAll(filepath.Join(town, "gastown", "crew", "joe"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return town, remote
+}
+
+func TestRegisterAndUnregister(t *testing.T) {
+	t.Parallel()
+	reg := NewRegistry(t.TempDir())
+
+	e := reg.NewEntry("beads", "gastown", "joe")
+	if e.ID != "beads/crew/gastown-joe" || e.Owner() != "gastown/crew/joe" {
+		t.Fatalf("NewEntry() = %+v", e)
+	}
+	first, err := reg.Register(e)
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	// Re-registering keeps the creation time and records the bead.
+	e.SourceBead = "bd-a1b2"
+	e.CreatedAt = first.CreatedAt.Add(time.Hour)
+	if _, err := reg.Register(e); err != nil {
+		t.Fatalf("Register() again error: %v", err)
+	}
+	got, err := reg.Get("beads/crew/gastown-joe/")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if got.SourceBead != "bd-a1b2" || !got.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("after re-register = %+v, want bead recorded and original creation time", got)
+	}
+
+	if err := reg.Unregister(e.ID); err != nil {
+		t.Fatalf("Unregister() error: %v", err)
+	}
+	if entries, _ := reg.List(); len(entries) != 0 {
+		t.Errorf("List() after Unregister = %d entries, want 0", len(entries))
+	}
+}
+
+func TestSplitName(t *testing.T) {
+	t.Parallel()
+	rigs := map[string]bool{"gastown": true, "my-rig": true, "my": true}
+	tests := []struct {
+		name, wantRig, wantCrew string
+		ok                      bool
+	}{
+		{"gastown-joe", "gastown", "joe", true},
+		{"my-rig-max", "my-rig", "max", true},     // Longest known prefix
+		{"my-other", "my", "other", true},         // Shorter rig still matches
+		{"gone-rig-sam", "gone", "rig-sam", true}, // Unknown rig: first hyphen
+		{"gastown-", "", "", false},
+		{"nohyphen", "", "", false},
+	}
+	for _, tt := range tests {
+		rig, crew, ok := splitName(tt.name, rigs)
+		if rig != tt.wantRig || crew != tt.wantCrew || ok != tt.ok {
+			t.Errorf("splitName(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.name, rig, crew, ok, tt.wantRig, tt.wantCrew, tt.ok)
+		}
+	}
+}
+
+func TestScanAndAssess(t *testing.T) {
+	t.Parallel()
+	town, _ := setupTown(t)
+	reg := NewRegistry(town)
+
+	entries, err := reg.Scan([]string{"gastown", "beads"})
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != "beads/crew/gastown-joe" || !entries[0].Discovered {
+		t.Fatalf("Scan() = %+v, want discovered beads/crew/gastown-joe", entries)
+	}
+	e := entries[0]
+	if e.LastCommitSubject != "initial" || e.Dirty || e.Unpushed != 0 {
+		t.Errorf("Scan() git state = %+v", e)
+	}
+	if saved, _ := reg.List(); len(saved) != 0 {
+		t.Errorf("Scan() saved %d entries, want registry untouched", len(saved))
+	}
+
+	now := time.Now()
+	if a := reg.Assess(e, now, DefaultStaleAfter, nil); a.Health != HealthOK {
+		t.Errorf("Assess() = %+v, want ok", a)
+	}
+	closed := func(string) bool { return true }
+	e.SourceBead = "bd-a1b2"
+	if a := reg.Assess(e, now, DefaultStaleAfter, closed); a.Health != HealthStale {
+		t.Errorf("Assess() with closed bead = %+v, want stale", a)
+	}
+	e.SourceBead = ""
+	if a := reg.Assess(e, now.Add(30*24*time.Hour), DefaultStaleAfter, nil); a.Health != HealthStale {
+		t.Errorf("Assess() after 30 days = %+v, want stale", a)
+	}
+
+	if err := os.RemoveAll(filepath.Join(town, "gastown", "crew", "joe")); err != nil {
+		t.Fatal(err)
+	}
+	if a := reg.Assess(e, now, DefaultStaleAfter, nil); a.Health != HealthOrphaned {
+		t.Errorf("Assess() without owner = %+v, want orphaned", a)
+	}
+}
+
+func TestReclaimRescuesUnpushedWork(t *testing.T) {
+	t.Parallel()
+	town, remote := setupTown(t)
+	reg := NewRegistry(town)
+	entries, err := reg.Sync([]string{"gastown", "beads"})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Sync() = %v, %v", entries, err)
+	}
+	e := entries[0]
+
+	// One local commit and one uncommitted file.
+	runGit(t, e.Path, "config", "user.name", "gastown/crew/joe")
+	runGit(t, e.Path, "config", "user.email", "joe@test.com")
+	if err := os.WriteFile(filepath.Join(e.Path, "fix.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, e.Path, "add", "fix.go")
+	runGit(t, e.Path, "commit", "-m", "fix")
+	if err := os.WriteFile(filepath.Join(e.Path, "wip.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dry, err := reg.Reclaim(e, true)
+	if err != nil {
+		t.Fatalf("Reclaim(dry run) error: %v", err)
+	}
+	if !dry.CommittedDirty || dry.RescuedCommits != 2 || dry.RescueBranch == "" {
+		t.Errorf("Reclaim(dry run) = %+v, want dirty tree and 2 commits to rescue", dry)
+	}
+	if _, err := os.Stat(e.Path); err != nil {
+		t.Fatalf("dry run removed the worktree: %v", err)
+	}
+
+	result, err := reg.Reclaim(e, false)
+	if err != nil {
+		t.Fatalf("Reclaim() error: %v", err)
+	}
+	if !result.Removed || result.RescuedCommits != 2 {
+		t.Errorf("Reclaim() = %+v", result)
+	}
+	if _, err := os.Stat(e.Path); !os.IsNotExist(err) {
+		t.Errorf("worktree still exists after Reclaim(): %v", err)
+	}
+	if saved, _ := reg.List(); len(saved) != 0 {
+		t.Errorf("registry has %d entries after Reclaim(), want 0", len(saved))
+	}
+
+	files := runGit(t, remote, "ls-tree", "--name-only", result.RescueBranch)
+	if !strings.Contains(files, "fix.go") || !strings.Contains(files, "wip.go") {
+		t.Errorf("rescue branch %s has files %q, want fix.go and wip.go", result.RescueBranch, files)
+	}
+}